@@ -0,0 +1,44 @@
+package startupbench
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// BenchmarkLoadCore runs the clojure.core loader, as every program does
+// while it starts, against the namespace it has already populated.
+func BenchmarkLoadCore(b *testing.B) {
+	load := runtime.GetNSLoader("clojure/core")
+	if load == nil {
+		b.Skip("clojure.core has no AOT loader")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		load()
+	}
+}
+
+// BenchmarkProcessStartup starts glj to evaluate nil, including process
+// creation and Go runtime initialization.
+func BenchmarkProcessStartup(b *testing.B) {
+	glj := filepath.Join(b.TempDir(), "glj")
+	build := exec.Command("go", "build", "-o", glj, "github.com/glojurelang/glojure/cmd/glj")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if out, err := exec.Command(glj, "-e", "nil").CombinedOutput(); err != nil {
+			b.Fatalf("%v: %s", err, out)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package ast
 
-import "github.com/glojurelang/glojure/pkg/lang"
+import (
+	"reflect"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
 
 type (
 	NodeOp int32
@@ -28,6 +32,8 @@ type (
 		Local      lang.Keyword
 		ArgID      int
 		IsVariadic bool
+		// Binding is the binding the local refers to.
+		Binding *BindingNode
 	}
 
 	VarNode struct {
@@ -175,16 +181,21 @@ type (
 		Method         *lang.Symbol
 		Args           []*Node
 		ResolvedMethod interface{}
+		// TargetType is the Go type inferred for the values of Target,
+		// or nil if it is unknown and the method is found by reflection.
+		TargetType reflect.Type
 	}
 
 	HostFieldNode struct {
-		Target *Node
-		Field  *lang.Symbol
+		Target     *Node
+		Field      *lang.Symbol
+		TargetType reflect.Type
 	}
 
 	HostInteropNode struct {
-		Target *Node
-		MOrF   *lang.Symbol
+		Target     *Node
+		MOrF       *lang.Symbol
+		TargetType reflect.Type
 	}
 
 	LetFnNode struct {
@@ -0,0 +1,65 @@
+package ast
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExtensionNode is the Sub of a node whose op was registered with
+// RegisterOp. Args are the analyzed subforms of the node, which
+// Transform visits like the children of built-in nodes; Data is left
+// to the extension.
+type ExtensionNode struct {
+	Args []*Node
+	Data interface{}
+}
+
+var (
+	extensionOpsMtx sync.RWMutex
+	extensionOps    = map[string]NodeOp{}
+	extensionNames  []string
+)
+
+// firstExtensionOp is the op of the first registered extension.
+const firstExtensionOp = OpThrow + 1
+
+// RegisterOp allocates an op for nodes built by an extension, such as a
+// registered special form. Registering the same name again returns the
+// same op.
+func RegisterOp(name string) NodeOp {
+	extensionOpsMtx.Lock()
+	defer extensionOpsMtx.Unlock()
+	if op, ok := extensionOps[name]; ok {
+		return op
+	}
+	op := firstExtensionOp + NodeOp(len(extensionNames))
+	extensionOps[name] = op
+	extensionNames = append(extensionNames, name)
+	return op
+}
+
+// LookupOp returns the op registered under name, if there is one.
+func LookupOp(name string) (NodeOp, bool) {
+	extensionOpsMtx.RLock()
+	defer extensionOpsMtx.RUnlock()
+	op, ok := extensionOps[name]
+	return op, ok
+}
+
+// IsExtensionOp reports whether op was allocated by RegisterOp.
+func IsExtensionOp(op NodeOp) bool {
+	return op >= firstExtensionOp
+}
+
+// OpName returns the name op was registered under, or a description of
+// a built-in op.
+func OpName(op NodeOp) string {
+	if IsExtensionOp(op) {
+		extensionOpsMtx.RLock()
+		defer extensionOpsMtx.RUnlock()
+		if i := int(op - firstExtensionOp); i < len(extensionNames) {
+			return extensionNames[i]
+		}
+	}
+	return fmt.Sprintf("op %d", op)
+}
@@ -176,6 +176,8 @@ func transformChildren(node *Node, fn func(*Node) (*Node, error)) error {
 		return transformNodes(sub.Exprs, fn)
 	case *GoNode:
 		return transformNode(&sub.Invoke, fn)
+	case *ExtensionNode:
+		return transformNodes(sub.Args, fn)
 	case *CaseNode:
 		if err := transformNode(&sub.Test, fn); err != nil {
 			return err
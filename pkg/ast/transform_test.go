@@ -77,3 +77,37 @@ func TestTransformVisitsCaseBranches(t *testing.T) {
 		t.Fatalf("visited %d case constants, want 4", count)
 	}
 }
+
+func TestTransformVisitsExtensionArgs(t *testing.T) {
+	op := RegisterOp("test/extension")
+	if again := RegisterOp("test/extension"); again != op {
+		t.Fatalf("re-registering returned op %d, want %d", again, op)
+	}
+	if !IsExtensionOp(op) || IsExtensionOp(OpThrow) {
+		t.Fatalf("IsExtensionOp misclassifies op %d", op)
+	}
+	if name := OpName(op); name != "test/extension" {
+		t.Fatalf("OpName = %q, want test/extension", name)
+	}
+
+	root := &Node{Op: op, Sub: &ExtensionNode{
+		Args: []*Node{{Op: OpConst, Sub: &ConstNode{Value: int64(1)}}},
+		Data: "data",
+	}}
+	transformed, err := Transform(root, func(node *Node) (*Node, error) {
+		if node.Op != OpConst {
+			return node, nil
+		}
+		return &Node{Op: OpConst, Sub: &ConstNode{Value: int64(2)}}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := transformed.Sub.(*ExtensionNode)
+	if value := sub.Args[0].Sub.(*ConstNode).Value; value != int64(2) {
+		t.Fatalf("transformed argument = %v, want 2", value)
+	}
+	if sub.Data != "data" {
+		t.Fatalf("extension data = %v, want data", sub.Data)
+	}
+}
@@ -37,6 +37,19 @@ type (
 
 		FindNamespace func(sym *lang.Symbol) *lang.Namespace
 		ResolveHost   func(sym *lang.Symbol) (interface{}, bool)
+
+		// Sandbox, if set, restricts what analyzed code may refer to.
+		Sandbox Sandbox
+		// ReflectionWarning, if set, is called with a warning for each
+		// host interop form whose target's type cannot be inferred, so
+		// that its member is found by reflection when it is evaluated.
+		ReflectionWarning func(msg string)
+		// pos is the position of the innermost form being analyzed that
+		// has one, for reflection warnings.
+		pos sourcePos
+		// trusted holds the symbols introduced by macros the sandbox
+		// trusts.
+		trusted map[*Symbol]bool
 	}
 )
 
@@ -101,12 +114,16 @@ func (a *Analyzer) analyzeSymbol(form *Symbol, env Env) (*ast.Node, error) {
 			Local:      bindingNode.Local,
 			ArgID:      bindingNode.ArgID,
 			IsVariadic: bindingNode.IsVariadic,
+			Binding:    bindingNode,
 		}
 	} else {
 		v := a.resolveSym(form, env)
 
 		vr, ok := v.(*Var)
 		if ok {
+			if err := a.checkVar(vr, form, mform); err != nil {
+				return nil, err
+			}
 			m := vr.Meta()
 			n.Op = ast.OpVar
 			n.Sub = &ast.VarNode{
@@ -117,6 +134,9 @@ func (a *Analyzer) analyzeSymbol(form *Symbol, env Env) (*ast.Node, error) {
 		} else if v != nil {
 			// The symbol resolves to a non-var  Treat it as a
 			// constant.
+			if err := a.checkAccess(AccessHost, hostName(form.Name()), form, mform); err != nil {
+				return nil, err
+			}
 			n.Op = ast.OpConst
 			n.Sub = &ast.ConstNode{
 				Type:  classifyType(v),
@@ -125,6 +145,9 @@ func (a *Analyzer) analyzeSymbol(form *Symbol, env Env) (*ast.Node, error) {
 		} else {
 			if a.ResolveHost != nil {
 				if value, ok := a.ResolveHost(form); ok {
+					if err := a.checkAccess(AccessHost, hostName(form.String()), form, mform); err != nil {
+						return nil, err
+					}
 					n.Op = ast.OpConst
 					n.Sub = &ast.ConstNode{
 						Type:       classifyType(value),
@@ -148,6 +171,14 @@ func (a *Analyzer) analyzeSymbol(form *Symbol, env Env) (*ast.Node, error) {
 						Value: v,
 					}
 				} else {
+					// The form names a Go value or host class member, or
+					// a var of a namespace not yet loaded.
+					if err := a.checkAccess(AccessHost, hostName(maybeClass+"."+form.Name()), form, mform); err != nil {
+						return nil, err
+					}
+					if err := a.checkAccess(AccessNamespace, maybeClass, form, mform); err != nil {
+						return nil, err
+					}
 					// TODO: does this make any sense for go?
 					n.Op = ast.OpMaybeHostForm
 					n.Sub = &ast.MaybeHostFormNode{
@@ -156,6 +187,9 @@ func (a *Analyzer) analyzeSymbol(form *Symbol, env Env) (*ast.Node, error) {
 					}
 				}
 			} else {
+				if err := a.checkAccess(AccessHost, hostName(form.String()), form, mform); err != nil {
+					return nil, err
+				}
 				n.Op = ast.OpMaybeClass
 				n.Sub = &ast.MaybeClassNode{Class: mform}
 			}
@@ -247,13 +281,33 @@ func (a *Analyzer) analyzeSeq(form ISeq, env Env) (*ast.Node, error) {
 	if op == nil {
 		return nil, exInfo("can't call nil", nil) // TODO: include form and source info
 	}
+	if a.ReflectionWarning != nil {
+		if pos, ok := formPos(form); ok {
+			defer func(outer sourcePos) { a.pos = outer }(a.pos)
+			a.pos = pos
+		}
+	}
 	if symbol, ok := op.(*Symbol); ok && isSpecialFormSymbol(symbol) {
+		if err := a.checkAccess(AccessSpecialForm, symbol.FullName(), symbol, form); err != nil {
+			return nil, err
+		}
 		return a.parse(form, env)
 	}
 	mform, err := a.Macroexpand1(form)
 	if err != nil {
 		return nil, err
 	}
+	if a.Sandbox != nil && !Equals(form, mform) {
+		if vr, ok := a.resolveSym(op, env).(*Var); ok && vr.IsMacro() {
+			if err := a.checkVar(vr, op.(*Symbol), form); err != nil {
+				return nil, err
+			}
+			a.trustExpansion(vr, form, mform)
+		} else if sym, ok := op.(*Symbol); ok && a.trusted[sym] {
+			// (.member target) in the expansion of a trusted macro.
+			a.trustSymbols(form, mform)
+		}
+	}
 
 	if Equals(form, mform) {
 		return a.parse(form, env)
@@ -268,7 +322,14 @@ func (a *Analyzer) analyzeSeq(form ISeq, env Env) (*ast.Node, error) {
 }
 
 func isSpecialFormSymbol(symbol *Symbol) bool {
-	switch symbol.FullName() {
+	if isBuiltinSpecialForm(symbol.FullName()) {
+		return true
+	}
+	return symbol.Namespace() != "" && registeredSpecialForm(symbol.FullName()) != nil
+}
+
+func isBuiltinSpecialForm(name string) bool {
+	switch name {
 	case "do", "if", "new", "quote", "set!", "try", "throw", "def", ".",
 		"let*", "letfn*", "loop*", "recur", "fn*", "var", "case*", "go/go":
 		return true
@@ -471,6 +532,11 @@ func (a *Analyzer) parse(form interface{}, env Env) (*ast.Node, error) {
 	case "go/go":
 		return a.parseGo(form, env)
 	}
+	if opSym.Namespace() != "" {
+		if parse := registeredSpecialForm(opSym.FullName()); parse != nil {
+			return parse(a, form, env)
+		}
+	}
 
 	return a.parseInvoke(form, env)
 }
@@ -511,7 +577,14 @@ func (a *Analyzer) parseInvoke(form interface{}, env Env) (*ast.Node, error) {
 			// allowing well-formed inline definitions onto the fast path.
 			if !containsResidualUnquote(expanded) &&
 				inlineExpansionSupported(expanded, a.ResolveHost) {
-				return a.analyzeForm(expanded, env)
+				if a.Sandbox != nil {
+					a.trustExpansion(vr, form, expanded)
+				}
+				n, err := a.analyzeForm(expanded, env)
+				if err != nil {
+					return nil, err
+				}
+				return withRawForm(n, form), nil
 			}
 		}
 	}
@@ -1025,6 +1098,14 @@ func (a *Analyzer) parseDef(form interface{}, env Env) (*ast.Node, error) {
 	if sym.Namespace() != "" && sym.Namespace() != Get(env, KWNS).(*Symbol).Name() {
 		return nil, exInfo("can't def namespace-qualified symbol", nil)
 	}
+	// Macros that define vars, such as defn, give the name new metadata,
+	// so whether they are trusted is not considered.
+	if a.Sandbox != nil {
+		access := Access{Kind: AccessDef, Name: Get(env, KWNS).(*Symbol).Name() + "/" + sym.Name(), Form: form}
+		if err := a.Sandbox.Check(access); err != nil {
+			return nil, err
+		}
+	}
 
 	var init, doc interface{}
 	hasInit := true
@@ -1139,6 +1220,7 @@ func (a *Analyzer) parseDot(form interface{}, env Env) (*ast.Node, error) {
 	}
 	target := second(form)
 	mOrF := MustNth(form, 2)
+	memberSym, _ := mOrF.(*Symbol)
 	args := Rest(Rest(Rest(form)))
 	isField := false
 	if sym, ok := mOrF.(*Symbol); ok && len(sym.Name()) > 0 && sym.Name()[0] == '-' {
@@ -1179,6 +1261,14 @@ func (a *Analyzer) parseDot(form interface{}, env Env) (*ast.Node, error) {
 			targetValue := targetExpr.Sub.(*ast.ConstNode).Value
 			resolvedMethod, _ = lang.FieldOrMethod(targetValue, method.Name())
 		}
+		targetType := InferType(targetExpr, a.ResolveHost)
+		if targetType == nil {
+			a.warnReflection("call to method %s can't be resolved", method)
+		}
+		targetExpr, err = a.guardMember(targetExpr, First(mOrF).(*Symbol), method.Name(), form, env)
+		if err != nil {
+			return nil, err
+		}
 
 		n := ast.MakeNode(ast.OpHostCall, form)
 		n.Env = env
@@ -1187,29 +1277,83 @@ func (a *Analyzer) parseDot(form interface{}, env Env) (*ast.Node, error) {
 			Method:         method,
 			Args:           argNodes,
 			ResolvedMethod: resolvedMethod,
+			TargetType:     targetType,
 		}
 		return n, nil
 	case isField:
+		field := NewSymbol(mOrF.(*Symbol).Name())
+		targetType := InferType(targetExpr, a.ResolveHost)
+		if targetType == nil {
+			a.warnReflection("reference to field %s can't be resolved", field)
+		}
+		targetExpr, err = a.guardMember(targetExpr, memberSym, field.Name(), form, env)
+		if err != nil {
+			return nil, err
+		}
 		n := ast.MakeNode(ast.OpHostField, form)
 		n.Env = env
 		n.IsAssignable = true
 		n.Sub = &ast.HostFieldNode{
-			Target: targetExpr,
-			Field:  NewSymbol(mOrF.(*Symbol).Name()),
+			Target:     targetExpr,
+			Field:      field,
+			TargetType: targetType,
 		}
 		return n, nil
 	default:
+		mOrF := NewSymbol(mOrF.(*Symbol).Name())
+		targetType := InferType(targetExpr, a.ResolveHost)
+		if targetType == nil {
+			a.warnReflection("reference to field or no-arg method %s can't be resolved", mOrF)
+		}
+		targetExpr, err = a.guardMember(targetExpr, memberSym, mOrF.Name(), form, env)
+		if err != nil {
+			return nil, err
+		}
 		n := ast.MakeNode(ast.OpHostInterop, form)
 		n.Env = env
 		n.IsAssignable = true
 		n.Sub = &ast.HostInteropNode{
-			Target: targetExpr,
-			MOrF:   NewSymbol(mOrF.(*Symbol).Name()),
+			Target:     targetExpr,
+			MOrF:       mOrF,
+			TargetType: targetType,
 		}
 		return n, nil
 	}
 }
 
+// sourcePos is the position of a form in its source file.
+type sourcePos struct {
+	file string
+	line int
+}
+
+func formPos(form interface{}) (sourcePos, bool) {
+	obj, ok := form.(IMeta)
+	if !ok || obj.Meta() == nil {
+		return sourcePos{}, false
+	}
+	line, ok := obj.Meta().ValAt(KWLine).(int)
+	if !ok {
+		return sourcePos{}, false
+	}
+	file, _ := obj.Meta().ValAt(KWFile).(string)
+	return sourcePos{file: file, line: line}, true
+}
+
+// warnReflection reports a host interop form whose target's type is
+// unknown, as Clojure does when *warn-on-reflection* is true.
+func (a *Analyzer) warnReflection(format string, member *Symbol) {
+	if a.ReflectionWarning == nil {
+		return
+	}
+	file := a.pos.file
+	if file == "" {
+		file = "NO_SOURCE_FILE"
+	}
+	a.ReflectionWarning(fmt.Sprintf("Reflection warning, %s:%d - "+format+" (target type is unknown).",
+		file, a.pos.line, member))
+}
+
 // (defn parse-let*
 //
 //	[form env]
@@ -1395,7 +1539,7 @@ func (a *Analyzer) parseRecur(form interface{}, env Env) (*ast.Node, error) {
 		errorMsg = fmt.Sprintf("mismatched argument count to recur, expected: %v args, had: %v", loopLocals, Count(exprs))
 	}
 	if errorMsg != "" {
-		return nil, exInfo(errorMsg, nil)
+		return nil, exInfo(errorMsg, form)
 	}
 	var exprNodes []*ast.Node
 	for seq := Seq(exprs); seq != nil; seq = seq.Next() {
@@ -1874,6 +2018,11 @@ func (a *Analyzer) parseVar(form interface{}, env Env) (*ast.Node, error) {
 	if !ok {
 		return nil, exInfo(fmt.Sprintf("expecting var, but %s is mapped to %v", vrSym, maybeVar), nil)
 	}
+	if sym, ok := vrSym.(*Symbol); ok {
+		if err := a.checkVar(vr, sym, form); err != nil {
+			return nil, err
+		}
+	}
 	n := ast.MakeNode(ast.OpTheVar, form)
 	n.Env = env
 	n.Sub = &ast.TheVarNode{
@@ -2052,9 +2201,19 @@ func second(x interface{}) interface{} {
 	return First(Rest(x))
 }
 
-func exInfo(errStr string, _ interface{}) error {
-	// TODO
-	return fmt.Errorf("%s", errStr)
+// An AnalysisError is an error found analyzing a form.
+type AnalysisError struct {
+	Msg string
+	// Form is the offending form, if known.
+	Form interface{}
+}
+
+func (e *AnalysisError) Error() string {
+	return e.Msg
+}
+
+func exInfo(errStr string, form interface{}) error {
+	return &AnalysisError{Msg: errStr, Form: form}
 }
 
 func withRawForm(n *ast.Node, form interface{}) *ast.Node {
@@ -1,10 +1,13 @@
 package compiler
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/glojurelang/glojure/pkg/ast"
 	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
 )
 
 func TestResolvedHostConstantsRetainTheirSymbols(t *testing.T) {
@@ -64,6 +67,56 @@ func TestSpecialFormsAreRecognizedBeforeMacroExpansion(t *testing.T) {
 	}
 }
 
+func TestRegisteredSpecialForm(t *testing.T) {
+	op := ast.RegisterOp("compiler.test/pair")
+	parse := func(a *Analyzer, form interface{}, env Env) (*ast.Node, error) {
+		n := ast.MakeNode(op, form)
+		n.Env = env
+		sub := &ast.ExtensionNode{Data: "pair"}
+		for seq := lang.Seq(lang.Rest(form)); seq != nil; seq = seq.Next() {
+			arg, err := a.AnalyzeExpr(seq.First(), env)
+			if err != nil {
+				return nil, err
+			}
+			sub.Args = append(sub.Args, arg)
+		}
+		n.Sub = sub
+		return n, nil
+	}
+	if err := RegisterSpecialForm("compiler.test/pair", parse); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"pair", "go/go", "compiler.test/pair"} {
+		if err := RegisterSpecialForm(name, parse); err == nil {
+			t.Errorf("registering special form %s succeeded", name)
+		}
+	}
+	if !isSpecialFormSymbol(lang.NewSymbol("compiler.test/pair")) ||
+		isSpecialFormSymbol(lang.NewSymbol("pair")) {
+		t.Fatal("registered special form was not recognized by its qualified name only")
+	}
+
+	analyzer := &Analyzer{
+		Macroexpand1: func(form interface{}) (interface{}, error) {
+			t.Fatalf("macroexpanded special form %v", form)
+			return form, nil
+		},
+	}
+	form := lang.NewList(lang.NewSymbol("compiler.test/pair"), int64(1), "two")
+	n, err := analyzer.Analyze(form, lang.NewMap().(Env))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Op != op {
+		t.Fatalf("special form op = %v, want %v", n.Op, op)
+	}
+	args := n.Sub.(*ast.ExtensionNode).Args
+	if len(args) != 2 || args[0].Sub.(*ast.ConstNode).Value != int64(1) ||
+		args[1].Sub.(*ast.ConstNode).Value != "two" {
+		t.Fatalf("special form arguments = %v", args)
+	}
+}
+
 func TestInlineExpansionSupportedChecksHostMethodArity(t *testing.T) {
 	resolveNumbers := func(sym *lang.Symbol) (interface{}, bool) {
 		if sym.String() == "test/Numbers" {
@@ -215,3 +268,80 @@ func TestRuntimeFormMeta(t *testing.T) {
 		t.Fatalf("mixed metadata became %v, want %v", got, want)
 	}
 }
+
+func TestHostInteropTargetTypes(t *testing.T) {
+	nsSym := lang.NewSymbol("compiler.target-type-test")
+	ns := lang.FindOrCreateNamespace(nsSym)
+	t.Cleanup(func() { lang.RemoveNamespace(nsSym) })
+
+	var warnings []string
+	analyzer := &Analyzer{
+		Macroexpand1:  func(form interface{}) (interface{}, error) { return form, nil },
+		FindNamespace: func(*lang.Symbol) *lang.Namespace { return ns },
+		ResolveHost: func(sym *lang.Symbol) (interface{}, bool) {
+			switch sym.String() {
+			case "strings.Builder":
+				return reflect.TypeOf(strings.Builder{}), true
+			case "strings.NewReader":
+				return strings.NewReader, true
+			}
+			return nil, false
+		},
+		Gensym:            func(prefix string) *lang.Symbol { return lang.NewSymbol(prefix + "1") },
+		ReflectionWarning: func(msg string) { warnings = append(warnings, msg) },
+	}
+	env := lang.NewMap(lang.KWNS, nsSym).(Env)
+	read := func(code string) interface{} {
+		form, err := reader.New(strings.NewReader(code), reader.WithFilename("types.glj")).ReadOne()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return form
+	}
+
+	for _, test := range []struct {
+		code       string
+		targetType reflect.Type
+		valueType  reflect.Type
+	}{
+		{`(let* [r (strings.NewReader "abc")] (. r Len))`, reflect.TypeOf(&strings.Reader{}), reflect.TypeOf(0)},
+		{`(. (new strings.Builder) String)`, reflect.TypeOf(&strings.Builder{}), reflect.TypeOf("")},
+		{`(fn* [^strings.Builder b] (. b Len))`, reflect.TypeOf(strings.Builder{}), nil},
+		{`(loop* [r (strings.NewReader "")] (. r Len))`, nil, nil},
+		{`(fn* [x]
+		   (. x Len))`, nil, nil},
+	} {
+		warnings = nil
+		n, err := analyzer.Analyze(read(test.code), env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var targetType reflect.Type
+		found := false
+		if _, err := ast.Transform(n, func(node *ast.Node) (*ast.Node, error) {
+			if sub, ok := node.Sub.(*ast.HostInteropNode); ok && !found {
+				targetType, found = sub.TargetType, true
+			}
+			return node, nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if !found || targetType != test.targetType {
+			t.Errorf("%s: target type = %v, want %v", test.code, targetType, test.targetType)
+		}
+		if got := InferType(n, analyzer.ResolveHost); got != test.valueType {
+			t.Errorf("%s: type = %v, want %v", test.code, got, test.valueType)
+		}
+		if test.targetType != nil && len(warnings) != 0 {
+			t.Errorf("%s: unexpected warnings %q", test.code, warnings)
+		}
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %q, want one", warnings)
+	}
+	want := "Reflection warning, types.glj:2 - reference to field or no-arg method Len can't be resolved (target type is unknown)."
+	if warnings[0] != want {
+		t.Fatalf("warning = %q, want %q", warnings[0], want)
+	}
+}
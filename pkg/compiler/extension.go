@@ -0,0 +1,238 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// SpecialFormParser analyzes a form whose operator is a registered
+// special form. It returns a node, usually of an op allocated with
+// ast.RegisterOp and with an *ast.ExtensionNode as its Sub, and
+// analyzes the subforms it evaluates with the analyzer's AnalyzeExpr.
+type SpecialFormParser func(a *Analyzer, form interface{}, env Env) (*ast.Node, error)
+
+// PassOrder constrains where a registered optimization pass runs
+// relative to other passes, by name. Names of passes that are not in a
+// pipeline are ignored, though a pass placed before a built-in pass a
+// pipeline leaves out is still not moved after the pipeline's last one.
+type PassOrder struct {
+	After  []string
+	Before []string
+}
+
+type registeredPass struct {
+	pass  OptimizationPass
+	order PassOrder
+}
+
+var (
+	extensionMtx sync.RWMutex
+	specialForms = map[string]SpecialFormParser{}
+	// haveSpecialForms lets the analysis of a qualified call skip the
+	// lock until a special form is registered.
+	haveSpecialForms atomic.Bool
+	registeredPasses []registeredPass
+)
+
+// RegisterSpecialForm makes the symbol name a special form parsed by
+// parse. The name must be namespace-qualified, so that syntax-quote
+// leaves it as written and it cannot shadow the built-in special forms.
+// Registration is meant for init functions; forms analyzed earlier are
+// not affected.
+func RegisterSpecialForm(name string, parse SpecialFormParser) error {
+	sym := lang.NewSymbol(name)
+	if sym.Namespace() == "" || sym.Name() == "" {
+		return fmt.Errorf("special form name %q must be namespace-qualified", name)
+	}
+	if parse == nil {
+		return fmt.Errorf("special form %s has no parser", name)
+	}
+	extensionMtx.Lock()
+	defer extensionMtx.Unlock()
+	if _, ok := specialForms[name]; ok || isBuiltinSpecialForm(name) {
+		return fmt.Errorf("special form %s is already defined", name)
+	}
+	specialForms[name] = parse
+	haveSpecialForms.Store(true)
+	return nil
+}
+
+// RegisteredSpecialForms returns the names of the registered special
+// forms, sorted.
+func RegisteredSpecialForms() []string {
+	extensionMtx.RLock()
+	defer extensionMtx.RUnlock()
+	names := make([]string, 0, len(specialForms))
+	for name := range specialForms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func registeredSpecialForm(name string) SpecialFormParser {
+	if !haveSpecialForms.Load() {
+		return nil
+	}
+	extensionMtx.RLock()
+	defer extensionMtx.RUnlock()
+	return specialForms[name]
+}
+
+// AnalyzeExpr analyzes a subform of a registered special form whose
+// value the form uses.
+func (a *Analyzer) AnalyzeExpr(form interface{}, env Env) (*ast.Node, error) {
+	return a.analyzeForm(form, ctxEnv(env, ctxExpr))
+}
+
+// RegisterOptimizationPass adds pass to the pipelines built by
+// NewDefaultOptimizer afterwards. Unless order places it before another
+// pass, the pass runs after the built-in passes; registered passes
+// free to run in either order run in the order they were registered.
+// It is an error to reuse the name of a pass or to order passes in a
+// cycle.
+func RegisterOptimizationPass(pass OptimizationPass, order PassOrder) error {
+	if pass == nil {
+		return fmt.Errorf("nil AST optimization pass")
+	}
+	extensionMtx.Lock()
+	defer extensionMtx.Unlock()
+	builtins := allBuiltinPasses()
+	for _, p := range builtins {
+		if p.Name() == pass.Name() {
+			return fmt.Errorf("AST optimization %q is already defined", pass.Name())
+		}
+	}
+	for _, rp := range registeredPasses {
+		if rp.pass.Name() == pass.Name() {
+			return fmt.Errorf("AST optimization %q is already defined", pass.Name())
+		}
+	}
+	passes := append(registeredPasses[:len(registeredPasses):len(registeredPasses)],
+		registeredPass{pass: pass, order: order})
+	if _, err := orderPasses(builtins, passes); err != nil {
+		return err
+	}
+	registeredPasses = passes
+	return nil
+}
+
+// RegisteredOptimizationPasses returns the names of the registered
+// optimization passes, sorted.
+func RegisteredOptimizationPasses() []string {
+	extensionMtx.RLock()
+	defer extensionMtx.RUnlock()
+	names := make([]string, 0, len(registeredPasses))
+	for _, rp := range registeredPasses {
+		names = append(names, rp.pass.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func withRegisteredPasses(builtins []OptimizationPass) []OptimizationPass {
+	extensionMtx.RLock()
+	defer extensionMtx.RUnlock()
+	if len(registeredPasses) == 0 {
+		return builtins
+	}
+	passes, err := orderPasses(builtins, registeredPasses)
+	if err != nil {
+		// RegisterOptimizationPass orders the passes with every built-in
+		// one. The built-in passes of a pipeline are a subsequence of
+		// those, and a pass placed before one that is left out stays
+		// placed, so each constraint here is implied by one there.
+		panic(err)
+	}
+	return passes
+}
+
+// allBuiltinPasses returns the built-in passes of every pipeline, in
+// their order. Those of each pipeline are a subsequence of them.
+func allBuiltinPasses() []OptimizationPass {
+	return builtinPasses(OptimizationOptions{DirectLinking: true})
+}
+
+// orderPasses sorts the built-in passes, in their order, and the
+// registered passes topologically by their constraints. A registered
+// pass placed before no other pass, present or built-in, runs after the
+// last built-in one.
+// Among the passes free to run next, the earliest in builtins then
+// registered runs first.
+func orderPasses(builtins []OptimizationPass, registered []registeredPass) ([]OptimizationPass, error) {
+	passes := append([]OptimizationPass(nil), builtins...)
+	builtin := map[string]bool{}
+	for _, p := range allBuiltinPasses() {
+		builtin[p.Name()] = true
+	}
+	index := make(map[string]int, len(builtins)+len(registered))
+	for i, p := range builtins {
+		index[p.Name()] = i
+	}
+	for _, rp := range registered {
+		index[rp.pass.Name()] = len(passes)
+		passes = append(passes, rp.pass)
+	}
+
+	succs := make([][]int, len(passes))
+	preds := make([]int, len(passes))
+	edge := func(from, to int) {
+		succs[from] = append(succs[from], to)
+		preds[to]++
+	}
+	for i := 1; i < len(builtins); i++ {
+		edge(i-1, i)
+	}
+	for i, rp := range registered {
+		i += len(builtins)
+		for _, name := range rp.order.After {
+			if j, ok := index[name]; ok {
+				edge(j, i)
+			}
+		}
+		placed := false
+		for _, name := range rp.order.Before {
+			if j, ok := index[name]; ok {
+				edge(i, j)
+				placed = true
+			} else if builtin[name] {
+				placed = true
+			}
+		}
+		if !placed && len(builtins) > 0 {
+			edge(len(builtins)-1, i)
+		}
+	}
+
+	ordered := make([]OptimizationPass, 0, len(passes))
+	done := make([]bool, len(passes))
+	for len(ordered) < len(passes) {
+		next := -1
+		for i := range passes {
+			if !done[i] && preds[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var cycle []string
+			for i, p := range passes {
+				if !done[i] {
+					cycle = append(cycle, p.Name())
+				}
+			}
+			return nil, fmt.Errorf("AST optimizations %q are ordered in a cycle", cycle)
+		}
+		done[next] = true
+		ordered = append(ordered, passes[next])
+		for _, j := range succs[next] {
+			preds[j]--
+		}
+	}
+	return ordered, nil
+}
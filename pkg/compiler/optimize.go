@@ -31,8 +31,13 @@ func NewOptimizer(passes ...OptimizationPass) *Optimizer {
 	return &Optimizer{passes: append([]OptimizationPass(nil), passes...)}
 }
 
-// NewDefaultOptimizer constructs the standard backend-neutral pass pipeline.
+// NewDefaultOptimizer constructs the standard backend-neutral pass
+// pipeline, including the passes added with RegisterOptimizationPass.
 func NewDefaultOptimizer(options OptimizationOptions) *Optimizer {
+	return &Optimizer{passes: withRegisteredPasses(builtinPasses(options))}
+}
+
+func builtinPasses(options OptimizationOptions) []OptimizationPass {
 	passes := []OptimizationPass{foldLiteralNumbersPass{}}
 	if options.DirectLinking {
 		passes = append(passes, fuseReplaceLastPass{})
@@ -41,7 +46,7 @@ func NewDefaultOptimizer(options OptimizationOptions) *Optimizer {
 	if options.DirectLinking {
 		passes = append(passes, lowerAssocPass{})
 	}
-	return NewOptimizer(passes...)
+	return passes
 }
 
 // Optimize applies every pass to root.
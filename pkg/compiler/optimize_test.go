@@ -1,6 +1,8 @@
 package compiler
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/glojurelang/glojure/pkg/ast"
@@ -185,3 +187,114 @@ func TestReplaceLastFusionRequiresDirectLinking(t *testing.T) {
 		t.Fatalf("optimized op = %v, want OpInvoke", result.Op)
 	}
 }
+
+func passNames(passes []OptimizationPass) []string {
+	names := make([]string, len(passes))
+	for i, pass := range passes {
+		names[i] = pass.Name()
+	}
+	return names
+}
+
+func TestOrderPassesHonorsConstraints(t *testing.T) {
+	identity := func(name string) testOptimizationPass {
+		return testOptimizationPass{
+			name:    name,
+			rewrite: func(root *ast.Node) (*ast.Node, error) { return root, nil },
+		}
+	}
+	builtins := []OptimizationPass{identity("a"), identity("b"), identity("c")}
+	ordered, err := orderPasses(builtins, []registeredPass{
+		{pass: identity("last")},
+		{pass: identity("before-b"), order: PassOrder{Before: []string{"b"}}},
+		{pass: identity("between"), order: PassOrder{After: []string{"a"}, Before: []string{"c"}}},
+		{pass: identity("after-last"), order: PassOrder{After: []string{"last", "missing"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(passNames(ordered), " ")
+	if want := "a before-b b between c last after-last"; got != want {
+		t.Fatalf("pass order = %s, want %s", got, want)
+	}
+
+	_, err = orderPasses(builtins, []registeredPass{
+		{pass: identity("x"), order: PassOrder{After: []string{"c"}, Before: []string{"a"}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("cyclic order error = %v", err)
+	}
+}
+
+func TestRegisterOptimizationPass(t *testing.T) {
+	pass := testOptimizationPass{
+		name:    "test-registered",
+		rewrite: func(root *ast.Node) (*ast.Node, error) { return root, nil },
+	}
+	if err := RegisterOptimizationPass(pass, PassOrder{
+		After:  []string{"fold-literal-numbers"},
+		Before: []string{"lower-keyword-lookup"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(passNames(NewDefaultOptimizer(OptimizationOptions{DirectLinking: true}).passes), " ")
+	want := "fold-literal-numbers fuse-replace-last test-registered lower-keyword-lookup lower-assoc"
+	if got != want {
+		t.Fatalf("default passes = %s, want %s", got, want)
+	}
+	if names := RegisteredOptimizationPasses(); !slices.Contains(names, "test-registered") {
+		t.Fatalf("registered passes = %v, want test-registered among them", names)
+	}
+
+	for _, bad := range []struct {
+		name  string
+		order PassOrder
+	}{
+		{"lower-assoc", PassOrder{}},
+		{"test-registered", PassOrder{}},
+		{"test-cyclic", PassOrder{After: []string{"lower-assoc"}, Before: []string{"test-registered"}}},
+	} {
+		pass := testOptimizationPass{name: bad.name}
+		if err := RegisterOptimizationPass(pass, bad.order); err == nil {
+			t.Errorf("registering %s succeeded", bad.name)
+		}
+	}
+	if got := len(NewDefaultOptimizer(OptimizationOptions{}).passes); got != 3 {
+		t.Fatalf("default pipeline has %d passes after rejected registrations, want 3", got)
+	}
+}
+
+func TestRegisteredPassesBeforeMissingBuiltin(t *testing.T) {
+	defer func(saved []registeredPass) { registeredPasses = saved }(registeredPasses)
+	registeredPasses = nil
+	identity := func(name string) testOptimizationPass {
+		return testOptimizationPass{
+			name:    name,
+			rewrite: func(root *ast.Node) (*ast.Node, error) { return root, nil },
+		}
+	}
+	if err := RegisterOptimizationPass(identity("test-before-assoc"), PassOrder{
+		Before: []string{"lower-assoc"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterOptimizationPass(identity("test-between"), PassOrder{
+		After:  []string{"test-before-assoc"},
+		Before: []string{"lower-keyword-lookup"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		options OptimizationOptions
+		want    string
+	}{
+		{OptimizationOptions{DirectLinking: true}, "fold-literal-numbers fuse-replace-last test-before-assoc test-between lower-keyword-lookup lower-assoc"},
+		{OptimizationOptions{}, "fold-literal-numbers test-before-assoc test-between lower-keyword-lookup"},
+	} {
+		got := strings.Join(passNames(NewDefaultOptimizer(tc.options).passes), " ")
+		if got != tc.want {
+			t.Errorf("passes with %+v = %s, want %s", tc.options, got, tc.want)
+		}
+	}
+}
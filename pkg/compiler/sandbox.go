@@ -0,0 +1,330 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+
+	. "github.com/glojurelang/glojure/pkg/lang"
+)
+
+// An AccessKind is a kind of thing analyzed code can refer to.
+type AccessKind int
+
+const (
+	// AccessHost is a Go value or host class, named by its package path
+	// and name, as in "os.RemoveAll" or "net/http.Get", or by its class
+	// name, as in "ProcessBuilder".
+	AccessHost AccessKind = iota
+	// AccessVar is a var, named by its qualified name.
+	AccessVar
+	// AccessNamespace is a namespace whose vars are referred to.
+	AccessNamespace
+	// AccessSpecialForm is a special form, such as set! or def.
+	AccessSpecialForm
+	// AccessDef is the definition of a var, named by its qualified name.
+	AccessDef
+	// AccessMember is a method or field of a host value, named by the
+	// value's Go type and the member, as in "*lang.Var.BindRoot". The
+	// type of a value is only known once it is evaluated, so members are
+	// checked as code runs.
+	AccessMember
+)
+
+var accessKindKeywords = [...]lang.Keyword{
+	AccessHost:        lang.NewKeyword("host"),
+	AccessVar:         lang.NewKeyword("var"),
+	AccessNamespace:   lang.NewKeyword("namespace"),
+	AccessSpecialForm: lang.NewKeyword("special-form"),
+	AccessDef:         lang.NewKeyword("def"),
+	AccessMember:      lang.NewKeyword("member"),
+}
+
+func (k AccessKind) String() string {
+	return accessKindKeywords[k].Name()
+}
+
+// An Access is a reference a form makes that a Sandbox checks.
+type Access struct {
+	Kind AccessKind
+	Name string
+	// Form is the form making the access.
+	Form interface{}
+}
+
+// A Sandbox restricts what analyzed code may refer to. The analyzer
+// checks each host value, var, namespace and special form a form refers
+// to as it resolves them, failing analysis with the error Check
+// returns. Host members are checked when they are accessed, and a
+// denied access panics with the error Check returns.
+//
+// Macros expand to code that refers to things the code that calls them
+// may not, such as the host functions clojure.core's macros use. The
+// symbols a macro the sandbox trusts introduces into its expansion,
+// rather than taking from its arguments, are not checked.
+type Sandbox interface {
+	Check(access Access) error
+	TrustsMacro(v *lang.Var) bool
+}
+
+// A SandboxError is the error analysis fails with when a Sandbox denies
+// an access. Its ex-data is a map with the keys :type,
+// :glojure/sandbox-violation, :access, the kind of access as a keyword,
+// and :name.
+type SandboxError struct {
+	Access Access
+}
+
+var (
+	kwType             = lang.NewKeyword("type")
+	kwSandboxViolation = lang.NewKeyword("glojure/sandbox-violation")
+	kwAccess           = lang.NewKeyword("access")
+	kwName             = lang.NewKeyword("name")
+)
+
+var _ lang.IExceptionInfo = (*SandboxError)(nil)
+
+func (e *SandboxError) Error() string {
+	msg := fmt.Sprintf("sandbox does not allow %s %s", strings.ReplaceAll(e.Access.Kind.String(), "-", " "), e.Access.Name)
+	if meta, ok := e.Access.Form.(lang.IMeta); ok && meta.Meta() != nil {
+		if line, ok := meta.Meta().ValAt(lang.KWLine).(int); ok {
+			file, _ := meta.Meta().ValAt(lang.KWFile).(string)
+			msg = fmt.Sprintf("%s:%d: %s", file, line, msg)
+		}
+	}
+	return msg
+}
+
+func (e *SandboxError) GetData() lang.IPersistentMap {
+	return lang.NewMap(
+		kwType, kwSandboxViolation,
+		kwAccess, accessKindKeywords[e.Access.Kind],
+		kwName, e.Access.Name,
+	)
+}
+
+// Rules allow or deny names. A name is denied if it matches a pattern
+// in Deny, or if Allow is not empty and it matches no pattern in Allow.
+// A pattern matches a name equal to it or, if it ends in "*", a name it
+// is a prefix of without the "*": "os.*" matches "os.RemoveAll" but not
+// "os/exec.Command".
+type Rules struct {
+	Allow []string
+	Deny  []string
+}
+
+// Allows reports whether the rules allow name.
+func (r Rules) Allows(name string) bool {
+	if matchAny(r.Deny, name) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchAny(r.Allow, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// A SandboxPolicy is a Sandbox of allow and deny rules.
+type SandboxPolicy struct {
+	// Host rules the Go values and host classes code may refer to.
+	Host Rules
+	// Namespaces rules the namespaces whose vars code may refer to,
+	// and Vars the vars, by qualified name.
+	Namespaces Rules
+	Vars       Rules
+	// SpecialForms rules the special forms code may use.
+	SpecialForms Rules
+	// Def rules the namespaces code may define vars in.
+	Def Rules
+	// Members rules the methods and fields of host values code may
+	// access.
+	Members Rules
+	// TrustMacrosIn names the namespaces whose macros are trusted.
+	TrustMacrosIn []string
+}
+
+// DefaultSandboxPolicy returns a policy for running untrusted code in a
+// runtime of its own. It denies the Go packages and host classes that
+// reach the file system, processes, the network and unsafe memory; the
+// vars that load code, evaluate it, return vars, define vars in other
+// namespaces or alter existing ones or their bindings; the members of vars and
+// namespaces, through which the same can be done; set!; and defining
+// vars in the standard library's namespaces, which runtimes share. It
+// trusts the standard library's macros, but denies with-redefs, which
+// alters the roots of the vars it is given.
+func DefaultSandboxPolicy() *SandboxPolicy {
+	return &SandboxPolicy{
+		Host: Rules{Deny: []string{
+			"os.*", "os/*", "syscall.*", "syscall/*", "unsafe.*", "plugin.*",
+			"net.*", "net/*", "io/fs.*", "io/ioutil.*", "path/filepath.*",
+			"runtime.*", "runtime/*", "reflect.*", "debug/*",
+			"github.com/glojurelang/glojure/*",
+			"ProcessBuilder*", "File*", "Path*", "System*", "Runtime*", "Thread*",
+			"java.*",
+		}},
+		Vars: Rules{Deny: []string{
+			"clojure.core/load", "clojure.core/load-file", "clojure.core/load-string",
+			"clojure.core/load-reader", "clojure.core/add-load-path",
+			"clojure.core/eval", "clojure.core/intern", "clojure.core/alter-var-root",
+			"clojure.core/ns-unmap", "clojure.core/remove-ns",
+			"clojure.core/resolve", "clojure.core/ns-resolve", "clojure.core/requiring-resolve",
+			"clojure.core/find-var", "clojure.core/ns-publics", "clojure.core/ns-interns",
+			"clojure.core/ns-map", "clojure.core/ns-refers", "clojure.core/ns-aliases",
+			"clojure.core/var-get", "clojure.core/var-set",
+			"clojure.core/get-thread-bindings", "clojure.core/push-thread-bindings",
+			"clojure.core/pop-thread-bindings", "clojure.core/with-bindings",
+			"clojure.core/with-bindings*",
+			"clojure.core/alter-meta!", "clojure.core/reset-meta!",
+			"clojure.core/with-redefs", "clojure.core/with-redefs-fn",
+			"clojure.core/slurp", "clojure.core/spit",
+			"clojure.core/shutdown-agents",
+		}},
+		Namespaces: Rules{Deny: []string{
+			"clojure.java.*", "glojure.go.*", "glojure.deps.*", "glojure.tools.*",
+			"glojure.profile", "clojure.core.server",
+		}},
+		Members:       Rules{Deny: []string{"*lang.Var.*", "*lang.Namespace.*"}},
+		SpecialForms:  Rules{Deny: []string{"set!"}},
+		Def:           Rules{Deny: []string{"clojure.*", "glojure.*"}},
+		TrustMacrosIn: []string{"clojure.*", "glojure.*"},
+	}
+}
+
+// Check implements Sandbox.
+func (p *SandboxPolicy) Check(access Access) error {
+	var allowed bool
+	switch access.Kind {
+	case AccessHost:
+		allowed = p.Host.Allows(access.Name)
+	case AccessVar:
+		allowed = p.Vars.Allows(access.Name)
+	case AccessNamespace:
+		allowed = p.Namespaces.Allows(access.Name)
+	case AccessSpecialForm:
+		allowed = p.SpecialForms.Allows(access.Name)
+	case AccessDef:
+		ns, _, _ := strings.Cut(access.Name, "/")
+		allowed = p.Def.Allows(ns)
+	case AccessMember:
+		allowed = p.Members.Allows(access.Name)
+	}
+	if allowed {
+		return nil
+	}
+	return &SandboxError{Access: access}
+}
+
+// TrustsMacro implements Sandbox.
+func (p *SandboxPolicy) TrustsMacro(v *lang.Var) bool {
+	return matchAny(p.TrustMacrosIn, v.Namespace().Name().String())
+}
+
+// checkAccess checks an access with the analyzer's sandbox, unless sym
+// was introduced by a trusted macro.
+func (a *Analyzer) checkAccess(kind AccessKind, name string, sym *Symbol, form interface{}) error {
+	if a.Sandbox == nil || a.trusted[sym] {
+		return nil
+	}
+	return a.Sandbox.Check(Access{Kind: kind, Name: name, Form: form})
+}
+
+// checkVar checks a reference through sym to vr and its namespace.
+func (a *Analyzer) checkVar(vr *Var, sym *Symbol, form interface{}) error {
+	if a.Sandbox == nil {
+		return nil
+	}
+	ns := vr.Namespace().Name().String()
+	if err := a.checkAccess(AccessNamespace, ns, sym, form); err != nil {
+		return err
+	}
+	return a.checkAccess(AccessVar, ns+"/"+vr.Symbol().Name(), sym, form)
+}
+
+// guardMember returns target, the node of the value whose member name
+// form accesses through sym, wrapped in a call that checks the access
+// with the analyzer's sandbox once the value is known, unless sym was
+// introduced by a trusted macro.
+func (a *Analyzer) guardMember(target *ast.Node, sym *Symbol, name string, form interface{}, env Env) (*ast.Node, error) {
+	if a.Sandbox == nil || a.trusted[sym] {
+		return target, nil
+	}
+	sandbox := a.Sandbox
+	guard := lang.NewFnFunc1(func(v interface{}) interface{} {
+		access := Access{Kind: AccessMember, Name: fmt.Sprintf("%T.%s", v, name), Form: form}
+		if err := sandbox.Check(access); err != nil {
+			panic(err)
+		}
+		return v
+	})
+	fn, err := a.analyzeConst(guard, env)
+	if err != nil {
+		return nil, err
+	}
+	n := ast.MakeNode(ast.OpInvoke, form)
+	n.Sub = &ast.InvokeNode{Fn: fn, Args: []*ast.Node{target}}
+	return n, nil
+}
+
+// hostName returns name, a Go value or host class as analyzed code
+// refers to it, with its package path unmunged.
+func hostName(name string) string {
+	pkg, export := pkgmap.SplitExport(name)
+	if pkg == "" {
+		return export
+	}
+	return pkgmap.UnmungePkg(pkg) + "." + export
+}
+
+// trustExpansion records the symbols of the expansion of form by the
+// macro or inliner of vr that the expansion introduced, if the sandbox
+// trusts vr.
+func (a *Analyzer) trustExpansion(vr *Var, form, expansion interface{}) {
+	if a.Sandbox.TrustsMacro(vr) {
+		a.trustSymbols(form, expansion)
+	}
+}
+
+// trustSymbols records the symbols of expansion that form does not
+// contain.
+func (a *Analyzer) trustSymbols(form, expansion interface{}) {
+	given := map[*Symbol]bool{}
+	walkSymbols(form, func(sym *Symbol) { given[sym] = true })
+	if a.trusted == nil {
+		a.trusted = map[*Symbol]bool{}
+	}
+	walkSymbols(expansion, func(sym *Symbol) {
+		if !given[sym] {
+			a.trusted[sym] = true
+		}
+	})
+}
+
+func walkSymbols(form interface{}, fn func(*Symbol)) {
+	switch form := form.(type) {
+	case *Symbol:
+		fn(form)
+	case IPersistentMap:
+		for s := Seq(form); s != nil; s = s.Next() {
+			entry := s.First().(IMapEntry)
+			walkSymbols(entry.Key(), fn)
+			walkSymbols(entry.Val(), fn)
+		}
+	case IPersistentVector, IPersistentSet, ISeq:
+		for s := Seq(form); s != nil; s = s.Next() {
+			walkSymbols(s.First(), fn)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package compiler
+
+import "testing"
+
+func TestRulesAllows(t *testing.T) {
+	r := Rules{Allow: []string{"strings.*", "math.Sqrt", "net/*"}, Deny: []string{"net/http.*"}}
+	for name, want := range map[string]bool{
+		"strings.ToUpper":  true,
+		"math.Sqrt":        true,
+		"math.Pow":         false,
+		"net/url.Parse":    true,
+		"net/http.Get":     false,
+		"stringsx.Foo":     false,
+		"os.RemoveAll":     false,
+		"strings/sub.Func": false,
+	} {
+		if got := r.Allows(name); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if !(Rules{}).Allows("anything") {
+		t.Error("empty rules deny a name")
+	}
+}
+
+func TestHostName(t *testing.T) {
+	for sym, want := range map[string]string{
+		"os.RemoveAll":    "os.RemoveAll",
+		"net:http.Get":    "net/http.Get",
+		"os/exec.Command": "os/exec.Command",
+		"ProcessBuilder":  "ProcessBuilder",
+	} {
+		if got := hostName(sym); got != want {
+			t.Errorf("hostName(%q) = %q, want %q", sym, got, want)
+		}
+	}
+}
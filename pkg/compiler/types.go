@@ -0,0 +1,167 @@
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+var ifnType = reflect.TypeOf((*lang.IFn)(nil)).Elem()
+
+// InferType returns the Go type of the values n evaluates to, if it is
+// known from a type hint, a constant, a constructor or the signature
+// of the Go function or method n calls, or nil. resolveHost resolves
+// the symbols of type hints, as Analyzer.ResolveHost does. The empty
+// interface is reported as unknown.
+func InferType(n *ast.Node, resolveHost func(sym *lang.Symbol) (interface{}, bool)) reflect.Type {
+	if n == nil {
+		return nil
+	}
+	if typ := tagType(formTag(n.Form), resolveHost); typ != nil {
+		return typ
+	}
+	var typ reflect.Type
+	switch sub := n.Sub.(type) {
+	case *ast.ConstNode:
+		typ = reflect.TypeOf(sub.Value)
+	case *ast.LocalNode:
+		if typ = tagType(formTag(sub.Name), resolveHost); typ != nil {
+			break
+		}
+		// The values of other locals, such as those of loops, can
+		// change type.
+		if sub.Binding != nil && sub.Binding.Local == lang.KWLet {
+			typ = InferType(sub.Binding.Init, resolveHost)
+		}
+	case *ast.NewNode:
+		if sub.Class.Op == ast.OpConst {
+			typ, _ = lang.HostInstanceType(sub.Class.Sub.(*ast.ConstNode).Value, len(sub.Args))
+		}
+	case *ast.InvokeNode:
+		switch fn := sub.Fn.Sub.(type) {
+		case *ast.ConstNode:
+			typ = funcResultType(reflect.TypeOf(fn.Value), 0, len(sub.Args))
+		case *ast.VarNode:
+			// As in Clojure, the tag of a function's var is the type of
+			// the values it returns.
+			typ = tagType(lang.Get(fn.Var.Meta(), lang.KWTag), resolveHost)
+		}
+	case *ast.HostCallNode:
+		typ = hostMemberType(sub.TargetType, sub.Method.Name(), len(sub.Args), false)
+	case *ast.HostInteropNode:
+		typ = hostMemberType(sub.TargetType, sub.MOrF.Name(), 0, true)
+	case *ast.HostFieldNode:
+		typ = hostMemberType(sub.TargetType, sub.Field.Name(), -1, true)
+	case *ast.QuoteNode:
+		typ = InferType(sub.Expr, resolveHost)
+	case *ast.DoNode:
+		typ = InferType(sub.Ret, resolveHost)
+	case *ast.LetNode:
+		typ = InferType(sub.Body, resolveHost)
+	case *ast.WithMetaNode:
+		typ = InferType(sub.Expr, resolveHost)
+	case *ast.IfNode:
+		if then := InferType(sub.Then, resolveHost); then != nil &&
+			then == InferType(sub.Else, resolveHost) {
+			typ = then
+		}
+	}
+	if typ != nil && typ.Kind() == reflect.Interface && typ.NumMethod() == 0 {
+		return nil
+	}
+	return typ
+}
+
+func formTag(form interface{}) interface{} {
+	obj, ok := form.(lang.IMeta)
+	if !ok {
+		return nil
+	}
+	return lang.Get(obj.Meta(), lang.KWTag)
+}
+
+// tagType returns the type a type hint names. A hint is a symbol such
+// as go/int64, strings.Builder or clojure.lang.ISeq, or, in metadata
+// that was evaluated, the type itself.
+func tagType(tag interface{}, resolveHost func(sym *lang.Symbol) (interface{}, bool)) reflect.Type {
+	sym, ok := tag.(*lang.Symbol)
+	if !ok {
+		return hostType(tag)
+	}
+	if sym.Namespace() == "go" {
+		return lang.BuiltinTypes[sym.Name()]
+	}
+	if resolveHost == nil {
+		return nil
+	}
+	if value, ok := resolveHost(sym); ok {
+		return hostType(value)
+	}
+	if name := sym.FullName(); strings.HasPrefix(name, "clojure.lang.") {
+		if value, ok := resolveHost(lang.NewSymbol(
+			"github.com:glojurelang:glojure:pkg:lang." + strings.TrimPrefix(name, "clojure.lang."),
+		)); ok {
+			return hostType(value)
+		}
+	}
+	return nil
+}
+
+func hostType(value interface{}) reflect.Type {
+	switch value := value.(type) {
+	case *lang.Class:
+		return value.Type
+	case reflect.Type:
+		return value
+	}
+	return nil
+}
+
+// hostMemberType returns the type of the values of the member name of
+// the values of typ: the result of a method called with nargs
+// arguments or, if field is true, a field. Method names are found as
+// reflection finds them, with the first letter upper-cased.
+func hostMemberType(typ reflect.Type, name string, nargs int, field bool) reflect.Type {
+	if typ == nil || name == "" {
+		return nil
+	}
+	name = strings.ToUpper(name[:1]) + name[1:]
+	if nargs >= 0 {
+		if method, ok := typ.MethodByName(name); ok {
+			receiver := 1
+			if typ.Kind() == reflect.Interface {
+				receiver = 0
+			}
+			return funcResultType(method.Type, receiver, nargs)
+		}
+	}
+	if !field {
+		return nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	if f, ok := typ.FieldByName(name); ok {
+		return f.Type
+	}
+	return nil
+}
+
+// funcResultType returns the type of the result of calling a Go
+// function of type fn, whose first skip parameters are already bound,
+// with nargs arguments, if it returns one value.
+func funcResultType(fn reflect.Type, skip, nargs int) reflect.Type {
+	if fn == nil || fn.Kind() != reflect.Func || fn.Implements(ifnType) || fn.NumOut() != 1 {
+		return nil
+	}
+	params := fn.NumIn() - skip
+	if params != nargs && !(fn.IsVariadic() && nargs >= params-1) {
+		return nil
+	}
+	return fn.Out(0)
+}
@@ -0,0 +1,216 @@
+// Package format formats Glojure source the way cljfmt does with its
+// default settings.
+//
+// Formatting reads the source with the reader's lossless concrete
+// syntax tree mode, so comments, discarded forms, reader macros and the
+// spelling of literals are kept. It changes only whitespace:
+//
+//   - lines are reindented by cljfmt's indentation rules, described at
+//     indentRules;
+//   - whitespace after an opening delimiter and before a closing one is
+//     removed, unless a comment must end the line;
+//   - a space is inserted between elements that touch;
+//   - trailing whitespace is removed, and runs of blank lines are
+//     collapsed to one.
+package format
+
+import (
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// Source formats src. It returns an error if src cannot be read.
+func Source(src string) (string, error) {
+	root, err := reader.ReadCST(strings.NewReader(src))
+	if err != nil {
+		return "", err
+	}
+	out := Node(root)
+	if strings.HasSuffix(strings.TrimRight(src, " \t,"), "\n") && out != "" {
+		out += "\n"
+	}
+	return out, nil
+}
+
+// Node formats the source of n, which may be a NodeFile or a single
+// form. Whitespace before the first and after the last form of a
+// NodeFile is dropped.
+func Node(n *reader.Node) string {
+	var f formatter
+	if n.Kind == reader.NodeFile {
+		f.children(n.Children, nil)
+	} else {
+		f.element(n, nil)
+	}
+	return f.out.String()
+}
+
+type formatter struct {
+	out strings.Builder
+	col int // column of the next rune, from 0
+	// lineHasText is whether anything but indentation has been written
+	// to the current line.
+	lineHasText bool
+}
+
+// A coll is a collection being written.
+type coll struct {
+	node   *reader.Node
+	col    int // column of the opening delimiter
+	parent *coll
+	// index is the position of the collection among the elements of
+	// parent.
+	index int
+	elems []elem
+}
+
+// elem is where an element of a collection was written.
+type elem struct {
+	col         int
+	firstInLine bool
+}
+
+// head returns the symbol that heads a list, without its namespace, or
+// "".
+func (c *coll) head() string {
+	if c == nil || c.node.Kind != reader.NodeList {
+		return ""
+	}
+	for _, child := range c.node.Children {
+		if !child.IsForm() {
+			continue
+		}
+		if child.Kind != reader.NodeToken || strings.HasPrefix(child.Text, ":") {
+			return ""
+		}
+		name := child.Text
+		if i := strings.LastIndexByte(name, '/'); i > 0 && i < len(name)-1 {
+			name = name[i+1:]
+		}
+		return name
+	}
+	return ""
+}
+
+func (f *formatter) write(s string) {
+	f.out.WriteString(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		f.col = len([]rune(s[i+1:]))
+		f.lineHasText = strings.TrimLeft(s[i+1:], " ") != ""
+		return
+	}
+	f.col += len([]rune(s))
+	if strings.TrimLeft(s, " ") != "" {
+		f.lineHasText = true
+	}
+}
+
+// newline writes ws, whitespace holding a newline, with the next line
+// indented by indent columns. Commas before the newline are kept, and at
+// most one blank line.
+func (f *formatter) newline(ws string, indent int) {
+	commas := strings.Trim(ws[:strings.IndexByte(ws, '\n')], " \t")
+	n := min(strings.Count(ws, "\n"), 2)
+	f.write(commas + strings.Repeat("\n", n) + strings.Repeat(" ", indent))
+}
+
+// children writes the children of a collection c, or the top-level
+// nodes when c is nil.
+func (f *formatter) children(children []*reader.Node, c *coll) {
+	var prev *reader.Node
+	for i, child := range children {
+		switch child.Kind {
+		case reader.NodeWhitespace:
+			last := true
+			for _, next := range children[i+1:] {
+				if next.Kind != reader.NodeWhitespace {
+					last = false
+					break
+				}
+			}
+			afterComment := prev != nil && prev.Kind == reader.NodeComment
+			switch {
+			case prev == nil:
+				// Whitespace after an opening delimiter or before the first
+				// top-level form.
+			case last && (c == nil || !afterComment):
+				// Whitespace before a closing delimiter or after the last
+				// top-level form.
+			case strings.Contains(child.Text, "\n"):
+				f.newline(child.Text, f.indent(c, len(elemsOf(c))))
+			default:
+				f.write(child.Text)
+			}
+		case reader.NodeComment:
+			if prev != nil && prev.Kind != reader.NodeWhitespace {
+				f.write(" ")
+			}
+			f.write(strings.TrimRight(child.Text, " \t"))
+		default:
+			if prev != nil && prev.Kind != reader.NodeWhitespace {
+				f.write(" ")
+			}
+			f.element(child, c)
+		}
+		prev = child
+	}
+}
+
+func elemsOf(c *coll) []elem {
+	if c == nil {
+		return nil
+	}
+	return c.elems
+}
+
+// element writes an element of c, or a top-level form when c is nil.
+func (f *formatter) element(n *reader.Node, c *coll) {
+	index := 0
+	if c != nil {
+		index = len(c.elems)
+		c.elems = append(c.elems, elem{col: f.col, firstInLine: !f.lineHasText})
+	}
+	f.node(n, c, index)
+}
+
+// node writes n, the element of c at index.
+func (f *formatter) node(n *reader.Node, c *coll, index int) {
+	switch {
+	case n.IsCollection():
+		inner := &coll{node: n, col: f.col, parent: c, index: index}
+		f.write(n.Text)
+		f.children(n.Children, inner)
+		f.write(n.Close())
+	case len(n.Children) > 0:
+		// A reader macro: its forms are written where it stands in c. A
+		// line starting the target of metadata is indented like the
+		// element after it, as cljfmt treats ^meta name as one argument.
+		f.write(n.Text)
+		at := index
+		if n.Kind == reader.NodeMeta {
+			at = index + 1
+		}
+		var prev *reader.Node
+		for _, child := range n.Children {
+			switch child.Kind {
+			case reader.NodeWhitespace:
+				if strings.Contains(child.Text, "\n") {
+					f.newline(child.Text, f.indent(c, at))
+				} else {
+					f.write(child.Text)
+				}
+			case reader.NodeComment:
+				f.write(strings.TrimRight(child.Text, " \t"))
+			default:
+				if prev != nil && prev.Kind != reader.NodeWhitespace {
+					f.write(" ")
+				}
+				f.node(child, c, index)
+			}
+			prev = child
+		}
+	default:
+		f.write(n.Text)
+	}
+}
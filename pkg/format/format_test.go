@@ -0,0 +1,172 @@
+package format_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/format"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{
+			name: "calls align with the first argument",
+			src:  "(foo bar\nbaz\n  qux)",
+			want: "(foo bar\n     baz\n     qux)",
+		},
+		{
+			name: "calls with the first argument on its own line",
+			src:  "(foo\nbar\nbaz)",
+			want: "(foo\n bar\n baz)",
+		},
+		{
+			name: "collections",
+			src:  "[1\n2]\n{:a 1\n:b 2}\n#{1\n2}",
+			want: "[1\n 2]\n{:a 1\n :b 2}\n#{1\n  2}",
+		},
+		{
+			name: "block bodies",
+			src:  "(let [x 1\ny 2]\n(+ x y))\n(if a\nb\nc)\n(if a b\nc)",
+			want: "(let [x 1\n      y 2]\n  (+ x y))\n(if a\n  b\n  c)\n(if a b\n    c)",
+		},
+		{
+			name: "inner bodies",
+			src:  "(defn f\n\"doc\"\n[x]\nx)\n(with-foo a\nb)\n(fn [x]\nx)",
+			want: "(defn f\n  \"doc\"\n  [x]\n  x)\n(with-foo a\n  b)\n(fn [x]\n  x)",
+		},
+		{
+			name: "nested inner bodies",
+			src:  "(defprotocol P\n(m [x]\n\"doc\"))\n(letfn [(f [x]\nx)]\n(f 1))\n(reify P\n(m [x]\nx))",
+			want: "(defprotocol P\n  (m [x]\n    \"doc\"))\n(letfn [(f [x]\n          x)]\n  (f 1))\n(reify P\n  (m [x]\n    x))",
+		},
+		{
+			name: "qualified heads",
+			src:  "(clojure.core/let [x 1]\nx)",
+			want: "(clojure.core/let [x 1]\n  x)",
+		},
+		{
+			name: "surrounding whitespace",
+			src:  "( foo  bar \n )\n[ 1 2 ]",
+			want: "(foo  bar)\n[1 2]",
+		},
+		{
+			name: "comments",
+			src:  "(foo ; trailing   \n;; own line\nbar ; last\n)",
+			want: "(foo ; trailing\n ;; own line\n bar ; last\n )",
+		},
+		{
+			name: "missing whitespace",
+			src:  "(foo(bar)[baz]\"s\")",
+			want: "(foo (bar) [baz] \"s\")",
+		},
+		{
+			name: "blank lines",
+			src:  "\n\n(a)\n\n\n\n(b)   \n\n\n",
+			want: "(a)\n\n(b)\n",
+		},
+		{
+			name: "literals keep their spelling",
+			src:  "(foo 0x1F\n1e3 \\space #\"a\\d\"\n\"multi\n   line\" ##Inf)",
+			want: "(foo 0x1F\n     1e3 \\space #\"a\\d\"\n     \"multi\n   line\" ##Inf)",
+		},
+		{
+			name: "reader macros",
+			src:  "(foo '(a\nb) #_(x\ny) ^:k\nbar\n@(d\ne))",
+			want: "(foo '(a\n       b) #_(x\n             y) ^:k\n     bar\n     @(d\n       e))",
+		},
+		{
+			name: "metadata and its target are one argument",
+			src:  "(ns ^{:doc \"d\"\n:author \"a\"}\nclojure.core)\n(foo ^{:a 1}\nbar\nbaz)",
+			want: "(ns ^{:doc \"d\"\n      :author \"a\"}\n  clojure.core)\n(foo ^{:a 1}\n     bar\n     baz)",
+		},
+		{
+			name: "commas before newlines",
+			src:  "{:a 1,\n:b 2}",
+			want: "{:a 1,\n :b 2}",
+		},
+		{
+			name: "function literals and reader conditionals",
+			src:  "#(foo\n%)\n#?(:clj a\n:glj b)",
+			want: "#(foo\n  %)\n#?(:clj a\n   :glj b)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := format.Source(test.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("Source(%q) =\n%s\nwant\n%s", test.src, got, test.want)
+			}
+			again, err := format.Source(got)
+			if err != nil || again != got {
+				t.Errorf("formatting is not idempotent: %q", again)
+			}
+		})
+	}
+
+	if _, err := format.Source("(a [b)"); err == nil {
+		t.Error("expected an error for unbalanced source")
+	}
+}
+
+// TestStdlib formats the standard library, checking that formatting
+// changes only whitespace and settles after one pass.
+func TestStdlib(t *testing.T) {
+	paths, err := filepath.Glob("../stdlib/clojure/*.glj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := format.Source(string(data))
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if again, _ := format.Source(formatted); again != formatted {
+			t.Errorf("%s: formatting is not idempotent", path)
+		}
+		if a, b := tokens(t, string(data)), tokens(t, formatted); a != b {
+			t.Errorf("%s: formatting changed more than whitespace", path)
+		}
+	}
+}
+
+// tokens returns the text of src without whitespace, except within
+// strings, regexes and comments.
+func tokens(t *testing.T, src string) string {
+	root, err := reader.ReadCST(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	var walk func(n *reader.Node)
+	walk = func(n *reader.Node) {
+		switch n.Kind {
+		case reader.NodeWhitespace:
+			b.WriteString(strings.Trim(n.Text, " \t\n"))
+			return
+		case reader.NodeComment:
+			b.WriteString(strings.TrimRight(n.Text, " \t"))
+		default:
+			b.WriteString(n.Text)
+		}
+		b.WriteString("\x00")
+		for _, child := range n.Children {
+			walk(child)
+		}
+		b.WriteString(n.Close())
+	}
+	walk(root)
+	return b.String()
+}
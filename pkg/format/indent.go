@@ -0,0 +1,158 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// An indentRule says how to indent the lines of lists headed by a
+// symbol. The rules are cljfmt's:
+//
+//   - A block rule with index i indents the body of the list two columns
+//     past its opening parenthesis when the element after the first i
+//     arguments starts a line, as in a let whose body follows its
+//     bindings; otherwise the list is indented like a call.
+//   - An inner rule with depth d indents two columns the lists d levels
+//     inside the list, and the list itself when d is 0. If index is
+//     set, only the lists within the argument at that index are.
+//
+// Lists no rule covers are indented like calls: lines align with the
+// first argument, or with the head when the first argument is on a line
+// of its own.
+type indentRule struct {
+	block bool
+	depth int
+	index int // -1 if any
+}
+
+func block(index int) indentRule      { return indentRule{block: true, index: index} }
+func inner(depth int) indentRule      { return indentRule{depth: depth, index: -1} }
+func innerAt(depth, i int) indentRule { return indentRule{depth: depth, index: i} }
+
+// indentRules are cljfmt's default rules for the symbols of
+// clojure.core and clojure.test. Symbols starting with def or with- not
+// listed here follow inner(0).
+var indentRules = map[string][]indentRule{
+	"are":                  {block(2)},
+	"as->":                 {block(2)},
+	"binding":              {block(1)},
+	"bound-fn":             {inner(0)},
+	"case":                 {block(1)},
+	"catch":                {block(2)},
+	"comment":              {block(0)},
+	"cond->":               {block(1)},
+	"cond->>":              {block(1)},
+	"condp":                {block(2)},
+	"def":                  {inner(0)},
+	"defprotocol":          {block(1), inner(1)},
+	"defrecord":            {block(2), inner(1)},
+	"defstruct":            {block(1)},
+	"deftype":              {block(2), inner(1)},
+	"do":                   {block(0)},
+	"doseq":                {block(1)},
+	"dotimes":              {block(1)},
+	"doto":                 {block(1)},
+	"extend":               {block(1)},
+	"extend-protocol":      {block(1), inner(1)},
+	"extend-type":          {block(1), inner(1)},
+	"finally":              {block(0)},
+	"fn":                   {inner(0)},
+	"for":                  {block(1)},
+	"future":               {block(0)},
+	"go":                   {block(0)},
+	"go-loop":              {block(1)},
+	"if":                   {block(1)},
+	"if-let":               {block(1)},
+	"if-not":               {block(1)},
+	"if-some":              {block(1)},
+	"let":                  {block(1)},
+	"letfn":                {block(1), innerAt(2, 0)},
+	"locking":              {block(1)},
+	"loop":                 {block(1)},
+	"ns":                   {block(1)},
+	"proxy":                {block(2), inner(1)},
+	"reify":                {inner(0), inner(1)},
+	"struct-map":           {block(1)},
+	"testing":              {block(1)},
+	"thread":               {block(0)},
+	"try":                  {block(0)},
+	"use-fixtures":         {inner(0)},
+	"when":                 {block(1)},
+	"when-first":           {block(1)},
+	"when-let":             {block(1)},
+	"when-not":             {block(1)},
+	"when-some":            {block(1)},
+	"while":                {block(1)},
+	"with-local-vars":      {block(1)},
+	"with-open":            {block(1)},
+	"with-out-str":         {block(0)},
+	"with-precision":       {block(1)},
+	"with-redefs":          {block(1)},
+	"with-redefs-fn":       {block(1)},
+	"with-bindings":        {block(1)},
+	"with-in-str":          {block(1)},
+	"with-loading-context": {block(0)},
+}
+
+func rulesFor(head string) []indentRule {
+	if rules, ok := indentRules[head]; ok {
+		return rules
+	}
+	if strings.HasPrefix(head, "def") || strings.HasPrefix(head, "with-") {
+		return []indentRule{inner(0)}
+	}
+	return nil
+}
+
+// indent returns the column at which to start a line of c whose first
+// element is the one at index.
+func (f *formatter) indent(c *coll, index int) int {
+	if c == nil {
+		return 0
+	}
+	if c.node.Kind != reader.NodeList && c.node.Kind != reader.NodeFn {
+		// Reader conditionals align their features like a map's keys.
+		return c.col + len([]rune(c.node.Text))
+	}
+
+	// The nearest list whose rules apply decides. path is the list, at
+	// depth, that holds c.
+	path := c
+	for top, depth := c, 0; top != nil && depth <= 2; top, depth = top.parent, depth+1 {
+		for _, rule := range rulesFor(top.head()) {
+			switch {
+			case rule.block && depth == 0:
+				if index > rule.index && c.startsLine(rule.index+1, index) {
+					return c.col + 2
+				}
+				return listIndent(c, index)
+			case !rule.block && rule.depth == depth:
+				if rule.index < 0 || depth > 0 && path.index == rule.index+1 {
+					return c.col + 2
+				}
+			}
+		}
+		path = top
+	}
+	return listIndent(c, index)
+}
+
+// startsLine reports whether the element of c at i starts a line when
+// the element at current is being indented.
+func (c *coll) startsLine(i, current int) bool {
+	if i >= len(c.elems) || i == current {
+		return true
+	}
+	return c.elems[i].firstInLine
+}
+
+// listIndent indents the element of c at index like an argument of a
+// call: aligned with the first argument, or one column past the opening
+// parenthesis.
+func listIndent(c *coll, index int) int {
+	if index > 1 && len(c.elems) > 1 {
+		return c.elems[1].col
+	}
+	return c.col + len([]rune(c.node.Text))
+}
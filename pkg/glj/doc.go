@@ -1,3 +1,5 @@
 // Package glj provides a minimal interface to bootstrap Glojure
-// access from Go.
+// access from Go. Call calls Glojure functions with typed results, and
+// Register and DefineNamespace expose Go functions and values to
+// Glojure code.
 package glj
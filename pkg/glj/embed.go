@@ -0,0 +1,103 @@
+package glj
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// Call calls fn, an IFn such as one returned by Var or a Go function,
+// with args and returns its value as a T. Call returns an error if the
+// call panics or if its value can't be converted to a T.
+func Call[T any](fn any, args ...any) (res T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = runtime.PanicError(r)
+		}
+	}()
+	val := lang.Apply(fn, args)
+	if res, ok := val.(T); ok {
+		return res, nil
+	}
+	out := reflect.ValueOf(&res).Elem()
+	converted, err := lang.CoerceGoValue(out.Type(), val)
+	if err != nil {
+		return res, fmt.Errorf("result of %v: %w", fn, err)
+	}
+	out.Set(converted)
+	return res, nil
+}
+
+// A Doc is a docstring for a function defined by Register.
+type Doc string
+
+// Register defines the var named name in the namespace ns, creating them
+// if they don't exist, as a function that calls the Go functions fns,
+// one for each arity, and returns the var. Arguments and results are
+// converted as described for lang.NewGoFunc; in particular, a function
+// whose last result is a non-nil error throws it. One of fns may be a
+// Doc, which becomes the var's :doc. The var's :arglists describe the
+// Go functions' parameters.
+//
+// Within Runtime.Run, Register defines the var in the runtime.
+func Register(ns, name string, fns ...any) (*lang.Var, error) {
+	var doc Doc
+	goFns := make([]any, 0, len(fns))
+	for _, fn := range fns {
+		if d, ok := fn.(Doc); ok {
+			doc = d
+			continue
+		}
+		goFns = append(goFns, fn)
+	}
+	fn, err := lang.NewGoFunc(goFns...)
+	if err != nil {
+		return nil, fmt.Errorf("registering %s/%s: %w", ns, name, err)
+	}
+	meta := fn.Meta().Assoc(lang.KWName, lang.NewSymbol(name)).(lang.IPersistentMap)
+	if doc != "" {
+		meta = meta.Assoc(lang.KWDoc, string(doc)).(lang.IPersistentMap)
+	}
+	vr := lang.InternVarName(lang.NewSymbol(ns), lang.NewSymbol(name))
+	vr.BindRoot(fn)
+	vr.SetMeta(meta)
+	return vr, nil
+}
+
+// DefineNamespace defines the namespace name with a var for each entry
+// of vars, and marks it loaded, so that scripts can require it to use
+// services the program provides. Go functions are defined as by
+// Register; other values, including IFns, are the vars' values.
+//
+// Within Runtime.Run, DefineNamespace defines the namespace in the
+// runtime.
+func DefineNamespace(name string, vars map[string]any) (*lang.Namespace, error) {
+	nsSym := lang.NewSymbol(name)
+	ns := lang.FindOrCreateNamespace(nsSym)
+	names := make([]string, 0, len(vars))
+	for varName := range vars {
+		names = append(names, varName)
+	}
+	sort.Strings(names)
+	for _, varName := range names {
+		val := vars[varName]
+		if _, isFn := val.(lang.IFn); !isFn && reflect.ValueOf(val).Kind() == reflect.Func {
+			if _, err := Register(name, varName, val); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		ns.Intern(lang.NewSymbol(varName)).BindRoot(val)
+	}
+
+	loadedLibs := lang.NSCore.FindInternedVar(lang.NewSymbol("*loaded-libs*")).Deref().(*lang.Ref)
+	lang.LockingTransaction.RunInTransaction(lang.FnFunc(func(...any) any {
+		return loadedLibs.Commute(lang.FnFunc1(func(libs any) any {
+			return libs.(lang.IPersistentSet).Cons(nsSym)
+		}), nil)
+	}))
+	return ns, nil
+}
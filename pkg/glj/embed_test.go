@@ -0,0 +1,72 @@
+package glj
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+func TestCall(t *testing.T) {
+	n, err := Call[int](Var("clojure.core", "+"), 1, 2, 3)
+	if err != nil || n != 6 {
+		t.Errorf("Call[int] = %v, %v; want 6", n, err)
+	}
+	s, err := Call[string](Var("clojure.core", "str"), "a", 1)
+	if err != nil || s != "a1" {
+		t.Errorf("Call[string] = %q, %v; want a1", s, err)
+	}
+	v, err := Call[any](Var("clojure.core", "first"), Read("[]"))
+	if err != nil || v != nil {
+		t.Errorf("Call[any] = %v, %v; want nil", v, err)
+	}
+
+	if _, err := Call[int](Var("clojure.core", "str"), "a"); err == nil {
+		t.Error("Call[int] of a string result succeeded")
+	}
+	_, err = Call[any](Var("clojure.core", "/"), 1, 0)
+	if err == nil || !strings.Contains(err.Error(), "divide by zero") {
+		t.Errorf("Call of (/ 1 0) error = %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	errEmpty := errors.New("empty name")
+	vr, err := Register("glj.test.embed", "greet",
+		Doc("Greets name."),
+		func(name string) (string, error) {
+			if name == "" {
+				return "", errEmpty
+			}
+			return "hello, " + name, nil
+		},
+		func(greeting, name string) string { return greeting + ", " + name },
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lang.PrintString(vr.Meta().ValAt(lang.KWArglists)); got != "([a] [a b])" {
+		t.Errorf(":arglists = %s", got)
+	}
+	if got := vr.Meta().ValAt(lang.KWDoc); got != "Greets name." {
+		t.Errorf(":doc = %v", got)
+	}
+
+	if got, err := Call[string](vr, "gopher"); err != nil || got != "hello, gopher" {
+		t.Errorf("arity 1 = %q, %v", got, err)
+	}
+	if got, err := Call[string](vr, "hi", "gopher"); err != nil || got != "hi, gopher" {
+		t.Errorf("arity 2 = %q, %v", got, err)
+	}
+	if _, err := Call[string](vr, ""); !errors.Is(err, errEmpty) {
+		t.Errorf("error result = %v, want %v", err, errEmpty)
+	}
+	if _, err := Call[string](vr, "a", "b", "c"); err == nil {
+		t.Error("call with no matching arity succeeded")
+	}
+
+	if _, err := Register("glj.test.embed", "bad", "not a function"); err == nil {
+		t.Error("Register of a non-function succeeded")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
 )
 
 func TestGLJ(t *testing.T) {
@@ -76,3 +78,36 @@ func TestFnQmarkRecognizesFnFuncN(t *testing.T) {
 		}
 	}
 }
+
+func TestStackTraceOfCompiledCode(t *testing.T) {
+	_, err := NewRuntime(RuntimeOptions{}).Eval(`(sort (fn [a b] (throw (ex-info "boom" {}))) [2 1])`)
+	if err == nil {
+		t.Fatal("sort with a throwing comparator succeeded")
+	}
+	for _, frame := range lang.StackTrace(err) {
+		if frame.QualifiedName() == "clojure.core/sort" && frame.Filename == "clojure/core.glj" && frame.Line > 0 {
+			return
+		}
+	}
+	t.Errorf("stack trace %v has no clojure.core/sort frame", lang.StackTrace(err))
+}
+
+func TestGetStackTraceOfCaughtException(t *testing.T) {
+	runtime.AddLoadPath(fstest.MapFS{"st.glj": {Data: []byte(`(ns st)
+(defn inner [] (throw (ex-info "boom" {})))
+(defn outer []
+  (inner))
+`)}})
+	got, err := NewRuntime(RuntimeOptions{}).Eval(`(require 'st)
+(try (st/outer)
+  (catch go/error e
+    (mapv (juxt #(.getClassName %) #(.getMethodName %) #(.getFileName %) #(.getLineNumber %) str)
+          (.getStackTrace e))))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[["st/inner" "invoke" "st.glj" 2 "st/inner.invoke(st.glj:2)"] ["st/outer" "invoke" "st.glj" 4 "st/outer.invoke(st.glj:4)"]]`
+	if s := lang.PrintString(got); s != want {
+		t.Errorf("stack trace elements = %s, want %s", s, want)
+	}
+}
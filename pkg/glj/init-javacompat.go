@@ -16,6 +16,7 @@ import (
 	_ "github.com/glojurelang/glojure/pkg/javacompat/mapentry"
 	_ "github.com/glojurelang/glojure/pkg/javacompat/math"
 	_ "github.com/glojurelang/glojure/pkg/javacompat/regex"
+	_ "github.com/glojurelang/glojure/pkg/javacompat/stacktrace"
 	_ "github.com/glojurelang/glojure/pkg/javacompat/streams"
 	_ "github.com/glojurelang/glojure/pkg/javacompat/string"
 	_ "github.com/glojurelang/glojure/pkg/javacompat/stringbuilder"
@@ -0,0 +1,6 @@
+//go:build !glj_aot_runtime
+
+package glj
+
+// Register the reloading implementation behind glojure.tools.namespace.
+import _ "github.com/glojurelang/glojure/pkg/stdlib/glojure/tools/namespace"
@@ -0,0 +1,6 @@
+//go:build !glj_aot_runtime
+
+package glj
+
+// Register the profiling implementation behind glojure.profile.
+import _ "github.com/glojurelang/glojure/pkg/stdlib/glojure/profile"
@@ -0,0 +1,6 @@
+//go:build !glj_aot_runtime
+
+package glj
+
+// Register the socket server implementation behind clojure.core.server.
+import _ "github.com/glojurelang/glojure/pkg/stdlib/clojure/core/server"
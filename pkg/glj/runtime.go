@@ -0,0 +1,39 @@
+package glj
+
+import (
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// RuntimeOptions configures a Runtime made by NewRuntime.
+type RuntimeOptions = runtime.RuntimeOptions
+
+// A Runtime is an isolated Glojure runtime with its own namespaces,
+// vars and load path. See runtime.Runtime.
+type Runtime struct {
+	rt *runtime.Runtime
+}
+
+// NewRuntime returns a new isolated runtime. Runtimes share clojure.core
+// and the rest of the standard library with the process, and so start
+// quickly, but the namespaces and vars they define are their own.
+func NewRuntime(opts RuntimeOptions) *Runtime {
+	return &Runtime{rt: runtime.NewRuntime(opts)}
+}
+
+// Eval reads and evaluates the forms of code in the runtime, returning
+// the value of the last.
+func (r *Runtime) Eval(code string) (any, error) {
+	return r.rt.ReadEval(code)
+}
+
+// Var returns an IFn that calls the var of the runtime associated with
+// the namespace and name.
+func (r *Runtime) Var(ns, name interface{}) lang.IFn {
+	return r.rt.Bind(r.rt.InternVar(asSym(ns).String(), asSym(name).String()))
+}
+
+// Run calls fn in the runtime. See runtime.Runtime.Run.
+func (r *Runtime) Run(fn func()) {
+	r.rt.Run(fn)
+}
@@ -0,0 +1,193 @@
+//go:build !glj_aot_runtime
+
+package glj
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+func TestRuntimesAreIsolated(t *testing.T) {
+	var out1, out2 bytes.Buffer
+	rt1 := NewRuntime(RuntimeOptions{Stdout: &out1, LoadPath: []fs.FS{fstest.MapFS{
+		"isolated/lib.glj": {Data: []byte(`(ns isolated.lib) (def who "one")`)},
+	}}})
+	rt2 := NewRuntime(RuntimeOptions{Stdout: &out2, LoadPath: []fs.FS{fstest.MapFS{
+		"isolated/lib.glj": {Data: []byte(`(ns isolated.lib) (def who "two")`)},
+	}}})
+
+	eval := func(rt *Runtime, code string) any {
+		t.Helper()
+		v, err := rt.Eval(code)
+		if err != nil {
+			t.Fatalf("Eval(%q): %v", code, err)
+		}
+		return v
+	}
+
+	eval(rt1, `(def x 1) (defn f [] (println "f" x) x)`)
+	eval(rt2, `(def x 2) (defn f [] (println "f" x) x)`)
+	if got := eval(rt1, `(f)`); !lang.Equals(got, 1) {
+		t.Errorf("rt1 (f) = %v, want 1", got)
+	}
+	if got := rt2.Var("user", "f").Invoke(); !lang.Equals(got, 2) {
+		t.Errorf("rt2 user/f = %v, want 2", got)
+	}
+	if out1.String() != "f 1\n" || out2.String() != "f 2\n" {
+		t.Errorf("outputs = %q, %q", out1.String(), out2.String())
+	}
+	if user := lang.FindNamespace(lang.NewSymbol("user")); user != nil && user.FindInternedVar(lang.NewSymbol("f")) != nil {
+		t.Error("runtime defined user/f in the process")
+	}
+
+	// Each runtime loads libs from its own load path.
+	for rt, want := range map[*Runtime]string{rt1: "one", rt2: "two"} {
+		if got := eval(rt, `(require 'isolated.lib) isolated.lib/who`); got != want {
+			t.Errorf("isolated.lib/who = %v, want %v", got, want)
+		}
+	}
+	if lang.FindNamespace(lang.NewSymbol("isolated.lib")) != nil {
+		t.Error("runtime loaded isolated.lib into the process")
+	}
+
+	// clojure.core, and the standard library, is shared.
+	if got := eval(rt1, `(the-ns 'clojure.core)`); got != lang.NSCore {
+		t.Errorf("rt1 clojure.core = %v, want the process's", got)
+	}
+	str1 := eval(rt1, `(require 'clojure.string) (the-ns 'clojure.string)`)
+	str2 := eval(rt2, `(require 'clojure.string) (the-ns 'clojure.string)`)
+	if str1 != str2 {
+		t.Error("runtimes loaded separate clojure.string namespaces")
+	}
+
+	// Futures run in the runtime that starts them.
+	if got := eval(rt2, `@(future (f))`); !lang.Equals(got, 2) {
+		t.Errorf("rt2 future (f) = %v, want 2", got)
+	}
+
+	if _, err := rt1.Eval(`(throw (ex-info "boom" {}))`); err == nil {
+		t.Error("Eval of throw returned no error")
+	}
+}
+
+func TestRuntimeSandbox(t *testing.T) {
+	rt := NewRuntime(RuntimeOptions{
+		Sandbox: compiler.DefaultSandboxPolicy(),
+		LoadPath: []fs.FS{fstest.MapFS{
+			"rules/evil.glj": {Data: []byte(`(ns rules.evil) (defn run [] (os.Getwd))`)},
+		}},
+	})
+
+	// Core macros expand to host interop the sandbox denies to the code
+	// calling them.
+	got, err := rt.Eval(`
+		(ns rules.ok (:require [clojure.string :as str]))
+		(defn shout [s] (str (str/upper-case s) "!"))
+		(let [xs (for [x [1 2 3] :when (odd? x)] x)]
+		  (shout (str (count xs))))`)
+	if err != nil || got != "2!" {
+		t.Fatalf("Eval = %v, %v; want 2!", got, err)
+	}
+
+	for _, tc := range []struct {
+		code   string
+		access string
+		name   string
+	}{
+		{`(os.RemoveAll "/tmp/nope")`, "host", "os.RemoveAll"},
+		{`(os/exec.Command "ls")`, "host", "os/exec.Command"},
+		{`(load "rules/other")`, "var", "clojure.core/load"},
+		{`(#'clojure.core/eval '(+ 1 2))`, "var", "clojure.core/eval"},
+		{`(set! *warn-on-reflection* true)`, "special-form", "set!"},
+		{`(in-ns 'clojure.core) (def map 1)`, "def", "clojure.core/map"},
+		{`(-> "/" os.RemoveAll)`, "host", "os.RemoveAll"},
+		{`(.BindRoot #'clojure.core/odd? (fn [& _] :pwned))`, "member", "*lang.Var.BindRoot"},
+		{`(let [v #'clojure.core/odd?] ((.Deref v) 1))`, "member", "*lang.Var.Deref"},
+		{`(.Intern *ns* 'x)`, "member", "*lang.Namespace.Intern"},
+		{`((.Deref (find-var 'clojure.core/slurp)) "/etc/hostname")`, "var", "clojure.core/find-var"},
+		{`(var-get #'clojure.core/slurp)`, "var", "clojure.core/var-get"},
+		{`(ns-publics 'clojure.core)`, "var", "clojure.core/ns-publics"},
+		{`(ns-interns 'clojure.core)`, "var", "clojure.core/ns-interns"},
+		{`(ns-map 'clojure.core)`, "var", "clojure.core/ns-map"},
+		{`(requiring-resolve 'clojure.core/slurp)`, "var", "clojure.core/requiring-resolve"},
+		{`(with-redefs [odd? even?] (odd? 1))`, "var", "clojure.core/with-redefs"},
+		{`(with-redefs-fn {#'odd? even?} #(odd? 1))`, "var", "clojure.core/with-redefs-fn"},
+		{`(alter-meta! #'clojure.core/odd? assoc :macro true)`, "var", "clojure.core/alter-meta!"},
+		{`(reset-meta! #'clojure.core/odd? {})`, "var", "clojure.core/reset-meta!"},
+		{`((get (ns-refers *ns*) 'slurp) "/etc/hostname")`, "var", "clojure.core/ns-refers"},
+		{`(apply (get (ns-refers *ns*) 'eval) ['(+ 1 2)])`, "var", "clojure.core/ns-refers"},
+		{`((get (ns-refers *ns*) 'alter-var-root) (get (ns-refers *ns*) 'even?) (constantly (fn [_] :pwned)))`, "var", "clojure.core/ns-refers"},
+		{`(vals (ns-aliases *ns*))`, "var", "clojure.core/ns-aliases"},
+		{`(keys (get-thread-bindings))`, "var", "clojure.core/get-thread-bindings"},
+		{`(push-thread-bindings {})`, "var", "clojure.core/push-thread-bindings"},
+		{`(with-bindings {} 1)`, "var", "clojure.core/with-bindings"},
+	} {
+		_, err := rt.Eval(tc.code)
+		var sbErr *compiler.SandboxError
+		if !errors.As(err, &sbErr) {
+			t.Errorf("Eval(%s) = %v, want a sandbox error", tc.code, err)
+			continue
+		}
+		if sbErr.Access.Kind.String() != tc.access || sbErr.Access.Name != tc.name {
+			t.Errorf("Eval(%s) denied %s %s, want %s %s", tc.code, sbErr.Access.Kind, sbErr.Access.Name, tc.access, tc.name)
+		}
+	}
+	rt.Eval(`(in-ns 'user)`)
+	if got, err := rt.Eval(`(.Count [1 2 3])`); err != nil || !lang.Equals(got, 3) {
+		t.Errorf("Eval((.Count [1 2 3])) = %v, %v; want 3", got, err)
+	}
+	// Macros the sandbox trusts still bind vars.
+	if got, err := rt.Eval(`(binding [*print-length* 1] (pr-str [1 2]))`); err != nil || got != "[1 ...]" {
+		t.Errorf("Eval(binding) = %v, %v; want [1 ...]", got, err)
+	}
+
+	// Libs loaded from the runtime's load path are sandboxed too, and
+	// code catching a violation sees it as ex-info.
+	got, err = rt.Eval(`(try (require 'rules.evil) (catch Exception e (ex-data e)))`)
+	if want := `{:type :glojure/sandbox-violation, :access :host, :name "os.Getwd"}`; err != nil || lang.PrintString(got) != want {
+		t.Errorf("ex-data of violation = %v, %v; want %s", lang.PrintString(got), err, want)
+	}
+
+	if got, _ := rt.Eval(`(count (clojure.core/map inc [1 2]))`); !lang.Equals(got, 2) {
+		t.Errorf("clojure.core/map was redefined: %v", got)
+	}
+	if got, _ := NewRuntime(RuntimeOptions{}).Eval(`[(odd? 1) (even? 2)]`); !lang.Equals(got, lang.NewVector(true, true)) {
+		t.Errorf("clojure.core/odd? or even? was rebound: %v", got)
+	}
+}
+
+func TestDefineNamespace(t *testing.T) {
+	var logged []string
+	rt := NewRuntime(RuntimeOptions{})
+	rt.Run(func() {
+		_, err := DefineNamespace("app.service", map[string]any{
+			"log":     func(format string, args ...any) { logged = append(logged, fmt.Sprintf(format, args...)) },
+			"version": "1.2.3",
+			"next":    Var("clojure.core", "inc"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	got, err := rt.Eval(`
+(require '[app.service :as svc])
+(svc/log "%s %d" "started" (svc/next 41))
+svc/version`)
+	if err != nil || got != "1.2.3" {
+		t.Fatalf("script = %v, %v", got, err)
+	}
+	if len(logged) != 1 || logged[0] != "started 42" {
+		t.Errorf("logged %q", logged)
+	}
+	if ns, _ := NewRuntime(RuntimeOptions{}).Eval(`(find-ns 'app.service)`); !lang.IsNil(ns) {
+		t.Error("namespace defined in a runtime is visible in another")
+	}
+}
@@ -0,0 +1,98 @@
+package gljmain
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// stringList collects the values of a repeated flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func parseBuildArgs(args []string) (runtime.BuildOptions, error) {
+	var opts runtime.BuildOptions
+	var resources, roots stringList
+	flags := flag.NewFlagSet("glj build", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&opts.Output, "o", "", "")
+	flags.StringVar(&opts.WorkDir, "work", "", "")
+	flags.StringVar(&opts.GlojureDir, "glojure", "", "")
+	flags.Var(&resources, "resources", "")
+	flags.BoolVar(&opts.Force, "force", false, "")
+	flags.BoolVar(&opts.TreeShake, "tree-shake", false, "")
+	flags.Var(&roots, "keep", "")
+	if err := flags.Parse(args); err != nil {
+		return opts, fmt.Errorf("glj build: %w", err)
+	}
+	if flags.NArg() != 1 {
+		return opts, fmt.Errorf("glj build: expected one entry namespace, got %d arguments", flags.NArg())
+	}
+	opts.Entry = flags.Arg(0)
+	opts.Resources = resources
+	opts.Roots = roots
+	return opts, nil
+}
+
+func runBuild(args []string) {
+	opts, err := parseBuildArgs(args)
+	if errors.Is(err, flag.ErrHelp) {
+		printHelp()
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	report, err := runtime.Build(opts)
+	if report != nil {
+		printBuildReport(os.Stdout, report)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printBuildReport(w io.Writer, report *runtime.BuildReport) {
+	fmt.Fprintf(w, "Compiled %d namespaces (%d unchanged, %d precompiled) into %s\n",
+		len(report.Compiled), len(report.Unchanged), len(report.Precompiled), report.Output)
+	if shake := report.TreeShaking; shake != nil {
+		fmt.Fprintf(w, "Tree shaking omitted %d of %d var initializers (%s of generated Go source)\n",
+			shake.Omitted, shake.Kept+shake.Omitted, formatByteSize(int64(shake.OmittedSourceBytes)))
+		fmt.Fprintf(w, "The executable is %s, %s smaller than without tree shaking (%s)\n",
+			formatByteSize(shake.BinaryBytes), formatByteSize(shake.UnshakenBinaryBytes-shake.BinaryBytes),
+			formatByteSize(shake.UnshakenBinaryBytes))
+	}
+	if len(report.Interpreted) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%d namespaces fell back to the interpreter; linked the full runtime:\n",
+		len(report.Interpreted))
+	for _, ns := range report.Interpreted {
+		fmt.Fprintf(w, "  %s: %v\n", ns.Name, ns.Reason)
+	}
+}
+
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d bytes", n)
+	}
+}
@@ -0,0 +1,107 @@
+//go:build !glj_aot_runtime
+
+package gljmain
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// buildProject writes files into a new project directory, builds its entry
+// namespace with glj build and the given flags, and returns the path of the
+// executable and the build's report.
+func buildProject(t *testing.T, files map[string]string, args ...string) (string, *runtime.BuildReport) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("glj build runs the go tool")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool not found")
+	}
+
+	project := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(project, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runtime.AddLoadPath(os.DirFS(project))
+
+	checkout, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), "app")
+	opts, err := parseBuildArgs(append([]string{"-o", output, "-glojure", checkout}, args...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log strings.Builder
+	opts.Stdout, opts.Stderr = &log, &log
+	report, err := runtime.Build(opts)
+	if err != nil {
+		t.Fatalf("build: %v\n%s", err, log.String())
+	}
+	if len(report.Interpreted) != 0 {
+		t.Fatalf("namespaces fell back to the interpreter: %+v", report.Interpreted)
+	}
+	return output, report
+}
+
+func runProgram(t *testing.T, path string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command(path, args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s: %v\n%s", path, err, out)
+	}
+	return string(out)
+}
+
+func TestBuildRunsProgram(t *testing.T) {
+	app, _ := buildProject(t, map[string]string{
+		"buildtest/util.glj": `(ns buildtest.util (:require [clojure.string :as str]))
+(defn shout [args] (str/join " " (map str/upper-case args)))`,
+		"buildtest/main.glj": `(ns buildtest.main (:require [buildtest.util :as util]))
+(defn -main [& args] (println (util/shout args)))`,
+	}, "buildtest.main")
+
+	if got := runProgram(t, app, "a", "b"); got != "A B\n" {
+		t.Fatalf("output = %q, want %q", got, "A B\n")
+	}
+}
+
+func TestBuildTreeShakenProgram(t *testing.T) {
+	files := map[string]string{
+		"shaketest/main.glj": `(ns shaketest.main (:require [clojure.string :as str]))
+(defmulti greet (fn [lang _] lang))
+(defmethod greet :en [_ who] (str "hello " who))
+(defn -main [& args] (println (greet :en (str/join "," args))))`,
+	}
+	full, _ := buildProject(t, files, "shaketest.main")
+	shaken, report := buildProject(t, files, "-tree-shake", "shaketest.main")
+	shake := report.TreeShaking
+	if shake == nil || shake.Kept == 0 || shake.Omitted == 0 {
+		t.Fatalf("tree shaking report = %+v", shake)
+	}
+	if info, err := os.Stat(shaken); err != nil || info.Size() != shake.BinaryBytes {
+		t.Errorf("reported executable size %d, want the size of %s", shake.BinaryBytes, shaken)
+	}
+	if shake.BinaryBytes >= shake.UnshakenBinaryBytes {
+		t.Errorf("shaken executable is %d bytes, unshaken %d", shake.BinaryBytes, shake.UnshakenBinaryBytes)
+	}
+
+	for _, app := range []string{full, shaken} {
+		if got := runProgram(t, app, "a", "b"); got != "hello a,b\n" {
+			t.Fatalf("%s: output = %q, want %q", app, got, "hello a,b\n")
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package gljmain
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/format"
+)
+
+func runFmt(args []string) {
+	flags := flag.NewFlagSet("glj fmt", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	check := flags.Bool("check", false, "")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		printHelp()
+		return
+	} else if err != nil {
+		log.Fatalf("glj fmt: %v", err)
+	}
+	paths := flags.Args()
+	if len(paths) == 1 && paths[0] == "-" {
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatalf("glj fmt: %v", err)
+		}
+		formatted, err := format.Source(string(src))
+		if err != nil {
+			log.Fatalf("glj fmt: %v", err)
+		}
+		os.Stdout.WriteString(formatted)
+		return
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := sourceFiles(paths)
+	if err != nil {
+		log.Fatalf("glj fmt: %v", err)
+	}
+
+	failed := false
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatalf("glj fmt: %v", err)
+		}
+		formatted, err := format.Source(string(src))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+			failed = true
+			continue
+		}
+		if formatted == string(src) {
+			continue
+		}
+		if *check {
+			fmt.Println(file)
+			failed = true
+			continue
+		}
+		if err := os.WriteFile(file, []byte(formatted), 0o644); err != nil {
+			log.Fatalf("glj fmt: %v", err)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// sourceFiles returns the files named by paths and the .glj, .clj and
+// .cljc files under the directories among them, skipping directories
+// whose names start with a dot.
+func sourceFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch {
+			case p == path && !d.IsDir():
+				files = append(files, p)
+			case d.IsDir() && p != path && strings.HasPrefix(d.Name(), "."):
+				return filepath.SkipDir
+			case !d.IsDir() && (strings.HasSuffix(p, ".glj") || strings.HasSuffix(p, ".clj") || strings.HasSuffix(p, ".cljc")):
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -2,12 +2,14 @@ package gljmain
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	// bootstrap the runtime
 	_ "github.com/glojurelang/glojure/pkg/glj"
@@ -15,13 +17,19 @@ import (
 	"github.com/glojurelang/glojure/pkg/lang"
 	"github.com/glojurelang/glojure/pkg/reader"
 	"github.com/glojurelang/glojure/pkg/runtime"
+	"github.com/glojurelang/glojure/pkg/stdlib/clojure/core/server"
+	"github.com/glojurelang/glojure/pkg/stdlib/glojure/profile"
+	"github.com/glojurelang/glojure/pkg/stdlib/glojure/tools/namespace"
 )
 
+// watchInterval is how often glj --watch scans for changed files.
+const watchInterval = 500 * time.Millisecond
+
 // InteractiveCommands supplies the optional terminal and network REPL
 // commands. Programs that need them should import pkg/gljmain/interactive.
 type InteractiveCommands interface {
 	StartREPL()
-	StartNREPL(arg string)
+	StartNREPL(args []string)
 	StartSREPL(arg string)
 	ConnectNREPL(args []string)
 	Color()
@@ -52,20 +60,89 @@ func printHelp() {
 	fmt.Printf(`Glojure v%s
 
 Usage: glj [options] [file]
+       glj build [build options] NAMESPACE
+       glj fmt [-check] [path...|-]
+       glj lint [-format human|edn|json] [path...]
+       glj lsp
 
 Options:
   -Sdeps <edn>          Merge inline deps data after the project deps.edn
+  --watch                Reload namespaces when their files change, with
+                         their dependents (before other options)
+  --cpuprofile <file>    Write a CPU profile labeled with Glojure fns to
+                         file when glj exits (before other options)
+  --memprofile <file>    Write a heap profile to file when glj exits
+                         (before other options)
   -e <expr>              Evaluate expression from command line
   --nrepl[=VALUE]        Start nREPL server
-  --nrepl-connect H:P    Connect REPL to nREPL server
+  --nrepl-connect ADDR   Connect REPL to nREPL server at HOST:PORT or
+                         unix:PATH
   --srepl[=VALUE]        Start socket REPL server
   --color                Syntax highlight stdin with ANSI colors
   -h, --help             Show this help message
   --version              Show version information
 
+nREPL options (after --nrepl or --nrepl-connect ADDR):
+  --transport bencode|edn
+                         Message framing (default: bencode)
+  --socket <path>        Listen on a unix domain socket instead of TCP
+  --tls-cert <file>      Use TLS with this certificate (with --tls-key)
+  --tls-key <file>       Private key for --tls-cert
+  --tls-ca <file>        Servers require client certificates signed by
+                         these CAs; clients verify the server against them
+  --history <file>       REPL history file (--nrepl-connect only)
+
+Build options:
+  -o <file>              Write the executable to file (default: first
+                         segment of NAMESPACE)
+  -work <dir>            Keep the generated Go module in dir; later builds
+                         reuse its loaders for unchanged namespaces
+  -force                 Regenerate every loader, even if up to date
+  -glojure <dir>         Build against a local Glojure checkout
+  -resources <dir>       Embed dir and add it to the load path (repeatable)
+  -tree-shake            Omit vars the program cannot reach from its -main
+  -keep <ns/name|ns>     Keep a var or namespace when tree shaking, such as
+                         one found with resolve (repeatable)
+
+glj fmt reformats source files, and the .glj, .clj and .cljc files in
+directories, in place by cljfmt's default rules. With -check it only
+lists the files that are not formatted, exiting with status 1 if any
+are. The path - formats stdin to stdout.
+
+glj lint analyzes source files, and the .glj, .clj and .cljc files in
+directories, without running their top-level forms, reporting
+unresolved symbols, wrong-arity calls, unused bindings and requires,
+shadowed and redefined vars, misplaced docstrings and recur outside
+tail position. It exits with status 1 if anything is found. Precede a
+form with #_:glj/ignore to silence findings within it.
+
+glj lsp serves the Language Server Protocol on stdin and stdout for
+editors, analyzing open files for diagnostics, completion, hover docs,
+definitions, references, symbols and formatting. Directories on
+GLJ_CLASSPATH are searched for references along with the workspace.
+
+With --watch, glj scans the files of the namespaces loaded from the
+load path for changes while the REPL, server or script runs, and
+reloads changed namespaces and those that depend on them in dependency
+order, as glojure.tools.namespace/refresh does.
+
+With --cpuprofile, evaluated functions label their samples with
+glj.fn, the var they were defined as, and glj.source, their file and
+line; e.g. go tool pprof -tagfocus=glj.fn=my.app/handle cpu.pprof.
+The glojure.profile/with-profile macro profiles a single expression.
+
 A deps.edn in the current directory is resolved before evaluating code,
 running a file, or starting a REPL or REPL server.
 
+The analyzed forms of files and of the libs they load from source are
+cached in $XDG_CACHE_HOME/glojure/ast, so that later runs skip reading
+and analyzing them; GLOJURE_AST_CACHE=off disables the cache.
+
+Each GLJ_SERVER_<NAME> environment variable starts a clojure.core.server
+socket server named <name> (lower case), running until glj exits, e.g.
+  GLJ_SERVER_REPL='{:port 5555 :accept clojure.core.server/repl}'
+  GLJ_SERVER_PREPL='{:port 5556 :accept clojure.core.server/io-prepl}'
+
 Examples:
   glj                           # Start REPL
   glj -e "(+ 1 2)"              # Evaluate expression
@@ -74,9 +151,19 @@ Examples:
   glj --nrepl=7888              # Start nREPL on port 7888
   glj --nrepl=0.0.0.0:7888      # Bind to all interfaces
   glj --nrepl=.nrepl-port       # Write port to file
+  glj --nrepl --socket repl.sock --transport edn
+                                # Serve EDN messages on a unix socket
+  glj --nrepl-connect unix:repl.sock --transport edn
+  glj --watch --nrepl           # Start nREPL, reloading changed files
+  glj --cpuprofile cpu.pprof script.glj
+                                # Profile a script
   glj --srepl                   # Start socket REPL on random port
   glj --srepl=7777              # Start socket REPL on port 7777
   glj --color < file.clj         # Syntax highlight Clojure code
+  glj build -o app my.app.main  # Build a standalone executable
+  glj fmt -check src            # List unformatted files under src
+  glj lint src                  # Lint the files under src
+  glj lsp                       # Serve LSP to an editor over stdio
   glj --version                 # Show version
   glj --help                    # Show this help
 
@@ -104,6 +191,47 @@ func splitDepsOption(args []string) (string, []string, error) {
 	return args[1], args[2:], nil
 }
 
+// runOptions are the options that apply to whatever glj runs.
+type runOptions struct {
+	watch      bool
+	cpuProfile string
+	memProfile string
+}
+
+// splitRunOptions removes the leading --watch, --cpuprofile and
+// --memprofile options from args.
+func splitRunOptions(args []string) (runOptions, []string, error) {
+	var opts runOptions
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		var dst *string
+		switch name {
+		case "--watch":
+			if hasValue {
+				return opts, nil, fmt.Errorf("glj: --watch takes no value")
+			}
+			opts.watch = true
+			args = args[1:]
+			continue
+		case "--cpuprofile":
+			dst = &opts.cpuProfile
+		case "--memprofile":
+			dst = &opts.memProfile
+		default:
+			return opts, args, nil
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return opts, nil, fmt.Errorf("glj: %s requires a file", name)
+			}
+			value, args = args[1], args[1:]
+		}
+		*dst = value
+		args = args[1:]
+	}
+	return opts, args, nil
+}
+
 func loadProjectDeps(extraEDN string) {
 	const path = "deps.edn"
 	projectEDN := "{}"
@@ -143,13 +271,39 @@ func Main(args []string) {
 			runtime.AddLoadPath(os.DirFS(path))
 		}
 	}
+	if astCacheEnabled() {
+		if dir, err := runtime.DefaultASTCacheDir(); err == nil {
+			runtime.SetASTCacheDir(dir)
+		}
+	}
 	extraEDN, args, err := splitDepsOption(args)
 	if err != nil {
 		log.Fatal(err)
 	}
+	runOpts, args, err := splitRunOptions(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if runOpts.cpuProfile != "" || runOpts.memProfile != "" {
+		p, err := profile.Start(profile.Options{CPUFile: runOpts.cpuProfile, MemFile: runOpts.memProfile})
+		if err != nil {
+			log.Fatalf("glj: %v", err)
+		}
+		defer func() {
+			if err := p.Stop(); err != nil {
+				log.Fatalf("glj: %v", err)
+			}
+		}()
+	}
 	if usesProjectDeps(args) {
 		loadProjectDeps(extraEDN)
 	}
+	if err := server.StartFromEnv(os.Environ()); err != nil {
+		log.Fatalf("glj: %v", err)
+	}
+	if runOpts.watch {
+		go namespace.Watch(context.Background(), watchInterval, os.Stderr)
+	}
 
 	if len(args) == 0 {
 		// Check if stdin is a terminal
@@ -169,11 +323,11 @@ func Main(args []string) {
 					break
 				}
 				if err != nil {
-					log.Fatal(err)
+					exitWithError(err, lang.PhaseReadSource)
 				}
-				result, err := env.Eval(val)
+				result, err := evalForm(env.Eval, val)
 				if err != nil {
-					log.Fatal(err)
+					exitWithError(err, lang.PhaseExecution)
 				}
 				if !lang.IsNil(result) {
 					fmt.Println(lang.PrintString(result))
@@ -187,7 +341,7 @@ func Main(args []string) {
 		printHelp()
 		return
 	} else if args[0] == "--nrepl" || strings.HasPrefix(args[0], "--nrepl=") {
-		interactiveCommands().StartNREPL(args[0])
+		interactiveCommands().StartNREPL(args)
 		return
 	} else if args[0] == "--srepl" || strings.HasPrefix(args[0], "--srepl=") {
 		interactiveCommands().StartSREPL(args[0])
@@ -220,11 +374,11 @@ func Main(args []string) {
 				break
 			}
 			if err != nil {
-				log.Fatal(err)
+				exitWithError(err, lang.PhaseReadSource)
 			}
-			result, err := env.Eval(val)
+			result, err := evalForm(env.Eval, val)
 			if err != nil {
-				log.Fatal(err)
+				exitWithError(err, lang.PhaseExecution)
 			}
 			lastResult = result
 		}
@@ -232,34 +386,78 @@ func Main(args []string) {
 		if !lang.IsNil(lastResult) {
 			fmt.Println(lang.PrintString(lastResult))
 		}
+	} else if args[0] == "build" {
+		runBuild(args[1:])
+	} else if args[0] == "fmt" {
+		runFmt(args[1:])
+	} else if args[0] == "lint" {
+		runLint(args[1:])
+	} else if args[0] == "lsp" {
+		runLSP(args[1:])
 	} else if strings.HasPrefix(args[0], "-") {
 		log.Fatalf("glj: unknown option: %s\nRun 'glj --help' for usage.", args[0])
 	} else {
 		// Execute file
-		file, err := os.Open(args[0])
+		code, err := os.ReadFile(args[0])
 		if err != nil {
 			log.Fatal(err)
 		}
-		env := lang.GlobalEnv
 
 		core := lang.FindNamespace(lang.NewSymbol("clojure.core"))
 		core.FindInternedVar(lang.NewSymbol("*command-line-args*")).BindRoot(lang.Seq(args[1:]))
 
-		rdr := reader.New(bufio.NewReader(file), reader.WithGetCurrentNS(func() *lang.Namespace {
-			return env.CurrentNamespace()
-		}))
-		for {
-			val, err := rdr.ReadOne()
-			if err == reader.ErrEOF {
-				break
-			}
-			if err != nil {
-				log.Fatal(err)
-			}
-			_, err = env.Eval(val)
-			if err != nil {
-				log.Fatal(err)
-			}
+		if phase, err := runScript(args[0], string(code)); err != nil {
+			exitWithError(err, phase)
+		}
+	}
+}
+
+// runScript reads and evaluates the forms of code, the content of the
+// script at path, returning the phase the first error was raised in and
+// the error.
+func runScript(path, code string) (lang.Keyword, error) {
+	script := runtime.NewScript(path, code)
+	for {
+		val, err := script.ReadOne()
+		if err == reader.ErrEOF {
+			break
 		}
+		if err != nil {
+			return lang.PhaseReadSource, err
+		}
+		if _, err := evalForm(script.Eval, val); err != nil {
+			return lang.PhaseExecution, err
+		}
+	}
+	// The cache only saves time; a script does not fail for want of it.
+	_ = script.Close()
+	return lang.Keyword{}, nil
+}
+
+// astCacheEnabled reports whether GLOJURE_AST_CACHE leaves the AST cache
+// enabled, as it is by default.
+func astCacheEnabled() bool {
+	switch strings.ToLower(os.Getenv("GLOJURE_AST_CACHE")) {
+	case "0", "false", "no", "off":
+		return false
 	}
+	return true
+}
+
+// evalForm evaluates form with eval, returning a panic that escapes
+// evaluation, such as that of a top-level throw, as an error.
+func evalForm(eval func(interface{}) (interface{}, error), form interface{}) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = runtime.PanicError(r)
+		}
+	}()
+	return eval(form)
+}
+
+// exitWithError reports err, raised in phase unless it records its own
+// phase, as clojure.main does and exits.
+func exitWithError(err error, phase lang.Keyword) {
+	fmt.Fprint(os.Stderr, runtime.ErrorString(err, phase))
+	os.Exit(1)
 }
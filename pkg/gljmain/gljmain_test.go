@@ -1,8 +1,14 @@
 package gljmain
 
 import (
+	"errors"
+	"flag"
 	"slices"
+	"strings"
 	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
 )
 
 func TestUsesProjectDeps(t *testing.T) {
@@ -50,3 +56,73 @@ func TestSplitDepsOption(t *testing.T) {
 		}
 	}
 }
+
+func TestParseBuildArgs(t *testing.T) {
+	opts, err := parseBuildArgs([]string{
+		"-o", "bin/app", "-resources", "res", "-resources", "data", "-force",
+		"-tree-shake", "-keep", "my.plugins", "-keep", "my.app/handler", "my.app",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Entry != "my.app" || opts.Output != "bin/app" || !opts.Force || !opts.TreeShake ||
+		!slices.Equal(opts.Resources, []string{"res", "data"}) ||
+		!slices.Equal(opts.Roots, []string{"my.plugins", "my.app/handler"}) {
+		t.Fatalf("parseBuildArgs = %+v", opts)
+	}
+
+	for _, args := range [][]string{nil, {"a", "b"}, {"-unknown", "a"}} {
+		if _, err := parseBuildArgs(args); err == nil {
+			t.Errorf("parseBuildArgs(%q) did not fail", args)
+		}
+	}
+	if _, err := parseBuildArgs([]string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("parseBuildArgs(-h) = %v, want flag.ErrHelp", err)
+	}
+}
+
+func TestSplitRunOptions(t *testing.T) {
+	tests := []struct {
+		args      []string
+		want      runOptions
+		wantArgs  []string
+		wantError bool
+	}{
+		{[]string{"main.clj", "--watch"}, runOptions{}, []string{"main.clj", "--watch"}, false},
+		{[]string{"--watch", "--nrepl"}, runOptions{watch: true}, []string{"--nrepl"}, false},
+		{[]string{"--cpuprofile", "cpu.out", "--memprofile=mem.out", "--watch", "main.clj"},
+			runOptions{watch: true, cpuProfile: "cpu.out", memProfile: "mem.out"}, []string{"main.clj"}, false},
+		{[]string{"--cpuprofile"}, runOptions{}, nil, true},
+		{[]string{"--watch=1"}, runOptions{}, nil, true},
+	}
+	for _, test := range tests {
+		got, gotArgs, err := splitRunOptions(test.args)
+		if (err != nil) != test.wantError || err == nil && (got != test.want || !slices.Equal(gotArgs, test.wantArgs)) {
+			t.Errorf("splitRunOptions(%q) = (%+v, %q, %v), want (%+v, %q, error=%v)",
+				test.args, got, gotArgs, err, test.want, test.wantArgs, test.wantError)
+		}
+	}
+}
+
+func TestRunScriptReportsLocation(t *testing.T) {
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, lang.VarCurrentNS.Deref()))
+	t.Cleanup(lang.PopThreadBindings)
+	for _, tc := range []struct {
+		path string
+		code string
+		want string
+	}{
+		{"s1.glj", "(defn script-f [x] x)\n\n(script-f 1 2)\n", "Execution error (ArityException) at (s1.glj:3).\n"},
+		{"s2.glj", "\n(nth [1] 5)\n", "Execution error at (s2.glj:2).\nindex out of range\n"},
+		{"s3.glj", "(ns script.test)\n(defn f [] (nth [1] 5))\n(f)\n", "Execution error at script.test/f (s3.glj:2).\n"},
+	} {
+		phase, err := runScript(tc.path, tc.code)
+		if err == nil {
+			t.Errorf("%s succeeded", tc.path)
+			continue
+		}
+		if got := runtime.ErrorString(err, phase); !strings.HasPrefix(got, tc.want) {
+			t.Errorf("%s reported\n%q\nwant\n%q", tc.path, got, tc.want)
+		}
+	}
+}
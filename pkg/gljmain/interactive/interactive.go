@@ -30,16 +30,42 @@ func (commands) StartREPL() {
 	repl.Start()
 }
 
-func (commands) StartNREPL(arg string) {
-	host, port, portFile := parseServerArg(arg, "--nrepl")
-	srv, err := nrepl.Start(host, port, portFile)
+func (commands) StartNREPL(args []string) {
+	flags, err := parseNREPLFlags(args[1:], false)
+	if err != nil {
+		log.Fatalf("glj: %v", err)
+	}
+	opts, err := flags.serverOptions()
+	if err != nil {
+		log.Fatalf("glj: %v", err)
+	}
+
+	if flags.socket != "" {
+		srv, err := nrepl.Listen("unix", flags.socket, opts...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("nREPL server started on socket %s (%s transport)\n",
+			flags.socket, flags.framingName())
+		go srv.Serve()
+		waitForShutdown("\nnREPL server shutting down...", srv.Stop)
+		return
+	}
+
+	host, port, portFile := parseServerArg(args[0], "--nrepl")
+	opts = append(opts, nrepl.WithPortFile(portFile))
+	srv, err := nrepl.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)), opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	scheme := "nrepl"
+	if flags.tlsCert != "" {
+		scheme = "nrepls"
+	}
 	actualPort := srv.Port()
-	fmt.Printf("nREPL server started on port %d on host %s - nrepl://%s:%d\n",
-		actualPort, host, host, actualPort)
+	fmt.Printf("nREPL server started on port %d on host %s - %s://%s:%d\n",
+		actualPort, host, scheme, host, actualPort)
 
 	go srv.Serve()
 	waitForShutdown("\nnREPL server shutting down...", srv.Stop)
@@ -62,34 +88,29 @@ func (commands) StartSREPL(arg string) {
 
 func (commands) ConnectNREPL(args []string) {
 	if len(args) < 2 {
-		log.Fatal("glj: --nrepl-connect requires HOST:PORT")
+		log.Fatal("glj: --nrepl-connect requires HOST:PORT or unix:PATH")
 	}
 	addr := args[1]
-	idx := strings.LastIndex(addr, ":")
-	if idx <= 0 || !isAllDigits(addr[idx+1:]) {
-		log.Fatalf("glj: invalid address: %s (expected HOST:PORT)", addr)
+	network := "tcp"
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		network, addr = "unix", path
+	} else if idx := strings.LastIndex(addr, ":"); idx <= 0 || !isAllDigits(addr[idx+1:]) {
+		log.Fatalf("glj: invalid address: %s (expected HOST:PORT or unix:PATH)", addr)
 	}
-	host := addr[:idx]
-	port, err := strconv.Atoi(addr[idx+1:])
+	flags, err := parseNREPLFlags(args[2:], true)
 	if err != nil {
-		log.Fatalf("glj: invalid port: %s", addr[idx+1:])
+		log.Fatalf("glj: %v", err)
 	}
-	client, err := nrepl.Connect(host, port)
+	opts, err := flags.clientOptions()
 	if err != nil {
-		log.Fatalf("glj: failed to connect to nREPL at %s: %v", addr, err)
+		log.Fatalf("glj: %v", err)
 	}
-	defer client.Close()
-
-	var histFile, histFmt string
-	for i := 2; i < len(args); i++ {
-		if args[i] == "--history" && i+1 < len(args) {
-			histFile = args[i+1]
-			i++
-		} else if args[i] == "--history-fmt" && i+1 < len(args) {
-			histFmt = args[i+1]
-			i++
-		}
+	client, err := nrepl.Dial(network, addr, opts...)
+	if err != nil {
+		log.Fatalf("glj: failed to connect to nREPL at %s: %v", args[1], err)
 	}
+	defer client.Close()
+	histFile, histFmt := flags.history, flags.historyFmt
 
 	fi, _ := os.Stdin.Stat()
 	if (fi.Mode() & os.ModeCharDevice) != 0 {
@@ -155,6 +176,105 @@ func isAllDigits(s string) bool {
 	return true
 }
 
+// nreplFlags are the options following --nrepl or --nrepl-connect.
+type nreplFlags struct {
+	framing    string // --transport
+	socket     string // --socket, servers only
+	tlsCert    string // --tls-cert
+	tlsKey     string // --tls-key
+	tlsCA      string // --tls-ca
+	history    string // --history, clients only
+	historyFmt string // --history-fmt, clients only
+}
+
+// parseNREPLFlags parses the options following --nrepl, or
+// --nrepl-connect and its address if client is set.
+func parseNREPLFlags(args []string, client bool) (nreplFlags, error) {
+	var flags nreplFlags
+	for i := 0; i < len(args); i++ {
+		var dst *string
+		switch args[i] {
+		case "--transport":
+			dst = &flags.framing
+		case "--tls-cert":
+			dst = &flags.tlsCert
+		case "--tls-key":
+			dst = &flags.tlsKey
+		case "--tls-ca":
+			dst = &flags.tlsCA
+		case "--socket":
+			if !client {
+				dst = &flags.socket
+			}
+		case "--history":
+			if client {
+				dst = &flags.history
+			}
+		case "--history-fmt":
+			if client {
+				dst = &flags.historyFmt
+			}
+		}
+		if dst == nil {
+			return flags, fmt.Errorf("unknown option %s", args[i])
+		}
+		if i+1 >= len(args) {
+			return flags, fmt.Errorf("%s requires a value", args[i])
+		}
+		*dst = args[i+1]
+		i++
+	}
+	switch flags.framing {
+	case "", nrepl.FramingBencode, nrepl.FramingEDN:
+	default:
+		return flags, fmt.Errorf("unknown transport %q (expected %s or %s)",
+			flags.framing, nrepl.FramingBencode, nrepl.FramingEDN)
+	}
+	if (flags.tlsCert == "") != (flags.tlsKey == "") {
+		return flags, fmt.Errorf("--tls-cert and --tls-key must be given together")
+	}
+	if !client && flags.tlsCA != "" && flags.tlsCert == "" {
+		return flags, fmt.Errorf("--tls-ca requires --tls-cert and --tls-key")
+	}
+	return flags, nil
+}
+
+func (f nreplFlags) framingName() string {
+	if f.framing == "" {
+		return nrepl.FramingBencode
+	}
+	return f.framing
+}
+
+// serverOptions returns the listen options for f. The server uses TLS
+// if it has a certificate, and requires client certificates signed by
+// --tls-ca if it is given.
+func (f nreplFlags) serverOptions() ([]nrepl.Option, error) {
+	opts := []nrepl.Option{nrepl.WithFraming(f.framing)}
+	if f.tlsCert != "" {
+		config, err := nrepl.ServerTLSConfig(f.tlsCert, f.tlsKey, f.tlsCA)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nrepl.WithTLS(config))
+	}
+	return opts, nil
+}
+
+// clientOptions returns the dial options for f. The client uses TLS if
+// any TLS option is given, verifying the server against --tls-ca.
+func (f nreplFlags) clientOptions() ([]nrepl.Option, error) {
+	opts := []nrepl.Option{nrepl.WithFraming(f.framing)}
+	if f.tlsCert != "" || f.tlsCA != "" {
+		config, err := nrepl.ClientTLSConfig(f.tlsCert, f.tlsKey, f.tlsCA)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nrepl.WithTLS(config))
+	}
+	return opts, nil
+}
+
 // parseServerArg extracts host, port, and port-file from a flag like
 // --nrepl=VALUE or --srepl=VALUE. The prefix is e.g. "--nrepl".
 func parseServerArg(arg, prefix string) (host string, port int, portFile string) {
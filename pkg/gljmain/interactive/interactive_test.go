@@ -45,3 +45,42 @@ func TestParseServerArg(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNREPLFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		client bool
+		want   nreplFlags
+		err    string
+	}{
+		{name: "none"},
+		{name: "edn socket", args: []string{"--transport", "edn", "--socket", "repl.sock"},
+			want: nreplFlags{framing: "edn", socket: "repl.sock"}},
+		{name: "tls", args: []string{"--tls-cert", "c.pem", "--tls-key", "k.pem", "--tls-ca", "ca.pem"},
+			want: nreplFlags{tlsCert: "c.pem", tlsKey: "k.pem", tlsCA: "ca.pem"}},
+		{name: "client history", args: []string{"--tls-ca", "ca.pem", "--history", "h"}, client: true,
+			want: nreplFlags{tlsCA: "ca.pem", history: "h"}},
+		{name: "unknown transport", args: []string{"--transport", "json"}, err: "unknown transport"},
+		{name: "missing value", args: []string{"--socket"}, err: "requires a value"},
+		{name: "cert without key", args: []string{"--tls-cert", "c.pem"}, err: "must be given together"},
+		{name: "server ca without cert", args: []string{"--tls-ca", "ca.pem"}, err: "requires --tls-cert"},
+		{name: "socket on client", args: []string{"--socket", "s"}, client: true, err: "unknown option"},
+		{name: "history on server", args: []string{"--history", "h"}, err: "unknown option"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNREPLFlags(tt.args, tt.client)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("parseNREPLFlags(%q) error = %v, want %q", tt.args, err, tt.err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseNREPLFlags(%q) = %+v, %v, want %+v", tt.args, got, err, tt.want)
+			}
+		})
+	}
+}
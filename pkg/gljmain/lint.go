@@ -0,0 +1,36 @@
+package gljmain
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/glojurelang/glojure/pkg/lint"
+)
+
+func runLint(args []string) {
+	flags := flag.NewFlagSet("glj lint", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", lint.FormatHuman, "")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		printHelp()
+		return
+	} else if err != nil {
+		log.Fatalf("glj lint: %v", err)
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	findings, err := lint.Paths(paths)
+	if err != nil {
+		log.Fatalf("glj lint: %v", err)
+	}
+	if err := lint.Write(os.Stdout, findings, *format); err != nil {
+		log.Fatalf("glj lint: %v", err)
+	}
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,33 @@
+package gljmain
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/glojurelang/glojure/pkg/lsp"
+)
+
+func runLSP(args []string) {
+	for _, arg := range args {
+		switch arg {
+		case "-h", "-help", "--help":
+			printHelp()
+			return
+		case "--stdio":
+			// Editors commonly pass --stdio; it is the only transport.
+		default:
+			log.Fatalf("glj lsp: unknown argument: %s", arg)
+		}
+	}
+	var roots []string
+	for _, path := range filepath.SplitList(os.Getenv("GLJ_CLASSPATH")) {
+		if path != "" {
+			roots = append(roots, path)
+		}
+	}
+	srv := lsp.NewServer(lsp.WithRoots(roots...), lsp.WithLog(os.Stderr))
+	if err := srv.Serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
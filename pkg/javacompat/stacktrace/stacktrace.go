@@ -3,6 +3,7 @@
 package stacktrace
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/glojurelang/glojure/pkg/lang"
@@ -28,6 +29,22 @@ func (e *StackTraceElement) GetMethodName() string { return e.methodName }
 func (e *StackTraceElement) GetFileName() any      { return e.fileName }
 func (e *StackTraceElement) GetLineNumber() int32  { return e.lineNumber }
 
+// String returns the element as Java's StackTraceElement.toString
+// does: class.method(file:line), with Unknown Source for an element
+// without a file.
+func (e *StackTraceElement) String() string {
+	source := "Unknown Source"
+	switch {
+	case e.fileName != nil && e.lineNumber >= 0:
+		source = fmt.Sprintf("%v:%d", e.fileName, e.lineNumber)
+	case e.fileName != nil:
+		source = fmt.Sprint(e.fileName)
+	case e.lineNumber == -2:
+		source = "Native Method"
+	}
+	return e.className + "." + e.methodName + "(" + source + ")"
+}
+
 // Link gives embedders an explicit package-retention reference.
 func Link() {}
 
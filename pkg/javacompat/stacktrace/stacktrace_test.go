@@ -11,3 +11,19 @@ func TestStackTraceElementAccessors(t *testing.T) {
 		t.Fatalf("unexpected element: %#v", element)
 	}
 }
+
+func TestStackTraceElementString(t *testing.T) {
+	for _, test := range []struct {
+		element *StackTraceElement
+		want    string
+	}{
+		{New("st/inner", "invoke", "st.glj", 2), "st/inner.invoke(st.glj:2)"},
+		{New("st/inner", "invoke", "st.glj", -1), "st/inner.invoke(st.glj)"},
+		{New("st/inner", "invoke", nil, 2), "st/inner.invoke(Unknown Source)"},
+		{New("go.runtime", "call", nil, -2), "go.runtime.call(Native Method)"},
+	} {
+		if got := test.element.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+	}
+}
@@ -14,6 +14,9 @@ type (
 	future struct {
 		done chan struct{}
 		res  interface{}
+		// panicked holds what the future's function panicked with, to
+		// rethrow on deref.
+		panicked interface{}
 	}
 )
 
@@ -28,13 +31,20 @@ var (
 
 func (f *future) Deref() interface{} {
 	<-f.done
+	return f.result()
+}
+
+func (f *future) result() interface{} {
+	if f.panicked != nil {
+		panic(f.panicked)
+	}
 	return f.res
 }
 
 func (f *future) DerefWithTimeout(timeoutMS int64, timeoutVal interface{}) interface{} {
 	select {
 	case <-f.done:
-		return f.res
+		return f.result()
 	case <-time.After(time.Duration(timeoutMS) * time.Millisecond):
 		return timeoutVal
 	}
@@ -47,7 +57,7 @@ func (f *future) Get() interface{} {
 func (f *future) GetWithTimeout(timeout int64, timeUnit time.Duration) interface{} {
 	select {
 	case <-f.done:
-		return f.res
+		return f.result()
 	case <-time.After(time.Duration(timeout) * time.Millisecond):
 		panic(NewTimeoutError("future timeout"))
 	}
@@ -108,8 +118,13 @@ func AgentSubmit(fn IFn) IBlockingDeref {
 		done: make(chan struct{}),
 	}
 	go func() {
+		defer close(fut.done)
+		defer func() {
+			if r := recover(); r != nil {
+				fut.panicked = r
+			}
+		}()
 		fut.res = fn.Invoke()
-		close(fut.done)
 	}()
 	return fut
 }
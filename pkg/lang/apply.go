@@ -324,6 +324,12 @@ func applySlice(goVal reflect.Value, args []interface{}) interface{} {
 	return goVal.Index(idx).Interface()
 }
 
+// CoerceGoValue converts val to a value of targetType as arguments of
+// calls to Go functions are converted, returning an error if it can't.
+func CoerceGoValue(targetType reflect.Type, val interface{}) (reflect.Value, error) {
+	return coerceGoValue(targetType, val)
+}
+
 // coerceGoValue attempts to coerce a Go value to be assignable to a
 // target type. If the value is already assignable, it is returned.
 // TODO: reconsider semantics of this function
@@ -1,7 +1,5 @@
 package lang
 
-import "fmt"
-
 // ArityFn represents a function with multiple fixed arities and an optional
 // variadic method. The fixed InvokeN methods keep common call sites off the
 // variadic []any path while Invoke and ApplyTo preserve general IFn behavior.
@@ -71,7 +69,7 @@ func (f ArityFn) Invoke(args ...any) any {
 	if f.variadic != nil && len(args) >= f.minVariadic {
 		return f.variadic.Invoke(args...)
 	}
-	panic(NewIllegalArgumentError(fmt.Sprintf("wrong number of arguments (%d)", len(args))))
+	panic(NewArityError(len(args), ""))
 }
 
 func (f ArityFn) Invoke0() any {
@@ -130,7 +128,7 @@ func (f ArityFn) ApplyTo(args ISeq) any {
 	if f.variadic != nil && arity >= f.minVariadic {
 		return f.variadic.ApplyTo(original)
 	}
-	panic(NewIllegalArgumentError(fmt.Sprintf("wrong number of arguments (%d)", arity)))
+	panic(NewArityError(arity, ""))
 }
 
 func (f ArityFn) Meta() IPersistentMap {
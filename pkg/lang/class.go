@@ -106,6 +106,26 @@ func NewHostInstance(class any, args ...any) any {
 	return reflect.New(t).Interface()
 }
 
+// HostInstanceType returns the type of the values NewHostInstance
+// constructs from class and nargs arguments, if it is known without
+// calling it: that of a Go zero value, as no constructor is registered.
+func HostInstanceType(class any, nargs int) (reflect.Type, bool) {
+	if c, ok := class.(*Class); ok {
+		if _, found := hostConstructors.Load(c.JavaName); found {
+			return nil, false
+		}
+		class = c.Type
+	}
+	t, ok := class.(reflect.Type)
+	if !ok || t == nil || nargs != 0 {
+		return nil, false
+	}
+	if _, found := hostTypeConstructors.Load(t); found {
+		return nil, false
+	}
+	return reflect.PointerTo(t), true
+}
+
 // Name shadows the embedded reflect.Type.Name() so `(.getName c)` (which
 // rewrite-core turns into `.Name`) returns the JVM-canonical name.
 func (c *Class) Name() string { return c.JavaName }
@@ -1,6 +1,7 @@
 package lang
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -33,11 +34,24 @@ type (
 		msg string
 	}
 
+	// ArityError is the error of calling a function with a number of
+	// arguments it doesn't take, Clojure's ArityException. It is also an
+	// IllegalArgumentError.
+	ArityError struct {
+		Actual int
+		Name   string
+	}
+
+	// CompilerError is an error raised while reading, macroexpanding or
+	// compiling a form, Clojure's CompilerException. Its ex-data records
+	// the phase of evaluation it occurred in and where.
 	CompilerError struct {
-		file string
-		line int
-		col  int
-		err  error
+		phase Keyword
+		file  string
+		line  int
+		col   int
+		sym   *Symbol
+		err   error
 	}
 
 	// Stacker is an interface for retrieving stack traces.
@@ -51,7 +65,11 @@ type (
 		stack []StackFrame
 	}
 
+	// StackFrame is a frame of a Glojure stack trace: the position of
+	// the code being evaluated in a function, named by its namespace
+	// and name, or outside any.
 	StackFrame struct {
+		Namespace    string
 		FunctionName string
 		Filename     string
 		Line         int
@@ -150,6 +168,37 @@ func (e *ArithmeticError) Is(other error) bool {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// NewArityError returns an error for a call of the function named name,
+// which may be empty, with actual arguments.
+func NewArityError(actual int, name string) error {
+	return &ArityError{Actual: actual, Name: name}
+}
+
+func (e *ArityError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("wrong number of args (%d)", e.Actual)
+	}
+	return fmt.Sprintf("wrong number of args (%d) passed to: %s", e.Actual, e.Name)
+}
+
+func (e *ArityError) Is(other error) bool {
+	switch other.(type) {
+	case *ArityError, *IllegalArgumentError:
+		return true
+	}
+	return false
+}
+
+func (e *ArityError) As(target any) bool {
+	if target, ok := target.(**IllegalArgumentError); ok {
+		*target = &IllegalArgumentError{msg: e.Error()}
+		return true
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 func NewIllegalStateError(msg string) error {
 	return &IllegalStateError{msg: msg}
 }
@@ -165,17 +214,138 @@ func (e *IllegalStateError) Is(other error) bool {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// The phases of evaluation, the values of :clojure.error/phase in the
+// ex-data of errors.
+var (
+	PhaseReadSource         = NewKeyword("read-source")
+	PhaseMacroSyntaxCheck   = NewKeyword("macro-syntax-check")
+	PhaseMacroexpansion     = NewKeyword("macroexpansion")
+	PhaseCompileSyntaxCheck = NewKeyword("compile-syntax-check")
+	PhaseExecution          = NewKeyword("execution")
+	PhasePrintEvalResult    = NewKeyword("print-eval-result")
+)
+
+// The keys of the ex-data of a CompilerError.
+var (
+	KWErrorPhase  = NewKeyword("clojure.error/phase")
+	KWErrorSource = NewKeyword("clojure.error/source")
+	KWErrorLine   = NewKeyword("clojure.error/line")
+	KWErrorColumn = NewKeyword("clojure.error/column")
+	KWErrorSymbol = NewKeyword("clojure.error/symbol")
+)
+
+var phaseDescriptions = map[Keyword]string{
+	PhaseReadSource:         "syntax error reading source",
+	PhaseMacroSyntaxCheck:   "syntax error macroexpanding",
+	PhaseMacroexpansion:     "error macroexpanding",
+	PhaseCompileSyntaxCheck: "compiler error",
+	PhaseExecution:          "error evaluating",
+	PhasePrintEvalResult:    "error printing result",
+}
+
+// NewCompilerError returns an error raised while compiling the form at
+// line and col of file.
 func NewCompilerError(file string, line, col int, err error) error {
+	return NewCompilerErrorInPhase(PhaseCompileSyntaxCheck, file, line, col, nil, err)
+}
+
+// NewCompilerErrorInPhase returns an error raised in phase, one of the
+// Phase keywords, while evaluating the form at line and col of file.
+// sym, which may be nil, names the macro or special form being
+// evaluated.
+func NewCompilerErrorInPhase(phase Keyword, file string, line, col int, sym *Symbol, err error) error {
 	return &CompilerError{
-		file: file,
-		line: line,
-		col:  col,
-		err:  err,
+		phase: phase,
+		file:  file,
+		line:  line,
+		col:   col,
+		sym:   sym,
+		err:   err,
 	}
 }
 
 func (e *CompilerError) Error() string {
-	return fmt.Sprintf("compiler error at %s:%d:%d: %v", e.file, e.line, e.col, e.err)
+	desc, ok := phaseDescriptions[e.phase]
+	if !ok {
+		desc = "compiler error"
+	}
+	return fmt.Sprintf("%s at %s:%d:%d: %v", desc, e.file, e.line, e.col, e.err)
+}
+
+// Phase returns the phase of evaluation the error occurred in.
+func (e *CompilerError) Phase() Keyword {
+	return e.phase
+}
+
+// GetData returns a map of the phase and location of the error, under
+// the keys :clojure.error/phase, :clojure.error/source,
+// :clojure.error/line, :clojure.error/column and
+// :clojure.error/symbol.
+func (e *CompilerError) GetData() IPersistentMap {
+	data := NewMap(KWErrorPhase, e.phase)
+	if e.sym != nil {
+		data = data.Assoc(KWErrorSymbol, e.sym).(IPersistentMap)
+	}
+	if e.file != "" {
+		data = data.Assoc(KWErrorSource, e.file).(IPersistentMap)
+	}
+	if e.line > 0 {
+		data = data.Assoc(KWErrorLine, e.line).(IPersistentMap)
+	}
+	if e.col > 0 {
+		data = data.Assoc(KWErrorColumn, e.col).(IPersistentMap)
+	}
+	return data
+}
+
+func (e *CompilerError) Unwrap() error {
+	return e.err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// QualifiedName returns the namespace-qualified name of the function
+// of the frame, or "" if it is outside any.
+func (f StackFrame) QualifiedName() string {
+	if f.FunctionName == "" || f.Namespace == "" {
+		return f.FunctionName
+	}
+	return f.Namespace + "/" + f.FunctionName
+}
+
+// StackTrace returns the frames of the stack trace of the first error
+// in err's chain that records one, innermost first.
+func StackTrace(err error) []StackFrame {
+	var st Stacker
+	if !errors.As(err, &st) {
+		return nil
+	}
+	return st.Stack()
+}
+
+// stackTraceElements returns the java.lang.StackTraceElement values of
+// the stack trace of err, the value of its getStackTrace method. They
+// are named for the functions of the frames, with the method invoke;
+// frames outside any function, which a Java trace has no element for,
+// are left out. Without the class registered, the trace is empty.
+func stackTraceElements(err error) []any {
+	constructor, ok := hostConstructors.Load("java.lang.StackTraceElement")
+	if !ok {
+		return []any{}
+	}
+	elements := []any{}
+	for _, frame := range StackTrace(err) {
+		name := frame.QualifiedName()
+		if name == "" {
+			continue
+		}
+		var file any
+		if frame.Filename != "" {
+			file = frame.Filename
+		}
+		elements = append(elements, Apply(constructor.(IFn), []any{name, "invoke", file, frame.Line}))
+	}
+	return elements
 }
 
 ////////////////////////////////////////////////////////////////////////////////
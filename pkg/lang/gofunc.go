@@ -0,0 +1,136 @@
+package lang
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// NewGoFunc returns a function that calls the Go functions fns, one for
+// each arity. A variadic Go function takes the calls with at least as
+// many arguments as its fixed parameters that no other function takes.
+// Arguments are converted to the parameter types as they are for calls
+// of Go functions from Glojure. If a function's last result is an
+// error, the call panics with it when it is not nil; the other results
+// are the call's value: nil if there are none, the result if there is
+// one and a vector of them otherwise.
+//
+// The function's metadata has :arglists, with a vector of parameters
+// for each Go function whose symbols are tagged with their Go types.
+func NewGoFunc(fns ...any) (ArityFn, error) {
+	if len(fns) == 0 {
+		return ArityFn{}, fmt.Errorf("no Go functions given")
+	}
+	fixed := map[int]IFn{}
+	var (
+		variadic    *goFunc
+		minVariadic int
+		arglists    []any
+	)
+	for _, fn := range fns {
+		val := reflect.ValueOf(fn)
+		if val.Kind() != reflect.Func || val.IsNil() {
+			return ArityFn{}, fmt.Errorf("%T is not a Go function", fn)
+		}
+		f := &goFunc{val: val, typ: val.Type()}
+		arity := f.typ.NumIn()
+		if f.typ.IsVariadic() {
+			if variadic != nil {
+				return ArityFn{}, fmt.Errorf("more than one variadic function: %s and %s", variadic.typ, f.typ)
+			}
+			variadic, minVariadic = f, arity-1
+		} else {
+			if other, ok := fixed[arity]; ok {
+				return ArityFn{}, fmt.Errorf("more than one function of arity %d: %s and %s", arity, other.(*goFunc).typ, f.typ)
+			}
+			fixed[arity] = f
+		}
+	}
+	arities := make([]int, 0, len(fixed))
+	for arity := range fixed {
+		if variadic != nil && arity > minVariadic {
+			return ArityFn{}, fmt.Errorf("function of arity %d takes more arguments than variadic function %s", arity, variadic.typ)
+		}
+		arities = append(arities, arity)
+	}
+	sort.Ints(arities)
+	for _, arity := range arities {
+		arglists = append(arglists, fixed[arity].(*goFunc).arglist())
+	}
+
+	var variadicFn IFn
+	if variadic != nil {
+		variadicFn = variadic
+		arglists = append(arglists, variadic.arglist())
+	}
+	fn := NewArityFnMethods(fixed, variadicFn, minVariadic)
+	return fn.WithMeta(NewMap(KWArglists, NewList(arglists...))).(ArityFn), nil
+}
+
+// goFunc is an IFn that calls a Go function through reflection.
+type goFunc struct {
+	val reflect.Value
+	typ reflect.Type
+}
+
+func (f *goFunc) Invoke(args ...any) any {
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		v, err := coerceGoValue(f.paramType(i), arg)
+		if err != nil {
+			panic(NewIllegalArgumentError(fmt.Sprintf("argument %d: %s", i, err)))
+		}
+		in[i] = v
+	}
+	out := f.val.Call(in)
+	if n := len(out); n > 0 && f.typ.Out(n-1) == errorType {
+		if err, _ := out[n-1].Interface().(error); err != nil {
+			panic(err)
+		}
+		out = out[:n-1]
+	}
+	switch len(out) {
+	case 0:
+		return nil
+	case 1:
+		return out[0].Interface()
+	}
+	res := make([]any, len(out))
+	for i, v := range out {
+		res[i] = v.Interface()
+	}
+	return NewVector(res...)
+}
+
+func (f *goFunc) ApplyTo(args ISeq) any {
+	return f.Invoke(seqToSlice(args)...)
+}
+
+// paramType returns the type of the parameter argument i is passed as.
+func (f *goFunc) paramType(i int) reflect.Type {
+	if n := f.typ.NumIn(); f.typ.IsVariadic() && i >= n-1 {
+		return f.typ.In(n - 1).Elem()
+	}
+	return f.typ.In(i)
+}
+
+// arglist returns a vector of parameters for the function, named a, b,
+// c and so on, or argN past z, with & more for variadic parameters.
+func (f *goFunc) arglist() IPersistentVector {
+	n := f.typ.NumIn()
+	var params []any
+	for i := 0; i < n; i++ {
+		name := "more"
+		if !f.typ.IsVariadic() || i < n-1 {
+			name = "arg" + strconv.Itoa(i)
+			if i < 26 {
+				name = string(rune('a' + i))
+			}
+		} else {
+			params = append(params, NewSymbol("&"))
+		}
+		params = append(params, NewSymbol(name).WithMeta(NewMap(KWTag, f.typ.In(i))))
+	}
+	return NewVector(params...)
+}
@@ -0,0 +1,71 @@
+package lang
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewGoFunc(t *testing.T) {
+	errNegative := errors.New("negative")
+	fn, err := NewGoFunc(
+		func(n int) (int, error) {
+			if n < 0 {
+				return 0, errNegative
+			}
+			return n * 2, nil
+		},
+		func(s string, n int) string { return strings.Repeat(s, n) },
+		func(sep, first, second string, rest ...string) string {
+			return strings.Join(append([]string{first, second}, rest...), sep)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fn.Invoke(int64(21)); got != 42 {
+		t.Errorf("arity 1 = %v, want 42", got)
+	}
+	if got := Apply2(fn, "ab", 2); got != "abab" {
+		t.Errorf("arity 2 = %v, want abab", got)
+	}
+	if got := fn.Invoke(",", "a", "b", "c"); got != "a,b,c" {
+		t.Errorf("variadic = %v, want a,b,c", got)
+	}
+	if got := fn.ApplyTo(NewList("-", "x", "y", "z", "w")); got != "x-y-z-w" {
+		t.Errorf("ApplyTo = %v, want x-y-z-w", got)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != errNegative {
+				t.Errorf("error result panicked with %v, want %v", r, errNegative)
+			}
+		}()
+		fn.Invoke(-1)
+	}()
+
+	arglists := PrintString(fn.Meta().ValAt(KWArglists))
+	if arglists != "([a] [a b] [a b c & more])" {
+		t.Errorf("arglists = %s", arglists)
+	}
+	if tag := First(First(fn.Meta().ValAt(KWArglists))).(*Symbol).Meta().ValAt(KWTag); tag != reflect.TypeOf(0) {
+		t.Errorf("parameter tag = %v, want int", tag)
+	}
+}
+
+func TestNewGoFuncRejectsAmbiguousArities(t *testing.T) {
+	for _, fns := range [][]any{
+		nil,
+		{42},
+		{func(a int) {}, func(b string) {}},
+		{func(a ...int) {}, func(a string, b ...int) {}},
+		{func(a, b, c int) {}, func(a int, b ...int) {}},
+	} {
+		if _, err := NewGoFunc(fns...); err == nil {
+			t.Errorf("NewGoFunc(%T) succeeded, want error", fns)
+		}
+	}
+}
@@ -122,7 +122,9 @@ var (
 	KWDynamic       = NewKeyword("dynamic")
 	KWRedef         = NewKeyword("redef")
 	KWDirectLinking = NewKeyword("direct-linking")
+	KWSnapshot      = NewKeyword("snapshot")
 	KWInline        = NewKeyword("inline")
 	KWInlineArities = NewKeyword("inline-arities")
+	KWExport        = NewKeyword("export")
 	KWNS            = NewKeyword("ns")
 )
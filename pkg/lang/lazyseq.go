@@ -2,6 +2,7 @@ package lang
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
 type LazySeq struct {
@@ -16,6 +17,29 @@ type LazySeq struct {
 	seqMtx     sync.Mutex
 }
 
+// lazySeqRealizeHook is called with the number of elements each lazy
+// seq realization produces.
+var lazySeqRealizeHook atomic.Pointer[func(n int)]
+
+// SetLazySeqRealizeHook installs a function called each time a lazy
+// seq is realized, with the number of elements realized: the size of
+// the chunk for chunked seqs, otherwise 1. It may panic to abort the
+// realization's caller. Passing nil removes the hook.
+func SetLazySeqRealizeHook(hook func(n int)) {
+	if hook == nil {
+		lazySeqRealizeHook.Store(nil)
+		return
+	}
+	lazySeqRealizeHook.Store(&hook)
+}
+
+// lazySeqRealized calls the realize hook, if any, with n.
+func lazySeqRealized(n int) {
+	if hook := lazySeqRealizeHook.Load(); hook != nil {
+		(*hook)(n)
+	}
+}
+
 func NewLazySeq(fn func() interface{}) ISeq {
 	return &LazySeq{fn: fn}
 }
@@ -103,6 +127,11 @@ func (s *LazySeq) realize() interface{} {
 	if s.fn != nil {
 		s.sv = s.fn()
 		s.fn = nil
+		if chunked, ok := s.sv.(IChunkedSeq); ok {
+			lazySeqRealized(chunked.ChunkedFirst().Count())
+		} else {
+			lazySeqRealized(1)
+		}
 	}
 	if s.sv != nil {
 		return s.sv
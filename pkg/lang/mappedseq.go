@@ -38,6 +38,7 @@ func (s *mappedSeq) realizeValue() any {
 	s.valueOnce.Do(func() {
 		s.value = Apply1(s.fn, s.source.First())
 		s.realized.Store(true)
+		lazySeqRealized(1)
 	})
 	return s.value
 }
@@ -125,6 +126,7 @@ func (s *mappedSeq) asStringSlice() []string {
 func (s *mappedSeq) Reduce(f IFn) any {
 	result := s.First()
 	for source := s.source.Next(); source != nil; source = source.Next() {
+		lazySeqRealized(1)
 		result = Apply2(f, result, Apply1(s.fn, source.First()))
 		if IsReduced(result) {
 			return result.(IDeref).Deref()
@@ -136,6 +138,7 @@ func (s *mappedSeq) Reduce(f IFn) any {
 func (s *mappedSeq) ReduceInit(f IFn, init any) any {
 	result := init
 	for source := s.source; source != nil; source = source.Next() {
+		lazySeqRealized(1)
 		result = Apply2(f, result, Apply1(s.fn, source.First()))
 		if IsReduced(result) {
 			return result.(IDeref).Deref()
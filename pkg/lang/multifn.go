@@ -180,6 +180,31 @@ func (m *MultiFn) AddMethod(dispatchVal any, method IFn) *MultiFn {
 	return m
 }
 
+// AddMethods adds the methods in kvs, alternating dispatch values and
+// methods, as AddMethod does each.
+func (m *MultiFn) AddMethods(kvs ...any) *MultiFn {
+	if len(kvs)%2 != 0 {
+		panic(NewIllegalArgumentError("AddMethods expects dispatch value and method pairs"))
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if table, ok := m.methodTable.(*Map); ok {
+		t := table.AsTransient().(*TransientMap)
+		for i := 0; i < len(kvs); i += 2 {
+			t.Assoc(kvs[i], kvs[i+1])
+		}
+		m.methodTable = t.Persistent().(IPersistentMap)
+	} else {
+		for i := 0; i < len(kvs); i += 2 {
+			m.methodTable = m.methodTable.Assoc(kvs[i], kvs[i+1]).(IPersistentMap)
+		}
+	}
+	m.resetCache()
+
+	return m
+}
+
 func (m *MultiFn) PreferMethod(dispatchValX, dispatchValY any) *MultiFn {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
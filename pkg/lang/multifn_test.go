@@ -46,6 +46,30 @@ func TestMultiFnRefreshesHierarchyBeforeMethodSelection(t *testing.T) {
 	}
 }
 
+func TestMultiFnAddMethods(t *testing.T) {
+	mf := NewMultiFn(
+		"test-add-methods",
+		FnFunc1(func(value any) any { return value }),
+		NewKeyword("default"),
+		NewVar(NSCore, NewSymbol("test-add-methods-hierarchy")),
+	)
+	methods := []any{NewKeyword("default"), FnFunc1(func(any) any { return "default" })}
+	for i := range 20 {
+		methods = append(methods, int64(i), FnFunc1(func(any) any { return int64(i) * 10 }))
+	}
+	mf.AddMethods(methods...)
+
+	if got := mf.GetMethodTable().Count(); got != 21 {
+		t.Fatalf("method table has %d methods, want 21", got)
+	}
+	for _, dispatchVal := range []int64{0, 7, 19} {
+		method := mf.GetMethodTable().ValAt(dispatchVal).(IFn)
+		if got := method.Invoke(dispatchVal); got != dispatchVal*10 {
+			t.Fatalf("method for %d returned %v", dispatchVal, got)
+		}
+	}
+}
+
 func TestKVReduceAutoRegisteredMethods(t *testing.T) {
 	oldIsA := varIsA.Deref()
 	defer varIsA.BindRoot(oldIsA)
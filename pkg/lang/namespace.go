@@ -3,6 +3,7 @@ package lang
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -44,50 +45,172 @@ type NamespaceReference struct {
 var (
 	SymbolCoreNamespace = NewSymbol("clojure.core")
 
-	namespaces = map[string]*Namespace{}
-	nsMtx      sync.RWMutex
+	rootNamespaces = &NamespaceTable{byName: map[string]*Namespace{}}
+
+	// namespaceTableVar is VarNamespaceTable, once it exists. It is
+	// stored here rather than read from VarNamespaceTable so that the
+	// namespace functions don't depend on the initialization of the vars
+	// of clojure.core, which use them.
+	namespaceTableVar atomic.Pointer[Var]
+	// errVar is VarErr, once it exists, for the same reason.
+	errVar atomic.Pointer[Var]
 )
 
-func AllNamespaces() ISeq {
-	nsMtx.RLock()
-	defer nsMtx.RUnlock()
-	ns := make([]*Namespace, 0, len(namespaces))
-	for _, n := range namespaces {
-		ns = append(ns, n)
+// A NamespaceTable maps names to namespaces. The root table holds the
+// namespaces of the process. A table made by NewNamespaceTable holds
+// namespaces of its own and sees the namespaces of the root table it
+// shares, so that code evaluated with the table bound to
+// VarNamespaceTable can define namespaces, and vars within them,
+// independently of other tables. Shared namespaces are the same
+// objects in every table that shares them: defining a var in one is
+// seen by all of them.
+type NamespaceTable struct {
+	mu     sync.RWMutex
+	byName map[string]*Namespace
+	parent *NamespaceTable
+	shared map[string]bool
+	owner  any
+}
+
+// NewNamespaceTable returns an empty table that shares the root
+// table's namespaces with the given names. owner is returned by Owner,
+// so that code holding the table can find what it belongs to.
+func NewNamespaceTable(owner any, shared ...string) *NamespaceTable {
+	t := &NamespaceTable{
+		byName: map[string]*Namespace{},
+		parent: rootNamespaces,
+		shared: map[string]bool{},
+		owner:  owner,
+	}
+	t.Share(shared...)
+	return t
+}
+
+// RootNamespaceTable returns the table of the process's namespaces,
+// used when no other table is bound.
+func RootNamespaceTable() *NamespaceTable {
+	return rootNamespaces
+}
+
+// CurrentNamespaceTable returns the table bound to VarNamespaceTable,
+// or the root table.
+func CurrentNamespaceTable() *NamespaceTable {
+	if v := namespaceTableVar.Load(); v != nil {
+		if t, ok := v.Deref().(*NamespaceTable); ok && t != nil {
+			return t
+		}
 	}
-	return Seq(ns)
+	return rootNamespaces
 }
 
-func FindNamespace(sym *Symbol) *Namespace {
-	nsMtx.RLock()
-	defer nsMtx.RUnlock()
-	return namespaces[sym.String()]
+// Owner returns the owner the table was made with, or nil for the root
+// table.
+func (t *NamespaceTable) Owner() any {
+	return t.owner
 }
 
-func FindOrCreateNamespace(sym *Symbol) *Namespace {
-	ns := FindNamespace(sym)
-	if ns != nil {
+// Share makes the root table's namespaces with the given names visible
+// in t, unless t has namespaces of its own with those names. Sharing
+// with the root table does nothing.
+func (t *NamespaceTable) Share(names ...string) {
+	if t.parent == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range names {
+		t.shared[name] = true
+	}
+}
+
+// IsShared reports whether the namespace named name is looked up in the
+// root table.
+func (t *NamespaceTable) IsShared(name string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.shared[name] && t.byName[name] == nil
+}
+
+func (t *NamespaceTable) find(name string) *Namespace {
+	t.mu.RLock()
+	ns, shared := t.byName[name], t.shared[name]
+	t.mu.RUnlock()
+	if ns == nil && shared {
+		return t.parent.find(name)
+	}
+	return ns
+}
+
+func (t *NamespaceTable) findOrCreate(sym *Symbol) *Namespace {
+	name := sym.String()
+	if ns := t.find(name); ns != nil {
 		return ns
 	}
-	nsMtx.Lock()
-	defer nsMtx.Unlock()
-	ns = namespaces[sym.String()]
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ns := t.byName[name]
 	if ns != nil {
 		return ns
 	}
+	if t.shared[name] {
+		if ns = t.parent.find(name); ns != nil {
+			return ns
+		}
+	}
 	ns = NewNamespace(sym)
-	namespaces[sym.String()] = ns
+	t.byName[name] = ns
+	return ns
+}
+
+func (t *NamespaceTable) all() []*Namespace {
+	t.mu.RLock()
+	ns := make([]*Namespace, 0, len(t.byName))
+	for _, n := range t.byName {
+		ns = append(ns, n)
+	}
+	var shared []string
+	for name := range t.shared {
+		if t.byName[name] == nil {
+			shared = append(shared, name)
+		}
+	}
+	t.mu.RUnlock()
+	for _, name := range shared {
+		if n := t.parent.find(name); n != nil {
+			ns = append(ns, n)
+		}
+	}
 	return ns
 }
 
+func (t *NamespaceTable) remove(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.byName, name)
+	delete(t.shared, name)
+}
+
+func AllNamespaces() ISeq {
+	return Seq(CurrentNamespaceTable().all())
+}
+
+func FindNamespace(sym *Symbol) *Namespace {
+	return CurrentNamespaceTable().find(sym.String())
+}
+
+func FindOrCreateNamespace(sym *Symbol) *Namespace {
+	return CurrentNamespaceTable().findOrCreate(sym)
+}
+
+// RemoveNamespace removes the namespace named sym from the current
+// table. Removing a shared namespace from a table other than the root
+// stops sharing it.
 func RemoveNamespace(sym *Symbol) {
 	if sym.String() == "clojure.core" {
 		panic(errors.New("cannot remove clojure.core namespace"))
 	}
 
-	nsMtx.Lock()
-	defer nsMtx.Unlock()
-	delete(namespaces, sym.String())
+	CurrentNamespaceTable().remove(sym.String())
 }
 
 func NamespaceFor(inns *Namespace, sym *Symbol) *Namespace {
@@ -190,10 +313,45 @@ func (ns *Namespace) Intern(sym *Symbol) *Var {
 	ns.mappingsMtx.Lock()
 	defer ns.mappingsMtx.Unlock()
 
+	return ns.internLocked(sym, func() *Var { return NewVar(ns, sym) })
+}
+
+// InternVars interns vars named syms in this namespace, as Intern does
+// each, allocating the vars it creates together. It is intended for
+// generated loaders that restore many vars at once.
+func (ns *Namespace) InternVars(syms []*Symbol) []*Var {
+	for _, sym := range syms {
+		if sym.Namespace() != "" {
+			panic(fmt.Errorf("can't intern qualified name: %s", sym))
+		}
+	}
+	vars := make([]*Var, len(syms))
+	var created []Var
+	var states []varState
+
+	ns.mappingsMtx.Lock()
+	defer ns.mappingsMtx.Unlock()
+
+	for i, sym := range syms {
+		vars[i] = ns.internLocked(sym, func() *Var {
+			if len(created) == 0 {
+				created = make([]Var, len(syms)-i)
+				states = make([]varState, len(syms)-i)
+			}
+			v := &created[0]
+			initVar(v, ns, sym, &states[0])
+			created, states = created[1:], states[1:]
+			return v
+		})
+	}
+	return vars
+}
+
+func (ns *Namespace) internLocked(sym *Symbol, newVar func() *Var) *Var {
 	key := sym.String()
 	mapping, exists := ns.visibleMappingLocked(key)
 	if !exists {
-		v := NewVar(ns, sym)
+		v := newVar()
 		ns.ensureMappingsMutableLocked()
 		ns.mappings[key] = namespaceMapping{sym: sym, val: v}
 		ns.mappingsSnapshot = nil
@@ -203,7 +361,7 @@ func (ns *Namespace) Intern(sym *Symbol) *Var {
 	if ns.isInternedMapping(sym, o) {
 		return o.(*Var)
 	}
-	v := NewVar(ns, sym)
+	v := newVar()
 	if ns.checkReplacement(sym, o, v) {
 		ns.ensureMappingsMutableLocked()
 		ns.mappings[key] = namespaceMapping{sym: sym, val: v}
@@ -226,7 +384,13 @@ func (ns *Namespace) checkReplacement(sym *Symbol, old, neu interface{}) bool {
 		| alias mapping  | name -> other/whatever | warn + replace                       | warn + replace                      |
 	*/
 
-	errOut := GlobalEnv.Stderr()
+	var errOut io.Writer
+	if v := errVar.Load(); v != nil {
+		errOut, _ = v.Deref().(io.Writer)
+	}
+	if errOut == nil {
+		errOut = GlobalEnv.Stderr()
+	}
 
 	if _, ok := old.(*Var); ok {
 		var nns *Namespace
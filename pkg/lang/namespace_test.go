@@ -1,6 +1,7 @@
 package lang
 
 import (
+	"strings"
 	"sync"
 	"testing"
 )
@@ -51,6 +52,24 @@ func TestNamespaceReferAll(t *testing.T) {
 	}
 }
 
+func TestNamespaceReplacementWarnsOnErr(t *testing.T) {
+	source := NewNamespace(NewSymbol("replacement-warning-source"))
+	source.Intern(NewSymbol("shadowed"))
+	target := NewNamespace(NewSymbol("replacement-warning-target"))
+	target.ReferAll(source, []NamespaceReference{
+		{Alias: NewSymbol("shadowed"), Source: NewSymbol("shadowed")},
+	})
+
+	var errOut strings.Builder
+	PushThreadBindings(NewMap(VarErr, &errOut))
+	defer PopThreadBindings()
+	target.Intern(NewSymbol("shadowed"))
+
+	if got := errOut.String(); !strings.HasPrefix(got, "WARNING: shadowed already refers to") {
+		t.Fatalf("*err* = %q, want a replacement warning", got)
+	}
+}
+
 func TestNamespaceReferAllSnapshotIsLazyAndStable(t *testing.T) {
 	source := NewNamespace(NewSymbol("refer-snapshot-source"))
 	first := source.Intern(NewSymbol("first"))
@@ -126,3 +145,27 @@ func TestNamespaceConcurrentIntern(t *testing.T) {
 		}
 	}
 }
+
+func TestNamespaceInternVars(t *testing.T) {
+	ns := NewNamespace(NewSymbol("test.namespace-intern-vars"))
+	existing := ns.Intern(NewSymbol("existing"))
+
+	syms := NewSymbols([]string{"first", "existing", "second"})
+	vars := ns.InternVars(syms)
+
+	if vars[1] != existing {
+		t.Fatalf("existing var = %p, want %p", vars[1], existing)
+	}
+	for i, sym := range syms {
+		if got := ns.FindInternedVar(sym); got != vars[i] {
+			t.Fatalf("%s = %v, want %v", sym, got, vars[i])
+		}
+		if vars[i].Namespace() != ns || !Equals(vars[i].Symbol(), sym) {
+			t.Fatalf("var %d is %v, want %s/%s", i, vars[i], ns.Name(), sym)
+		}
+	}
+	vars[0].BindRoot(int64(1))
+	if got := vars[0].Get(); got != int64(1) || vars[2].IsBound() {
+		t.Fatalf("vars share roots: first = %v, second bound = %v", got, vars[2].IsBound())
+	}
+}
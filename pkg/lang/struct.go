@@ -225,10 +225,9 @@ func FieldOrMethod(v interface{}, name string) (interface{}, bool) {
 				return nil
 			}), true
 		case "getStackTrace", "GetStackTrace", "StackTrace":
-			// Hosted Go errors do not expose JVM program counters as
-			// StackTraceElement values. Return the faithful empty Java-array
-			// shape until a richer frame mapper is available.
-			return FnFunc0(func() any { return []any{} }), true
+			// Errors raised in Glojure code record its frames; other
+			// hosted Go errors have an empty trace.
+			return FnFunc0(func() any { return stackTraceElements(err) }), true
 		case "setStackTrace", "SetStackTrace":
 			return FnFunc1(func(any) any { return nil }), true
 		}
@@ -630,6 +629,23 @@ func MustHostCast[T any](value any) T {
 	return result
 }
 
+// ExportValue converts the result of a Glojure function to the Go type
+// declared by a generated export wrapper, coercing it as host calls coerce
+// their arguments.
+func ExportValue[T any](value any) T {
+	if result, ok := value.(T); ok {
+		return result
+	}
+	var result T
+	target := reflect.ValueOf(&result).Elem()
+	coerced, err := coerceGoValue(target.Type(), value)
+	if err != nil {
+		panic(NewIllegalArgumentError(err.Error()))
+	}
+	target.Set(coerced)
+	return result
+}
+
 func SetField(target interface{}, name string, val interface{}) error {
 	targetVal := reflect.ValueOf(target)
 
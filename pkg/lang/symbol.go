@@ -36,7 +36,26 @@ func NewSymbolUnchecked(s string) *Symbol {
 	return newSymbol(s)
 }
 
+// NewSymbols constructs the symbols named names, as NewSymbolUnchecked
+// does each, allocating them together. It is intended for generated
+// loaders that restore many symbols at once.
+func NewSymbols(names []string) []*Symbol {
+	syms := make([]Symbol, len(names))
+	ptrs := make([]*Symbol, len(names))
+	for i, s := range names {
+		initSymbol(&syms[i], s)
+		ptrs[i] = &syms[i]
+	}
+	return ptrs
+}
+
 func newSymbol(s string) *Symbol {
+	sym := &Symbol{}
+	initSymbol(sym, s)
+	return sym
+}
+
+func initSymbol(sym *Symbol, s string) {
 	ns, name := "", s
 	hasNs := false
 
@@ -46,7 +65,7 @@ func newSymbol(s string) *Symbol {
 		name = s[idx+1:]
 		hasNs = true
 	}
-	return &Symbol{
+	*sym = Symbol{
 		ns:    ns,
 		name:  name,
 		hash:  hashSymbol(ns, name),
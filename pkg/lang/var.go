@@ -49,9 +49,15 @@ type (
 	lazyVarMeta struct {
 		once sync.Once
 		fn   func() IPersistentMap
+		src  MetaSource
 		meta IPersistentMap
 	}
 
+	// MetaSource produces a var's metadata on first use. See SetMetaSource.
+	MetaSource interface {
+		VarMeta() IPersistentMap
+	}
+
 	varBindings map[*Var]*Box
 	glStorage   struct {
 		bindings []varBindings
@@ -60,6 +66,12 @@ type (
 	// TODO: public rev counter
 )
 
+func init() {
+	VarNamespaceTable.SetMeta(NewMap(KWPrivate, true))
+	namespaceTableVar.Store(VarNamespaceTable)
+	errVar.Store(VarErr)
+}
+
 func (uv *UnboundVar) String() string {
 	return "Unbound: " + uv.v.String()
 }
@@ -78,11 +90,15 @@ var (
 	VarAgent            = InternVarReplaceRoot(NSCore, NewSymbol("*agent*"), nil).SetDynamic()
 	VarPrintReadably    = InternVarReplaceRoot(NSCore, NewSymbol("*print-readably*"), true).SetDynamic()
 	VarOut              = InternVarReplaceRoot(NSCore, NewSymbol("*out*"), os.Stdout).SetDynamic()
+	VarErr              = InternVarReplaceRoot(NSCore, NewSymbol("*err*"), os.Stderr).SetDynamic()
 	VarIn               = InternVarReplaceRoot(NSCore, NewSymbol("*in*"), os.Stdin).SetDynamic()
 	VarAssert           = InternVarReplaceRoot(NSCore, NewSymbol("*assert*"), false).SetDynamic()
 	VarCompileFiles     = InternVarReplaceRoot(NSCore, NewSymbol("*compile-files*"), false).SetDynamic()
 	VarFile             = InternVarReplaceRoot(NSCore, NewSymbol("*file*"), "NO_SOURCE_FILE").SetDynamic()
 	VarDataReaders      = InternVarReplaceRoot(NSCore, NewSymbol("*data-readers*"), emptyMap).SetDynamic()
+	// VarNamespaceTable holds the *NamespaceTable that namespaces are
+	// found in and created in. Its root is nil, meaning the root table.
+	VarNamespaceTable = InternVarReplaceRoot(NSCore, NewSymbol("*namespace-table*"), nil).SetDynamic()
 
 	// TODO: use variant of InternVar that doesn't replace root.
 	VarPrintInitialized = InternVarName(NSCore.Name(), NewSymbol("print-initialized"))
@@ -123,19 +139,31 @@ func InternVarName(nsSym, nameSym *Symbol) *Var {
 }
 
 func NewVar(ns *Namespace, sym *Symbol) *Var {
-	v := &Var{
-		ns:      ns,
-		sym:     sym,
-		watches: emptyMap,
-	}
-	v.root.Store(&varRoot{
-		val:     &UnboundVar{v: v},
-		version: &VarRootVersion{},
-	})
-	v.meta.Store(NewBox(emptyMap))
+	v := &Var{}
+	initVar(v, ns, sym, &varState{})
 	return v
 }
 
+// varState holds the values a new var points to, so that vars created
+// together can be allocated together.
+type varState struct {
+	root    varRoot
+	unbound UnboundVar
+	version VarRootVersion
+	meta    Box
+}
+
+func initVar(v *Var, ns *Namespace, sym *Symbol, state *varState) {
+	v.ns = ns
+	v.sym = sym
+	v.watches = emptyMap
+	state.unbound.v = v
+	state.root = varRoot{val: &state.unbound, version: &state.version}
+	v.root.Store(&state.root)
+	state.meta.val = emptyMap
+	v.meta.Store(&state.meta)
+}
+
 func NewVarWithRoot(ns *Namespace, sym *Symbol, root interface{}) *Var {
 	v := NewVar(ns, sym)
 	v.BindRoot(root)
@@ -218,9 +246,14 @@ func (v *Var) Meta() IPersistentMap {
 	value := v.meta.Load().(*Box).val
 	if lazy, ok := value.(*lazyVarMeta); ok {
 		lazy.once.Do(func() {
-			lazy.meta = lazy.fn()
+			if lazy.fn != nil {
+				lazy.meta = lazy.fn()
+			} else {
+				lazy.meta = lazy.src.VarMeta()
+			}
 			lazy.meta = lazy.meta.Assoc(KWNS, v.ns).(IPersistentMap)
 			lazy.fn = nil
+			lazy.src = nil
 		})
 		return lazy.meta
 	}
@@ -242,6 +275,19 @@ func (v *Var) SetMetaLazy(fn func() IPersistentMap) {
 	v.meta.Store(NewBox(&lazyVarMeta{fn: fn}))
 }
 
+// SetMetaSource is like SetMetaLazy, but takes the metadata from src and
+// records up front whether it marks the var as a macro, so that macro
+// checks during analysis don't force it. Snapshot loaders use this to keep
+// metadata as static data until it is observed.
+func (v *Var) SetMetaSource(src MetaSource, macro bool) {
+	if macro {
+		v.isMacroCached.Store(2)
+	} else {
+		v.isMacroCached.Store(1)
+	}
+	v.meta.Store(NewBox(&lazyVarMeta{src: src}))
+}
+
 func (v *Var) AlterMeta(alter IFn, args ISeq) IPersistentMap {
 	meta := alter.ApplyTo(NewCons(v.Meta(), args)).(IPersistentMap)
 	v.SetMeta(meta)
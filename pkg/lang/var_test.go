@@ -181,3 +181,41 @@ func TestVarRootVersionChangesWithRoot(t *testing.T) {
 		t.Fatalf("altered root = %v, want 2", got)
 	}
 }
+
+type testMetaSource struct {
+	calls atomic.Int32
+	meta  IPersistentMap
+}
+
+func (s *testMetaSource) VarMeta() IPersistentMap {
+	s.calls.Add(1)
+	return s.meta
+}
+
+func TestVarMetaSource(t *testing.T) {
+	ns := FindOrCreateNamespace(NewSymbol("test.meta-source"))
+	v := InternVarReplaceRoot(ns, NewSymbol("value"), nil)
+	src := &testMetaSource{meta: NewMap(KWDoc, "source", KWMacro, true)}
+	v.SetMetaSource(src, true)
+
+	if !v.IsMacro() {
+		t.Fatal("var from macro source is not a macro")
+	}
+	if src.calls.Load() != 0 {
+		t.Fatal("metadata source read before the metadata was observed")
+	}
+	for range 2 {
+		meta := v.Meta()
+		if meta.ValAt(KWDoc) != "source" || meta.ValAt(KWNS) != ns {
+			t.Fatalf("metadata = %v", meta)
+		}
+	}
+	if src.calls.Load() != 1 {
+		t.Fatalf("metadata source read %d times, want 1", src.calls.Load())
+	}
+
+	v.SetMeta(NewMap())
+	if v.IsMacro() {
+		t.Fatal("macro flag from metadata source survived SetMeta")
+	}
+}
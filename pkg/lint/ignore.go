@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// ignoreMarker, discarded with #_ before a form, suppresses the
+// findings within the form.
+const ignoreMarker = ":glj/ignore"
+
+type region struct {
+	line, column, endLine, endColumn int
+}
+
+type regions []region
+
+func (rs regions) contains(line, col int) bool {
+	for _, r := range rs {
+		if (line > r.line || line == r.line && col >= r.column) &&
+			(line < r.endLine || line == r.endLine && col < r.endColumn) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredRegions returns the spans of src, from each #_:glj/ignore
+// marker to the end of the form after it. When the form's extent is not
+// known, the rest of the marker's line is ignored.
+func ignoredRegions(path, src string) regions {
+	runes := []rune(src)
+	lines := make([]int, len(runes)+1)
+	cols := make([]int, len(runes)+1)
+	line, col := 1, 1
+	for i, r := range runes {
+		lines[i], cols[i] = line, col
+		if r == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	lines[len(runes)], cols[len(runes)] = line, col
+
+	var rs regions
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case ';':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case '\\':
+			i++
+		case '"':
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+		case '#':
+			if i+1 >= len(runes) || runes[i+1] != '_' {
+				continue
+			}
+			j := i + 2
+			for j < len(runes) && (unicode.IsSpace(runes[j]) || runes[j] == ',') {
+				j++
+			}
+			end := j + len([]rune(ignoreMarker))
+			if end > len(runes) || string(runes[j:end]) != ignoreMarker ||
+				end < len(runes) && !isDelimiter(runes[end]) {
+				continue
+			}
+			r := region{line: lines[i], column: cols[i], endLine: lines[i], endColumn: 1 << 30}
+			if endLine, endCol, ok := formEnd(path, string(runes[end:]), lines[end], cols[end]); ok {
+				r.endLine, r.endColumn = endLine, endCol
+			}
+			rs = append(rs, r)
+			i = end - 1
+		}
+	}
+	return rs
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(",()[]{}\";", r)
+}
+
+// formEnd reads the first form of src, which starts at line and col of
+// the file, and returns the position just after it.
+func formEnd(path, src string, line, col int) (endLine, endCol int, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	rdr := reader.New(strings.NewReader(src),
+		reader.WithFilename(path),
+		reader.WithStartPosition(line, col))
+	form, err := rdr.ReadOne()
+	if err != nil {
+		return 0, 0, false
+	}
+	obj, isMeta := form.(lang.IMeta)
+	if !isMeta {
+		return 0, 0, false
+	}
+	meta := obj.Meta()
+	endLine, ok1 := lang.AsInt(lang.Get(meta, lang.KWEndLine))
+	endCol, ok2 := lang.AsInt(lang.Get(meta, lang.KWEndColumn))
+	return endLine, endCol + 1, ok1 && ok2
+}
@@ -0,0 +1,755 @@
+// Package lint reports likely mistakes in Glojure source without
+// running it.
+//
+// Each top-level form is read and analyzed with the compiler's
+// analyzer. Only the forms that later forms cannot be analyzed without
+// are evaluated: namespace declarations, requires and macro
+// definitions (see Evaluated). Other top-level side effects never run,
+// in the file or in the libs it requires (see Eval).
+//
+// A form preceded by #_:glj/ignore is not reported on.
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+	"github.com/glojurelang/glojure/pkg/reader"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// A Level is the severity of a finding.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+)
+
+// The rules findings are reported under.
+const (
+	RuleSyntax              = "syntax"
+	RuleAnalysis            = "analysis"
+	RuleUnresolvedSymbol    = "unresolved-symbol"
+	RuleUnresolvedNamespace = "unresolved-namespace"
+	RuleInvalidArity        = "invalid-arity"
+	RuleUnusedBinding       = "unused-binding"
+	RuleUnusedNamespace     = "unused-namespace"
+	RuleUnusedReferredVar   = "unused-referred-var"
+	RuleShadowedVar         = "shadowed-var"
+	RuleRedefinedVar        = "redefined-var"
+	RuleMisplacedDocstring  = "misplaced-docstring"
+	RuleRecurNotInTail      = "recur-not-in-tail-position"
+)
+
+// A Finding is a problem found in a file. Lines and columns count from
+// 1, columns in runes; EndColumn is one past the last rune.
+type Finding struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+	Level     Level  `json:"level"`
+	Rule      string `json:"rule"`
+	Message   string `json:"message"`
+}
+
+// evaluatedForms head the top-level forms Evaluated reports.
+var evaluatedForms = map[string]bool{
+	"ns":            true,
+	"in-ns":         true,
+	"require":       true,
+	"use":           true,
+	"import":        true,
+	"refer":         true,
+	"refer-clojure": true,
+	"alias":         true,
+	"defmacro":      true,
+}
+
+// Evaluated reports whether the top-level form is evaluated, rather
+// than only analyzed, when source is inspected without running it:
+// the forms after it cannot be analyzed without its effects on the
+// namespace or its macros.
+func Evaluated(form any) bool {
+	if _, ok := form.(lang.ISeq); !ok {
+		return false
+	}
+	head, ok := lang.First(form).(*lang.Symbol)
+	return ok && evaluatedForms[head.Name()] &&
+		(!head.HasNamespace() || head.Namespace() == "clojure.core")
+}
+
+// Eval evaluates form, a form Evaluated reports is evaluated. The libs
+// it loads from source, other than the standard library's, are
+// declared rather than run: each of their top-level forms is analyzed,
+// which interns the vars it defines, and only those Evaluated reports
+// are evaluated.
+func Eval(form any) error {
+	var err error
+	runtime.WithLibForms(declare, func() { err = eval(form) })
+	return err
+}
+
+// declare analyzes form, a top-level form of a required lib, and
+// evaluates it if Evaluated reports it is evaluated. The vars it
+// defines get the metadata of their names, such as their arglists.
+// Errors are left to the lint of the lib's own file.
+func declare(form any) {
+	if Evaluated(form) {
+		eval(form)
+		return
+	}
+	node, err := analyze(form, lang.VarCurrentNS.Deref().(*lang.Namespace))
+	if err != nil {
+		return
+	}
+	ast.Transform(node, func(n *ast.Node) (*ast.Node, error) {
+		if def, ok := n.Sub.(*ast.DefNode); ok && !def.Var.HasRoot() {
+			def.Var.SetMeta(def.Name.Meta())
+		}
+		return n, nil
+	})
+}
+
+// Paths lints the source files named by paths, and the .glj, .clj and
+// .cljc files under the directories among them, returning the
+// findings sorted by file and position.
+func Paths(paths []string) ([]Finding, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			switch filepath.Ext(p) {
+			case ".glj", ".clj", ".cljc":
+				if !d.IsDir() {
+					files = append(files, p)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var findings []Finding
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, File(file, string(src))...)
+	}
+	sortFindings(findings)
+	return findings, nil
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// File lints src, the content of the file at path, returning the
+// findings sorted by position.
+func File(path, src string) []Finding {
+	l := &linter{
+		path:     path,
+		ns:       lang.FindOrCreateNamespace(lang.NewSymbol("user")),
+		defs:     map[*lang.Var]*definition{},
+		bindings: map[position]*binding{},
+		excluded: map[string]bool{},
+	}
+
+	lang.PushThreadBindings(lang.NewMap(
+		lang.VarCurrentNS, l.ns,
+		lang.VarOut, io.Discard,
+		lang.VarErr, io.Discard,
+	))
+	defer lang.PopThreadBindings()
+	// Forms before any ns form are in user, which refers clojure.core
+	// as it does at the REPL.
+	if eval(lang.NewList(lang.NewSymbol("clojure.core/ns"), lang.NewSymbol("user"))) == nil {
+		l.ns = lang.VarCurrentNS.Deref().(*lang.Namespace)
+	}
+
+	rdr := reader.New(strings.NewReader(src),
+		reader.WithFilename(path),
+		reader.WithGetCurrentNS(func() *lang.Namespace { return l.ns }))
+	for {
+		form, err := readForm(rdr)
+		if errors.Is(err, reader.ErrEOF) {
+			break
+		}
+		if err != nil {
+			l.readError(err)
+			break
+		}
+		l.lintForm(form)
+	}
+	l.finish()
+
+	ignored := ignoredRegions(path, src)
+	findings := l.findings[:0]
+	for _, f := range l.findings {
+		if !ignored.contains(f.Line, f.Column) {
+			findings = append(findings, f)
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+type (
+	linter struct {
+		path     string
+		ns       *lang.Namespace
+		findings []Finding
+		// forms holds the top-level forms after the ns form, which are
+		// searched for uses of required namespaces.
+		forms    []any
+		requires []require
+		excluded map[string]bool
+
+		defs     map[*lang.Var]*definition
+		invokes  []invoke
+		bindings map[position]*binding
+	}
+
+	// position is the start of a form in the file.
+	position struct {
+		line, column int
+	}
+
+	definition struct {
+		line int
+		fn   *ast.FnNode
+	}
+
+	invoke struct {
+		vr   *lang.Var
+		argc int
+		form any
+	}
+
+	// A binding is a local bound by the symbol at a position. Macros
+	// may bind the symbol more than once.
+	binding struct {
+		sym  *lang.Symbol
+		used bool
+	}
+
+	require struct {
+		lib    *lang.Symbol
+		alias  *lang.Symbol
+		refers []*lang.Symbol
+	}
+)
+
+func readForm(rdr *reader.Reader) (form any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return rdr.ReadOne()
+}
+
+func (l *linter) readError(err error) {
+	f := Finding{File: l.path, Line: 1, Column: 1, Level: LevelError, Rule: RuleSyntax}
+	var rerr *reader.Error
+	if errors.As(err, &rerr) {
+		_, f.Line, f.Column = rerr.Position()
+		err = rerr.Unwrap()
+	}
+	f.EndLine, f.EndColumn = f.Line, f.Column+1
+	f.Message = err.Error()
+	l.findings = append(l.findings, f)
+}
+
+// report adds a finding for form, or for fallback if form has no
+// position in the file.
+func (l *linter) report(form, fallback any, level Level, rule, format string, args ...any) {
+	f := Finding{File: l.path, Level: level, Rule: rule, Message: fmt.Sprintf(format, args...)}
+	var ok bool
+	if f.Line, f.Column, f.EndLine, f.EndColumn, ok = l.span(form); !ok {
+		f.Line, f.Column, f.EndLine, f.EndColumn, ok = l.span(fallback)
+		if !ok {
+			f.Line, f.Column, f.EndLine, f.EndColumn = 1, 1, 1, 1
+		}
+	}
+	l.findings = append(l.findings, f)
+}
+
+// span returns where the reader found form in the file.
+func (l *linter) span(form any) (line, col, endLine, endCol int, ok bool) {
+	obj, isMeta := form.(lang.IMeta)
+	if !isMeta {
+		return 0, 0, 0, 0, false
+	}
+	meta := obj.Meta()
+	if lang.Get(meta, lang.KWFile) != l.path {
+		return 0, 0, 0, 0, false
+	}
+	line, ok1 := lang.AsInt(lang.Get(meta, lang.KWLine))
+	col, ok2 := lang.AsInt(lang.Get(meta, lang.KWColumn))
+	endLine, ok3 := lang.AsInt(lang.Get(meta, lang.KWEndLine))
+	endCol, ok4 := lang.AsInt(lang.Get(meta, lang.KWEndColumn))
+	return line, col, endLine, endCol + 1, ok1 && ok2 && ok3 && ok4
+}
+
+func (l *linter) lintForm(form any) {
+	if l.isNSForm(form) {
+		l.parseNSForm(form.(lang.ISeq))
+	} else {
+		l.forms = append(l.forms, form)
+	}
+	l.checkDocstring(form)
+
+	node, err := analyze(form, l.ns)
+	if err != nil {
+		var aerr *compiler.AnalysisError
+		if errors.As(err, &aerr) && strings.HasPrefix(aerr.Msg, "can only recur from tail position") {
+			l.report(aerr.Form, form, LevelError, RuleRecurNotInTail, "recur is not in tail position")
+			return
+		}
+		rule := RuleAnalysis
+		if strings.HasPrefix(err.Error(), "wrong number of arguments") {
+			// An inlined call with the wrong arity fails to expand.
+			rule = RuleInvalidArity
+		}
+		l.report(nil, form, LevelError, rule, "%s", firstLine(err.Error()))
+		return
+	}
+	ast.Transform(node, func(n *ast.Node) (*ast.Node, error) {
+		l.visit(n, form)
+		return n, nil
+	})
+
+	if Evaluated(form) {
+		if err := Eval(form); err != nil {
+			l.report(nil, form, LevelError, RuleAnalysis, "%s", firstLine(err.Error()))
+		}
+		l.ns = lang.VarCurrentNS.Deref().(*lang.Namespace)
+	}
+}
+
+func analyze(form any, ns *lang.Namespace) (node *ast.Node, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return runtime.Analyze(form, ns)
+}
+
+func eval(form any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	_, err = lang.GlobalEnv.Eval(form)
+	return err
+}
+
+// visit records what the node n in the top-level form top defines,
+// binds and uses, and reports the unresolved symbols it names.
+func (l *linter) visit(n *ast.Node, top any) {
+	switch n.Op {
+	case ast.OpBinding:
+		b := n.Sub.(*ast.BindingNode)
+		if line, col, _, _, ok := l.span(b.Name); ok {
+			pos := position{line, col}
+			if l.bindings[pos] == nil {
+				l.bindings[pos] = &binding{sym: b.Name}
+				l.checkShadowing(b.Name)
+			}
+		}
+	case ast.OpLocal:
+		if b := n.Sub.(*ast.LocalNode).Binding; b != nil {
+			if line, col, _, _, ok := l.span(b.Name); ok {
+				if bnd := l.bindings[position{line, col}]; bnd != nil {
+					bnd.used = true
+				}
+			}
+		}
+	case ast.OpInvoke:
+		inv := n.Sub.(*ast.InvokeNode)
+		if inv.Fn.Op == ast.OpVar {
+			l.invokes = append(l.invokes, invoke{vr: inv.Fn.Sub.(*ast.VarNode).Var, argc: len(inv.Args), form: n.Form})
+		}
+	case ast.OpDef:
+		l.visitDef(n)
+	case ast.OpMaybeClass:
+		sym, ok := n.Sub.(*ast.MaybeClassNode).Class.(*lang.Symbol)
+		if !ok {
+			break
+		}
+		if _, ok := pkgmap.Get(sym.FullName()); ok || strings.ContainsAny(sym.Name(), ".:") {
+			break
+		}
+		if _, _, _, _, ok := l.span(n.Form); ok {
+			l.report(n.Form, nil, LevelError, RuleUnresolvedSymbol, "unable to resolve symbol: %s", sym)
+		}
+	case ast.OpMaybeHostForm:
+		host := n.Sub.(*ast.MaybeHostFormNode)
+		class := lang.NewSymbol(host.Class)
+		if host.Class == "" || strings.ContainsAny(host.Class, ".:") ||
+			host.Class[0] >= 'A' && host.Class[0] <= 'Z' ||
+			l.ns.LookupAlias(class) != nil || lang.FindNamespace(class) != nil ||
+			len(pkgmap.PkgEntries(host.Class)) > 0 {
+			break
+		}
+		if _, _, _, _, ok := l.span(n.Form); ok {
+			l.report(n.Form, nil, LevelError, RuleUnresolvedNamespace, "unresolved namespace %s", host.Class)
+		}
+	}
+}
+
+func (l *linter) visitDef(n *ast.Node) {
+	def := n.Sub.(*ast.DefNode)
+	name, _ := lang.First(lang.Rest(n.Form)).(*lang.Symbol)
+	line, _, _, _, ok := l.span(name)
+	if !ok || def.Init == nil {
+		// Forward declarations and generated defs are not checked.
+		return
+	}
+
+	if prev := l.defs[def.Var]; prev != nil {
+		l.report(name, nil, LevelWarning, RuleRedefinedVar,
+			"%s is already defined on line %d", name, prev.line)
+	} else if core := lang.NSCore.FindInternedVar(lang.NewSymbol(name.Name())); core != nil &&
+		l.ns != lang.NSCore && core.IsPublic() && !l.excluded[name.Name()] {
+		l.report(name, nil, LevelWarning, RuleShadowedVar,
+			"%s shadows clojure.core/%s; exclude it with :refer-clojure", name, name.Name())
+	}
+
+	d := &definition{line: line}
+	init := def.Init
+	if init.Op == ast.OpWithMeta {
+		init = init.Sub.(*ast.WithMetaNode).Expr
+	}
+	if init.Op == ast.OpFn {
+		d.fn = init.Sub.(*ast.FnNode)
+	}
+	l.defs[def.Var] = d
+}
+
+// checkShadowing reports a local named sym that hides a var of
+// clojure.core.
+func (l *linter) checkShadowing(sym *lang.Symbol) {
+	if sym.HasNamespace() || strings.HasPrefix(sym.Name(), "_") {
+		return
+	}
+	if vr, ok := l.ns.GetMapping(sym).(*lang.Var); ok && vr.Namespace() == lang.NSCore {
+		l.report(sym, nil, LevelWarning, RuleShadowedVar, "%s shadows clojure.core/%s", sym, sym)
+	}
+}
+
+// checkDocstring reports a defn whose docstring follows its parameters,
+// where it is only an expression.
+func (l *linter) checkDocstring(form any) {
+	seq, ok := form.(lang.ISeq)
+	if !ok {
+		return
+	}
+	head, _ := seq.First().(*lang.Symbol)
+	if head == nil || (head.Name() != "defn" && head.Name() != "defn-" && head.Name() != "defmacro") {
+		return
+	}
+	elems := lang.Next(lang.Next(seq)) // after the name
+	if _, ok := lang.First(elems).(lang.IPersistentVector); !ok {
+		return
+	}
+	if _, ok := lang.First(lang.Next(elems)).(string); ok && lang.Next(lang.Next(elems)) != nil {
+		l.report(lang.First(lang.Next(seq)), form, LevelWarning, RuleMisplacedDocstring,
+			"the docstring of %s follows its parameters; move it before them", lang.First(lang.Next(seq)))
+	}
+}
+
+func (l *linter) isNSForm(form any) bool {
+	seq, ok := form.(lang.ISeq)
+	if !ok {
+		return false
+	}
+	head, ok := seq.First().(*lang.Symbol)
+	return ok && head.Name() == "ns" && len(l.requires) == 0 && len(l.forms) == 0
+}
+
+// parseNSForm records the requires and :refer-clojure exclusions of an
+// ns form.
+func (l *linter) parseNSForm(form lang.ISeq) {
+	for seq := lang.Seq(lang.Next(lang.Next(form))); seq != nil; seq = seq.Next() {
+		clause, ok := seq.First().(lang.ISeq)
+		if !ok {
+			continue
+		}
+		switch lang.First(clause) {
+		case lang.NewKeyword("require"):
+			for spec := lang.Seq(clause.Next()); spec != nil; spec = spec.Next() {
+				if vec, ok := spec.First().(lang.IPersistentVector); ok {
+					l.parseLibspec(vec)
+				}
+			}
+		case lang.NewKeyword("refer-clojure"):
+			opts := lang.Seq(clause.Next())
+			for ; opts != nil && opts.Next() != nil; opts = opts.Next().Next() {
+				if opts.First() != lang.NewKeyword("exclude") {
+					continue
+				}
+				for names := lang.Seq(opts.Next().First()); names != nil; names = names.Next() {
+					if sym, ok := names.First().(*lang.Symbol); ok {
+						l.excluded[sym.Name()] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func (l *linter) parseLibspec(spec lang.IPersistentVector) {
+	seq := lang.Seq(spec)
+	lib, ok := seq.First().(*lang.Symbol)
+	if !ok {
+		return
+	}
+	req := require{lib: lib}
+	for opts := seq.Next(); opts != nil && opts.Next() != nil; opts = opts.Next().Next() {
+		switch opts.First() {
+		case lang.NewKeyword("as"), lang.NewKeyword("as-alias"):
+			req.alias, _ = opts.Next().First().(*lang.Symbol)
+		case lang.NewKeyword("refer"):
+			for names := lang.Seq(opts.Next().First()); names != nil; names = names.Next() {
+				if sym, ok := names.First().(*lang.Symbol); ok {
+					req.refers = append(req.refers, sym)
+				}
+			}
+		}
+	}
+	if req.alias != nil || len(req.refers) > 0 {
+		l.requires = append(l.requires, req)
+	}
+}
+
+// finish reports what can only be known once the whole file has been
+// analyzed: unused bindings and requires, and calls with the wrong
+// number of arguments.
+func (l *linter) finish() {
+	for _, b := range l.bindings {
+		if !b.used && !strings.HasPrefix(b.sym.Name(), "_") && b.sym.Name() != "&" {
+			l.report(b.sym, nil, LevelWarning, RuleUnusedBinding, "unused binding %s", b.sym)
+		}
+	}
+
+	for _, inv := range l.invokes {
+		if inv.vr.IsMacro() {
+			continue
+		}
+		fixed, variadic, ok := l.arities(inv.vr)
+		if !ok || acceptsArgs(fixed, variadic, inv.argc) {
+			continue
+		}
+		l.report(inv.form, nil, LevelError, RuleInvalidArity, "%s/%s is called with %d %s but expects %s",
+			inv.vr.Namespace().Name(), inv.vr.Symbol().Name(), inv.argc, plural(inv.argc, "arg"),
+			expectedArgs(fixed, variadic))
+	}
+
+	l.checkRequires()
+}
+
+// arities returns the fixed arities of the function in vr, and the
+// least number of arguments its variadic arity takes, or -1. They come
+// from its def in the file or else its :arglists.
+func (l *linter) arities(vr *lang.Var) (fixed []int, variadic int, ok bool) {
+	variadic = -1
+	if def := l.defs[vr]; def != nil {
+		if def.fn == nil {
+			return nil, 0, false
+		}
+		for _, m := range def.fn.Methods {
+			method := m.Sub.(*ast.FnMethodNode)
+			if method.IsVariadic {
+				variadic = method.FixedArity
+			} else {
+				fixed = append(fixed, method.FixedArity)
+			}
+		}
+		return fixed, variadic, true
+	}
+	arglists := lang.Get(vr.Meta(), lang.KWArglists)
+	if arglists == nil {
+		return nil, 0, false
+	}
+	for seq := lang.Seq(arglists); seq != nil; seq = seq.Next() {
+		params, isVec := seq.First().(lang.IPersistentVector)
+		if !isVec {
+			return nil, 0, false
+		}
+		n := 0
+		isVariadic := false
+		for p := lang.Seq(params); p != nil; p = p.Next() {
+			if sym, isSym := p.First().(*lang.Symbol); isSym && sym.Name() == "&" {
+				isVariadic = true
+				break
+			}
+			n++
+		}
+		if isVariadic {
+			variadic = n
+		} else {
+			fixed = append(fixed, n)
+		}
+	}
+	return fixed, variadic, len(fixed) > 0 || variadic >= 0
+}
+
+func acceptsArgs(fixed []int, variadic, argc int) bool {
+	if variadic >= 0 && argc >= variadic {
+		return true
+	}
+	for _, n := range fixed {
+		if n == argc {
+			return true
+		}
+	}
+	return false
+}
+
+func expectedArgs(fixed []int, variadic int) string {
+	sort.Ints(fixed)
+	var parts []string
+	for _, n := range fixed {
+		if variadic < 0 || n < variadic {
+			parts = append(parts, fmt.Sprint(n))
+		}
+	}
+	if variadic >= 0 {
+		parts = append(parts, fmt.Sprintf("%d or more", variadic))
+	}
+	switch len(parts) {
+	case 1:
+		return parts[0]
+	default:
+		return strings.Join(parts[:len(parts)-1], ", ") + " or " + parts[len(parts)-1]
+	}
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+// checkRequires reports required namespaces, and vars referred from
+// them, that the rest of the file never names.
+func (l *linter) checkRequires() {
+	usedNS := map[string]bool{}
+	usedVars := map[*lang.Var]bool{}
+	var walk func(form any)
+	walk = func(form any) {
+		switch form := form.(type) {
+		case *lang.Symbol:
+			if form.HasNamespace() {
+				nsSym := lang.NewSymbol(form.Namespace())
+				if target := l.ns.LookupAlias(nsSym); target != nil {
+					usedNS[target.Name().String()] = true
+				}
+				usedNS[form.Namespace()] = true
+			}
+			if vr := resolveVar(l.ns, form); vr != nil {
+				usedVars[vr] = true
+				usedNS[vr.Namespace().Name().String()] = true
+			}
+		case lang.Keyword:
+			if ns, ok := form.Namespace().(string); ok {
+				usedNS[ns] = true
+			}
+		case lang.IPersistentMap:
+			for seq := lang.Seq(form); seq != nil; seq = seq.Next() {
+				entry := seq.First().(lang.IMapEntry)
+				walk(entry.Key())
+				walk(entry.Val())
+			}
+		case lang.ISeq, lang.IPersistentVector, lang.IPersistentSet:
+			for seq := lang.Seq(form); seq != nil; seq = seq.Next() {
+				walk(seq.First())
+			}
+		}
+	}
+	for _, form := range l.forms {
+		walk(form)
+	}
+
+	for _, req := range l.requires {
+		if !usedNS[req.lib.String()] {
+			l.report(req.lib, nil, LevelWarning, RuleUnusedNamespace,
+				"namespace %s is required but never used", req.lib)
+			continue
+		}
+		for _, sym := range req.refers {
+			if vr := resolveVar(l.ns, sym); vr == nil || !usedVars[vr] {
+				l.report(sym, nil, LevelWarning, RuleUnusedReferredVar,
+					"%s is referred from %s but never used", sym, req.lib)
+			}
+		}
+	}
+}
+
+func resolveVar(ns *lang.Namespace, sym *lang.Symbol) (vr *lang.Var) {
+	defer func() {
+		if recover() != nil {
+			vr = nil
+		}
+	}()
+	if sym.HasNamespace() {
+		target := lang.NamespaceFor(ns, sym)
+		if target == nil {
+			return nil
+		}
+		vr, _ = target.GetMapping(lang.NewSymbol(sym.Name())).(*lang.Var)
+		return vr
+	}
+	vr, _ = ns.GetMapping(sym).(*lang.Var)
+	return vr
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,270 @@
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+	"github.com/glojurelang/glojure/pkg/lint"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// summary formats a finding as line:column rule.
+func summary(f lint.Finding) string {
+	return fmt.Sprintf("%d:%d %s", f.Line, f.Column, f.Rule)
+}
+
+func summaries(findings []lint.Finding) string {
+	var lines []string
+	for _, f := range findings {
+		lines = append(lines, summary(f))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "unresolved",
+			src: `(ns lint-test.unresolved)
+(defn f [] (g 1))
+(defn h [] (nope/thing 1))`,
+			want: []string{"2:13 unresolved-symbol", "3:13 unresolved-namespace"},
+		},
+		{
+			name: "arity",
+			src: `(ns lint-test.arity)
+(defn one [x] x)
+(defn many ([] 0) ([a b & more] (apply + a b more)))
+(one 1 2)
+(many 1)
+(many 1 2 3)
+(inc 1 2)`,
+			want: []string{"4:1 invalid-arity", "5:1 invalid-arity", "7:1 invalid-arity"},
+		},
+		{
+			name: "unused bindings",
+			src: `(ns lint-test.unused-bindings)
+(defn f [a _b]
+  (let [c 1 d 2]
+    (+ a d)))`,
+			want: []string{"3:9 unused-binding"},
+		},
+		{
+			name: "unused requires",
+			src: `(ns lint-test.unused-requires
+  (:require [clojure.string :as str :refer [join blank?]]
+            [clojure.template :as template]
+            [clojure.walk :refer [postwalk]]))
+(defn f [xs] (str/upper-case (join xs)))
+(defn g [x] (postwalk identity x))`,
+			want: []string{"2:50 unused-referred-var", "3:14 unused-namespace"},
+		},
+		{
+			name: "shadowed",
+			src: `(ns lint-test.shadowed
+  (:refer-clojure :exclude [first]))
+(defn first [xs] xs)
+(defn count [_xs] 0)
+(defn f [name] name)`,
+			want: []string{"4:7 shadowed-var", "5:10 shadowed-var"},
+		},
+		{
+			name: "redefined",
+			src: `(ns lint-test.redefined)
+(declare x)
+(def x 1)
+(def x 2)`,
+			want: []string{"4:6 redefined-var"},
+		},
+		{
+			name: "misplaced docstring",
+			src: `(ns lint-test.docstring)
+(defn f [x]
+  "Returns x."
+  x)
+(defn g [_x] "just a string")`,
+			want: []string{"2:7 misplaced-docstring"},
+		},
+		{
+			name: "recur",
+			src: `(ns lint-test.recur)
+(defn f [x]
+  (loop [i x]
+    (inc (recur (dec i)))))`,
+			want: []string{"4:10 recur-not-in-tail-position"},
+		},
+		{
+			name: "syntax",
+			src: `(ns lint-test.syntax)
+(defn f [x]
+  (+ x 1]`,
+			want: []string{"3:9 syntax"},
+		},
+		{
+			name: "no ns",
+			src:  `(println (str "ok"))`,
+		},
+		{
+			name: "top-level effects do not run",
+			src: `(ns lint-test.effects)
+(def boom (throw (ex-info "ran" {})))
+(spit "/nonexistent/lint-test" "x")`,
+		},
+		{
+			name: "ignore",
+			src: `(ns lint-test.ignore)
+#_:glj/ignore
+(defn f [unused] (missing))
+#_ :glj/ignore (g)
+(h) "#_:glj/ignore" ; #_:glj/ignore
+(i)`,
+			want: []string{"5:2 unresolved-symbol", "6:2 unresolved-symbol"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := summaries(lint.File("test.glj", test.src))
+			if want := strings.Join(test.want, "\n"); got != want {
+				t.Errorf("got findings\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestMessages(t *testing.T) {
+	findings := lint.File("test.glj", `(ns lint-test.messages)
+(defn f ([x] x) ([_x y & _z] y))
+(f)
+(def v 1)
+(def v 2)`)
+	var got []string
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%s %s", f.Level, f.Message))
+	}
+	want := []string{
+		"error lint-test.messages/f is called with 0 args but expects 1 or 2 or more",
+		"warning v is already defined on line 4",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if f := findings[0]; f.EndLine != 3 || f.EndColumn != 4 {
+		t.Errorf("got end %d:%d, want 3:4", f.EndLine, f.EndColumn)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.glj", "(ns lint-test.paths-a)\n(defn f [] (nope))\n")
+	write("sub/b.clj", "(ns lint-test.paths-b)\n(defn g [x] 1)\n")
+	write("sub/notes.txt", "(nope)")
+	write(".hidden/c.glj", "(nope)")
+
+	findings, err := lint.Paths([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		rel, _ := filepath.Rel(dir, f.File)
+		got = append(got, rel+" "+summary(f))
+	}
+	want := "a.glj 2:13 unresolved-symbol\nsub/b.clj 2:10 unused-binding"
+	if strings.Join(got, "\n") != want {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), want)
+	}
+
+	if _, err := lint.Paths([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
+
+func TestRequiredLibsAreNotRun(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	lib := fmt.Sprintf(`(ns lint-test.required-lib)
+(os.Mkdir %q 0755)
+(defmacro twice [x] (list 'do x x))
+(defn greet [who] (str "hi " who))`, marker)
+	if err := os.MkdirAll(filepath.Join(dir, "lint_test"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lint_test", "required_lib.glj"), []byte(lib), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runtime.AddLoadPath(os.DirFS(dir))
+
+	findings := lint.File("test.glj", `(ns lint-test.requires-lib (:require [lint-test.required-lib :as lib]))
+(lib/twice (lib/greet "a"))
+(lib/greet 1 2)`)
+	if got, want := summaries(findings), "3:1 invalid-arity"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("linting ran a top-level form of a required lib")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	findings := lint.File("test.glj", "(ns lint-test.write)\n(defn f [x] (g))\n")
+
+	var buf bytes.Buffer
+	if err := lint.Write(&buf, findings, lint.FormatHuman); err != nil {
+		t.Fatal(err)
+	}
+	want := `test.glj:2:10: warning: unused binding x [unused-binding]
+test.glj:2:14: error: unable to resolve symbol: g [unresolved-symbol]
+1 error, 1 warning
+`
+	if buf.String() != want {
+		t.Errorf("human output:\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := lint.Write(&buf, findings[:1], lint.FormatEDN); err != nil {
+		t.Fatal(err)
+	}
+	wantEDN := `[{:file "test.glj", :line 2, :column 10, :end-line 2, :end-column 11, :level :warning, :rule :unused-binding, :message "unused binding x"}]` + "\n"
+	if buf.String() != wantEDN {
+		t.Errorf("edn output:\n%s\nwant\n%s", buf.String(), wantEDN)
+	}
+
+	buf.Reset()
+	if err := lint.Write(&buf, findings, lint.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[1]["rule"] != "unresolved-symbol" || decoded[1]["endColumn"] != float64(15) {
+		t.Errorf("json output: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := lint.Write(&buf, nil, lint.FormatJSON); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("json output for no findings: %q, %v", buf.String(), err)
+	}
+	if err := lint.Write(&buf, nil, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
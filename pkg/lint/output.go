@@ -0,0 +1,64 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// The formats Write accepts.
+const (
+	FormatHuman = "human"
+	FormatEDN   = "edn"
+	FormatJSON  = "json"
+)
+
+// Write writes findings to w in format: one line per finding followed
+// by a count for FormatHuman, or a vector of maps for FormatEDN and an
+// array of objects for FormatJSON.
+func Write(w io.Writer, findings []Finding, format string) error {
+	switch format {
+	case FormatHuman:
+		var errs, warnings int
+		for _, f := range findings {
+			if f.Level == LevelError {
+				errs++
+			} else {
+				warnings++
+			}
+			if _, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s [%s]\n",
+				f.File, f.Line, f.Column, f.Level, f.Message, f.Rule); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "%d %s, %d %s\n", errs, plural(errs, "error"), warnings, plural(warnings, "warning"))
+		return err
+	case FormatEDN:
+		items := make([]any, len(findings))
+		for i, f := range findings {
+			items[i] = lang.NewMap(
+				lang.NewKeyword("file"), f.File,
+				lang.NewKeyword("line"), int64(f.Line),
+				lang.NewKeyword("column"), int64(f.Column),
+				lang.NewKeyword("end-line"), int64(f.EndLine),
+				lang.NewKeyword("end-column"), int64(f.EndColumn),
+				lang.NewKeyword("level"), lang.NewKeyword(string(f.Level)),
+				lang.NewKeyword("rule"), lang.NewKeyword(f.Rule),
+				lang.NewKeyword("message"), f.Message,
+			)
+		}
+		_, err := fmt.Fprintln(w, lang.PrintString(lang.NewVector(items...)))
+		return err
+	case FormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(findings)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
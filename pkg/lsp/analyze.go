@@ -0,0 +1,382 @@
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/lint"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+	"github.com/glojurelang/glojure/pkg/reader"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// A document is the text of a source file with the results of
+// analyzing it.
+type document struct {
+	uri   string
+	path  string
+	text  string
+	lines []string
+
+	// ns is the namespace in effect at the end of the document.
+	ns          *lang.Namespace
+	diagnostics []Diagnostic
+	refs        []reference
+	defs        []definition
+	symbols     []DocumentSymbol
+}
+
+// A reference is a symbol in a document that names a var.
+type reference struct {
+	rng Range
+	vr  *lang.Var
+}
+
+// A definition is a def of a var in a document.
+type definition struct {
+	vr      *lang.Var
+	rng     Range
+	nameRng Range
+	// meta is the metadata of the name, which holds the docstring and
+	// arglists before the def is evaluated.
+	meta lang.IPersistentMap
+}
+
+// analyzeDocument reads text, the content of the file at path, and
+// analyzes each top-level form in turn. Reading stops at the first
+// read error. Output printed while evaluating goes to out.
+func analyzeDocument(uri, path, text string, out io.Writer) *document {
+	doc := &document{
+		uri:   uri,
+		path:  path,
+		text:  text,
+		lines: strings.Split(text, "\n"),
+		ns:    lang.FindOrCreateNamespace(lang.NewSymbol("user")),
+	}
+
+	lang.PushThreadBindings(lang.NewMap(
+		lang.VarCurrentNS, doc.ns,
+		lang.VarOut, out,
+		lang.VarErr, out,
+	))
+	defer lang.PopThreadBindings()
+	// Forms before any ns form are in user, which refers clojure.core
+	// as it does at the REPL.
+	if eval(lang.NewList(lang.NewSymbol("clojure.core/ns"), lang.NewSymbol("user"))) == nil {
+		doc.ns = lang.VarCurrentNS.Deref().(*lang.Namespace)
+	}
+
+	rdr := reader.New(strings.NewReader(text),
+		reader.WithFilename(path),
+		reader.WithGetCurrentNS(func() *lang.Namespace { return doc.ns }))
+	for {
+		form, err := readForm(rdr)
+		if errors.Is(err, reader.ErrEOF) {
+			break
+		}
+		if err != nil {
+			doc.readError(err)
+			break
+		}
+		doc.analyzeForm(form)
+	}
+	return doc
+}
+
+func readForm(rdr *reader.Reader) (form any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return rdr.ReadOne()
+}
+
+func (doc *document) readError(err error) {
+	rng := Range{End: doc.position(len(doc.lines), len([]rune(doc.lines[len(doc.lines)-1]))+1)}
+	var rerr *reader.Error
+	if errors.As(err, &rerr) {
+		_, line, col := rerr.Position()
+		rng.Start = doc.position(line, col)
+		rng.End = doc.position(line, col+1)
+		err = rerr.Unwrap()
+	}
+	doc.diagnostics = append(doc.diagnostics, Diagnostic{
+		Range:    rng,
+		Severity: SeverityError,
+		Source:   "glj",
+		Message:  err.Error(),
+	})
+}
+
+// analyzeForm analyzes the top-level form, indexing the vars it
+// defines and refers to, and evaluates it with lint.Eval if
+// lint.Evaluated reports it is evaluated.
+func (doc *document) analyzeForm(form any) {
+	rng, _ := doc.formRange(form)
+	report := func(severity int, msg string) {
+		doc.diagnostics = append(doc.diagnostics, Diagnostic{
+			Range:    rng,
+			Severity: severity,
+			Source:   "glj",
+			Message:  msg,
+		})
+	}
+
+	head, _ := lang.First(form).(*lang.Symbol)
+	if seq, ok := form.(lang.ISeq); ok && head != nil && head.Name() == "ns" {
+		if name, ok := lang.First(seq.Next()).(*lang.Symbol); ok {
+			nameRng, _ := doc.formRange(name)
+			doc.symbols = append(doc.symbols, DocumentSymbol{
+				Name:           name.String(),
+				Kind:           SymbolKindNamespace,
+				Range:          rng,
+				SelectionRange: nameRng,
+			})
+		}
+	}
+
+	defined := len(doc.symbols)
+	node, err := analyze(form, doc.ns)
+	if err != nil {
+		report(SeverityError, firstLine(err.Error()))
+		return
+	}
+	doc.index(node)
+
+	if lint.Evaluated(form) {
+		if err := lint.Eval(form); err != nil {
+			report(SeverityError, firstLine(err.Error()))
+		}
+		// defmacro marks its var a macro when evaluated.
+		for i := range doc.symbols[defined:] {
+			sym := &doc.symbols[defined+i]
+			if vr := resolveVar(doc.ns, sym.Name); vr != nil && vr.IsMacro() {
+				sym.Detail = "macro"
+			}
+		}
+		doc.ns = lang.VarCurrentNS.Deref().(*lang.Namespace)
+	}
+}
+
+func analyze(form any, ns *lang.Namespace) (node *ast.Node, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return runtime.Analyze(form, ns)
+}
+
+func eval(form any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	_, err = lang.GlobalEnv.Eval(form)
+	return err
+}
+
+// index records the definitions, var references and unresolved
+// symbols in node.
+func (doc *document) index(node *ast.Node) {
+	ast.Transform(node, func(n *ast.Node) (*ast.Node, error) {
+		switch n.Op {
+		case ast.OpVar:
+			doc.addRef(n.Form, n.Sub.(*ast.VarNode).Var)
+		case ast.OpTheVar:
+			doc.addRef(lang.First(lang.Rest(n.Form)), n.Sub.(*ast.TheVarNode).Var)
+		case ast.OpMaybeClass:
+			sym, ok := n.Sub.(*ast.MaybeClassNode).Class.(*lang.Symbol)
+			if !ok {
+				break
+			}
+			if _, ok := pkgmap.Get(sym.FullName()); ok {
+				break
+			}
+			if rng, ok := doc.formRange(n.Form); ok {
+				doc.diagnostics = append(doc.diagnostics, Diagnostic{
+					Range:    rng,
+					Severity: SeverityError,
+					Source:   "glj",
+					Message:  "unable to resolve symbol: " + sym.String(),
+				})
+			}
+		case ast.OpDef:
+			doc.addDef(n)
+		}
+		return n, nil
+	})
+}
+
+func (doc *document) addRef(form any, vr *lang.Var) {
+	if rng, ok := doc.formRange(form); ok {
+		doc.refs = append(doc.refs, reference{rng: rng, vr: vr})
+	}
+}
+
+func (doc *document) addDef(n *ast.Node) {
+	def := n.Sub.(*ast.DefNode)
+	name, ok := lang.First(lang.Rest(n.Form)).(*lang.Symbol)
+	if !ok {
+		return
+	}
+	nameRng, ok := doc.formRange(name)
+	if !ok {
+		return
+	}
+	rng, ok := doc.formRange(n.Form)
+	if !ok {
+		rng = nameRng
+	}
+	doc.defs = append(doc.defs, definition{
+		vr:      def.Var,
+		rng:     rng,
+		nameRng: nameRng,
+		meta:    name.Meta(),
+	})
+	doc.refs = append(doc.refs, reference{rng: nameRng, vr: def.Var})
+
+	init := def.Init
+	if init != nil && init.Op == ast.OpWithMeta {
+		init = init.Sub.(*ast.WithMetaNode).Expr
+	}
+	kind := SymbolKindVariable
+	switch {
+	case init == nil:
+	case init.Op == ast.OpFn:
+		kind = SymbolKindFunction
+	case init.Op == ast.OpConst:
+		kind = SymbolKindConstant
+	}
+	doc.symbols = append(doc.symbols, DocumentSymbol{
+		Name:           name.Name(),
+		Kind:           kind,
+		Range:          rng,
+		SelectionRange: nameRng,
+	})
+}
+
+// formRange returns the range the reader recorded for form, if it was
+// read from this document.
+func (doc *document) formRange(form any) (Range, bool) {
+	obj, ok := form.(lang.IMeta)
+	if !ok {
+		return Range{}, false
+	}
+	meta := obj.Meta()
+	if lang.Get(meta, lang.KWFile) != doc.path {
+		return Range{}, false
+	}
+	line, ok1 := lang.AsInt(lang.Get(meta, lang.KWLine))
+	col, ok2 := lang.AsInt(lang.Get(meta, lang.KWColumn))
+	endLine, ok3 := lang.AsInt(lang.Get(meta, lang.KWEndLine))
+	endCol, ok4 := lang.AsInt(lang.Get(meta, lang.KWEndColumn))
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return Range{}, false
+	}
+	// The reader's end column is that of the last rune of the form.
+	return Range{Start: doc.position(line, col), End: doc.position(endLine, endCol+1)}, true
+}
+
+// position converts a reader line and column, counting runes from 1,
+// to a Position.
+func (doc *document) position(line, col int) Position {
+	if line < 1 {
+		return Position{}
+	}
+	if line > len(doc.lines) {
+		return Position{Line: len(doc.lines)}
+	}
+	runes := []rune(doc.lines[line-1])
+	if col-1 > len(runes) {
+		col = len(runes) + 1
+	}
+	return Position{Line: line - 1, Character: len(utf16.Encode(runes[:max(col-1, 0)]))}
+}
+
+// offset returns the index in runes of pos within its line.
+func (doc *document) offset(pos Position) (line []rune, i int) {
+	if pos.Line < 0 || pos.Line >= len(doc.lines) {
+		return nil, 0
+	}
+	line = []rune(doc.lines[pos.Line])
+	units := 0
+	for i = 0; i < len(line) && units < pos.Character; i++ {
+		units += len(utf16.Encode(line[i : i+1]))
+	}
+	return line, i
+}
+
+// tokenAt returns the symbol or keyword around pos, and the part of it
+// before pos.
+func (doc *document) tokenAt(pos Position) (token, prefix string) {
+	line, i := doc.offset(pos)
+	start, end := i, i
+	for start > 0 && isTokenRune(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isTokenRune(line[end]) {
+		end++
+	}
+	return string(line[start:end]), string(line[start:i])
+}
+
+func isTokenRune(r rune) bool {
+	return !strings.ContainsRune(" \t\r\n,()[]{}\"';`@^~\\", r)
+}
+
+// varAt returns the var named at pos: the var of a reference the
+// analysis found there, or else the var the token there resolves to in
+// the document's namespace.
+func (doc *document) varAt(pos Position) (*lang.Var, *Range) {
+	for i := range doc.refs {
+		if contains(doc.refs[i].rng, pos) {
+			return doc.refs[i].vr, &doc.refs[i].rng
+		}
+	}
+	token, _ := doc.tokenAt(pos)
+	if token == "" || strings.HasPrefix(token, ":") {
+		return nil, nil
+	}
+	return resolveVar(doc.ns, token), nil
+}
+
+func resolveVar(ns *lang.Namespace, name string) (vr *lang.Var) {
+	defer func() {
+		if recover() != nil {
+			vr = nil
+		}
+	}()
+	sym := lang.NewSymbol(name)
+	if sym.HasNamespace() {
+		target := lang.NamespaceFor(ns, sym)
+		if target == nil {
+			return nil
+		}
+		vr, _ = target.GetMapping(lang.NewSymbol(sym.Name())).(*lang.Var)
+		return vr
+	}
+	vr, _ = ns.GetMapping(sym).(*lang.Var)
+	return vr
+}
+
+func contains(rng Range, pos Position) bool {
+	before := func(a, b Position) bool {
+		return a.Line < b.Line || a.Line == b.Line && a.Character <= b.Character
+	}
+	return before(rng.Start, pos) && before(pos, rng.End)
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,582 @@
+// Package lsp implements a Language Server Protocol server for Glojure.
+//
+// The server analyzes each open document with the reader and the
+// compiler's analyzer, publishing read and analysis errors as
+// diagnostics, and answers completion, hover, go-to-definition,
+// find-references, document symbol and formatting requests. Forms that
+// change the namespace or define macros are evaluated, so that the
+// forms after them can be analyzed; everything else is only analyzed.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/format"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/nrepl"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// A Server serves one client. Requests are handled one at a time, on
+// the goroutine that calls Serve.
+type Server struct {
+	opts  options
+	roots []string
+	out   io.Writer
+
+	// docs holds the open documents by URI.
+	docs map[string]*document
+	// files holds the analyzed source files under the roots that are
+	// not open, by path, once references have been asked for.
+	files map[string]*document
+
+	shutdown bool
+}
+
+type options struct {
+	roots []string
+	log   io.Writer
+}
+
+// An Option configures a Server.
+type Option func(*options)
+
+// WithRoots adds directories searched for references and definitions,
+// in addition to the workspace folders the client names.
+func WithRoots(dirs ...string) Option {
+	return func(o *options) {
+		o.roots = append(o.roots, dirs...)
+	}
+}
+
+// WithLog sends output printed by evaluated code to w. It is discarded
+// by default.
+func WithLog(w io.Writer) Option {
+	return func(o *options) {
+		o.log = w
+	}
+}
+
+// NewServer returns a server configured by opts.
+func NewServer(opts ...Option) *Server {
+	o := options{log: io.Discard}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Server{
+		opts:  o,
+		roots: append([]string(nil), o.roots...),
+		docs:  map[string]*document{},
+	}
+}
+
+// ErrExitWithoutShutdown is returned by Serve when the client sends
+// exit without first requesting shutdown.
+var ErrExitWithoutShutdown = errors.New("lsp: exit without shutdown")
+
+// Serve reads requests from r and writes responses and notifications
+// to w until the client sends exit or r is closed.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = w
+	br := bufio.NewReader(r)
+	for {
+		req, err := readMessage(br)
+		var rerr *responseError
+		if errors.As(err, &rerr) {
+			if err := writeMessage(w, &errorResponse{JSONRPC: "2.0", Error: rerr}); err != nil {
+				return err
+			}
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return ErrExitWithoutShutdown
+			}
+			return nil
+		}
+		if err := s.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+type (
+	requestHandler      func(*Server, json.RawMessage) (any, error)
+	notificationHandler func(*Server, json.RawMessage) error
+)
+
+var requestHandlers = map[string]requestHandler{
+	"initialize":                  (*Server).initialize,
+	"shutdown":                    (*Server).shutdownRequest,
+	"textDocument/completion":     (*Server).completion,
+	"textDocument/hover":          (*Server).hover,
+	"textDocument/definition":     (*Server).definition,
+	"textDocument/references":     (*Server).references,
+	"textDocument/documentSymbol": (*Server).documentSymbol,
+	"textDocument/formatting":     (*Server).formatting,
+}
+
+var notificationHandlers = map[string]notificationHandler{
+	"textDocument/didOpen":   (*Server).didOpen,
+	"textDocument/didChange": (*Server).didChange,
+	"textDocument/didClose":  (*Server).didClose,
+}
+
+func (s *Server) handle(req *request) error {
+	if req.ID == nil {
+		if h, ok := notificationHandlers[req.Method]; ok {
+			if err := h(s, req.Params); err != nil {
+				return s.notify("window/logMessage", map[string]any{"type": 1, "message": err.Error()})
+			}
+		}
+		return nil
+	}
+
+	h, ok := requestHandlers[req.Method]
+	if !ok {
+		return writeMessage(s.out, &errorResponse{JSONRPC: "2.0", ID: req.ID, Error: &responseError{
+			Code:    codeMethodNotFound,
+			Message: "method not found: " + req.Method,
+		}})
+	}
+	result, err := h(s, req.Params)
+	if err != nil {
+		rerr, ok := err.(*responseError)
+		if !ok {
+			rerr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		return writeMessage(s.out, &errorResponse{JSONRPC: "2.0", ID: req.ID, Error: rerr})
+	}
+	return writeMessage(s.out, &response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return writeMessage(s.out, &notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func decode(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p initializeParams
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	for _, folder := range p.WorkspaceFolders {
+		if dir := uriToPath(folder.URI); dir != "" {
+			s.roots = append(s.roots, dir)
+		}
+	}
+	if len(p.WorkspaceFolders) == 0 && p.RootURI != "" {
+		s.roots = append(s.roots, uriToPath(p.RootURI))
+	}
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync": textDocumentSyncFull,
+			"completionProvider": map[string]any{
+				"triggerCharacters": []string{"/", ":"},
+			},
+			"hoverProvider":              true,
+			"definitionProvider":         true,
+			"referencesProvider":         true,
+			"documentSymbolProvider":     true,
+			"documentFormattingProvider": true,
+		},
+		"serverInfo": map[string]any{
+			"name":    "glj",
+			"version": runtime.Version,
+		},
+	}, nil
+}
+
+func (s *Server) shutdownRequest(json.RawMessage) (any, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) didOpen(params json.RawMessage) error {
+	var p didOpenParams
+	if err := decode(params, &p); err != nil {
+		return err
+	}
+	return s.update(p.TextDocument.URI, p.TextDocument.Text)
+}
+
+func (s *Server) didChange(params json.RawMessage) error {
+	var p didChangeParams
+	if err := decode(params, &p); err != nil {
+		return err
+	}
+	if len(p.ContentChanges) == 0 {
+		return nil
+	}
+	// With full synchronization, the last change holds the whole text.
+	return s.update(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+}
+
+func (s *Server) didClose(params json.RawMessage) error {
+	var p struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+	}
+	if err := decode(params, &p); err != nil {
+		return err
+	}
+	doc, ok := s.docs[p.TextDocument.URI]
+	delete(s.docs, p.TextDocument.URI)
+	// Once indexed, the saved file stands in for the closed document.
+	if ok && s.files != nil && isSourceFile(doc.path) {
+		if text, err := os.ReadFile(doc.path); err == nil {
+			s.files[doc.path] = analyzeDocument(pathToURI(doc.path), doc.path, string(text), s.opts.log)
+		}
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         p.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+// update analyzes the new text of the document at uri and publishes its
+// diagnostics.
+func (s *Server) update(uri, text string) error {
+	doc := analyzeDocument(uri, uriToPath(uri), text, s.opts.log)
+	s.docs[uri] = doc
+	if s.files != nil {
+		delete(s.files, doc.path)
+	}
+	diagnostics := doc.diagnostics
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+// document returns the open document named by params.
+func (s *Server) document(params json.RawMessage) (*document, Position, error) {
+	var p textDocumentPositionParams
+	if err := decode(params, &p); err != nil {
+		return nil, Position{}, err
+	}
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return nil, Position{}, &responseError{Code: codeInvalidParams, Message: "document not open: " + p.TextDocument.URI}
+	}
+	return doc, p.Position, nil
+}
+
+func (s *Server) completion(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	_, prefix := doc.tokenAt(pos)
+	items := []CompletionItem{}
+	if prefix == "" {
+		return items, nil
+	}
+	seen := map[string]bool{}
+	for _, c := range nrepl.Completions(doc.ns, prefix) {
+		if seen[c.Candidate] {
+			continue
+		}
+		seen[c.Candidate] = true
+		item := CompletionItem{Label: c.Candidate, Detail: c.NS}
+		switch {
+		case c.Type == "keyword":
+			item.Kind = CompletionKindKeyword
+		case c.Type == "namespace":
+			item.Kind = CompletionKindModule
+		case c.Type == "class":
+			item.Kind = CompletionKindClass
+		case c.Type == "static-method":
+			item.Kind = CompletionKindFunction
+		case c.Var != nil:
+			item.Kind = CompletionKindVariable
+			if c.Var.IsMacro() || lang.Get(c.Var.Meta(), lang.KWArglists) != nil {
+				item.Kind = CompletionKindFunction
+			}
+			if doc, ok := lang.Get(c.Var.Meta(), lang.KWDoc).(string); ok && doc != "" {
+				item.Documentation = &MarkupContent{Kind: "plaintext", Value: doc}
+			}
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items, nil
+}
+
+func (s *Server) hover(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	vr, rng := doc.varAt(pos)
+	if vr == nil {
+		return nil, nil
+	}
+	meta := vr.Meta()
+	if lang.Get(meta, lang.KWDoc) == nil && lang.Get(meta, lang.KWArglists) == nil {
+		if def := s.findDefinition(vr, false); def != nil {
+			meta = def.meta
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("```clojure\n")
+	b.WriteString(vr.Namespace().Name().String() + "/" + vr.Symbol().Name())
+	if arglists := lang.Get(meta, lang.KWArglists); arglists != nil {
+		// Arglists read from a defn are still quoted.
+		if seq, ok := arglists.(lang.ISeq); ok && lang.Equals(seq.First(), lang.NewSymbol("quote")) {
+			arglists = lang.First(seq.Next())
+		}
+		for seq := lang.Seq(arglists); seq != nil; seq = seq.Next() {
+			b.WriteString("\n" + lang.PrintString(seq.First()))
+		}
+	}
+	b.WriteString("\n```")
+	if vr.IsMacro() || lang.Get(meta, lang.KWMacro) == true {
+		b.WriteString("\n\nMacro")
+	}
+	if doc, ok := lang.Get(meta, lang.KWDoc).(string); ok && doc != "" {
+		b.WriteString("\n\n" + doc)
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}, Range: rng}, nil
+}
+
+func (s *Server) definition(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	vr, _ := doc.varAt(pos)
+	if vr == nil {
+		return []Location{}, nil
+	}
+	if def := s.findDefinition(vr, true); def != nil {
+		return []Location{{URI: def.uri, Range: def.nameRng}}, nil
+	}
+	if loc, ok := s.metaLocation(vr); ok {
+		return []Location{loc}, nil
+	}
+	return []Location{}, nil
+}
+
+type foundDefinition struct {
+	definition
+	uri string
+}
+
+// findDefinition returns the def of vr in an open document or, if
+// searchRoots is set, in a source file under the roots.
+func (s *Server) findDefinition(vr *lang.Var, searchRoots bool) *foundDefinition {
+	docs := s.openDocuments()
+	if searchRoots {
+		docs = append(docs, s.rootFiles()...)
+	}
+	for _, doc := range docs {
+		for _, def := range doc.defs {
+			if def.vr == vr {
+				return &foundDefinition{definition: def, uri: doc.uri}
+			}
+		}
+	}
+	return nil
+}
+
+// metaLocation returns the location the :file, :line and :column
+// metadata of vr name, if the file is found, as is or relative to a
+// root.
+func (s *Server) metaLocation(vr *lang.Var) (Location, bool) {
+	meta := vr.Meta()
+	file, _ := lang.Get(meta, lang.KWFile).(string)
+	line, ok := lang.AsInt(lang.Get(meta, lang.KWLine))
+	if file == "" || !ok {
+		return Location{}, false
+	}
+	col, ok := lang.AsInt(lang.Get(meta, lang.KWColumn))
+	if !ok {
+		col = 1
+	}
+	candidates := []string{file}
+	if !filepath.IsAbs(file) {
+		candidates = nil
+		for _, root := range s.roots {
+			candidates = append(candidates, filepath.Join(root, file))
+		}
+	}
+	for _, path := range candidates {
+		text, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		doc := &document{lines: strings.Split(string(text), "\n")}
+		pos := doc.position(line, col)
+		return Location{URI: pathToURI(path), Range: Range{Start: pos, End: pos}}, true
+	}
+	return Location{}, false
+}
+
+func (s *Server) references(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	var p struct {
+		Context struct {
+			IncludeDeclaration bool `json:"includeDeclaration"`
+		} `json:"context"`
+	}
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	vr, _ := doc.varAt(pos)
+	locations := []Location{}
+	if vr == nil {
+		return locations, nil
+	}
+	for _, d := range append(s.openDocuments(), s.rootFiles()...) {
+		for _, ref := range d.refs {
+			if ref.vr != vr {
+				continue
+			}
+			if !p.Context.IncludeDeclaration && d.isDefinition(ref) {
+				continue
+			}
+			locations = append(locations, Location{URI: d.uri, Range: ref.rng})
+		}
+	}
+	return locations, nil
+}
+
+func (doc *document) isDefinition(ref reference) bool {
+	for _, def := range doc.defs {
+		if def.vr == ref.vr && def.nameRng == ref.rng {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) documentSymbol(params json.RawMessage) (any, error) {
+	doc, _, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	if doc.symbols == nil {
+		return []DocumentSymbol{}, nil
+	}
+	return doc.symbols, nil
+}
+
+func (s *Server) formatting(params json.RawMessage) (any, error) {
+	doc, _, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(doc.text)
+	if err != nil {
+		// Leave documents that cannot be read alone; their
+		// diagnostics say why.
+		return []TextEdit{}, nil
+	}
+	if formatted == doc.text {
+		return []TextEdit{}, nil
+	}
+	last := len(doc.lines)
+	end := doc.position(last, len([]rune(doc.lines[last-1]))+1)
+	return []TextEdit{{Range: Range{End: end}, NewText: formatted}}, nil
+}
+
+// openDocuments returns the open documents, ordered by URI.
+func (s *Server) openDocuments() []*document {
+	docs := make([]*document, 0, len(s.docs))
+	for _, doc := range s.docs {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].uri < docs[j].uri })
+	return docs
+}
+
+// rootFiles returns the analyzed source files under the roots that are
+// not open, analyzing them the first time it is called.
+func (s *Server) rootFiles() []*document {
+	if s.files == nil {
+		s.files = map[string]*document{}
+		open := map[string]bool{}
+		for _, doc := range s.docs {
+			open[doc.path] = true
+		}
+		for _, root := range s.roots {
+			filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() {
+					if path != root && strings.HasPrefix(d.Name(), ".") {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if !isSourceFile(path) || open[path] || s.files[path] != nil {
+					return nil
+				}
+				text, err := os.ReadFile(path)
+				if err != nil {
+					return nil
+				}
+				s.files[path] = analyzeDocument(pathToURI(path), path, string(text), s.opts.log)
+				return nil
+			})
+		}
+	}
+	docs := make([]*document, 0, len(s.files))
+	for _, doc := range s.files {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].uri < docs[j].uri })
+	return docs
+}
+
+func isSourceFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".glj", ".clj", ".cljc":
+		return true
+	}
+	return false
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,375 @@
+package lsp_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+	"github.com/glojurelang/glojure/pkg/lsp"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// client drives a Server through in-memory pipes.
+type client struct {
+	t      *testing.T
+	w      io.WriteCloser
+	r      *bufio.Reader
+	nextID int
+	// notifications holds the notifications read while waiting for
+	// responses.
+	notifications []rawMessage
+	done          chan error
+}
+
+type rawMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newClient(t *testing.T, root string) *client {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &client{t: t, w: inW, r: bufio.NewReader(outR), done: make(chan error, 1)}
+	go func() {
+		err := lsp.NewServer().Serve(inR, outW)
+		outW.Close()
+		c.done <- err
+	}()
+	t.Cleanup(func() { inW.Close() })
+
+	var init struct {
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	c.call("initialize", map[string]any{"rootUri": fileURI(root)}, &init)
+	if init.Capabilities["hoverProvider"] != true {
+		t.Fatalf("capabilities = %v", init.Capabilities)
+	}
+	c.notify("initialized", map[string]any{})
+	return c
+}
+
+func (c *client) send(msg map[string]any) {
+	msg["jsonrpc"] = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *client) read() rawMessage {
+	c.t.Helper()
+	type result struct {
+		msg rawMessage
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+		if err != nil {
+			ch <- result{err: err}
+			return
+		}
+		n, _ := strconv.Atoi(header.Get("Content-Length"))
+		body := make([]byte, n)
+		if _, err := io.ReadFull(c.r, body); err != nil {
+			ch <- result{err: err}
+			return
+		}
+		var msg rawMessage
+		err = json.Unmarshal(body, &msg)
+		ch <- result{msg: msg, err: err}
+	}()
+	select {
+	case res := <-ch:
+		if res.err != nil {
+			c.t.Fatal(res.err)
+		}
+		return res.msg
+	case <-time.After(60 * time.Second):
+		c.t.Fatal("timed out waiting for the server")
+		return rawMessage{}
+	}
+}
+
+// call sends a request and decodes its result into result, failing the
+// test on an error response.
+func (c *client) call(method string, params, result any) {
+	c.t.Helper()
+	if err := c.callErr(method, params, result); err != nil {
+		c.t.Fatalf("%s: %v", method, err)
+	}
+}
+
+func (c *client) callErr(method string, params, result any) error {
+	c.t.Helper()
+	c.nextID++
+	id := c.nextID
+	c.send(map[string]any{"id": id, "method": method, "params": params})
+	for {
+		msg := c.read()
+		if msg.ID == nil {
+			c.notifications = append(c.notifications, msg)
+			continue
+		}
+		if *msg.ID != id {
+			c.t.Fatalf("response to %d while waiting for %d", *msg.ID, id)
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("error %d: %s", msg.Error.Code, msg.Error.Message)
+		}
+		if result != nil {
+			if err := json.Unmarshal(msg.Result, result); err != nil {
+				c.t.Fatal(err)
+			}
+		}
+		return nil
+	}
+}
+
+func (c *client) notify(method string, params any) {
+	c.send(map[string]any{"method": method, "params": params})
+}
+
+// diagnostics returns the next diagnostics published for uri.
+func (c *client) diagnostics(uri string) []lsp.Diagnostic {
+	c.t.Helper()
+	for {
+		var msg rawMessage
+		if len(c.notifications) > 0 {
+			msg, c.notifications = c.notifications[0], c.notifications[1:]
+		} else {
+			msg = c.read()
+		}
+		if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var p struct {
+			URI         string           `json:"uri"`
+			Diagnostics []lsp.Diagnostic `json:"diagnostics"`
+		}
+		if err := json.Unmarshal(msg.Params, &p); err != nil {
+			c.t.Fatal(err)
+		}
+		if p.URI == uri {
+			return p.Diagnostics
+		}
+	}
+}
+
+func (c *client) open(uri, text string) []lsp.Diagnostic {
+	c.t.Helper()
+	c.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "clojure", "version": 1, "text": text},
+	})
+	return c.diagnostics(uri)
+}
+
+func at(uri string, line, character int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": line, "character": character},
+	}
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func writeFile(t *testing.T, path, text string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	root := t.TempDir()
+	c := newClient(t, root)
+	uri := fileURI(filepath.Join(root, "diag.glj"))
+
+	diags := c.open(uri, "(ns lsp.test.diag)\n\n(defn f [x]\n  (+ x missing))\n(defn g [] (f 1)")
+	if len(diags) != 2 {
+		t.Fatalf("diagnostics = %+v, want an unresolved symbol and a read error", diags)
+	}
+	unresolved := diags[0]
+	if unresolved.Message != "unable to resolve symbol: missing" ||
+		unresolved.Range != (lsp.Range{Start: lsp.Position{Line: 3, Character: 7}, End: lsp.Position{Line: 3, Character: 14}}) {
+		t.Errorf("unresolved symbol diagnostic = %+v", unresolved)
+	}
+	if diags[1].Severity != lsp.SeverityError || !strings.Contains(diags[1].Message, "EOF") {
+		t.Errorf("read error diagnostic = %+v", diags[1])
+	}
+
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []any{map[string]any{"text": "(ns lsp.test.diag)\n\n(defn f [x]\n  (+ x 1))\n(defn g [] (f 1))"}},
+	})
+	if diags := c.diagnostics(uri); len(diags) != 0 {
+		t.Errorf("diagnostics after fixing = %+v", diags)
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	runtime.AddLoadPath(os.DirFS(src))
+	writeFile(t, filepath.Join(src, "lsp", "test", "util.glj"), `(ns lsp.test.util)
+
+(defn greet
+  "Returns a greeting for name."
+  [name]
+  (str "hello, " name))
+`)
+	writeFile(t, filepath.Join(src, "lsp", "test", "other.glj"), `(ns lsp.test.other
+  (:require [lsp.test.util :refer [greet]]))
+
+(def greeting (greet "other"))
+`)
+	c := newClient(t, root)
+
+	uri := fileURI(filepath.Join(src, "lsp", "test", "main.glj"))
+	text := `(ns lsp.test.main
+  (:require [lsp.test.util :as u]))
+
+(defn shout
+  "Shouts s."
+  [s]
+  (str s "!"))
+
+(defn run []
+  (shout (u/greet "world")))
+`
+	if diags := c.open(uri, text); len(diags) != 0 {
+		t.Fatalf("diagnostics = %+v", diags)
+	}
+
+	t.Run("hover", func(t *testing.T) {
+		var hover lsp.Hover
+		c.call("textDocument/hover", at(uri, 9, 13), &hover)
+		for _, want := range []string{"lsp.test.util/greet", "[name]", "Returns a greeting for name."} {
+			if !strings.Contains(hover.Contents.Value, want) {
+				t.Errorf("hover = %q, want %q in it", hover.Contents.Value, want)
+			}
+		}
+		// A function defined in the document, but not evaluated.
+		c.call("textDocument/hover", at(uri, 9, 4), &hover)
+		if !strings.Contains(hover.Contents.Value, "Shouts s.") {
+			t.Errorf("hover = %q", hover.Contents.Value)
+		}
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		var items []lsp.CompletionItem
+		c.call("textDocument/completion", at(uri, 9, 12), &items)
+		if len(items) != 1 || items[0].Label != "u/greet" || items[0].Kind != lsp.CompletionKindFunction {
+			t.Errorf("completions of u/g = %+v", items)
+		}
+		c.call("textDocument/completion", at(uri, 9, 5), &items)
+		found := false
+		for _, item := range items {
+			found = found || item.Label == "shout"
+		}
+		if !found {
+			t.Errorf("completions of sho = %+v, want shout", items)
+		}
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		var locs []lsp.Location
+		c.call("textDocument/definition", at(uri, 9, 13), &locs)
+		want := lsp.Location{
+			URI:   fileURI(filepath.Join(src, "lsp", "test", "util.glj")),
+			Range: lsp.Range{Start: lsp.Position{Line: 2, Character: 6}, End: lsp.Position{Line: 2, Character: 11}},
+		}
+		if len(locs) != 1 || locs[0] != want {
+			t.Errorf("definition = %+v, want %+v", locs, want)
+		}
+		c.call("textDocument/definition", at(uri, 9, 3), &locs)
+		if len(locs) != 1 || locs[0].URI != uri || locs[0].Range.Start != (lsp.Position{Line: 3, Character: 6}) {
+			t.Errorf("definition of shout = %+v", locs)
+		}
+	})
+
+	t.Run("references", func(t *testing.T) {
+		var locs []lsp.Location
+		params := at(uri, 9, 13)
+		params["context"] = map[string]any{"includeDeclaration": false}
+		c.call("textDocument/references", params, &locs)
+		var files []string
+		for _, loc := range locs {
+			files = append(files, filepath.Base(loc.URI)+":"+strconv.Itoa(loc.Range.Start.Line))
+		}
+		if got := strings.Join(files, " "); got != "main.glj:9 other.glj:3" {
+			t.Errorf("references = %s", got)
+		}
+	})
+}
+
+func TestDocumentSymbolsAndFormatting(t *testing.T) {
+	root := t.TempDir()
+	c := newClient(t, root)
+	uri := fileURI(filepath.Join(root, "fmt.glj"))
+	c.open(uri, "(ns lsp.test.fmt)\n(def limit 10)\n(defn f [x]\n(inc x))\n(defmacro m [x]\n     x)\n")
+
+	var symbols []lsp.DocumentSymbol
+	c.call("textDocument/documentSymbol", map[string]any{"textDocument": map[string]any{"uri": uri}}, &symbols)
+	var got []string
+	for _, s := range symbols {
+		got = append(got, fmt.Sprintf("%s:%d:%d%s", s.Name, s.Kind, s.SelectionRange.Start.Line, s.Detail))
+	}
+	want := fmt.Sprintf("lsp.test.fmt:%d:0 limit:%d:1 f:%d:2 m:%d:4macro",
+		lsp.SymbolKindNamespace, lsp.SymbolKindConstant, lsp.SymbolKindFunction, lsp.SymbolKindFunction)
+	if strings.Join(got, " ") != want {
+		t.Errorf("symbols = %s, want %s", strings.Join(got, " "), want)
+	}
+
+	var edits []lsp.TextEdit
+	c.call("textDocument/formatting", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"options":      map[string]any{"tabSize": 2, "insertSpaces": true},
+	}, &edits)
+	if len(edits) != 1 || edits[0].NewText != "(ns lsp.test.fmt)\n(def limit 10)\n(defn f [x]\n  (inc x))\n(defmacro m [x]\n  x)\n" {
+		t.Errorf("edits = %+v", edits)
+	}
+}
+
+func TestUnknownMethodAndExit(t *testing.T) {
+	c := newClient(t, t.TempDir())
+	err := c.callErr("workspace/unknown", map[string]any{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "-32601") {
+		t.Errorf("unknown method error = %v", err)
+	}
+	c.call("shutdown", nil, nil)
+	c.notify("exit", nil)
+	select {
+	case err := <-c.done:
+		if err != nil {
+			t.Errorf("Serve = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not exit")
+	}
+}
@@ -0,0 +1,409 @@
+package nrepl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/format"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// ciderMiddleware answers tooling ops compatible with cider-nrepl from
+// Glojure's own namespaces and var metadata.
+var ciderMiddleware = []*Middleware{
+	opMiddleware("cider.info", []string{"clone"}, map[string]OpDoc{
+		"lookup": {
+			Doc:      "Returns the metadata of a var.",
+			Requires: map[string]string{"sym": "The symbol to look up."},
+			Optional: map[string]string{"ns": "The namespace to resolve the symbol in."},
+			Returns:  map[string]string{"info": "A map of the var's name, ns, doc, arglists-str, file, line and column."},
+		},
+		"eldoc": {
+			Doc:      "Returns the arglists and docstring of a var, for display while typing a call.",
+			Requires: map[string]string{"sym": "The symbol to look up."},
+			Optional: map[string]string{"ns": "The namespace to resolve the symbol in."},
+			Returns: map[string]string{
+				"eldoc":     "A list of arglists, each a list of strings.",
+				"type":      "function, macro or variable.",
+				"docstring": "The docstring.",
+			},
+		},
+	}, map[string]serverOp{
+		"lookup": (*Server).opLookup,
+		"eldoc":  (*Server).opEldoc,
+	}),
+	opMiddleware("cider.ns", nil, map[string]OpDoc{
+		"ns-list": {
+			Doc:     "Lists the loaded namespaces.",
+			Returns: map[string]string{"ns-list": "The sorted namespace names."},
+		},
+		"ns-vars": {
+			Doc:      "Lists the public vars of a namespace.",
+			Requires: map[string]string{"ns": "The namespace."},
+			Returns:  map[string]string{"ns-vars": "The sorted var names."},
+		},
+	}, map[string]serverOp{
+		"ns-list": (*Server).opNSList,
+		"ns-vars": (*Server).opNSVars,
+	}),
+	opMiddleware("cider.macroexpand", []string{"clone"}, map[string]OpDoc{
+		"macroexpand": {
+			Doc:      "Macroexpands a form.",
+			Requires: map[string]string{"code": "The form to expand."},
+			Optional: map[string]string{
+				"ns":       "The namespace to expand in.",
+				"expander": "macroexpand-1, macroexpand (the default) or macroexpand-all.",
+			},
+			Returns: map[string]string{"expansion": "The printed expansion."},
+		},
+	}, map[string]serverOp{
+		"macroexpand": (*Server).opMacroexpand,
+	}),
+	opMiddleware("cider.format", nil, map[string]OpDoc{
+		"format-code": {
+			Doc:      "Reindents code.",
+			Requires: map[string]string{"code": "The code to format."},
+			Returns:  map[string]string{"formatted-code": "The formatted code."},
+		},
+	}, map[string]serverOp{
+		"format-code": (*Server).opFormatCode,
+	}),
+	opMiddleware("cider.classpath", nil, map[string]OpDoc{
+		"classpath": {
+			Doc:     "Lists the load path, where namespaces are loaded from.",
+			Returns: map[string]string{"classpath": "The load path entries."},
+		},
+	}, map[string]serverOp{
+		"classpath": (*Server).opClasspath,
+	}),
+	opMiddleware("cider.stacktrace", []string{"clone"}, map[string]OpDoc{
+		"stacktrace": {
+			Doc:      "Analyzes *e of the session, replying once for each error in its cause chain.",
+			Requires: map[string]string{"session": "The session."},
+			Returns: map[string]string{
+				"class":      "The Go type of the error.",
+				"message":    "The error message.",
+				"stacktrace": "A list of frames, each with name, file and line.",
+				"data":       "The printed ex-data, if any.",
+			},
+		},
+		"analyze-last-stacktrace": {
+			Doc:      "Same as stacktrace.",
+			Requires: map[string]string{"session": "The session."},
+		},
+	}, map[string]serverOp{
+		"stacktrace":              (*Server).opStacktrace,
+		"analyze-last-stacktrace": (*Server).opStacktrace,
+	}),
+}
+
+func (s *Server) opLookup(msg map[string]interface{}, conn Transport) {
+	sess, vr := s.resolveVar(msg)
+	if vr == nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": sess.ID,
+			"status":  []interface{}{"lookup-error", "done"},
+		})
+		return
+	}
+	sendMsg(conn, map[string]interface{}{
+		"id":      msg["id"],
+		"session": sess.ID,
+		"info":    varInfo(vr),
+		"status":  []interface{}{"done"},
+	})
+}
+
+func (s *Server) opEldoc(msg map[string]interface{}, conn Transport) {
+	sess, vr := s.resolveVar(msg)
+	if vr == nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": sess.ID,
+			"status":  []interface{}{"no-eldoc", "done"},
+		})
+		return
+	}
+
+	eldoc := []interface{}{}
+	meta := vr.Meta()
+	for seq := lang.Seq(lang.Get(meta, lang.KWArglists)); seq != nil; seq = seq.Next() {
+		arglist := []interface{}{}
+		for args := lang.Seq(seq.First()); args != nil; args = args.Next() {
+			arglist = append(arglist, lang.PrintString(args.First()))
+		}
+		eldoc = append(eldoc, arglist)
+	}
+	typ := "variable"
+	if vr.IsMacro() {
+		typ = "macro"
+	} else if vr.IsBound() && lang.IsFn(vr.Get()) {
+		typ = "function"
+	}
+	resp := map[string]interface{}{
+		"id":      msg["id"],
+		"session": sess.ID,
+		"ns":      vr.Namespace().Name().String(),
+		"name":    vr.Symbol().Name(),
+		"eldoc":   eldoc,
+		"type":    typ,
+		"status":  []interface{}{"done"},
+	}
+	if doc, ok := lang.Get(meta, lang.KWDoc).(string); ok {
+		resp["docstring"] = doc
+	}
+	sendMsg(conn, resp)
+}
+
+func (s *Server) opNSList(msg map[string]interface{}, conn Transport) {
+	var names []string
+	for seq := lang.AllNamespaces(); seq != nil; seq = seq.Next() {
+		names = append(names, seq.First().(*lang.Namespace).Name().String())
+	}
+	sendMsg(conn, map[string]interface{}{
+		"id":      msg["id"],
+		"session": msgStr(msg, "session"),
+		"ns-list": sortedList(names),
+		"status":  []interface{}{"done"},
+	})
+}
+
+func (s *Server) opNSVars(msg map[string]interface{}, conn Transport) {
+	nsName := msgStr(msg, "ns")
+	ns := lang.FindNamespace(lang.NewSymbol(nsName))
+	if ns == nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": msgStr(msg, "session"),
+			"ns":      nsName,
+			"status":  []interface{}{"error", "namespace-not-found", "done"},
+		})
+		return
+	}
+
+	var names []string
+	for seq := lang.Seq(ns.Mappings()); seq != nil; seq = seq.Next() {
+		entry := seq.First().(lang.IMapEntry)
+		vr, ok := entry.Val().(*lang.Var)
+		if !ok || vr.Namespace() != ns || lang.IsTruthy(lang.Get(vr.Meta(), lang.KWPrivate)) {
+			continue
+		}
+		names = append(names, entry.Key().(*lang.Symbol).Name())
+	}
+	sendMsg(conn, map[string]interface{}{
+		"id":      msg["id"],
+		"session": msgStr(msg, "session"),
+		"ns-vars": sortedList(names),
+		"status":  []interface{}{"done"},
+	})
+}
+
+func sortedList(names []string) []interface{} {
+	sort.Strings(names)
+	list := make([]interface{}, len(names))
+	for i, name := range names {
+		list[i] = name
+	}
+	return list
+}
+
+func (s *Server) opMacroexpand(msg map[string]interface{}, conn Transport) {
+	sess := s.getOrCreateSession(msgStr(msg, "session"))
+	reply := func(resp map[string]interface{}) {
+		resp["id"] = msg["id"]
+		resp["session"] = sess.ID
+		sendMsg(conn, resp)
+	}
+	fail := func(err error) {
+		reply(map[string]interface{}{
+			"err":    err.Error() + "\n",
+			"status": []interface{}{"macroexpand-error", "done"},
+		})
+	}
+
+	var expander *lang.Var
+	switch msgStr(msg, "expander") {
+	case "", "macroexpand":
+		expander = coreVar("macroexpand")
+	case "macroexpand-1":
+		expander = coreVar("macroexpand-1")
+	case "macroexpand-all":
+		v, err := requireVar("clojure.walk", "macroexpand-all")
+		if err != nil {
+			fail(err)
+			return
+		}
+		expander = v
+	default:
+		fail(fmt.Errorf("unknown expander %q", msgStr(msg, "expander")))
+		return
+	}
+
+	bindings := sess.frame()
+	if nsName := msgStr(msg, "ns"); nsName != "" {
+		if ns := lang.FindNamespace(lang.NewSymbol(nsName)); ns != nil {
+			bindings = bindings.Assoc(lang.VarCurrentNS, ns).(lang.IPersistentMap)
+		}
+	}
+	var expansion string
+	err := withBindings(bindings, func() {
+		env := lang.GlobalEnv
+		rdr := reader.New(strings.NewReader(msgStr(msg, "code")), reader.WithGetCurrentNS(func() *lang.Namespace {
+			return env.CurrentNamespace()
+		}))
+		form, err := rdr.ReadOne()
+		if err != nil {
+			panic(err)
+		}
+		expansion = lang.PrintString(expander.Invoke(form))
+	})
+	if err != nil {
+		fail(err)
+		return
+	}
+	reply(map[string]interface{}{
+		"expansion": expansion,
+		"status":    []interface{}{"done"},
+	})
+}
+
+// withBindings calls fn with bindings pushed, turning a panic into an
+// error.
+func withBindings(bindings lang.IPersistentMap, fn func()) (err error) {
+	lang.PushThreadBindings(bindings)
+	defer lang.PopThreadBindings()
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// requireVar loads the namespace nsName and returns its var name.
+func requireVar(nsName, name string) (vr *lang.Var, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot load %s: %v", nsName, r)
+		}
+	}()
+	nsSym := lang.NewSymbol(nsName)
+	coreVar("require").Invoke(nsSym)
+	vr = lang.FindNamespace(nsSym).FindInternedVar(lang.NewSymbol(name))
+	if vr == nil {
+		return nil, fmt.Errorf("%s/%s is not defined", nsName, name)
+	}
+	return vr, nil
+}
+
+func (s *Server) opFormatCode(msg map[string]interface{}, conn Transport) {
+	formatted, err := format.Source(msgStr(msg, "code"))
+	if err != nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": msgStr(msg, "session"),
+			"err":     err.Error() + "\n",
+			"status":  []interface{}{"format-code-error", "done"},
+		})
+		return
+	}
+	sendMsg(conn, map[string]interface{}{
+		"id":             msg["id"],
+		"session":        msgStr(msg, "session"),
+		"formatted-code": formatted,
+		"status":         []interface{}{"done"},
+	})
+}
+
+func (s *Server) opClasspath(msg map[string]interface{}, conn Transport) {
+	entries := []interface{}{}
+	for _, fsys := range runtime.LoadPaths() {
+		// os.DirFS is a directory name; other filesystems, such as the
+		// embedded standard library, are named by their type.
+		if v := reflect.ValueOf(fsys); v.Kind() == reflect.String {
+			entries = append(entries, v.String())
+		} else {
+			entries = append(entries, fmt.Sprintf("%T", fsys))
+		}
+	}
+	sendMsg(conn, map[string]interface{}{
+		"id":        msg["id"],
+		"session":   msgStr(msg, "session"),
+		"classpath": entries,
+		"status":    []interface{}{"done"},
+	})
+}
+
+func (s *Server) opStacktrace(msg map[string]interface{}, conn Transport) {
+	sess := s.getOrCreateSession(msgStr(msg, "session"))
+	reply := func(resp map[string]interface{}) {
+		resp["id"] = msg["id"]
+		resp["session"] = sess.ID
+		sendMsg(conn, resp)
+	}
+
+	err, ok := sess.frame().ValAt(coreVar("*e")).(error)
+	if !ok {
+		reply(map[string]interface{}{
+			"status": []interface{}{"no-error", "done"},
+		})
+		return
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		cause := map[string]interface{}{
+			"class":      fmt.Sprintf("%T", err),
+			"message":    err.Error(),
+			"stacktrace": []interface{}{},
+		}
+		if evalErr, ok := err.(*runtime.RTEvalError); ok {
+			cause["message"] = evalErr.Err.Error()
+			cause["stacktrace"] = stackFrames(evalErr.Stack())
+		}
+		if info, ok := err.(lang.IExceptionInfo); ok {
+			cause["data"] = lang.PrintString(info.GetData())
+		}
+		reply(cause)
+	}
+	reply(map[string]interface{}{
+		"status": []interface{}{"done"},
+	})
+}
+
+// stackFrames returns the frames of a stack trace in the form of the
+// cider stacktrace op.
+func stackFrames(stack []lang.StackFrame) []interface{} {
+	frames := []interface{}{}
+	for _, f := range stack {
+		frame := map[string]interface{}{
+			"name": f.QualifiedName(),
+			"type": "clj",
+		}
+		if f.FunctionName != "" {
+			frame["ns"] = f.Namespace
+			frame["fn"] = f.FunctionName
+			frame["var"] = f.QualifiedName()
+		}
+		if f.Filename != "" {
+			frame["file"] = f.Filename
+		}
+		if f.Line > 0 {
+			frame["line"] = int64(f.Line)
+		}
+		if f.Column > 0 {
+			frame["column"] = int64(f.Column)
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
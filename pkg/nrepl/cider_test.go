@@ -0,0 +1,159 @@
+package nrepl_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupAndEldoc(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.request(map[string]interface{}{"op": "lookup", "session": sess, "sym": "map"})
+	info, _ := resps[0]["info"].(map[string]interface{})
+	if info["ns"] != "clojure.core" || info["name"] != "map" {
+		t.Errorf("info = %v", info)
+	}
+	if doc, _ := info["doc"].(string); doc == "" {
+		t.Errorf("info has no doc: %v", info)
+	}
+
+	resps = c.request(map[string]interface{}{"op": "eldoc", "session": sess, "sym": "when"})
+	if resps[0]["type"] != "macro" {
+		t.Errorf("eldoc type = %v", resps[0]["type"])
+	}
+	eldoc, _ := resps[0]["eldoc"].([]interface{})
+	if len(eldoc) != 1 || len(eldoc[0].([]interface{})) != 3 {
+		t.Errorf("eldoc = %v", eldoc)
+	}
+
+	resps = c.request(map[string]interface{}{"op": "lookup", "session": sess, "sym": "no-such-var"})
+	if !hasStatus(resps[0], "lookup-error") {
+		t.Errorf("lookup of unknown var = %v", resps[0])
+	}
+}
+
+func TestNSListAndVars(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+	c.eval(sess, `(ns listed.ns) (defn f []) (defn- g []) (def x 1)`)
+
+	resps := c.request(map[string]interface{}{"op": "ns-list", "session": sess})
+	var found bool
+	for _, ns := range resps[0]["ns-list"].([]interface{}) {
+		found = found || ns == "listed.ns"
+	}
+	if !found {
+		t.Errorf("ns-list = %v", resps[0]["ns-list"])
+	}
+
+	resps = c.request(map[string]interface{}{"op": "ns-vars", "session": sess, "ns": "listed.ns"})
+	if got, _ := resps[0]["ns-vars"].([]interface{}); len(got) != 2 || got[0] != "f" || got[1] != "x" {
+		t.Errorf("ns-vars = %v", got)
+	}
+}
+
+func TestMacroexpand(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	expand := func(expander string) string {
+		resps := c.request(map[string]interface{}{
+			"op":       "macroexpand",
+			"session":  sess,
+			"expander": expander,
+			"code":     "(when a (when c d))",
+		})
+		got, _ := resps[0]["expansion"].(string)
+		return got
+	}
+	if got := expand("macroexpand-1"); !strings.HasPrefix(got, "(if a (do (") || strings.Count(got, "(if") != 1 {
+		t.Errorf("macroexpand-1 = %q", got)
+	}
+	if got, want := expand("macroexpand-all"), "(if a (do (if c (do d))))"; got != want {
+		t.Errorf("macroexpand-all = %q, want %q", got, want)
+	}
+}
+
+func TestFormatCode(t *testing.T) {
+	c := dialTestServer(t)
+
+	code := "(defn f [x]\n(let [y 1\nz 2]\n(+ x\ny z)))   \n"
+	want := "(defn f [x]\n  (let [y 1\n        z 2]\n    (+ x\n       y z)))\n"
+	resps := c.request(map[string]interface{}{"op": "format-code", "code": code})
+	if got := resps[0]["formatted-code"]; got != want {
+		t.Errorf("formatted-code = %q, want %q", got, want)
+	}
+
+	resps = c.request(map[string]interface{}{"op": "format-code", "code": "(a [b)"})
+	if !hasStatus(resps[0], "format-code-error") {
+		t.Errorf("formatting unbalanced code = %v", resps[0])
+	}
+}
+
+func TestStacktrace(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.request(map[string]interface{}{"op": "stacktrace", "session": sess})
+	if !hasStatus(resps[0], "no-error") {
+		t.Errorf("stacktrace without an error = %v", resps[0])
+	}
+
+	c.eval(sess, `(throw (ex-info "boom" {:a 1}))`)
+	resps = c.request(map[string]interface{}{"op": "stacktrace", "session": sess})
+	var messages, data []string
+	for _, resp := range resps {
+		if m, ok := resp["message"].(string); ok {
+			messages = append(messages, m)
+		}
+		if d, ok := resp["data"].(string); ok {
+			data = append(data, d)
+		}
+	}
+	if len(messages) == 0 || !strings.Contains(strings.Join(messages, "\n"), "boom") {
+		t.Errorf("messages = %q", messages)
+	}
+	if len(data) != 1 || data[0] != "{:a 1}" {
+		t.Errorf("data = %q", data)
+	}
+}
+
+func TestTestAndRetest(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+	c.eval(sess, `
+(ns tested.ns (:require [clojure.test :refer [deftest is testing]]))
+(deftest passes (is (= 1 1)))
+(deftest fails (testing "arithmetic" (is (= 1 2) "nope")))
+(defn not-a-test [])`)
+
+	resps := c.request(map[string]interface{}{"op": "test", "session": sess, "ns": "tested.ns"})
+	resp := resps[len(resps)-1]
+	summary, _ := resp["summary"].(map[string]interface{})
+	if summary["test"] != int64(2) || summary["pass"] != int64(1) || summary["fail"] != int64(1) || summary["ns"] != int64(1) {
+		t.Errorf("summary = %v", summary)
+	}
+	results, _ := resp["results"].(map[string]interface{})["tested.ns"].(map[string]interface{})
+	fails, _ := results["fails"].([]interface{})
+	if len(fails) != 1 {
+		t.Fatalf("results = %v", results)
+	}
+	fail := fails[0].(map[string]interface{})
+	if fail["type"] != "fail" || fail["message"] != "nope" || fail["expected"] != "(= 1 2)" || fail["context"] != "arithmetic" {
+		t.Errorf("failure = %v", fail)
+	}
+
+	resps = c.request(map[string]interface{}{"op": "retest", "session": sess})
+	resp = resps[len(resps)-1]
+	summary, _ = resp["summary"].(map[string]interface{})
+	if summary["test"] != int64(1) || summary["fail"] != int64(1) {
+		t.Errorf("retest summary = %v", summary)
+	}
+
+	resps = c.request(map[string]interface{}{"op": "test", "session": sess, "ns": "tested.ns", "tests": []interface{}{"passes"}})
+	summary, _ = resps[len(resps)-1]["summary"].(map[string]interface{})
+	if summary["test"] != int64(1) || summary["pass"] != int64(1) {
+		t.Errorf("summary of selected tests = %v", summary)
+	}
+}
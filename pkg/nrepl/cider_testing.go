@@ -0,0 +1,250 @@
+package nrepl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// ciderTestMiddleware runs clojure.test tests for cider-nrepl's test
+// ops.
+var ciderTestMiddleware = opMiddleware("cider.test", []string{"clone"}, map[string]OpDoc{
+	"test": {
+		Doc:      "Runs the tests of a namespace with clojure.test, loading it if needed.",
+		Requires: map[string]string{"ns": "The namespace to test."},
+		Optional: map[string]string{"tests": "A list of the names of the test vars to run, instead of all of them."},
+		Returns: map[string]string{
+			"results":    "A map from namespace to test var to a list of assertion results.",
+			"summary":    "Counts of the vars, tests, passes, failures and errors.",
+			"testing-ns": "The namespace tested.",
+		},
+	},
+	"retest": {
+		Doc: "Reruns the tests of the session that did not pass when last run.",
+		Returns: map[string]string{
+			"results": "As for test.",
+			"summary": "As for test.",
+		},
+	},
+}, map[string]serverOp{
+	"test":   (*Server).opTest,
+	"retest": (*Server).opRetest,
+})
+
+var (
+	kwType     = lang.NewKeyword("type")
+	kwVar      = lang.NewKeyword("var")
+	kwMessage  = lang.NewKeyword("message")
+	kwExpected = lang.NewKeyword("expected")
+	kwActual   = lang.NewKeyword("actual")
+)
+
+func (s *Server) opTest(msg map[string]interface{}, conn Transport) {
+	sess := s.getOrCreateSession(msgStr(msg, "session"))
+	sess.enqueue(msg, conn, func(*runtime.Interrupter) {
+		nsName := msgStr(msg, "ns")
+		vars, err := testVars(nsName, msg["tests"])
+		if err != nil {
+			sendTestError(sess, msg, conn, err)
+			return
+		}
+		s.runTests(sess, msg, conn, nsName, vars)
+	})
+}
+
+func (s *Server) opRetest(msg map[string]interface{}, conn Transport) {
+	sess := s.getOrCreateSession(msgStr(msg, "session"))
+	sess.enqueue(msg, conn, func(*runtime.Interrupter) {
+		sess.mu.Lock()
+		vars := sess.failedTests
+		sess.mu.Unlock()
+		s.runTests(sess, msg, conn, "", vars)
+	})
+}
+
+// testVars loads the namespace nsName and returns its test vars, or the
+// named ones.
+func testVars(nsName string, names interface{}) (vars []*lang.Var, err error) {
+	if nsName == "" {
+		return nil, fmt.Errorf("no namespace given")
+	}
+	if _, err := requireVar("clojure.test", "test-vars"); err != nil {
+		return nil, err
+	}
+	nsSym := lang.NewSymbol(nsName)
+	ns := lang.FindNamespace(nsSym)
+	if ns == nil {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("cannot load %s: %v", nsName, r)
+				}
+			}()
+			coreVar("require").Invoke(nsSym)
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if ns = lang.FindNamespace(nsSym); ns == nil {
+			return nil, fmt.Errorf("namespace %s not found", nsName)
+		}
+	}
+
+	if list, ok := names.([]interface{}); ok && len(list) > 0 {
+		for _, name := range list {
+			str, _ := name.(string)
+			vr := ns.FindInternedVar(lang.NewSymbol(str))
+			if vr == nil {
+				return nil, fmt.Errorf("%s/%v is not defined", nsName, name)
+			}
+			vars = append(vars, vr)
+		}
+		return vars, nil
+	}
+	for seq := lang.Seq(ns.Mappings()); seq != nil; seq = seq.Next() {
+		vr, ok := seq.First().(lang.IMapEntry).Val().(*lang.Var)
+		if ok && vr.Namespace() == ns && lang.Get(vr.Meta(), lang.NewKeyword("test")) != nil {
+			vars = append(vars, vr)
+		}
+	}
+	return vars, nil
+}
+
+// runTests runs vars with clojure.test/test-vars, collecting what they
+// report instead of printing it, and remembers the ones that did not
+// pass for retest.
+func (s *Server) runTests(sess *Session, msg map[string]interface{}, conn Transport, nsName string, vars []*lang.Var) {
+	testNS := lang.FindNamespace(lang.NewSymbol("clojure.test"))
+	if testNS == nil {
+		sendTestError(sess, msg, conn, fmt.Errorf("clojure.test is not loaded"))
+		return
+	}
+	testVarsFn := testNS.FindInternedVar(lang.NewSymbol("test-vars"))
+	contextsStr := testNS.FindInternedVar(lang.NewSymbol("testing-contexts-str"))
+
+	results := map[string]interface{}{}
+	summary := map[string]int64{"ns": 0, "var": 0, "test": 0, "pass": 0, "fail": 0, "error": 0}
+	testedNS := map[string]bool{}
+	var failed []*lang.Var
+	var current *lang.Var
+
+	report := lang.FnFunc1(func(m any) any {
+		typ, _ := lang.Get(m, kwType).(lang.Keyword)
+		switch typ.Name() {
+		case "begin-test-var":
+			current, _ = lang.Get(m, kwVar).(*lang.Var)
+			if current == nil {
+				return nil
+			}
+			summary["var"]++
+			summary["test"]++
+			testedNS[current.Namespace().Name().String()] = true
+		case "pass", "fail", "error":
+			if current == nil {
+				return nil
+			}
+			nsName := current.Namespace().Name().String()
+			varName := current.Symbol().Name()
+			nsResults, _ := results[nsName].(map[string]interface{})
+			if nsResults == nil {
+				nsResults = map[string]interface{}{}
+				results[nsName] = nsResults
+			}
+			varResults, _ := nsResults[varName].([]interface{})
+			result := map[string]interface{}{
+				"type":    typ.Name(),
+				"ns":      nsName,
+				"var":     varName,
+				"index":   int64(len(varResults)),
+				"context": "",
+				"message": "",
+			}
+			if contextsStr != nil {
+				result["context"] = lang.ToString(contextsStr.Invoke())
+			}
+			if message := lang.Get(m, kwMessage); message != nil {
+				result["message"] = lang.ToString(message)
+			}
+			if typ.Name() != "pass" {
+				result["expected"] = lang.PrintString(lang.Get(m, kwExpected))
+				actual := lang.Get(m, kwActual)
+				result["actual"] = lang.PrintString(actual)
+				if err, ok := actual.(error); ok {
+					result["error"] = err.Error()
+				}
+				meta := current.Meta()
+				if file, ok := lang.Get(meta, lang.KWFile).(string); ok {
+					result["file"] = file
+				}
+				if line := lang.Get(meta, lang.KWLine); line != nil {
+					result["line"] = line
+				}
+				if len(failed) == 0 || failed[len(failed)-1] != current {
+					failed = append(failed, current)
+				}
+			}
+			nsResults[varName] = append(varResults, result)
+			summary[typ.Name()]++
+		}
+		return nil
+	})
+
+	outWriter := &nreplWriter{conn: conn, id: msg["id"], sessionID: sess.ID, key: "out"}
+	errWriter := &nreplWriter{conn: conn, id: msg["id"], sessionID: sess.ID, key: "err"}
+	bindings := sess.frame().
+		Assoc(lang.VarOut, outWriter).
+		Assoc(lang.VarErr, errWriter).
+		Assoc(testNS.FindInternedVar(lang.NewSymbol("report")), report)
+	if testOut := testNS.FindInternedVar(lang.NewSymbol("*test-out*")); testOut != nil {
+		bindings = bindings.Assoc(testOut, outWriter)
+	}
+	args := make([]interface{}, len(vars))
+	for i, vr := range vars {
+		args[i] = vr
+	}
+	err := withBindings(bindings.(lang.IPersistentMap), func() {
+		testVarsFn.Invoke(lang.NewVector(args...))
+	})
+	outWriter.flush()
+	errWriter.flush()
+	if err != nil {
+		sendTestError(sess, msg, conn, err)
+		return
+	}
+
+	sess.mu.Lock()
+	sess.failedTests = failed
+	sess.mu.Unlock()
+
+	summary["ns"] = int64(len(testedNS))
+	summaryMsg := map[string]interface{}{}
+	for k, v := range summary {
+		summaryMsg[k] = v
+	}
+	resp := map[string]interface{}{
+		"id":      msg["id"],
+		"session": sess.ID,
+		"results": results,
+		"summary": summaryMsg,
+		"status":  []interface{}{"done"},
+	}
+	if nsName != "" {
+		resp["testing-ns"] = nsName
+	}
+	sendMsg(conn, resp)
+}
+
+func sendTestError(sess *Session, msg map[string]interface{}, conn Transport, err error) {
+	status := "error"
+	if errors.Is(err, runtime.ErrInterrupted) {
+		status = "interrupted"
+	}
+	sendMsg(conn, map[string]interface{}{
+		"id":      msg["id"],
+		"session": sess.ID,
+		"err":     err.Error() + "\n",
+		"status":  []interface{}{status, "done"},
+	})
+}
@@ -1,6 +1,7 @@
 package nrepl
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strconv"
@@ -10,23 +11,46 @@ import (
 
 // Client connects to a running nREPL server.
 type Client struct {
-	conn    net.Conn
-	br      byteReaderInterface
+	conn    Transport
 	mu      sync.Mutex
 	session string
 	ns      string
 }
 
-// Connect dials an nREPL server and clones a session.
+// Connect dials a bencode nREPL server over TCP and clones a session.
 func Connect(host string, port int) (*Client, error) {
-	addr := net.JoinHostPort(host, strconv.Itoa(port))
-	conn, err := net.Dial("tcp", addr)
+	return Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// Dial connects to an nREPL server on network, "tcp" or "unix", at
+// addr and clones a session. The framing and TLS options must match the
+// server's.
+func Dial(network, addr string, opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := checkFraming(o.framing); err != nil {
+		return nil, err
+	}
+	var nc net.Conn
+	var err error
+	if o.tls != nil {
+		config := o.tls
+		if config.ServerName == "" && network == "tcp" {
+			config = config.Clone()
+			config.ServerName, _, _ = net.SplitHostPort(addr)
+		}
+		nc, err = tls.Dial(network, addr, config)
+	} else {
+		nc, err = net.Dial(network, addr)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("nrepl: connect %s: %w", addr, err)
 	}
+	conn, _ := NewTransport(nc, o.framing)
 	c := &Client{
 		conn: conn,
-		br:   newByteReader(conn),
 		ns:   "user",
 	}
 	if err := c.clone(); err != nil {
@@ -58,7 +82,9 @@ func (c *Client) NS() string {
 
 // Eval sends code to the server for evaluation. It returns the
 // printed value, the current namespace, any stdout output, and any
-// error. Stdout output is accumulated from "out" messages.
+// error. Stdout output is accumulated from "out" messages. The error
+// of a failed evaluation has the "err" output, which holds the
+// server's report of it.
 func (c *Client) Eval(code string) (value, ns, out string, err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -70,7 +96,7 @@ func (c *Client) Eval(code string) (value, ns, out string, err error) {
 		"ns":      c.ns,
 	})
 
-	var outBuf strings.Builder
+	var outBuf, errBuf strings.Builder
 	for {
 		resp, readErr := c.recv()
 		if readErr != nil {
@@ -80,6 +106,9 @@ func (c *Client) Eval(code string) (value, ns, out string, err error) {
 		if v, ok := resp["out"].(string); ok {
 			outBuf.WriteString(v)
 		}
+		if v, ok := resp["err"].(string); ok {
+			errBuf.WriteString(v)
+		}
 		if v, ok := resp["value"].(string); ok {
 			value = v
 		}
@@ -88,6 +117,9 @@ func (c *Client) Eval(code string) (value, ns, out string, err error) {
 			ns = v
 		}
 		if v, ok := resp["ex"].(string); ok {
+			if report := strings.TrimRight(errBuf.String(), "\n"); report != "" {
+				v = report
+			}
 			err = fmt.Errorf("%s", v)
 		}
 
@@ -174,24 +206,11 @@ func (c *Client) clone() error {
 }
 
 func (c *Client) send(msg map[string]interface{}) error {
-	data, err := BencodeEncode(msg)
-	if err != nil {
-		return err
-	}
-	_, err = c.conn.Write(data)
-	return err
+	return c.conn.Send(msg)
 }
 
 func (c *Client) recv() (map[string]interface{}, error) {
-	val, err := bencodeRead(c.br)
-	if err != nil {
-		return nil, err
-	}
-	msg, ok := val.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("nrepl: expected dict, got %T", val)
-	}
-	return msg, nil
+	return c.conn.Recv()
 }
 
 func statusDone(msg map[string]interface{}) bool {
@@ -0,0 +1,145 @@
+package nrepl
+
+import (
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+)
+
+// A Completion is a candidate for completing a prefix.
+type Completion struct {
+	Candidate string
+	// NS names the namespace of the var a symbol candidate refers to.
+	NS string
+	// Type is "keyword", "namespace", "class" or "static-method", or
+	// empty for the mappings of a namespace.
+	Type string
+	// Var is the var a symbol candidate refers to, if any.
+	Var *lang.Var
+}
+
+// Completions returns the candidates for completing prefix in ns: the
+// symbols ns maps, the public vars of a namespace or alias before a
+// slash, keywords, namespace names, and host classes and their members.
+func Completions(ns *lang.Namespace, prefix string) []Completion {
+	var completions []Completion
+
+	// Complete qualified symbols: str/jo offers str/join when str is
+	// an alias or namespace.
+	if slash := strings.IndexByte(prefix, '/'); slash > 0 && prefix[0] != ':' {
+		qual := lang.NewSymbol(prefix[:slash])
+		target := ns.LookupAlias(qual)
+		if target == nil {
+			target = lang.FindNamespace(qual)
+		}
+		if target != nil {
+			for seq := lang.Seq(target.Mappings()); seq != nil; seq = seq.Next() {
+				entry := seq.First().(lang.IMapEntry)
+				vr, ok := entry.Val().(*lang.Var)
+				if !ok || vr.Namespace() != target || !vr.IsPublic() {
+					continue
+				}
+				name := entry.Key().(*lang.Symbol).Name()
+				if strings.HasPrefix(name, prefix[slash+1:]) {
+					completions = append(completions, Completion{
+						Candidate: prefix[:slash] + "/" + name,
+						NS:        target.Name().String(),
+						Var:       vr,
+					})
+				}
+			}
+		}
+	}
+
+	// Complete from current namespace mappings.
+	if mappings := ns.Mappings(); mappings != nil {
+		for seq := lang.Seq(mappings); seq != nil; seq = seq.Next() {
+			entry := seq.First().(lang.IMapEntry)
+			sym := entry.Key().(*lang.Symbol)
+			name := sym.Name()
+			if strings.HasPrefix(name, prefix) {
+				comp := Completion{Candidate: name}
+				if vr, ok := entry.Val().(*lang.Var); ok {
+					comp.NS = vr.Namespace().Name().String()
+					comp.Var = vr
+				}
+				completions = append(completions, comp)
+			}
+		}
+	}
+
+	// Complete keywords.
+	if strings.HasPrefix(prefix, ":") {
+		kwPrefix := prefix[1:]
+		for _, kw := range lang.AllKeywords() {
+			if strings.HasPrefix(kw, kwPrefix) {
+				completions = append(completions, Completion{
+					Candidate: ":" + kw,
+					Type:      "keyword",
+				})
+			}
+		}
+	}
+
+	// Complete namespace names.
+	for nsSeq := lang.AllNamespaces(); nsSeq != nil; nsSeq = nsSeq.Next() {
+		nsObj := nsSeq.First().(*lang.Namespace)
+		name := nsObj.Name().String()
+		if strings.HasPrefix(name, prefix) {
+			completions = append(completions, Completion{
+				Candidate: name,
+				Type:      "namespace",
+			})
+		}
+	}
+
+	// Complete javacompat host classes (Math, System, ...) and their
+	// members. For a bare prefix like "Ma" we offer "Math/"; for a
+	// qualified prefix like "Math/sq" we list matching entries. A fully
+	// qualified form like java.lang.Math or java.time.Instant is treated
+	// as the bare class name by stripping the registered host-class
+	// package prefix.
+	if slash := strings.IndexByte(prefix, '/'); slash > 0 {
+		cls := prefix[:slash]
+		memberPrefix := prefix[slash+1:]
+		lookup := cls
+		if i := strings.LastIndex(cls, "."); i >= 0 {
+			bare := cls[i+1:]
+			if pkgmap.HostClassPackage(bare)+"."+bare == cls {
+				lookup = bare
+			}
+		}
+		for _, name := range pkgmap.PkgEntries(lookup) {
+			if strings.HasPrefix(name, memberPrefix) {
+				completions = append(completions, Completion{
+					Candidate: cls + "/" + name,
+					Type:      "static-method",
+				})
+			}
+		}
+	} else {
+		// Only uppercase-leading host classes get the package-qualified
+		// form, since pkgmap.HostClasses() also includes Go stdlib pkgs.
+		// The package comes from pkgmap.SetHostClassPackage, defaulting
+		// to java.lang when unset.
+		for _, hc := range pkgmap.HostClasses() {
+			if strings.HasPrefix(hc, prefix) {
+				completions = append(completions, Completion{
+					Candidate: hc + "/",
+					Type:      "class",
+				})
+			}
+			if hc == "" || hc[0] < 'A' || hc[0] > 'Z' {
+				continue
+			}
+			if fq := pkgmap.HostClassPackage(hc) + "." + hc; strings.HasPrefix(fq, prefix) {
+				completions = append(completions, Completion{
+					Candidate: fq + "/",
+					Type:      "class",
+				})
+			}
+		}
+	}
+	return completions
+}
@@ -0,0 +1,398 @@
+package nrepl
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Handler handles a request, replying to it or passing it on to the
+// next handler.
+type Handler func(req *Request)
+
+// Request is a message received from a client, together with the
+// transport it arrived on.
+type Request struct {
+	Msg       map[string]interface{}
+	Transport Transport
+	Server    *Server
+}
+
+// Op returns the requested operation.
+func (r *Request) Op() string {
+	return msgStr(r.Msg, "op")
+}
+
+// Session returns the session named by the request, creating one if it
+// names none or an unknown one.
+func (r *Request) Session() *Session {
+	return r.Server.getOrCreateSession(msgStr(r.Msg, "session"))
+}
+
+// Reply sends resp to the client, tagged with the request's id and
+// session.
+func (r *Request) Reply(resp map[string]interface{}) {
+	resp["id"] = r.Msg["id"]
+	if _, ok := resp["session"]; !ok {
+		resp["session"] = r.Msg["session"]
+	}
+	sendMsg(r.Transport, resp)
+}
+
+// Middleware is a layer of the handler stack. Wrap receives the handler
+// below it and returns a handler that answers the ops the middleware
+// implements and passes other messages on, possibly changed.
+//
+// Requires and Expects place the middleware relative to others, naming
+// either middleware or ops they handle. Everything in Requires sees a
+// message before this middleware does; everything in Expects sees the
+// messages it passes on.
+type Middleware struct {
+	Name     string
+	Requires []string
+	Expects  []string
+	Handles  map[string]OpDoc
+	Wrap     func(next Handler) Handler
+}
+
+// OpDoc describes an op for the describe op.
+type OpDoc struct {
+	Doc      string
+	Requires map[string]string
+	Optional map[string]string
+	Returns  map[string]string
+}
+
+var registry struct {
+	sync.Mutex
+	middleware []*Middleware
+	version    int
+}
+
+// RegisterMiddleware adds mw to the middleware of every server,
+// replacing any middleware of the same name. Running servers pick up the
+// change with the next message they receive.
+func RegisterMiddleware(mw *Middleware) error {
+	if mw.Name == "" {
+		return fmt.Errorf("nrepl: middleware has no name")
+	}
+	if mw.Wrap == nil {
+		return fmt.Errorf("nrepl: middleware %s has no Wrap function", mw.Name)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+
+	list := make([]*Middleware, 0, len(registry.middleware)+1)
+	replaced := false
+	for _, other := range registry.middleware {
+		if other.Name == mw.Name {
+			list = append(list, mw)
+			replaced = true
+		} else {
+			list = append(list, other)
+		}
+	}
+	if !replaced {
+		list = append(list, mw)
+	}
+	if _, err := linearize(list); err != nil {
+		return err
+	}
+	registry.middleware = list
+	registry.version++
+	return nil
+}
+
+// mustRegister registers built-in middleware.
+func mustRegister(mws ...*Middleware) {
+	for _, mw := range mws {
+		if err := RegisterMiddleware(mw); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// linearize orders mws from outermost to innermost so that every
+// Requires and Expects constraint holds, keeping registration order
+// where the constraints leave a choice.
+func linearize(mws []*Middleware) ([]*Middleware, error) {
+	index := make(map[string]int, len(mws))
+	for i, mw := range mws {
+		index[mw.Name] = i
+		for op := range mw.Handles {
+			if _, ok := index[op]; !ok {
+				index[op] = i
+			}
+		}
+	}
+	// Middleware names win over op names.
+	for i, mw := range mws {
+		index[mw.Name] = i
+	}
+
+	after := make([][]int, len(mws)) // after[i] must be inside i
+	inDegree := make([]int, len(mws))
+	edge := func(outer, inner int) {
+		if outer == inner {
+			return
+		}
+		after[outer] = append(after[outer], inner)
+		inDegree[inner]++
+	}
+	for i, mw := range mws {
+		for _, name := range mw.Requires {
+			j, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("nrepl: middleware %s requires %s, which is not registered", mw.Name, name)
+			}
+			edge(j, i)
+		}
+		for _, name := range mw.Expects {
+			// Expected middleware is optional.
+			if j, ok := index[name]; ok {
+				edge(i, j)
+			}
+		}
+	}
+
+	var order []*Middleware
+	done := make([]bool, len(mws))
+	for len(order) < len(mws) {
+		next := -1
+		for i := range mws {
+			if !done[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var names []string
+			for i, mw := range mws {
+				if !done[i] {
+					names = append(names, mw.Name)
+				}
+			}
+			return nil, fmt.Errorf("nrepl: middleware dependency cycle among %s", strings.Join(names, ", "))
+		}
+		done[next] = true
+		order = append(order, mws[next])
+		for _, j := range after[next] {
+			inDegree[j]--
+		}
+	}
+	return order, nil
+}
+
+// stack is a composed handler and the middleware it was built from.
+type stack struct {
+	version    int
+	handler    Handler
+	middleware []*Middleware
+}
+
+// handlerStack returns the server's handler, rebuilding it when the
+// registered middleware has changed.
+func (s *Server) handlerStack() *stack {
+	registry.Lock()
+	defer registry.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stack != nil && s.stack.version == registry.version {
+		return s.stack
+	}
+	order, err := linearize(registry.middleware)
+	if err != nil {
+		// RegisterMiddleware only accepts lists that linearize.
+		panic(err)
+	}
+	handler := unknownOp
+	for i := len(order) - 1; i >= 0; i-- {
+		handler = order[i].Wrap(handler)
+	}
+	s.stack = &stack{
+		version:    registry.version,
+		handler:    handler,
+		middleware: order,
+	}
+	return s.stack
+}
+
+// unknownOp is the innermost handler, reached by ops no middleware
+// handles.
+func unknownOp(req *Request) {
+	req.Reply(map[string]interface{}{
+		"status": []interface{}{"error", "unknown-op", "done"},
+	})
+}
+
+// describeOps returns the ops of the stack for the describe op, with
+// their documentation if verbose is set.
+func (st *stack) describeOps(verbose bool) map[string]interface{} {
+	ops := map[string]interface{}{}
+	for _, mw := range st.middleware {
+		for op, doc := range mw.Handles {
+			if _, ok := ops[op]; ok {
+				// An outer middleware answers it.
+				continue
+			}
+			desc := map[string]interface{}{}
+			if verbose {
+				desc["doc"] = doc.Doc
+				desc["requires"] = stringMap(doc.Requires)
+				desc["optional"] = stringMap(doc.Optional)
+				desc["returns"] = stringMap(doc.Returns)
+			}
+			ops[op] = desc
+		}
+	}
+	return ops
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// serverOp is an op implemented by a Server method.
+type serverOp func(s *Server, msg map[string]interface{}, conn Transport)
+
+// opMiddleware returns middleware answering each op in ops with the
+// given Server method.
+func opMiddleware(name string, requires []string, docs map[string]OpDoc, ops map[string]serverOp) *Middleware {
+	return &Middleware{
+		Name:     name,
+		Requires: requires,
+		Handles:  docs,
+		Wrap: func(next Handler) Handler {
+			return func(req *Request) {
+				if op, ok := ops[req.Op()]; ok {
+					op(req.Server, req.Msg, req.Transport)
+					return
+				}
+				next(req)
+			}
+		},
+	}
+}
+
+// middlewareNames returns the names of mws, sorted.
+func middlewareNames(mws []*Middleware) []interface{} {
+	names := make([]string, len(mws))
+	for i, mw := range mws {
+		names[i] = mw.Name
+	}
+	return sortedList(names)
+}
+
+func init() {
+	mustRegister(builtinMiddleware...)
+	mustRegister(ciderMiddleware...)
+	mustRegister(ciderTestMiddleware)
+}
+
+// builtinMiddleware implements the standard nREPL ops.
+var builtinMiddleware = []*Middleware{
+	opMiddleware("session", nil, map[string]OpDoc{
+		"clone": {
+			Doc:      "Clones the given session, or creates a new one, returning its id.",
+			Optional: map[string]string{"session": "The session to clone."},
+			Returns:  map[string]string{"new-session": "The id of the new session."},
+		},
+		"close": {
+			Doc:      "Closes the given session.",
+			Requires: map[string]string{"session": "The session to close."},
+		},
+		"ls-sessions": {
+			Doc:     "Lists the ids of the open sessions.",
+			Returns: map[string]string{"sessions": "A list of session ids."},
+		},
+	}, map[string]serverOp{
+		"clone":       (*Server).opClone,
+		"close":       (*Server).opClose,
+		"ls-sessions": (*Server).opLsSessions,
+	}),
+	opMiddleware("describe", nil, map[string]OpDoc{
+		"describe": {
+			Doc:      "Describes the server's ops, versions and middleware.",
+			Optional: map[string]string{"verbose?": "Include documentation for each op."},
+			Returns: map[string]string{
+				"ops":        "A map of the supported ops.",
+				"versions":   "A map of version information.",
+				"middleware": "The names of the installed middleware.",
+			},
+		},
+	}, map[string]serverOp{
+		"describe": (*Server).opDescribe,
+	}),
+	opMiddleware("eval", []string{"clone"}, map[string]OpDoc{
+		"eval": {
+			Doc:      "Evaluates code in the session, replying with the value of each form.",
+			Requires: map[string]string{"code": "The code to evaluate."},
+			Optional: map[string]string{
+				"session": "The session to evaluate in.",
+				"ns":      "The namespace to evaluate in.",
+				"file":    "The name of the file the code comes from.",
+				"line":    "The line the code starts on.",
+				"column":  "The column the code starts at.",
+			},
+			Returns: map[string]string{
+				"value": "The printed value of a form.",
+				"ns":    "The current namespace after the form.",
+				"out":   "Output written to *out*.",
+				"err":   "Output written to *err*.",
+				"ex":    "The error, if evaluation failed.",
+			},
+		},
+		"interrupt": {
+			Doc:      "Interrupts the running evaluation of a session, or removes a queued one.",
+			Requires: map[string]string{"session": "The session of the evaluation."},
+			Optional: map[string]string{"interrupt-id": "The message id of the evaluation."},
+			Returns: map[string]string{
+				"status": "interrupted, session-idle if nothing is running, or interrupt-id-mismatch.",
+			},
+		},
+		"stdin": {
+			Doc:      "Adds input for *in* in the session. Empty input signals end of file.",
+			Requires: map[string]string{"stdin": "The input."},
+		},
+	}, map[string]serverOp{
+		"eval":      (*Server).opEval,
+		"interrupt": (*Server).opInterrupt,
+		"stdin":     (*Server).opStdin,
+	}),
+	opMiddleware("load-file", []string{"clone"}, map[string]OpDoc{
+		"load-file": {
+			Doc:      "Loads the content of a file.",
+			Requires: map[string]string{"file": "The content of the file."},
+			Optional: map[string]string{
+				"file-path": "The path of the file.",
+				"file-name": "The name of the file.",
+			},
+		},
+	}, map[string]serverOp{
+		"load-file": (*Server).opLoadFile,
+	}),
+	opMiddleware("completions", nil, map[string]OpDoc{
+		"completions": {
+			Doc:      "Returns completion candidates for a prefix.",
+			Requires: map[string]string{"prefix": "The prefix to complete."},
+			Optional: map[string]string{"ns": "The namespace to complete in."},
+			Returns:  map[string]string{"completions": "A list of candidates."},
+		},
+	}, map[string]serverOp{
+		"completions": (*Server).opCompletions,
+	}),
+	opMiddleware("info", nil, map[string]OpDoc{
+		"info": {
+			Doc:      "Returns the metadata of a var.",
+			Requires: map[string]string{"sym": "The symbol to look up."},
+			Optional: map[string]string{"ns": "The namespace to resolve the symbol in."},
+		},
+	}, map[string]serverOp{
+		"info": (*Server).opInfo,
+	}),
+}
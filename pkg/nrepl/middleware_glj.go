@@ -0,0 +1,239 @@
+package nrepl
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// The glojure.nrepl namespace lets Glojure code register middleware:
+//
+//	(defn wrap-hello
+//	  {:nrepl.middleware/descriptor
+//	   {:requires #{"clone"}
+//	    :handles {"hello" {:doc "Says hello."}}}}
+//	  [handler]
+//	  (fn [msg]
+//	    (if (= "hello" (:op msg))
+//	      ((:reply msg) {:greeting "hello" :status ["done"]})
+//	      (handler msg))))
+//
+//	(glojure.nrepl/register-middleware! #'wrap-hello)
+//
+// Handlers receive messages as maps with keyword keys, plus a :reply
+// function sending a response to the client.
+var installNSOnce sync.Once
+
+var (
+	kwDescriptor = lang.NewKeyword("nrepl.middleware/descriptor")
+	kwRequires   = lang.NewKeyword("requires")
+	kwExpects    = lang.NewKeyword("expects")
+	kwHandles    = lang.NewKeyword("handles")
+	kwOptional   = lang.NewKeyword("optional")
+	kwReturns    = lang.NewKeyword("returns")
+	kwReply      = lang.NewKeyword("reply")
+	kwTransport  = lang.NewKeyword("transport")
+)
+
+func installNS() {
+	installNSOnce.Do(func() {
+		nsSym := lang.NewSymbol("glojure.nrepl")
+		ns := lang.FindOrCreateNamespace(nsSym)
+		register := lang.InternVar(ns, lang.NewSymbol("register-middleware!"), lang.FnFunc(func(args ...any) any {
+			if len(args) < 1 || len(args) > 2 {
+				panic(fmt.Errorf("wrong number of args (%d) passed to register-middleware!", len(args)))
+			}
+			vr, ok := args[0].(*lang.Var)
+			if !ok {
+				panic(fmt.Errorf("register-middleware! expects a var, got %T", args[0]))
+			}
+			var descriptor any
+			if len(args) == 2 {
+				descriptor = args[1]
+			}
+			mw, err := VarMiddleware(vr, descriptor)
+			if err != nil {
+				panic(err)
+			}
+			if err := RegisterMiddleware(mw); err != nil {
+				panic(err)
+			}
+			return mw.Name
+		}), true)
+		register.SetMeta(lang.NewMap(
+			lang.KWDoc, "Adds the middleware in var to the nREPL handler stack, replacing\n"+
+				"any registered under the same name. The descriptor defaults to the\n"+
+				":nrepl.middleware/descriptor metadata of var.",
+			lang.KWArglists, lang.NewList(
+				lang.NewVector(lang.NewSymbol("var")),
+				lang.NewVector(lang.NewSymbol("var"), lang.NewSymbol("descriptor"))),
+		))
+		markLoaded(nsSym)
+	})
+}
+
+// markLoaded adds lib to *loaded-libs*, so that require does not look
+// for its source.
+func markLoaded(lib *lang.Symbol) {
+	loadedRef, ok := coreVar("*loaded-libs*").Deref().(*lang.Ref)
+	if !ok {
+		return
+	}
+	lang.LockingTransaction.RunInTransaction(lang.FnFunc(func(...any) any {
+		return loadedRef.Commute(lang.FnFunc1(func(libs any) any {
+			return libs.(lang.IPersistentSet).Cons(lib)
+		}), nil)
+	}))
+}
+
+// VarMiddleware returns middleware whose Wrap calls the Glojure function
+// in vr with the next handler. The descriptor, a map with :requires,
+// :expects and :handles keys, is taken from the var's
+// :nrepl.middleware/descriptor metadata if nil.
+func VarMiddleware(vr *lang.Var, descriptor any) (*Middleware, error) {
+	if descriptor == nil {
+		descriptor = lang.Get(vr.Meta(), kwDescriptor)
+	}
+	mw := &Middleware{
+		Name:     vr.Namespace().Name().String() + "/" + vr.Symbol().Name(),
+		Requires: stringList(lang.Get(descriptor, kwRequires)),
+		Expects:  stringList(lang.Get(descriptor, kwExpects)),
+		Handles:  map[string]OpDoc{},
+	}
+	for seq := lang.Seq(lang.Get(descriptor, kwHandles)); seq != nil; seq = seq.Next() {
+		entry, ok := seq.First().(lang.IMapEntry)
+		if !ok {
+			return nil, fmt.Errorf("nrepl: middleware %s: :handles must be a map", mw.Name)
+		}
+		doc := entry.Val()
+		mw.Handles[nameOf(entry.Key())] = OpDoc{
+			Doc:      lang.ToString(lang.Get(doc, lang.KWDoc)),
+			Requires: stringMapOf(lang.Get(doc, kwRequires)),
+			Optional: stringMapOf(lang.Get(doc, kwOptional)),
+			Returns:  stringMapOf(lang.Get(doc, kwReturns)),
+		}
+	}
+	mw.Wrap = func(next Handler) Handler {
+		var (
+			once    sync.Once
+			handler any
+		)
+		nextFn := lang.FnFunc1(func(m any) any {
+			req, _ := lang.Get(m, kwTransport).(*Request)
+			if req == nil {
+				panic(fmt.Errorf("nrepl: message passed to the next handler has no :transport"))
+			}
+			next(&Request{Msg: fromGlojure(m).(map[string]interface{}), Transport: req.Transport, Server: req.Server})
+			return nil
+		})
+		return func(req *Request) {
+			// The Glojure function is called lazily so that registering
+			// middleware does not run Glojure code under the registry lock.
+			once.Do(func() { handler = vr.Invoke(nextFn) })
+			lang.Apply(handler, []any{requestMap(req)})
+		}
+	}
+	return mw, nil
+}
+
+// requestMap returns the message of req as a Glojure map, with :reply
+// and :transport entries.
+func requestMap(req *Request) lang.IPersistentMap {
+	m := toGlojure(req.Msg).(lang.IPersistentMap)
+	m = m.Assoc(kwReply, lang.FnFunc1(func(resp any) any {
+		req.Reply(fromGlojure(resp).(map[string]interface{}))
+		return nil
+	})).(lang.IPersistentMap)
+	return m.Assoc(kwTransport, req).(lang.IPersistentMap)
+}
+
+// toGlojure converts a decoded bencode value to Glojure data, with
+// keyword map keys.
+func toGlojure(v interface{}) any {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		kvs := make([]any, 0, 2*len(v))
+		for k, val := range v {
+			kvs = append(kvs, lang.NewKeyword(k), toGlojure(val))
+		}
+		return lang.NewMap(kvs...)
+	case []interface{}:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = toGlojure(item)
+		}
+		return lang.NewVector(items...)
+	default:
+		return v
+	}
+}
+
+// fromGlojure converts Glojure data to values bencode can encode:
+// keywords and symbols become their names, integers int64, collections
+// lists and maps with string keys. Entries with nil values, :reply and
+// :transport are dropped.
+func fromGlojure(v any) interface{} {
+	switch v := v.(type) {
+	case nil, string, int64:
+		return v
+	case lang.Keyword, *lang.Symbol:
+		return nameOf(v)
+	case bool:
+		return fmt.Sprint(v)
+	case lang.IPersistentMap:
+		out := map[string]interface{}{}
+		for seq := lang.Seq(v); seq != nil; seq = seq.Next() {
+			entry := seq.First().(lang.IMapEntry)
+			if entry.Key() == kwReply || entry.Key() == kwTransport || entry.Val() == nil {
+				continue
+			}
+			out[nameOf(entry.Key())] = fromGlojure(entry.Val())
+		}
+		return out
+	case lang.IPersistentCollection, lang.ISeq:
+		var out []interface{}
+		for seq := lang.Seq(v); seq != nil; seq = seq.Next() {
+			out = append(out, fromGlojure(seq.First()))
+		}
+		if out == nil {
+			out = []interface{}{}
+		}
+		return out
+	}
+	if lang.IsInteger(v) {
+		return lang.AsInt64(v)
+	}
+	return lang.PrintString(v)
+}
+
+// nameOf returns the name of a keyword or symbol, including its
+// namespace, or the string form of anything else.
+func nameOf(v any) string {
+	switch v := v.(type) {
+	case lang.Keyword:
+		return v.String()[1:]
+	case *lang.Symbol:
+		return v.String()
+	case string:
+		return v
+	}
+	return lang.ToString(v)
+}
+
+func stringList(v any) []string {
+	var out []string
+	for seq := lang.Seq(v); seq != nil; seq = seq.Next() {
+		out = append(out, nameOf(seq.First()))
+	}
+	return out
+}
+
+func stringMapOf(v any) map[string]string {
+	out := map[string]string{}
+	for seq := lang.Seq(v); seq != nil; seq = seq.Next() {
+		entry := seq.First().(lang.IMapEntry)
+		out[nameOf(entry.Key())] = lang.ToString(entry.Val())
+	}
+	return out
+}
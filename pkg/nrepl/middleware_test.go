@@ -0,0 +1,136 @@
+package nrepl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/nrepl"
+)
+
+func TestDescribeListsOpsAndMiddleware(t *testing.T) {
+	c := dialTestServer(t)
+
+	resps := c.request(map[string]interface{}{"op": "describe", "verbose?": "true"})
+	ops, _ := resps[0]["ops"].(map[string]interface{})
+	for _, op := range []string{
+		"eval", "clone", "lookup", "eldoc", "ns-list", "ns-vars", "macroexpand",
+		"format-code", "test", "retest", "classpath", "stacktrace",
+	} {
+		desc, ok := ops[op].(map[string]interface{})
+		if !ok {
+			t.Errorf("describe does not list %s", op)
+			continue
+		}
+		if doc, _ := desc["doc"].(string); doc == "" {
+			t.Errorf("%s has no doc", op)
+		}
+	}
+	middleware, _ := resps[0]["middleware"].([]interface{})
+	var names []string
+	for _, name := range middleware {
+		names = append(names, name.(string))
+	}
+	if got := strings.Join(names, " "); !strings.Contains(got, "cider.info") || !strings.Contains(got, "eval") {
+		t.Errorf("middleware = %q", got)
+	}
+}
+
+func TestGoMiddlewareWrapsInnerHandlers(t *testing.T) {
+	err := nrepl.RegisterMiddleware(&nrepl.Middleware{
+		Name:     "test.shout",
+		Requires: []string{"clone"},
+		Expects:  []string{"eval"},
+		Handles:  map[string]nrepl.OpDoc{"shout": {Doc: "Evaluates code, adding an exclamation mark."}},
+		Wrap: func(next nrepl.Handler) nrepl.Handler {
+			return func(req *nrepl.Request) {
+				if req.Op() != "shout" {
+					next(req)
+					return
+				}
+				req.Msg["op"] = "eval"
+				req.Msg["code"] = "(str " + req.Msg["code"].(string) + ` "!")`
+				next(req)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := dialTestServer(t)
+	sess := c.clone()
+	resps := c.request(map[string]interface{}{"op": "shout", "session": sess, "code": `"hi"`})
+	if got := collect(resps, "value"); len(got) != 1 || got[0] != `"hi!"` {
+		t.Errorf("values = %q", got)
+	}
+}
+
+func TestRegisterMiddlewareRejectsBadDependencies(t *testing.T) {
+	wrap := func(next nrepl.Handler) nrepl.Handler { return next }
+	if err := nrepl.RegisterMiddleware(&nrepl.Middleware{
+		Name:     "test.missing",
+		Requires: []string{"no-such-op"},
+		Wrap:     wrap,
+	}); err == nil {
+		t.Error("registered middleware requiring an unknown op")
+	}
+	for _, mw := range []*nrepl.Middleware{
+		{Name: "test.outer", Wrap: wrap},
+		{Name: "test.inner", Requires: []string{"test.outer"}, Wrap: wrap},
+	} {
+		if err := nrepl.RegisterMiddleware(mw); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := nrepl.RegisterMiddleware(&nrepl.Middleware{
+		Name:     "test.outer",
+		Requires: []string{"test.inner"},
+		Wrap:     wrap,
+	}); err == nil {
+		t.Error("registered a dependency cycle")
+	}
+
+	// The rejected registrations leave the stack working.
+	c := dialTestServer(t)
+	if got := collect(c.eval(c.clone(), "(+ 1 2)"), "value"); len(got) != 1 || got[0] != "3" {
+		t.Errorf("values = %q", got)
+	}
+}
+
+func TestGlojureMiddleware(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.eval(sess, `
+(require 'glojure.nrepl)
+(defn wrap-hello
+  {:nrepl.middleware/descriptor
+   {:requires #{"clone"}
+    :expects #{"eval"}
+    :handles {"hello" {:doc "Says hello."
+                       :requires {"name" "Who to greet."}}}}}
+  [handler]
+  (fn [msg]
+    (if (= "hello" (:op msg))
+      ((:reply msg) {:greeting (str "hello " (:name msg)) :status [:done]})
+      (handler msg))))
+(glojure.nrepl/register-middleware! #'wrap-hello)`)
+	if got := collect(resps, "value"); len(got) != 3 || got[2] != `"user/wrap-hello"` {
+		t.Fatalf("values = %q, err = %q", got, collect(resps, "err"))
+	}
+
+	resps = c.request(map[string]interface{}{"op": "hello", "session": sess, "name": "glojure"})
+	if got := collect(resps, "greeting"); len(got) != 1 || got[0] != "hello glojure" {
+		t.Errorf("greeting = %q", got)
+	}
+	// Other ops pass through it.
+	if got := collect(c.eval(sess, "(+ 1 2) (+ 3 4)"), "value"); strings.Join(got, " ") != "3 7" {
+		t.Errorf("values = %q", got)
+	}
+
+	resps = c.request(map[string]interface{}{"op": "describe", "verbose?": "true"})
+	hello, _ := resps[0]["ops"].(map[string]interface{})["hello"].(map[string]interface{})
+	if hello["doc"] != "Says hello." {
+		t.Errorf("describe hello = %v", hello)
+	}
+}
@@ -1,14 +1,13 @@
 package nrepl
 
 import (
+	"errors"
 	"fmt"
-	"net"
 	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/glojurelang/glojure/pkg/lang"
-	"github.com/glojurelang/glojure/pkg/pkgmap"
 	"github.com/glojurelang/glojure/pkg/reader"
 	"github.com/glojurelang/glojure/pkg/runtime"
 )
@@ -17,12 +16,18 @@ func newSessionID() string {
 	return uuid.New().String()
 }
 
-func (s *Server) opClone(msg map[string]interface{}, conn net.Conn) {
+func (s *Server) opClone(msg map[string]interface{}, conn Transport) {
 	sess := s.createSession()
-	// If cloning from an existing session, inherit its namespace.
+	// If cloning from an existing session, inherit its bindings.
 	if srcID := msgStr(msg, "session"); srcID != "" {
 		if src := s.getSession(srcID); src != nil {
-			sess.NS = src.NS
+			src.mu.Lock()
+			ns, bindings := src.NS, src.bindings
+			src.mu.Unlock()
+			sess.mu.Lock()
+			sess.NS = ns
+			sess.bindings = bindings.Assoc(lang.VarIn, sess.in).(lang.IPersistentMap)
+			sess.mu.Unlock()
 		}
 	}
 	sendMsg(conn, map[string]interface{}{
@@ -32,7 +37,7 @@ func (s *Server) opClone(msg map[string]interface{}, conn net.Conn) {
 	})
 }
 
-func (s *Server) opClose(msg map[string]interface{}, conn net.Conn) {
+func (s *Server) opClose(msg map[string]interface{}, conn Transport) {
 	sessionID := msgStr(msg, "session")
 	if sessionID != "" {
 		s.removeSession(sessionID)
@@ -44,21 +49,13 @@ func (s *Server) opClose(msg map[string]interface{}, conn net.Conn) {
 	})
 }
 
-func (s *Server) opDescribe(msg map[string]interface{}, conn net.Conn) {
+func (s *Server) opDescribe(msg map[string]interface{}, conn Transport) {
+	st := s.handlerStack()
 	sendMsg(conn, map[string]interface{}{
-		"id":      msg["id"],
-		"session": msgStr(msg, "session"),
-		"ops": map[string]interface{}{
-			"clone":       map[string]interface{}{},
-			"close":       map[string]interface{}{},
-			"describe":    map[string]interface{}{},
-			"eval":        map[string]interface{}{},
-			"completions": map[string]interface{}{},
-			"info":        map[string]interface{}{},
-			"interrupt":   map[string]interface{}{},
-			"load-file":   map[string]interface{}{},
-			"ls-sessions": map[string]interface{}{},
-		},
+		"id":         msg["id"],
+		"session":    msgStr(msg, "session"),
+		"ops":        st.describeOps(msgBool(msg, "verbose?")),
+		"middleware": middlewareNames(st.middleware),
 		"versions": map[string]interface{}{
 			"glojure": map[string]interface{}{
 				"version-string": runtime.Version,
@@ -74,114 +71,163 @@ func (s *Server) opDescribe(msg map[string]interface{}, conn net.Conn) {
 	})
 }
 
-func (s *Server) opEval(msg map[string]interface{}, conn net.Conn) {
-	code := msgStr(msg, "code")
-	sessionID := msgStr(msg, "session")
-	msgID := msg["id"]
-
-	sess := s.getOrCreateSession(sessionID)
-	sessionID = sess.ID
+func (s *Server) opEval(msg map[string]interface{}, conn Transport) {
+	sess := s.getOrCreateSession(msgStr(msg, "session"))
+	sess.enqueue(msg, conn, func(in *runtime.Interrupter) {
+		s.eval(sess, msg, conn, in)
+	})
+}
 
-	// Resolve the namespace for this session.
-	if nsStr := msgStr(msg, "ns"); nsStr != "" {
-		sess.NS = nsStr
-	}
-	ns := lang.FindNamespace(lang.NewSymbol(sess.NS))
-	if ns == nil {
-		ns = lang.FindNamespace(lang.NewSymbol("user"))
+// eval evaluates the forms in msg's code one at a time with the session's
+// bindings, replying with the value of each and streaming *out* and
+// *err*. The bindings left by the evaluation are kept for the next one.
+// An interrupt stops the evaluation before the next form.
+func (s *Server) eval(sess *Session, msg map[string]interface{}, conn Transport, in *runtime.Interrupter) {
+	msgID := msg["id"]
+	reply := func(resp map[string]interface{}) {
+		resp["id"] = msgID
+		resp["session"] = sess.ID
+		sendMsg(conn, resp)
 	}
 
-	if ns == nil {
-		// Create the user namespace if it doesn't exist yet.
-		ns = lang.FindOrCreateNamespace(lang.NewSymbol(sess.NS))
+	bindings := sess.frame()
+	if nsStr := msgStr(msg, "ns"); nsStr != "" {
+		ns := lang.FindNamespace(lang.NewSymbol(nsStr))
+		if ns == nil {
+			reply(map[string]interface{}{
+				"status": []interface{}{"error", "namespace-not-found", "done"},
+				"ns":     nsStr,
+			})
+			return
+		}
+		bindings = bindings.Assoc(lang.VarCurrentNS, ns).(lang.IPersistentMap)
 	}
 
-	// Create a writer that sends "out" messages over the nREPL connection.
+	// Writers that send "out" and "err" messages over the connection.
 	outWriter := &nreplWriter{
 		conn:      conn,
 		id:        msgID,
-		sessionID: sessionID,
+		sessionID: sess.ID,
 		key:       "out",
 	}
-	// Push thread bindings for *ns*, *out*, and other dynamic vars
-	// that the evaluator expects (same set as the REPL's initEnv).
-	bindings := lang.NewMap(
-		lang.VarCurrentNS, ns,
-		lang.VarOut, outWriter,
-		lang.VarWarnOnReflection, lang.VarWarnOnReflection.Deref(),
-		lang.VarUncheckedMath, lang.VarUncheckedMath.Deref(),
-		lang.VarDataReaders, lang.VarDataReaders.Deref(),
-	)
+	errWriter := &nreplWriter{
+		conn:      conn,
+		id:        msgID,
+		sessionID: sess.ID,
+		key:       "err",
+	}
+	flush := func() {
+		outWriter.flush()
+		errWriter.flush()
+	}
 
-	var lastValue string
-	var evalErr error
+	sess.in.setRequest(func() {
+		flush()
+		reply(map[string]interface{}{
+			"status": []interface{}{"need-input"},
+		})
+	})
+	defer sess.in.setRequest(nil)
 
-	func() {
-		lang.PushThreadBindings(bindings)
-		defer lang.PopThreadBindings()
-		defer func() {
-			if r := recover(); r != nil {
-				evalErr = fmt.Errorf("%v", r)
-			}
-		}()
-
-		env := lang.GlobalEnv
-		rdr := reader.New(
-			strings.NewReader(code),
-			reader.WithFilename("nrepl"),
-			reader.WithGetCurrentNS(func() *lang.Namespace {
-				return env.CurrentNamespace()
-			}),
-		)
-		vals, err := rdr.ReadAll()
+	star1, star2, star3, starE := coreVar("*1"), coreVar("*2"), coreVar("*3"), coreVar("*e")
+
+	lang.PushThreadBindings(bindings.
+		Assoc(lang.VarOut, outWriter).
+		Assoc(lang.VarErr, errWriter).(lang.IPersistentMap))
+
+	env := lang.GlobalEnv
+	filename := msgStr(msg, "file")
+	if filename == "" {
+		filename = "nrepl"
+	}
+	rdr := reader.New(
+		strings.NewReader(msgStr(msg, "code")),
+		reader.WithFilename(filename),
+		reader.WithStartPosition(msgInt(msg, "line"), msgInt(msg, "column")),
+		reader.WithGetCurrentNS(func() *lang.Namespace {
+			return env.CurrentNamespace()
+		}),
+	)
+	fail := func(err error, phase lang.Keyword) {
+		starE.Set(err)
+		fmt.Fprint(errWriter, runtime.ErrorString(err, phase))
+		flush()
+		ex, rootEx := exceptionClasses(err)
+		reply(map[string]interface{}{
+			"status":  []interface{}{"eval-error"},
+			"ex":      ex,
+			"root-ex": rootEx,
+		})
+	}
+	for !in.Interrupted() {
+		form, err := rdr.ReadOne()
+		if err == reader.ErrEOF {
+			break
+		}
 		if err != nil {
-			evalErr = err
-			return
+			fail(err, lang.PhaseReadSource)
+			break
 		}
-		for _, val := range vals {
-			result, err := env.Eval(val)
-			if err != nil {
-				evalErr = err
-				return
-			}
-			lastValue = lang.PrintString(result)
+		result, err := evalForm(env, form)
+		if errors.Is(err, runtime.ErrInterrupted) {
+			break
 		}
-		// Update session namespace from current thread binding.
-		sess.NS = env.CurrentNamespace().Name().String()
-	}()
+		if err != nil {
+			fail(err, lang.PhaseExecution)
+			continue
+		}
+		star3.Set(star2.Deref())
+		star2.Set(star1.Deref())
+		star1.Set(result)
+		value := lang.PrintString(result)
+		flush()
+		reply(map[string]interface{}{
+			"value": value,
+			"ns":    env.CurrentNamespace().Name().String(),
+		})
+	}
 
-	// Flush any buffered output.
-	outWriter.flush()
+	frame := lang.GetThreadBindings()
+	lang.PopThreadBindings()
+	sess.saveFrame(frame)
 
-	if evalErr != nil {
-		sendMsg(conn, map[string]interface{}{
-			"id":      msgID,
-			"session": sessionID,
-			"err":     evalErr.Error() + "\n",
-		})
-		sendMsg(conn, map[string]interface{}{
-			"id":      msgID,
-			"session": sessionID,
-			"status":  []interface{}{"eval-error", "done"},
-			"ex":      evalErr.Error(),
+	flush()
+	if in.Interrupted() {
+		reply(map[string]interface{}{
+			"status": []interface{}{"interrupted"},
 		})
-		return
 	}
-
-	sendMsg(conn, map[string]interface{}{
-		"id":      msgID,
-		"session": sessionID,
-		"value":   lastValue,
-		"ns":      sess.NS,
-	})
-	sendMsg(conn, map[string]interface{}{
-		"id":      msgID,
-		"session": sessionID,
-		"status":  []interface{}{"done"},
+	reply(map[string]interface{}{
+		"status": []interface{}{"done"},
 	})
 }
 
-func (s *Server) opCompletions(msg map[string]interface{}, conn net.Conn) {
+// evalForm evaluates form, turning a panic into an error.
+// exceptionClasses returns the classes of err and of its root cause
+// for the "ex" and "root-ex" of an eval-error reply, such as "class
+// clojure.lang.ExceptionInfo".
+func exceptionClasses(err error) (ex, rootEx string) {
+	via := lang.Get(runtime.ThrowableMap(err), lang.NewKeyword("via"))
+	class := func(i int) string {
+		return "class " + lang.ToString(lang.Get(lang.Get(via, i), lang.KWType))
+	}
+	return class(0), class(lang.Count(via) - 1)
+}
+
+func evalForm(env lang.Environment, form interface{}) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	return env.Eval(form)
+}
+
+func (s *Server) opCompletions(msg map[string]interface{}, conn Transport) {
 	defer func() {
 		if recover() != nil {
 			sendCompletionMsg(conn, msg, msgStr(msg, "session"), nil)
@@ -200,12 +246,12 @@ func (s *Server) opCompletions(msg map[string]interface{}, conn net.Conn) {
 	// Use the ns from the message if provided, otherwise session ns.
 	nsName := msgStr(msg, "ns")
 	if nsName == "" {
-		nsName = sess.NS
+		nsName = sess.currentNS()
 	}
 	ns := lang.FindNamespace(lang.NewSymbol(nsName))
 	if ns == nil {
 		// Try resolving as an alias in the session namespace.
-		sessNS := lang.FindNamespace(lang.NewSymbol(sess.NS))
+		sessNS := lang.FindNamespace(lang.NewSymbol(sess.currentNS()))
 		if sessNS != nil {
 			ns = sessNS.LookupAlias(lang.NewSymbol(nsName))
 		}
@@ -215,96 +261,15 @@ func (s *Server) opCompletions(msg map[string]interface{}, conn net.Conn) {
 	}
 
 	var completions []interface{}
-
-	// Complete from current namespace mappings.
-	if mappings := ns.Mappings(); mappings != nil {
-		for seq := lang.Seq(mappings); seq != nil; seq = seq.Next() {
-			entry := seq.First().(lang.IMapEntry)
-			sym := entry.Key().(*lang.Symbol)
-			name := sym.Name()
-			if strings.HasPrefix(name, prefix) {
-				comp := map[string]interface{}{
-					"candidate": name,
-				}
-				if vr, ok := entry.Val().(*lang.Var); ok {
-					comp["ns"] = vr.Namespace().Name().String()
-				}
-				completions = append(completions, comp)
-			}
+	for _, c := range Completions(ns, prefix) {
+		comp := map[string]interface{}{"candidate": c.Candidate}
+		if c.NS != "" {
+			comp["ns"] = c.NS
 		}
-	}
-
-	// Complete keywords.
-	if strings.HasPrefix(prefix, ":") {
-		kwPrefix := prefix[1:]
-		for _, kw := range lang.AllKeywords() {
-			if strings.HasPrefix(kw, kwPrefix) {
-				completions = append(completions, map[string]interface{}{
-					"candidate": ":" + kw,
-					"type":      "keyword",
-				})
-			}
-		}
-	}
-
-	// Complete namespace names.
-	for nsSeq := lang.AllNamespaces(); nsSeq != nil; nsSeq = nsSeq.Next() {
-		nsObj := nsSeq.First().(*lang.Namespace)
-		name := nsObj.Name().String()
-		if strings.HasPrefix(name, prefix) {
-			completions = append(completions, map[string]interface{}{
-				"candidate": name,
-				"type":      "namespace",
-			})
-		}
-	}
-
-	// Complete javacompat host classes (Math, System, ...) and their
-	// members. For a bare prefix like "Ma" we offer "Math/"; for a
-	// qualified prefix like "Math/sq" we list matching entries. A fully
-	// qualified form like java.lang.Math or java.time.Instant is treated
-	// as the bare class name by stripping the registered host-class
-	// package prefix.
-	if slash := strings.IndexByte(prefix, '/'); slash > 0 {
-		cls := prefix[:slash]
-		memberPrefix := prefix[slash+1:]
-		lookup := cls
-		if i := strings.LastIndex(cls, "."); i >= 0 {
-			bare := cls[i+1:]
-			if pkgmap.HostClassPackage(bare)+"."+bare == cls {
-				lookup = bare
-			}
-		}
-		for _, name := range pkgmap.PkgEntries(lookup) {
-			if strings.HasPrefix(name, memberPrefix) {
-				completions = append(completions, map[string]interface{}{
-					"candidate": cls + "/" + name,
-					"type":      "static-method",
-				})
-			}
-		}
-	} else {
-		// Only uppercase-leading host classes get the package-qualified
-		// form, since pkgmap.HostClasses() also includes Go stdlib pkgs.
-		// The package comes from pkgmap.SetHostClassPackage, defaulting
-		// to java.lang when unset.
-		for _, hc := range pkgmap.HostClasses() {
-			if strings.HasPrefix(hc, prefix) {
-				completions = append(completions, map[string]interface{}{
-					"candidate": hc + "/",
-					"type":      "class",
-				})
-			}
-			if hc == "" || hc[0] < 'A' || hc[0] > 'Z' {
-				continue
-			}
-			if fq := pkgmap.HostClassPackage(hc) + "." + hc; strings.HasPrefix(fq, prefix) {
-				completions = append(completions, map[string]interface{}{
-					"candidate": fq + "/",
-					"type":      "class",
-				})
-			}
+		if c.Type != "" {
+			comp["type"] = c.Type
 		}
+		completions = append(completions, comp)
 	}
 
 	if completions == nil {
@@ -314,7 +279,7 @@ func (s *Server) opCompletions(msg map[string]interface{}, conn net.Conn) {
 	sendCompletionMsg(conn, msg, sess.ID, completions)
 }
 
-func sendCompletionMsg(conn net.Conn, msg map[string]interface{}, sessionID string, completions []interface{}) {
+func sendCompletionMsg(conn Transport, msg map[string]interface{}, sessionID string, completions []interface{}) {
 	if completions == nil {
 		completions = []interface{}{}
 	}
@@ -326,35 +291,43 @@ func sendCompletionMsg(conn net.Conn, msg map[string]interface{}, sessionID stri
 	})
 }
 
-func (s *Server) opInfo(msg map[string]interface{}, conn net.Conn) {
+func (s *Server) opInfo(msg map[string]interface{}, conn Transport) {
+	sess, vr := s.resolveVar(msg)
+	if vr == nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": sess.ID,
+			"status":  []interface{}{"no-info", "done"},
+		})
+		return
+	}
+
+	resp := varInfo(vr)
+	resp["id"] = msg["id"]
+	resp["session"] = sess.ID
+	resp["status"] = []interface{}{"done"}
+	sendMsg(conn, resp)
+}
+
+// resolveVar resolves the sym field of msg to a var, in the namespace
+// named by the ns field or else the session's namespace.
+func (s *Server) resolveVar(msg map[string]interface{}) (*Session, *lang.Var) {
 	sym := msgStr(msg, "sym")
-	sessionID := msgStr(msg, "session")
-	sess := s.getOrCreateSession(sessionID)
+	sess := s.getOrCreateSession(msgStr(msg, "session"))
 
 	nsName := msgStr(msg, "ns")
 	if nsName == "" {
-		nsName = sess.NS
+		nsName = sess.currentNS()
 	}
 
 	ns := lang.FindNamespace(lang.NewSymbol(nsName))
 	if ns == nil {
 		ns = lang.FindNamespace(lang.NewSymbol("user"))
 	}
-
-	noInfo := func() {
-		sendMsg(conn, map[string]interface{}{
-			"id":      msg["id"],
-			"session": sess.ID,
-			"status":  []interface{}{"no-info", "done"},
-		})
-	}
-
 	if sym == "" || ns == nil {
-		noInfo()
-		return
+		return sess, nil
 	}
 
-	// Resolve the symbol to a var.
 	var vr *lang.Var
 	func() {
 		defer func() { recover() }()
@@ -368,64 +341,94 @@ func (s *Server) opInfo(msg map[string]interface{}, conn net.Conn) {
 			vr, _ = ns.GetMapping(symObj).(*lang.Var)
 		}
 	}()
+	return sess, vr
+}
 
-	if vr == nil {
-		noInfo()
-		return
-	}
-
-	resp := map[string]interface{}{
-		"id":      msg["id"],
-		"session": sess.ID,
-		"name":    vr.Symbol().Name(),
-		"ns":      vr.Namespace().Name().String(),
-		"status":  []interface{}{"done"},
+// varInfo returns the fields describing vr in info responses.
+func varInfo(vr *lang.Var) map[string]interface{} {
+	info := map[string]interface{}{
+		"name": vr.Symbol().Name(),
+		"ns":   vr.Namespace().Name().String(),
 	}
 
 	meta := vr.Meta()
 	if meta != nil {
 		if doc, ok := meta.ValAt(lang.KWDoc).(string); ok && doc != "" {
-			resp["doc"] = doc
+			info["doc"] = doc
 		}
 		if arglists := meta.ValAt(lang.KWArglists); arglists != nil {
-			resp["arglists-str"] = lang.PrintString(arglists)
+			info["arglists-str"] = lang.PrintString(arglists)
 		}
 		if file, ok := meta.ValAt(lang.KWFile).(string); ok && file != "" {
-			resp["file"] = file
+			info["file"] = file
 		}
 		if line := meta.ValAt(lang.KWLine); line != nil {
-			resp["line"] = line
+			info["line"] = line
 		}
 		if col := meta.ValAt(lang.KWColumn); col != nil {
-			resp["column"] = col
+			info["column"] = col
 		}
 	}
 	if vr.IsMacro() {
-		resp["macro"] = "true"
+		info["macro"] = "true"
 	}
-
-	sendMsg(conn, resp)
+	return info
 }
 
-func (s *Server) opInterrupt(msg map[string]interface{}, conn net.Conn) {
-	// Stub: no real interrupt support yet.
+func (s *Server) opInterrupt(msg map[string]interface{}, conn Transport) {
+	sessionID := msgStr(msg, "session")
+	sess := s.getSession(sessionID)
+	if sess == nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": sessionID,
+			"status":  []interface{}{"error", "unknown-session", "done"},
+		})
+		return
+	}
 	sendMsg(conn, map[string]interface{}{
 		"id":      msg["id"],
-		"session": msgStr(msg, "session"),
-		"status":  []interface{}{"done"},
+		"session": sess.ID,
+		"status":  []interface{}{sess.interrupt(msgStr(msg, "interrupt-id")), "done"},
 	})
 }
 
-func (s *Server) opLoadFile(msg map[string]interface{}, conn net.Conn) {
-	// Treat load-file as eval of the file content.
-	file := msgStr(msg, "file")
-	if file != "" {
-		msg["code"] = file
+func (s *Server) opLoadFile(msg map[string]interface{}, conn Transport) {
+	// Treat load-file as eval of the file content, read as the named file.
+	evalMsg := map[string]interface{}{
+		"id":      msg["id"],
+		"session": msg["session"],
+		"ns":      msg["ns"],
+		"code":    msg["file"],
+	}
+	if path := msgStr(msg, "file-path"); path != "" {
+		evalMsg["file"] = path
+	} else if name := msgStr(msg, "file-name"); name != "" {
+		evalMsg["file"] = name
 	}
-	s.opEval(msg, conn)
+	s.opEval(evalMsg, conn)
+}
+
+func (s *Server) opStdin(msg map[string]interface{}, conn Transport) {
+	sessionID := msgStr(msg, "session")
+	sess := s.getSession(sessionID)
+	if sess == nil {
+		sendMsg(conn, map[string]interface{}{
+			"id":      msg["id"],
+			"session": sessionID,
+			"status":  []interface{}{"error", "unknown-session", "done"},
+		})
+		return
+	}
+	sess.in.write(msgStr(msg, "stdin"))
+	sendMsg(conn, map[string]interface{}{
+		"id":      msg["id"],
+		"session": sess.ID,
+		"status":  []interface{}{"done"},
+	})
 }
 
-func (s *Server) opLsSessions(msg map[string]interface{}, conn net.Conn) {
+func (s *Server) opLsSessions(msg map[string]interface{}, conn Transport) {
 	s.mu.RLock()
 	ids := make([]interface{}, 0, len(s.sessions))
 	for id := range s.sessions {
@@ -442,7 +445,7 @@ func (s *Server) opLsSessions(msg map[string]interface{}, conn net.Conn) {
 
 // nreplWriter sends writes as nREPL "out" or "err" messages.
 type nreplWriter struct {
-	conn      net.Conn
+	conn      Transport
 	id        interface{}
 	sessionID string
 	key       string // "out" or "err"
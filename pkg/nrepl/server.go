@@ -1,6 +1,7 @@
 package nrepl
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -13,63 +14,111 @@ import (
 
 // Server is a minimal nREPL server for editor integration.
 type Server struct {
-	listener    net.Listener
-	sessions    map[string]*Session
-	mu          sync.RWMutex
-	done        chan struct{}
-	portFile    string
-	wg          sync.WaitGroup
+	listener net.Listener
+	sessions map[string]*Session
+	mu       sync.RWMutex
+	done     chan struct{}
+	portFile string
+	framing  string
+	wg       sync.WaitGroup
+	stack    *stack // guarded by mu
 }
 
 // Session tracks per-session state for an nREPL client.
 type Session struct {
 	ID string
 	NS string // current namespace name
+
+	mu       sync.Mutex
+	bindings lang.IPersistentMap // dynamic bindings kept between evaluations
+	in       *sessionInput
+
+	// The eval queue, run by the session's worker goroutine.
+	wake    *sync.Cond
+	queue   []*evalTask
+	current *evalTask
+	closed  bool
+
+	failedTests []*lang.Var // tests that did not pass when last run
 }
 
-// Start creates and starts an nREPL server on the given host and port.
-// Port 0 means auto-assign a free port.
+// Start creates and starts a bencode nREPL server on the given host and
+// port. Port 0 means auto-assign a free port.
 func Start(host string, port int, portFile string) (*Server, error) {
-	addr := net.JoinHostPort(host, strconv.Itoa(port))
-	ln, err := net.Listen("tcp", addr)
+	return Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)), WithPortFile(portFile))
+}
+
+// Listen creates an nREPL server listening on network, "tcp" or "unix",
+// at addr.
+func Listen(network, addr string, opts ...Option) (*Server, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := checkFraming(o.framing); err != nil {
+		return nil, err
+	}
+	if network != "tcp" && network != "unix" {
+		return nil, fmt.Errorf("nrepl: unsupported network %q", network)
+	}
+	ln, err := net.Listen(network, addr)
 	if err != nil {
 		return nil, fmt.Errorf("nrepl: listen %s: %w", addr, err)
 	}
+	if o.tls != nil {
+		ln = tls.NewListener(ln, o.tls)
+	}
 
 	// Ensure the user namespace is properly initialized.
 	initUserNS()
+	installNS()
 
 	s := &Server{
 		listener: ln,
 		sessions: make(map[string]*Session),
 		done:     make(chan struct{}),
-		portFile: portFile,
+		framing:  o.framing,
 	}
 
-	if portFile != "" {
-		dir := filepath.Dir(portFile)
+	// Only TCP servers have a port to write.
+	if o.portFile != "" && network == "tcp" {
+		dir := filepath.Dir(o.portFile)
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			ln.Close()
 			return nil, fmt.Errorf("nrepl: create port file dir: %w", err)
 		}
 		actualPort := s.Port()
-		if err := os.WriteFile(portFile, []byte(strconv.Itoa(actualPort)), 0644); err != nil {
+		if err := os.WriteFile(o.portFile, []byte(strconv.Itoa(actualPort)), 0644); err != nil {
 			ln.Close()
 			return nil, fmt.Errorf("nrepl: write port file: %w", err)
 		}
+		s.portFile = o.portFile
 	}
 
 	return s, nil
 }
 
-// Port returns the port the server is listening on.
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Port returns the port the server is listening on, or 0 for a unix
+// socket.
 func (s *Server) Port() int {
-	return s.listener.Addr().(*net.TCPAddr).Port
+	if addr, ok := s.listener.Addr().(*net.TCPAddr); ok {
+		return addr.Port
+	}
+	return 0
 }
 
-// Host returns the host the server is listening on.
+// Host returns the host the server is listening on, or "" for a unix
+// socket.
 func (s *Server) Host() string {
-	return s.listener.Addr().(*net.TCPAddr).IP.String()
+	if addr, ok := s.listener.Addr().(*net.TCPAddr); ok {
+		return addr.IP.String()
+	}
+	return ""
 }
 
 // Serve accepts connections in a loop. Blocks until Stop is called.
@@ -98,54 +147,42 @@ func (s *Server) Stop() {
 	close(s.done)
 	s.listener.Close()
 	s.wg.Wait()
+	s.mu.Lock()
+	for id, sess := range s.sessions {
+		sess.close()
+		delete(s.sessions, id)
+	}
+	s.mu.Unlock()
 	if s.portFile != "" {
 		os.Remove(s.portFile)
 	}
 }
 
-func (s *Server) handleConnection(conn net.Conn) {
+func (s *Server) handleConnection(nc net.Conn) {
+	conn, _ := NewTransport(nc, s.framing)
 	defer conn.Close()
-	br := newByteReader(conn)
 	for {
-		val, err := bencodeRead(br)
+		msg, err := conn.Recv()
 		if err != nil {
 			return // connection closed or read error
 		}
-		msg, ok := val.(map[string]interface{})
-		if !ok {
-			continue
-		}
 		s.dispatch(msg, conn)
 	}
 }
 
-func (s *Server) dispatch(msg map[string]interface{}, conn net.Conn) {
-	op, _ := msg["op"].(string)
-	switch op {
-	case "clone":
-		s.opClone(msg, conn)
-	case "close":
-		s.opClose(msg, conn)
-	case "describe":
-		s.opDescribe(msg, conn)
-	case "eval":
-		s.opEval(msg, conn)
-	case "completions":
-		s.opCompletions(msg, conn)
-	case "info":
-		s.opInfo(msg, conn)
-	case "interrupt":
-		s.opInterrupt(msg, conn)
-	case "load-file":
-		s.opLoadFile(msg, conn)
-	case "ls-sessions":
-		s.opLsSessions(msg, conn)
-	default:
-		sendMsg(conn, map[string]interface{}{
-			"id":     msg["id"],
-			"status": []interface{}{"error", "unknown-op", "done"},
-		})
-	}
+func (s *Server) dispatch(msg map[string]interface{}, conn Transport) {
+	req := &Request{Msg: msg, Transport: conn, Server: s}
+	defer func() {
+		// A failing middleware answers its request with an error rather
+		// than taking down the connection.
+		if r := recover(); r != nil {
+			req.Reply(map[string]interface{}{
+				"status": []interface{}{"error", "done"},
+				"err":    fmt.Sprintf("%v\n", r),
+			})
+		}
+	}()
+	s.handlerStack().handler(req)
 }
 
 func (s *Server) getSession(id string) *Session {
@@ -167,7 +204,11 @@ func (s *Server) createSession() *Session {
 	sess := &Session{
 		ID: newSessionID(),
 		NS: "user",
+		in: newSessionInput(),
 	}
+	sess.bindings = newSessionBindings(lang.FindOrCreateNamespace(lang.NewSymbol(sess.NS)), sess.in)
+	sess.wake = sync.NewCond(&sess.mu)
+	go sess.work()
 	s.mu.Lock()
 	s.sessions[sess.ID] = sess
 	s.mu.Unlock()
@@ -197,25 +238,50 @@ func initUserNS() {
 
 func (s *Server) removeSession(id string) {
 	s.mu.Lock()
+	sess := s.sessions[id]
 	delete(s.sessions, id)
 	s.mu.Unlock()
+	if sess != nil {
+		sess.close()
+	}
 }
 
-func sendMsg(conn net.Conn, msg map[string]interface{}) {
+func sendMsg(conn Transport, msg map[string]interface{}) {
 	// Strip nil values -- bencode can't encode nil.
 	for k, v := range msg {
 		if v == nil {
 			delete(msg, k)
 		}
 	}
-	data, err := BencodeEncode(msg)
-	if err != nil {
-		return
-	}
-	conn.Write(data)
+	conn.Send(msg)
 }
 
 func msgStr(msg map[string]interface{}, key string) string {
 	v, _ := msg[key].(string)
 	return v
 }
+
+// msgBool reports whether a flag field is set. Clients send flags as
+// integers or strings.
+func msgBool(msg map[string]interface{}, key string) bool {
+	switch v := msg[key].(type) {
+	case int64:
+		return v != 0
+	case string:
+		return v != "" && v != "false"
+	}
+	return false
+}
+
+// msgInt returns an integer field, which clients may send as a bencode
+// integer or a string.
+func msgInt(msg map[string]interface{}, key string) int {
+	switch v := msg[key].(type) {
+	case int64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
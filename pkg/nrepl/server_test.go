@@ -0,0 +1,324 @@
+package nrepl_test
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+	"github.com/glojurelang/glojure/pkg/nrepl"
+)
+
+type testConn struct {
+	t    *testing.T
+	conn net.Conn
+	n    int
+}
+
+func dialTestServer(t *testing.T) *testConn {
+	t.Helper()
+	srv, err := nrepl.Start("127.0.0.1", 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve()
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(srv.Port())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+	return &testConn{t: t, conn: conn}
+}
+
+// send sends msg with a fresh id and returns the id.
+func (c *testConn) send(msg map[string]interface{}) string {
+	c.t.Helper()
+	c.n++
+	id := strconv.Itoa(c.n)
+	msg["id"] = id
+	data, err := nrepl.BencodeEncode(msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := c.conn.Write(data); err != nil {
+		c.t.Fatal(err)
+	}
+	return id
+}
+
+func (c *testConn) recv() map[string]interface{} {
+	c.t.Helper()
+	c.conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	val, err := nrepl.BencodeDecode(c.conn)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return val.(map[string]interface{})
+}
+
+// request sends msg and collects the responses up to the one with status
+// done.
+func (c *testConn) request(msg map[string]interface{}) []map[string]interface{} {
+	c.t.Helper()
+	c.send(msg)
+	var resps []map[string]interface{}
+	for {
+		resp := c.recv()
+		resps = append(resps, resp)
+		if hasStatus(resp, "done") {
+			return resps
+		}
+	}
+}
+
+func (c *testConn) clone() string {
+	c.t.Helper()
+	resps := c.request(map[string]interface{}{"op": "clone"})
+	return resps[0]["new-session"].(string)
+}
+
+func (c *testConn) eval(session, code string) []map[string]interface{} {
+	c.t.Helper()
+	return c.request(map[string]interface{}{"op": "eval", "session": session, "code": code})
+}
+
+func collect(resps []map[string]interface{}, key string) []string {
+	var vals []string
+	for _, resp := range resps {
+		if v, ok := resp[key].(string); ok {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+func hasStatus(resp map[string]interface{}, status string) bool {
+	list, _ := resp["status"].([]interface{})
+	for _, s := range list {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEvalReturnsEachValue(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.eval(sess, `(+ 1 2) (println "hi") (binding [*out* *err*] (print "oops\n")) :k`)
+	if got, want := strings.Join(collect(resps, "value"), " "), "3 nil nil :k"; got != want {
+		t.Errorf("values = %q, want %q", got, want)
+	}
+	if got := strings.Join(collect(resps, "out"), ""); got != "hi\n" {
+		t.Errorf("out = %q", got)
+	}
+	if got := strings.Join(collect(resps, "err"), ""); got != "oops\n" {
+		t.Errorf("err = %q", got)
+	}
+}
+
+func TestSessionKeepsBindings(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	c.eval(sess, `(set! *warn-on-reflection* true) (ns other.ns) (+ 20 22) :a`)
+	resps := c.eval(sess, `[*warn-on-reflection* (str *ns*) *1 *2]`)
+	if got := collect(resps, "value"); len(got) != 1 || got[0] != `[true "other.ns" :a 42]` {
+		t.Errorf("values = %q", got)
+	}
+	if ns := collect(resps, "ns"); len(ns) != 1 || ns[0] != "other.ns" {
+		t.Errorf("ns = %q", ns)
+	}
+
+	// Bindings are per session.
+	other := c.clone()
+	resps = c.eval(other, `[(true? *warn-on-reflection*) (str *ns*) *1]`)
+	if got := collect(resps, "value"); len(got) != 1 || got[0] != `[false "user" nil]` {
+		t.Errorf("values in new session = %q", got)
+	}
+}
+
+func TestEvalErrorSetsStarE(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.eval(sess, `(throw (ex-info "boom" {})) :after`)
+	var sawError bool
+	for _, resp := range resps {
+		sawError = sawError || hasStatus(resp, "eval-error")
+	}
+	if !sawError {
+		t.Errorf("no eval-error status in %v", resps)
+	}
+	if errs := strings.Join(collect(resps, "err"), ""); !strings.Contains(errs, "boom") {
+		t.Errorf("err = %q", errs)
+	}
+	if got := collect(resps, "value"); len(got) != 1 || got[0] != ":after" {
+		t.Errorf("values = %q", got)
+	}
+
+	resps = c.eval(sess, `(ex-message *e)`)
+	if got := collect(resps, "value"); len(got) != 1 || got[0] != `"boom"` {
+		t.Errorf("values = %q", got)
+	}
+}
+
+func TestEvalUsesMessagePosition(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.request(map[string]interface{}{
+		"op":      "eval",
+		"session": sess,
+		"code":    "(meta '(x))",
+		"file":    "src/app.glj",
+		"line":    int64(10),
+		"column":  int64(3),
+	})
+	got := collect(resps, "value")
+	if len(got) != 1 || !strings.Contains(got[0], ":line 10,") || !strings.Contains(got[0], ":column 10,") || !strings.Contains(got[0], `"src/app.glj"`) {
+		t.Errorf("values = %q", got)
+	}
+}
+
+func TestEvalReadsStdin(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	evalID := c.send(map[string]interface{}{
+		"op":      "eval",
+		"session": sess,
+		"code":    `[(read-line) (read-line) (read-line)]`,
+	})
+	for _, input := range []string{"first\nsec", "ond\n", ""} {
+		for {
+			resp := c.recv()
+			if resp["id"] == evalID && hasStatus(resp, "need-input") {
+				break
+			}
+			if resp["id"] == evalID && hasStatus(resp, "done") {
+				t.Fatalf("eval finished early: %v", resp)
+			}
+		}
+		c.send(map[string]interface{}{"op": "stdin", "session": sess, "stdin": input})
+	}
+
+	var values []string
+	for {
+		resp := c.recv()
+		if resp["id"] != evalID {
+			continue
+		}
+		if v, ok := resp["value"].(string); ok {
+			values = append(values, v)
+		}
+		if hasStatus(resp, "done") {
+			break
+		}
+	}
+	if len(values) != 1 || values[0] != `["first" "second" nil]` {
+		t.Errorf("values = %q", values)
+	}
+}
+
+// await reads responses until one to id satisfies ok, failing if the
+// request finishes first.
+func (c *testConn) await(id string, ok func(map[string]interface{}) bool) map[string]interface{} {
+	c.t.Helper()
+	for {
+		resp := c.recv()
+		if resp["id"] != id {
+			continue
+		}
+		if ok(resp) {
+			return resp
+		}
+		if hasStatus(resp, "done") {
+			c.t.Fatalf("request %s finished early: %v", id, resp)
+		}
+	}
+}
+
+func TestInterruptStopsRunningEval(t *testing.T) {
+	for _, loop := range []string{
+		`(loop [] (recur))`,
+		`(loop [i 0] (if (>= i 0) (recur (inc i)) i))`,
+		`((fn spin [n] (spin (inc n))) 0)`,
+	} {
+		t.Run(loop, func(t *testing.T) {
+			c := dialTestServer(t)
+			sess := c.clone()
+
+			evalID := c.send(map[string]interface{}{
+				"op":      "eval",
+				"session": sess,
+				"code":    `(println "started") ` + loop + ` :not-reached`,
+			})
+			c.await(evalID, func(resp map[string]interface{}) bool { return resp["out"] == "started\n" })
+
+			// Tooling ops are answered while the eval runs.
+			descID := c.send(map[string]interface{}{"op": "ns-list", "session": sess})
+			c.await(descID, func(resp map[string]interface{}) bool { return hasStatus(resp, "done") })
+
+			intID := c.send(map[string]interface{}{"op": "interrupt", "session": sess, "interrupt-id": evalID})
+			var interrupted, replied bool
+			for !interrupted || !replied {
+				resp := c.recv()
+				switch resp["id"] {
+				case intID:
+					if !hasStatus(resp, "interrupted") {
+						t.Fatalf("interrupt reply = %v", resp)
+					}
+					replied = true
+				case evalID:
+					if _, ok := resp["value"]; ok {
+						t.Errorf("interrupted eval replied with a value: %v", resp)
+					}
+					if hasStatus(resp, "interrupted") {
+						interrupted = true
+					}
+				}
+			}
+			c.await(evalID, func(resp map[string]interface{}) bool { return hasStatus(resp, "done") })
+
+			// The session keeps working.
+			if got := collect(c.eval(sess, ":after"), "value"); len(got) != 1 || got[0] != ":after" {
+				t.Errorf("values = %q", got)
+			}
+		})
+	}
+}
+
+func TestInterruptStatuses(t *testing.T) {
+	c := dialTestServer(t)
+	sess := c.clone()
+
+	resps := c.request(map[string]interface{}{"op": "interrupt", "session": sess})
+	if !hasStatus(resps[0], "session-idle") {
+		t.Errorf("interrupting an idle session = %v", resps[0])
+	}
+
+	evalID := c.send(map[string]interface{}{"op": "eval", "session": sess, "code": `(read-line)`})
+	c.await(evalID, func(resp map[string]interface{}) bool { return hasStatus(resp, "need-input") })
+	queuedID := c.send(map[string]interface{}{"op": "eval", "session": sess, "code": `:queued`})
+
+	resps = c.request(map[string]interface{}{"op": "interrupt", "session": sess, "interrupt-id": "no-such-id"})
+	if !hasStatus(resps[0], "interrupt-id-mismatch") {
+		t.Errorf("interrupting another id = %v", resps[0])
+	}
+
+	// A queued eval is removed without running.
+	intID := c.send(map[string]interface{}{"op": "interrupt", "session": sess, "interrupt-id": queuedID})
+	c.await(queuedID, func(resp map[string]interface{}) bool { return hasStatus(resp, "interrupted") })
+	c.await(intID, func(resp map[string]interface{}) bool { return hasStatus(resp, "interrupted") })
+
+	// An eval waiting for input is interrupted.
+	c.send(map[string]interface{}{"op": "interrupt", "session": sess})
+	c.await(evalID, func(resp map[string]interface{}) bool { return hasStatus(resp, "interrupted") })
+}
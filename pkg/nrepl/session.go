@@ -0,0 +1,327 @@
+package nrepl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// sessionVarNames are the clojure.core vars every session binds, as
+// clojure.main/repl does, so that set! works on them and changes last
+// for the life of the session.
+var sessionVarNames = []string{
+	"*warn-on-reflection*",
+	"*unchecked-math*",
+	"*data-readers*",
+	"*default-data-reader-fn*",
+	"*print-meta*",
+	"*print-length*",
+	"*print-level*",
+	"*print-namespace-maps*",
+	"*assert*",
+	"*command-line-args*",
+	"*compile-path*",
+}
+
+// replVarNames are the vars holding recent results and the last error.
+// They start out nil in each session.
+var replVarNames = []string{"*1", "*2", "*3", "*e"}
+
+func coreVar(name string) *lang.Var {
+	return lang.NSCore.FindInternedVar(lang.NewSymbol(name))
+}
+
+// newSessionBindings returns the initial binding frame of a session.
+func newSessionBindings(ns *lang.Namespace, in *sessionInput) lang.IPersistentMap {
+	kvs := []interface{}{
+		lang.VarCurrentNS, ns,
+		lang.VarIn, in,
+	}
+	for _, name := range sessionVarNames {
+		if vr := coreVar(name); vr != nil && vr.IsDynamic() {
+			kvs = append(kvs, vr, vr.Deref())
+		}
+	}
+	for _, name := range replVarNames {
+		if vr := coreVar(name); vr != nil && vr.IsDynamic() {
+			kvs = append(kvs, vr, nil)
+		}
+	}
+	return lang.NewMap(kvs...)
+}
+
+// currentNS returns the name of the session's current namespace.
+func (sess *Session) currentNS() string {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.NS
+}
+
+// frame returns the binding frame the next evaluation starts with.
+func (sess *Session) frame() lang.IPersistentMap {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.bindings
+}
+
+// saveFrame records the bindings left by an evaluation. The output
+// writers belong to the message being answered, so they are not kept.
+func (sess *Session) saveFrame(bindings lang.IPersistentMap) {
+	bindings = bindings.Without(lang.VarOut).Without(lang.VarErr)
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.bindings = bindings
+	if ns, ok := bindings.ValAt(lang.VarCurrentNS).(*lang.Namespace); ok {
+		sess.NS = ns.Name().String()
+	}
+}
+
+// evalTask is a request waiting in, or running from, a session's eval
+// queue.
+type evalTask struct {
+	msg         map[string]interface{}
+	conn        Transport
+	run         func(in *runtime.Interrupter)
+	interrupter runtime.Interrupter
+}
+
+func (t *evalTask) reply(sessionID string, resp map[string]interface{}) {
+	resp["id"] = t.msg["id"]
+	resp["session"] = sessionID
+	sendMsg(t.conn, resp)
+}
+
+// enqueue adds a request to the session's eval queue. The session's
+// worker runs queued requests one at a time and in order, so the
+// connection keeps answering other messages, such as stdin, interrupt
+// and tooling ops, while they run.
+func (sess *Session) enqueue(msg map[string]interface{}, conn Transport, run func(in *runtime.Interrupter)) {
+	task := &evalTask{msg: msg, conn: conn, run: run}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.closed {
+		task.reply(sess.ID, map[string]interface{}{
+			"status": []interface{}{"error", "unknown-session", "done"},
+		})
+		return
+	}
+	sess.queue = append(sess.queue, task)
+	sess.wake.Signal()
+}
+
+// work is the session's worker goroutine. It runs until the session is
+// closed.
+func (sess *Session) work() {
+	for {
+		sess.mu.Lock()
+		for len(sess.queue) == 0 && !sess.closed {
+			sess.wake.Wait()
+		}
+		if sess.closed {
+			sess.mu.Unlock()
+			return
+		}
+		task := sess.queue[0]
+		sess.queue[0] = nil
+		sess.queue = sess.queue[1:]
+		sess.current = task
+		sess.mu.Unlock()
+
+		sess.runTask(task)
+
+		sess.mu.Lock()
+		sess.current = nil
+		sess.mu.Unlock()
+	}
+}
+
+func (sess *Session) runTask(task *evalTask) {
+	defer func() {
+		// Requests recover their own errors; this keeps the worker alive
+		// if one does not.
+		if r := recover(); r != nil {
+			task.reply(sess.ID, map[string]interface{}{
+				"err":    fmt.Sprintf("%v\n", r),
+				"status": []interface{}{"error", "done"},
+			})
+		}
+	}()
+	task.interrupter.Run(func() {
+		task.run(&task.interrupter)
+	})
+}
+
+// interrupt interrupts the running request, or removes a queued one,
+// with the message id id; an empty id means the running request. It
+// returns the status to answer the interrupt with.
+func (sess *Session) interrupt(id string) string {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if id != "" {
+		for i, task := range sess.queue {
+			if msgStr(task.msg, "id") == id {
+				sess.queue = append(sess.queue[:i], sess.queue[i+1:]...)
+				task.reply(sess.ID, map[string]interface{}{
+					"status": []interface{}{"interrupted", "done"},
+				})
+				return "interrupted"
+			}
+		}
+	}
+	switch {
+	case sess.current == nil:
+		return "session-idle"
+	case id != "" && msgStr(sess.current.msg, "id") != id:
+		return "interrupt-id-mismatch"
+	}
+	sess.current.interrupter.Interrupt()
+	sess.in.interrupt()
+	return "interrupted"
+}
+
+// close stops the session's worker, interrupting the running request
+// and dropping queued ones.
+func (sess *Session) close() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.closed {
+		return
+	}
+	sess.closed = true
+	for _, task := range sess.queue {
+		task.reply(sess.ID, map[string]interface{}{
+			"status": []interface{}{"interrupted", "done"},
+		})
+	}
+	sess.queue = nil
+	if sess.current != nil {
+		sess.current.interrupter.Interrupt()
+	}
+	sess.in.close()
+	sess.wake.Broadcast()
+}
+
+// sessionInput is the *in* of a session. Reads block until a stdin
+// message supplies input, asking the client for it with a need-input
+// status.
+type sessionInput struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	buf     []byte
+	eof     bool // an empty stdin message, consumed by the next read
+	closed  bool
+	request func()
+
+	interrupted bool // the reading request was interrupted
+}
+
+var _ io.Reader = (*sessionInput)(nil)
+
+func newSessionInput() *sessionInput {
+	in := &sessionInput{}
+	in.cond = sync.NewCond(&in.mu)
+	return in
+}
+
+// write adds input from a stdin message. Empty input signals end of
+// file to the read waiting for it.
+func (in *sessionInput) write(s string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if s == "" {
+		in.eof = true
+	} else {
+		in.buf = append(in.buf, s...)
+	}
+	in.cond.Broadcast()
+}
+
+// close makes every pending and future read return end of file.
+func (in *sessionInput) close() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.closed = true
+	in.cond.Broadcast()
+}
+
+// setRequest sets the function called when a read finds no input. It
+// is called as a request starts and, with nil, as it ends, and clears
+// any interrupt.
+func (in *sessionInput) setRequest(fn func()) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.request = fn
+	in.interrupted = false
+}
+
+// interrupt makes the pending and future reads of the current request
+// fail with runtime.ErrInterrupted.
+func (in *sessionInput) interrupt() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.interrupted = true
+	in.cond.Broadcast()
+}
+
+// fill waits with mu held until input is buffered, reporting false at
+// end of file.
+func (in *sessionInput) fill() bool {
+	requested := false
+	for len(in.buf) == 0 && !in.eof && !in.closed && !in.interrupted {
+		if !requested && in.request != nil {
+			in.request()
+			requested = true
+		}
+		in.cond.Wait()
+	}
+	if len(in.buf) == 0 {
+		in.eof = false
+		return false
+	}
+	return true
+}
+
+func (in *sessionInput) Read(p []byte) (int, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if !in.fill() {
+		if in.interrupted {
+			return 0, runtime.ErrInterrupted
+		}
+		return 0, io.EOF
+	}
+	n := copy(p, in.buf)
+	in.buf = in.buf[n:]
+	return n, nil
+}
+
+// ReadLine implements read-line. It returns the next line without its
+// line terminator, or nil at end of file.
+func (in *sessionInput) ReadLine() interface{} {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	var line []byte
+	for {
+		if !in.fill() {
+			if in.interrupted {
+				panic(runtime.ErrInterrupted)
+			}
+			if len(line) == 0 {
+				return nil
+			}
+			return string(line)
+		}
+		if i := bytes.IndexByte(in.buf, '\n'); i >= 0 {
+			line = append(line, in.buf[:i]...)
+			in.buf = in.buf[i+1:]
+			return strings.TrimSuffix(string(line), "\r")
+		}
+		line = append(line, in.buf...)
+		in.buf = in.buf[:0]
+	}
+}
@@ -0,0 +1,306 @@
+package nrepl
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// Transport sends and receives nREPL messages over a connection. Send
+// may be called from several goroutines; each message is written
+// whole.
+type Transport interface {
+	Recv() (map[string]interface{}, error)
+	Send(msg map[string]interface{}) error
+	Close() error
+}
+
+// Framings are the message encodings a Transport can use.
+const (
+	FramingBencode = "bencode"
+	FramingEDN     = "edn"
+)
+
+// NewTransport returns a Transport over conn using framing, one of
+// FramingBencode and FramingEDN.
+func NewTransport(conn net.Conn, framing string) (Transport, error) {
+	if err := checkFraming(framing); err != nil {
+		return nil, err
+	}
+	if framing == FramingEDN {
+		return &ednTransport{conn: conn, rdr: reader.New(bufio.NewReader(conn))}, nil
+	}
+	return &bencodeTransport{conn: conn, br: bufio.NewReader(conn)}, nil
+}
+
+func checkFraming(framing string) error {
+	switch framing {
+	case "", FramingBencode, FramingEDN:
+		return nil
+	}
+	return fmt.Errorf("nrepl: unknown framing %q", framing)
+}
+
+type bencodeTransport struct {
+	conn net.Conn
+	br   *bufio.Reader
+	mu   sync.Mutex // serializes writes
+}
+
+func (t *bencodeTransport) Recv() (map[string]interface{}, error) {
+	for {
+		val, err := bencodeRead(t.br)
+		if err != nil {
+			return nil, err
+		}
+		if msg, ok := val.(map[string]interface{}); ok {
+			return msg, nil
+		}
+	}
+}
+
+func (t *bencodeTransport) Send(msg map[string]interface{}) error {
+	data, err := BencodeEncode(msg)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, err = t.conn.Write(data)
+	return err
+}
+
+func (t *bencodeTransport) Close() error {
+	return t.conn.Close()
+}
+
+// ednTransport frames messages as EDN maps with keyword keys, as
+// nREPL's EDN transport does. Statuses are sets of keywords.
+type ednTransport struct {
+	conn net.Conn
+	rdr  *reader.Reader
+	mu   sync.Mutex // serializes writes
+}
+
+func (t *ednTransport) Recv() (msg map[string]interface{}, err error) {
+	for {
+		form, err := t.readForm()
+		if err == reader.ErrEOF {
+			return nil, net.ErrClosed
+		}
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := form.(lang.IPersistentMap); ok {
+			return fromGlojure(m).(map[string]interface{}), nil
+		}
+	}
+}
+
+// readForm reads the next form, turning reader panics into errors.
+func (t *ednTransport) readForm() (form interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("nrepl: edn: %v", r)
+		}
+	}()
+	return t.rdr.ReadOne()
+}
+
+func (t *ednTransport) Send(msg map[string]interface{}) error {
+	var buf bytes.Buffer
+	if err := ednWrite(&buf, msg, false); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, err := t.conn.Write(buf.Bytes())
+	return err
+}
+
+func (t *ednTransport) Close() error {
+	return t.conn.Close()
+}
+
+// ednWrite writes a message value as EDN. Map keys become keywords, and
+// the status list a set of keywords.
+func ednWrite(buf *bytes.Buffer, v interface{}, keywords bool) error {
+	switch val := v.(type) {
+	case string:
+		if keywords {
+			buf.WriteByte(':')
+			buf.WriteString(val)
+			return nil
+		}
+		ednWriteString(buf, val)
+	case int:
+		buf.WriteString(strconv.Itoa(val))
+	case int64:
+		buf.WriteString(strconv.FormatInt(val, 10))
+	case []interface{}:
+		open, close := "[", "]"
+		if keywords {
+			open, close = "#{", "}"
+		}
+		buf.WriteString(open)
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			if err := ednWrite(buf, item, keywords); err != nil {
+				return err
+			}
+		}
+		buf.WriteString(close)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteByte(':')
+			buf.WriteString(k)
+			buf.WriteByte(' ')
+			if err := ednWrite(buf, val[k], k == "status"); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("edn: unsupported type %T", v)
+	}
+	return nil
+}
+
+func ednWriteString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == utf8.RuneError {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// Option configures how a server listens or a client dials.
+type Option func(*options)
+
+type options struct {
+	framing  string
+	tls      *tls.Config
+	portFile string
+}
+
+// WithFraming selects the message framing, FramingBencode (the default)
+// or FramingEDN.
+func WithFraming(framing string) Option {
+	return func(o *options) {
+		o.framing = framing
+	}
+}
+
+// WithTLS makes connections use TLS with config.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.tls = config
+	}
+}
+
+// WithPortFile makes a TCP server write its port to path, removing the
+// file when it stops.
+func WithPortFile(path string) Option {
+	return func(o *options) {
+		o.portFile = path
+	}
+}
+
+// ServerTLSConfig returns a TLS configuration for a server presenting
+// the certificate in certFile and keyFile. If clientCAFile is not
+// empty, clients must present a certificate signed by one of the CAs
+// in it.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("nrepl: load certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pool, err := loadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ClientTLSConfig returns a TLS configuration for a client verifying
+// the server against the CAs in caFile, or the system roots if it is
+// empty, and presenting the certificate in certFile and keyFile if they
+// are not empty.
+func ClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("nrepl: load certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("nrepl: read CA certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("nrepl: no certificates in %s", file)
+	}
+	return pool, nil
+}
@@ -0,0 +1,174 @@
+package nrepl_test
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/nrepl"
+)
+
+func listen(t *testing.T, network, addr string, opts ...nrepl.Option) *nrepl.Server {
+	t.Helper()
+	srv, err := nrepl.Listen(network, addr, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve()
+	// Cleanups run last-in first-out, so clients close before Stop
+	// waits for their connections.
+	t.Cleanup(srv.Stop)
+	return srv
+}
+
+func dial(t *testing.T, network, addr string, opts ...nrepl.Option) *nrepl.Client {
+	t.Helper()
+	client, err := nrepl.Dial(network, addr, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func checkEval(t *testing.T, client *nrepl.Client) {
+	t.Helper()
+	value, _, out, err := client.Eval(`(println "hi") (+ 1 2)`)
+	if err != nil || value != "3" || out != "hi\n" {
+		t.Errorf("Eval = %q, %q, %v", value, out, err)
+	}
+}
+
+func TestEDNTransport(t *testing.T) {
+	srv := listen(t, "tcp", "127.0.0.1:0", nrepl.WithFraming(nrepl.FramingEDN))
+	client := dial(t, "tcp", srv.Addr().String(), nrepl.WithFraming(nrepl.FramingEDN))
+	checkEval(t, client)
+
+	// Messages are EDN maps with keyword keys and keyword statuses.
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	if _, err := conn.Write([]byte(`{:op "eval" :code "(str \"a\" \"\\\"b\")" :id "7"}`)); err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(conn)
+	var lines []string
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read: %v, after %q", err, lines)
+		}
+		lines = append(lines, line)
+		if strings.Contains(line, ":done") {
+			break
+		}
+	}
+	got := strings.Join(lines, "")
+	if !strings.Contains(got, `:value "\"a\\\"b\""`) || !strings.Contains(got, `:status #{:done}`) || !strings.Contains(got, `:id "7"`) {
+		t.Errorf("responses = %s", got)
+	}
+}
+
+func TestUnixSocketTransport(t *testing.T) {
+	dir, err := os.MkdirTemp("", "nrepl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nrepl.sock")
+
+	srv := listen(t, "unix", path)
+	if srv.Port() != 0 {
+		t.Errorf("Port() = %d for a unix socket", srv.Port())
+	}
+	checkEval(t, dial(t, "unix", path))
+}
+
+func TestTLSTransportRequiresClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	writeCert(t, dir, "client", ca, caKey)
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	serverConfig, err := nrepl.ServerTLSConfig(file("server.pem"), file("server-key.pem"), file("ca.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := listen(t, "tcp", "127.0.0.1:0", nrepl.WithTLS(serverConfig))
+
+	clientConfig, err := nrepl.ClientTLSConfig(file("client.pem"), file("client-key.pem"), file("ca.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEval(t, dial(t, "tcp", srv.Addr().String(), nrepl.WithTLS(clientConfig)))
+
+	anonymous, err := nrepl.ClientTLSConfig("", "", file("ca.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client, err := nrepl.Dial("tcp", srv.Addr().String(), nrepl.WithTLS(anonymous)); err == nil {
+		client.Close()
+		t.Error("connected without a client certificate")
+	}
+}
+
+// writeCert writes name.pem and name-key.pem to dir, signed by parent,
+// or self-signed as a CA if parent is nil.
+func writeCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, block := range map[string]*pem.Block{
+		name + ".pem":     {Type: "CERTIFICATE", Bytes: der},
+		name + "-key.pem": {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := os.WriteFile(filepath.Join(dir, file), pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
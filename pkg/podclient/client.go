@@ -94,13 +94,13 @@ func Start(executable string, arguments ...string) (*Client, error) {
 				continue
 			}
 			qualifiedName := name + "/" + varName
-			fn := lang.NewFnFunc(func(args ...interface{}) interface{} {
-				value, invokeErr := client.Invoke(qualifiedName, args)
-				if invokeErr != nil {
-					panic(invokeErr)
-				}
-				return value
+			fn, err := lang.NewGoFunc(func(args ...interface{}) (interface{}, error) {
+				return client.Invoke(qualifiedName, args)
 			})
+			if err != nil {
+				_ = command.Process.Kill()
+				return nil, err
+			}
 			ns.InternWithValue(lang.NewSymbol(varName), fn, true)
 		}
 	}
@@ -0,0 +1,351 @@
+package reader
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// A NodeKind is the kind of a Node.
+type NodeKind int
+
+const (
+	// NodeFile is the root of a tree, holding all of the input.
+	NodeFile NodeKind = iota
+	// NodeWhitespace is a run of spaces, tabs, newlines and commas.
+	NodeWhitespace
+	// NodeComment is a ; or #! comment, without its newline.
+	NodeComment
+	// NodeToken is a symbol, keyword, number or character, or nil,
+	// true or false.
+	NodeToken
+	NodeString
+	NodeRegex
+
+	// Collections. Text is the opening delimiter.
+	NodeList
+	NodeVector
+	NodeMap
+	NodeSet
+	NodeFn                // #(
+	NodeReaderConditional // #?( or #?@(
+
+	// Reader macros. Text is the prefix, and Children are the nodes up
+	// to and including the forms it applies to.
+	NodeQuote           // '
+	NodeSyntaxQuote     // `
+	NodeUnquote         // ~
+	NodeUnquoteSplicing // ~@
+	NodeDeref           // @
+	NodeVar             // #'
+	NodeDiscard         // #_
+	NodeMeta            // ^ or #^, followed by the metadata and its target
+	NodeTagged          // #tag
+	NodeSymbolic        // ##
+	NodeNamespacedMap   // #:ns or #::ns, followed by a map
+)
+
+// A Node is a node of a concrete syntax tree. Unlike the forms the
+// reader returns, the tree keeps comments, whitespace, discarded forms,
+// reader macros and the spelling of every token, so that printing it
+// reproduces the source exactly.
+type Node struct {
+	Kind NodeKind
+	// Text is the source of a leaf node, the opening delimiter of a
+	// collection or the prefix of a reader macro.
+	Text     string
+	Children []*Node
+	// Line and Column are where the node starts in the input. Lines and
+	// columns count from 1, columns in runes.
+	Line, Column int
+}
+
+// String returns the source of the node.
+func (n *Node) String() string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+func (n *Node) write(b *strings.Builder) {
+	b.WriteString(n.Text)
+	for _, child := range n.Children {
+		child.write(b)
+	}
+	b.WriteString(n.Close())
+}
+
+// Close returns the closing delimiter of a collection, or "" for other
+// nodes.
+func (n *Node) Close() string {
+	switch n.Kind {
+	case NodeList, NodeFn, NodeReaderConditional:
+		return ")"
+	case NodeVector:
+		return "]"
+	case NodeMap, NodeSet:
+		return "}"
+	}
+	return ""
+}
+
+// IsCollection reports whether the node is a delimited collection.
+func (n *Node) IsCollection() bool {
+	return n.Close() != ""
+}
+
+// IsForm reports whether the node reads as a form: it is not
+// whitespace, a comment or a discarded form.
+func (n *Node) IsForm() bool {
+	switch n.Kind {
+	case NodeWhitespace, NodeComment, NodeDiscard, NodeFile:
+		return false
+	}
+	return true
+}
+
+// ReadCST reads all of r into a concrete syntax tree rooted at a
+// NodeFile node. The options set the filename and start position for
+// positions and errors; nothing is resolved or evaluated, so the tree
+// can be read without a namespace or data readers.
+func ReadCST(r io.RuneScanner, opts ...Option) (*Node, error) {
+	c := &cstReader{Reader: New(r, opts...)}
+	root := &Node{Kind: NodeFile, Line: c.rs.nextRuneLine, Column: c.rs.nextRuneColumn}
+	for {
+		n, err := c.node(0)
+		if err == io.EOF {
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		root.Children = append(root.Children, n)
+	}
+}
+
+// cstReader reads nodes using the scanner and errors of a Reader.
+type cstReader struct {
+	*Reader
+	// closed is set when a reader macro is cut short by the closing
+	// delimiter of its collection, as a reader conditional that reads as
+	// nothing may leave it.
+	closed bool
+}
+
+// node reads the next node. At the end of the input it returns io.EOF
+// itself; when close is read it returns a nil node.
+func (c *cstReader) node(close rune) (*Node, error) {
+	rn, _, err := c.rs.ReadRune()
+	if err != nil {
+		return nil, err
+	}
+	p := c.rs.pos()
+	n := &Node{Line: p.Line, Column: p.Column, Text: string(rn)}
+
+	switch rn {
+	case ')', ']', '}':
+		if rn == close {
+			return nil, nil
+		}
+		return nil, c.error("unexpected '%c'", rn)
+	case ';':
+		n.Kind = NodeComment
+		n.Text += c.readWhile(func(r rune) bool { return r != '\n' })
+	case '"':
+		n.Kind = NodeString
+		if err := c.readString(n); err != nil {
+			return nil, err
+		}
+	case '\\':
+		n.Kind = NodeToken
+		first, _, err := c.rs.ReadRune()
+		if err != nil {
+			return nil, c.error("error reading character: %w", err)
+		}
+		n.Text += string(first) + c.readWhile(isTokenRune)
+	case '(':
+		return c.collection(n, NodeList, ')')
+	case '[':
+		return c.collection(n, NodeVector, ']')
+	case '{':
+		return c.collection(n, NodeMap, '}')
+	case '\'':
+		return c.macro(n, NodeQuote, 1, close)
+	case '`':
+		return c.macro(n, NodeSyntaxQuote, 1, close)
+	case '@':
+		return c.macro(n, NodeDeref, 1, close)
+	case '~':
+		if c.accept('@') {
+			n.Text += "@"
+			return c.macro(n, NodeUnquoteSplicing, 1, close)
+		}
+		return c.macro(n, NodeUnquote, 1, close)
+	case '^':
+		return c.macro(n, NodeMeta, 2, close)
+	case '#':
+		return c.dispatch(n, close)
+	default:
+		if isSpace(rn) {
+			n.Kind = NodeWhitespace
+			n.Text += c.readWhile(isSpace)
+			break
+		}
+		n.Kind = NodeToken
+		n.Text += c.readWhile(isTokenRune)
+	}
+	return n, nil
+}
+
+func (c *cstReader) dispatch(n *Node, close rune) (*Node, error) {
+	rn, _, err := c.rs.ReadRune()
+	if err != nil {
+		return nil, c.error("error reading input: %w", err)
+	}
+	n.Text += string(rn)
+	switch rn {
+	case '{':
+		return c.collection(n, NodeSet, '}')
+	case '(':
+		return c.collection(n, NodeFn, ')')
+	case '_':
+		return c.macro(n, NodeDiscard, 1, close)
+	case '\'':
+		return c.macro(n, NodeVar, 1, close)
+	case '^':
+		return c.macro(n, NodeMeta, 2, close)
+	case '#':
+		return c.macro(n, NodeSymbolic, 1, close)
+	case '"':
+		n.Kind = NodeRegex
+		return n, c.readString(n)
+	case '!':
+		n.Kind = NodeComment
+		n.Text += c.readWhile(func(r rune) bool { return r != '\n' })
+		return n, nil
+	case '?':
+		if c.accept('@') {
+			n.Text += "@"
+		}
+		if !c.accept('(') {
+			return nil, c.error("reader conditional body must be a list")
+		}
+		n.Text += "("
+		return c.collection(n, NodeReaderConditional, ')')
+	case ':':
+		n.Text += c.readWhile(isTokenRune)
+		return c.macro(n, NodeNamespacedMap, 1, close)
+	}
+	if unicode.IsLetter(rn) {
+		n.Text += c.readWhile(isTokenRune)
+		return c.macro(n, NodeTagged, 1, close)
+	}
+	return nil, c.error("invalid dispatch character: %c", rn)
+}
+
+// collection reads the children of n up to the close delimiter.
+func (c *cstReader) collection(n *Node, kind NodeKind, close rune) (*Node, error) {
+	n.Kind = kind
+	for {
+		child, err := c.node(close)
+		if err == io.EOF {
+			return nil, c.error("unterminated %s starting at line %d, column %d", n.Text, n.Line, n.Column)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			return n, nil
+		}
+		n.Children = append(n.Children, child)
+		if c.closed {
+			c.closed = false
+			return n, nil
+		}
+	}
+}
+
+// macro reads the children of n through the next count forms, or up to
+// close.
+func (c *cstReader) macro(n *Node, kind NodeKind, count int, close rune) (*Node, error) {
+	n.Kind = kind
+	for count > 0 {
+		child, err := c.node(close)
+		if err == io.EOF {
+			return nil, c.error("missing form after %s", n.Text)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			c.closed = true
+			return n, nil
+		}
+		if child.IsForm() {
+			count--
+		}
+		n.Children = append(n.Children, child)
+		if c.closed {
+			return n, nil
+		}
+	}
+	return n, nil
+}
+
+// readString adds the rest of a string or regex literal, through its
+// closing quote, to n.
+func (c *cstReader) readString(n *Node) error {
+	var b strings.Builder
+	for {
+		rn, _, err := c.rs.ReadRune()
+		if err != nil {
+			return c.error("error reading string: %w", err)
+		}
+		b.WriteRune(rn)
+		if rn == '\\' {
+			rn, _, err = c.rs.ReadRune()
+			if err != nil {
+				return c.error("error reading string: %w", err)
+			}
+			b.WriteRune(rn)
+			continue
+		}
+		if rn == '"' {
+			n.Text += b.String()
+			return nil
+		}
+	}
+}
+
+// readWhile reads and returns the runes for which ok holds.
+func (c *cstReader) readWhile(ok func(rune) bool) string {
+	var b strings.Builder
+	for {
+		rn, _, err := c.rs.ReadRune()
+		if err != nil {
+			return b.String()
+		}
+		if !ok(rn) {
+			c.rs.UnreadRune()
+			return b.String()
+		}
+		b.WriteRune(rn)
+	}
+}
+
+// accept reads the next rune if it is want.
+func (c *cstReader) accept(want rune) bool {
+	rn, _, err := c.rs.ReadRune()
+	if err != nil {
+		return false
+	}
+	if rn != want {
+		c.rs.UnreadRune()
+		return false
+	}
+	return true
+}
+
+func isTokenRune(rn rune) bool {
+	return !isSpace(rn) && !isSyntaxRune(rn)
+}
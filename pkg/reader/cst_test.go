@@ -0,0 +1,163 @@
+package reader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadCSTRoundTrip(t *testing.T) {
+	paths, err := filepath.Glob("testdata/reader/*.glj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdlib, err := filepath.Glob("../stdlib/clojure/*.glj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range append(paths, stdlib...) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := ReadCST(strings.NewReader(string(data)), WithFilename(path))
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if got := root.String(); got != string(data) {
+			t.Errorf("%s: printing the tree does not reproduce the source", path)
+		}
+	}
+}
+
+// describe prints the kinds and text of a tree, one node per line.
+func describe(b *strings.Builder, n *Node, depth int) {
+	fmt.Fprintf(b, "%s%d:%d %s %q\n", strings.Repeat("  ", depth), n.Line, n.Column, kindNames[n.Kind], n.Text)
+	for _, child := range n.Children {
+		describe(b, child, depth+1)
+	}
+}
+
+var kindNames = map[NodeKind]string{
+	NodeFile: "file", NodeWhitespace: "ws", NodeComment: "comment",
+	NodeToken: "token", NodeString: "string", NodeRegex: "regex",
+	NodeList: "list", NodeVector: "vector", NodeMap: "map", NodeSet: "set",
+	NodeFn: "fn", NodeReaderConditional: "cond", NodeQuote: "quote",
+	NodeSyntaxQuote: "syntax-quote", NodeUnquote: "unquote",
+	NodeUnquoteSplicing: "unquote-splicing", NodeDeref: "deref",
+	NodeVar: "var", NodeDiscard: "discard", NodeMeta: "meta",
+	NodeTagged: "tagged", NodeSymbolic: "symbolic", NodeNamespacedMap: "ns-map",
+}
+
+func TestReadCST(t *testing.T) {
+	src := "; hi\n(f 0x1F, \"a\\nb\" #_ #_ x y\n  ^:k 'z)\n#{\\space #\"r\"} #?@(:clj [1]) #:a{:b ##Inf} #inst \"2020\" `(~@a ~b @c #'d #(%))"
+	root, err := ReadCST(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := root.String(); got != src {
+		t.Fatalf("String() = %q, want %q", got, src)
+	}
+	var b strings.Builder
+	describe(&b, root, 0)
+	want := `1:1 file ""
+  1:1 comment "; hi"
+  1:5 ws "\n"
+  2:1 list "("
+    2:2 token "f"
+    2:3 ws " "
+    2:4 token "0x1F"
+    2:8 ws ", "
+    2:10 string "\"a\\nb\""
+    2:16 ws " "
+    2:17 discard "#_"
+      2:19 ws " "
+      2:20 discard "#_"
+        2:22 ws " "
+        2:23 token "x"
+      2:24 ws " "
+      2:25 token "y"
+    2:26 ws "\n  "
+    3:3 meta "^"
+      3:4 token ":k"
+      3:6 ws " "
+      3:7 quote "'"
+        3:8 token "z"
+  3:10 ws "\n"
+  4:1 set "#{"
+    4:3 token "\\space"
+    4:9 ws " "
+    4:10 regex "#\"r\""
+  4:15 ws " "
+  4:16 cond "#?@("
+    4:20 token ":clj"
+    4:24 ws " "
+    4:25 vector "["
+      4:26 token "1"
+  4:29 ws " "
+  4:30 ns-map "#:a"
+    4:33 map "{"
+      4:34 token ":b"
+      4:36 ws " "
+      4:37 symbolic "##"
+        4:39 token "Inf"
+  4:43 ws " "
+  4:44 tagged "#inst"
+    4:49 ws " "
+    4:50 string "\"2020\""
+  4:56 ws " "
+  4:57 syntax-quote "` + "`" + `"
+    4:58 list "("
+      4:59 unquote-splicing "~@"
+        4:61 token "a"
+      4:62 ws " "
+      4:63 unquote "~"
+        4:64 token "b"
+      4:65 ws " "
+      4:66 deref "@"
+        4:67 token "c"
+      4:68 ws " "
+      4:69 var "#'"
+        4:71 token "d"
+      4:72 ws " "
+      4:73 fn "#("
+        4:75 token "%"
+`
+	if got := b.String(); got != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReadCSTErrors(t *testing.T) {
+	for src, want := range map[string]string{
+		"(a [b)":  "1:6: unexpected ')'",
+		"(a\n(b)": "2:3: unterminated ( starting at line 1, column 1",
+		"\"abc":   "error reading string: EOF",
+		"'":       "missing form after '",
+		"#?[a]":   "reader conditional body must be a list",
+		"#%":      "invalid dispatch character: %",
+	} {
+		_, err := ReadCST(strings.NewReader(src))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadCST(%q) error = %v, want %q", src, err, want)
+		}
+	}
+}
+
+func TestReadCSTMacroCutShort(t *testing.T) {
+	// A reader conditional may read as nothing, leaving a reader macro
+	// before it without a form when the collection closes.
+	for _, src := range []string{"[^#?@(:clj [:a])]", "(a '^:x)", "(b) c"} {
+		root, err := ReadCST(strings.NewReader(src))
+		if err != nil {
+			t.Errorf("ReadCST(%q): %v", src, err)
+			continue
+		}
+		if got := root.String(); got != src {
+			t.Errorf("ReadCST(%q).String() = %q", src, got)
+		}
+	}
+}
@@ -123,6 +123,12 @@ func (e *Error) Unwrap() error {
 	return e.wrapped
 }
 
+// Position returns the file, line and column at which the error
+// occurred. Lines and columns count from 1, columns in runes.
+func (e *Error) Position() (filename string, line, column int) {
+	return e.pos.Filename, e.pos.Line, e.pos.Column
+}
+
 func newTrackingRuneScanner(rs io.RuneScanner, filename string) *trackingRuneScanner {
 	if filename == "" {
 		filename = "<unknown-file>"
@@ -225,6 +231,7 @@ type (
 
 type options struct {
 	filename     string
+	line, column int
 	resolver     SymbolResolver
 	getCurrentNS func() *lang.Namespace
 }
@@ -239,6 +246,16 @@ func WithFilename(filename string) Option {
 	}
 }
 
+// WithStartPosition sets the line and column of the first rune of the
+// input, for input taken from the middle of a file. Values less than 1
+// are ignored.
+func WithStartPosition(line, column int) Option {
+	return func(o *options) {
+		o.line = line
+		o.column = column
+	}
+}
+
 // WithSymbolResolver sets the symbol resolver to be used when reading.
 func WithSymbolResolver(resolver SymbolResolver) Option {
 	return func(o *options) {
@@ -268,8 +285,15 @@ func New(r io.RuneScanner, opts ...Option) *Reader {
 	if o.getCurrentNS != nil {
 		getCurrentNS = o.getCurrentNS
 	}
+	rs := newTrackingRuneScanner(r, o.filename)
+	if o.line > 0 {
+		rs.nextRuneLine = o.line
+	}
+	if o.column > 0 {
+		rs.nextRuneColumn = o.column
+	}
 	return &Reader{
-		rs:             newTrackingRuneScanner(r, o.filename),
+		rs:             rs,
 		symbolResolver: o.resolver,
 		getCurrentNS:   getCurrentNS,
 
@@ -18,10 +18,10 @@ import (
 	"github.com/gloathub/go-readline"
 	"github.com/gloathub/go-readline/inputrc"
 
+	"github.com/glojurelang/glojure/pkg/format"
 	"github.com/glojurelang/glojure/pkg/lang"
 	"github.com/glojurelang/glojure/pkg/nrepl"
 	"github.com/glojurelang/glojure/pkg/pkgmap"
-	"github.com/glojurelang/glojure/pkg/runtime"
 	"github.com/glojurelang/glojure/pkg/srepl"
 )
 
@@ -201,7 +201,7 @@ func Start(opts ...Option) {
 	formatCmd := os.Getenv("GLJ_REPL_FORMATTER")
 	showTrace := false
 	if formatCmd == "" {
-		formatCmd = "cat"
+		formatCmd = builtinFormatter
 	}
 
 	// Override editing mode from env var
@@ -641,23 +641,13 @@ func evalWithInterrupt(o options, val interface{}) (string, error) {
 	go func() {
 		lang.PushThreadBindings(bindings)
 		defer lang.PopThreadBindings()
-		defer func() {
-			if panicErr := recover(); panicErr != nil {
-				resCh <- result{"", fmt.Errorf("panic: %v\nstacktrace:\n%s", panicErr, string(debug.Stack()))}
-			}
-		}()
-		v, err := o.env.Eval(val)
-		runtime.Debug = false
-		if err != nil {
-			resCh <- result{"", err}
-			return
-		}
-		resCh <- result{lang.PrintString(v), nil}
+		out, err := evalPrint(o.env, val)
+		resCh <- result{out, err}
 	}()
 
 	select {
 	case <-sigCh:
-		return "", fmt.Errorf("Interrupted")
+		return "", errInterrupted
 	case r := <-resCh:
 		return r.out, r.err
 	}
@@ -935,7 +925,18 @@ func evalReplInput(input string, o *options, evalFn EvalFunc) {
 	}
 }
 
+// builtinFormatter is the format command that formats with package
+// format rather than running a shell command.
+const builtinFormatter = "glj fmt"
+
 func runFormat(cmdStr, text string) string {
+	if cmdStr == builtinFormatter {
+		formatted, err := format.Source(text)
+		if err != nil {
+			return text
+		}
+		return strings.TrimRight(formatted, "\n")
+	}
 	cmd := exec.Command("sh", "-c", cmdStr)
 	cmd.Stdin = strings.NewReader(text)
 	out, err := cmd.Output()
@@ -14,6 +14,9 @@ import (
 	"github.com/glojurelang/glojure/pkg/runtime"
 )
 
+// errInterrupted is the error of an evaluation interrupted with Ctrl-C.
+var errInterrupted = errors.New("Interrupted")
+
 // EvalFunc is the signature for eval functions passed to readEvalPrint.
 // CLI wraps evalSafe with signal handling; WASM uses evalSafe directly.
 type EvalFunc func(*options, interface{}) (string, error)
@@ -126,23 +129,40 @@ func isExpressionComplete(input string, env lang.Environment) bool {
 
 // evalSafe evaluates a single form with panic recovery, no signal handling.
 func evalSafe(o *options, val interface{}) (string, error) {
-	var out string
-	var err error
-	func() {
-		defer func() {
-			if panicErr := recover(); panicErr != nil {
-				err = fmt.Errorf("panic: %v\nstacktrace:\n%s", panicErr, string(debug.Stack()))
-			}
-		}()
-		var v interface{}
-		v, err = o.env.Eval(val)
-		runtime.Debug = false
-		if err != nil {
-			return
+	return evalPrint(o.env, val)
+}
+
+// evalPrint evaluates val and prints its value, returning panics as
+// errors. Errors printing the value record the phase
+// :print-eval-result.
+func evalPrint(env lang.Environment, val interface{}) (out string, err error) {
+	defer func() {
+		if panicErr := recover(); panicErr != nil {
+			err = replPanicError(panicErr)
+		}
+	}()
+	v, err := env.Eval(val)
+	runtime.Debug = false
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if panicErr := recover(); panicErr != nil {
+			err = lang.NewExceptionInfoWithCause("", lang.NewMap(lang.KWErrorPhase, lang.PhasePrintEvalResult), replPanicError(panicErr))
 		}
-		out = lang.PrintString(v)
 	}()
-	return out, err
+	return lang.PrintString(v), nil
+}
+
+// replPanicError returns the error for a recovered panic. Panics of
+// the Go runtime, such as nil dereferences, keep the Go stack.
+func replPanicError(panicErr interface{}) error {
+	if err, ok := panicErr.(error); ok {
+		if _, isRuntime := err.(goruntime.Error); !isRuntime {
+			return err
+		}
+	}
+	return fmt.Errorf("panic: %v\nstacktrace:\n%s", panicErr, string(debug.Stack()))
 }
 
 // handleReplCommand processes :repl/* commands that work on all platforms.
@@ -168,7 +188,7 @@ type helpColors struct {
 var noColors = helpColors{}
 
 // printHelp prints the REPL help text. editorMode is "vi" or "emacs",
-// formatCmd is the current format command (e.g. "cat"),
+// formatCmd is the current format command (e.g. "glj fmt"),
 // serverURL is the nREPL server URL (empty if no server).
 func printHelp(w io.Writer, editorMode, formatCmd, nreplURL, sreplURL string, c helpColors) {
 	isEmacs := editorMode == "emacs"
@@ -225,15 +245,19 @@ func readEvalPrint(input string, o *options, evalFn EvalFunc) {
 	)
 	vals, err := rdr.ReadAll()
 	if err != nil {
-		fmt.Fprintln(o.stdout, err)
+		fmt.Fprint(o.stdout, runtime.ErrorString(err, lang.PhaseReadSource))
 		return
 	}
 	for _, val := range vals {
 		out, err := evalFn(o, val)
-		if err != nil {
+		if errors.Is(err, errInterrupted) {
 			fmt.Fprintln(o.stdout, err)
 			continue
 		}
+		if err != nil {
+			fmt.Fprint(o.stdout, runtime.ErrorString(err, lang.PhaseExecution))
+			continue
+		}
 		fmt.Fprintln(o.stdout, out)
 	}
 }
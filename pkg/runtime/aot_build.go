@@ -0,0 +1,431 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+const (
+	// buildModule is the module path of the generated build module.
+	buildModule = "gljbuild"
+	// buildResourceDir holds embedded sources and resources. The leading
+	// underscore keeps the go tool from treating it as a package.
+	buildResourceDir = "_resources"
+
+	glojureModule = "github.com/glojurelang/glojure"
+)
+
+// buildNamespace is one namespace of the program, in load order.
+type buildNamespace struct {
+	name     string
+	resource string
+	// importPath is the Go package providing the loader, if any.
+	importPath string
+	// source and data hold the file the namespace was loaded from, which is
+	// embedded if the namespace falls back to the interpreter.
+	source string
+	data   []byte
+	// sourceRoot is the directory of the load path entry the source was
+	// read from, if it is on disk. Line directives in the loader refer
+	// to the source there.
+	sourceRoot string
+}
+
+// Build compiles the program rooted at the entry namespace into a
+// standalone executable. It loads the entry namespace and everything it
+// requires, generates a loader for each namespace loaded from source,
+// writes a main package that runs the entry namespace's -main, and builds
+// it with the glj_aot_runtime tag. Namespaces the generator cannot handle
+// are embedded as source and loaded by the interpreter at startup, in which
+// case the executable links the full runtime instead.
+func Build(opts BuildOptions) (*BuildReport, error) {
+	if opts.Entry == "" {
+		return nil, fmt.Errorf("build: no entry namespace")
+	}
+	if opts.Stdout == nil {
+		opts.Stdout = os.Stdout
+	}
+	if opts.Stderr == nil {
+		opts.Stderr = os.Stderr
+	}
+	output := opts.Output
+	if output == "" {
+		output = strings.SplitN(opts.Entry, ".", 2)[0]
+	}
+	output, err := filepath.Abs(output)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		workDir, err = os.MkdirTemp("", "glj-build-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(workDir)
+	} else if err := os.MkdirAll(workDir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := writeBuildModule(workDir, opts.GlojureDir); err != nil {
+		return nil, err
+	}
+	namespaces, err := loadBuildNamespaces(opts.Entry)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BuildReport{Output: output}
+	for i := range namespaces {
+		bns := &namespaces[i]
+		if bns.importPath != "" {
+			report.Precompiled = append(report.Precompiled, bns.name)
+			continue
+		}
+		ns := lang.FindNamespace(lang.NewSymbol(bns.name))
+		targetFile := filepath.Join(workDir, bns.resource, "loader.go")
+		written, err := writeNamespaceAOT(targetFile, ns, bns.sourceRoot, opts.Force)
+		if err != nil {
+			_ = os.RemoveAll(filepath.Dir(targetFile))
+			report.Interpreted = append(report.Interpreted, InterpretedNamespace{
+				Name:   bns.name,
+				Reason: err,
+			})
+			continue
+		}
+		bns.importPath = buildModule + "/" + bns.resource
+		report.Compiled = append(report.Compiled, bns.name)
+		if written {
+			fmt.Fprintf(opts.Stdout, "Compiling %s\n", bns.name)
+		} else {
+			report.Unchanged = append(report.Unchanged, bns.name)
+		}
+	}
+
+	hasResources := false
+	for _, bns := range namespaces {
+		if bns.importPath != "" {
+			continue
+		}
+		hasResources = true
+		if err := copyBuildSource(workDir, bns); err != nil {
+			return nil, err
+		}
+	}
+	for _, dir := range opts.Resources {
+		hasResources = true
+		if err := copyBuildResources(workDir, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := buildMainSource(opts.Entry, namespaces, hasResources)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "main.go"), src, 0644); err != nil {
+		return nil, err
+	}
+
+	// Interpreted namespaces resolve host symbols through the complete
+	// package map, which the compact AOT runtime omits.
+	report.FullRuntime = len(report.Interpreted) > 0
+	overlay := ""
+	if opts.TreeShake && !report.FullRuntime {
+		overlay, report.TreeShaking, err = treeShakeBuild(workDir, opts, namespaces)
+		if err != nil {
+			return report, err
+		}
+	} else if opts.TreeShake {
+		// Interpreted code is analyzed at run time and may use any var,
+		// including macros.
+		fmt.Fprintf(opts.Stdout, "Not tree shaking: %d namespaces are interpreted\n", len(report.Interpreted))
+	}
+	if err := goBuild(workDir, output, overlay, report.FullRuntime, opts); err != nil {
+		return report, err
+	}
+	if shake := report.TreeShaking; shake != nil {
+		// Measure what tree shaking saved by building the program again
+		// without it.
+		unshaken := filepath.Join(workDir, shakeDir, "unshaken")
+		if err := goBuild(workDir, unshaken, "", false, opts); err != nil {
+			return report, err
+		}
+		if shake.BinaryBytes, err = fileSize(output); err != nil {
+			return report, err
+		}
+		if shake.UnshakenBinaryBytes, err = fileSize(unshaken); err != nil {
+			return report, err
+		}
+		if err := os.Remove(unshaken); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// goBuild builds the main package in workDir into output, with the files
+// overlay names replaced if it is not empty, linking the compact runtime
+// unless fullRuntime is set.
+func goBuild(workDir, output, overlay string, fullRuntime bool, opts BuildOptions) error {
+	args := []string{"build", "-mod=mod", "-o", output}
+	if overlay != "" {
+		args = append(args, "-overlay", overlay)
+	}
+	if !fullRuntime {
+		args = append(args, "-tags", "glj_aot_runtime")
+	}
+	cmd := exec.Command("go", append(args, ".")...)
+	cmd.Dir = workDir
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("build: go build failed: %w", err)
+	}
+	return nil
+}
+
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// loadBuildNamespaces requires the entry namespace, forcing every library
+// it depends on to load again, and returns the traced namespaces with
+// dependencies ahead of their dependents.
+func loadBuildNamespaces(entry string) (namespaces []buildNamespace, err error) {
+	trace := startLoadTrace()
+	defer trace.stop()
+
+	entrySym := lang.NewSymbol(entry)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("build: failed to load %s: %v", entry, r)
+			}
+		}()
+		require := lang.NSCore.FindInternedVar(lang.NewSymbol("require"))
+		lang.Apply(require, []any{entrySym, lang.NewKeyword("reload-all")})
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	entryNS := lang.FindNamespace(entrySym)
+	if entryNS == nil {
+		return nil, fmt.Errorf("build: namespace %s not found after loading", entry)
+	}
+	if mainVar := entryNS.FindInternedVar(lang.NewSymbol("-main")); mainVar == nil || !mainVar.IsBound() {
+		return nil, fmt.Errorf("build: namespace %s does not define -main", entry)
+	}
+
+	for _, loaded := range trace.loaded() {
+		name := pathToNS(loaded.resource)
+		if lang.FindNamespace(lang.NewSymbol(name)) == nil {
+			// A file loaded into an enclosing namespace with load; its
+			// definitions are generated with that namespace.
+			continue
+		}
+		bns := buildNamespace{name: name, resource: loaded.resource}
+		if loaded.fsys == nil {
+			importPath, ok := nsLoaderPackage(loaded.resource)
+			if !ok {
+				return nil, fmt.Errorf("build: cannot determine the Go package of the %s loader", name)
+			}
+			bns.importPath = importPath
+		} else {
+			data, err := readFile(loaded.fsys, loaded.filename)
+			if err != nil {
+				return nil, fmt.Errorf("build: failed to read %s: %w", loaded.filename, err)
+			}
+			bns.source = loaded.filename
+			bns.data = data
+			if dir, ok := fsDirPath(loaded.fsys); ok {
+				bns.sourceRoot, _ = filepath.Abs(dir)
+			}
+		}
+		namespaces = append(namespaces, bns)
+	}
+	return namespaces, nil
+}
+
+func copyBuildSource(workDir string, bns buildNamespace) error {
+	target := filepath.Join(workDir, buildResourceDir, filepath.FromSlash(bns.source))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, bns.data, 0644)
+}
+
+func copyBuildResources(workDir, dir string) error {
+	target := filepath.Join(workDir, buildResourceDir)
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(target, rel), 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(target, rel), data, 0644)
+	})
+}
+
+// nsLoaderPackage returns the import path of the Go package that registered
+// the loader for resource. Generated loaders register their package-level
+// LoadNS function, whose symbol name carries the import path.
+func nsLoaderPackage(resource string) (string, bool) {
+	entry := nsLoaders[resource]
+	if entry == nil {
+		return "", false
+	}
+	fn := goruntime.FuncForPC(reflect.ValueOf(entry.load).Pointer())
+	if fn == nil {
+		return "", false
+	}
+	return loaderSymbolPackage(fn.Name())
+}
+
+// loaderSymbolPackage extracts the import path from the linker symbol of a
+// package's LoadNS function, such as "example.com/app/core.LoadNS".
+func loaderSymbolPackage(symbol string) (string, bool) {
+	slash := strings.LastIndex(symbol, "/")
+	dot := strings.Index(symbol[slash+1:], ".")
+	if dot < 0 || symbol[slash+1+dot:] != ".LoadNS" {
+		return "", false
+	}
+	// The linker escapes dots in the last path element.
+	return strings.ReplaceAll(symbol[:slash+1+dot], "%2e", "."), true
+}
+
+func buildMainSource(entry string, namespaces []buildNamespace, hasResources bool) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by glj build. DO NOT EDIT.\n\n")
+	b.WriteString("package main\n\n")
+	b.WriteString("import (\n")
+	if hasResources {
+		b.WriteString("\t\"embed\"\n")
+	}
+	b.WriteString("\t\"fmt\"\n")
+	if hasResources {
+		b.WriteString("\t\"io/fs\"\n")
+	}
+	b.WriteString("\t\"os\"\n\n")
+	b.WriteString("\t_ \"github.com/glojurelang/glojure/pkg/glj\"\n")
+	b.WriteString("\t\"github.com/glojurelang/glojure/pkg/lang\"\n")
+	if hasResources {
+		b.WriteString("\t\"github.com/glojurelang/glojure/pkg/runtime\"\n")
+	}
+	seen := map[string]bool{}
+	for _, bns := range namespaces {
+		if bns.importPath == "" || seen[bns.importPath] {
+			continue
+		}
+		seen[bns.importPath] = true
+		fmt.Fprintf(&b, "\t_ %q\n", bns.importPath)
+	}
+	b.WriteString(")\n\n")
+
+	if hasResources {
+		fmt.Fprintf(&b, "//go:embed all:%s\nvar resources embed.FS\n\n", buildResourceDir)
+	}
+
+	b.WriteString("func main() {\n")
+	b.WriteString("\tdefer func() {\n")
+	b.WriteString("\t\tif r := recover(); r != nil {\n")
+	b.WriteString("\t\t\tfmt.Fprintln(os.Stderr, r)\n")
+	b.WriteString("\t\t\tos.Exit(1)\n")
+	b.WriteString("\t\t}\n")
+	b.WriteString("\t}()\n\n")
+	if hasResources {
+		fmt.Fprintf(&b, "\tsub, err := fs.Sub(resources, %q)\n", buildResourceDir)
+		b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+		b.WriteString("\truntime.AddLoadPath(sub)\n\n")
+	}
+	b.WriteString("\tcore := lang.FindNamespace(lang.NewSymbol(\"clojure.core\"))\n")
+	b.WriteString("\tcore.FindInternedVar(lang.NewSymbol(\"*command-line-args*\")).BindRoot(lang.Seq(os.Args[1:]))\n")
+	b.WriteString("\tlang.Apply(core.FindInternedVar(lang.NewSymbol(\"require\")), []any{\n")
+	for _, bns := range namespaces {
+		fmt.Fprintf(&b, "\t\tlang.NewSymbol(%q),\n", bns.name)
+	}
+	b.WriteString("\t})\n\n")
+	fmt.Fprintf(&b, "\tns := lang.FindNamespace(lang.NewSymbol(%q))\n", entry)
+	b.WriteString("\targs := make([]any, len(os.Args)-1)\n")
+	b.WriteString("\tfor i, arg := range os.Args[1:] {\n\t\targs[i] = arg\n\t}\n")
+	b.WriteString("\tlang.Apply(ns.FindInternedVar(lang.NewSymbol(\"-main\")), args)\n")
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+func writeBuildModule(workDir, glojureDir string) error {
+	version := Version
+	if glojureDir == "" && (version == "0.0.0" || strings.Contains(version, "+dirty")) {
+		dir, err := currentGlojureModuleDir()
+		if err != nil {
+			return fmt.Errorf("build: cannot locate a Glojure module for development version %s; "+
+				"pass the checkout with -glojure: %w", version, err)
+		}
+		glojureDir = dir
+	}
+	if glojureDir != "" {
+		dir, err := filepath.Abs(glojureDir)
+		if err != nil {
+			return err
+		}
+		glojureDir = dir
+		version = "0.0.0"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "module %s\n\ngo 1.24\n\nrequire %s v%s\n", buildModule, glojureModule, version)
+	if glojureDir != "" {
+		fmt.Fprintf(&b, "\nreplace %s => %s\n", glojureModule, filepath.ToSlash(glojureDir))
+		if sums, err := os.ReadFile(filepath.Join(glojureDir, "go.sum")); err == nil {
+			if err := os.WriteFile(filepath.Join(workDir, "go.sum"), sums, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return os.WriteFile(filepath.Join(workDir, "go.mod"), b.Bytes(), 0644)
+}
+
+// currentGlojureModuleDir returns the directory of the Glojure module used
+// by the Go module in the current directory, which is either a Glojure
+// checkout or a project that depends on Glojure.
+func currentGlojureModuleDir() (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", glojureModule).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+	} else if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return "", fmt.Errorf("module %s has no directory", glojureModule)
+	}
+	return dir, nil
+}
@@ -0,0 +1,109 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadBuildNamespacesOrdersDependencies(t *testing.T) {
+	AddLoadPath(fstest.MapFS{
+		"buildtest/app.glj": {Data: []byte(`
+			(ns buildtest.app
+			  (:require [buildtest.util :as util]))
+			(load "/buildtest/app_helpers")
+			(defn -main [& args] (util/greet (helper args)))`)},
+		"buildtest/app_helpers.glj": {Data: []byte(`
+			(in-ns 'buildtest.app)
+			(defn helper [args] (first args))`)},
+		"buildtest/util.glj": {Data: []byte(`
+			(ns buildtest.util)
+			(defn greet [s] (str "hello " s))`)},
+		"buildtest/nomain.glj": {Data: []byte(`(ns buildtest.nomain)`)},
+	})
+
+	namespaces, err := loadBuildNamespaces("buildtest.app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, ns := range namespaces {
+		names = append(names, ns.name)
+		if ns.importPath != "" || len(ns.data) == 0 {
+			t.Errorf("%s: import path %q, %d source bytes", ns.name, ns.importPath, len(ns.data))
+		}
+	}
+	if got, want := strings.Join(names, " "), "buildtest.util buildtest.app"; got != want {
+		t.Fatalf("build namespaces = %q, want %q", got, want)
+	}
+
+	if _, err := loadBuildNamespaces("buildtest.nomain"); err == nil ||
+		!strings.Contains(err.Error(), "does not define -main") {
+		t.Fatalf("missing -main error = %v", err)
+	}
+}
+
+func TestLoaderSymbolPackage(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   string
+		ok     bool
+	}{
+		{"github.com/glojurelang/glojure/pkg/stdlib/clojure/string.LoadNS", "github.com/glojurelang/glojure/pkg/stdlib/clojure/string", true},
+		{"gljbuild/app/my%2eutil.LoadNS", "gljbuild/app/my.util", true},
+		{"main.LoadNS", "main", true},
+		{"github.com/example/app.TestLoader.func1", "", false},
+	}
+	for _, test := range tests {
+		got, ok := loaderSymbolPackage(test.symbol)
+		if got != test.want || ok != test.ok {
+			t.Errorf("loaderSymbolPackage(%q) = %q, %v; want %q, %v",
+				test.symbol, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestBuildMainSourceRequiresNamespacesInLoadOrder(t *testing.T) {
+	namespaces := []buildNamespace{
+		{name: "app.util", resource: "app/util", importPath: "gljbuild/app/util"},
+		{name: "clojure.string", resource: "clojure/string",
+			importPath: "github.com/glojurelang/glojure/pkg/stdlib/clojure/string"},
+		{name: "app.legacy", resource: "app/legacy", source: "app/legacy.glj"},
+		{name: "app.core", resource: "app/core", importPath: "gljbuild/app/core"},
+	}
+	src, err := buildMainSource("app.core", namespaces, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "main.go", src, parser.ImportsOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imports := map[string]bool{}
+	for _, spec := range file.Imports {
+		imports[strings.Trim(spec.Path.Value, `"`)] = true
+	}
+	for _, want := range []string{"embed", "gljbuild/app/util", "gljbuild/app/core",
+		"github.com/glojurelang/glojure/pkg/stdlib/clojure/string"} {
+		if !imports[want] {
+			t.Errorf("main.go does not import %s:\n%s", want, src)
+		}
+	}
+
+	text := string(src)
+	last := -1
+	for _, ns := range namespaces {
+		index := strings.Index(text, `lang.NewSymbol("`+ns.name+`"),`)
+		if index < last {
+			t.Fatalf("%s is required out of load order:\n%s", ns.name, src)
+		}
+		last = index
+	}
+	if !strings.Contains(text, "//go:embed all:_resources") {
+		t.Fatalf("main.go does not embed interpreted sources:\n%s", src)
+	}
+}
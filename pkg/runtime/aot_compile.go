@@ -13,37 +13,89 @@ import (
 	"github.com/glojurelang/glojure/pkg/lang"
 )
 
-// compileNSToFile compiles the given namespace to a Go source file,
-// given a fs.FS and the script base name (without extension).
-func compileNSToFile(filesystem fs.FS, scriptBase string) {
+// compileNSToFile compiles the current namespace to a Go source file
+// once resource, its own file, has loaded from filesystem. Files loaded
+// into the namespace with load are generated with it, so compiling them
+// does nothing.
+func compileNSToFile(filesystem fs.FS, resource string) {
+	ns := lang.VarCurrentNS.Deref().(*lang.Namespace)
+	if nsToPath(ns.Name().Name()) != resource {
+		return
+	}
+	fsDir, ok := fsDirPath(filesystem)
+	if !ok {
+		panic(fmt.Errorf("cannot compile %s: filesystem is not writable", resource))
+	}
+	generateNamespaceAOT(fsDir, ns)
+}
+
+// fsDirPath returns the directory of a filesystem created by os.DirFS.
+func fsDirPath(filesystem fs.FS) (string, bool) {
 	// os.DirFS is a named string type. Other filesystem implementations do not
-	// provide a writable path for the generated loader.
+	// provide a path on disk.
 	if reflect.TypeOf(filesystem).Kind() != reflect.String {
-		panic(fmt.Errorf("cannot compile %s: filesystem is not writable", scriptBase))
+		return "", false
 	}
-	fsDir := fmt.Sprintf("%s", filesystem)
-	generateNamespaceAOT(fsDir, lang.VarCurrentNS.Deref().(*lang.Namespace))
+	return fmt.Sprintf("%s", filesystem), true
 }
 
 func generateNamespaceAOT(fsDir string, ns *lang.Namespace) {
 	path := nsToPath(ns.Name().Name())
-	targetDir := filepath.Join(fsDir, path)
-	targetFile := filepath.Join(targetDir, "loader.go")
+	targetFile := filepath.Join(fsDir, path, "loader.go")
 
-	fmt.Printf("Compiling %s to %s\n", ns.Name(), targetFile)
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
+	// The loader sits beside the source, so line directives use relative
+	// paths and generated files do not depend on where they were built.
+	written, err := writeNamespaceAOT(targetFile, ns, "", aotForce)
+	if err != nil {
 		panic(err)
 	}
+	if written {
+		fmt.Printf("Compiling %s to %s\n", ns.Name(), targetFile)
+	} else {
+		fmt.Printf("Skipping %s: %s is up to date\n", ns.Name(), targetFile)
+	}
+}
+
+// writeNamespaceAOT generates the loader for ns and writes it to
+// targetFile, creating its directory. Line directives in the loader refer
+// to source files under sourceRoot, or relative to the loader if it is
+// empty. Unless force is set, an existing
+// loader whose recorded fingerprint matches ns is left alone and written
+// is false. Generator panics are returned as errors. On a generation
+// failure the partial output is still written to aid debugging.
+func writeNamespaceAOT(targetFile string, ns *lang.Namespace, sourceRoot string, force bool) (written bool, err error) {
+	fingerprint, ok := namespaceFingerprint(ns, sourceRoot)
+	if ok && !force && loaderUpToDate(targetFile, fingerprint) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(targetFile), 0755); err != nil {
+		return false, err
+	}
 
 	var buf bytes.Buffer
+	defer func() {
+		if r := recover(); r != nil {
+			_ = os.WriteFile(targetFile, buf.Bytes(), 0644)
+			err = fmt.Errorf("failed to generate code for namespace %s: %v", ns.Name(), r)
+		}
+	}()
 	gen := newGenerator(&buf, aotDirectLinkEnabled())
+	gen.snapshot = snapshotEnabled()
+	gen.EnableLineDirectives(sourceRoot)
 	if err := gen.Generate(ns); err != nil {
 		_ = os.WriteFile(targetFile, buf.Bytes(), 0644)
-		panic(fmt.Errorf("failed to generate code for namespace %s: %w", ns.Name(), err))
+		return false, fmt.Errorf("failed to generate code for namespace %s: %w", ns.Name(), err)
 	}
 	if err := os.WriteFile(targetFile, buf.Bytes(), 0644); err != nil {
-		panic(fmt.Errorf("failed to write generated code to %s: %w", targetFile, err))
+		return false, fmt.Errorf("failed to write generated code to %s: %w", targetFile, err)
+	}
+	if !ok {
+		fingerprint = ""
+	}
+	if err := writeLoaderFingerprint(targetFile, fingerprint, buf.Bytes()); err != nil {
+		return false, err
 	}
+	return true, nil
 }
 
 func aotDirectLinkEnabled() bool {
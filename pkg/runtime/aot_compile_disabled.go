@@ -7,9 +7,18 @@ import (
 	"io/fs"
 )
 
-func compileNSToFile(_ fs.FS, scriptBase string) {
+// compileNSToFile panics: executables built with the glj_aot_runtime tag
+// link the compact runtime, which cannot generate Go source.
+func compileNSToFile(_ fs.FS, resource string) {
 	panic(fmt.Errorf(
 		"cannot compile %s: Go source generation is unavailable in a glj_aot_runtime build",
-		scriptBase,
+		resource,
 	))
 }
+
+// Build returns an error: executables built with the glj_aot_runtime tag
+// link the compact runtime, which cannot generate Go source. Use a glj
+// built without the tag to build programs.
+func Build(BuildOptions) (*BuildReport, error) {
+	return nil, fmt.Errorf("build: Go source generation is unavailable in a glj_aot_runtime build")
+}
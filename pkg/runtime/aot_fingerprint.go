@@ -0,0 +1,156 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// aotFingerprintFile is written next to each generated loader. It records
+// the fingerprint of the inputs the loader was generated from and a hash of
+// the loader itself, so that a loader edited or checked out since is not
+// mistaken for an up to date one. Keeping it out of the loader means
+// committed loaders do not change when only the fingerprint does.
+const aotFingerprintFile = ".glj-fingerprint"
+
+// aotForce disables fingerprint checks for compile, regenerating every
+// loader. Build has its own Force option.
+var aotForce = os.Getenv("GLOJURE_AOT_FORCE") == "1"
+
+// namespaceFingerprint hashes everything the loader generated for ns
+// depends on: the source files loaded into ns, the fingerprints of the
+// namespaces they refer to (which include the macros used to expand them),
+// the compiler options, the compiler itself and the source root that line
+// directives refer to. It reports false if any
+// input is unknown, for example a dependency loaded from source before
+// tracking began, in which case the loader must be regenerated.
+func namespaceFingerprint(ns *lang.Namespace, sourceRoot string) (string, bool) {
+	compiler, ok := compilerIdentity()
+	if !ok {
+		return "", false
+	}
+	f := &fingerprinter{
+		compiler: compiler,
+		memo:     map[string]string{},
+		visiting: map[string]bool{},
+	}
+	nsHash, ok := f.namespace(ns.Name().Name())
+	if !ok {
+		return "", false
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "glojure-aot-fingerprint 1\n")
+	fmt.Fprintf(h, "compiler %s\n", compiler)
+	fmt.Fprintf(h, "compiler-options %s\n", compilerOptionsString())
+	fmt.Fprintf(h, "direct-linking %t\n", aotDirectLinkEnabled())
+	fmt.Fprintf(h, "source-root %s\n", sourceRoot)
+	fmt.Fprintf(h, "namespace %s\n", nsHash)
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+type fingerprinter struct {
+	compiler string
+	memo     map[string]string
+	visiting map[string]bool
+}
+
+func (f *fingerprinter) namespace(name string) (string, bool) {
+	if hash, ok := f.memo[name]; ok {
+		return hash, true
+	}
+	if f.visiting[name] {
+		// A cycle: the namespace's own inputs are hashed where the
+		// cycle was entered.
+		return "cycle " + name, true
+	}
+	f.visiting[name] = true
+	defer delete(f.visiting, name)
+
+	h := sha256.New()
+	sources := lookupNSSources(name)
+	switch {
+	case len(sources) > 0:
+		resources := make([]string, 0, len(sources))
+		refs := map[string]bool{}
+		for resource, src := range sources {
+			srcRefs, ok := src.references()
+			if !ok {
+				return "", false
+			}
+			resources = append(resources, resource)
+			for _, ref := range srcRefs {
+				refs[ref] = true
+			}
+		}
+		sort.Strings(resources)
+		for _, resource := range resources {
+			fmt.Fprintf(h, "source %s %x\n", resource, sources[resource].hash)
+		}
+		for _, ref := range sortedKeys(refs) {
+			refHash, ok := f.namespace(ref)
+			if !ok {
+				return "", false
+			}
+			fmt.Fprintf(h, "ref %s %s\n", ref, refHash)
+		}
+	case GetNSLoader(nsToPath(name)) != nil:
+		// Loaders are compiled into this executable, so the compiler
+		// identity covers them.
+		fmt.Fprintf(h, "loader %s %s\n", name, f.compiler)
+	default:
+		return "", false
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	f.memo[name] = hash
+	return hash, true
+}
+
+func compilerOptionsString() string {
+	compilerOptions := lang.NSCore.FindInternedVar(
+		lang.NewSymbol("*compiler-options*"),
+	)
+	if compilerOptions == nil || !compilerOptions.IsBound() {
+		return "nil"
+	}
+	return lang.PrintString(compilerOptions.Get())
+}
+
+// loaderUpToDate reports whether the loader at path was generated from
+// inputs with the given fingerprint and has not been modified since.
+func loaderUpToDate(path, fingerprint string) bool {
+	recorded, err := os.ReadFile(filepath.Join(filepath.Dir(path), aotFingerprintFile))
+	if err != nil {
+		return false
+	}
+	loader, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return string(recorded) == fingerprintRecord(fingerprint, loader)
+}
+
+// writeLoaderFingerprint records the fingerprint of the loader at path,
+// or removes a stale record if fingerprint is empty.
+func writeLoaderFingerprint(path, fingerprint string, loader []byte) error {
+	recordFile := filepath.Join(filepath.Dir(path), aotFingerprintFile)
+	if fingerprint == "" {
+		if err := os.Remove(recordFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(recordFile, []byte(fingerprintRecord(fingerprint, loader)), 0644)
+}
+
+func fingerprintRecord(fingerprint string, loader []byte) string {
+	return fmt.Sprintf("%s %x\n", fingerprint, sha256.Sum256(loader))
+}
@@ -0,0 +1,122 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+func TestWriteNamespaceAOTSkipsUnchangedNamespaces(t *testing.T) {
+	// As with make aot, the sources are on a load path on disk and each
+	// loader is written beside its source.
+	root := t.TempDir()
+	write := func(name, data string) {
+		t.Helper()
+		writeSourceFile(t, root, name, data)
+	}
+	write("fingerprint/macros.glj", `
+		(ns fingerprint.macros)
+		(defmacro twice [x] (list 'do x x))`)
+	write("fingerprint/app.glj", `
+		(ns fingerprint.app
+		  (:require [fingerprint.macros :refer [twice]]))
+		(defn -main [& args] (twice (println args)))`)
+	write("fingerprint/other.glj", `(ns fingerprint.other)`)
+	AddLoadPath(os.DirFS(root))
+
+	target := filepath.Join(root, "fingerprint", "app", "loader.go")
+	compile := func(force bool) bool {
+		t.Helper()
+		if _, err := loadBuildNamespaces("fingerprint.app"); err != nil {
+			t.Fatal(err)
+		}
+		ns := lang.FindNamespace(lang.NewSymbol("fingerprint.app"))
+		written, err := writeNamespaceAOT(target, ns, "", force)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return written
+	}
+
+	if !compile(false) {
+		t.Fatal("first compile did not write the loader")
+	}
+	if compile(false) {
+		t.Error("unchanged namespace was regenerated")
+	}
+	if err := os.WriteFile(target, []byte("package edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !compile(false) {
+		t.Error("edited loader was not regenerated")
+	}
+	if !compile(true) {
+		t.Error("forced compile did not regenerate the loader")
+	}
+
+	// Editing an unrelated namespace keeps the loader; editing a macro
+	// the namespace expands does not.
+	write("fingerprint/other.glj", `(ns fingerprint.other) (def x 1)`)
+	if compile(false) {
+		t.Error("unrelated change regenerated the loader")
+	}
+	write("fingerprint/macros.glj", `
+		(ns fingerprint.macros)
+		(defmacro twice [x] (list 'do x x nil))`)
+	if !compile(false) {
+		t.Error("macro change did not regenerate the loader")
+	}
+
+	compilerOptions := lang.NSCore.FindInternedVar(lang.NewSymbol("*compiler-options*"))
+	lang.PushThreadBindings(lang.NewMap(compilerOptions,
+		lang.NewMap(lang.KWDirectLinking, false)))
+	defer lang.PopThreadBindings()
+	if !compile(false) {
+		t.Error("compiler option change did not regenerate the loader")
+	}
+}
+
+func TestCompileGeneratesNamespaceOnceLoaded(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "nestedcompile/app.glj", `
+		(ns nestedcompile.app)
+		(load "/nestedcompile/app_helpers")
+		(defn after-load [] (helper))`)
+	writeSourceFile(t, root, "nestedcompile/app_helpers.glj", `
+		(in-ns 'nestedcompile.app)
+		(defn helper [] 1)`)
+	AddLoadPath(os.DirFS(root))
+
+	// The namespace is loaded first, as clojure.core is before make aot
+	// compiles it, so its sources are known. Compiling must still wait
+	// for the namespace's own file rather than generate it when the
+	// helper file loads.
+	ReadEval(`(require 'nestedcompile.app)`)
+	ns := lang.FindNamespace(lang.NewSymbol("nestedcompile.app"))
+	ns.Unmap(lang.NewSymbol("after-load"))
+	ReadEval(`(compile 'nestedcompile.app)`)
+
+	loader, err := os.ReadFile(filepath.Join(root, "nestedcompile", "app", "loader.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(loader), `"after-load"`) {
+		t.Fatalf("loader was generated before the namespace finished loading:\n%s", loader)
+	}
+}
+
+func writeSourceFile(t *testing.T, root, name, data string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
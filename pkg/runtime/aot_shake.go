@@ -0,0 +1,745 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// Tree shaking removes the initializers of vars a program cannot reach from
+// the loaders linked into its executable. Every generated loader initializes
+// its namespace in LoadNS, one block per var, and refers to other vars
+// through handles declared at the top of LoadNS, so the dependencies between
+// initializers can be read from the loaders themselves, including the
+// precompiled ones of the standard library. Starting from the entry
+// namespace's -main, exported functions and the declared roots, the shaker
+// follows every reference a reachable initializer makes, including
+// direct-linked calls, but not references to macros, which have already been
+// expanded. The pruned loaders replace the originals through a go build
+// overlay, leaving the loaders in the work directory and module cache
+// untouched.
+//
+// Omitted vars are still interned, so code that finds one at run time, with
+// resolve or eval, sees an unbound var rather than a missing one.
+
+// shakeDir holds the pruned loaders and the overlay naming them. The leading
+// underscore keeps the go tool from treating it as a package.
+const shakeDir = "_shaken"
+
+// compactRuntimeNamespaces are linked into every compact executable by
+// pkg/glj, whether or not the program requires them.
+var compactRuntimeNamespaces = []string{"clojure.core", "glojure.go.io"}
+
+// treeShakeRuntimeRoots are the vars the Go runtime looks up by name, so
+// no loader refers to them. TestTreeShakeRuntimeRoots finds the lookups.
+var treeShakeRuntimeRoots = []string{
+	"clojure.core/*command-line-args*",
+	"clojure.core/*compiler-options*",
+	"clojure.core/*glojure-version*",
+	"clojure.core/*loaded-libs*",
+	"clojure.core/*loading-verbosely*",
+	"clojure.core/apply",
+	"clojure.core/assoc",
+	"clojure.core/conj",
+	"clojure.core/contains?",
+	"clojure.core/deref",
+	"clojure.core/in-ns",
+	"clojure.core/isa?",
+	"clojure.core/load",
+	"clojure.core/load-file",
+	"clojure.core/parents",
+	"clojure.core/pr-on",
+	"clojure.core/print-dup",
+	"clojure.core/print-initialized",
+	"clojure.core/print-method",
+	"clojure.core/reduce",
+	"clojure.core/ref",
+	"clojure.core/require",
+	"clojure.core/sorted-set",
+	"clojure.core/swap!",
+}
+
+// treeShakeBuild prunes the loaders of the program's namespaces and returns
+// the path of the overlay that substitutes them.
+func treeShakeBuild(workDir string, opts BuildOptions, namespaces []buildNamespace) (string, *TreeShakeReport, error) {
+	workDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return "", nil, err
+	}
+	outDir := filepath.Join(workDir, shakeDir)
+	if err := os.RemoveAll(outDir); err != nil {
+		return "", nil, err
+	}
+
+	// Collect the loader of every linked namespace.
+	type linked struct{ name, resource, importPath string }
+	var all []linked
+	seen := map[string]bool{}
+	for _, bns := range namespaces {
+		seen[bns.name] = true
+		all = append(all, linked{bns.name, bns.resource, bns.importPath})
+	}
+	for _, name := range compactRuntimeNamespaces {
+		if seen[name] {
+			continue
+		}
+		resource := nsToPath(name)
+		importPath, ok := nsLoaderPackage(resource)
+		if !ok {
+			return "", nil, fmt.Errorf("tree shaking: cannot determine the Go package of the %s loader", name)
+		}
+		all = append(all, linked{name, resource, importPath})
+	}
+	var precompiled []string
+	for _, l := range all {
+		if !strings.HasPrefix(l.importPath, buildModule+"/") {
+			precompiled = append(precompiled, l.importPath)
+		}
+	}
+	dirs, err := goPackageDirs(workDir, precompiled)
+	if err != nil {
+		return "", nil, fmt.Errorf("tree shaking: %w", err)
+	}
+
+	var loaders []*shakeLoader
+	for _, l := range all {
+		path := filepath.Join(workDir, filepath.FromSlash(l.resource), aotLoaderFile)
+		if dir, ok := dirs[l.importPath]; ok {
+			path = filepath.Join(dir, aotLoaderFile)
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("tree shaking: %s has no generated loader: %w", l.name, err)
+		}
+		loader, err := parseShakeLoader(path, src)
+		if err != nil {
+			return "", nil, fmt.Errorf("tree shaking: %w", err)
+		}
+		loaders = append(loaders, loader)
+	}
+
+	roots := append([]string{opts.Entry + "/-main"}, opts.Roots...)
+	for _, loader := range loaders {
+		for _, s := range loader.stmts {
+			if vr := lookupQualifiedVar(s.vr); vr != nil {
+				if _, ok := exportMeta(vr); ok {
+					roots = append(roots, s.vr)
+				}
+			}
+		}
+	}
+	isMacro := func(name string) bool {
+		vr := lookupQualifiedVar(name)
+		return vr != nil && vr.IsMacro()
+	}
+	if err := treeShake(loaders, roots, treeShakeRuntimeRoots, isMacro); err != nil {
+		return "", nil, err
+	}
+
+	report := &TreeShakeReport{}
+	overlay := map[string]map[string]string{"Replace": {}}
+	for i, loader := range loaders {
+		shaken := loader.shaken()
+		for _, s := range loader.stmts {
+			if s.vr == "" {
+				continue
+			}
+			if s.reached {
+				report.Kept++
+			} else {
+				report.Omitted++
+			}
+		}
+		report.OmittedSourceBytes += len(loader.src) - len(shaken)
+
+		target := filepath.Join(outDir, strconv.Itoa(i), aotLoaderFile)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", nil, err
+		}
+		if err := os.WriteFile(target, shaken, 0644); err != nil {
+			return "", nil, err
+		}
+		overlay["Replace"][loader.path] = target
+	}
+	data, err := json.MarshalIndent(overlay, "", "  ")
+	if err != nil {
+		return "", nil, err
+	}
+	overlayFile := filepath.Join(outDir, "overlay.json")
+	if err := os.WriteFile(overlayFile, data, 0644); err != nil {
+		return "", nil, err
+	}
+	return overlayFile, report, nil
+}
+
+// goPackageDirs returns the directories of the packages with the given
+// import paths, resolved by the module in workDir.
+func goPackageDirs(workDir string, importPaths []string) (map[string]string, error) {
+	dirs := map[string]string{}
+	if len(importPaths) == 0 {
+		return dirs, nil
+	}
+	args := append([]string{"list", "-mod=mod", "-f", "{{.ImportPath}}\t{{.Dir}}"}, importPaths...)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = workDir
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, fmt.Errorf("go list failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+	} else if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if importPath, dir, ok := strings.Cut(line, "\t"); ok {
+			dirs[importPath] = dir
+		}
+	}
+	return dirs, nil
+}
+
+func lookupQualifiedVar(name string) *lang.Var {
+	nsName, varName, ok := strings.Cut(name, "/")
+	if !ok {
+		return nil
+	}
+	ns := lang.FindNamespace(lang.NewSymbol(nsName))
+	if ns == nil {
+		return nil
+	}
+	return ns.FindInternedVar(lang.NewSymbol(varName))
+}
+
+// shakeLoader is a generated loader split into the top-level statements
+// of its LoadNS function.
+type shakeLoader struct {
+	path string
+	src  []byte
+	fset *token.FileSet
+	file *ast.File
+	body *ast.BlockStmt
+	// vars maps the var handles declared in LoadNS to qualified names.
+	vars map[string]string
+	// locals are the names declared at the top level of LoadNS.
+	locals map[string]bool
+	stmts  []*shakeStmt
+}
+
+type shakeStmtKind int
+
+const (
+	// shakeInit is a block that initializes a var or a value shared by
+	// other initializers. It is kept only if reachable.
+	shakeInit shakeStmtKind = iota
+	// shakeDecl declares names at the top of LoadNS. Declarations are
+	// always kept, but followed only if reachable.
+	shakeDecl
+	// shakeEffect is any other statement, kept and followed as a root.
+	shakeEffect
+)
+
+type shakeStmt struct {
+	loader *shakeLoader
+	node   ast.Stmt
+	kind   shakeStmtKind
+	// defines lists the loader-level names the statement assigns, and uses
+	// the loader-level names it refers to.
+	defines []string
+	uses    []string
+	// vr is the qualified name of the var a block initializes, if any.
+	vr      string
+	reached bool
+}
+
+func parseShakeLoader(path string, src []byte) (*shakeLoader, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	l := &shakeLoader{
+		path:   path,
+		src:    src,
+		fset:   fset,
+		file:   file,
+		vars:   map[string]string{},
+		locals: map[string]bool{},
+	}
+	globals := map[string]bool{}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					globals[name.Name] = true
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil && decl.Name.Name == "LoadNS" {
+				l.body = decl.Body
+			}
+		}
+	}
+	if l.body == nil {
+		return nil, fmt.Errorf("%s has no LoadNS function", path)
+	}
+
+	// Symbols and var handles are declared first, each with a literal
+	// name or, in loaders generated with the :snapshot compiler option,
+	// taken from the namespace snapshot by index.
+	snapshotSymbols, snapshotVars := parseSnapshot(file)
+	symbols := map[string]string{}
+	for _, stmt := range l.body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		name := assign.Lhs[0].(*ast.Ident).Name
+		if index, ok := assign.Rhs[0].(*ast.IndexExpr); ok {
+			if i, ok := literalIndex(index, "restored.Symbols"); ok && i < len(snapshotSymbols) {
+				symbols[name] = snapshotSymbols[i]
+			} else if i, ok := literalIndex(index, "restored.Vars"); ok && i < len(snapshotVars) {
+				l.vars[name] = snapshotVars[i]
+			}
+			continue
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		switch callName(call) {
+		case "lang.NewSymbolUnchecked":
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+				symbols[name], _ = strconv.Unquote(lit.Value)
+			}
+		case "lang.InternVarName":
+			nsArg, ok1 := call.Args[0].(*ast.Ident)
+			nameArg, ok2 := call.Args[1].(*ast.Ident)
+			if ok1 && ok2 {
+				l.vars[name] = symbols[nsArg.Name] + "/" + symbols[nameArg.Name]
+			}
+		}
+	}
+
+	for _, stmt := range l.body.List {
+		s := &shakeStmt{loader: l, node: stmt}
+		switch stmt := stmt.(type) {
+		case *ast.BlockStmt:
+			s.kind = shakeInit
+			for _, inner := range stmt.List {
+				// Vars restored from a snapshot are interned already;
+				// their initializers only bind the root.
+				if vr, ok := bindRootVar(l, inner); ok {
+					s.vr = vr
+					continue
+				}
+				assign, ok := inner.(*ast.AssignStmt)
+				if !ok || assign.Tok != token.ASSIGN {
+					continue
+				}
+				for _, lhs := range assign.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						s.defines = append(s.defines, id.Name)
+						if vr, ok := l.vars[id.Name]; ok {
+							s.vr = vr
+						}
+					}
+				}
+			}
+			if len(s.defines) == 0 && s.vr == "" {
+				s.kind = shakeEffect
+			}
+		case *ast.AssignStmt:
+			if stmt.Tok != token.DEFINE {
+				s.kind = shakeEffect
+				break
+			}
+			s.kind = shakeDecl
+			for _, lhs := range stmt.Lhs {
+				s.defines = append(s.defines, lhs.(*ast.Ident).Name)
+			}
+		case *ast.DeclStmt:
+			s.kind = shakeDecl
+			for _, spec := range stmt.Decl.(*ast.GenDecl).Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					for _, name := range vs.Names {
+						s.defines = append(s.defines, name.Name)
+					}
+				}
+			}
+		default:
+			s.kind = shakeEffect
+		}
+		if s.kind == shakeDecl {
+			for _, name := range s.defines {
+				l.locals[name] = true
+				globals[name] = true
+			}
+		}
+		l.stmts = append(l.stmts, s)
+	}
+
+	// Only now are all loader-level names known.
+	for _, s := range l.stmts {
+		// A declaration uses the names in its values, not those it
+		// declares.
+		nodes := []ast.Node{s.node}
+		switch stmt := s.node.(type) {
+		case *ast.AssignStmt:
+			if s.kind == shakeDecl {
+				nodes = nodes[:0]
+				for _, rhs := range stmt.Rhs {
+					nodes = append(nodes, rhs)
+				}
+			}
+		case *ast.DeclStmt:
+			nodes = nodes[:0]
+			for _, spec := range stmt.Decl.(*ast.GenDecl).Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					for _, value := range vs.Values {
+						nodes = append(nodes, value)
+					}
+				}
+			}
+		}
+		used := map[string]bool{}
+		for _, node := range nodes {
+			ast.Inspect(node, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && globals[id.Name] {
+					used[id.Name] = true
+				}
+				return true
+			})
+		}
+		if s.kind == shakeInit {
+			for _, name := range s.defines {
+				delete(used, name)
+			}
+		}
+		for name := range used {
+			s.uses = append(s.uses, name)
+		}
+		sort.Strings(s.uses)
+	}
+	return l, nil
+}
+
+// bindRootVar returns the qualified name of the var whose root stmt
+// binds through one of l's var handles.
+func bindRootVar(l *shakeLoader, stmt ast.Stmt) (string, bool) {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return "", false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "BindRoot" {
+		return "", false
+	}
+	handle, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	vr, ok := l.vars[handle.Name]
+	return vr, ok
+}
+
+// parseSnapshot returns the symbols and the qualified names of the vars
+// in the nsSnapshot declaration of a loader generated with the :snapshot
+// compiler option, in order, or nil if it has none.
+func parseSnapshot(file *ast.File) (symbols, vars []string) {
+	var fields map[string]ast.Expr
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Names) != 1 || vs.Names[0].Name != "nsSnapshot" || len(vs.Values) != 1 {
+				continue
+			}
+			if lit, ok := vs.Values[0].(*ast.CompositeLit); ok {
+				fields = compositeFields(lit)
+			}
+		}
+	}
+	if fields == nil {
+		return nil, nil
+	}
+
+	if lit, ok := fields["Symbols"].(*ast.CompositeLit); ok {
+		for _, elt := range lit.Elts {
+			sym, _ := stringLiteral(elt)
+			symbols = append(symbols, sym)
+		}
+	}
+	symbol := func(expr ast.Expr) string {
+		lit, ok := expr.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return ""
+		}
+		i, err := strconv.Atoi(lit.Value)
+		if err != nil || i >= len(symbols) {
+			return ""
+		}
+		return symbols[i]
+	}
+	ns := symbol(fields["Name"])
+	varsLit, ok := fields["Vars"].(*ast.CompositeLit)
+	if ns == "" || !ok {
+		return symbols, nil
+	}
+	vars = make([]string, len(varsLit.Elts))
+	for i, elt := range varsLit.Elts {
+		if lit, ok := elt.(*ast.CompositeLit); ok {
+			if name := symbol(compositeFields(lit)["Name"]); name != "" {
+				vars[i] = ns + "/" + name
+			}
+		}
+	}
+	return symbols, vars
+}
+
+// compositeFields returns the keyed fields of a composite literal.
+func compositeFields(lit *ast.CompositeLit) map[string]ast.Expr {
+	fields := map[string]ast.Expr{}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				fields[key.Name] = kv.Value
+			}
+		}
+	}
+	return fields
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// literalIndex returns the constant index of an expression such as
+// restored.Vars[3] whose indexed operand is name.
+func literalIndex(index *ast.IndexExpr, name string) (int, bool) {
+	sel, ok := index.X.(*ast.SelectorExpr)
+	if !ok {
+		return 0, false
+	}
+	if x, ok := sel.X.(*ast.Ident); !ok || x.Name+"."+sel.Sel.Name != name {
+		return 0, false
+	}
+	lit, ok := index.Index.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	i, err := strconv.Atoi(lit.Value)
+	return i, err == nil
+}
+
+func callName(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return pkg.Name + "." + sel.Sel.Name
+}
+
+// treeShake marks the statements of loaders reachable from roots, which
+// name vars as ns/name or whole namespaces. It is an error for a root to
+// name nothing. References to macros are not followed; runtimeRoots are
+// kept if present.
+func treeShake(loaders []*shakeLoader, roots, runtimeRoots []string, isMacro func(string) bool) error {
+	inits := map[string][]*shakeStmt{}
+	definers := map[*shakeLoader]map[string][]*shakeStmt{}
+	for _, l := range loaders {
+		definers[l] = map[string][]*shakeStmt{}
+		for _, s := range l.stmts {
+			if s.vr != "" {
+				inits[s.vr] = append(inits[s.vr], s)
+			}
+			for _, name := range s.defines {
+				definers[l][name] = append(definers[l][name], s)
+			}
+		}
+	}
+
+	var work []*shakeStmt
+	reach := func(s *shakeStmt) {
+		if !s.reached {
+			s.reached = true
+			work = append(work, s)
+		}
+	}
+	rooted := map[string]bool{}
+	reachVar := func(name string) {
+		if isMacro(name) && !rooted[name] {
+			return
+		}
+		for _, s := range inits[name] {
+			reach(s)
+		}
+	}
+
+	for _, root := range roots {
+		if strings.Contains(root, "/") {
+			if len(inits[root]) == 0 {
+				return fmt.Errorf("tree shaking: no compiled var %s", root)
+			}
+			rooted[root] = true
+			continue
+		}
+		found := false
+		for name := range inits {
+			if strings.HasPrefix(name, root+"/") {
+				rooted[name] = true
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("tree shaking: no compiled namespace %s", root)
+		}
+	}
+	for _, root := range runtimeRoots {
+		rooted[root] = true
+	}
+	for name := range rooted {
+		reachVar(name)
+	}
+	for _, l := range loaders {
+		for _, s := range l.stmts {
+			if s.kind == shakeEffect {
+				reach(s)
+			}
+		}
+	}
+
+	for len(work) > 0 {
+		s := work[len(work)-1]
+		work = work[:len(work)-1]
+		for _, name := range s.uses {
+			if vr, ok := s.loader.vars[name]; ok {
+				reachVar(vr)
+				continue
+			}
+			for _, d := range definers[s.loader][name] {
+				reach(d)
+			}
+		}
+	}
+	return nil
+}
+
+// shaken returns the loader without its unreachable initializers. Names
+// and imports only they used are kept referenced so the loader still
+// compiles.
+func (l *shakeLoader) shaken() []byte {
+	type cut struct{ start, end int }
+	var cuts []cut
+	used := map[string]bool{}
+	removed := map[ast.Node]bool{}
+	var prevEnd token.Pos = l.body.Lbrace + 1
+	for _, s := range l.stmts {
+		if s.kind == shakeInit && !s.reached {
+			// Take the comments and directives that precede the block.
+			cuts = append(cuts, cut{l.offset(prevEnd), l.offset(s.node.End())})
+			removed[s.node] = true
+		} else {
+			for _, name := range s.uses {
+				used[name] = true
+			}
+		}
+		prevEnd = s.node.End()
+	}
+	if len(cuts) == 0 {
+		return l.src
+	}
+
+	idents := map[string]bool{}
+	ast.Inspect(l.file, func(n ast.Node) bool {
+		if _, ok := n.(*ast.ImportSpec); ok || removed[n] {
+			return false
+		}
+		if id, ok := n.(*ast.Ident); ok {
+			idents[id.Name] = true
+		}
+		return true
+	})
+
+	var unused []string
+	for name := range l.locals {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	var tail bytes.Buffer
+	for _, name := range unused {
+		fmt.Fprintf(&tail, "\t_ = %s\n", name)
+	}
+
+	var out bytes.Buffer
+	pos := 0
+	for _, imp := range l.file.Imports {
+		if imp.Name == nil || imp.Name.Name == "_" || idents[imp.Name.Name] {
+			continue
+		}
+		start := l.offset(imp.Name.Pos())
+		out.Write(l.src[pos:start])
+		out.WriteString("_")
+		pos = l.offset(imp.Name.End())
+	}
+	for _, c := range cuts {
+		out.Write(l.src[pos:c.start])
+		pos = c.end
+	}
+	rbrace := l.offset(l.body.Rbrace)
+	out.Write(l.src[pos:rbrace])
+	out.Write(tail.Bytes())
+	out.Write(l.src[rbrace:])
+	return renumberLoaderLines(out.Bytes())
+}
+
+func (l *shakeLoader) offset(pos token.Pos) int {
+	return l.fset.Position(pos).Offset
+}
+
+var loaderLineDirective = regexp.MustCompile(`^//line ` + regexp.QuoteMeta(aotLoaderFile) + `:\d+$`)
+
+// renumberLoaderLines updates the directives that restore positions in the
+// loader after lines before them were removed.
+func renumberLoaderLines(src []byte) []byte {
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	for i, line := range lines {
+		if loaderLineDirective.Match(bytes.TrimSuffix(line, []byte("\n"))) {
+			lines[i] = fmt.Appendf(nil, "//line %s:%d\n", aotLoaderFile, i+2)
+		}
+	}
+	return bytes.Join(lines, nil)
+}
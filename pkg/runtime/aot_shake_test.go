@@ -0,0 +1,195 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+func TestTreeShakeOmitsUnreachableVars(t *testing.T) {
+	ns := lang.FindOrCreateNamespace(lang.NewSymbol("shake.app"))
+	ns.ReferAllSnapshot(lang.NSCore, nil)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, ns))
+	defer lang.PopThreadBindings()
+
+	ReadEval(`
+(defn helper-for-macro [x] x)
+(defmacro twice [x] (list 'do x (helper-for-macro x)))
+(defn used [x] (inc x))
+(defn unused [x] (dec x))
+(defn plugin [x] (unused x))
+(defn -main [& args] (twice (used 1)))`, WithFilename("shake/app.glj"))
+
+	// Loaders generated with the :snapshot compiler option take their
+	// var handles from the namespace snapshot rather than interning them.
+	for _, snapshot := range []bool{false, true} {
+		t.Run(fmt.Sprintf("snapshot=%t", snapshot), func(t *testing.T) {
+			var output bytes.Buffer
+			generator := NewGenerator(&output)
+			generator.snapshot = snapshot
+			generator.EnableLineDirectives("")
+			if err := generator.Generate(ns); err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			isMacro := func(name string) bool {
+				vr := lookupQualifiedVar(name)
+				return vr != nil && vr.IsMacro()
+			}
+			shake := func(roots ...string) map[string]bool {
+				t.Helper()
+				loader, err := parseShakeLoader("loader.go", output.Bytes())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := treeShake([]*shakeLoader{loader}, roots, nil, isMacro); err != nil {
+					t.Fatal(err)
+				}
+				kept := map[string]bool{}
+				for _, s := range loader.stmts {
+					if s.vr != "" && s.reached {
+						kept[strings.TrimPrefix(s.vr, "shake.app/")] = true
+					}
+				}
+
+				shaken := string(loader.shaken())
+				if _, err := parser.ParseFile(token.NewFileSet(), "loader.go", shaken, parser.ParseComments); err != nil {
+					t.Fatalf("shaken loader does not parse: %v", err)
+				}
+				for i, line := range strings.Split(shaken, "\n") {
+					if rest, ok := strings.CutPrefix(line, "//line loader.go:"); ok && rest != strconv.Itoa(i+2) {
+						t.Errorf("line %d: %q does not name the following line", i+1, line)
+					}
+				}
+				return kept
+			}
+
+			kept := shake("shake.app/-main")
+			for name, want := range map[string]bool{
+				"-main": true, "used": true,
+				"twice": false, "helper-for-macro": false, "unused": false, "plugin": false,
+			} {
+				if kept[name] != want {
+					t.Errorf("kept %s = %t, want %t", name, kept[name], want)
+				}
+			}
+
+			if kept := shake("shake.app/-main", "shake.app/plugin"); !kept["plugin"] || !kept["unused"] {
+				t.Errorf("declared root was not kept with its references: %v", kept)
+			}
+			if kept := shake("shake.app"); len(kept) != 6 {
+				t.Errorf("namespace root kept %v, want every var", kept)
+			}
+
+			loader, err := parseShakeLoader("loader.go", output.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := treeShake([]*shakeLoader{loader}, []string{"shake.app/missing"}, nil, isMacro); err == nil {
+				t.Error("a root naming no var was accepted")
+			}
+		})
+	}
+}
+
+// TestTreeShakeRuntimeRoots checks that treeShakeRuntimeRoots names every
+// clojure.core var that the compact runtime, or the main package glj
+// build generates, looks up by name. A var looked up only to replace its
+// root if it exists, as the native core functions are, need not be kept.
+func TestTreeShakeRuntimeRoots(t *testing.T) {
+	ctx := build.Default
+	ctx.BuildTags = append(ctx.BuildTags, "glj_aot_runtime")
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, dir := range []string{"../lang", ".", "../glj"} {
+		pkg, err := ctx.ImportDir(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range pkg.GoFiles {
+			file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files = append(files, file)
+		}
+	}
+	main, err := buildMainSource("app.main", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parser.ParseFile(fset, "main.go", main, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, file)
+
+	roots := map[string]bool{}
+	for _, name := range treeShakeRuntimeRoots {
+		roots[name] = true
+	}
+	for _, file := range files {
+		replaced := map[ast.Expr]bool{}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.IfStmt:
+				// if vr := core.FindInternedVar(...); vr != nil { ... }
+				if init, ok := n.Init.(*ast.AssignStmt); ok && len(init.Rhs) == 1 {
+					replaced[init.Rhs[0]] = true
+				}
+			case *ast.CallExpr:
+				name, ok := coreVarLookup(n)
+				if ok && !replaced[n] && !roots["clojure.core/"+name] {
+					t.Errorf("%s: the runtime looks up clojure.core/%s, which is not in treeShakeRuntimeRoots",
+						fset.Position(n.Pos()), name)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// coreVarLookup returns the name of the clojure.core var that call looks
+// up, if it is a lookup by a literal name.
+func coreVarLookup(call *ast.CallExpr) (string, bool) {
+	fun := types.ExprString(call.Fun)
+	switch {
+	case strings.HasSuffix(fun, ".FindInternedVar") && len(call.Args) == 1:
+		switch strings.TrimSuffix(fun, ".FindInternedVar") {
+		case "core", "NSCore", "lang.NSCore":
+			return symbolLiteral(call.Args[0])
+		}
+	case (fun == "InternVarName" || fun == "lang.InternVarName") && len(call.Args) == 2:
+		switch types.ExprString(call.Args[0]) {
+		case "NSCore.Name()", "lang.NSCore.Name()":
+			return symbolLiteral(call.Args[1])
+		}
+	case fun == "coreVar" && len(call.Args) == 1:
+		return stringLiteral(call.Args[0])
+	}
+	return "", false
+}
+
+// symbolLiteral returns the name of the symbol that e, a call of
+// NewSymbol with a string literal, makes.
+func symbolLiteral(e ast.Expr) (string, bool) {
+	call, ok := e.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+	if fun := types.ExprString(call.Fun); fun != "NewSymbol" && fun != "lang.NewSymbol" {
+		return "", false
+	}
+	return stringLiteral(call.Args[0])
+}
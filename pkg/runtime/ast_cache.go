@@ -0,0 +1,471 @@
+package runtime
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// Scripts and libs loaded from source can be evaluated from a cache of
+// their analyzed forms, skipping reading and analysis. A file's cache is
+// keyed by its source and the compiler, and each of its forms records
+// whether direct linking was enabled and the versions of the namespaces
+// the form refers to, which are checked before the form is evaluated from
+// the cache: a
+// namespace's version hashes the source files loaded into it and the
+// versions of the libs they require, so a change to a macro a form uses,
+// or to any lib its namespace requires, is seen. Forms after the first
+// whose check fails are read and analyzed again, and the cache rewritten.
+//
+// Expanding a macro has no side effects when a form is evaluated from the
+// cache, as when code compiled ahead of time is loaded.
+
+const astCacheFormat = 1
+
+var astCacheMagic = []byte("glojure-ast-cache\n")
+
+var (
+	astCacheDir     string
+	astCacheDirLock sync.Mutex
+)
+
+// SetASTCacheDir sets the directory that the analyzed forms of scripts
+// and of libs loaded from source are cached in. An empty dir, the
+// default, disables the cache.
+func SetASTCacheDir(dir string) {
+	astCacheDirLock.Lock()
+	defer astCacheDirLock.Unlock()
+	astCacheDir = dir
+}
+
+// DefaultASTCacheDir returns the directory glj caches analyzed forms in,
+// glojure/ast under $XDG_CACHE_HOME or else the user's cache directory.
+func DefaultASTCacheDir() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		var err error
+		if dir, err = os.UserCacheDir(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(dir, "glojure", "ast"), nil
+}
+
+// astCachePath returns the path of the cache of the file identified by
+// name, or "" if the cache is disabled. Sandboxed and isolated runtimes
+// analyze their own code, as does a process whose standard library is
+// loaded from GLOJURE_STDLIB_PATH, as changes to clojure.core are not
+// seen.
+func astCachePath(name string) string {
+	astCacheDirLock.Lock()
+	dir := astCacheDir
+	astCacheDirLock.Unlock()
+	if dir == "" || currentSandbox() != nil || currentRuntime() != nil || os.Getenv("GLOJURE_STDLIB_PATH") != "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(name))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16])+".ast")
+}
+
+// astCacheKey returns the key of the cache of code, read from filename,
+// or false if the compiler cannot be identified.
+func astCacheKey(filename, code string) (string, bool) {
+	compiler, ok := compilerIdentity()
+	if !ok {
+		return "", false
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "compiler %s\n", compiler)
+	fmt.Fprintf(h, "filename %s\n", filename)
+	fmt.Fprintf(h, "source %x\n", sha256.Sum256([]byte(code)))
+	fmt.Fprintf(h, "data-readers %s\n", lang.PrintString(lang.VarDataReaders.Deref()))
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// nsVersion is the version of a namespace a cached form refers to.
+type nsVersion struct {
+	ns, version string
+}
+
+// formDeps returns the versions of the namespaces other than ns that
+// form refers to in ns.
+func formDeps(ns *lang.Namespace, form any) []nsVersion {
+	found := map[string]bool{}
+	collectNamespaceRefs(ns, form, found)
+	delete(found, ns.Name().Name())
+	deps := make([]nsVersion, 0, len(found))
+	memo := map[string]string{}
+	for _, name := range sortedKeys(found) {
+		deps = append(deps, nsVersion{name, namespaceVersion(name, memo)})
+	}
+	return deps
+}
+
+// namespaceVersion returns a hash of the source files loaded into the
+// named namespace and of the versions of the libs they require, or "" for
+// a namespace not loaded from source, such as one compiled into the
+// program or one defined by the script being evaluated.
+func namespaceVersion(name string, memo map[string]string) string {
+	if version, ok := memo[name]; ok {
+		return version
+	}
+	// A cycle: the namespace's own files are hashed where the cycle was
+	// entered.
+	memo[name] = "cycle " + name
+
+	nsFilesLock.Lock()
+	var files []*nsFile
+	for _, f := range nsFiles {
+		if f.ns == name {
+			files = append(files, f)
+		}
+	}
+	nsFilesLock.Unlock()
+	if len(files) == 0 {
+		memo[name] = ""
+		return ""
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].resource < files[j].resource })
+
+	h := sha256.New()
+	requires := map[string]bool{}
+	for _, f := range files {
+		fmt.Fprintf(h, "source %s %x\n", f.resource, f.hash)
+		for _, lib := range f.requires {
+			requires[lib] = true
+		}
+	}
+	for _, lib := range sortedKeys(requires) {
+		fmt.Fprintf(h, "require %s %s\n", lib, namespaceVersion(lib, memo))
+	}
+	version := hex.EncodeToString(h.Sum(nil))
+	memo[name] = version
+	return version
+}
+
+// A Script reads and evaluates the top-level forms of a source file.
+// If the AST cache is enabled, forms are evaluated from the cache while
+// it is valid, and the cache is written when a script read and analyzed
+// is evaluated to the end.
+type Script struct {
+	langEnv  lang.Environment
+	env      *environment
+	filename string
+	code     string
+	path     string
+	key      string
+
+	rdr *reader.Reader
+	// replayed holds the entries evaluated from the cache, in case the
+	// cache turns out to be stale and must be rewritten.
+	replayed []scriptEntry
+	dec      *astDecoder
+	pending  int
+	// node is the AST of the form ReadOne last returned, if it was
+	// decoded from the cache.
+	node *ast.Node
+
+	enc     *astEncoder
+	entries int
+	eof     bool
+	failed  bool
+}
+
+// scriptEntry is the cache entry of a top-level form.
+type scriptEntry struct {
+	// ns is the namespace the form was read in.
+	ns         *lang.Namespace
+	deps       []nsVersion
+	directLink bool
+	form       any
+	// node is nil for forms evaluated without analysis.
+	node *ast.Node
+}
+
+// NewScript returns a Script for code, the contents of the file
+// filename, that evaluates it in the global environment.
+func NewScript(filename, code string) *Script {
+	name := filename
+	if abs, err := filepath.Abs(filename); err == nil {
+		name = abs
+	}
+	return newScript(lang.GlobalEnv, filename, "script "+name, code)
+}
+
+// newScript returns a Script for code, the contents of the file
+// filename, cached as name, that evaluates it in env.
+func newScript(env lang.Environment, filename, name, code string) *Script {
+	s := &Script{langEnv: env, filename: filename, code: code}
+	s.env, _ = env.(*environment)
+	if s.env == nil {
+		s.rdr = s.newReader(nil)
+		return s
+	}
+	if name != "" {
+		s.path = astCachePath(name)
+	}
+	if s.path != "" {
+		var ok bool
+		if s.key, ok = astCacheKey(filename, code); !ok {
+			s.path = ""
+		}
+	}
+	if s.path != "" {
+		s.openCache()
+	}
+	if s.dec == nil {
+		s.rdr = s.newReader(nil)
+		if s.path != "" {
+			s.enc = newASTEncoder()
+		}
+	}
+	return s
+}
+
+func (s *Script) newReader(getCurrentNS func() *lang.Namespace) *reader.Reader {
+	if getCurrentNS == nil {
+		getCurrentNS = func() *lang.Namespace {
+			return s.langEnv.CurrentNamespace()
+		}
+	}
+	opts := []reader.Option{reader.WithGetCurrentNS(getCurrentNS)}
+	if s.filename != "" {
+		opts = append(opts, reader.WithFilename(s.filename))
+	}
+	return reader.New(strings.NewReader(s.code), opts...)
+}
+
+// openCache prepares the script to be evaluated from its cache, if it
+// has one for its key.
+func (s *Script) openCache() {
+	data, err := os.ReadFile(s.path)
+	if err != nil || !bytes.HasPrefix(data, astCacheMagic) {
+		return
+	}
+	d := newASTDecoder(data[len(astCacheMagic):])
+	var key string
+	var count int
+	err = d.decode(func() {
+		if d.uint() != astCacheFormat {
+			d.fail("format")
+		}
+		n := d.len()
+		key = string(d.buf[d.pos : d.pos+n])
+		d.pos += n
+		count = d.len()
+	})
+	if err != nil || key != s.key {
+		return
+	}
+	s.dec, s.pending = d, count
+}
+
+// ReadOne returns the next top-level form of the script, or reader.ErrEOF
+// after the last.
+func (s *Script) ReadOne() (any, error) {
+	s.node = nil
+	if s.dec != nil {
+		if s.pending == 0 {
+			s.eof = true
+			return nil, reader.ErrEOF
+		}
+		entry, err := s.decodeEntry()
+		if err == nil {
+			s.pending--
+			s.replayed = append(s.replayed, entry)
+			s.node = entry.node
+			return entry.form, nil
+		}
+		s.fallBack()
+	}
+	form, err := s.rdr.ReadOne()
+	if err == reader.ErrEOF {
+		s.eof = true
+	} else if err != nil {
+		s.failed = true
+	}
+	return form, err
+}
+
+// decodeEntry decodes the next entry of the cache, or returns an error if
+// the versions of the namespaces it depends on have changed.
+func (s *Script) decodeEntry() (scriptEntry, error) {
+	entry := scriptEntry{ns: s.env.CurrentNamespace()}
+	d := s.dec
+	err := d.decode(func() {
+		// Forms are analyzed again to report reflection warnings.
+		if lang.IsTruthy(lang.VarWarnOnReflection.Deref()) {
+			d.fail("reflection warnings enabled")
+		}
+		memo := map[string]string{}
+		for i := d.len(); i > 0; i-- {
+			dep := nsVersion{d.string(), d.string()}
+			if namespaceVersion(dep.ns, memo) != dep.version {
+				d.fail("namespace %s changed", dep.ns)
+			}
+			entry.deps = append(entry.deps, dep)
+		}
+		entry.directLink = d.bool()
+		if entry.directLink != directLinkEnabled() {
+			d.fail("direct linking changed")
+		}
+		entry.form = d.value()
+		if d.bool() {
+			entry.node = d.node()
+		}
+	})
+	return entry, err
+}
+
+// fallBack reads and analyzes the forms of the script left to evaluate,
+// and encodes the entries evaluated from the cache to rewrite it.
+func (s *Script) fallBack() {
+	s.dec = nil
+	i := 0
+	rdr := s.newReader(func() *lang.Namespace {
+		if i < len(s.replayed) {
+			return s.replayed[i].ns
+		}
+		return s.env.CurrentNamespace()
+	})
+	for ; i < len(s.replayed); i++ {
+		if _, err := rdr.ReadOne(); err != nil {
+			// The source matched the key; it cannot fail to read.
+			panic(fmt.Errorf("rereading %s: %w", s.filename, err))
+		}
+	}
+	s.rdr = rdr
+
+	s.enc = newASTEncoder()
+	for _, entry := range s.replayed {
+		s.record(entry)
+	}
+	s.replayed = nil
+}
+
+// Eval evaluates form, which ReadOne last returned.
+func (s *Script) Eval(form any) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.failed = true
+			panic(r)
+		}
+		if err != nil {
+			s.failed = true
+		}
+	}()
+	switch {
+	case s.env == nil:
+		return s.langEnv.Eval(form)
+	case s.node != nil:
+		node := s.node
+		return s.env.evalTopLevel(form, func() (any, error) {
+			return s.env.EvalAST(node)
+		})
+	case s.dec != nil:
+		// A form evaluated without analysis.
+		return s.env.Eval(form)
+	case s.enc == nil:
+		return s.env.Eval(form)
+	}
+
+	entry := scriptEntry{
+		ns:         s.env.CurrentNamespace(),
+		deps:       formDeps(s.env.CurrentNamespace(), form),
+		directLink: directLinkEnabled(),
+		form:       form,
+	}
+	if directSelfEvaluating(form) {
+		s.record(entry)
+		return form, nil
+	}
+	recorded := false
+	res, err = s.env.evalTopLevel(form, func() (any, error) {
+		return s.env.eval(form, func(node *ast.Node) {
+			entry.node = node
+			s.record(entry)
+			recorded = true
+		})
+	})
+	if !recorded {
+		s.record(entry)
+	}
+	return res, err
+}
+
+// record encodes entry, or stops recording if it cannot be cached.
+func (s *Script) record(entry scriptEntry) {
+	if s.enc == nil {
+		return
+	}
+	e := s.enc
+	err := e.encode(func() {
+		e.uint(uint64(len(entry.deps)))
+		for _, dep := range entry.deps {
+			e.string(dep.ns)
+			e.string(dep.version)
+		}
+		e.bool(entry.directLink)
+		e.value(entry.form)
+		e.bool(entry.node != nil)
+		if entry.node != nil {
+			e.node(entry.node)
+		}
+	})
+	if err != nil {
+		s.enc = nil
+		return
+	}
+	s.entries++
+}
+
+// Close writes the script's cache if the script was read and analyzed
+// and every form was evaluated without error.
+func (s *Script) Close() error {
+	if s.enc == nil || !s.eof || s.failed {
+		return nil
+	}
+	enc := s.enc
+	s.enc = nil
+
+	var buf []byte
+	buf = append(buf, astCacheMagic...)
+	buf = binary.AppendUvarint(buf, astCacheFormat)
+	buf = binary.AppendUvarint(buf, uint64(len(s.key)))
+	buf = append(buf, s.key...)
+	buf = binary.AppendUvarint(buf, uint64(s.entries))
+	buf = append(buf, enc.buf...)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	// Written to a temporary file and renamed, so that a script run
+	// concurrently never reads a partial cache.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(buf)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(tmp.Name()))
+	}
+	return nil
+}
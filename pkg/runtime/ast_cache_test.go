@@ -0,0 +1,98 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestASTCache(t *testing.T) {
+	SetASTCacheDir(t.TempDir())
+	t.Cleanup(func() { SetASTCacheDir("") })
+
+	files := fstest.MapFS{
+		"astcache/macros.glj": {Data: []byte(`
+			(ns astcache.macros)
+			(defmacro twice [x] (list '* 2 x))`)},
+	}
+	AddLoadPath(files)
+
+	env := NewEnvironment().(*environment)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, env.CurrentNamespace()))
+	t.Cleanup(lang.PopThreadBindings)
+
+	const code = `(ns astcache.app (:require [astcache.macros :refer [twice]]))
+(defn f [x] (case x 0 :zero (twice x)))
+(defn g [^String s] (.Len (strings.NewReader s)))
+[(f 0) (f 21) (g "abc") #"a+" 1/2]`
+	run := func() (any, int) {
+		t.Helper()
+		s := newScript(env, "app.glj", "test app.glj", code)
+		var res any
+		for {
+			form, err := s.ReadOne()
+			if err == reader.ErrEOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res, err = s.Eval(form); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return res, len(s.replayed)
+	}
+	check := func(want string, wantReplayed int) {
+		t.Helper()
+		res, replayed := run()
+		if got := lang.PrintString(res); got != want {
+			t.Errorf("script returned %s, want %s", got, want)
+		}
+		if replayed != wantReplayed {
+			t.Errorf("%d forms evaluated from the cache, want %d", replayed, wantReplayed)
+		}
+	}
+
+	check(`[:zero 42 3 #"a+" 1/2]`, 0)
+	path := astCachePath("test app.glj")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	check(`[:zero 42 3 #"a+" 1/2]`, 4)
+
+	// Changing the macro invalidates the forms that expand it.
+	files["astcache/macros.glj"] = &fstest.MapFile{Data: []byte(`
+		(ns astcache.macros)
+		(defmacro twice [x] (list '* 3 x))`)}
+	reload, err := reader.New(strings.NewReader(`(require 'astcache.macros :reload)`)).ReadOne()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.Eval(reload); err != nil {
+		t.Fatal(err)
+	}
+	res, replayed := run()
+	if got := lang.PrintString(res); got != `[:zero 63 3 #"a+" 1/2]` {
+		t.Errorf("script returned %s after the macro changed", got)
+	}
+	if replayed > 1 {
+		t.Errorf("%d forms evaluated from the cache after the macro changed", replayed)
+	}
+	check(`[:zero 63 3 #"a+" 1/2]`, 4)
+
+	// A corrupt cache is ignored.
+	if err := os.WriteFile(path, []byte("glojure-ast-cache\n\x01"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	check(`[:zero 63 3 #"a+" 1/2]`, 0)
+}
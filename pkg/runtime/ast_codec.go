@@ -0,0 +1,1170 @@
+package runtime
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+)
+
+// The AST cache stores analyzed top-level forms in a compact binary
+// encoding. Values are tagged; strings and the values of pointer types,
+// such as forms, are stored once and referred to by index after, so the
+// subforms that nodes share with the form they were analyzed from, and
+// the environments nodes share with each other, cost an index each.
+// Nodes are stored in the order they are reached and referred to by index
+// when reached again.
+//
+// Only data is stored. Vars are stored by name and found again when the
+// nodes that refer to them are decoded, as are the Go values that host
+// symbols resolved to. A node holding any other value cannot be stored.
+
+var (
+	// errNotCacheable is returned encoding a node that holds a value
+	// that cannot be stored.
+	errNotCacheable = errors.New("not cacheable")
+	// errStaleCache is returned decoding a node that no longer
+	// describes the program, such as one referring to a var that does
+	// not exist.
+	errStaleCache = errors.New("stale AST cache")
+)
+
+const (
+	valNil byte = iota
+	valTrue
+	valFalse
+	valInt64
+	valInt
+	valFloat64
+	valString
+	valChar
+	valKeyword
+	valSymbol
+	valList
+	valCons
+	valVector
+	valMap
+	valSet
+	valBigInt
+	valRatio
+	valBigDecimal
+	valRegexp
+	valVar
+	valRef
+	valType
+	valClass
+	valSortedMap
+	valSortedSet
+)
+
+const (
+	nodeNil byte = iota
+	nodeRef
+	nodeNew
+)
+
+// codecError carries an encoding or decoding error out of the recursion
+// to the entry point that recovers it.
+type codecError struct {
+	err error
+}
+
+func recoverCodecError(err *error) {
+	if r := recover(); r != nil {
+		cerr, ok := r.(codecError)
+		if !ok {
+			panic(r)
+		}
+		*err = cerr.err
+	}
+}
+
+type astEncoder struct {
+	buf      []byte
+	strings  map[string]int
+	values   map[any]int
+	nvalues  int
+	nodes    map[*ast.Node]int
+	bindings map[*ast.BindingNode]int
+	// envs holds the environments stored for the environments of
+	// nodes, which omit the nodes of locals.
+	envs map[lang.IPersistentMap]lang.IPersistentMap
+}
+
+func newASTEncoder() *astEncoder {
+	return &astEncoder{
+		strings:  map[string]int{},
+		values:   map[any]int{},
+		nodes:    map[*ast.Node]int{},
+		bindings: map[*ast.BindingNode]int{},
+		envs:     map[lang.IPersistentMap]lang.IPersistentMap{},
+	}
+}
+
+// encode appends the encoding of fn's writes to the encoder's buffer, or
+// leaves the buffer as it was and returns an error if a value cannot be
+// stored. After an error the encoder must not be used again, as the
+// tables may refer to values whose encoding was discarded.
+func (e *astEncoder) encode(fn func()) (err error) {
+	n := len(e.buf)
+	defer func() {
+		if err != nil {
+			e.buf = e.buf[:n]
+		}
+	}()
+	defer recoverCodecError(&err)
+	fn()
+	return nil
+}
+
+func (e *astEncoder) fail(format string, args ...any) {
+	panic(codecError{fmt.Errorf("%w: "+format, append([]any{errNotCacheable}, args...)...)})
+}
+
+func (e *astEncoder) byte(b byte) {
+	e.buf = append(e.buf, b)
+}
+
+func (e *astEncoder) bool(b bool) {
+	if b {
+		e.byte(1)
+	} else {
+		e.byte(0)
+	}
+}
+
+func (e *astEncoder) uint(n uint64) {
+	e.buf = binary.AppendUvarint(e.buf, n)
+}
+
+func (e *astEncoder) int(n int64) {
+	e.buf = binary.AppendVarint(e.buf, n)
+}
+
+func (e *astEncoder) string(s string) {
+	if i, ok := e.strings[s]; ok {
+		e.uint(uint64(i) + 1)
+		return
+	}
+	e.strings[s] = len(e.strings)
+	e.uint(0)
+	e.uint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *astEncoder) symbol(sym *lang.Symbol) {
+	if sym == nil {
+		e.value(nil)
+		return
+	}
+	e.value(sym)
+}
+
+func (e *astEncoder) keyword(kw lang.Keyword) {
+	e.string(kw.String()[1:])
+}
+
+// value writes v, which must be data or a var.
+func (e *astEncoder) value(v any) {
+	switch v.(type) {
+	case *lang.Symbol, *lang.List, *lang.EmptyList, *lang.Cons, lang.ISeq,
+		lang.IPersistentVector, lang.IPersistentMap, lang.IPersistentSet,
+		*lang.BigInt, *lang.Ratio, *lang.BigDecimal, *regexp.Regexp:
+		// Values are numbered as the decoder numbers them, after their
+		// contents; only pointers can be found again.
+		pointer := reflect.ValueOf(v).Kind() == reflect.Pointer
+		if pointer {
+			if i, ok := e.values[v]; ok {
+				e.byte(valRef)
+				e.uint(uint64(i))
+				return
+			}
+		}
+		defer func() {
+			if pointer {
+				e.values[v] = e.nvalues
+			}
+			e.nvalues++
+		}()
+	}
+
+	switch v := v.(type) {
+	case nil:
+		e.byte(valNil)
+	case bool:
+		if v {
+			e.byte(valTrue)
+		} else {
+			e.byte(valFalse)
+		}
+	case int64:
+		e.byte(valInt64)
+		e.int(v)
+	case int:
+		e.byte(valInt)
+		e.int(int64(v))
+	case float64:
+		e.byte(valFloat64)
+		e.uint(math.Float64bits(v))
+	case string:
+		e.byte(valString)
+		e.string(v)
+	case lang.Char:
+		e.byte(valChar)
+		e.int(int64(v))
+	case lang.Keyword:
+		e.byte(valKeyword)
+		e.keyword(v)
+	case *lang.Symbol:
+		if !symbolRoundTrips(v) {
+			e.fail("symbol %q", v.String())
+		}
+		e.byte(valSymbol)
+		e.string(v.String())
+		e.meta(v.Meta())
+	case *lang.Var:
+		e.byte(valVar)
+		e.string(v.Namespace().Name().Name())
+		e.string(v.Symbol().Name())
+	case *lang.BigInt:
+		e.byte(valBigInt)
+		e.string(v.String())
+	case *lang.Ratio:
+		e.byte(valRatio)
+		e.string(v.Numerator().String())
+		e.string(v.Denominator().String())
+	case *lang.BigDecimal:
+		e.byte(valBigDecimal)
+		e.string(v.String())
+	case *regexp.Regexp:
+		e.byte(valRegexp)
+		e.string(v.String())
+	case *lang.Class:
+		// Classes, like the types below, are stored by the name they
+		// are exported under, such as those type hints evaluate to.
+		if c, ok := pkgmap.Get(v.JavaName); !ok || c != v {
+			e.fail("class %s", v.JavaName)
+		}
+		e.byte(valClass)
+		e.string(v.JavaName)
+	case reflect.Type:
+		name, ok := typeName(v)
+		if !ok {
+			e.fail("type %s", v)
+		}
+		e.byte(valType)
+		e.string(name)
+	case *lang.Cons:
+		e.byte(valCons)
+		e.value(v.First())
+		e.value(v.More())
+		e.meta(v.Meta())
+	case lang.IRecord:
+		e.fail("%T", v)
+	case lang.Sorted:
+		// Only the default order, such as case uses, can be rebuilt.
+		if v.Comparator() != nil {
+			e.fail("%T with comparator", v)
+		}
+		switch v := v.(type) {
+		case lang.IPersistentMap:
+			e.byte(valSortedMap)
+			e.uint(uint64(v.Count()))
+			for s := lang.Seq(v); s != nil; s = s.Next() {
+				entry := s.First().(lang.IMapEntry)
+				e.value(entry.Key())
+				e.value(entry.Val())
+			}
+		case lang.IPersistentSet:
+			e.byte(valSortedSet)
+			e.uint(uint64(v.Count()))
+			for s := lang.Seq(v); s != nil; s = s.Next() {
+				e.value(s.First())
+			}
+		default:
+			e.fail("%T", v)
+		}
+		e.collMeta(v)
+	case lang.IPersistentVector:
+		e.byte(valVector)
+		e.uint(uint64(v.Count()))
+		for i := 0; i < v.Count(); i++ {
+			e.value(v.Nth(i))
+		}
+		e.collMeta(v)
+	case lang.IPersistentMap:
+		e.byte(valMap)
+		e.uint(uint64(v.Count()))
+		for s := lang.Seq(v); s != nil; s = s.Next() {
+			entry := s.First().(lang.IMapEntry)
+			e.value(entry.Key())
+			e.value(entry.Val())
+		}
+		e.collMeta(v)
+	case lang.IPersistentSet:
+		e.byte(valSet)
+		e.uint(uint64(v.Count()))
+		for s := lang.Seq(v); s != nil; s = s.Next() {
+			e.value(s.First())
+		}
+		e.collMeta(v)
+	case *lang.EmptyList, lang.ISeq:
+		// Seqs other than conses, such as those macros return, are
+		// stored as lists.
+		var items []any
+		for s := lang.Seq(v); s != nil; s = s.Next() {
+			items = append(items, s.First())
+		}
+		e.byte(valList)
+		e.uint(uint64(len(items)))
+		for _, item := range items {
+			e.value(item)
+		}
+		e.collMeta(v)
+	default:
+		e.fail("%T", v)
+	}
+}
+
+// typeName returns the name t is exported under, if any.
+func typeName(t reflect.Type) (string, bool) {
+	if t.PkgPath() == "" {
+		name := t.String()
+		return name, lang.BuiltinTypes[name] == t
+	}
+	name := t.PkgPath() + "." + t.Name()
+	v, ok := pkgmap.Get(name)
+	return name, ok && v == t
+}
+
+// symbolRoundTrips reports whether sym is the symbol its string reads
+// as, which is not the case for some made by clojure.core/symbol.
+func symbolRoundTrips(sym *lang.Symbol) bool {
+	ns, name := sym.Namespace(), sym.Name()
+	if ns == "" {
+		return !sym.HasNamespace() && (name == "/" || !strings.Contains(name, "/"))
+	}
+	return !strings.Contains(ns, "/")
+}
+
+func (e *astEncoder) collMeta(v any) {
+	var meta lang.IPersistentMap
+	if obj, ok := v.(lang.IMeta); ok {
+		meta = obj.Meta()
+	}
+	e.meta(meta)
+}
+
+func (e *astEncoder) meta(meta lang.IPersistentMap) {
+	if meta == nil {
+		e.value(nil)
+		return
+	}
+	e.value(meta)
+}
+
+func (e *astEncoder) env(env lang.IPersistentMap) {
+	if env == nil {
+		e.value(nil)
+		return
+	}
+	stored, ok := e.envs[env]
+	if !ok {
+		// Locals are found by the nodes that refer to them.
+		stored = lang.Dissoc(lang.Dissoc(env, lang.KWLocals), lang.KWLoopLocals).(lang.IPersistentMap)
+		e.envs[env] = stored
+	}
+	e.value(stored)
+}
+
+func (e *astEncoder) nodeList(nodes []*ast.Node) {
+	e.uint(uint64(len(nodes)))
+	for _, n := range nodes {
+		e.node(n)
+	}
+}
+
+func (e *astEncoder) node(n *ast.Node) {
+	if n == nil {
+		e.byte(nodeNil)
+		return
+	}
+	if i, ok := e.nodes[n]; ok {
+		e.byte(nodeRef)
+		e.uint(uint64(i))
+		return
+	}
+	id := len(e.nodes)
+	e.nodes[n] = id
+	e.byte(nodeNew)
+	e.op(n.Op)
+	e.bool(n.IsLiteral)
+	e.bool(n.IsAssignable)
+	e.value(n.Form)
+	e.uint(uint64(len(n.RawForms)))
+	for _, form := range n.RawForms {
+		e.value(form)
+	}
+	e.env(n.Env)
+	e.sub(id, n)
+}
+
+// op writes op. The ops of extensions are allocated as they are
+// registered, so they are written by name.
+func (e *astEncoder) op(op ast.NodeOp) {
+	if ast.IsExtensionOp(op) {
+		e.bool(true)
+		e.string(ast.OpName(op))
+		return
+	}
+	e.bool(false)
+	e.uint(uint64(op))
+}
+
+// sub writes the Op-specific struct of n. Nodes that bind locals are
+// written before the nodes that may refer to them.
+func (e *astEncoder) sub(id int, n *ast.Node) {
+	switch sub := n.Sub.(type) {
+	case nil:
+		e.byte(0)
+	case *ast.LocalNode:
+		e.byte(1)
+		e.symbol(sub.Name)
+		e.keyword(sub.Local)
+		e.int(int64(sub.ArgID))
+		e.bool(sub.IsVariadic)
+		if sub.Binding == nil {
+			e.uint(0)
+		} else if bid, ok := e.bindings[sub.Binding]; ok {
+			e.uint(uint64(bid) + 1)
+		} else {
+			e.fail("local %s outside its binding", sub.Name)
+		}
+	case *ast.VarNode:
+		e.byte(2)
+		e.value(sub.Var)
+	case *ast.ConstNode:
+		e.byte(3)
+		e.keyword(sub.Type)
+		e.constValue(n, sub)
+		e.node(sub.Meta)
+		e.symbol(sub.HostSymbol)
+	case *ast.GoBuiltinNode:
+		e.byte(4)
+		e.symbol(sub.Sym)
+	case *ast.GoNode:
+		e.byte(5)
+		e.node(sub.Invoke)
+	case *ast.MaybeHostFormNode:
+		e.byte(6)
+		e.string(sub.Class)
+		e.symbol(sub.Field)
+	case *ast.MaybeClassNode:
+		e.byte(7)
+		e.value(sub.Class)
+	case *ast.VectorNode:
+		e.byte(8)
+		e.nodeList(sub.Items)
+	case *ast.MapNode:
+		e.byte(9)
+		e.nodeList(sub.Keys)
+		e.nodeList(sub.Vals)
+	case *ast.SetNode:
+		e.byte(10)
+		e.nodeList(sub.Items)
+	case *ast.DoNode:
+		e.byte(11)
+		e.nodeList(sub.Statements)
+		e.node(sub.Ret)
+		e.bool(sub.IsBody)
+	case *ast.LetNode:
+		e.byte(12)
+		e.nodeList(sub.Bindings)
+		e.node(sub.Body)
+		e.symbol(sub.LoopID)
+	case *ast.BindingNode:
+		e.byte(13)
+		e.bindings[sub] = id
+		e.symbol(sub.Name)
+		e.keyword(sub.Local)
+		e.int(int64(sub.ArgID))
+		e.bool(sub.IsVariadic)
+		e.node(sub.Init)
+	case *ast.InvokeNode:
+		e.byte(14)
+		e.meta(sub.Meta)
+		e.node(sub.Fn)
+		e.nodeList(sub.Args)
+	case *ast.KeywordLookupNode:
+		e.byte(15)
+		e.meta(sub.Meta)
+		e.keyword(sub.Keyword)
+		e.node(sub.Target)
+		e.node(sub.Default)
+	case *ast.AssocNode:
+		e.byte(16)
+		e.meta(sub.Meta)
+		e.node(sub.Target)
+		e.uint(uint64(len(sub.Entries)))
+		for _, entry := range sub.Entries {
+			e.node(entry.Key)
+			e.node(entry.Val)
+		}
+	case *ast.ReplaceLastNode:
+		e.byte(17)
+		e.meta(sub.Meta)
+		e.node(sub.Collection)
+		e.node(sub.Value)
+	case *ast.IfNode:
+		e.byte(18)
+		e.node(sub.Test)
+		e.node(sub.Then)
+		e.node(sub.Else)
+	case *ast.NewNode:
+		e.byte(19)
+		e.node(sub.Class)
+		e.nodeList(sub.Args)
+	case *ast.QuoteNode:
+		e.byte(20)
+		e.node(sub.Expr)
+	case *ast.SetBangNode:
+		e.byte(21)
+		e.node(sub.Target)
+		e.node(sub.Val)
+	case *ast.TryNode:
+		e.byte(22)
+		e.node(sub.Body)
+		e.nodeList(sub.Catches)
+		e.node(sub.Finally)
+	case *ast.CatchNode:
+		e.byte(23)
+		e.node(sub.Class)
+		e.node(sub.Local)
+		e.node(sub.Body)
+	case *ast.ThrowNode:
+		e.byte(24)
+		e.node(sub.Exception)
+	case *ast.DefNode:
+		e.byte(25)
+		e.symbol(sub.Name)
+		e.value(sub.Var)
+		e.node(sub.Meta)
+		e.node(sub.Init)
+		e.value(sub.Doc)
+	case *ast.HostCallNode:
+		e.byte(26)
+		e.node(sub.Target)
+		e.symbol(sub.Method)
+		e.nodeList(sub.Args)
+	case *ast.HostFieldNode:
+		e.byte(27)
+		e.node(sub.Target)
+		e.symbol(sub.Field)
+	case *ast.HostInteropNode:
+		e.byte(28)
+		e.node(sub.Target)
+		e.symbol(sub.MOrF)
+	case *ast.LetFnNode:
+		e.byte(29)
+		e.nodeList(sub.Bindings)
+		e.node(sub.Body)
+	case *ast.RecurNode:
+		e.byte(30)
+		e.nodeList(sub.Exprs)
+		e.symbol(sub.LoopID)
+	case *ast.FnNode:
+		e.byte(31)
+		e.bool(sub.IsVariadic)
+		e.int(int64(sub.MaxFixedArity))
+		e.bool(sub.Once)
+		e.node(sub.Local)
+		e.nodeList(sub.Methods)
+	case *ast.FnMethodNode:
+		e.byte(32)
+		e.nodeList(sub.Params)
+		e.int(int64(sub.FixedArity))
+		e.symbol(sub.LoopID)
+		e.bool(sub.IsVariadic)
+		e.node(sub.Body)
+	case *ast.WithMetaNode:
+		e.byte(33)
+		e.node(sub.Expr)
+		e.node(sub.Meta)
+	case *ast.CaseNode:
+		e.byte(34)
+		e.node(sub.Test)
+		e.int(sub.Shift)
+		e.int(sub.Mask)
+		e.value(sub.TestType)
+		e.value(sub.SwitchType)
+		e.node(sub.Default)
+		e.uint(uint64(len(sub.Entries)))
+		for _, entry := range sub.Entries {
+			e.int(entry.Key)
+			e.node(entry.TestConstant)
+			e.node(entry.ResultExpr)
+			e.bool(entry.HasCollision)
+		}
+		keys := make([]int64, 0, len(sub.SkipCheck))
+		for key, skip := range sub.SkipCheck {
+			if skip {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		e.uint(uint64(len(keys)))
+		for _, key := range keys {
+			e.int(key)
+		}
+	case *ast.TheVarNode:
+		e.byte(35)
+		e.value(sub.Var)
+	case *ast.ExtensionNode:
+		e.byte(36)
+		e.nodeList(sub.Args)
+		e.value(sub.Data)
+	default:
+		e.fail("node %T", sub)
+	}
+}
+
+// constValue writes the value of a constant. Values that host symbols or
+// the mappings of the namespace resolved to are resolved again when they
+// are decoded.
+func (e *astEncoder) constValue(n *ast.Node, sub *ast.ConstNode) {
+	if sub.HostSymbol != nil {
+		e.byte(1)
+		return
+	}
+	if sym, ok := n.Form.(*lang.Symbol); ok && sym.Namespace() == "" && !isData(sub.Value) {
+		e.byte(2)
+		return
+	}
+	e.byte(0)
+	e.value(sub.Value)
+}
+
+// isData reports whether v is a value the encoder stores as it is,
+// rather than a host value a symbol resolved to.
+func isData(v any) bool {
+	switch v.(type) {
+	case nil, bool, int64, int, float64, string, lang.Char, lang.Keyword,
+		*lang.Symbol, *lang.BigInt, *lang.Ratio, *lang.BigDecimal,
+		*regexp.Regexp, lang.IPersistentCollection:
+		return true
+	}
+	return false
+}
+
+type astDecoder struct {
+	buf     []byte
+	pos     int
+	strings []string
+	values  []any
+	nodes   []*ast.Node
+}
+
+func newASTDecoder(buf []byte) *astDecoder {
+	return &astDecoder{buf: buf}
+}
+
+// decode calls fn to read from the decoder, returning an error if the
+// data is corrupt or stale.
+func (d *astDecoder) decode(fn func()) (err error) {
+	defer recoverCodecError(&err)
+	fn()
+	return nil
+}
+
+func (d *astDecoder) fail(format string, args ...any) {
+	panic(codecError{fmt.Errorf("%w: "+format, append([]any{errStaleCache}, args...)...)})
+}
+
+func (d *astDecoder) done() bool {
+	return d.pos >= len(d.buf)
+}
+
+func (d *astDecoder) byte() byte {
+	if d.pos >= len(d.buf) {
+		d.fail("truncated")
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *astDecoder) bool() bool {
+	return d.byte() != 0
+}
+
+func (d *astDecoder) uint() uint64 {
+	n, size := binary.Uvarint(d.buf[d.pos:])
+	if size <= 0 {
+		d.fail("bad uvarint")
+	}
+	d.pos += size
+	return n
+}
+
+func (d *astDecoder) int() int64 {
+	n, size := binary.Varint(d.buf[d.pos:])
+	if size <= 0 {
+		d.fail("bad varint")
+	}
+	d.pos += size
+	return n
+}
+
+// len reads a count, which cannot exceed the bytes left to read.
+func (d *astDecoder) len() int {
+	n := d.uint()
+	if n > uint64(len(d.buf)-d.pos) {
+		d.fail("bad length")
+	}
+	return int(n)
+}
+
+func (d *astDecoder) string() string {
+	i := d.uint()
+	if i > 0 {
+		if i > uint64(len(d.strings)) {
+			d.fail("bad string index")
+		}
+		return d.strings[i-1]
+	}
+	n := d.len()
+	s := string(d.buf[d.pos : d.pos+n])
+	d.pos += n
+	d.strings = append(d.strings, s)
+	return s
+}
+
+func (d *astDecoder) symbol() *lang.Symbol {
+	v := d.value()
+	if v == nil {
+		return nil
+	}
+	sym, ok := v.(*lang.Symbol)
+	if !ok {
+		d.fail("want symbol, got %T", v)
+	}
+	return sym
+}
+
+func (d *astDecoder) keyword() lang.Keyword {
+	return lang.NewKeyword(d.string())
+}
+
+func (d *astDecoder) meta() lang.IPersistentMap {
+	v := d.value()
+	if v == nil {
+		return nil
+	}
+	meta, ok := v.(lang.IPersistentMap)
+	if !ok {
+		d.fail("want map, got %T", v)
+	}
+	return meta
+}
+
+func (d *astDecoder) withMeta(v any) any {
+	meta := d.meta()
+	if meta == nil {
+		return v
+	}
+	return v.(lang.IObj).WithMeta(meta)
+}
+
+func (d *astDecoder) items() []any {
+	items := make([]any, d.len())
+	for i := range items {
+		items[i] = d.value()
+	}
+	return items
+}
+
+func (d *astDecoder) value() any {
+	var v any
+	switch tag := d.byte(); tag {
+	case valNil:
+		return nil
+	case valTrue:
+		return true
+	case valFalse:
+		return false
+	case valInt64:
+		return d.int()
+	case valInt:
+		return int(d.int())
+	case valFloat64:
+		return math.Float64frombits(d.uint())
+	case valString:
+		return d.string()
+	case valChar:
+		return lang.Char(d.int())
+	case valKeyword:
+		return d.keyword()
+	case valVar:
+		ns, name := d.string(), d.string()
+		return d.findVar(ns, name)
+	case valType:
+		name := d.string()
+		if t, ok := lang.BuiltinTypes[name]; ok {
+			return t
+		}
+		if t, ok := pkgmap.Get(name); ok {
+			if t, ok := t.(reflect.Type); ok {
+				return t
+			}
+		}
+		d.fail("type %s", name)
+	case valClass:
+		name := d.string()
+		c, ok := pkgmap.Get(name)
+		if !ok {
+			d.fail("class %s", name)
+		}
+		if _, ok := c.(*lang.Class); !ok {
+			d.fail("class %s", name)
+		}
+		return c
+	case valRef:
+		i := d.uint()
+		if i >= uint64(len(d.values)) {
+			d.fail("bad value index")
+		}
+		return d.values[i]
+	case valSymbol:
+		v = d.withMeta(lang.NewSymbolUnchecked(d.string()))
+	case valBigInt:
+		n, err := lang.NewBigInt(d.string())
+		if err != nil {
+			d.fail("%v", err)
+		}
+		v = n
+	case valRatio:
+		num, err := lang.NewBigInt(d.string())
+		if err != nil {
+			d.fail("%v", err)
+		}
+		den, err := lang.NewBigInt(d.string())
+		if err != nil {
+			d.fail("%v", err)
+		}
+		v = lang.NewRatioBigInt(num, den)
+	case valBigDecimal:
+		n, err := lang.NewBigDecimal(d.string())
+		if err != nil {
+			d.fail("%v", err)
+		}
+		v = n
+	case valRegexp:
+		re, err := regexp.Compile(d.string())
+		if err != nil {
+			d.fail("%v", err)
+		}
+		v = re
+	case valCons:
+		first := d.value()
+		v = d.withMeta(lang.NewCons(first, d.value()))
+	case valVector:
+		v = d.withMeta(lang.NewVector(d.items()...))
+	case valMap:
+		n := d.len()
+		kvs := make([]any, 0, 2*n)
+		for i := 0; i < n; i++ {
+			kvs = append(kvs, d.value(), d.value())
+		}
+		v = d.withMeta(lang.NewMap(kvs...))
+	case valSet:
+		v = d.withMeta(lang.NewSet(d.items()...))
+	case valSortedMap:
+		n := d.len()
+		kvs := make([]any, 0, 2*n)
+		for i := 0; i < n; i++ {
+			kvs = append(kvs, d.value(), d.value())
+		}
+		v = d.withMeta(lang.CreatePersistentTreeMap(lang.NewList(kvs...)))
+	case valSortedSet:
+		v = d.withMeta(lang.CreatePersistentTreeSet(lang.Seq(lang.NewList(d.items()...))))
+	case valList:
+		v = d.withMeta(lang.NewList(d.items()...))
+	default:
+		d.fail("bad value tag %d", tag)
+	}
+	d.values = append(d.values, v)
+	return v
+}
+
+func (d *astDecoder) findVar(ns, name string) *lang.Var {
+	namespace := lang.FindNamespace(lang.NewSymbol(ns))
+	if namespace == nil {
+		d.fail("no namespace %s", ns)
+	}
+	vr := namespace.FindInternedVar(lang.NewSymbol(name))
+	if vr == nil {
+		d.fail("no var %s/%s", ns, name)
+	}
+	return vr
+}
+
+// defVar reads the var of a def, which analyzing the def interned.
+func (d *astDecoder) defVar() *lang.Var {
+	if d.byte() != valVar {
+		d.fail("want var")
+	}
+	ns, name := d.string(), d.string()
+	namespace := lang.FindNamespace(lang.NewSymbol(ns))
+	if namespace == nil {
+		d.fail("no namespace %s", ns)
+	}
+	return namespace.Intern(lang.NewSymbol(name))
+}
+
+func (d *astDecoder) varRef() *lang.Var {
+	vr, ok := d.value().(*lang.Var)
+	if !ok {
+		d.fail("want var")
+	}
+	return vr
+}
+
+func (d *astDecoder) nodeList() []*ast.Node {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]*ast.Node, n)
+	for i := range nodes {
+		nodes[i] = d.node()
+	}
+	return nodes
+}
+
+func (d *astDecoder) node() *ast.Node {
+	switch tag := d.byte(); tag {
+	case nodeNil:
+		return nil
+	case nodeRef:
+		i := d.uint()
+		if i >= uint64(len(d.nodes)) {
+			d.fail("bad node index")
+		}
+		return d.nodes[i]
+	case nodeNew:
+	default:
+		d.fail("bad node tag %d", tag)
+	}
+	n := &ast.Node{}
+	d.nodes = append(d.nodes, n)
+	n.Op = d.op()
+	n.IsLiteral = d.bool()
+	n.IsAssignable = d.bool()
+	n.Form = d.value()
+	if count := d.len(); count > 0 {
+		n.RawForms = make([]any, count)
+		for i := range n.RawForms {
+			n.RawForms[i] = d.value()
+		}
+	}
+	n.Env = d.meta()
+	d.sub(n)
+	return n
+}
+
+func (d *astDecoder) op() ast.NodeOp {
+	if !d.bool() {
+		return ast.NodeOp(d.uint())
+	}
+	name := d.string()
+	op, ok := ast.LookupOp(name)
+	if !ok {
+		d.fail("op %s is not registered", name)
+	}
+	return op
+}
+
+func (d *astDecoder) sub(n *ast.Node) {
+	switch kind := d.byte(); kind {
+	case 0:
+	case 1:
+		sub := &ast.LocalNode{
+			Name:       d.symbol(),
+			Local:      d.keyword(),
+			ArgID:      int(d.int()),
+			IsVariadic: d.bool(),
+		}
+		if i := d.uint(); i > 0 {
+			if i > uint64(len(d.nodes)) {
+				d.fail("bad binding index")
+			}
+			binding, ok := d.nodes[i-1].Sub.(*ast.BindingNode)
+			if !ok {
+				d.fail("local %s outside its binding", sub.Name)
+			}
+			sub.Binding = binding
+		}
+		n.Sub = sub
+	case 2:
+		vr := d.varRef()
+		n.Sub = &ast.VarNode{Var: vr, Meta: vr.Meta()}
+	case 3:
+		sub := &ast.ConstNode{Type: d.keyword()}
+		resolve := d.byte()
+		if resolve == 0 {
+			sub.Value = d.value()
+		}
+		sub.Meta = d.node()
+		sub.HostSymbol = d.symbol()
+		switch resolve {
+		case 1:
+			value, ok := resolveHost(sub.HostSymbol)
+			if !ok {
+				d.fail("unresolved host symbol %s", sub.HostSymbol)
+			}
+			sub.Value = value
+		case 2:
+			sub.Value = d.resolveMapping(n)
+		}
+		n.Sub = sub
+	case 4:
+		sym := d.symbol()
+		value, ok := lang.Builtins[sym.Name()]
+		if !ok {
+			d.fail("unknown go builtin %s", sym)
+		}
+		n.Sub = &ast.GoBuiltinNode{Sym: sym, Value: value}
+	case 5:
+		n.Sub = &ast.GoNode{Invoke: d.node()}
+	case 6:
+		n.Sub = &ast.MaybeHostFormNode{Class: d.string(), Field: d.symbol()}
+	case 7:
+		n.Sub = &ast.MaybeClassNode{Class: d.value()}
+	case 8:
+		n.Sub = &ast.VectorNode{Items: d.nodeList()}
+	case 9:
+		n.Sub = &ast.MapNode{Keys: d.nodeList(), Vals: d.nodeList()}
+	case 10:
+		n.Sub = &ast.SetNode{Items: d.nodeList()}
+	case 11:
+		n.Sub = &ast.DoNode{Statements: d.nodeList(), Ret: d.node(), IsBody: d.bool()}
+	case 12:
+		n.Sub = &ast.LetNode{Bindings: d.nodeList(), Body: d.node(), LoopID: d.symbol()}
+	case 13:
+		// The node is reachable, from locals in its init, before its
+		// init is decoded.
+		sub := &ast.BindingNode{}
+		n.Sub = sub
+		sub.Name = d.symbol()
+		sub.Local = d.keyword()
+		sub.ArgID = int(d.int())
+		sub.IsVariadic = d.bool()
+		sub.Init = d.node()
+	case 14:
+		n.Sub = &ast.InvokeNode{Meta: d.meta(), Fn: d.node(), Args: d.nodeList()}
+	case 15:
+		n.Sub = &ast.KeywordLookupNode{Meta: d.meta(), Keyword: d.keyword(), Target: d.node(), Default: d.node()}
+	case 16:
+		sub := &ast.AssocNode{Meta: d.meta(), Target: d.node()}
+		sub.Entries = make([]ast.AssocEntry, d.len())
+		for i := range sub.Entries {
+			sub.Entries[i] = ast.AssocEntry{Key: d.node(), Val: d.node()}
+		}
+		n.Sub = sub
+	case 17:
+		n.Sub = &ast.ReplaceLastNode{Meta: d.meta(), Collection: d.node(), Value: d.node()}
+	case 18:
+		n.Sub = &ast.IfNode{Test: d.node(), Then: d.node(), Else: d.node()}
+	case 19:
+		n.Sub = &ast.NewNode{Class: d.node(), Args: d.nodeList()}
+	case 20:
+		n.Sub = &ast.QuoteNode{Expr: d.node()}
+	case 21:
+		n.Sub = &ast.SetBangNode{Target: d.node(), Val: d.node()}
+	case 22:
+		n.Sub = &ast.TryNode{Body: d.node(), Catches: d.nodeList(), Finally: d.node()}
+	case 23:
+		n.Sub = &ast.CatchNode{Class: d.node(), Local: d.node(), Body: d.node()}
+	case 24:
+		n.Sub = &ast.ThrowNode{Exception: d.node()}
+	case 25:
+		n.Sub = &ast.DefNode{Name: d.symbol(), Var: d.defVar(), Meta: d.node(), Init: d.node(), Doc: d.value()}
+	case 26:
+		sub := &ast.HostCallNode{Target: d.node(), Method: d.symbol(), Args: d.nodeList()}
+		// As in analysis, methods of constant targets are resolved
+		// ahead of the call.
+		if sub.Target.Op == ast.OpConst {
+			sub.ResolvedMethod, _ = lang.FieldOrMethod(sub.Target.Sub.(*ast.ConstNode).Value, sub.Method.Name())
+		}
+		// The types of targets are inferred again, as they are not all
+		// types the cache can name.
+		sub.TargetType = compiler.InferType(sub.Target, resolveHost)
+		n.Sub = sub
+	case 27:
+		sub := &ast.HostFieldNode{Target: d.node(), Field: d.symbol()}
+		sub.TargetType = compiler.InferType(sub.Target, resolveHost)
+		n.Sub = sub
+	case 28:
+		sub := &ast.HostInteropNode{Target: d.node(), MOrF: d.symbol()}
+		sub.TargetType = compiler.InferType(sub.Target, resolveHost)
+		n.Sub = sub
+	case 29:
+		n.Sub = &ast.LetFnNode{Bindings: d.nodeList(), Body: d.node()}
+	case 30:
+		n.Sub = &ast.RecurNode{Exprs: d.nodeList(), LoopID: d.symbol()}
+	case 31:
+		n.Sub = &ast.FnNode{IsVariadic: d.bool(), MaxFixedArity: int(d.int()), Once: d.bool(), Local: d.node(), Methods: d.nodeList()}
+	case 32:
+		n.Sub = &ast.FnMethodNode{Params: d.nodeList(), FixedArity: int(d.int()), LoopID: d.symbol(), IsVariadic: d.bool(), Body: d.node()}
+	case 33:
+		n.Sub = &ast.WithMetaNode{Expr: d.node(), Meta: d.node()}
+	case 34:
+		sub := &ast.CaseNode{Test: d.node(), Shift: d.int(), Mask: d.int(), TestType: d.value(), SwitchType: d.value(), Default: d.node()}
+		sub.Entries = make([]ast.CaseEntry, d.len())
+		for i := range sub.Entries {
+			sub.Entries[i] = ast.CaseEntry{Key: d.int(), TestConstant: d.node(), ResultExpr: d.node(), HasCollision: d.bool()}
+		}
+		sub.SkipCheck = map[int64]bool{}
+		for i := d.len(); i > 0; i-- {
+			sub.SkipCheck[d.int()] = true
+		}
+		n.Sub = sub
+	case 35:
+		n.Sub = &ast.TheVarNode{Var: d.varRef()}
+	case 36:
+		n.Sub = &ast.ExtensionNode{Args: d.nodeList(), Data: d.value()}
+	default:
+		d.fail("bad node kind %d", kind)
+	}
+}
+
+// resolveMapping resolves the symbol form of the constant n in the
+// namespace it was analyzed in, as analysis did.
+func (d *astDecoder) resolveMapping(n *ast.Node) any {
+	sym, _ := n.Form.(*lang.Symbol)
+	nsSym, _ := lang.Get(n.Env, lang.KWNS).(*lang.Symbol)
+	if sym == nil || nsSym == nil {
+		d.fail("constant without a symbol")
+	}
+	ns := lang.FindNamespace(nsSym)
+	if ns == nil {
+		d.fail("no namespace %s", nsSym)
+	}
+	value := ns.GetMapping(sym)
+	if value == nil {
+		d.fail("unresolved symbol %s", sym)
+	}
+	if _, ok := value.(*lang.Var); ok {
+		d.fail("symbol %s resolves to a var", sym)
+	}
+	return value
+}
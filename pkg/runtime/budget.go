@@ -0,0 +1,177 @@
+package runtime
+
+import (
+	"fmt"
+	"runtime/metrics"
+	"sync/atomic"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// A Budget limits the resources an evaluation may use. Zero fields are
+// unlimited. An evaluation that exceeds its budget fails with a
+// *BudgetExceededError at its next check: evaluated code checks its
+// budget on each loop iteration and each call of an evaluated function,
+// as it does for an Interrupter, and on each realization of a lazy seq.
+// Go code that it calls, such as AOT-compiled functions or blocking host
+// calls, runs to completion before the budget takes effect.
+type Budget struct {
+	// Steps limits the loop iterations and evaluated function calls.
+	Steps int64
+	// LazyElements limits the lazy seq elements realized.
+	LazyElements int64
+	// Timeout limits the wall-clock time.
+	Timeout time.Duration
+	// Memory is a soft limit on the bytes of the process's heap in
+	// use, checked every few thousand steps. Heap is not attributed to
+	// evaluations, so every evaluation running when the process exceeds
+	// it fails.
+	Memory uint64
+}
+
+// Budget resources, as reported by BudgetExceededError.
+const (
+	BudgetSteps        = "steps"
+	BudgetLazyElements = "lazy-elements"
+	BudgetTime         = "time"
+	BudgetMemory       = "memory"
+)
+
+// A BudgetExceededError is the error an evaluation fails with when it
+// exceeds its budget. Its ex-data is a map with the keys :type,
+// :glojure/budget-exceeded, :resource, the resource exceeded as a
+// keyword, and :limit.
+type BudgetExceededError struct {
+	Resource string
+	Limit    any
+}
+
+var (
+	kwType           = lang.NewKeyword("type")
+	kwBudgetExceeded = lang.NewKeyword("glojure/budget-exceeded")
+	kwResource       = lang.NewKeyword("resource")
+	kwLimit          = lang.NewKeyword("limit")
+)
+
+var _ lang.IExceptionInfo = (*BudgetExceededError)(nil)
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("evaluation budget exceeded: %s limit of %v", e.Resource, e.Limit)
+}
+
+func (e *BudgetExceededError) GetData() lang.IPersistentMap {
+	return lang.NewMap(
+		kwType, kwBudgetExceeded,
+		kwResource, lang.NewKeyword(e.Resource),
+		kwLimit, e.Limit,
+	)
+}
+
+// memoryCheckSteps is the number of steps between checks of the heap.
+const memoryCheckSteps = 4096
+
+var (
+	// varBudget holds the *budgetState of the evaluation running on a
+	// goroutine. It is dynamic so that futures and agents charge the
+	// budget of the evaluation that starts them, and not interned, so
+	// that code cannot rebind it.
+	varBudget = lang.NewVarWithRoot(lang.NSCore, lang.NewSymbol("*budget*"), nil).SetDynamic()
+	// budgetsRunning counts the evaluations running under a budget, so
+	// that checks are a single load while there are none.
+	budgetsRunning atomic.Int32
+)
+
+func init() {
+	lang.SetLazySeqRealizeHook(chargeLazyElements)
+}
+
+type budgetState struct {
+	budget       Budget
+	steps        atomic.Int64
+	lazyElements atomic.Int64
+	expired      atomic.Bool
+	exceeded     atomic.Pointer[BudgetExceededError]
+}
+
+// Run calls fn on the current goroutine under a new allowance of the
+// budget. Calls of Run within fn do not start another: the outermost
+// budget applies.
+func (b Budget) Run(fn func()) {
+	if b == (Budget{}) || currentBudget() != nil {
+		fn()
+		return
+	}
+	st := &budgetState{budget: b}
+	if b.Timeout > 0 {
+		timer := time.AfterFunc(b.Timeout, func() { st.expired.Store(true) })
+		defer timer.Stop()
+	}
+	budgetsRunning.Add(1)
+	defer budgetsRunning.Add(-1)
+	lang.PushThreadBindings(lang.NewMap(varBudget, st))
+	defer lang.PopThreadBindings()
+	fn()
+}
+
+func currentBudget() *budgetState {
+	st, _ := varBudget.Deref().(*budgetState)
+	return st
+}
+
+// chargeStep charges a step to the budget of the current goroutine,
+// returning a *BudgetExceededError once it is exceeded.
+func chargeStep() error {
+	st := currentBudget()
+	if st == nil {
+		return nil
+	}
+	if err := st.exceeded.Load(); err != nil {
+		return err
+	}
+	steps := st.steps.Add(1)
+	b := &st.budget
+	switch {
+	case b.Steps > 0 && steps > b.Steps:
+		return st.exceed(BudgetSteps, b.Steps)
+	case st.expired.Load():
+		return st.exceed(BudgetTime, b.Timeout.String())
+	case b.Memory > 0 && steps%memoryCheckSteps == 0 && heapInUse() > b.Memory:
+		return st.exceed(BudgetMemory, b.Memory)
+	}
+	return nil
+}
+
+// chargeLazyElements charges n realized lazy seq elements to the budget
+// of the current goroutine, panicking once it is exceeded.
+func chargeLazyElements(n int) {
+	if budgetsRunning.Load() == 0 {
+		return
+	}
+	st := currentBudget()
+	if st == nil || st.budget.LazyElements <= 0 {
+		return
+	}
+	if st.lazyElements.Add(int64(n)) > st.budget.LazyElements {
+		panic(st.exceed(BudgetLazyElements, st.budget.LazyElements))
+	}
+}
+
+// exceed records that the budget is exceeded, so that every later check
+// fails, even in code that catches the first error.
+func (st *budgetState) exceed(resource string, limit any) *BudgetExceededError {
+	err := &BudgetExceededError{Resource: resource, Limit: limit}
+	if st.exceeded.CompareAndSwap(nil, err) {
+		return err
+	}
+	return st.exceeded.Load()
+}
+
+func heapInUse() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,80 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestBudgetStopsEvaluation(t *testing.T) {
+	env := NewEnvironment().(*environment)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, env.CurrentNamespace()))
+	t.Cleanup(lang.PopThreadBindings)
+
+	for _, tc := range []struct {
+		code     string
+		budget   Budget
+		resource string
+	}{
+		{`(loop [] (recur))`, Budget{Steps: 10000}, BudgetSteps},
+		{`((fn spin [n] (spin (inc n))) 0)`, Budget{Steps: 1000}, BudgetSteps},
+		{`(dorun (map inc (range)))`, Budget{LazyElements: 1000}, BudgetLazyElements},
+		{`(loop [i 0] (if (>= i 0) (recur (inc i)) i))`, Budget{Timeout: 20 * time.Millisecond}, BudgetTime},
+		{`(try (loop [] (recur)) (catch go/error e (loop [] (recur))))`, Budget{Steps: 1000}, BudgetSteps},
+		{`@(future (loop [] (recur)))`, Budget{Steps: 1000}, BudgetSteps},
+	} {
+		t.Run(tc.code, func(t *testing.T) {
+			form, err := reader.New(strings.NewReader(tc.code)).ReadOne()
+			if err != nil {
+				t.Fatal(err)
+			}
+			done := make(chan error, 1)
+			go func() {
+				var err error
+				tc.budget.Run(func() {
+					defer func() {
+						if r := recover(); r != nil {
+							err, _ = r.(error)
+						}
+					}()
+					_, err = env.Eval(form)
+				})
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				var budgetErr *BudgetExceededError
+				if !errors.As(err, &budgetErr) || budgetErr.Resource != tc.resource {
+					t.Fatalf("error = %v, want %s budget exceeded", err, tc.resource)
+				}
+				data := lang.GetExData(err)
+				if got := lang.Get(data, kwType); got != kwBudgetExceeded {
+					t.Errorf("ex-data :type = %v", got)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("evaluation did not stop")
+			}
+			if budgetsRunning.Load() != 0 {
+				t.Error("budget outlived Run")
+			}
+		})
+	}
+
+	// Evaluations within the budget are unaffected, and each evaluation
+	// in an environment with a budget gets its own.
+	env.budget = Budget{Steps: 5000}
+	t.Cleanup(func() { env.budget = Budget{} })
+	for i := 0; i < 3; i++ {
+		got, err := env.Eval(lang.NewList(lang.NewSymbol("reduce"), lang.NewSymbol("+"),
+			lang.NewList(lang.NewSymbol("range"), 1000)))
+		if err != nil || !lang.Equals(got, 499500) {
+			t.Fatalf("Eval = %v, %v; want 499500", got, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package runtime
+
+import "io"
+
+// BuildOptions configures Build.
+type BuildOptions struct {
+	// Entry names the namespace whose -main function the executable runs.
+	Entry string
+	// Output is the executable path. It defaults to the first segment of the
+	// entry namespace, in the current directory.
+	Output string
+	// WorkDir receives the generated Go module. When empty, a temporary
+	// directory is used and removed once the build finishes. Loaders left
+	// in WorkDir by an earlier build are reused if their inputs are
+	// unchanged.
+	WorkDir string
+	// Force regenerates every loader, even those that are up to date.
+	Force bool
+	// GlojureDir replaces the Glojure module with a local checkout. When
+	// empty, the released version matching this runtime is required, or the
+	// checkout used by the Go module in the current directory.
+	GlojureDir string
+	// TreeShake omits the initializers of vars the program cannot reach
+	// from the entry namespace's -main, its ^:export functions or Roots,
+	// from every loader linked into the executable. It has no effect if
+	// any namespace is interpreted. The program is also built without
+	// tree shaking, to report the difference in size.
+	TreeShake bool
+	// Roots lists vars, as ns/name, and namespaces that tree shaking keeps
+	// although no compiled code refers to them, such as the targets of
+	// resolve or requiring-resolve.
+	Roots []string
+	// Resources lists directories that are embedded into the executable and
+	// added to its load path.
+	Resources []string
+	// Stdout and Stderr receive progress messages and go tool output. They
+	// default to os.Stdout and os.Stderr.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// BuildReport describes the namespaces linked into an executable by Build.
+type BuildReport struct {
+	// Output is the path of the executable.
+	Output string
+	// Compiled lists the namespaces emitted as AOT loaders, in load order.
+	Compiled []string
+	// Unchanged lists the compiled namespaces whose loaders were reused
+	// from an earlier build in the same work directory.
+	Unchanged []string
+	// Precompiled lists the namespaces served by loaders from existing Go
+	// packages, such as the standard library.
+	Precompiled []string
+	// Interpreted lists the namespaces whose source was embedded because
+	// they could not be compiled.
+	Interpreted []InterpretedNamespace
+	// FullRuntime reports whether the executable links the full runtime
+	// rather than the compact glj_aot_runtime, because some namespaces are
+	// interpreted.
+	FullRuntime bool
+	// TreeShaking describes the omitted var initializers, if the build
+	// was tree shaken.
+	TreeShaking *TreeShakeReport
+}
+
+// TreeShakeReport describes the var initializers that tree shaking omitted
+// from the loaders linked into an executable.
+type TreeShakeReport struct {
+	// Kept and Omitted count the var initializers in the loaders.
+	Kept    int
+	Omitted int
+	// OmittedSourceBytes is the size of the generated Go source omitted
+	// from the loaders.
+	OmittedSourceBytes int
+	// BinaryBytes is the size of the executable, and UnshakenBinaryBytes
+	// the size of the same program built without tree shaking, which
+	// Build builds a second time to measure.
+	BinaryBytes         int64
+	UnshakenBinaryBytes int64
+}
+
+// InterpretedNamespace records a namespace that fell back to the
+// interpreter and the generator error that caused it.
+type InterpretedNamespace struct {
+	Name   string
+	Reason error
+}
@@ -9,6 +9,7 @@ import (
 	"go/format"
 	"go/token"
 	"io"
+	"maps"
 	"math"
 	"net/http"
 	"os"
@@ -163,6 +164,16 @@ type Generator struct {
 	aotNamespace           *lang.Namespace
 	directLink             bool
 
+	// snapshot is set to generate a loader that restores a
+	// NamespaceSnapshot of the namespace's vars. snapshotIndex indexes
+	// snapshotVars, and snapshotMeta and snapshotRoots hold the metadata
+	// and roots it carries.
+	snapshot      bool
+	snapshotVars  []*lang.Var
+	snapshotIndex map[varInfo]int
+	snapshotMeta  map[*lang.Var]string
+	snapshotRoots map[*lang.Var]string
+
 	// Fields for handling closures
 	liftedValues  map[liftedKey]*liftedValue // Dedupe by composite key
 	liftedCounter int                        // Counter for closed0, closed1...
@@ -171,6 +182,12 @@ type Generator struct {
 	// specializationTarget is non-nil only while generating the root function
 	// value for a Var. Nested function literals retain the generic code path.
 	specializationTarget *aotSpecializationTarget
+
+	// Fields for emitting //line directives
+	lineDirectives bool
+	lineSourceRoot string
+	lineStack      []sourcePos
+	lineStates     map[io.Writer]*lineState
 }
 
 var (
@@ -178,6 +195,7 @@ var (
 		// initialized by the runtime
 		"#'clojure.core/*in*":            true,
 		"#'clojure.core/*out*":           true,
+		"#'clojure.core/*err*":           true,
 		"#'clojure.core/*compile-files*": true,
 		"#'clojure.core/load-file":       true,
 		"#'clojure.core/add-load-path":   true,
@@ -363,8 +381,15 @@ func (g *Generator) Generate(ns *lang.Namespace) error {
 	sort.Slice(internedVars, func(i, j int) bool {
 		return internedVars[i].name.String() < internedVars[j].name.String()
 	})
+	if g.snapshot {
+		g.prepareSnapshot(internedVars)
+	}
 	g.prepareAOTRecordTypes(internedVars)
 	g.prepareAOTCallTargets(internedVars)
+	exports, err := prepareExports(internedVars)
+	if err != nil {
+		return err
+	}
 	for _, nv := range internedVars {
 		if isRuntimeOwnedVar(nv.vr) {
 			// Skip runtime-owned vars
@@ -444,14 +469,14 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 `)
 	initBuf.WriteString(`func checkArity(args []any, expected int) {
   if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
   }
 }
 
 `)
 	initBuf.WriteString(`func checkArityGTE(args []any, min int) {
   if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
   }
 }
 
@@ -459,6 +484,19 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 	initBuf.WriteString(fmt.Sprintf("// LoadNS initializes the namespace %q\n", ns.Name().String()))
 	initBuf.WriteString("func LoadNS() {\n")
 
+	// A snapshot loader restores its vars, symbols and keywords first,
+	// interning any other vars it refers to by name.
+	var snapshotSrc []byte
+	if g.snapshot {
+		for vi := range g.varVariables {
+			if _, ok := g.snapshotIndex[vi]; !ok {
+				g.allocSymVar(vi.ns)
+				g.allocSymVar(vi.sym)
+			}
+		}
+		snapshotSrc = g.generateSnapshot(ns, &initBuf)
+	}
+
 	//////////////////////////
 	// Symbols
 	var symbolNames []string
@@ -466,8 +504,12 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 		symbolNames = append(symbolNames, sym)
 	}
 	sort.Strings(symbolNames) // Sort for deterministic output
-	for _, sym := range symbolNames {
+	for i, sym := range symbolNames {
 		varName := g.symbolVariables[sym]
+		if g.snapshot {
+			initBuf.WriteString(fmt.Sprintf("%s := restored.Symbols[%d]\n", varName, i))
+			continue
+		}
 		initBuf.WriteString(fmt.Sprintf("%s := lang.NewSymbolUnchecked(%q)\n", varName, sym))
 	}
 
@@ -478,8 +520,12 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 		kwNames = append(kwNames, kw)
 	}
 	sort.Strings(kwNames) // Sort for deterministic output
-	for _, kw := range kwNames {
+	for i, kw := range kwNames {
 		varName := g.kwVariables[kw]
+		if g.snapshot {
+			initBuf.WriteString(fmt.Sprintf("%s := restored.Keywords[%d]\n", varName, i))
+			continue
+		}
 		initBuf.WriteString(fmt.Sprintf("%s := lang.NewKeyword(%q)\n", varName, kw))
 	}
 
@@ -494,6 +540,10 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 	sort.Strings(varNames) // Sort for deterministic output
 	for _, varName := range varNames {
 		vi := inverseVarMap[varName]
+		if i, ok := g.snapshotIndex[vi]; ok {
+			initBuf.WriteString(fmt.Sprintf("%s := restored.Vars[%d]\n", varName, i))
+			continue
+		}
 		initBuf.WriteString(fmt.Sprintf("// var %s/%s\n", vi.ns, vi.sym))
 		// NB: the variables will already have been allocated
 		initBuf.WriteString(fmt.Sprintf("%s := lang.InternVarName(%s, %s)\n", varName, g.allocSymVar(vi.ns), g.allocSymVar(vi.sym)))
@@ -539,6 +589,12 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 	/////////////////////////////
 	// Var and closed-over value inits
 
+	// Value inits are reordered below, so each must end in the generated
+	// file's own positions.
+	if g.lineDirectives {
+		g.finishLineDirectives()
+	}
+
 	// NS boilerplate
 	initBuf.Write(nsBuf.Bytes())
 
@@ -610,13 +666,16 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 	initBuf.WriteString("}\n")
 	g.generateAOTKeywordHelpers()
 	g.generateAOTExternalAdapters()
+	exportsSrc := g.generateExports(ns, exports)
 
 	////////////////////////////////////////////////////////////////////////////////
 
 	// Prepare the final source
 	sourceBytes := []byte(g.header(mungePackageName(getLastNSPart(ns.Name().String())))) // File header with package and imports
 	sourceBytes = append(sourceBytes, g.aotDeclarations.Bytes()...)                      // Package-level AOT call caches
+	sourceBytes = append(sourceBytes, snapshotSrc...)                                    // The namespace snapshot, if any
 	sourceBytes = append(sourceBytes, initBuf.Bytes()...)                                // The complete init function
+	sourceBytes = append(sourceBytes, exportsSrc...)                                     // Typed wrappers for ^:export functions
 
 	// Format the generated code
 	formatted, err := format.Source(sourceBytes)
@@ -625,6 +684,7 @@ runtime.RegisterNSLoader(` + fmt.Sprintf("%q", rootResourceName) + `, LoadNS)
 		g.originalWriter.Write(sourceBytes)
 		return fmt.Errorf("formatting failed: %w\n", err)
 	}
+	formatted = resolveLineDirectives(formatted)
 
 	// Write formatted code to the original writer
 	_, err = g.originalWriter.Write(formatted)
@@ -682,9 +742,18 @@ func (g *Generator) generateVar(nsVariableName string, name *lang.Symbol, vr *la
 		return nil
 	}
 
-	// Generate code for the var
-	varVar := g.allocVarVar(vr.Namespace().Name().String(), name.String())
+	// Generate code for the var. A snapshot loader refers to the var
+	// only if it has code to run for it.
+	nsName := vr.Namespace().Name().String()
+	varVar := varVariableName(nsName, name.String())
+	if !g.snapshot {
+		g.allocVarVar(nsName, name.String())
+	}
 	g.startNewValueInit(varVar)
+	if g.lineDirectives {
+		// Attribute the var's initialization to its definition.
+		defer g.enterSourcePosition(vr)()
+	}
 
 	g.pushVarScope()
 	defer g.popVarScope()
@@ -695,16 +764,28 @@ func (g *Generator) generateVar(nsVariableName string, name *lang.Symbol, vr *la
 	defer g.writef("}\n")
 
 	meta := vr.Meta()
-	varSym := g.allocateTempVar()
 	var isDynamic bool
-	g.writef("%s := %s\n", varSym, g.allocSymVar(name.String()))
 	if !lang.IsNil(meta) && RT.BooleanCast(lang.Get(meta, lang.KWDynamic)) {
 		isDynamic = true
 	}
+	var varSym string
+	if !g.snapshot {
+		varSym = g.allocateTempVar()
+		g.writef("%s := %s\n", varSym, g.allocSymVar(name.String()))
+	}
+	// bindRoot writes code binding the root of the var to valueExpr,
+	// interning it first unless it is restored from the snapshot.
+	bindRoot := func(valueExpr string) {
+		if g.snapshot {
+			g.writef("%s.BindRoot(%s)\n", g.allocVarVar(nsName, name.String()), valueExpr)
+			return
+		}
+		g.writef("%s = %s.InternWithValue(%s, %s, true)\n", varVar, nsVariableName, varSym, valueExpr)
+	}
 
 	// check if the var has a value
 	if initializer, ok := runtimeStateInitializer(vr); ok {
-		g.writef("%s = %s.InternWithValue(%s, %s, true)\n", varVar, nsVariableName, varSym, initializer)
+		bindRoot(initializer)
 	} else if vr.IsBound() {
 		// we call Get() on a new goroutine to ensure we get the root value in the case
 		// of dynamic vars
@@ -716,28 +797,37 @@ func (g *Generator) generateVar(nsVariableName string, name *lang.Symbol, vr *la
 		if target := g.aotCallTargets[vr]; target != nil {
 			g.specializationTarget = target
 		}
-		valueExpr := g.generateValue(v)
+		// A snapshot restores the root if it is not itself built with
+		// code, as the specialized functions are.
+		var valueExpr string
+		if g.snapshot && g.specializationTarget == nil {
+			valueExpr = g.generateSnapshotRoot(vr, v)
+		}
+		if valueExpr == "" {
+			valueExpr = g.generateValue(v)
+		}
 		if target := g.specializationTarget; target != nil {
 			g.writef("%s = %s\n", target.directFnVar, valueExpr)
 		}
-		g.writef("%s = %s.InternWithValue(%s, %s, true)\n", varVar, nsVariableName, varSym, valueExpr)
+		bindRoot(valueExpr)
 		if target := g.specializationTarget; target != nil && target.rootVersionVar != "" {
 			g.writef("%s = %s.RootVersion()\n", target.rootVersionVar, varVar)
 		}
-	} else {
+	} else if !g.snapshot {
 		g.writef("%s = %s.Intern(%s)\n", varVar, nsVariableName, varSym)
 	}
 
-	// Set metadata on the var if the symbol has metadata
-	if meta != nil {
-		g.writef("%s.SetMetaLazy(func() lang.IPersistentMap {\n", varVar)
+	// Set metadata on the var if the symbol has metadata, unless the
+	// snapshot carries it
+	if _, ok := g.snapshotMeta[vr]; meta != nil && !ok {
+		g.writef("%s.SetMetaLazy(func() lang.IPersistentMap {\n", g.allocVarVar(nsName, name.String()))
 		g.pushVarScope()
 		metaVariable := g.generateValue(meta)
 		g.writef("\treturn %s\n", metaVariable)
 		g.popVarScope()
 		g.writef("})\n")
 	}
-	if isDynamic {
+	if isDynamic && !g.snapshot {
 		g.writef("%s.SetDynamic()\n", varVar)
 	}
 
@@ -1323,8 +1413,10 @@ func (g *Generator) generateMultiFn(mf *lang.MultiFn) string {
 	// lang.NewMultiFn already seeds via registerWellKnownMethods: their
 	// method values are opaque Go FnFuncs (un-generatable), and the
 	// compiled binary re-seeds them on construction.
+	// The methods are added together, so that the table is built once.
 	methodTable := mf.GetMethodTable()
 	if methodTable != nil && methodTable.Count() > 0 {
+		var methods []string
 		for seq := lang.Seq(methodTable); seq != nil; seq = seq.Next() {
 			entry := seq.First().(lang.IMapEntry)
 			dispatchVal := entry.Key()
@@ -1337,7 +1429,14 @@ func (g *Generator) generateMultiFn(mf *lang.MultiFn) string {
 			dispatchValVar := g.generateValue(dispatchVal)
 			methodVar := g.generateValue(method)
 
-			g.writef("%s.AddMethod(%s, %s)\n", mfVar, dispatchValVar, methodVar)
+			methods = append(methods, dispatchValVar, methodVar)
+		}
+		if len(methods) > 0 {
+			g.writef("%s.AddMethods(\n", mfVar)
+			for i := 0; i < len(methods); i += 2 {
+				g.writef("%s, %s,\n", methods[i], methods[i+1])
+			}
+			g.writef(")\n")
 		}
 	}
 
@@ -1742,6 +1841,9 @@ func (g *Generator) generateFnMethodFixed(methodNode *ast.FnMethodNode, paramVar
 
 // generateASTNode generates code for an AST node
 func (g *Generator) generateASTNode(node *ast.Node) (res string) {
+	if g.lineDirectives {
+		defer g.enterSourcePosition(nodeForms(node)...)()
+	}
 	switch node.Op {
 	case ast.OpDef:
 		return g.generateDef(node)
@@ -1901,6 +2003,9 @@ func (g *Generator) generateASTNode(node *ast.Node) (res string) {
 	case ast.OpNew:
 		return g.generateNew(node)
 	default:
+		if ast.IsExtensionOp(node.Op) {
+			return g.generateExtension(node)
+		}
 		panic(fmt.Sprintf("unsupported AST node type %T", node.Sub))
 	}
 }
@@ -3002,8 +3107,9 @@ func (g *Generator) generateHostCall(node *ast.Node) string {
 		g.writef("%s := %s.%s(%s)\n", resultId, tgtId, directMethod, strings.Join(directArgs, ", "))
 		return resultId
 	}
-	if directMethod, receiver, directArgs, ok := g.directInferredHostCall(
+	if directMethod, receiver, directArgs, ok := g.directTypedHostCall(
 		tgt,
+		hostCallNode.TargetType,
 		tgtId,
 		methodName,
 		argIds,
@@ -3163,6 +3269,21 @@ func convertDirectHostArgs(
 	return converted, true
 }
 
+// directTypedHostCall is directInferredHostCall for a target the
+// analyzer inferred the type of, if typ is not nil.
+func (g *Generator) directTypedHostCall(
+	target *ast.Node,
+	typ reflect.Type,
+	targetID string,
+	name string,
+	args []string,
+) (methodName, receiver string, converted []string, ok bool) {
+	if typ == nil {
+		return g.directInferredHostCall(target, targetID, name, args)
+	}
+	return g.directHostCallForType(typ, target, targetID, name, args)
+}
+
 func (g *Generator) directInferredHostCall(
 	target *ast.Node,
 	targetID string,
@@ -3173,10 +3294,27 @@ func (g *Generator) directInferredHostCall(
 	if !ok {
 		return "", "", nil, false
 	}
+	return g.directHostCallForType(typ, target, targetID, name, args)
+}
+
+func (g *Generator) directHostCallForType(
+	typ reflect.Type,
+	target *ast.Node,
+	targetID string,
+	name string,
+	args []string,
+) (methodName, receiver string, converted []string, ok bool) {
 	method, receiverOffset, ok := directHostMethodForType(typ, name)
 	if !ok {
 		return "", "", nil, false
 	}
+	imports := maps.Clone(g.imports)
+	defer func() {
+		if !ok {
+			// Drop the imports of types named for a call not made.
+			g.imports = imports
+		}
+	}()
 	converted, ok = convertDirectHostArgs(
 		method,
 		receiverOffset,
@@ -3193,12 +3331,26 @@ func (g *Generator) directInferredHostCall(
 	if !ok {
 		return "", "", nil, false
 	}
+	if !anyValuedTarget(target) {
+		// The target may be the typed result of another direct call.
+		targetID = fmt.Sprintf("any(%s)", targetID)
+	}
 	return method.Name,
 		fmt.Sprintf("%s.(%s)", targetID, interfaceExpr),
 		converted,
 		true
 }
 
+// anyValuedTarget reports whether the code generated for target is
+// always of type any, as that of a function's parameters is.
+func anyValuedTarget(target *ast.Node) bool {
+	if target == nil || target.Op != ast.OpLocal {
+		return false
+	}
+	binding := target.Sub.(*ast.LocalNode).Binding
+	return binding == nil || binding.Local == lang.KWArg
+}
+
 func (g *Generator) convertInferredDirectHostArg(
 	paramType reflect.Type,
 	arg string,
@@ -3320,8 +3472,9 @@ func (g *Generator) generateHostInterop(node *ast.Node) string {
 	tgtId := g.generateASTNode(hostInteropNode.Target)
 
 	mOrF := hostInteropNode.MOrF.Name()
-	if directMethod, receiver, _, ok := g.directInferredHostCall(
+	if directMethod, receiver, _, ok := g.directTypedHostCall(
 		hostInteropNode.Target,
+		hostInteropNode.TargetType,
 		tgtId,
 		mOrF,
 		nil,
@@ -3593,7 +3746,17 @@ import (
 }
 
 func (g *Generator) writef(format string, args ...any) error {
-	_, err := fmt.Fprintf(g.currentWriter, format, args...)
+	if !g.lineDirectives {
+		_, err := fmt.Fprintf(g.currentWriter, format, args...)
+		return err
+	}
+	s := fmt.Sprintf(format, args...)
+	if !strings.HasPrefix(strings.TrimSpace(s), "//") {
+		// Comments keep the directive state; see lineState.
+		g.syncLineDirective()
+	}
+	g.noteLineWritten(s)
+	_, err := io.WriteString(g.currentWriter, s)
 	return err
 }
 
@@ -3807,7 +3970,9 @@ func mungeID(name string) string {
 
 func mungePackageName(name string) string {
 	name = mungeID(name)
-	if !token.IsIdentifier(name) {
+	// A loader in package main could not be imported, which namespaces
+	// like my.app.main must be.
+	if !token.IsIdentifier(name) || name == "main" {
 		return "pkg_" + name
 	}
 	return name
@@ -3846,15 +4011,22 @@ func (g *Generator) allocVarVar(ns, sym string) string {
 		return v
 	}
 
-	// also allocate for ns and symbols
-	g.allocSymVar(ns)
-	g.allocSymVar(sym)
+	// also allocate for ns and symbols, which a snapshot loader only
+	// needs for vars outside its snapshot
+	if !g.snapshot {
+		g.allocSymVar(ns)
+		g.allocSymVar(sym)
+	}
 
-	varName := "var_" + mungeID(ns) + "_" + mungeID(sym)
+	varName := varVariableName(ns, sym)
 	g.varVariables[varInfo] = varName
 	return varName
 }
 
+func varVariableName(ns, sym string) string {
+	return "var_" + mungeID(ns) + "_" + mungeID(sym)
+}
+
 func (g *Generator) allocSymVar(sym string) string {
 	if v, ok := g.symbolVariables[sym]; ok {
 		return v
@@ -0,0 +1,278 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+)
+
+// Functions marked ^:export get exported, statically typed Go wrappers in
+// the generated package, so Go programs can import a compiled namespace and
+// call them directly:
+//
+//	(defn ^:export add-ints ^go/int64 [^go/int64 a ^go/int64 b] (+ a b))
+//
+// becomes
+//
+//	func AddInts(a int64, b int64) int64
+//
+// Parameter and return types come from :tag hints on the single arglist,
+// or on the var for the return type; unhinted values are any. The Go name
+// is the var name in CamelCase, or the string given as :export.
+
+// aotExport is one exported wrapper.
+type aotExport struct {
+	goName   string
+	vr       *lang.Var
+	params   []aotExportParam
+	variadic *aotExportParam
+	result   reflect.Type // nil for any
+}
+
+type aotExportParam struct {
+	name string
+	typ  reflect.Type // nil for any
+}
+
+// prepareExports collects the exported functions among vars.
+func prepareExports(vars []namedVar) ([]aotExport, error) {
+	var exports []aotExport
+	goNames := map[string]string{}
+	for _, nv := range vars {
+		export, ok := exportMeta(nv.vr)
+		if !ok {
+			continue
+		}
+		exp, err := newAOTExport(nv.vr, export)
+		if err != nil {
+			return nil, fmt.Errorf("cannot export %s: %w", nv.vr, err)
+		}
+		if other, ok := goNames[exp.goName]; ok {
+			return nil, fmt.Errorf("cannot export %s: Go name %s is already used by %s", nv.vr, exp.goName, other)
+		}
+		goNames[exp.goName] = nv.vr.String()
+		exports = append(exports, exp)
+	}
+	return exports, nil
+}
+
+// exportMeta returns the :export metadata of vr, if it is set.
+func exportMeta(vr *lang.Var) (any, bool) {
+	export := lang.Get(vr.Meta(), lang.KWExport)
+	return export, export != nil && export != false
+}
+
+func newAOTExport(vr *lang.Var, export any) (aotExport, error) {
+	exp := aotExport{vr: vr}
+	if name, ok := export.(string); ok {
+		exp.goName = name
+	} else {
+		exp.goName = exportGoName(vr.Symbol().Name())
+	}
+	if !token.IsIdentifier(exp.goName) || !token.IsExported(exp.goName) {
+		return exp, fmt.Errorf("%q is not an exported Go identifier; name one with ^{:export \"Name\"}", exp.goName)
+	}
+	if exp.goName == "LoadNS" {
+		return exp, fmt.Errorf("Go name LoadNS is reserved for the namespace loader")
+	}
+	if !vr.IsBound() || !lang.IsFn(vr.Get()) || vr.IsMacro() {
+		return exp, fmt.Errorf("only functions can be exported")
+	}
+
+	meta := vr.Meta()
+	arglists := lang.Get(meta, lang.KWArglists)
+	if lang.Count(arglists) != 1 {
+		return exp, fmt.Errorf("exported functions must have exactly one arglist, found %d", lang.Count(arglists))
+	}
+	arglist, ok := lang.First(arglists).(lang.IPersistentVector)
+	if !ok {
+		return exp, fmt.Errorf("invalid arglist %v", lang.First(arglists))
+	}
+
+	used := map[string]bool{}
+	paramName := func(form any, i int) string {
+		name := fmt.Sprintf("arg%d", i)
+		if sym, ok := form.(*lang.Symbol); ok {
+			if munged := mungeID(sym.Name()); token.IsIdentifier(munged) && !reservedExportName(munged) {
+				name = munged
+			}
+		}
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		return name
+	}
+	variadic := false
+	for i := 0; i < arglist.Count(); i++ {
+		form := arglist.Nth(i)
+		if sym, ok := form.(*lang.Symbol); ok && sym.Name() == "&" {
+			variadic = true
+			continue
+		}
+		typ, err := exportTagType(tagOf(form))
+		if err != nil {
+			return exp, err
+		}
+		param := aotExportParam{name: paramName(form, i), typ: typ}
+		if variadic {
+			exp.variadic = &param
+			break
+		}
+		exp.params = append(exp.params, param)
+	}
+
+	// A hint on the arglist takes precedence over one on the var, which
+	// defn also uses for the return type.
+	resultTag := tagOf(arglist)
+	if resultTag == nil {
+		resultTag = lang.Get(meta, lang.KWTag)
+	}
+	result, err := exportTagType(resultTag)
+	if err != nil {
+		return exp, err
+	}
+	exp.result = result
+	return exp, nil
+}
+
+// exportGoName converts a Glojure name like add-ints to AddInts.
+func exportGoName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_'
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
+
+// reservedExportName reports whether a parameter name would shadow an
+// identifier the wrapper body uses.
+func reservedExportName(name string) bool {
+	switch name {
+	case "lang", "loadExports", "exportVars", "exportArgs", "any":
+		return true
+	}
+	return token.IsKeyword(name)
+}
+
+func tagOf(form any) any {
+	imeta, ok := form.(lang.IMeta)
+	if !ok {
+		return nil
+	}
+	return lang.Get(imeta.Meta(), lang.KWTag)
+}
+
+// exportTagType resolves a type hint to a Go type. A hint may be a symbol
+// such as go/int64 or time.Duration, or, when defn evaluated the metadata
+// on a function name, the type itself.
+func exportTagType(tag any) (reflect.Type, error) {
+	switch tag := tag.(type) {
+	case nil:
+		return nil, nil
+	case *lang.Symbol:
+		if tag.Namespace() == "go" {
+			if typ, ok := lang.BuiltinTypes[tag.Name()]; ok {
+				return typ, nil
+			}
+		} else if value, ok := pkgmap.Get(tag.FullName()); ok {
+			if typ, ok := unwrappedReflectType(value); ok {
+				return typ, nil
+			}
+		}
+	default:
+		if typ, ok := unwrappedReflectType(tag); ok {
+			return typ, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type hint %v", tag)
+}
+
+// generateExports returns the wrapper functions for exports, with the
+// helper that loads the namespace before the first call.
+func (g *Generator) generateExports(ns *lang.Namespace, exports []aotExport) []byte {
+	if len(exports) == 0 {
+		return nil
+	}
+	var b bytes.Buffer
+	syncPkg := g.addImportWithAlias("sync")
+
+	fmt.Fprintf(&b, `
+var (
+	exportsOnce %s.Once
+	exportVars  [%d]*lang.Var
+)
+
+// loadExports loads %s before the first call to an exported
+// function. The Glojure runtime must already be initialized, for example
+// by importing github.com/glojurelang/glojure/pkg/glj.
+func loadExports() {
+	exportsOnce.Do(func() {
+		nsSym := lang.NewSymbol(%q)
+		lang.Apply(lang.NSCore.FindInternedVar(lang.NewSymbol("require")), []any{nsSym})
+		ns := lang.FindNamespace(nsSym)
+`, syncPkg, len(exports), ns.Name(), ns.Name().String())
+	for i, exp := range exports {
+		fmt.Fprintf(&b, "\t\texportVars[%d] = ns.FindInternedVar(lang.NewSymbol(%q))\n", i, exp.vr.Symbol().Name())
+	}
+	b.WriteString("\t})\n}\n")
+
+	for i, exp := range exports {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "// %s calls %s.\n", exp.goName, strings.TrimPrefix(exp.vr.String(), "#'"))
+		if doc, ok := lang.Get(exp.vr.Meta(), lang.KWDoc).(string); ok && doc != "" {
+			b.WriteString("//\n")
+			for _, line := range strings.Split(strings.TrimSpace(doc), "\n") {
+				b.WriteString(strings.TrimRight("// "+strings.TrimSpace(line), " ") + "\n")
+			}
+		}
+
+		params := make([]string, 0, len(exp.params)+1)
+		args := make([]string, 0, len(exp.params))
+		for _, param := range exp.params {
+			params = append(params, param.name+" "+g.exportTypeString(param.typ))
+			args = append(args, param.name)
+		}
+		if exp.variadic != nil {
+			params = append(params, exp.variadic.name+" ..."+g.exportTypeString(exp.variadic.typ))
+		}
+		fmt.Fprintf(&b, "func %s(%s) %s {\n", exp.goName, strings.Join(params, ", "), g.exportTypeString(exp.result))
+		b.WriteString("\tloadExports()\n")
+
+		call := fmt.Sprintf("exportVars[%d].Invoke(%s)", i, strings.Join(args, ", "))
+		if exp.variadic != nil {
+			fmt.Fprintf(&b, "\texportArgs := make([]any, 0, %d+len(%s))\n", len(args), exp.variadic.name)
+			if len(args) > 0 {
+				fmt.Fprintf(&b, "\texportArgs = append(exportArgs, %s)\n", strings.Join(args, ", "))
+			}
+			fmt.Fprintf(&b, "\tfor _, arg := range %s {\n\t\texportArgs = append(exportArgs, arg)\n\t}\n", exp.variadic.name)
+			call = fmt.Sprintf("exportVars[%d].Invoke(exportArgs...)", i)
+		}
+		if exp.result == nil {
+			fmt.Fprintf(&b, "\treturn %s\n", call)
+		} else {
+			fmt.Fprintf(&b, "\treturn lang.ExportValue[%s](%s)\n", g.getTypeString(exp.result), call)
+		}
+		b.WriteString("}\n")
+	}
+	return b.Bytes()
+}
+
+func (g *Generator) exportTypeString(typ reflect.Type) string {
+	if typ == nil {
+		return "any"
+	}
+	return g.getTypeString(typ)
+}
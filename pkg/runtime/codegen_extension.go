@@ -0,0 +1,48 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+)
+
+// generateExtension generates the code for a node of an op allocated
+// with ast.RegisterOp, with the op's registered NodeGenerator.
+func (g *Generator) generateExtension(node *ast.Node) string {
+	gen := nodeGenerator(node.Op)
+	if gen == nil {
+		panic(fmt.Sprintf("no code generator for %s", ast.OpName(node.Op)))
+	}
+	res, err := gen(g, node)
+	if err != nil {
+		panic(fmt.Sprintf("generate %s: %v", ast.OpName(node.Op), err))
+	}
+	return res
+}
+
+// GenerateNode implements CodeGenerator.
+func (g *Generator) GenerateNode(n *ast.Node) string {
+	return g.generateASTNode(n)
+}
+
+// GenerateValue implements CodeGenerator.
+func (g *Generator) GenerateValue(v any) string {
+	return g.generateValue(v)
+}
+
+// Writef implements CodeGenerator.
+func (g *Generator) Writef(format string, args ...any) {
+	g.writef(format, args...)
+}
+
+// TempVar implements CodeGenerator.
+func (g *Generator) TempVar() string {
+	return g.allocateTempVar()
+}
+
+// Import implements CodeGenerator.
+func (g *Generator) Import(pkg string) string {
+	return g.addImportWithAlias(pkg)
+}
@@ -4,11 +4,15 @@ package runtime
 
 import (
 	"bytes"
+	"go/parser"
+	"go/token"
+	"io"
 	"io/fs"
 	"math"
 	"os"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -126,6 +130,7 @@ func TestMungePackageNameAvoidsGoKeywords(t *testing.T) {
 		"case":   "pkg_case",
 		"normal": "normal",
 		"1thing": "pkg_1thing",
+		"main":   "pkg_main",
 	} {
 		if got := mungePackageName(input); got != want {
 			t.Errorf("mungePackageName(%q) = %q, want %q", input, got, want)
@@ -1492,3 +1497,105 @@ func aotTestIf(test, then, otherwise *ast.Node) *ast.Node {
 	node.Sub = &ast.IfNode{Test: test, Then: then, Else: otherwise}
 	return node
 }
+
+func TestGenerateLineDirectives(t *testing.T) {
+	ns := lang.FindOrCreateNamespace(lang.NewSymbol("codegen.lines"))
+	ns.ReferAllSnapshot(lang.NSCore, nil)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, ns))
+	defer lang.PopThreadBindings()
+
+	ReadEval(`
+;; a comment
+(defn pick [x]
+  (let [v (nth [1 2] x)]
+    (inc v)))`, WithFilename("codegen/lines.glj"))
+
+	var output bytes.Buffer
+	generator := NewGenerator(&output)
+	generator.EnableLineDirectives("")
+	if err := generator.Generate(ns); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	generated := output.String()
+	for _, want := range []string{
+		"//line ../../codegen/lines.glj:3:7\n",
+		"//line ../../codegen/lines.glj:4:11\n",
+		"//line ../../codegen/lines.glj:5:5\n",
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("generated code lacks %q:\n%s", want, generated)
+		}
+	}
+
+	// Directives back to the loader name the line that follows them.
+	lines := strings.Split(generated, "\n")
+	restored := 0
+	for i, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "//line loader.go:"); ok {
+			restored++
+			if rest != strconv.Itoa(i+2) {
+				t.Errorf("line %d: %q does not name the following line", i+1, line)
+			}
+		}
+	}
+	if restored == 0 {
+		t.Errorf("generated code never restores loader positions:\n%s", generated)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "loader.go", generated, parser.ParseComments); err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+}
+
+func TestGenerateTypedExports(t *testing.T) {
+	ns := lang.FindOrCreateNamespace(lang.NewSymbol("codegen.exports"))
+	ns.ReferAllSnapshot(lang.NSCore, nil)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, ns))
+	defer lang.PopThreadBindings()
+
+	ReadEval(`
+(defn ^:export add-ints
+  "Adds a and b."
+  ^go/int64 [^go/int64 a ^go/int64 b]
+  (+ a b))
+(defn ^{:export "Greeting"} greet ^go/string [^go/string who & more]
+  (apply str "hello " who more))
+(defn ^:export identity-of [x] x)
+(defn internal [x] x)`)
+
+	var output bytes.Buffer
+	if err := NewGenerator(&output).Generate(ns); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	generated := output.String()
+	for _, want := range []string{
+		"// AddInts calls codegen.exports/add-ints.\n//\n// Adds a and b.\nfunc AddInts(a int64, b int64) int64 {",
+		"return lang.ExportValue[int64](exportVars[0].Invoke(a, b))",
+		"func Greeting(who string, more ...any) string {",
+		"func IdentityOf(x any) any {",
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("generated code lacks %q:\n%s", want, generated)
+		}
+	}
+	if strings.Contains(generated, "func Internal(") {
+		t.Errorf("unexported function got a wrapper:\n%s", generated)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "loader.go", generated, parser.ParseComments); err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+}
+
+func TestGenerateExportRejectsMultipleArities(t *testing.T) {
+	ns := lang.FindOrCreateNamespace(lang.NewSymbol("codegen.exports-arities"))
+	ns.ReferAllSnapshot(lang.NSCore, nil)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, ns))
+	defer lang.PopThreadBindings()
+
+	ReadEval(`(defn ^:export pick ([x] x) ([x y] y))`)
+
+	err := NewGenerator(io.Discard).Generate(ns)
+	if err == nil || !strings.Contains(err.Error(), "exactly one arglist") {
+		t.Fatalf("expected an arity error, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// Line directives map generated statements back to the Glojure forms they
+// were generated from, so Go panics, profiles, coverage and debuggers
+// report .glj source locations. The generator tracks the position of the
+// form being generated and emits a //line directive before the next line
+// written for it. When generation leaves the outermost form, a directive
+// restoring the generated file's own positions follows; its line number is
+// only known after formatting, so a placeholder is written and filled in
+// by resolveLineDirectives.
+
+// aotLoaderFile is the name of the generated loader in its package
+// directory.
+const aotLoaderFile = "loader.go"
+
+// generatedLinePlaceholder marks a directive that restores positions in
+// the generated file. It keeps the //line prefix so that gofmt leaves it
+// in the first column.
+const generatedLinePlaceholder = "//line glj-generated.go:1"
+
+// sourcePos is a position in Glojure source. The zero value denotes the
+// generated code itself.
+type sourcePos struct {
+	file      string
+	line, col int
+}
+
+// lineState is the directive state of one output buffer.
+type lineState struct {
+	emitted sourcePos
+	midLine bool
+	// afterComment is set when the last line written was a comment. gofmt
+	// mangles a comment adjacent to a directive, so directives are not
+	// written before comments and are separated from a preceding comment
+	// by a blank line.
+	afterComment bool
+}
+
+// EnableLineDirectives makes the generator emit //line directives from the
+// :file, :line and :column metadata the reader attaches to forms. Forms
+// carry source paths relative to the load path root. If sourceRoot names
+// that directory, directives use absolute paths under it; otherwise they
+// are relative to the generated loader, which must then live in its
+// namespace's directory under the same root, as compile places it.
+func (g *Generator) EnableLineDirectives(sourceRoot string) {
+	g.lineDirectives = true
+	g.lineSourceRoot = sourceRoot
+	g.lineStates = make(map[io.Writer]*lineState)
+}
+
+// enterSourcePosition makes the position of the first of forms that has
+// one the position of the code generated until the returned function is
+// called. Without one, the enclosing position is kept.
+func (g *Generator) enterSourcePosition(forms ...any) func() {
+	var pos sourcePos
+	ok := false
+	for _, form := range forms {
+		if pos, ok = g.formSourcePos(form); ok {
+			break
+		}
+	}
+	if !ok {
+		if len(g.lineStack) == 0 {
+			return func() {}
+		}
+		pos = g.lineStack[len(g.lineStack)-1]
+	}
+	g.lineStack = append(g.lineStack, pos)
+	return func() {
+		g.lineStack = g.lineStack[:len(g.lineStack)-1]
+		if len(g.lineStack) == 0 {
+			g.syncLineDirective()
+		}
+	}
+}
+
+func (g *Generator) formSourcePos(form any) (sourcePos, bool) {
+	imeta, ok := form.(lang.IMeta)
+	if !ok {
+		return sourcePos{}, false
+	}
+	meta := imeta.Meta()
+	if meta == nil {
+		return sourcePos{}, false
+	}
+	file, _ := meta.ValAt(lang.KWFile).(string)
+	line, _ := meta.ValAt(lang.KWLine).(int)
+	col, _ := meta.ValAt(lang.KWColumn).(int)
+	// The reader names unnamed inputs like <unknown-file>.
+	if file == "" || strings.HasPrefix(file, "<") || line <= 0 {
+		return sourcePos{}, false
+	}
+	switch {
+	case path.IsAbs(file), filepath.IsAbs(file):
+	case g.lineSourceRoot != "":
+		file = filepath.ToSlash(filepath.Join(g.lineSourceRoot, file))
+	case g.aotNamespace != nil:
+		// The Go toolchain resolves relative directive paths against
+		// the generated file's directory.
+		depth := strings.Count(nsToPath(g.aotNamespace.Name().Name()), "/") + 1
+		file = strings.Repeat("../", depth) + file
+	}
+	return sourcePos{file: file, line: line, col: max(col, 1)}, true
+}
+
+// syncLineDirective writes a directive to the current writer if the
+// position of the code being generated differs from the last one written
+// there. Directives must start a line, so nothing is written in the middle
+// of one; the directive follows on the next line instead.
+func (g *Generator) syncLineDirective() {
+	state := g.lineStates[g.currentWriter]
+	if state == nil {
+		state = &lineState{}
+		g.lineStates[g.currentWriter] = state
+	}
+	var want sourcePos
+	if len(g.lineStack) > 0 {
+		want = g.lineStack[len(g.lineStack)-1]
+	}
+	if state.midLine || state.emitted == want {
+		return
+	}
+	if state.afterComment {
+		fmt.Fprintln(g.currentWriter)
+	}
+	if want == (sourcePos{}) {
+		fmt.Fprintf(g.currentWriter, "%s\n", generatedLinePlaceholder)
+	} else {
+		fmt.Fprintf(g.currentWriter, "//line %s:%d:%d\n", want.file, want.line, want.col)
+	}
+	state.emitted = want
+}
+
+// nodeForms returns the forms of node to take a source position from: the
+// analyzed form, then the forms it was macroexpanded from, outermost first.
+// Macros rarely copy position metadata onto their expansions.
+func nodeForms(node *ast.Node) []any {
+	forms := make([]any, 0, len(node.RawForms)+1)
+	forms = append(forms, node.Form)
+	for i := len(node.RawForms) - 1; i >= 0; i-- {
+		forms = append(forms, node.RawForms[i])
+	}
+	return forms
+}
+
+// finishLineDirectives restores generated positions at the end of every
+// buffer left in a source position.
+func (g *Generator) finishLineDirectives() {
+	for w, state := range g.lineStates {
+		if state.emitted != (sourcePos{}) && !state.midLine {
+			if state.afterComment {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s\n", generatedLinePlaceholder)
+			state.emitted = sourcePos{}
+		}
+	}
+}
+
+func (g *Generator) noteLineWritten(s string) {
+	if s == "" {
+		return
+	}
+	state := g.lineStates[g.currentWriter]
+	if state == nil {
+		state = &lineState{}
+		g.lineStates[g.currentWriter] = state
+	}
+	state.midLine = !strings.HasSuffix(s, "\n")
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	state.afterComment = strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "//")
+}
+
+// resolveLineDirectives replaces each placeholder in formatted source with
+// a directive naming the line that follows it in the generated file.
+func resolveLineDirectives(src []byte) []byte {
+	if !bytes.Contains(src, []byte(generatedLinePlaceholder)) {
+		return src
+	}
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	for i, line := range lines {
+		if string(bytes.TrimSpace(line)) == generatedLinePlaceholder {
+			lines[i] = fmt.Appendf(nil, "//line %s:%d\n", aotLoaderFile, i+2)
+		}
+	}
+	return bytes.Join(lines, nil)
+}
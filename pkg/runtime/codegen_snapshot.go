@@ -0,0 +1,239 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// prepareSnapshot selects the vars a snapshot loader restores: those the
+// loader would otherwise intern itself. Their metadata goes into the
+// snapshot if it is data; their roots go in as generateVar meets them.
+func (g *Generator) prepareSnapshot(vars []namedVar) {
+	g.snapshotIndex = make(map[varInfo]int)
+	g.snapshotMeta = make(map[*lang.Var]string)
+	g.snapshotRoots = make(map[*lang.Var]string)
+	for _, nv := range vars {
+		if isRuntimeOwnedVar(nv.vr) || omittedVars[nv.vr.String()] {
+			continue
+		}
+		vi := varInfo{ns: nv.vr.Namespace().Name().String(), sym: nv.name.String()}
+		g.snapshotIndex[vi] = len(g.snapshotVars)
+		g.snapshotVars = append(g.snapshotVars, nv.vr)
+		if meta, ok := encodeSnapshotMeta(nv.vr.Meta()); ok {
+			g.snapshotMeta[nv.vr] = meta
+		}
+	}
+}
+
+// generateSnapshot returns the declaration of the snapshot of ns and
+// writes the statement restoring it to initBuf. The snapshot's symbols
+// and keywords start with those the loader refers to, in the order it
+// declares them.
+func (g *Generator) generateSnapshot(ns *lang.Namespace, initBuf *bytes.Buffer) []byte {
+	var symbols []string
+	for sym := range g.symbolVariables {
+		symbols = append(symbols, sym)
+	}
+	sort.Strings(symbols)
+	var extra []string
+	for _, vr := range g.snapshotVars {
+		if _, ok := g.symbolVariables[vr.Symbol().String()]; !ok {
+			extra = append(extra, vr.Symbol().String())
+		}
+	}
+	sort.Strings(extra)
+	symbols = append(symbols, extra...)
+	symbolIndex := make(map[string]int, len(symbols))
+	for i, sym := range symbols {
+		symbolIndex[sym] = i
+	}
+
+	var keywords []string
+	for kw := range g.kwVariables {
+		keywords = append(keywords, kw)
+	}
+	sort.Strings(keywords)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// nsSnapshot holds the vars of %s as they were after it was loaded.\n", ns.Name())
+	buf.WriteString("var nsSnapshot = runtime.NamespaceSnapshot{\n")
+	buf.WriteString("Symbols: []string{\n")
+	for _, sym := range symbols {
+		fmt.Fprintf(&buf, "%q,\n", sym)
+	}
+	buf.WriteString("},\n")
+	buf.WriteString("Keywords: []string{\n")
+	for _, kw := range keywords {
+		fmt.Fprintf(&buf, "%q,\n", kw)
+	}
+	buf.WriteString("},\n")
+	fmt.Fprintf(&buf, "Name: %d,\n", symbolIndex[ns.Name().String()])
+	buf.WriteString("Vars: []runtime.SnapshotVar{\n")
+	for _, vr := range g.snapshotVars {
+		fmt.Fprintf(&buf, "{Name: %d", symbolIndex[vr.Symbol().String()])
+		if RT.BooleanCast(lang.Get(vr.Meta(), lang.KWDynamic)) {
+			buf.WriteString(", Dynamic: true")
+		}
+		if meta, ok := g.snapshotMeta[vr]; ok {
+			if vr.IsMacro() {
+				buf.WriteString(", Macro: true")
+			}
+			fmt.Fprintf(&buf, ", Meta: %q", meta)
+		}
+		if root, ok := g.snapshotRoots[vr]; ok {
+			fmt.Fprintf(&buf, ", Root: %q", root)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("},\n")
+	buf.WriteString("}\n\n")
+
+	initBuf.WriteString("restored := runtime.RestoreSnapshot(&nsSnapshot)\n")
+	return buf.Bytes()
+}
+
+// generateSnapshotRoot puts v, the root of vr, in the snapshot and
+// returns the expression restoring it, or "" if the loader must build v
+// itself: vr is not in the snapshot or v is a function or other value
+// that is not data. The values v holds that are not data are built by
+// the loader and passed to RestoredSnapshot.Root.
+func (g *Generator) generateSnapshotRoot(vr *lang.Var, v any) string {
+	i, ok := g.snapshotIndex[varInfo{ns: vr.Namespace().Name().String(), sym: vr.Symbol().String()}]
+	if !ok {
+		return ""
+	}
+	root, holes, ok := encodeSnapshotRoot(v)
+	if !ok {
+		return ""
+	}
+	g.snapshotRoots[vr] = root
+	if len(holes) == 0 {
+		return fmt.Sprintf("restored.Root(%d)", i)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "restored.Root(%d,\n", i)
+	for _, hole := range holes {
+		fmt.Fprintf(&buf, "%s,\n", g.generateValue(hole))
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// encodeSnapshotRoot returns v as SnapshotVar.Root holds it, with the
+// values left to the loader in the order they fill its holes, or false
+// if v is itself such a value.
+func encodeSnapshotRoot(v any) (string, []any, bool) {
+	e := &rootEncoder{astEncoder: newASTEncoder()}
+	if err := e.encode(func() { e.root(v) }); err != nil {
+		return "", nil, false
+	}
+	if e.buf[0] == rootHole {
+		return "", nil, false
+	}
+	return string(e.buf), e.holes, true
+}
+
+// rootEncoder encodes snapshot roots, collecting the values that are not
+// data in holes. Like the values generateValue builds, the collections
+// and symbols of a root are stored without their metadata, which holds
+// little more than the reader's positions; atoms keep theirs.
+type rootEncoder struct {
+	*astEncoder
+	holes []any
+}
+
+func (e *rootEncoder) root(v any) {
+	switch v := v.(type) {
+	case *lang.Atom:
+		e.byte(rootAtom)
+		e.root(v.Deref())
+		e.root(v.Meta())
+	case *lang.MultiFn:
+		e.multiFn(v)
+	case *lang.Symbol:
+		if !symbolRoundTrips(v) {
+			e.hole(v)
+			return
+		}
+		e.byte(rootData)
+		e.value(v.WithMeta(nil))
+	case lang.IRecord, lang.Sorted:
+		e.hole(v)
+	case lang.IPersistentMap:
+		e.byte(rootMap)
+		e.uint(uint64(v.Count()))
+		for s := lang.Seq(v); s != nil; s = s.Next() {
+			entry := s.First().(lang.IMapEntry)
+			e.root(entry.Key())
+			e.root(entry.Val())
+		}
+	case lang.IPersistentVector:
+		e.rootColl(rootVector, v)
+	case lang.IPersistentSet:
+		e.rootColl(rootSet, v)
+	case *lang.EmptyList, lang.ISeq:
+		e.rootColl(rootList, v)
+	default:
+		if !snapshotData(v) {
+			e.hole(v)
+			return
+		}
+		e.byte(rootData)
+		e.value(v)
+	}
+}
+
+func (e *rootEncoder) hole(v any) {
+	e.byte(rootHole)
+	e.holes = append(e.holes, v)
+}
+
+func (e *rootEncoder) rootColl(tag byte, coll any) {
+	var items []any
+	for s := lang.Seq(coll); s != nil; s = s.Next() {
+		items = append(items, s.First())
+	}
+	e.byte(tag)
+	e.uint(uint64(len(items)))
+	for _, item := range items {
+		e.root(item)
+	}
+}
+
+// multiFn encodes mf as generateMultiFn builds it, leaving out the
+// methods lang.NewMultiFn registers itself.
+func (e *rootEncoder) multiFn(mf *lang.MultiFn) {
+	e.byte(rootMultiFn)
+	e.string(mf.GetName())
+	e.root(mf.GetDispatchFn())
+	e.root(mf.GetDefaultDispatchVal())
+	e.root(mf.GetHierarchy())
+	var methods []any
+	for s := lang.Seq(mf.GetMethodTable()); s != nil; s = s.Next() {
+		entry := s.First().(lang.IMapEntry)
+		if lang.IsAutoRegisteredMethod(mf.GetName(), entry.Key(), entry.Val()) {
+			continue
+		}
+		methods = append(methods, entry.Key(), entry.Val())
+	}
+	e.uint(uint64(len(methods) / 2))
+	for _, m := range methods {
+		e.root(m)
+	}
+	var prefers []any
+	for s := lang.Seq(mf.PreferTable()); s != nil; s = s.Next() {
+		entry := s.First().(lang.IMapEntry)
+		for p := lang.Seq(entry.Val()); p != nil; p = p.Next() {
+			prefers = append(prefers, entry.Key(), p.First())
+		}
+	}
+	e.uint(uint64(len(prefers) / 2))
+	for _, p := range prefers {
+		e.root(p)
+	}
+}
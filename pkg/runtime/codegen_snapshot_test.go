@@ -0,0 +1,136 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+func TestGenerateSnapshotLoader(t *testing.T) {
+	ns := lang.FindOrCreateNamespace(lang.NewSymbol("codegen.snapshot"))
+	ns.ReferAllSnapshot(lang.NSCore, nil)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, ns))
+	defer lang.PopThreadBindings()
+
+	ReadEval(`
+		(defn plain "Doc." [^String s] s)
+		(defmacro mac [x] x)
+		(def ^:dynamic *dyn* 1)
+		(declare unbound)
+		(def ^{:made-by (fn [] 1)} opaque 2)
+		(defmulti multi (fn [x] x))
+		(defmethod multi 1 [_] :one)
+		(defmethod multi 2 [_] :two)
+		(prefer-method multi 1 2)
+		(def data {:a [1 2] :b #{"x"} :c (list 'sym)})
+		(def table (atom {:on-interface true :fns {:f (fn [] 1)}} :meta {:m 1}))`)
+
+	var output bytes.Buffer
+	g := newGenerator(&output, true)
+	g.snapshot = true
+	if err := g.Generate(ns); err != nil {
+		t.Fatalf("generate snapshot loader: %v", err)
+	}
+	generated := output.String()
+	if _, err := parser.ParseFile(token.NewFileSet(), "load.go", generated, 0); err != nil {
+		t.Fatalf("generated loader does not parse: %v\n%s", err, generated)
+	}
+	for _, want := range []string{
+		"var nsSnapshot = runtime.NamespaceSnapshot{",
+		"restored := runtime.RestoreSnapshot(&nsSnapshot)",
+		"Macro: true",
+		"Dynamic: true",
+		"restored.Root(",
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("generated loader omitted %q:\n%s", want, generated)
+		}
+	}
+	for _, notWant := range []string{"InternWithValue", ".Intern(", "SetDynamic", "lang.NewSymbolUnchecked", ".AddMethods(", "lang.NewAtom"} {
+		if strings.Contains(generated, notWant) {
+			t.Errorf("generated loader unexpectedly contains %q:\n%s", notWant, generated)
+		}
+	}
+	// Only the metadata holding a function is left to code.
+	if got := strings.Count(generated, "SetMetaLazy"); got != 1 {
+		t.Errorf("generated loader sets metadata with code %d times, want 1:\n%s", got, generated)
+	}
+
+	// Restoring the snapshot into another namespace reproduces the vars.
+	restoredNS := lang.NewSymbol("codegen.snapshot-restored")
+	s := NamespaceSnapshot{Symbols: []string{restoredNS.String()}}
+	var originals []*lang.Var
+	holes := make(map[*lang.Var][]any)
+	for _, vr := range g.snapshotVars {
+		sv := SnapshotVar{
+			Name:    len(s.Symbols),
+			Dynamic: vr.IsDynamic(),
+			Macro:   vr.IsMacro(),
+			Meta:    g.snapshotMeta[vr],
+			Root:    g.snapshotRoots[vr],
+		}
+		if sv.Root != "" {
+			_, holes[vr], _ = encodeSnapshotRoot(vr.Get())
+		}
+		s.Symbols = append(s.Symbols, vr.Symbol().String())
+		s.Vars = append(s.Vars, sv)
+		originals = append(originals, vr)
+	}
+	restored := RestoreSnapshot(&s)
+	for i, vr := range restored.Vars {
+		original := originals[i]
+		if vr.Namespace().Name().String() != restoredNS.String() ||
+			vr.Symbol().String() != original.Symbol().String() {
+			t.Fatalf("restored var %d is %v, want %s", i, vr, original.Symbol())
+		}
+		if vr.IsDynamic() != original.IsDynamic() || vr.IsMacro() != original.IsMacro() {
+			t.Errorf("restored %v: dynamic %v, macro %v", vr, vr.IsDynamic(), vr.IsMacro())
+		}
+		if s.Vars[i].Root != "" {
+			checkRestoredRoot(t, original, restored.Root(i, holes[original]...))
+		}
+		if s.Vars[i].Meta == "" {
+			continue
+		}
+		want := original.Meta().Without(lang.KWNS)
+		if got := vr.Meta().Without(lang.KWNS); !lang.Equals(got, want) {
+			t.Errorf("restored %v metadata = %v, want %v", vr, got, want)
+		}
+	}
+}
+
+// checkRestoredRoot checks that root, restored from the snapshot of
+// original's root, is equal to it.
+func checkRestoredRoot(t *testing.T, original *lang.Var, root any) {
+	t.Helper()
+	switch want := original.Get().(type) {
+	case *lang.MultiFn:
+		got, ok := root.(*lang.MultiFn)
+		if !ok {
+			t.Fatalf("restored %v root is %T, want a multimethod", original, root)
+		}
+		if got.GetName() != want.GetName() || got.GetDispatchFn() != want.GetDispatchFn() ||
+			!lang.Equals(got.GetMethodTable(), want.GetMethodTable()) ||
+			!lang.Equals(got.PreferTable(), want.PreferTable()) {
+			t.Errorf("restored %v = %v, want %v", original, got.GetMethodTable(), want.GetMethodTable())
+		}
+	case *lang.Atom:
+		got, ok := root.(*lang.Atom)
+		if !ok {
+			t.Fatalf("restored %v root is %T, want an atom", original, root)
+		}
+		if !lang.Equals(got.Deref(), want.Deref()) || !lang.Equals(got.Meta(), want.Meta()) {
+			t.Errorf("restored %v = %v %v, want %v %v", original, got.Deref(), got.Meta(), want.Deref(), want.Meta())
+		}
+	default:
+		if !lang.Equals(root, want) {
+			t.Errorf("restored %v root = %v, want %v", original, root, want)
+		}
+	}
+}
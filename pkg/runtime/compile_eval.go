@@ -315,7 +315,6 @@ func (c threadedEvalCompiler) compile(n *ast.Node) evalFn {
 		return func(env *environment) (res interface{}, err error) {
 			defer env.recoverOptimizedInvoke(
 				lookup.Meta,
-				n.Form,
 				&res,
 				&err,
 			)
@@ -351,7 +350,6 @@ func (c threadedEvalCompiler) compile(n *ast.Node) evalFn {
 		return func(env *environment) (res interface{}, err error) {
 			defer env.recoverOptimizedInvoke(
 				assoc.Meta,
-				n.Form,
 				&res,
 				&err,
 			)
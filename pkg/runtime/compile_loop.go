@@ -100,6 +100,10 @@ func (loop *compiledInt64Loop) run(bindNameVals []interface{}) (interface{}, boo
 		values[i] = value
 	}
 	for loop.test(&values) {
+		if checksPending() && checkInterrupt() != nil {
+			// The general evaluator reports the interrupt.
+			return nil, false
+		}
 		var next [4]int64
 		for i, expr := range loop.next {
 			next[i] = expr(&values)
@@ -1,6 +1,16 @@
 package runtime
 
-import "github.com/glojurelang/glojure/pkg/lang"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
 
 func directLinkEnabled() bool {
 	compilerOptions := lang.NSCore.FindInternedVar(
@@ -21,3 +31,57 @@ func directLinkEnabled() bool {
 	}
 	return RT.BooleanCast(value)
 }
+
+// snapshotEnabled reports whether the :snapshot compiler option asks
+// for namespaces to be compiled to loaders that restore a snapshot of
+// their vars. See NamespaceSnapshot.
+func snapshotEnabled() bool {
+	compilerOptions := lang.NSCore.FindInternedVar(
+		lang.NewSymbol("*compiler-options*"),
+	)
+	if compilerOptions == nil || !compilerOptions.IsBound() {
+		return false
+	}
+	return RT.BooleanCast(lang.Get(compilerOptions.Get(), lang.KWSnapshot))
+}
+
+// compilerIdentity identifies the compiler, for the fingerprints of
+// generated loaders and the keys of cached ASTs: its build and the
+// optimization passes and special forms registered with it, which
+// change what it generates.
+func compilerIdentity() (string, bool) {
+	build, ok := compilerBuild()
+	if !ok {
+		return "", false
+	}
+	passes := compiler.RegisteredOptimizationPasses()
+	forms := compiler.RegisteredSpecialForms()
+	if len(passes) == 0 && len(forms) == 0 {
+		return build, true
+	}
+	return build + " passes " + strings.Join(passes, ",") +
+		" special-forms " + strings.Join(forms, ","), true
+}
+
+// compilerBuild identifies the build of the compiler.
+var compilerBuild = sync.OnceValues(func() (string, bool) {
+	if Version != "0.0.0" && !strings.Contains(Version, "+dirty") {
+		return Version, true
+	}
+	// Development builds share a version, so identify the compiler by
+	// the executable itself.
+	exe, err := os.Executable()
+	if err != nil {
+		return "", false
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", false
+	}
+	return Version + " " + hex.EncodeToString(h.Sum(nil)), true
+})
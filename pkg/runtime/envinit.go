@@ -68,6 +68,7 @@ type evalOptions struct {
 	stdout   io.Writer
 	stderr   io.Writer
 	loadPath []string
+	budget   Budget
 	env      *environment
 }
 
@@ -91,6 +92,15 @@ func WithLoadPath(path []string) EvalOption {
 	}
 }
 
+// WithBudget limits each evaluation in the environment to budget.
+// Evaluations that an evaluation makes, such as calls of eval or loads
+// of libs, share its budget.
+func WithBudget(budget Budget) EvalOption {
+	return func(opts *evalOptions) {
+		opts.budget = budget
+	}
+}
+
 func withEnv(env lang.Environment) EvalOption {
 	e := env.(*environment)
 	return func(opts *evalOptions) {
@@ -195,7 +205,7 @@ func NewEnvironment(opts ...EvalOption) lang.Environment {
 	}), true)
 
 	lang.InternVar(core, lang.NewSymbol("add-load-path"), lang.FnFunc1(func(path any) any {
-		AddLoadPath(os.DirFS(path.(string)))
+		addLoadPath(os.DirFS(path.(string)))
 		return nil
 	}), true)
 
@@ -228,5 +238,8 @@ func NewEnvironment(opts ...EvalOption) lang.Environment {
 
 	installGrenadineHost()
 
+	// The budget applies to evaluations after bootstrap.
+	env.budget = options.budget
+
 	return env
 }
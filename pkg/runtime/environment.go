@@ -46,6 +46,9 @@ type (
 		stderr io.Writer
 
 		loadPath []string
+
+		// budget limits each evaluation.
+		budget Budget
 	}
 )
 
@@ -65,7 +68,6 @@ func newEnvironment(ctx context.Context, stdout, stderr io.Writer) *environment
 		"compile-path",
 		"unchecked-math",
 		"compiler-options",
-		"err",
 		"flush-on-newline",
 		"print-meta",
 		"print-dup",
@@ -0,0 +1,362 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+var (
+	kwVia     = lang.NewKeyword("via")
+	kwTrace   = lang.NewKeyword("trace")
+	kwCause   = lang.NewKeyword("cause")
+	kwData    = lang.NewKeyword("data")
+	kwPhase   = lang.NewKeyword("phase")
+	kwMessage = lang.NewKeyword("message")
+	kwAt      = lang.NewKeyword("at")
+
+	kwErrorClass = lang.NewKeyword("clojure.error/class")
+	kwErrorCause = lang.NewKeyword("clojure.error/cause")
+	kwErrorPath  = lang.NewKeyword("clojure.error/path")
+)
+
+// errorClassNames maps error types to the names of the Clojure and Java
+// exceptions they stand for, which error reports show.
+var errorClassNames = map[reflect.Type]string{
+	reflect.TypeOf(&lang.ArityError{}):                "clojure.lang.ArityException",
+	reflect.TypeOf(&lang.ExceptionInfo{}):             "clojure.lang.ExceptionInfo",
+	reflect.TypeOf(&lang.CompilerError{}):             "clojure.lang.Compiler$CompilerException",
+	reflect.TypeOf(&lang.Error{}):                     "java.lang.Exception",
+	reflect.TypeOf(&lang.IllegalArgumentError{}):      "java.lang.IllegalArgumentException",
+	reflect.TypeOf(&lang.IllegalStateError{}):         "java.lang.IllegalStateException",
+	reflect.TypeOf(&lang.UnsupportedOperationError{}): "java.lang.UnsupportedOperationException",
+	reflect.TypeOf(&lang.ArithmeticError{}):           "java.lang.ArithmeticException",
+	reflect.TypeOf(&lang.IndexOutOfBoundsError{}):     "java.lang.IndexOutOfBoundsException",
+	reflect.TypeOf(&lang.TimeoutError{}):              "java.util.concurrent.TimeoutException",
+	reflect.TypeOf(&compiler.AnalysisError{}):         "java.lang.RuntimeException",
+	reflect.TypeOf(&reader.Error{}):                   "clojure.lang.LispReader$ReaderException",
+	reflect.TypeOf(errors.New("")):                    "java.lang.Exception",
+	reflect.TypeOf(fmt.Errorf("%w", errors.New(""))):  "java.lang.Exception",
+}
+
+// coreNamespaces are the namespaces whose frames an execution error
+// report skips to find the code that raised the error.
+var coreNamespaces = map[string]bool{
+	"clojure.core":           true,
+	"clojure.core.protocols": true,
+	"clojure.core.reducers":  true,
+	"clojure.main":           true,
+}
+
+// ThrowableMap returns a data representation of err, the value of
+// clojure.core/Throwable->map: a map of the chain of errors it wraps
+// under :via, the stack trace under :trace, the message and ex-data
+// of the innermost error under :cause and :data and the phase of
+// evaluation it occurred in, if it records one, under :phase.
+//
+// The wrappers that record the Glojure stack of an evaluation error
+// are left out of :via.
+func ThrowableMap(err any) lang.IPersistentMap {
+	e, ok := err.(error)
+	if !ok {
+		e = fmt.Errorf("%v", err)
+	}
+
+	var (
+		via   []any
+		trace []any
+		phase any
+		root  error
+	)
+	for ; e != nil; e = errors.Unwrap(e) {
+		if st, ok := e.(lang.Stacker); ok {
+			if trace == nil {
+				trace = stackTraceVecs(st.Stack())
+			}
+			if _, isEval := e.(*RTEvalError); isEval {
+				continue
+			}
+			if _, isEval := e.(*lang.EvalError); isEval {
+				continue
+			}
+		}
+		entry := lang.NewMap(
+			lang.KWType, lang.NewSymbol(errorClassName(e)),
+			kwMessage, errorMessage(e),
+		)
+		if data := errorData(e); data != nil {
+			entry = entry.Assoc(kwData, data).(lang.IPersistentMap)
+			if phase == nil {
+				phase = data.ValAt(lang.KWErrorPhase)
+			}
+		}
+		via = append(via, entry)
+		root = e
+	}
+	if root == nil {
+		return lang.NewMap(kwVia, lang.NewVector(), kwTrace, lang.NewVector())
+	}
+	if len(trace) > 0 {
+		via[0] = via[0].(lang.Associative).Assoc(kwAt, trace[0])
+	}
+
+	res := lang.NewMap(
+		kwVia, lang.NewVector(via...),
+		kwTrace, lang.NewVector(trace...),
+		kwCause, errorMessage(root),
+	)
+	if data := errorData(root); data != nil {
+		res = res.Assoc(kwData, data).(lang.IPersistentMap)
+	}
+	if phase != nil {
+		res = res.Assoc(kwPhase, phase).(lang.IPersistentMap)
+	}
+	return res
+}
+
+// ExTriage returns an analysis of the map ThrowableMap returns for an
+// error, the value of clojure.main/ex-triage: a map of the phase the
+// error occurred in, the class and message of its cause and the
+// source, line, column and symbol it can be attributed to, under
+// :clojure.error/ keys. The phase is :execution unless the map names
+// another.
+func ExTriage(throwableMap lang.IPersistentMap) lang.IPersistentMap {
+	via := lang.Get(throwableMap, kwVia)
+	trace := lang.Get(throwableMap, kwTrace)
+	phase := lang.GetDefault(throwableMap, kwPhase, lang.PhaseExecution)
+
+	last := lang.Get(via, lang.Count(via)-1)
+	class := lang.Get(last, lang.KWType)
+	message := lang.Get(last, kwMessage)
+
+	// Go errors are wrapped more freely than Java exceptions, so the
+	// phase and location are those of the outermost error that records
+	// them rather than of the first.
+	var topData lang.IPersistentMap = lang.NewMap()
+	for s := lang.Seq(via); s != nil; s = s.Next() {
+		data, _ := lang.Get(s.First(), kwData).(lang.IPersistentMap)
+		if data != nil && (data.ValAt(lang.KWErrorPhase) != nil || data.ValAt(lang.KWErrorLine) != nil) {
+			topData = data
+			break
+		}
+	}
+
+	var res lang.IPersistentMap
+	switch phase {
+	case lang.PhaseReadSource:
+		res = withErrorSource(topData)
+		if message != nil {
+			res = res.Assoc(kwErrorCause, message).(lang.IPersistentMap)
+		}
+	case lang.PhaseMacroSyntaxCheck, lang.PhaseMacroexpansion, lang.PhaseCompileSyntaxCheck:
+		res = withErrorSource(topData)
+		if class != nil {
+			res = res.Assoc(kwErrorClass, class).(lang.IPersistentMap)
+		}
+		if message != nil {
+			res = res.Assoc(kwErrorCause, message).(lang.IPersistentMap)
+		}
+	case lang.PhasePrintEvalResult:
+		res = topData
+		if elem := lang.First(trace); elem != nil {
+			res = withTraceElement(res, elem)
+		}
+		if class != nil {
+			res = res.Assoc(kwErrorClass, class).(lang.IPersistentMap)
+		}
+		if message != nil {
+			res = res.Assoc(kwErrorCause, message).(lang.IPersistentMap)
+		}
+	default:
+		res = lang.NewMap(kwErrorClass, class)
+		attributed := false
+		for s := lang.Seq(trace); s != nil; s = s.Next() {
+			if sym, ok := lang.First(s.First()).(*lang.Symbol); ok && coreNamespaces[sym.Namespace()] {
+				continue
+			}
+			res = withTraceElement(res, s.First())
+			attributed = true
+			break
+		}
+		// Top-level forms evaluated in clojure.core, as scripts are,
+		// are located by the last frame, that of the form.
+		if n := lang.Count(trace); !attributed && n > 0 {
+			res = withTraceSource(res, lang.Get(trace, n-1))
+		}
+		if message != nil {
+			res = res.Assoc(kwErrorCause, message).(lang.IPersistentMap)
+		}
+	}
+	return res.Assoc(lang.KWErrorPhase, phase).(lang.IPersistentMap)
+}
+
+// ExStr returns a message describing the error analysed by ExTriage,
+// the value of clojure.main/ex-str.
+func ExStr(triage lang.IPersistentMap) string {
+	get := func(k lang.Keyword) any { return triage.ValAt(k) }
+	source, path := get(lang.KWErrorSource), get(kwErrorPath)
+	line, column := get(lang.KWErrorLine), get(lang.KWErrorColumn)
+	cause := get(kwErrorCause)
+
+	loc := "REPL"
+	if path != nil {
+		loc = lang.ToString(path)
+	} else if source != nil {
+		loc = lang.ToString(source)
+	}
+	if line == nil {
+		line = 1
+	}
+	loc += ":" + lang.ToString(line)
+	if column != nil {
+		loc += ":" + lang.ToString(column)
+	}
+
+	causeType := ""
+	if class := get(kwErrorClass); class != nil {
+		name := lang.ToString(class)
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		if name != "Exception" && name != "RuntimeException" {
+			causeType = " (" + name + ")"
+		}
+	}
+	symbol := ""
+	if sym := get(lang.KWErrorSymbol); sym != nil {
+		symbol = lang.ToString(sym) + " "
+	}
+
+	switch get(lang.KWErrorPhase) {
+	case lang.PhaseReadSource:
+		return fmt.Sprintf("Syntax error reading source at (%s).\n%v\n", loc, cause)
+	case lang.PhaseMacroSyntaxCheck:
+		return fmt.Sprintf("Syntax error macroexpanding %sat (%s).\n%v\n", symbol, loc, cause)
+	case lang.PhaseMacroexpansion:
+		return fmt.Sprintf("Unexpected error%s macroexpanding %sat (%s).\n%v\n", causeType, symbol, loc, cause)
+	case lang.PhaseCompileSyntaxCheck:
+		return fmt.Sprintf("Syntax error%s compiling %sat (%s).\n%v\n", causeType, symbol, loc, cause)
+	case lang.PhasePrintEvalResult:
+		return fmt.Sprintf("Error printing return value%s at %s(%s).\n%v\n", causeType, symbol, loc, cause)
+	default:
+		return fmt.Sprintf("Execution error%s at %s(%s).\n%v\n", causeType, symbol, loc, cause)
+	}
+}
+
+// ErrorString returns the message the REPL prints for err, an error
+// raised in phase unless it records the phase it occurred in.
+func ErrorString(err any, phase lang.Keyword) string {
+	m := ThrowableMap(err)
+	if m.ValAt(kwPhase) == nil {
+		m = m.Assoc(kwPhase, phase).(lang.IPersistentMap)
+	}
+	return ExStr(ExTriage(m))
+}
+
+// errorClassName returns the name of the class err is reported as.
+func errorClassName(err error) string {
+	typ := reflect.TypeOf(err)
+	if name, ok := errorClassNames[typ]; ok {
+		return name
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Name() == "" {
+		return "java.lang.Exception"
+	}
+	return typ.String()
+}
+
+// errorMessage returns the message of err, without the messages of the
+// errors it wraps where it keeps them apart.
+func errorMessage(err error) string {
+	if m, ok := err.(interface{ Message() string }); ok {
+		return m.Message()
+	}
+	return err.Error()
+}
+
+// errorData returns the ex-data of err itself, not of an error it
+// wraps. Reader errors have the phase and location of the syntax
+// error.
+func errorData(err error) lang.IPersistentMap {
+	switch err := err.(type) {
+	case lang.IExceptionInfo:
+		return err.GetData()
+	case *reader.Error:
+		file, line, col := err.Position()
+		data := lang.NewMap(lang.KWErrorPhase, lang.PhaseReadSource, lang.KWErrorLine, line, lang.KWErrorColumn, col)
+		if file != "" {
+			data = data.Assoc(lang.KWErrorSource, file).(lang.IPersistentMap)
+		}
+		return data
+	}
+	return nil
+}
+
+// stackTraceVecs returns the [symbol method file line] vectors of
+// frames, those of clojure.core/StackTraceElement->vec. The symbol
+// names the function of the frame, and it and the method are nil for
+// frames outside any.
+func stackTraceVecs(frames []lang.StackFrame) []any {
+	vecs := make([]any, len(frames))
+	for i, frame := range frames {
+		var sym, method any
+		if name := frame.QualifiedName(); name != "" {
+			sym, method = lang.NewSymbol(name), lang.NewSymbol("invoke")
+		}
+		var file any
+		if frame.Filename != "" {
+			file = frame.Filename
+		}
+		vecs[i] = lang.NewVector(sym, method, file, frame.Line)
+	}
+	return vecs
+}
+
+// withErrorSource adds :clojure.error/path to data, a triage map, for
+// its :clojure.error/source, or removes the source if it's unknown.
+func withErrorSource(data lang.IPersistentMap) lang.IPersistentMap {
+	source, ok := data.ValAt(lang.KWErrorSource).(string)
+	if !ok {
+		return data
+	}
+	if !knownSource(source) {
+		return data.Without(lang.KWErrorSource)
+	}
+	return data.Assoc(kwErrorPath, source).(lang.IPersistentMap)
+}
+
+// withTraceElement adds the line, source and symbol of elem, a
+// [symbol method file line] vector, to data.
+func withTraceElement(data lang.IPersistentMap, elem any) lang.IPersistentMap {
+	data = withTraceSource(data, elem)
+	if sym := lang.Get(elem, 0); sym != nil {
+		data = data.Assoc(lang.KWErrorSymbol, sym).(lang.IPersistentMap)
+	}
+	return data
+}
+
+// withTraceSource adds the line and source of elem, a [symbol method
+// file line] vector, to data.
+func withTraceSource(data lang.IPersistentMap, elem any) lang.IPersistentMap {
+	if line := lang.Get(elem, 3); line != nil && line != 0 {
+		data = data.Assoc(lang.KWErrorLine, line).(lang.IPersistentMap)
+	}
+	if file, ok := lang.Get(elem, 2).(string); ok && knownSource(file) {
+		data = data.Assoc(lang.KWErrorSource, file).(lang.IPersistentMap)
+	}
+	return data
+}
+
+// knownSource reports whether file names a source file. The reader
+// names unnamed inputs like <unknown-file>.
+func knownSource(file string) bool {
+	return file != "" && !strings.HasPrefix(file, "<")
+}
@@ -0,0 +1,82 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestErrorString(t *testing.T) {
+	env := NewEnvironment().(*environment)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, env.CurrentNamespace()))
+	t.Cleanup(lang.PopThreadBindings)
+
+	rdr := reader.New(strings.NewReader(`
+(ns triage.test)
+(defn bar [x] x)
+(defn div [x] (/ 1 x))
+(defmacro checked [x] (when-not (symbol? x) (throw (ex-info "not a symbol" {:x x}))) x)
+(defmacro broken [] (/ 1 0))
+`), reader.WithFilename("setup.glj"))
+	for {
+		form, err := rdr.ReadOne()
+		if err == reader.ErrEOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := env.Eval(form); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"(bar 1 2)", "Execution error (ArityException) at triage.test/eval (foo.glj:1).\nwrong number of args (2) passed to: triage.test/bar\n"},
+		{"(div 0)", "Execution error (ArithmeticException) at triage.test/div (setup.glj:4).\ndivide by zero\n"},
+		{"(checked 1)", "Syntax error macroexpanding triage.test/checked at (foo.glj:1:1).\nnot a symbol\n"},
+		{"(checked)", "Syntax error (ArityException) compiling triage.test/checked at (foo.glj:1:1).\nwrong number of args (0) passed to: triage.test/checked\n"},
+		{"(broken)", "Unexpected error (ArithmeticException) macroexpanding triage.test/broken at (foo.glj:1:1).\ndivide by zero\n"},
+		{"(if)", "Syntax error compiling at (foo.glj:1:1).\n"},
+		{"(inc nope)", "Syntax error compiling at (foo.glj:1:6).\nunable to resolve symbol: nope\n"},
+		{"(inc 1", "Syntax error reading source at (foo.glj:1:6).\n"},
+	} {
+		rdr := reader.New(strings.NewReader(tc.code), reader.WithFilename("foo.glj"))
+		form, err := rdr.ReadOne()
+		phase := lang.PhaseReadSource
+		if err == nil {
+			phase = lang.PhaseExecution
+			_, err = env.Eval(form)
+		}
+		if err == nil {
+			t.Errorf("%s succeeded", tc.code)
+			continue
+		}
+		if got := ErrorString(err, phase); !strings.HasPrefix(got, tc.want) {
+			t.Errorf("%s reported\n%q\nwant\n%q", tc.code, got, tc.want)
+		}
+	}
+}
+
+func TestThrowableMap(t *testing.T) {
+	err := &RTEvalError{
+		Err:    lang.NewExceptionInfoWithCause("outer", lang.NewMap(lang.NewKeyword("a"), 1), lang.NewIllegalArgumentError("inner")),
+		frames: &frameList{frame: lang.StackFrame{Filename: "x.glj", Line: 3, Column: 2}},
+	}
+	m := ThrowableMap(err)
+	want := `{:via [{:type clojure.lang.ExceptionInfo, :message "outer", :data {:a 1}, :at [nil nil "x.glj" 3]} {:type java.lang.IllegalArgumentException, :message "inner"}], :trace [[nil nil "x.glj" 3]], :cause "inner"}`
+	if got := lang.PrintString(m); got != want {
+		t.Errorf("ThrowableMap =\n%s\nwant\n%s", got, want)
+	}
+	triage := ExTriage(m)
+	if got := lang.PrintString(triage); !strings.Contains(got, ":clojure.error/phase :execution") || !strings.Contains(got, `:clojure.error/cause "inner"`) {
+		t.Errorf("ExTriage = %s", got)
+	}
+}
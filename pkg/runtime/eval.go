@@ -1,8 +1,11 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
+	"github.com/glojurelang/glojure/pkg/ast"
 	"github.com/glojurelang/glojure/pkg/compiler"
 	"github.com/glojurelang/glojure/pkg/lang"
 	"github.com/glojurelang/glojure/pkg/pkgmap"
@@ -58,7 +61,11 @@ func (env *environment) macroexpand1(
 	if len(symStr) > 1 && symStr[0] == '.' && symStr[1] != '.' {
 		fieldSym := lang.NewSymbol(sym.String()[1:])
 		// rewrite the expression to a dot expression
-		dotExpr := lang.NewCons(SymbolDot, lang.NewCons(seq.Next().First(), lang.NewCons(fieldSym, seq.Next().Next())))
+		var dotExpr any = lang.NewCons(SymbolDot, lang.NewCons(seq.Next().First(), lang.NewCons(fieldSym, seq.Next().Next())))
+		// Keep the position of the form for the analyzer's warnings.
+		if obj, ok := form.(lang.IMeta); ok && obj.Meta() != nil {
+			dotExpr = dotExpr.(*lang.Cons).WithMeta(obj.Meta())
+		}
 		return env.macroexpand1(dotExpr, currentNS)
 	}
 
@@ -73,12 +80,17 @@ func (env *environment) macroexpand1(
 	}
 	res, err := env.applyMacro(applyer, form.(lang.ISeq))
 	if err != nil {
-		return nil, env.errorf(form, "error applying macro: %w", err)
+		return nil, macroexpansionError(form, macroVar, err)
 	}
 	return res, nil
 }
 
-func (env *environment) applyMacro(fn lang.IFn, form lang.ISeq) (interface{}, error) {
+func (env *environment) applyMacro(fn lang.IFn, form lang.ISeq) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, PanicError(r)
+		}
+	}()
 	argList := form.Next()
 	// two hidden arguments, $form and $env (nil for now).
 	// $form is the form that was passed to the macro
@@ -86,18 +98,103 @@ func (env *environment) applyMacro(fn lang.IFn, form lang.ISeq) (interface{}, er
 	return fn.ApplyTo(lang.NewCons(form, lang.NewCons(nil, argList))), nil
 }
 
-func (env *environment) Eval(n interface{}) (interface{}, error) {
+// macroexpansionError returns the error for err, raised expanding form,
+// a call of the macro macroVar. As in Clojure, ex-info thrown by a
+// macro reports a syntax error in the call and a call with the wrong
+// number of arguments is a syntax error of the macro, not counting its
+// hidden arguments; other errors are unexpected.
+func macroexpansionError(form interface{}, macroVar *lang.Var, err error) error {
+	var compilerErr *lang.CompilerError
+	if errors.As(err, &compilerErr) {
+		return err
+	}
+	name := lang.NewSymbol(macroVar.Namespace().Name().Name() + "/" + macroVar.Symbol().Name())
+	phase := lang.PhaseMacroexpansion
+	var arityErr *lang.ArityError
+	switch {
+	case errors.As(err, &arityErr) && (arityErr.Name == "" || arityErr.Name == name.String()):
+		phase = lang.PhaseCompileSyntaxCheck
+		err = lang.NewArityError(arityErr.Actual-2, name.String())
+	case isExceptionInfo(err):
+		phase = lang.PhaseMacroSyntaxCheck
+	}
+	var meta lang.IPersistentMap
+	if obj, ok := form.(lang.IMeta); ok {
+		meta = obj.Meta()
+	}
+	frame := metaStackFrame(meta)
+	return lang.NewCompilerErrorInPhase(phase, frame.Filename, frame.Line, frame.Column, name, err)
+}
+
+// isExceptionInfo reports whether err, apart from the stack trace
+// recorded for it, is an ExceptionInfo.
+func isExceptionInfo(err error) bool {
+	if evalErr, ok := err.(*RTEvalError); ok {
+		err = evalErr.Err
+	}
+	_, ok := err.(*lang.ExceptionInfo)
+	return ok
+}
+
+func (env *environment) Eval(n interface{}) (res interface{}, err error) {
 	if directSelfEvaluating(n) {
 		return n, nil
 	}
-	currentNS := env.CurrentNamespace()
-	if result, ok, err := env.evalDirectInt64ReducePipeline(n, currentNS); ok {
-		return result, err
+	return env.evalTopLevel(n, func() (interface{}, error) {
+		return env.eval(n, nil)
+	})
+}
+
+// evalTopLevel calls eval to evaluate n, a top-level form, within the
+// environment's budget.
+func (env *environment) evalTopLevel(n interface{}, eval func() (interface{}, error)) (res interface{}, err error) {
+	defer recoverEval(n, env.CurrentNamespace(), &err)
+	if env.budget != (Budget{}) && currentBudget() == nil {
+		env.budget.Run(func() {
+			res, err = eval()
+		})
+		return res, err
+	}
+	return eval()
+}
+
+// recoverEval names the frame of the stack trace of an error raised
+// evaluating n, a top-level form, in ns as ns/eval, as Clojure names
+// the class it compiles a form to.
+func recoverEval(n interface{}, ns *lang.Namespace, err *error) {
+	if r := recover(); r != nil {
+		panic(evalFormError(PanicError(r), n, ns))
+	}
+	if evalErr, ok := (*err).(*RTEvalError); ok {
+		*err = evalFormError(evalErr, n, ns)
 	}
-	if result, ok, err := env.evalDirectInvoke(n, currentNS); ok {
-		return result, err
+}
+
+func evalFormError(err error, n interface{}, ns *lang.Namespace) *RTEvalError {
+	evalErr, ok := err.(*RTEvalError)
+	if !ok {
+		evalErr = &RTEvalError{Err: err, pcs: callers()}
+	}
+	return evalErr.inFunction(ns.Name().Name(), "eval", func() lang.StackFrame {
+		return formStackFrame(n)
+	})
+}
+
+// eval evaluates n. If n is analyzed rather than evaluated directly and
+// analyzed is not nil, analyzed is called with its AST before it is
+// evaluated.
+func (env *environment) eval(n interface{}, analyzed func(*ast.Node)) (interface{}, error) {
+	currentNS := env.CurrentNamespace()
+	// The direct paths skip analysis, which enforces the sandbox.
+	if currentSandbox() == nil {
+		if result, ok, err := env.evalDirectInt64ReducePipeline(n, currentNS); ok {
+			return result, err
+		}
+		if result, ok, err := env.evalDirectInvoke(n, currentNS); ok {
+			return result, err
+		}
 	}
-	return env.evalInternalInNamespace(n, currentNS)
+	return env.evalInternalInNamespace(n, currentNS, analyzed)
 }
 
 func directSelfEvaluating(form interface{}) bool {
@@ -115,44 +212,47 @@ func directSelfEvaluating(form interface{}) bool {
 
 func (env *environment) evalInternal(n interface{}) (interface{}, error) {
 	currentNS := env.CurrentNamespace()
-	return env.evalInternalInNamespace(n, currentNS)
+	return env.evalInternalInNamespace(n, currentNS, nil)
 }
 
 func (env *environment) evalInternalInNamespace(
 	n interface{},
 	currentNS *lang.Namespace,
+	analyzed func(*ast.Node),
 ) (interface{}, error) {
-	analyzer := &compiler.Analyzer{
-		Macroexpand1: func(form interface{}) (interface{}, error) {
-			return env.macroexpand1(form, currentNS)
-		},
-		CreateVar: func(sym *lang.Symbol, e compiler.Env) (interface{}, error) {
-			vr := currentNS.Intern(sym)
-			return vr, nil
-		},
-		IsVar: func(v interface{}) bool {
-			_, ok := v.(*lang.Var)
-			return ok
-		},
-		Gensym: func(prefix string) *lang.Symbol {
-			num := env.nextSymNum()
-			return lang.NewSymbol(fmt.Sprintf("%s%d", prefix, num))
-		},
-		FindNamespace: lang.FindNamespace,
-		ResolveHost:   resolveHost,
-		Optimizer: compiler.NewDefaultOptimizer(compiler.OptimizationOptions{
-			DirectLinking: directLinkEnabled(),
-		}),
-	}
-	astNode, err := analyzer.Analyze(n, lang.NewMap(
-		lang.KWNS, currentNS.Name(),
-	))
+	astNode, err := env.analyze(n, currentNS)
 	if err != nil {
-		return nil, err
+		return nil, analysisError(n, err)
+	}
+	if analyzed != nil {
+		analyzed(astNode)
 	}
 	return env.EvalAST(astNode)
 }
 
+// analysisError returns the error for err, raised analyzing form, as a
+// syntax error at the offending form or else at form. Errors that
+// already record their phase, and those that carry ex-data, such as
+// sandbox violations, are returned as they are.
+func analysisError(form interface{}, err error) error {
+	var info lang.IExceptionInfo
+	if errors.As(err, &info) {
+		return err
+	}
+	var analysisErr *compiler.AnalysisError
+	if errors.As(err, &analysisErr) && analysisErr.Form != nil {
+		if obj, ok := analysisErr.Form.(lang.IMeta); ok && obj.Meta() != nil && lang.Get(obj.Meta(), lang.KWLine) != nil {
+			form = analysisErr.Form
+		}
+	}
+	var meta lang.IPersistentMap
+	if obj, ok := form.(lang.IMeta); ok {
+		meta = obj.Meta()
+	}
+	frame := metaStackFrame(meta)
+	return lang.NewCompilerErrorInPhase(lang.PhaseCompileSyntaxCheck, frame.Filename, frame.Line, frame.Column, nil, err)
+}
+
 func (env *environment) evalDirectInvoke(
 	form interface{},
 	currentNS *lang.Namespace,
@@ -310,3 +410,51 @@ func seqToSlice(seq lang.ISeq) []interface{} {
 	}
 	return items
 }
+
+// Analyze macroexpands and analyzes form in ns without evaluating it,
+// for tools that inspect code, such as the language server. As when
+// evaluating, analyzing a def interns its var.
+func Analyze(form interface{}, ns *lang.Namespace) (*ast.Node, error) {
+	return lang.GlobalEnv.(*environment).analyze(form, ns)
+}
+
+func (env *environment) analyze(n interface{}, currentNS *lang.Namespace) (*ast.Node, error) {
+	analyzer := &compiler.Analyzer{
+		Macroexpand1: func(form interface{}) (interface{}, error) {
+			return env.macroexpand1(form, currentNS)
+		},
+		CreateVar: func(sym *lang.Symbol, e compiler.Env) (interface{}, error) {
+			vr := currentNS.Intern(sym)
+			return vr, nil
+		},
+		IsVar: func(v interface{}) bool {
+			_, ok := v.(*lang.Var)
+			return ok
+		},
+		Gensym: func(prefix string) *lang.Symbol {
+			num := env.nextSymNum()
+			return lang.NewSymbol(fmt.Sprintf("%s%d", prefix, num))
+		},
+		FindNamespace: lang.FindNamespace,
+		ResolveHost:   resolveHost,
+		Sandbox:       currentSandbox(),
+		Optimizer: compiler.NewDefaultOptimizer(compiler.OptimizationOptions{
+			DirectLinking: directLinkEnabled(),
+		}),
+	}
+	if lang.IsTruthy(lang.VarWarnOnReflection.Deref()) {
+		analyzer.ReflectionWarning = env.warn
+	}
+	return analyzer.Analyze(n, lang.NewMap(
+		lang.KWNS, currentNS.Name(),
+	))
+}
+
+// warn writes a compiler warning to *err*.
+func (env *environment) warn(msg string) {
+	w, ok := lang.VarErr.Deref().(io.Writer)
+	if !ok {
+		w = env.Stderr()
+	}
+	fmt.Fprintln(w, msg)
+}
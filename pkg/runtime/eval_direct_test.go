@@ -99,7 +99,7 @@ func TestEvalDirectInvokeBuildsRuntimeErrorFrame(t *testing.T) {
 	if !errors.As(err, &evalErr) {
 		t.Fatalf("direct invoke error = %T %v, want RTEvalError", err, err)
 	}
-	if len(evalErr.GLJStack) != 1 {
-		t.Fatalf("GLJ stack has %d frames, want 1", len(evalErr.GLJStack))
+	if len(evalErr.Stack()) != 1 {
+		t.Fatalf("GLJ stack has %d frames, want 1", len(evalErr.Stack()))
 	}
 }
@@ -4,10 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"runtime/debug"
 	"strings"
 
 	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/compiler"
 	"github.com/glojurelang/glojure/pkg/lang"
 	"github.com/glojurelang/glojure/pkg/pkgmap"
 
@@ -38,27 +38,19 @@ var (
 	SymInNS = lang.NewSymbol("in-ns")
 )
 
+// RTEvalError is an error raised evaluating Glojure code, with the
+// stack trace of the code it was raised in.
 type RTEvalError struct {
-	Err      error
-	GLJStack []string
-	GoStack  string
+	Err error
+
+	// frames are the frames recorded as the error unwound.
+	frames *frameList
+	// pcs is the Go stack where the error was first recorded.
+	pcs []uintptr
 }
 
 func (e *RTEvalError) Error() string {
-	sb := strings.Builder{}
-	sb.WriteString(e.Err.Error())
-	sb.WriteString("\n\n")
-	if e.GoStack != "" && false {
-		sb.WriteString("Go Stack:\n")
-		sb.WriteString(e.GoStack)
-		sb.WriteString("\n\n")
-	}
-	sb.WriteString("GLJ Stack:\n")
-	for _, s := range e.GLJStack {
-		sb.WriteString(s)
-		sb.WriteString("\n")
-	}
-	return sb.String()
+	return e.Err.Error()
 }
 
 func (e *RTEvalError) Unwrap() error {
@@ -138,6 +130,9 @@ func (env *environment) EvalAST(x interface{}) (ret interface{}, err error) {
 	case ast.OpThrow:
 		return env.EvalASTThrow(n)
 	default:
+		if ast.IsExtensionOp(n.Op) {
+			return env.evalExtension(n)
+		}
 		panic(fmt.Errorf("unimplemented op: %d. Form: %s", n.Op, lang.ToString(n.Form)))
 	}
 }
@@ -148,7 +143,6 @@ func (env *environment) EvalASTKeywordLookup(
 	lookup := n.Sub.(*ast.KeywordLookupNode)
 	defer env.recoverOptimizedInvoke(
 		lookup.Meta,
-		n.Form,
 		&res,
 		&err,
 	)
@@ -172,7 +166,6 @@ func (env *environment) EvalASTAssoc(
 	assoc := n.Sub.(*ast.AssocNode)
 	defer env.recoverOptimizedInvoke(
 		assoc.Meta,
-		n.Form,
 		&res,
 		&err,
 	)
@@ -201,11 +194,10 @@ func (env *environment) EvalASTAssoc(
 
 func (env *environment) recoverOptimizedInvoke(
 	meta lang.IPersistentMap,
-	form interface{},
 	res *interface{},
 	err *error,
 ) {
-	env.recoverInvokeForm(recover(), meta, form, res, err)
+	env.recoverInvokeForm(recover(), meta, res, err)
 }
 
 func (env *environment) EvalASTReplaceLast(
@@ -231,7 +223,7 @@ func (env *environment) recoverReplaceLast(
 	err *error,
 ) {
 	replace := n.Sub.(*ast.ReplaceLastNode)
-	env.recoverInvokeForm(recover(), replace.Meta, n.Form, res, err)
+	env.recoverInvokeForm(recover(), replace.Meta, res, err)
 }
 
 func (env *environment) EvalASTDef(n *ast.Node) (interface{}, error) {
@@ -258,6 +250,7 @@ func (env *environment) EvalASTDef(n *ast.Node) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	nameDefinedFn(init, initVal, vr)
 	vr.BindRoot(initVal)
 
 	return vr, nil
@@ -326,7 +319,7 @@ var (
 )
 
 func (c *evalCompiler) Specials() *lang.Set {
-	return lang.NewSet(
+	specials := []any{
 		lang.NewSymbol("def"),
 		lang.NewSymbol("if"),
 		lang.NewSymbol("do"),
@@ -347,7 +340,11 @@ func (c *evalCompiler) Specials() *lang.Set {
 		lang.NewSymbol("&"),
 		lang.NewSymbol("case*"),
 		lang.NewSymbol("deftype*"),
-	)
+	}
+	for _, name := range compiler.RegisteredSpecialForms() {
+		specials = append(specials, lang.NewSymbol(name))
+	}
+	return lang.NewSet(specials...)
 }
 
 func (c *evalCompiler) Eval(form interface{}) interface{} {
@@ -404,13 +401,9 @@ func (env *environment) EvalASTMaybeClass(n *ast.Node) (interface{}, error) {
 	if ok {
 		meta = imeta.Meta()
 	}
-	err := &RTEvalError{
-		Err: errors.New("unable to resolve symbol: " + lang.ToString(sym)),
-		GLJStack: []string{
-			fmt.Sprintf("%s:%d:%d:\t%s", lang.Get(meta, KWFile), lang.Get(meta, KWLine), lang.Get(meta, KWColumn), n.Form),
-		},
-		GoStack: string(debug.Stack()),
-	}
+	frame := metaStackFrame(meta)
+	err := newEvalError(lang.NewCompilerErrorInPhase(lang.PhaseCompileSyntaxCheck, frame.Filename, frame.Line, frame.Column, nil,
+		errors.New("unable to resolve symbol: "+lang.ToString(sym))), frame)
 	return nil, err
 }
 
@@ -839,6 +832,9 @@ func (env *environment) EvalASTLet(n *ast.Node, isLoop bool) (interface{}, error
 	}
 
 Recur:
+	if err := checkInterrupt(); err != nil {
+		return nil, err
+	}
 	for i := 0; i < len(bindNameVals); i += 2 {
 		name := bindNameVals[i].(*lang.Symbol)
 		val := bindNameVals[i+1]
@@ -958,7 +954,7 @@ func (env *environment) evalASTInvokeDefault(
 
 func (env *environment) recoverInvoke(n *ast.Node, res *interface{}, err *error) {
 	invokeNode := n.Sub.(*ast.InvokeNode)
-	env.recoverInvokeForm(recover(), invokeNode.Meta, n.Form, res, err)
+	env.recoverInvokeForm(recover(), invokeNode.Meta, res, err)
 }
 
 func (env *environment) recoverDirectInvoke(
@@ -970,44 +966,43 @@ func (env *environment) recoverDirectInvoke(
 	if obj, ok := form.(lang.IObj); ok {
 		meta = obj.Meta()
 	}
-	env.recoverInvokeForm(recover(), meta, form, res, err)
+	env.recoverInvokeForm(recover(), meta, res, err)
 }
 
 func (env *environment) recoverInvokeForm(
 	r interface{},
 	meta lang.IPersistentMap,
-	form interface{},
 	res *interface{},
 	err *error,
 ) {
 	if r == nil {
 		return
 	}
-	gljFrame := fmt.Sprintf("%s:%d:%d:\t%s", lang.Get(meta, KWFile), lang.Get(meta, KWLine), lang.Get(meta, KWColumn), form)
 	*res = nil
-	if rErr, ok := r.(error); ok {
-		if errors.Is(rErr, &RTEvalError{}) {
-			var evalErr *RTEvalError
-			errors.As(rErr, &evalErr)
-			evalErr.GLJStack = append(evalErr.GLJStack, gljFrame) // TODO: copy
-			if evalErr.GoStack == "" {
-				evalErr.GoStack = string(debug.Stack())
-			}
-			*err = evalErr
-		} else {
-			*err = &RTEvalError{
-				Err:      rErr,
-				GLJStack: []string{gljFrame},
-				GoStack:  string(debug.Stack()),
-			}
-		}
-	} else {
-		*err = &RTEvalError{
-			Err:      fmt.Errorf("%v", r),
-			GLJStack: []string{gljFrame},
-			GoStack:  string(debug.Stack()),
-		}
+	frame := metaStackFrame(meta)
+	if evalErr, ok := r.(*RTEvalError); ok {
+		*err = evalErr.withFrame(frame)
+		return
+	}
+	*err = newEvalError(PanicError(r), frame)
+}
+
+// formStackFrame returns the stack frame for the position of a form or
+// var.
+func formStackFrame(x any) lang.StackFrame {
+	var meta lang.IPersistentMap
+	if obj, ok := x.(lang.IMeta); ok {
+		meta = obj.Meta()
 	}
+	return metaStackFrame(meta)
+}
+
+// metaStackFrame returns the stack frame for a form with meta.
+func metaStackFrame(meta lang.IPersistentMap) lang.StackFrame {
+	file, _ := lang.Get(meta, KWFile).(string)
+	line, _ := lang.Get(meta, KWLine).(int)
+	col, _ := lang.Get(meta, KWColumn).(int)
+	return lang.StackFrame{Filename: file, Line: line, Column: col}
 }
 
 // evalASTApply evaluates call arguments and uses the fixed-arity dispatch
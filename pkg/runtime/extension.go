@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// NodeEvaluator evaluates nodes of an op allocated with ast.RegisterOp.
+// eval evaluates another node, such as one of the node's Args, in the
+// environment the node is evaluated in.
+type NodeEvaluator func(n *ast.Node, eval func(*ast.Node) (any, error)) (any, error)
+
+// NodeGenerator writes the Go code computing a node of an op allocated
+// with ast.RegisterOp into an AOT-compiled namespace loader, and returns
+// a Go expression holding its value.
+type NodeGenerator func(g CodeGenerator, n *ast.Node) (string, error)
+
+// CodeGenerator is the code generator of an AOT-compiled loader, as a
+// NodeGenerator sees it.
+type CodeGenerator interface {
+	// GenerateNode writes the code computing n and returns a Go
+	// expression holding its value.
+	GenerateNode(n *ast.Node) string
+	// GenerateValue returns a Go expression constructing v.
+	GenerateValue(v any) string
+	// Writef writes a statement.
+	Writef(format string, args ...any)
+	// TempVar returns the name of a fresh variable.
+	TempVar() string
+	// Import imports the Go package pkg and returns its name.
+	Import(pkg string) string
+}
+
+var (
+	extensionOpsMtx sync.RWMutex
+	nodeEvaluators  = map[ast.NodeOp]NodeEvaluator{}
+	nodeGenerators  = map[ast.NodeOp]NodeGenerator{}
+)
+
+// RegisterNodeEvaluator makes eval evaluate the nodes of op.
+func RegisterNodeEvaluator(op ast.NodeOp, eval NodeEvaluator) error {
+	if eval == nil {
+		return fmt.Errorf("nil evaluator for %s", ast.OpName(op))
+	}
+	return registerExtensionOp(nodeEvaluators, op, eval, "evaluator")
+}
+
+// RegisterNodeGenerator makes gen generate the code for the nodes of op
+// when a namespace is AOT-compiled. Without one, namespaces using op
+// can only be evaluated.
+func RegisterNodeGenerator(op ast.NodeOp, gen NodeGenerator) error {
+	if gen == nil {
+		return fmt.Errorf("nil code generator for %s", ast.OpName(op))
+	}
+	return registerExtensionOp(nodeGenerators, op, gen, "code generator")
+}
+
+func registerExtensionOp[T any](hooks map[ast.NodeOp]T, op ast.NodeOp, hook T, kind string) error {
+	if !ast.IsExtensionOp(op) {
+		return fmt.Errorf("op %d was not allocated with ast.RegisterOp", op)
+	}
+	extensionOpsMtx.Lock()
+	defer extensionOpsMtx.Unlock()
+	if _, ok := hooks[op]; ok {
+		return fmt.Errorf("%s already has a %s", ast.OpName(op), kind)
+	}
+	hooks[op] = hook
+	return nil
+}
+
+func nodeEvaluator(op ast.NodeOp) NodeEvaluator {
+	extensionOpsMtx.RLock()
+	defer extensionOpsMtx.RUnlock()
+	return nodeEvaluators[op]
+}
+
+func nodeGenerator(op ast.NodeOp) NodeGenerator {
+	extensionOpsMtx.RLock()
+	defer extensionOpsMtx.RUnlock()
+	return nodeGenerators[op]
+}
+
+// evalExtension evaluates a node of an op allocated with
+// ast.RegisterOp.
+func (env *environment) evalExtension(n *ast.Node) (interface{}, error) {
+	eval := nodeEvaluator(n.Op)
+	if eval == nil {
+		panic(fmt.Errorf("no evaluator for %s. Form: %s", ast.OpName(n.Op), lang.ToString(n.Form)))
+	}
+	return eval(n, func(child *ast.Node) (any, error) {
+		return env.EvalAST(child)
+	})
+}
@@ -0,0 +1,145 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+var registerUnless sync.Once
+
+// registerUnlessForm registers (extension.test/unless test then), which
+// evaluates then only if test is false or nil.
+func registerUnlessForm(t *testing.T) {
+	registerUnless.Do(func() {
+		op := ast.RegisterOp("extension.test/unless")
+		err := compiler.RegisterSpecialForm("extension.test/unless",
+			func(a *compiler.Analyzer, form interface{}, env compiler.Env) (*ast.Node, error) {
+				if lang.Count(form) != 3 {
+					return nil, fmt.Errorf("unless takes a test and an expression")
+				}
+				sub := &ast.ExtensionNode{}
+				for seq := lang.Seq(lang.Rest(form)); seq != nil; seq = seq.Next() {
+					arg, err := a.AnalyzeExpr(seq.First(), env)
+					if err != nil {
+						return nil, err
+					}
+					sub.Args = append(sub.Args, arg)
+				}
+				n := ast.MakeNode(op, form)
+				n.Env = env
+				n.Sub = sub
+				return n, nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = RegisterNodeEvaluator(op, func(n *ast.Node, eval func(*ast.Node) (any, error)) (any, error) {
+			args := n.Sub.(*ast.ExtensionNode).Args
+			test, err := eval(args[0])
+			if err != nil || lang.IsTruthy(test) {
+				return nil, err
+			}
+			return eval(args[1])
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = RegisterNodeGenerator(op, func(g CodeGenerator, n *ast.Node) (string, error) {
+			args := n.Sub.(*ast.ExtensionNode).Args
+			langPkg := g.Import("github.com/glojurelang/glojure/pkg/lang")
+			test := g.GenerateNode(args[0])
+			res := g.TempVar()
+			g.Writef("var %s any\n", res)
+			g.Writef("if !%s.IsTruthy(%s) {\n", langPkg, test)
+			g.Writef("%s = %s\n", res, g.GenerateNode(args[1]))
+			g.Writef("}\n")
+			return res, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestRegisteredSpecialFormEvaluation(t *testing.T) {
+	registerUnlessForm(t)
+	ns := lang.FindOrCreateNamespace(lang.NewSymbol("extension.eval"))
+	ns.ReferAllSnapshot(lang.NSCore, nil)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, ns))
+	defer lang.PopThreadBindings()
+
+	got := ReadEval(`
+		(defn f [x] (extension.test/unless (nil? x) (inc x)))
+		[(f nil) (f 1)
+		 (extension.test/unless true (throw (ex-info "evaluated" {})))
+		 (special-symbol? 'extension.test/unless)]`)
+	if s := lang.PrintString(got); s != "[nil 2 nil true]" {
+		t.Fatalf("evaluated %s, want [nil 2 nil true]", s)
+	}
+
+	var output bytes.Buffer
+	g := newGenerator(&output, false)
+	if err := g.Generate(ns); err != nil {
+		t.Fatalf("generate loader: %v", err)
+	}
+	generated := output.String()
+	if _, err := parser.ParseFile(token.NewFileSet(), "load.go", generated, 0); err != nil {
+		t.Fatalf("generated loader does not parse: %v\n%s", err, generated)
+	}
+	if !strings.Contains(generated, ".IsTruthy(") {
+		t.Errorf("generated loader omitted the special form's code:\n%s", generated)
+	}
+}
+
+func TestRegisteredSpecialFormASTCache(t *testing.T) {
+	registerUnlessForm(t)
+	if compiler, ok := compilerIdentity(); !ok || !strings.Contains(compiler, "extension.test/unless") {
+		t.Fatalf("compiler identity %q omits the registered special form", compiler)
+	}
+
+	SetASTCacheDir(t.TempDir())
+	t.Cleanup(func() { SetASTCacheDir("") })
+	env := NewEnvironment().(*environment)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, env.CurrentNamespace()))
+	t.Cleanup(lang.PopThreadBindings)
+
+	const code = `(ns extension.cache)
+[(extension.test/unless false 1) (extension.test/unless true 2)]`
+	for _, wantReplayed := range []int{0, 2} {
+		s := newScript(env, "cache.glj", "test cache.glj", code)
+		var res any
+		for {
+			form, err := s.ReadOne()
+			if err == reader.ErrEOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res, err = s.Eval(form); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got := lang.PrintString(res); got != "[1 nil]" {
+			t.Errorf("script returned %s, want [1 nil]", got)
+		}
+		if len(s.replayed) != wantReplayed {
+			t.Errorf("%d forms evaluated from the cache, want %d", len(s.replayed), wantReplayed)
+		}
+	}
+}
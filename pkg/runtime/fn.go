@@ -11,6 +11,9 @@ import (
 
 type Fn struct {
 	meta lang.IPersistentMap
+	// def is the var the function was defined as, if any, for profile
+	// labels and stack traces.
+	def *lang.Var
 
 	astNode *ast.Node
 	env     lang.Environment
@@ -178,7 +181,16 @@ func (fn *Fn) invokeSingle1(a0 interface{}) interface{} {
 		if !frame.captured {
 			fn.releaseFrame(frame)
 		}
+		if r := recover(); r != nil {
+			panic(fn.stackError(r))
+		}
 	}()
+	if err := checkInterrupt(); err != nil {
+		panic(err)
+	}
+	if profileLabels.Load() {
+		defer popProfileLabels(fn.pushProfileLabels())
+	}
 
 	if fn.singleLocal != nil {
 		frame.env.BindLocal(fn.singleLocal, fn)
@@ -186,7 +198,7 @@ func (fn *Fn) invokeSingle1(a0 interface{}) interface{} {
 	frame.args[0] = a0
 	res, err := fn.singleEval(&frame.env)
 	if err != nil {
-		panic(errorWithStack(err, lang.StackFrame{}))
+		panic(err)
 	}
 	return res
 }
@@ -197,7 +209,16 @@ func (fn *Fn) invokeSingle2(a0, a1 interface{}) interface{} {
 		if !frame.captured {
 			fn.releaseFrame(frame)
 		}
+		if r := recover(); r != nil {
+			panic(fn.stackError(r))
+		}
 	}()
+	if err := checkInterrupt(); err != nil {
+		panic(err)
+	}
+	if profileLabels.Load() {
+		defer popProfileLabels(fn.pushProfileLabels())
+	}
 
 	if fn.singleLocal != nil {
 		frame.env.BindLocal(fn.singleLocal, fn)
@@ -206,7 +227,7 @@ func (fn *Fn) invokeSingle2(a0, a1 interface{}) interface{} {
 	frame.args[1] = a1
 	res, err := fn.singleEval(&frame.env)
 	if err != nil {
-		panic(errorWithStack(err, lang.StackFrame{}))
+		panic(err)
 	}
 	return res
 }
@@ -217,7 +238,16 @@ func (fn *Fn) invokeSingle3(a0, a1, a2 interface{}) interface{} {
 		if !frame.captured {
 			fn.releaseFrame(frame)
 		}
+		if r := recover(); r != nil {
+			panic(fn.stackError(r))
+		}
 	}()
+	if err := checkInterrupt(); err != nil {
+		panic(err)
+	}
+	if profileLabels.Load() {
+		defer popProfileLabels(fn.pushProfileLabels())
+	}
 
 	if fn.singleLocal != nil {
 		frame.env.BindLocal(fn.singleLocal, fn)
@@ -227,7 +257,7 @@ func (fn *Fn) invokeSingle3(a0, a1, a2 interface{}) interface{} {
 	frame.args[2] = a2
 	res, err := fn.singleEval(&frame.env)
 	if err != nil {
-		panic(errorWithStack(err, lang.StackFrame{}))
+		panic(err)
 	}
 	return res
 }
@@ -265,7 +295,7 @@ func (fn *Fn) invoke(args []interface{}) interface{} {
 	maxArity := fnNode.MaxFixedArity
 
 	if !variadic && len(args) > maxArity {
-		panic(lang.NewIllegalArgumentError(fmt.Sprintf("too many arguments (%d)", len(args))))
+		panic(fn.arityError(len(args)))
 	}
 
 	method, err := fn.findMethod(args)
@@ -304,7 +334,13 @@ func (fn *Fn) invokeMethod(
 		if !frame.captured {
 			fn.releaseFrame(frame)
 		}
+		if r := recover(); r != nil {
+			panic(fn.stackError(r))
+		}
 	}()
+	if profileLabels.Load() {
+		defer popProfileLabels(fn.pushProfileLabels())
+	}
 
 	if fnNode.Local != nil {
 		localNode := fnNode.Local.Sub.(*ast.BindingNode)
@@ -323,6 +359,9 @@ func (fn *Fn) invokeMethod(
 	}
 
 Recur:
+	if err := checkInterrupt(); err != nil {
+		panic(err)
+	}
 
 	params := methodNode.Params
 	for i, paramValue := range bindingValues {
@@ -386,7 +425,7 @@ Recur:
 			}
 			goto Recur
 		}
-		panic(errorWithStack(err, lang.StackFrame{})) // TODO: think through error stacks
+		panic(err)
 	}
 	return res
 }
@@ -398,13 +437,13 @@ func (fn *Fn) findMethod(args []interface{}) (*ast.Node, error) {
 			methodNode.IsVariadic && len(args) >= methodNode.FixedArity {
 			return fn.singleMethod, nil
 		}
-		return nil, lang.NewIllegalArgumentError(fmt.Sprintf("wrong number of arguments (%d)", len(args)))
+		return nil, fn.arityError(len(args))
 	}
 	if method := fn.methodsByArity[len(args)]; method != nil {
 		return method, nil
 	}
 	if fn.variadicMethod == nil || len(args) < fn.variadicMethod.Sub.(*ast.FnMethodNode).FixedArity {
-		return nil, lang.NewIllegalArgumentError(fmt.Sprintf("wrong number of arguments (%d)", len(args)))
+		return nil, fn.arityError(len(args))
 	}
 	return fn.variadicMethod, nil
 }
@@ -434,7 +473,7 @@ func (fn *Fn) ApplyTo(args lang.ISeq) interface{} {
 		method = fn.variadicMethod
 	}
 	if method == nil {
-		panic(lang.NewIllegalArgumentError(fmt.Sprintf("wrong number of arguments (%d)", arity)))
+		panic(fn.arityError(arity))
 	}
 
 	methodNode := method.Sub.(*ast.FnMethodNode)
@@ -451,13 +490,51 @@ func (fn *Fn) ApplyTo(args lang.ISeq) interface{} {
 	return fn.invokeMethod(method, bindingValues, bindingRestValue)
 }
 
-func errorWithStack(err error, stackFrame lang.StackFrame) error {
-	if err == nil {
-		return nil
+// arityError returns the error of calling fn with n arguments.
+func (fn *Fn) arityError(n int) error {
+	name := ""
+	if fn.def != nil {
+		name = fn.def.Namespace().Name().Name() + "/" + fn.def.Symbol().Name()
+	} else if local := fn.astNode.Sub.(*ast.FnNode).Local; local != nil {
+		name = local.Sub.(*ast.BindingNode).Name.Name()
 	}
-	valErr, ok := err.(*lang.EvalError)
+	return lang.NewArityError(n, name)
+}
+
+// stackError returns the error for r, a panic raised in a call of fn,
+// with the frames of its stack trace in fn named for it.
+func (fn *Fn) stackError(r any) *RTEvalError {
+	evalErr, ok := r.(*RTEvalError)
 	if !ok {
-		return lang.NewEvalError(stackFrame, err)
+		evalErr = &RTEvalError{Err: PanicError(r), pcs: callers()}
 	}
-	return valErr.AddStack(stackFrame)
+	ns, name := fn.stackName()
+	return evalErr.inFunction(ns, name, fn.stackPos)
+}
+
+// stackName returns the namespace and name of fn in stack traces: those
+// of the var it was defined as, or else of the namespace it was
+// evaluated in and the name it has in its body, or fn.
+func (fn *Fn) stackName() (ns, name string) {
+	if fn.def != nil {
+		return fn.def.Namespace().Name().Name(), fn.def.Symbol().Name()
+	}
+	if sym, ok := lang.Get(fn.astNode.Env, lang.KWNS).(*lang.Symbol); ok {
+		ns = sym.Name()
+	}
+	if local := fn.astNode.Sub.(*ast.FnNode).Local; local != nil {
+		return ns, local.Sub.(*ast.BindingNode).Name.Name()
+	}
+	return ns, "fn"
+}
+
+// stackPos returns the position of fn's form, or of the var it was
+// defined as if the form has none.
+func (fn *Fn) stackPos() lang.StackFrame {
+	frame := formStackFrame(fn.astNode.Form)
+	if frame.Line == 0 && fn.def != nil {
+		// Macros such as defn build the fn form without a position.
+		frame = formStackFrame(fn.def)
+	}
+	return frame
 }
@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glojurelang/glojure/internal/goid"
+)
+
+// ErrInterrupted is the error an interrupted evaluation fails with.
+var ErrInterrupted = errors.New("evaluation interrupted")
+
+// An Interrupter lets another goroutine stop an evaluation. The
+// evaluator checks for an interrupt on each loop iteration and each call
+// of an evaluated function. Go code that it calls, such as AOT-compiled
+// functions or blocking host calls, runs to completion before the
+// interrupt takes effect.
+type Interrupter struct {
+	mu          sync.Mutex
+	gid         int64 // goroutine running under the interrupter, or 0
+	interrupted atomic.Bool
+}
+
+var (
+	// interrupters maps goroutine ids to the Interrupter they run
+	// under.
+	interrupters sync.Map
+	// interruptsPending counts the interrupters that are both running
+	// and interrupted, so that checks are a single load while there
+	// are none.
+	interruptsPending atomic.Int32
+)
+
+// Run calls fn on the current goroutine, stopping it with
+// ErrInterrupted if Interrupt is called before it returns.
+func (in *Interrupter) Run(fn func()) {
+	gid := goid.Get()
+	prev, hadPrev := interrupters.Load(gid)
+
+	in.mu.Lock()
+	in.gid = gid
+	interrupters.Store(gid, in)
+	in.mu.Unlock()
+
+	defer func() {
+		in.mu.Lock()
+		defer in.mu.Unlock()
+		in.gid = 0
+		if hadPrev {
+			interrupters.Store(gid, prev)
+		} else {
+			interrupters.Delete(gid)
+		}
+		if in.interrupted.Swap(false) {
+			interruptsPending.Add(-1)
+		}
+	}()
+	fn()
+}
+
+// Interrupt stops the evaluation running under in at its next check,
+// reporting false if there is none.
+func (in *Interrupter) Interrupt() bool {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.gid == 0 {
+		return false
+	}
+	if in.interrupted.CompareAndSwap(false, true) {
+		interruptsPending.Add(1)
+	}
+	return true
+}
+
+// Interrupted reports whether the evaluation running under in has been
+// interrupted.
+func (in *Interrupter) Interrupted() bool {
+	return in.interrupted.Load()
+}
+
+// checkInterrupt returns ErrInterrupted if the current goroutine runs
+// under an interrupted Interrupter, and charges a step to its Budget,
+// returning a *BudgetExceededError if it is exceeded.
+func checkInterrupt() error {
+	if interruptsPending.Load() != 0 {
+		if in, ok := interrupters.Load(goid.Get()); ok && in.(*Interrupter).Interrupted() {
+			return ErrInterrupted
+		}
+	}
+	if budgetsRunning.Load() != 0 {
+		return chargeStep()
+	}
+	return nil
+}
+
+// checksPending reports whether checkInterrupt may fail.
+func checksPending() bool {
+	return interruptsPending.Load() != 0 || budgetsRunning.Load() != 0
+}
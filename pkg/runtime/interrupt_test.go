@@ -0,0 +1,64 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestInterrupterStopsEvaluation(t *testing.T) {
+	env := NewEnvironment().(*environment)
+	previousNS := env.CurrentNamespace()
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, previousNS))
+	t.Cleanup(lang.PopThreadBindings)
+
+	for _, code := range []string{
+		`(loop [] (recur))`,
+		`(loop [i 0] (if (>= i 0) (recur (inc i)) i))`,
+		`((fn spin [n] (spin (inc n))) 0)`,
+		`(try (loop [] (recur)) (catch go/error e (loop [] (recur))))`,
+	} {
+		t.Run(code, func(t *testing.T) {
+			form, err := reader.New(strings.NewReader(code)).ReadOne()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var in Interrupter
+			done := make(chan error, 1)
+			go func() {
+				var err error
+				in.Run(func() {
+					defer func() {
+						// Errors raised from catch blocks panic.
+						if r := recover(); r != nil {
+							err, _ = r.(error)
+						}
+					}()
+					_, err = env.Eval(form)
+				})
+				done <- err
+			}()
+
+			for !in.Interrupt() {
+				time.Sleep(time.Millisecond)
+			}
+			select {
+			case err := <-done:
+				if !errors.Is(err, ErrInterrupted) {
+					t.Errorf("error = %v, want ErrInterrupted", err)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("evaluation was not interrupted")
+			}
+			if in.Interrupted() || interruptsPending.Load() != 0 {
+				t.Error("interrupt outlived Run")
+			}
+		})
+	}
+}
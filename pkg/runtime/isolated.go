@@ -0,0 +1,319 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// RuntimeOptions configures a Runtime.
+type RuntimeOptions struct {
+	// Stdout and Stderr are the runtime's *out* and *err*. They default
+	// to os.Stdout and os.Stderr.
+	Stdout io.Writer
+	Stderr io.Writer
+	// LoadPath is searched for the runtime's libs after the process's
+	// load path, so its files take precedence over files of the same
+	// name there.
+	LoadPath []fs.FS
+	// Share names namespaces of the process to share with the runtime
+	// in addition to the standard library's.
+	Share []string
+	// Sandbox, if set, restricts what the runtime's code may refer to.
+	// It applies to code evaluated in the runtime and to the libs loaded
+	// from its LoadPath, but not to libs loaded from the process's load
+	// path, which are trusted. See compiler.DefaultSandboxPolicy.
+	Sandbox compiler.Sandbox
+	// Budget limits each call of Eval and ReadEval.
+	Budget Budget
+}
+
+// A Runtime is an isolated Glojure runtime within the process. It has
+// its own namespaces, and so its own vars, its own load path and its
+// own *ns*, *out* and *err*, so that two runtimes can define the same
+// names independently.
+//
+// To start quickly, a runtime shares the namespaces of the standard
+// library with the process rather than loading them again: clojure.core
+// and the clojure.* and glojure.* namespaces loaded when it is made, and
+// the standard library namespaces it requires later. Shared namespaces
+// are the same objects in every runtime, so altering them, for example
+// with alter-var-root or by defining vars in them, affects every
+// runtime.
+//
+// Code runs in a runtime while called from Run, Eval or ReadEval, and in
+// the futures and agents it starts, which convey its bindings. Go code
+// that starts goroutines of its own must call Run on them.
+type Runtime struct {
+	namespaces *lang.NamespaceTable
+	loadPath   []fs.FS
+	loadedLibs *lang.Ref
+	rootLibs   any
+	sandbox    compiler.Sandbox
+	budget     Budget
+	stdout     io.Writer
+	stderr     io.Writer
+
+	mu sync.Mutex
+	ns *lang.Namespace // *ns* between evaluations
+}
+
+// NewRuntime returns a new runtime whose current namespace is user. The
+// process's runtime must have been initialized, for example by
+// importing pkg/glj.
+func NewRuntime(opts RuntimeOptions) *Runtime {
+	rt := &Runtime{
+		loadPath: append([]fs.FS(nil), opts.LoadPath...),
+		rootLibs: rootLoadedLibs(),
+		sandbox:  opts.Sandbox,
+		budget:   opts.Budget,
+		stdout:   opts.Stdout,
+		stderr:   opts.Stderr,
+		ns:       lang.NSCore,
+	}
+	if rt.stdout == nil {
+		rt.stdout = os.Stdout
+	}
+	if rt.stderr == nil {
+		rt.stderr = os.Stderr
+	}
+
+	var shared []string
+	for _, ns := range rootNamespaces() {
+		name := ns.Name().String()
+		if strings.HasPrefix(name, "clojure.") || strings.HasPrefix(name, "glojure.") {
+			shared = append(shared, name)
+		}
+	}
+	shared = append(shared, opts.Share...)
+	rt.namespaces = lang.NewNamespaceTable(rt, shared...)
+
+	// The runtime has loaded the shared libs the process has loaded.
+	var libs []any
+	for s := lang.Seq(rt.rootLibs.(*lang.Ref).Deref()); s != nil; s = s.Next() {
+		if rt.namespaces.IsShared(s.First().(*lang.Symbol).String()) {
+			libs = append(libs, s.First())
+		}
+	}
+	rt.loadedLibs = coreVar("ref").Invoke(lang.Apply(coreVar("sorted-set"), libs)).(*lang.Ref)
+
+	rt.Run(func() {
+		runTrusted(func() {
+			ReadEval("(ns user)")
+		})
+	})
+	return rt
+}
+
+// Run calls fn on the current goroutine with the runtime's namespaces,
+// libs and dynamic bindings in effect. Calls of Run may nest, including
+// for different runtimes.
+func (rt *Runtime) Run(fn func()) {
+	rt.mu.Lock()
+	ns := rt.ns
+	rt.mu.Unlock()
+	lang.PushThreadBindings(lang.NewMap(
+		lang.VarNamespaceTable, rt.namespaces,
+		coreVar("*loaded-libs*"), rt.loadedLibs,
+		varSandbox, rt.sandbox,
+		lang.VarCurrentNS, ns,
+		lang.VarOut, rt.stdout,
+		lang.VarErr, rt.stderr,
+		lang.VarWarnOnReflection, lang.VarWarnOnReflection.Deref(),
+		lang.VarUncheckedMath, lang.VarUncheckedMath.Deref(),
+		lang.VarDataReaders, lang.VarDataReaders.Deref(),
+	))
+	defer func() {
+		ns := lang.VarCurrentNS.Deref().(*lang.Namespace)
+		lang.PopThreadBindings()
+		rt.mu.Lock()
+		rt.ns = ns
+		rt.mu.Unlock()
+	}()
+	fn()
+}
+
+// Eval evaluates form in the runtime.
+func (rt *Runtime) Eval(form any) (res any, err error) {
+	rt.Run(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = PanicError(r)
+			}
+		}()
+		rt.budget.Run(func() {
+			res, err = lang.GlobalEnv.Eval(form)
+		})
+	})
+	return res, err
+}
+
+// ReadEval reads the forms of code and evaluates them in the runtime in
+// turn, returning the value of the last.
+func (rt *Runtime) ReadEval(code string) (res any, err error) {
+	rt.Run(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = PanicError(r)
+			}
+		}()
+		r := reader.New(strings.NewReader(code), reader.WithGetCurrentNS(func() *lang.Namespace {
+			return lang.GlobalEnv.CurrentNamespace()
+		}))
+		rt.budget.Run(func() {
+			for {
+				form, readErr := r.ReadOne()
+				if readErr == reader.ErrEOF {
+					return
+				}
+				if readErr != nil {
+					err = readErr
+					return
+				}
+				if res, err = lang.GlobalEnv.Eval(form); err != nil {
+					return
+				}
+			}
+		})
+	})
+	return res, err
+}
+
+// FindNamespace returns the runtime's namespace named name, or nil.
+func (rt *Runtime) FindNamespace(name string) *lang.Namespace {
+	var ns *lang.Namespace
+	rt.Run(func() {
+		ns = lang.FindNamespace(lang.NewSymbol(name))
+	})
+	return ns
+}
+
+// InternVar returns the var named name in the runtime's namespace ns,
+// creating them if they don't exist.
+func (rt *Runtime) InternVar(ns, name string) *lang.Var {
+	var vr *lang.Var
+	rt.Run(func() {
+		vr = lang.InternVarName(lang.NewSymbol(ns), lang.NewSymbol(name))
+	})
+	return vr
+}
+
+// Bind returns a function that calls fn in the runtime, for Go code to
+// call the runtime's functions.
+func (rt *Runtime) Bind(fn lang.IFn) lang.IFn {
+	return runtimeFn{rt: rt, fn: fn}
+}
+
+type runtimeFn struct {
+	rt *Runtime
+	fn lang.IFn
+}
+
+func (f runtimeFn) Invoke(args ...any) any {
+	var res any
+	f.rt.Run(func() { res = f.fn.Invoke(args...) })
+	return res
+}
+
+func (f runtimeFn) ApplyTo(args lang.ISeq) any {
+	var res any
+	f.rt.Run(func() { res = f.fn.ApplyTo(args) })
+	return res
+}
+
+// currentRuntime returns the runtime the current goroutine runs in, or
+// nil.
+func currentRuntime() *Runtime {
+	rt, _ := lang.CurrentNamespaceTable().Owner().(*Runtime)
+	return rt
+}
+
+// rootLoadedLibs returns the process's *loaded-libs* ref.
+func rootLoadedLibs() any {
+	if rt := currentRuntime(); rt != nil {
+		return rt.rootLibs
+	}
+	return coreVar("*loaded-libs*").Deref()
+}
+
+func rootNamespaces() []*lang.Namespace {
+	var all []*lang.Namespace
+	runInRoot(func() {
+		for s := lang.AllNamespaces(); s != nil; s = s.Next() {
+			all = append(all, s.First().(*lang.Namespace))
+		}
+	})
+	return all
+}
+
+// runInRoot calls fn with the process's namespaces and libs, outside
+// any runtime.
+func runInRoot(fn func()) {
+	lang.PushThreadBindings(lang.NewMap(
+		lang.VarNamespaceTable, nil,
+		coreVar("*loaded-libs*"), rootLoadedLibs(),
+	))
+	defer lang.PopThreadBindings()
+	fn()
+}
+
+// loadShared loads the AOT-compiled lib at resource into the process, if
+// it hasn't been, and shares its namespace with the runtime, reporting
+// whether there is such a lib.
+func (rt *Runtime) loadShared(resource string) bool {
+	entry := nsLoaders[resource]
+	if entry == nil {
+		return false
+	}
+	runInRoot(func() {
+		entry.run(true)
+	})
+	rt.namespaces.Share(strings.ReplaceAll(strings.ReplaceAll(resource, "/", "."), "_", "-"))
+	return true
+}
+
+// loadPaths returns the load path of the current goroutine: the
+// process's, followed by its runtime's, from index own.
+func loadPaths() (lp []fs.FS, own int) {
+	loadPathLock.Lock()
+	lp = loadPath
+	loadPathLock.Unlock()
+	own = len(lp)
+	if rt := currentRuntime(); rt != nil {
+		rt.mu.Lock()
+		lp = append(lp[:own:own], rt.loadPath...)
+		rt.mu.Unlock()
+	}
+	return lp, own
+}
+
+// addLoadPath adds fsys to the load path of the current runtime, or to
+// the process's outside any.
+func addLoadPath(fsys fs.FS) {
+	if rt := currentRuntime(); rt != nil {
+		rt.mu.Lock()
+		rt.loadPath = append(rt.loadPath, fsys)
+		rt.mu.Unlock()
+		return
+	}
+	AddLoadPath(fsys)
+}
+
+func coreVar(name string) *lang.Var {
+	return lang.NSCore.FindInternedVar(lang.NewSymbol(name))
+}
+
+// PanicError returns r, a value recovered from a panic, as an error.
+func PanicError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
@@ -0,0 +1,72 @@
+package runtime
+
+import (
+	"io/fs"
+	"sync"
+)
+
+// loadTrace records the namespace resources loaded by RT.Load while it is
+// active. Resources are recorded when their load completes, so every
+// resource appears after the resources it required.
+type loadTrace struct {
+	mu      sync.Mutex
+	entries []loadTraceEntry
+	seen    map[string]bool
+}
+
+// loadTraceEntry describes one traced resource. Resources served by a
+// registered AOT loader have a nil fsys.
+type loadTraceEntry struct {
+	resource string
+	fsys     fs.FS
+	filename string
+}
+
+var (
+	activeLoadTrace     *loadTrace
+	activeLoadTraceLock sync.Mutex
+)
+
+// startLoadTrace begins recording loads. Only one trace may be active at a
+// time; the returned trace must be stopped by the caller.
+func startLoadTrace() *loadTrace {
+	activeLoadTraceLock.Lock()
+	defer activeLoadTraceLock.Unlock()
+	if activeLoadTrace != nil {
+		panic("runtime: a load trace is already active")
+	}
+	activeLoadTrace = &loadTrace{seen: make(map[string]bool)}
+	return activeLoadTrace
+}
+
+func (t *loadTrace) stop() {
+	activeLoadTraceLock.Lock()
+	defer activeLoadTraceLock.Unlock()
+	if activeLoadTrace == t {
+		activeLoadTrace = nil
+	}
+}
+
+// loaded returns the traced resources in completion order.
+func (t *loadTrace) loaded() []loadTraceEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]loadTraceEntry(nil), t.entries...)
+}
+
+func traceLoad(entry loadTraceEntry) {
+	activeLoadTraceLock.Lock()
+	t := activeLoadTrace
+	activeLoadTraceLock.Unlock()
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.seen[entry.resource] {
+		return
+	}
+	t.seen[entry.resource] = true
+	t.entries = append(t.entries, entry)
+}
@@ -0,0 +1,391 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// nsFile records a source file RT.Load read into a namespace, so that
+// the namespace can be reloaded when the file changes.
+type nsFile struct {
+	resource string
+	fsys     fs.FS
+	filename string
+	ns       string
+	// parent is the resource whose load loaded this one, or "".
+	parent string
+	// requires lists the libs named by the file's ns form and its
+	// top-level require and use calls.
+	requires []string
+
+	hash    [sha256.Size]byte
+	modTime time.Time
+	size    int64
+}
+
+var (
+	// nsFiles holds the files loaded from the load path, keyed by
+	// resource, in the order their loads completed.
+	nsFiles      = map[string]*nsFile{}
+	nsFileOrder  []string
+	nsFilesLock  sync.Mutex
+	symPendPaths = lang.NewSymbol("*pending-paths*")
+)
+
+// recordNSFile records a file loaded into the namespace that was current
+// when its load finished. Files loaded into clojure.core are not
+// recorded, as clojure.core cannot be unloaded, nor are files loaded in
+// a Runtime, whose namespaces are not the process's.
+func recordNSFile(resource string, fsys fs.FS, filename string, src []byte, requires map[string]bool) {
+	ns, ok := lang.VarCurrentNS.Deref().(*lang.Namespace)
+	if !ok || ns == lang.NSCore || currentRuntime() != nil {
+		return
+	}
+	f := &nsFile{
+		resource: resource,
+		fsys:     fsys,
+		filename: filename,
+		ns:       ns.Name().Name(),
+		parent:   parentResource(resource),
+		hash:     sha256.Sum256(src),
+	}
+	for lib := range requires {
+		f.requires = append(f.requires, lib)
+	}
+	if info, err := fs.Stat(fsys, filename); err == nil {
+		f.modTime, f.size = info.ModTime(), info.Size()
+	}
+
+	nsFilesLock.Lock()
+	defer nsFilesLock.Unlock()
+	if _, ok := nsFiles[resource]; ok {
+		removeResourceLocked(resource)
+	}
+	nsFiles[resource] = f
+	nsFileOrder = append(nsFileOrder, resource)
+}
+
+// parentResource returns the resource being loaded around the load of
+// resource, from clojure.core/*pending-paths*.
+func parentResource(resource string) string {
+	vr := lang.NSCore.FindInternedVar(symPendPaths)
+	if vr == nil {
+		return ""
+	}
+	seq := lang.Seq(vr.Deref())
+	if seq == nil {
+		return ""
+	}
+	if path, ok := seq.First().(string); !ok || strings.TrimPrefix(path, "/") != resource {
+		// Loaded directly with RT.Load rather than through load.
+		return ""
+	}
+	if rest := seq.Next(); rest != nil {
+		if path, ok := rest.First().(string); ok {
+			return strings.TrimPrefix(path, "/")
+		}
+	}
+	return ""
+}
+
+func removeResourceLocked(resource string) {
+	delete(nsFiles, resource)
+	for i, r := range nsFileOrder {
+		if r == resource {
+			nsFileOrder = append(nsFileOrder[:i:i], nsFileOrder[i+1:]...)
+			return
+		}
+	}
+}
+
+// NamespaceDependencies returns the dependency graph of the namespaces
+// loaded from source files on the load path: each namespace maps to the
+// sorted names of the libs its files require. Namespaces served by AOT
+// loaders are not included.
+func NamespaceDependencies() map[string][]string {
+	nsFilesLock.Lock()
+	defer nsFilesLock.Unlock()
+	return dependenciesLocked()
+}
+
+func dependenciesLocked() map[string][]string {
+	sets := map[string]map[string]bool{}
+	for _, f := range nsFiles {
+		if sets[f.ns] == nil {
+			sets[f.ns] = map[string]bool{}
+		}
+		for _, lib := range f.requires {
+			if lib != f.ns {
+				sets[f.ns][lib] = true
+			}
+		}
+	}
+	graph := make(map[string][]string, len(sets))
+	for ns, set := range sets {
+		deps := make([]string, 0, len(set))
+		for lib := range set {
+			deps = append(deps, lib)
+		}
+		sort.Strings(deps)
+		graph[ns] = deps
+	}
+	return graph
+}
+
+// NamespaceResources returns the resources to load to reload the named
+// namespace: those of its files that were not loaded by another of its
+// files, in load order.
+func NamespaceResources(name string) []string {
+	nsFilesLock.Lock()
+	defer nsFilesLock.Unlock()
+	var resources []string
+	for _, r := range nsFileOrder {
+		f := nsFiles[r]
+		if f.ns != name {
+			continue
+		}
+		if parent := nsFiles[f.parent]; parent != nil && parent.ns == name {
+			continue
+		}
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+// ChangedNamespaces scans the files recorded for the loaded namespaces
+// and returns the namespaces with a file that changed since it was
+// loaded, and those with a file that can no longer be read. A file whose
+// modification time and size are unchanged is not read again.
+func ChangedNamespaces() (changed, removed []string) {
+	nsFilesLock.Lock()
+	files := make([]*nsFile, 0, len(nsFiles))
+	for _, r := range nsFileOrder {
+		files = append(files, nsFiles[r])
+	}
+	nsFilesLock.Unlock()
+
+	changedSet, removedSet := map[string]bool{}, map[string]bool{}
+	for _, f := range files {
+		info, err := fs.Stat(f.fsys, f.filename)
+		if err != nil {
+			removedSet[f.ns] = true
+			continue
+		}
+		if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+			continue
+		}
+		src, err := readFile(f.fsys, f.filename)
+		if err != nil {
+			removedSet[f.ns] = true
+			continue
+		}
+		if sha256.Sum256(src) != f.hash {
+			changedSet[f.ns] = true
+			continue
+		}
+		// Touched but not modified.
+		nsFilesLock.Lock()
+		f.modTime, f.size = info.ModTime(), info.Size()
+		nsFilesLock.Unlock()
+	}
+	for ns := range removedSet {
+		delete(changedSet, ns)
+		removed = append(removed, ns)
+	}
+	for ns := range changedSet {
+		changed = append(changed, ns)
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return changed, removed
+}
+
+// ReloadOrder returns the named namespaces and the loaded namespaces
+// that depend on them, directly or indirectly, ordered so that every
+// namespace comes after the namespaces it requires. Ties are broken by
+// name. It returns an error if the namespaces require each other in a
+// cycle.
+func ReloadOrder(names []string) ([]string, error) {
+	nsFilesLock.Lock()
+	graph := dependenciesLocked()
+	nsFilesLock.Unlock()
+
+	dependents := map[string][]string{}
+	for ns, deps := range graph {
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], ns)
+		}
+	}
+	affected := map[string]bool{}
+	var mark func(string)
+	mark = func(ns string) {
+		if affected[ns] {
+			return
+		}
+		affected[ns] = true
+		for _, d := range dependents[ns] {
+			mark(d)
+		}
+	}
+	for _, ns := range names {
+		mark(ns)
+	}
+
+	// Kahn's algorithm over the affected namespaces.
+	pending := map[string]int{}
+	for ns := range affected {
+		for _, dep := range graph[ns] {
+			if affected[dep] {
+				pending[ns]++
+			}
+		}
+	}
+	var ready, order []string
+	for ns := range affected {
+		if pending[ns] == 0 {
+			ready = append(ready, ns)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		ns := ready[0]
+		ready = ready[1:]
+		order = append(order, ns)
+		for _, d := range dependents[ns] {
+			if !affected[d] {
+				continue
+			}
+			if pending[d]--; pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	if len(order) < len(affected) {
+		var cycle []string
+		for ns := range affected {
+			if pending[ns] > 0 {
+				cycle = append(cycle, ns)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("cyclic namespace dependency among %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// ForgetNamespace drops the files recorded for the named namespace, as
+// when it is unloaded. Reloading it records them again.
+func ForgetNamespace(name string) {
+	nsFilesLock.Lock()
+	defer nsFilesLock.Unlock()
+	for _, r := range append([]string(nil), nsFileOrder...) {
+		if nsFiles[r].ns == name {
+			removeResourceLocked(r)
+		}
+	}
+}
+
+var (
+	symNS      = lang.NewSymbol("ns")
+	symRequire = lang.NewSymbol("require")
+	symUse     = lang.NewSymbol("use")
+	symQuote   = lang.NewSymbol("quote")
+	kwRequire  = lang.NewKeyword("require")
+	kwUse      = lang.NewKeyword("use")
+	kwAsAlias  = lang.NewKeyword("as-alias")
+)
+
+// requiredLibs adds the libs required by a top-level form, an ns form or
+// a require or use call with quoted arguments, to libs.
+func requiredLibs(form any, libs map[string]bool) {
+	seq, ok := form.(lang.ISeq)
+	if !ok || seq == nil {
+		return
+	}
+	head, ok := seq.First().(*lang.Symbol)
+	if !ok || head.Namespace() != "" && head.Namespace() != "clojure.core" {
+		return
+	}
+	switch head.Name() {
+	case symNS.Name():
+		for s := seq.Next(); s != nil; s = s.Next() {
+			clause, ok := s.First().(lang.ISeq)
+			if !ok || clause == nil {
+				continue
+			}
+			if kw := clause.First(); kw == kwRequire || kw == kwUse {
+				for spec := clause.Next(); spec != nil; spec = spec.Next() {
+					libspecLibs(spec.First(), "", libs)
+				}
+			}
+		}
+	case symRequire.Name(), symUse.Name():
+		for s := seq.Next(); s != nil; s = s.Next() {
+			quoted, ok := s.First().(lang.ISeq)
+			if !ok || quoted == nil || !lang.Equals(quoted.First(), symQuote) || quoted.Next() == nil {
+				continue
+			}
+			libspecLibs(quoted.Next().First(), "", libs)
+		}
+	}
+}
+
+// libspecLibs adds the libs named by a libspec or prefix list to libs.
+// Libs required only with :as-alias are not loaded, and are skipped.
+func libspecLibs(spec any, prefix string, libs map[string]bool) {
+	qualify := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+	switch spec := spec.(type) {
+	case *lang.Symbol:
+		libs[qualify(spec.Name())] = true
+	case lang.Sequential:
+		elems := lang.Seq(spec)
+		if elems == nil {
+			return
+		}
+		lib, ok := elems.First().(*lang.Symbol)
+		if !ok {
+			return
+		}
+		rest := elems.Next()
+		if rest == nil {
+			libs[qualify(lib.Name())] = true
+			return
+		}
+		if _, ok := rest.First().(lang.Keyword); ok {
+			for opt := rest; opt != nil; opt = opt.Next() {
+				if opt.First() != kwAsAlias {
+					libs[qualify(lib.Name())] = true
+					return
+				}
+				if opt = opt.Next(); opt == nil {
+					break
+				}
+			}
+			return
+		}
+		for ; rest != nil; rest = rest.Next() {
+			libspecLibs(rest.First(), qualify(lib.Name()), libs)
+		}
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
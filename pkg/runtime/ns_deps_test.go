@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestRequiredLibs(t *testing.T) {
+	src := `
+(ns a.core
+  (:require [a.util :as u]
+            a.plain
+            [a.alias :as-alias al]
+            [a.both :as-alias b :refer [x]]
+            (a.prefix one [two :as t]))
+  (:use [a.used])
+  (:import (fmt Stringer)))
+(require '[b.late] :reload)
+(clojure.core/use 'b.qualified)
+(defn f [] (require 'not.top-level))
+(other/require 'not.core)`
+	r := reader.New(strings.NewReader(src),
+		reader.WithGetCurrentNS(func() *lang.Namespace { return lang.NSCore }))
+	libs := map[string]bool{}
+	for {
+		form, err := r.ReadOne()
+		if err == reader.ErrEOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		requiredLibs(form, libs)
+	}
+	want := []string{
+		"a.both", "a.plain", "a.prefix.one", "a.prefix.two", "a.used",
+		"a.util", "b.late", "b.qualified",
+	}
+	var got []string
+	for lib := range libs {
+		got = append(got, lib)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("requiredLibs = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"strings"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+// nsSource records one source file loaded into a namespace: the hash of its
+// text and the namespaces whose vars it refers to. AOT compilation uses
+// these records to decide whether a namespace's generated code is stale.
+type nsSource struct {
+	hash [sha256.Size]byte
+
+	// ns, filename and code are kept until the references are first
+	// needed. Only processes that compile need them, so files are not
+	// read a second time as they load.
+	ns       *lang.Namespace
+	filename string
+	code     string
+
+	refsOnce sync.Once
+	// refs lists the other namespaces referenced by symbols in the
+	// file, resolved in the namespace the file was loaded into. Macro
+	// namespaces are found this way along with ordinary dependencies.
+	refs []string
+	// complete is false when the file could not be re-read to find its
+	// references.
+	complete bool
+}
+
+var (
+	nsSources     = map[string]map[string]*nsSource{}
+	nsSourcesLock sync.Mutex
+)
+
+// recordNSSource records code, loaded from resource, against the namespace
+// that was current when the load finished. Every load is recorded, as the
+// libraries a compile depends on, such as clojure.core when the standard
+// library is built from source, are loaded before it starts.
+func recordNSSource(resource, filename, code string) {
+	ns, ok := lang.VarCurrentNS.Deref().(*lang.Namespace)
+	if !ok {
+		return
+	}
+	src := &nsSource{
+		hash:     sha256.Sum256([]byte(code)),
+		ns:       ns,
+		filename: filename,
+		code:     code,
+	}
+
+	nsSourcesLock.Lock()
+	defer nsSourcesLock.Unlock()
+	name := ns.Name().Name()
+	if nsSources[name] == nil {
+		nsSources[name] = map[string]*nsSource{}
+	}
+	nsSources[name][resource] = src
+}
+
+// references returns the namespaces the file refers to, and false if
+// they could not be found.
+func (src *nsSource) references() ([]string, bool) {
+	src.refsOnce.Do(func() {
+		src.refs, src.complete = referencedNamespaces(src.ns, src.filename, src.code)
+		src.ns, src.code = nil, ""
+	})
+	return src.refs, src.complete
+}
+
+// lookupNSSources returns the recorded source files of the named
+// namespace, keyed by resource.
+func lookupNSSources(name string) map[string]*nsSource {
+	nsSourcesLock.Lock()
+	defer nsSourcesLock.Unlock()
+	return nsSources[name]
+}
+
+// referencedNamespaces re-reads code and resolves its symbols in ns,
+// returning the names of the other namespaces they refer to. Symbols that
+// only name locals may resolve to vars of the same name; the extra
+// dependencies make the result conservative, never wrong.
+func referencedNamespaces(ns *lang.Namespace, filename, code string) ([]string, bool) {
+	r := reader.New(strings.NewReader(code),
+		reader.WithGetCurrentNS(func() *lang.Namespace { return ns }),
+		reader.WithFilename(filename))
+
+	found := map[string]bool{}
+	for {
+		form, err := r.ReadOne()
+		if err == reader.ErrEOF {
+			break
+		}
+		if err != nil {
+			return nil, false
+		}
+		collectNamespaceRefs(ns, form, found)
+	}
+	delete(found, ns.Name().Name())
+
+	refs := make([]string, 0, len(found))
+	for name := range found {
+		refs = append(refs, name)
+	}
+	return refs, true
+}
+
+func collectNamespaceRefs(ns *lang.Namespace, form any, found map[string]bool) {
+	switch form := form.(type) {
+	case *lang.Symbol:
+		if form.Namespace() != "" {
+			nsSym := lang.NewSymbol(form.Namespace())
+			if target := ns.LookupAlias(nsSym); target != nil {
+				found[target.Name().Name()] = true
+			} else if lang.FindNamespace(nsSym) != nil {
+				found[form.Namespace()] = true
+			}
+			return
+		}
+		if v, ok := ns.GetMapping(form).(*lang.Var); ok {
+			found[v.Namespace().Name().Name()] = true
+		}
+	case *lang.MapEntry:
+		collectNamespaceRefs(ns, form.Key(), found)
+		collectNamespaceRefs(ns, form.Val(), found)
+	case lang.IPersistentCollection:
+		for s := lang.Seq(form); s != nil; s = s.Next() {
+			collectNamespaceRefs(ns, s.First(), found)
+		}
+	}
+}
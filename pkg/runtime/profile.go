@@ -0,0 +1,126 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glojurelang/glojure/internal/goid"
+	"github.com/glojurelang/glojure/pkg/ast"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// Profile label keys. While profile labels are enabled, each call of an
+// evaluated function sets these runtime/pprof labels on its goroutine,
+// so CPU profile samples taken while it runs carry the function rather
+// than only the evaluator's frames.
+const (
+	// ProfileLabelFn is the qualified name of the var the function was
+	// defined as, or "fn" for an anonymous function.
+	ProfileLabelFn = "glj.fn"
+	// ProfileLabelSource is the file and line the function was read
+	// from.
+	ProfileLabelSource = "glj.source"
+)
+
+var (
+	profileLabels atomic.Bool
+
+	// fnLabels caches the label sets of profiled functions.
+	fnLabels sync.Map // fnLabelKey -> pprof.LabelSet
+	// labelContexts holds the labels of the innermost profiled call on
+	// each goroutine, restored when the calls within it return.
+	labelContexts sync.Map // goroutine id -> context.Context
+)
+
+// SetProfileLabels enables or disables the profile labels of evaluated
+// functions, returning the previous setting. Labels cost a little on
+// every call, so they are off unless a profile is being taken.
+func SetProfileLabels(enabled bool) bool {
+	return profileLabels.Swap(enabled)
+}
+
+type fnLabelKey struct {
+	node *ast.Node
+	def  *lang.Var
+}
+
+// nameDefinedFn records that val, the value of init, was defined as vr
+// when init is a fn form, possibly with metadata, so that profiles show
+// the var.
+func nameDefinedFn(init *ast.Node, val any, vr *lang.Var) {
+	if init.Op == ast.OpWithMeta {
+		init = init.Sub.(*ast.WithMetaNode).Expr
+	}
+	if fn, ok := val.(*Fn); ok && init.Op == ast.OpFn {
+		fn.def = vr
+	}
+}
+
+// profileLabelSet returns the labels of calls to fn.
+func (fn *Fn) profileLabelSet() pprof.LabelSet {
+	key := fnLabelKey{node: fn.astNode, def: fn.def}
+	if labels, ok := fnLabels.Load(key); ok {
+		return labels.(pprof.LabelSet)
+	}
+	name := "fn"
+	source, ok := sourceLocation(fn.astNode.Form)
+	if fn.def != nil {
+		name = fn.def.Namespace().Name().Name() + "/" + fn.def.Symbol().Name()
+		if !ok {
+			// Macros such as defn build the fn form without a position.
+			source, _ = sourceLocation(fn.def)
+		}
+	}
+	labels := pprof.Labels(ProfileLabelFn, name, ProfileLabelSource, source)
+	fnLabels.Store(key, labels)
+	return labels
+}
+
+// sourceLocation returns the file:line of a form or var from its
+// metadata.
+func sourceLocation(x any) (string, bool) {
+	imeta, ok := x.(lang.IMeta)
+	if !ok || imeta.Meta() == nil {
+		return "", false
+	}
+	meta := imeta.Meta()
+	file, _ := meta.ValAt(lang.KWFile).(string)
+	line, ok := meta.ValAt(lang.KWLine).(int)
+	if !ok || file == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s:%d", file, line), true
+}
+
+// pushProfileLabels sets the labels of fn on the current goroutine,
+// returning what popProfileLabels needs to restore the caller's.
+func (fn *Fn) pushProfileLabels() profileFrame {
+	gid := goid.Get()
+	parent := context.Background()
+	prev, ok := labelContexts.Load(gid)
+	if ok {
+		parent = prev.(context.Context)
+	}
+	ctx := pprof.WithLabels(parent, fn.profileLabelSet())
+	labelContexts.Store(gid, ctx)
+	pprof.SetGoroutineLabels(ctx)
+	return profileFrame{gid: gid, prev: prev}
+}
+
+type profileFrame struct {
+	gid  int64
+	prev any
+}
+
+func popProfileLabels(f profileFrame) {
+	if f.prev == nil {
+		labelContexts.Delete(f.gid)
+		pprof.SetGoroutineLabels(context.Background())
+		return
+	}
+	labelContexts.Store(f.gid, f.prev)
+	pprof.SetGoroutineLabels(f.prev.(context.Context))
+}
@@ -2,7 +2,6 @@ package runtime
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/glojurelang/glojure/pkg/lang"
 	"github.com/glojurelang/glojure/pkg/reader"
@@ -18,6 +17,14 @@ type (
 		env lang.Environment
 		// filename is the name of the file being read.
 		filename string
+		// observe, if set, is called with each form before it is
+		// evaluated.
+		observe func(form any)
+		// cacheName, if set, names the file in the AST cache.
+		cacheName string
+		// handle, if set, is called with each form in place of
+		// evaluating it.
+		handle func(form any)
 	}
 )
 
@@ -35,6 +42,30 @@ func WithFilename(filename string) ReadEvalOption {
 	}
 }
 
+// withFormObserver calls observe with each form read, before it is
+// evaluated.
+func withFormObserver(observe func(form any)) ReadEvalOption {
+	return func(o *readEvalOptions) {
+		o.observe = observe
+	}
+}
+
+// withFormHandler calls handle with each form read in place of
+// evaluating it.
+func withFormHandler(handle func(form any)) ReadEvalOption {
+	return func(o *readEvalOptions) {
+		o.handle = handle
+	}
+}
+
+// withASTCache evaluates the forms from the AST cache, under name, while
+// it is valid.
+func withASTCache(name string) ReadEvalOption {
+	return func(o *readEvalOptions) {
+		o.cacheName = name
+	}
+}
+
 // ReadEval reads and evaluates a string that may contain one or more
 // forms in the global environment.
 func ReadEval(code string, options ...ReadEvalOption) interface{} {
@@ -46,30 +77,30 @@ func ReadEval(code string, options ...ReadEvalOption) interface{} {
 	if env == nil {
 		env = lang.GlobalEnv
 	}
-	readerOpts := []reader.Option{
-		reader.WithGetCurrentNS(func() *lang.Namespace {
-			return env.CurrentNamespace()
-		}),
-	}
-	if opts.filename != "" {
-		readerOpts = append(readerOpts, reader.WithFilename(opts.filename))
-	}
-
-	r := reader.New(strings.NewReader(string(code)), readerOpts...)
+	script := newScript(env, opts.filename, opts.cacheName, code)
 
 	var lastValue interface{}
 	for {
-		expr, err := r.ReadOne()
+		expr, err := script.ReadOne()
 		if err == reader.ErrEOF {
 			break
 		}
 		if err != nil {
 			panic(fmt.Sprintf("error reading %v: %v", opts.filename, err))
 		}
-		lastValue, err = env.Eval(expr)
+		if opts.observe != nil {
+			opts.observe(expr)
+		}
+		if opts.handle != nil {
+			opts.handle(expr)
+			continue
+		}
+		lastValue, err = script.Eval(expr)
 		if err != nil {
-			panic(fmt.Sprintf("error evaluating %v: %v", opts.filename, err))
+			panic(fmt.Errorf("error evaluating %v: %w", opts.filename, err))
 		}
 	}
+	// The cache only saves time; a load does not fail for want of it.
+	_ = script.Close()
 	return lastValue
 }
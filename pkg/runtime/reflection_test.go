@@ -0,0 +1,48 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestWarnOnReflection(t *testing.T) {
+	env := NewEnvironment().(*environment)
+	var stderr bytes.Buffer
+	lang.PushThreadBindings(lang.NewMap(
+		lang.VarCurrentNS, env.CurrentNamespace(),
+		lang.VarWarnOnReflection, true,
+		lang.VarErr, &stderr,
+	))
+	t.Cleanup(lang.PopThreadBindings)
+
+	rdr := reader.New(strings.NewReader(`
+(ns reflection.test)
+(defn known [s] (.Len (strings.NewReader s)))
+(defn tagged [^strings.Builder b] (.String b))
+(defn unknown [x]
+  (.Len x))
+`), reader.WithFilename("reflect.glj"))
+	for {
+		form, err := rdr.ReadOne()
+		if err == reader.ErrEOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := env.Eval(form); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := "Reflection warning, reflect.glj:6 - reference to field or no-arg method Len can't be resolved (target type is unknown).\n"
+	if got := stderr.String(); got != want {
+		t.Fatalf("warnings = %q, want %q", got, want)
+	}
+}
@@ -30,6 +30,14 @@ var (
 	sourceTransformer     lang.IFn
 	sourceTransformerLock sync.RWMutex
 
+	// stdlibFS is the load path entry of the standard library.
+	stdlibFS fs.FS
+
+	// varLibForms holds the function that the libs loaded from source
+	// outside the standard library pass their top-level forms to in
+	// place of evaluating them, or nil. It is not interned.
+	varLibForms = lang.NewVarWithRoot(lang.NSCore, lang.NewSymbol("*lib-forms*"), nil).SetDynamic()
+
 	useAot = func() bool {
 		// default to true
 		gua := strings.ToLower(os.Getenv("GLOJURE_USE_AOT"))
@@ -48,13 +56,25 @@ func SetSourceTransformer(transformer lang.IFn) {
 	sourceTransformerLock.Unlock()
 }
 
+// WithLibForms calls fn, passing each top-level form of the libs it
+// loads from source, other than those of the standard library, to
+// handle rather than evaluating it. Tools that inspect code without
+// running it use it to learn what the libs the code requires define.
+// The libs are not recorded as loaded from their files.
+func WithLibForms(handle func(form any), fn func()) {
+	lang.PushThreadBindings(lang.NewMap(varLibForms, handle))
+	defer lang.PopThreadBindings()
+	fn()
+}
+
 func init() {
 	stdlibPath := os.Getenv("GLOJURE_STDLIB_PATH")
 	if stdlibPath != "" {
-		AddLoadPath(os.DirFS(stdlibPath))
+		stdlibFS = os.DirFS(stdlibPath)
 	} else {
-		AddLoadPath(stdlib.StdLib)
+		stdlibFS = stdlib.StdLib
 	}
+	AddLoadPath(stdlibFS)
 }
 
 func GetUseAOT() bool {
@@ -69,6 +89,15 @@ func AddLoadPath(fs fs.FS) {
 	loadPath = append(loadPath, fs)
 }
 
+// LoadPaths returns the filesystems on the load path, in the order they
+// are searched.
+func LoadPaths() []fs.FS {
+	loadPathLock.Lock()
+	defer loadPathLock.Unlock()
+
+	return append([]fs.FS(nil), loadPath...)
+}
+
 // RT is a struct with methods that map to Clojure's RT class' static
 // methods. This approach is used to make translation of core.clj to
 // Glojure easier.
@@ -329,9 +358,15 @@ func (rt *RTMethods) Load(scriptBase string) {
 	resourceBase := strings.ReplaceAll(strings.TrimPrefix(scriptBase, "/"), ".", "/")
 
 	if useAot {
-		// check nsloaders
-		if loader := GetNSLoader(resourceBase); loader != nil {
+		// Runtimes share the process's AOT-compiled libs.
+		if rt := currentRuntime(); rt != nil {
+			if rt.loadShared(resourceBase) {
+				traceLoad(loadTraceEntry{resource: resourceBase})
+				return
+			}
+		} else if loader := GetNSLoader(resourceBase); loader != nil {
 			loader()
+			traceLoad(loadTraceEntry{resource: resourceBase})
 			return
 		}
 	}
@@ -339,11 +374,10 @@ func (rt *RTMethods) Load(scriptBase string) {
 	var buf []byte
 	var foundFS fs.FS
 	var filename string
+	var trusted bool
 
-	loadPathLock.Lock()
-	lp := loadPath
-	loadPathLock.Unlock()
-	for _, fs := range lp {
+	lp, own := loadPaths()
+	for i, fs := range lp {
 		for _, testFilename := range []string{
 			resourceBase + ".glj",
 			resourceBase + ".clj",
@@ -354,6 +388,7 @@ func (rt *RTMethods) Load(scriptBase string) {
 				buf = b
 				foundFS = fs
 				filename = testFilename
+				trusted = i < own
 				break
 			}
 		}
@@ -376,7 +411,26 @@ func (rt *RTMethods) Load(scriptBase string) {
 			panic(fmt.Errorf("source transformer returned %T, want string", transformed))
 		}
 	}
-	ReadEval(code, WithFilename(filename))
+	if handle, ok := varLibForms.Deref().(func(form any)); ok && foundFS != stdlibFS {
+		ReadEval(code, WithFilename(filename), withFormHandler(handle))
+		return
+	}
+	requires := map[string]bool{}
+	eval := func() {
+		ReadEval(code, WithFilename(filename), withASTCache("lib "+filename), withFormObserver(func(form any) {
+			requiredLibs(form, requires)
+		}))
+	}
+	// The libs of the process's load path are trusted by the sandbox of
+	// a runtime loading them; the runtime's own are not.
+	if trusted {
+		runTrusted(eval)
+	} else {
+		eval()
+	}
+	recordNSFile(resourceBase, foundFS, filename, buf, requires)
+	recordNSSource(resourceBase, filename, code)
+	traceLoad(loadTraceEntry{resource: resourceBase, fsys: foundFS, filename: filename})
 
 	// if compileFiles is set, compile the namespace to a .go file
 	compileFiles := VarCompileFiles.Get().(bool)
@@ -384,7 +438,7 @@ func (rt *RTMethods) Load(scriptBase string) {
 		return
 	}
 
-	compileNSToFile(foundFS, scriptBase)
+	compileNSToFile(foundFS, resourceBase)
 }
 
 func readFile(fs fs.FS, filename string) ([]byte, error) {
@@ -492,6 +546,18 @@ func (rt *RTMethods) Munge(name string) string {
 	return sb.String()
 }
 
+func (rt *RTMethods) ThrowableMap(err any) IPersistentMap {
+	return ThrowableMap(err)
+}
+
+func (rt *RTMethods) ExTriage(throwableMap IPersistentMap) IPersistentMap {
+	return ExTriage(throwableMap)
+}
+
+func (rt *RTMethods) ExStr(triage IPersistentMap) string {
+	return ExStr(triage)
+}
+
 func RTReadString(s string) any {
 	rdr := reader.New(strings.NewReader(s), reader.WithGetCurrentNS(func() *lang.Namespace {
 		return lang.VarCurrentNS.Deref().(*lang.Namespace)
@@ -0,0 +1,28 @@
+package runtime
+
+import (
+	"github.com/glojurelang/glojure/pkg/compiler"
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// varSandbox holds the compiler.Sandbox code is analyzed under, or nil.
+// It is not interned, so code cannot rebind it.
+var varSandbox = lang.NewVarWithRoot(lang.NSCore, lang.NewSymbol("*sandbox*"), nil).SetDynamic()
+
+// currentSandbox returns the sandbox of the current goroutine, or nil.
+func currentSandbox() compiler.Sandbox {
+	sb, _ := varSandbox.Deref().(compiler.Sandbox)
+	return sb
+}
+
+// runTrusted calls fn without the sandbox, for code the process
+// trusts, such as the libs on its load path.
+func runTrusted(fn func()) {
+	if currentSandbox() == nil {
+		fn()
+		return
+	}
+	lang.PushThreadBindings(lang.NewMap(varSandbox, nil))
+	defer lang.PopThreadBindings()
+	fn()
+}
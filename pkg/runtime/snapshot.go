@@ -0,0 +1,243 @@
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// A NamespaceSnapshot describes the vars of a namespace as they were
+// after the namespace was loaded, as static data. Loaders generated with
+// the :snapshot compiler option restore it before binding the roots of
+// the vars, in place of interning each var and building its metadata
+// and root with code. A root holding functions, vars or types is stored
+// with holes for them, which the loader fills with values it builds;
+// only roots that are themselves such values are left to the loader.
+type NamespaceSnapshot struct {
+	// Symbols and Keywords are the names of the symbols and keywords
+	// the loader uses.
+	Symbols  []string
+	Keywords []string
+	// Name is the index in Symbols of the namespace's name.
+	Name int
+	Vars []SnapshotVar
+}
+
+// A SnapshotVar describes one var interned in a snapshot's namespace.
+type SnapshotVar struct {
+	// Name is the index in Symbols of the var's name.
+	Name    int
+	Dynamic bool
+	Macro   bool
+	// Meta is the var's metadata, without :ns, in the encoding of the
+	// AST cache, or "" if the loader sets the metadata itself because
+	// it holds values that are not data.
+	Meta string
+	// Root is the var's root, in the encoding of snapshot roots, or ""
+	// if the loader builds it itself.
+	Root string
+}
+
+// RestoredSnapshot holds the values a loader refers to, in the order of
+// the snapshot it was restored from.
+type RestoredSnapshot struct {
+	Symbols  []*lang.Symbol
+	Keywords []lang.Keyword
+	Vars     []*lang.Var
+
+	snapshot *NamespaceSnapshot
+}
+
+// RestoreSnapshot interns the vars of s, with their metadata and dynamic
+// flags, and returns them with the symbols and keywords s names.
+func RestoreSnapshot(s *NamespaceSnapshot) *RestoredSnapshot {
+	res := &RestoredSnapshot{
+		Symbols:  lang.NewSymbols(s.Symbols),
+		Keywords: make([]lang.Keyword, len(s.Keywords)),
+		snapshot: s,
+	}
+	for i, name := range s.Keywords {
+		res.Keywords[i] = lang.NewKeyword(name)
+	}
+
+	ns := lang.FindOrCreateNamespace(res.Symbols[s.Name])
+	names := make([]*lang.Symbol, len(s.Vars))
+	for i := range s.Vars {
+		names[i] = res.Symbols[s.Vars[i].Name]
+	}
+	res.Vars = ns.InternVars(names)
+	for i := range s.Vars {
+		sv := &s.Vars[i]
+		vr := res.Vars[i]
+		if sv.Meta != "" {
+			vr.SetMetaSource(sv, sv.Macro)
+		}
+		if sv.Dynamic {
+			vr.SetDynamic()
+		}
+	}
+	return res
+}
+
+// VarMeta decodes the var's metadata.
+func (sv *SnapshotVar) VarMeta() lang.IPersistentMap {
+	var meta any
+	d := newASTDecoder([]byte(sv.Meta))
+	if err := d.decode(func() { meta = d.value() }); err != nil {
+		panic(fmt.Errorf("corrupt snapshot metadata: %w", err))
+	}
+	if meta == nil {
+		return lang.NewMap()
+	}
+	return meta.(lang.IPersistentMap)
+}
+
+// Root decodes the root of the snapshot's ith var, filling its holes
+// with values, in order.
+func (r *RestoredSnapshot) Root(i int, values ...any) any {
+	d := &rootDecoder{astDecoder: newASTDecoder([]byte(r.snapshot.Vars[i].Root)), holes: values}
+	var root any
+	if err := d.decode(func() { root = d.root() }); err != nil {
+		panic(fmt.Errorf("corrupt snapshot root of %v: %w", r.Vars[i], err))
+	}
+	if len(d.holes) != 0 {
+		panic(fmt.Errorf("corrupt snapshot root of %v: %d values left", r.Vars[i], len(d.holes)))
+	}
+	return root
+}
+
+// Tags of the encoding of snapshot roots. A root is data, encoded as
+// the AST cache encodes values, a hole, or a value holding roots.
+// Collections hold roots, without their metadata.
+const (
+	rootData byte = iota
+	rootHole
+	rootMap
+	rootVector
+	rootSet
+	rootList
+	rootAtom
+	rootMultiFn
+)
+
+// rootDecoder decodes snapshot roots, taking the values of their holes
+// from holes.
+type rootDecoder struct {
+	*astDecoder
+	holes []any
+}
+
+func (d *rootDecoder) root() any {
+	switch tag := d.byte(); tag {
+	case rootData:
+		return d.value()
+	case rootHole:
+		if len(d.holes) == 0 {
+			d.fail("missing value")
+		}
+		v := d.holes[0]
+		d.holes = d.holes[1:]
+		return v
+	case rootMap:
+		kvs := d.roots(2 * d.len())
+		return lang.NewMap(kvs...)
+	case rootVector:
+		return lang.NewVector(d.roots(d.len())...)
+	case rootSet:
+		return lang.NewSet(d.roots(d.len())...)
+	case rootList:
+		return lang.NewList(d.roots(d.len())...)
+	case rootAtom:
+		val := d.root()
+		if meta := d.root(); meta != nil {
+			m, ok := meta.(lang.IPersistentMap)
+			if !ok {
+				d.fail("want map, got %T", meta)
+			}
+			return lang.NewAtomWithMeta(val, m)
+		}
+		return lang.NewAtom(val)
+	case rootMultiFn:
+		name := d.string()
+		dispatchFn, ok := d.root().(lang.IFn)
+		if !ok {
+			d.fail("multimethod %s has no dispatch function", name)
+		}
+		defaultVal := d.root()
+		hierarchy, _ := d.root().(lang.IRef)
+		mf := lang.NewMultiFn(name, dispatchFn, defaultVal, hierarchy)
+		if methods := d.roots(2 * d.len()); len(methods) > 0 {
+			mf.AddMethods(methods...)
+		}
+		prefers := d.roots(2 * d.len())
+		for i := 0; i < len(prefers); i += 2 {
+			mf.PreferMethod(prefers[i], prefers[i+1])
+		}
+		return mf
+	default:
+		d.fail("bad root tag %d", tag)
+	}
+	return nil
+}
+
+func (d *rootDecoder) roots(n int) []any {
+	roots := make([]any, n)
+	for i := range roots {
+		roots[i] = d.root()
+	}
+	return roots
+}
+
+// encodeSnapshotMeta returns meta, without :ns, as SnapshotVar.Meta
+// holds it, or false if it holds values that are not data. Types and
+// classes are left to the loader, as the names they are found under
+// differ between the compiler and the programs it is built into.
+func encodeSnapshotMeta(meta lang.IPersistentMap) (string, bool) {
+	if meta == nil {
+		return "", false
+	}
+	meta = meta.Without(lang.KWNS)
+	if !snapshotData(meta) {
+		return "", false
+	}
+	e := newASTEncoder()
+	if err := e.encode(func() { e.value(meta) }); err != nil {
+		return "", false
+	}
+	return string(e.buf), true
+}
+
+// snapshotData reports whether v is made only of values that decode to
+// equal values in any program.
+func snapshotData(v any) bool {
+	if obj, ok := v.(lang.IMeta); ok {
+		if meta := obj.Meta(); meta != nil && !snapshotData(meta) {
+			return false
+		}
+	}
+	switch v := v.(type) {
+	case nil, bool, int64, int, float64, string, lang.Char, lang.Keyword,
+		*lang.BigInt, *lang.Ratio, *lang.BigDecimal:
+		return true
+	case *lang.Symbol:
+		return symbolRoundTrips(v)
+	case lang.IRecord, lang.Sorted:
+		return false
+	case lang.IPersistentMap:
+		for s := lang.Seq(v); s != nil; s = s.Next() {
+			entry := s.First().(lang.IMapEntry)
+			if !snapshotData(entry.Key()) || !snapshotData(entry.Val()) {
+				return false
+			}
+		}
+		return true
+	case lang.IPersistentVector, lang.IPersistentSet, *lang.EmptyList, lang.ISeq:
+		for s := lang.Seq(v); s != nil; s = s.Next() {
+			if !snapshotData(s.First()) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package runtime
+
+import (
+	"reflect"
+	goruntime "runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+)
+
+// Stack traces of evaluation errors are recorded as the error unwinds.
+// Each invoke form it passes through records its position, unless one
+// in the same function already has; when the error leaves a function,
+// the function names the frame, or adds one at its own position, and a
+// top-level evaluation does the same for the form it evaluated. Nothing
+// is recorded for calls that return.
+//
+// Code compiled ahead of time has no such hooks, but the //line
+// directives of its loaders map Go program counters to .glj positions.
+// The Go stack is captured when an error is first recorded and merged
+// with the recorded frames when the trace is asked for: each recorded
+// function frame belongs at the Go frame of the call that named it.
+
+// maxStackPCs bounds the Go stack captured for an error.
+const maxStackPCs = 4096
+
+// frameList is an immutable list of stack frames, outermost first, so
+// that recording a frame copies none and errors rethrown from a catch
+// share the frames recorded before it.
+type frameList struct {
+	frame lang.StackFrame
+	inner *frameList
+}
+
+// stackBoundaries are the Go functions that name the frames of the
+// errors that unwind through them.
+var stackBoundaries = func() map[string]bool {
+	pkg := reflect.TypeOf(Fn{}).PkgPath()
+	boundaries := make(map[string]bool)
+	for _, fn := range []string{
+		"(*Fn).invokeSingle1",
+		"(*Fn).invokeSingle2",
+		"(*Fn).invokeSingle3",
+		"(*Fn).invokeMethod",
+		"(*environment).Eval",
+	} {
+		boundaries[pkg+"."+fn] = true
+	}
+	return boundaries
+}()
+
+// newEvalError returns an evaluation error for err, raised at frame.
+func newEvalError(err error, frame lang.StackFrame) *RTEvalError {
+	return &RTEvalError{
+		Err:    err,
+		frames: &frameList{frame: frame},
+		pcs:    callers(),
+	}
+}
+
+// callers returns the program counters of the calling goroutine's
+// stack.
+func callers() []uintptr {
+	pcs := make([]uintptr, 64)
+	for {
+		n := goruntime.Callers(2, pcs)
+		if n < len(pcs) || len(pcs) >= maxStackPCs {
+			return pcs[:n]
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
+// withFrame returns e with frame, the position of an invoke form it
+// unwound through, recorded, unless the position of one in the same
+// function already is.
+func (e *RTEvalError) withFrame(frame lang.StackFrame) *RTEvalError {
+	if e.frames != nil && e.frames.frame.FunctionName == "" {
+		return e
+	}
+	cpy := *e
+	cpy.frames = &frameList{frame: frame, inner: e.frames}
+	return &cpy
+}
+
+// inFunction returns e with its outermost frame named as the function
+// name of namespace ns, as it leaves the function. If the frame is
+// already named, the error was raised outside any invoke form of the
+// function, and a frame at the position pos returns is added.
+func (e *RTEvalError) inFunction(ns, name string, pos func() lang.StackFrame) *RTEvalError {
+	var frame lang.StackFrame
+	inner := e.frames
+	if e.frames != nil && e.frames.frame.FunctionName == "" {
+		frame, inner = e.frames.frame, e.frames.inner
+	} else {
+		frame = pos()
+	}
+	frame.Namespace, frame.FunctionName = ns, name
+	cpy := *e
+	cpy.frames = &frameList{frame: frame, inner: inner}
+	return &cpy
+}
+
+// Stack returns the frames of the Glojure stack trace of the error,
+// innermost first.
+func (e *RTEvalError) Stack() []lang.StackFrame {
+	var recorded []lang.StackFrame
+	for f := e.frames; f != nil; f = f.inner {
+		recorded = append(recorded, f.frame)
+	}
+	slices.Reverse(recorded)
+	if len(e.pcs) == 0 {
+		return recorded
+	}
+
+	var (
+		stack []lang.StackFrame
+		defs  *aotDefs
+	)
+	frames := goruntime.CallersFrames(e.pcs)
+	for {
+		f, more := frames.Next()
+		switch {
+		case strings.HasSuffix(f.File, ".glj"):
+			if defs == nil {
+				defs = newAOTDefs()
+			}
+			stack = append(stack, defs.frame(f))
+		case stackBoundaries[f.Function]:
+			for len(recorded) > 0 {
+				frame := recorded[0]
+				recorded = recorded[1:]
+				stack = append(stack, frame)
+				if frame.FunctionName != "" {
+					break
+				}
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	// The Go stack was captured elsewhere if the error was rethrown
+	// after it unwound, by a delay or a future.
+	return append(stack, recorded...)
+}
+
+// aotDefs indexes the vars of the loaded namespaces by the file and line
+// they are defined at, to name the frames of code compiled ahead of
+// time.
+type aotDefs struct {
+	files map[string][]aotDef
+}
+
+type aotDef struct {
+	line int
+	vr   *lang.Var
+}
+
+func newAOTDefs() *aotDefs {
+	files := make(map[string][]aotDef)
+	for s := lang.AllNamespaces(); s != nil; s = s.Next() {
+		ns := s.First().(*lang.Namespace)
+		for m := lang.Seq(ns.Mappings()); m != nil; m = m.Next() {
+			vr, ok := m.First().(lang.IMapEntry).Val().(*lang.Var)
+			if !ok || vr.Namespace() != ns {
+				continue
+			}
+			meta := vr.Meta()
+			file, _ := lang.Get(meta, lang.KWFile).(string)
+			line, _ := lang.Get(meta, lang.KWLine).(int)
+			if file == "" || line <= 0 {
+				continue
+			}
+			files[file] = append(files[file], aotDef{line: line, vr: vr})
+		}
+	}
+	for _, defs := range files {
+		sort.Slice(defs, func(i, j int) bool { return defs[i].line < defs[j].line })
+	}
+	return &aotDefs{files: files}
+}
+
+// frame returns the stack frame of f, a Go frame positioned in a .glj
+// file, in the last var defined in the file before it. The file is the
+// one the var's metadata names, relative to the load path, as in the
+// frames of evaluated code.
+func (d *aotDefs) frame(f goruntime.Frame) lang.StackFrame {
+	frame := lang.StackFrame{Filename: f.File, Line: f.Line}
+	file := ""
+	for name := range d.files {
+		if (f.File == name || strings.HasSuffix(f.File, "/"+name)) && len(name) > len(file) {
+			file = name
+		}
+	}
+	if file == "" {
+		return frame
+	}
+	frame.Filename = file
+	defs := d.files[file]
+	i := sort.Search(len(defs), func(i int) bool { return defs[i].line > f.Line })
+	if i > 0 {
+		vr := defs[i-1].vr
+		frame.Namespace = vr.Namespace().Name().Name()
+		frame.FunctionName = vr.Symbol().Name()
+	}
+	return frame
+}
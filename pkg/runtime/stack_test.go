@@ -0,0 +1,71 @@
+//go:build !glj_aot_runtime
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+func TestStackTrace(t *testing.T) {
+	env := NewEnvironment().(*environment)
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, env.CurrentNamespace()))
+	t.Cleanup(lang.PopThreadBindings)
+
+	rdr := reader.New(strings.NewReader(`(ns stack.test)
+(defn inner [x] (/ 1 x))
+(defn middle [x]
+  (inc (inner x)))
+(defn rethrow [x] (try (middle x) (catch Exception e (throw e))))
+(defn thrower [] (throw (ex-info "boom" {})))
+(defn lazy [x] (map inner [x]))
+`), reader.WithFilename("stack.glj"))
+	for {
+		form, err := rdr.ReadOne()
+		if err == reader.ErrEOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := env.Eval(form); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lang.InternVarReplaceRoot(lang.FindNamespace(lang.NewSymbol("stack.test")), lang.NewSymbol("go-call"),
+		lang.FnFunc(func(args ...any) any {
+			return lang.Apply(args[0], args[1:])
+		}))
+
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"(middle 0)", "stack.test/inner stack.glj:2:7, stack.test/middle stack.glj:4:8, stack.test/eval repl.glj:1:1"},
+		{"(rethrow 0)", "stack.test/inner stack.glj:2:7, stack.test/middle stack.glj:4:8, stack.test/rethrow stack.glj:5:24, stack.test/eval repl.glj:1:1"},
+		{"(thrower)", "stack.test/thrower stack.glj:6:7, stack.test/eval repl.glj:1:1"},
+		{"(let [s (lazy 0)] (first s))", "stack.test/inner stack.glj:2:7, clojure.core/first clojure/core.glj:54:0, stack.test/eval repl.glj:1:19"},
+		{"(go-call middle 0)", "stack.test/inner stack.glj:2:7, stack.test/middle stack.glj:4:8, stack.test/eval repl.glj:1:1"},
+	} {
+		form, err := reader.New(strings.NewReader(tc.code), reader.WithFilename("repl.glj")).ReadOne()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = env.Eval(form)
+		if err == nil {
+			t.Errorf("%s succeeded", tc.code)
+			continue
+		}
+		var frames []string
+		for _, f := range lang.StackTrace(err) {
+			frames = append(frames, fmt.Sprintf("%s %s:%d:%d", f.QualifiedName(), f.Filename, f.Line, f.Column))
+		}
+		if got := strings.Join(frames, ", "); got != tc.want {
+			t.Errorf("%s has stack trace\n%s\nwant\n%s", tc.code, got, tc.want)
+		}
+	}
+}
@@ -180,13 +180,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -422,6 +422,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -495,6 +496,8 @@ func LoadNS() {
 	}
 	ns.AddAlias(sym_core, lang.FindOrCreateNamespace(sym_clojure_DOT_core))
 	// >!
+
+//line ../../../clojure/core/async.glj:44:7
 	{
 		tmp0 := sym__GT__BANG_
 		var tmp1 lang.FnFunc2
@@ -503,7 +506,9 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:48:3
 			tmp4 := lang.Apply2(lang.Builtins["send"], v2, v3)
+//line ../../../clojure/core/async.glj:44:7
 			_ = tmp4
 			return true
 		})
@@ -513,7 +518,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(44), kw_column, int(7), kw_end_DASH_line, int(44), kw_end_DASH_column, int(8), kw_arglists, lang.NewList(lang.NewVector(sym_port, sym_val)), kw_doc, "puts a val into port. nil values are not allowed. Will park if no buffer space is available.\n  Returns true, or throws if port is already closed.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:522
 	// >!!
+
+//line ../../../clojure/core/async.glj:51:6
 	{
 		tmp0 := sym__GT__BANG__BANG_
 		var tmp1 lang.FnFunc2
@@ -522,7 +530,9 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:48:3
 			tmp4 := lang.Apply2(lang.Builtins["send"], v2, v3)
+//line ../../../clojure/core/async.glj:51:6
 			_ = tmp4
 			return true
 		})
@@ -532,17 +542,24 @@ func LoadNS() {
 			return lang.NewMap(kw_file, "clojure/core/async.glj", kw_line, int(51), kw_column, int(6), kw_end_DASH_line, int(51), kw_end_DASH_column, int(8), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:546
 	// <!
+
+//line ../../../clojure/core/async.glj:35:7
 	{
 		tmp0 := sym__LT__BANG_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:39:3
 			var tmp3 any
 			{ // let
 				// let binding "vec__690"
+
+//line ../../../clojure/core/async.glj:39:17
 				tmp4 := lang.Apply1(lang.Builtins["recv"], v2)
+//line ../../../clojure/core/async.glj:39:3
 				var v5 any = tmp4
 				_ = v5
 				// let binding "val"
@@ -555,6 +572,7 @@ func LoadNS() {
 				_ = v9
 				tmp3 = v7
 			} // end let
+//line ../../../clojure/core/async.glj:35:7
 			return tmp3
 		})
 		aotDirectFn0 = tmp1
@@ -563,17 +581,24 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(35), kw_column, int(7), kw_end_DASH_line, int(35), kw_end_DASH_column, int(8), kw_arglists, lang.NewList(lang.NewVector(sym_port)), kw_doc, "takes a val from port. Will return nil if closed. Will park if\n  nothing is available.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:585
 	// <!!
+
+//line ../../../clojure/core/async.glj:42:6
 	{
 		tmp0 := sym__LT__BANG__BANG_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:39:3
 			var tmp3 any
 			{ // let
 				// let binding "vec__690"
+
+//line ../../../clojure/core/async.glj:39:17
 				tmp4 := lang.Apply1(lang.Builtins["recv"], v2)
+//line ../../../clojure/core/async.glj:39:3
 				var v5 any = tmp4
 				_ = v5
 				// let binding "val"
@@ -586,6 +611,7 @@ func LoadNS() {
 				_ = v9
 				tmp3 = v7
 			} // end let
+//line ../../../clojure/core/async.glj:42:6
 			return tmp3
 		})
 		aotDirectFn1 = tmp1
@@ -594,7 +620,10 @@ func LoadNS() {
 			return lang.NewMap(kw_file, "clojure/core/async.glj", kw_line, int(42), kw_column, int(6), kw_end_DASH_line, int(42), kw_end_DASH_column, int(8), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:624
 	// alt!
+
+//line ../../../clojure/core/async.glj:250:11
 	{
 		tmp0 := sym_alt_BANG_
 		var tmp1 lang.ArityFn
@@ -611,7 +640,9 @@ func LoadNS() {
 				_ = v3
 				var v4 any = rest
 				_ = v4
+//line ../../../clojure/core/async.glj:279:3
 				tmp5 := aotDirectFn10(sym_clojure_DOT_core_DOT_async_SLASH_alts_BANG_, v4)
+//line ../../../clojure/core/async.glj:250:11
 				return tmp5
 			}),
 			2,
@@ -621,7 +652,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(250), kw_column, int(11), kw_end_DASH_line, int(250), kw_end_DASH_column, int(14), kw_arglists, lang.NewList(lang.NewVector(sym__AMP_, sym_clauses)), kw_doc, "Makes a single choice between one of several channel operations,\n  as if by alts!, returning the value of the result expr corresponding\n  to the operation completed.\n\n  Each clause takes the form of:\n\n  channel-op[s] result-expr\n\n  where channel-ops is one of:\n\n  take-port - a single port to take\n  [take-port | [put-port put-val] ...] - a vector of ports as per alts!\n  :default | :priority - an option for alts!\n\n  and result-expr is either a list beginning with a vector, whereupon that\n  vector will be treated as a binding for the [val port] return of the\n  operation, else any other expression.\n\n  (alt!\n    [c t] ([val ch] (foo ch val))\n    x ([v] v)\n    [[out val]] :wrote\n    :default 42)\n\n  Each option may appear at most once. The choice and parking\n  characteristics are those of alts!.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async), kw_macro, true)
 		})
 	}
+//line loader.go:656
 	// alts!
+
+//line ../../../clojure/core/async.glj:185:7
 	{
 		tmp0 := sym_alts_BANG_
 		var tmp1 lang.ArityFn
@@ -672,7 +706,9 @@ func LoadNS() {
 					// let binding "opts"
 					var v17 any = v16
 					_ = v17
+//line ../../../clojure/core/async.glj:208:3
 					tmp18 := aotDirectFn11(v2, v17)
+//line ../../../clojure/core/async.glj:185:7
 					tmp4 = tmp18
 				} // end let
 				return tmp4
@@ -685,7 +721,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(185), kw_column, int(7), kw_end_DASH_line, int(185), kw_end_DASH_column, int(11), kw_arglists, lang.NewList(lang.NewVector(sym_ports, sym__AMP_, lang.NewMap(kw_as, sym_opts))), kw_doc, "Completes at most one of several channel operations. Must ports is a\n  vector of channel endpoints, which can be either a channel to take\n  from or a vector of [channel-to-put-to val-to-put], in any\n  combination.  Takes will be made as if by <!, and puts will be made\n  as if by >!. Unless the :priority option is true, if more than one\n  port operation is ready a non-deterministic choice will be made. If\n  no operation is ready and a :default value is\n  supplied, [default-val :default] will be returned, otherwise alts!\n  will park until the first operation to become ready\n  completes. Returns [val port] of the completed operation, where val\n  is the value taken for takes, and true for puts.\n\n  opts are passed as :key val ... Supported options:\n\n  :default val - the value to use if none of the operations are immediately ready\n  :priority true - (default nil) when true, the operations will be tried in order.\n\n  Note: there is no guarantee that the port exps or val exprs will be\n  used, nor in what order should they be, so they should not be\n  depended upon for side effects.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:725
 	// alts!!
+
+//line ../../../clojure/core/async.glj:210:6
 	{
 		tmp0 := sym_alts_BANG__BANG_
 		var tmp1 lang.ArityFn
@@ -736,7 +775,9 @@ func LoadNS() {
 					// let binding "opts"
 					var v17 any = v16
 					_ = v17
+//line ../../../clojure/core/async.glj:208:3
 					tmp18 := aotDirectFn11(v2, v17)
+//line ../../../clojure/core/async.glj:210:6
 					tmp4 = tmp18
 				} // end let
 				return tmp4
@@ -749,18 +790,25 @@ func LoadNS() {
 			return lang.NewMap(kw_file, "clojure/core/async.glj", kw_line, int(210), kw_column, int(6), kw_end_DASH_line, int(210), kw_end_DASH_column, int(11), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:794
 	// chan
+
+//line ../../../clojure/core/async.glj:18:7
 	{
 		tmp0 := sym_chan
 		var tmp1 lang.ArityFn
 		aotDirectFn6Arity0 = lang.FnFunc0(func() any {
+//line ../../../clojure/core/async.glj:27:7
 			tmp2 := aotDirectFn6Arity1(nil)
+//line ../../../clojure/core/async.glj:18:7
 			return tmp2
 		})
 		aotDirectFn6Arity1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:28:15
 			tmp3 := aotDirectFn6Arity2(v2, nil)
+//line ../../../clojure/core/async.glj:18:7
 			return tmp3
 		})
 		aotDirectFn6Arity2 = lang.FnFunc2(func(p0, p1 any) any {
@@ -768,7 +816,9 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:29:21
 			tmp4 := aotDirectFn6Arity3(v2, v3, nil)
+//line ../../../clojure/core/async.glj:18:7
 			return tmp4
 		})
 		aotDirectFn6Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -778,19 +828,28 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../../clojure/core/async.glj:31:4
 			var tmp5 any
 			if lang.IsTruthy(v3) {
 			} else {
 			}
+//line ../../../clojure/core/async.glj:18:7
 			_ = tmp5
+//line ../../../clojure/core/async.glj:32:4
 			var tmp6 any
 			if lang.IsTruthy(v3) {
+//line ../../../clojure/core/async.glj:32:23
 				tmp7 := lang.Apply1(fmt.Errorf, "xform and ex-handler not yet supported")
+//line ../../../clojure/core/async.glj:32:16
 				panic(tmp7)
+//line ../../../clojure/core/async.glj:32:4
 			} else {
 			}
+//line ../../../clojure/core/async.glj:18:7
 			_ = tmp6
+//line ../../../clojure/core/async.glj:33:13
 			tmp8 := lang.Apply1(lang.Builtins["chan-of"], lang.Builtins["any"])
+//line ../../../clojure/core/async.glj:33:33
 			var tmp9 any
 			{ // let
 				// let binding "or__0__auto__"
@@ -804,7 +863,9 @@ func LoadNS() {
 				}
 				tmp9 = tmp11
 			} // end let
+//line ../../../clojure/core/async.glj:33:4
 			tmp10 := lang.Apply2(lang.Builtins["make"], tmp8, tmp9)
+//line ../../../clojure/core/async.glj:18:7
 			return tmp10
 		})
 		tmp1 = lang.NewArityFn(
@@ -822,48 +883,75 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(18), kw_column, int(7), kw_end_DASH_line, int(18), kw_end_DASH_column, int(10), kw_arglists, lang.NewList(lang.NewVector(), lang.NewVector(sym_buf_DASH_or_DASH_n), lang.NewVector(sym_buf_DASH_or_DASH_n, sym_xform), lang.NewVector(sym_buf_DASH_or_DASH_n, sym_xform, sym_ex_DASH_handler)), kw_doc, "Creates a channel with an optional buffer, an optional transducer\n  (like (map f), (filter p) etc or a composition thereof), and an\n  optional exception-handler.  If buf-or-n is a number, will create\n  and use a fixed buffer of that size. If a transducer is supplied a\n  buffer must be specified. ex-handler must be a fn of one argument -\n  if an exception occurs during transformation it will be called with\n  the Throwable as an argument, and any non-nil return value will be\n  placed in the channel.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:887
 	// check-unique-ports!
+
+//line ../../../clojure/core/async.glj:158:8
 	{
 		tmp0 := sym_check_DASH_unique_DASH_ports_BANG_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:160:3
 			var tmp3 any
 			{ // let
 				// let binding "chans"
+
+//line ../../../clojure/core/async.glj:160:20
 				var tmp4 lang.FnFunc1
 				tmp4 = lang.FnFunc1(func(p0 any) any {
 					v5 := p0
 					_ = v5
+//line ../../../clojure/core/async.glj:160:21
 					var tmp6 any
+//line ../../../clojure/core/async.glj:160:25
 					tmp7 := aotExternalFn8(v5)
+//line ../../../clojure/core/async.glj:160:21
 					if lang.IsTruthy(tmp7) {
+//line ../../../clojure/core/async.glj:160:37
 						tmp8 := lang.Apply1(v5, int64(0))
+//line ../../../clojure/core/async.glj:160:21
 						tmp6 = tmp8
 					} else {
 						tmp6 = v5
 					}
+//line ../../../clojure/core/async.glj:160:20
 					return tmp6
 				})
+//line ../../../clojure/core/async.glj:160:15
 				tmp5 := aotExternalFn7(tmp4, v2)
+//line ../../../clojure/core/async.glj:160:3
 				var v6 any = tmp5
 				_ = v6
 				// let binding "s"
+
+//line ../../../clojure/core/async.glj:161:11
 				tmp7 := aotExternalFn9(v6)
+//line ../../../clojure/core/async.glj:160:3
 				var v8 any = tmp7
 				_ = v8
+//line ../../../clojure/core/async.glj:162:5
 				var tmp9 any
+//line ../../../clojure/core/async.glj:162:17
 				tmp10 := lang.Count(v8)
+//line ../../../clojure/core/async.glj:162:27
 				tmp11 := lang.Count(v2)
+//line ../../../clojure/core/async.glj:162:11
 				tmp12 := aotExternalFn10(tmp10, tmp11)
+//line ../../../clojure/core/async.glj:162:5
 				if lang.IsTruthy(tmp12) {
+//line ../../../clojure/core/async.glj:163:14
 					tmp13 := lang.Apply1(fmt.Errorf, "duplicate ports found in alt(s)! operation")
+//line ../../../clojure/core/async.glj:163:7
 					panic(tmp13)
+//line ../../../clojure/core/async.glj:162:5
 				} else {
 				}
+//line ../../../clojure/core/async.glj:160:3
 				tmp3 = tmp9
 			} // end let
+//line ../../../clojure/core/async.glj:158:8
 			return tmp3
 		})
 		aotDirectFn7 = tmp1
@@ -872,14 +960,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(158), kw_column, int(8), kw_end_DASH_line, int(158), kw_end_DASH_column, int(26), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_ports)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:964
 	// close!
+
+//line ../../../clojure/core/async.glj:53:7
 	{
 		tmp0 := sym_close_BANG_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:65:3
 			tmp3 := lang.Apply1(lang.Builtins["close"], v2)
+//line ../../../clojure/core/async.glj:53:7
 			return tmp3
 		})
 		aotDirectFn8 = tmp1
@@ -888,19 +981,28 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(53), kw_column, int(7), kw_end_DASH_line, int(53), kw_end_DASH_column, int(12), kw_arglists, lang.NewList(lang.NewVector(sym_chan)), kw_doc, "Closes a channel. The channel will no longer accept any puts (they\n  will be ignored). Data in the channel remains available for taking,\n  until exhausted, after which takes will return nil. If there are any\n  pending takes, they will be dispatched with nil. Closing a closed\n  channel will throw an exception.\n\n  Logically closing happens after all puts have been\n  delivered. Therefore, any blocked or parked puts will remain\n  blocked/parked until a taker releases them.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:985
 	// default-case
+
+//line ../../../clojure/core/async.glj:100:8
 	{
 		tmp0 := sym_default_DASH_case
 		var tmp1 lang.FnFunc0
 		tmp1 = lang.FnFunc0(func() any {
+//line ../../../clojure/core/async.glj:102:3
 			var tmp2 any
 			{ // let
 				// let binding "def"
+
+//line ../../../clojure/core/async.glj:102:13
 				tmp3 := reflect.TypeOf((*reflect.SelectCase)(nil)).Elem()
 				tmp4 := lang.NewHostInstance(tmp3)
+//line ../../../clojure/core/async.glj:102:3
 				var v5 any = tmp4
 				_ = v5
 				// set! host field
+
+//line ../../../clojure/core/async.glj:103:5
 				var tmp6 any
 				{
 					targetV := reflect.ValueOf(v5)
@@ -927,9 +1029,11 @@ func LoadNS() {
 					}
 					tmp6 = reflect.SelectDefault
 				}
+//line ../../../clojure/core/async.glj:102:3
 				_ = tmp6
 				tmp2 = v5
 			} // end let
+//line ../../../clojure/core/async.glj:100:8
 			return tmp2
 		})
 		aotDirectFn9 = tmp1
@@ -938,7 +1042,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(100), kw_column, int(8), kw_end_DASH_line, int(100), kw_end_DASH_column, int(19), kw_private, true, kw_arglists, lang.NewList(lang.NewVector()), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1046
 	// offer!
+
+//line ../../../clojure/core/async.glj:143:7
 	{
 		tmp0 := sym_offer_BANG_
 		var tmp1 lang.FnFunc2
@@ -947,19 +1054,26 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:147:3
 			var tmp4 any
 			{ // let
 				// let binding "ret"
+
+//line ../../../clojure/core/async.glj:147:13
 				tmp5 := aotDirectFn17(v2, v3)
+//line ../../../clojure/core/async.glj:147:3
 				var v6 any = tmp5
 				_ = v6
+//line ../../../clojure/core/async.glj:148:5
 				var tmp7 any
 				if lang.IsTruthy(v6) {
 					tmp7 = v6
 				} else {
 				}
+//line ../../../clojure/core/async.glj:147:3
 				tmp4 = tmp7
 			} // end let
+//line ../../../clojure/core/async.glj:143:7
 			return tmp4
 		})
 		aotDirectFn12 = tmp1
@@ -968,7 +1082,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(143), kw_column, int(7), kw_end_DASH_line, int(143), kw_end_DASH_column, int(12), kw_arglists, lang.NewList(lang.NewVector(sym_port, sym_val)), kw_doc, "Puts a val into port if it's possible to do so immediately.\n   nil values are not allowed. Never blocks. Returns true if offer succeeds.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1086
 	// pipe
+
+//line ../../../clojure/core/async.glj:292:7
 	{
 		tmp0 := sym_pipe
 		var tmp1 lang.ArityFn
@@ -977,7 +1094,9 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:297:14
 			tmp4 := aotDirectFn13Arity3(v2, v3, true)
+//line ../../../clojure/core/async.glj:292:7
 			return tmp4
 		})
 		aotDirectFn13Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -987,6 +1106,7 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../../clojure/core/async.glj:299:6
 			var tmp5 any
 			{ // let
 				// let binding "c__0__auto__"
@@ -1002,33 +1122,52 @@ func LoadNS() {
 						var tmp10 any
 						{ // let
 							for {
+//line ../../../clojure/core/async.glj:300:7
 								var tmp11 any
 								{ // let
 									// let binding "v"
+
+//line ../../../clojure/core/async.glj:300:15
 									tmp12 := aotDirectFn0(v2)
+//line ../../../clojure/core/async.glj:300:7
 									var v13 any = tmp12
 									_ = v13
+//line ../../../clojure/core/async.glj:301:9
 									var tmp14 any
+//line ../../../clojure/core/async.glj:301:13
 									tmp15 := lang.Identical(v13, nil)
+//line ../../../clojure/core/async.glj:301:9
 									if lang.IsTruthy(tmp15) {
+//line ../../../clojure/core/async.glj:302:11
 										var tmp16 any
 										if lang.IsTruthy(v4) {
+//line ../../../clojure/core/async.glj:302:24
 											tmp17 := aotDirectFn8(v3)
+//line ../../../clojure/core/async.glj:302:11
 											tmp16 = tmp17
 										} else {
 										}
+//line ../../../clojure/core/async.glj:301:9
 										tmp14 = tmp16
 									} else {
+//line ../../../clojure/core/async.glj:303:11
 										var tmp18 any
+//line ../../../clojure/core/async.glj:303:17
 										tmp19 := aotDirectFn2(v3, v13)
+//line ../../../clojure/core/async.glj:303:11
 										if lang.IsTruthy(tmp19) {
+//line ../../../clojure/core/async.glj:304:13
 											continue
+//line ../../../clojure/core/async.glj:303:11
 										} else {
 										}
+//line ../../../clojure/core/async.glj:301:9
 										tmp14 = tmp18
 									}
+//line ../../../clojure/core/async.glj:300:7
 									tmp11 = tmp14
 								} // end let
+//line ../../../clojure/core/async.glj:299:6
 								tmp10 = tmp11
 								break
 							}
@@ -1047,6 +1186,7 @@ func LoadNS() {
 				tmp11 := lang.Apply1(tmp10, v7)
 				tmp5 = tmp11
 			} // end let
+//line ../../../clojure/core/async.glj:292:7
 			_ = tmp5
 			return v3
 		})
@@ -1065,17 +1205,24 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(292), kw_column, int(7), kw_end_DASH_line, int(292), kw_end_DASH_column, int(10), kw_arglists, lang.NewList(lang.NewVector(sym_from, sym_to), lang.NewVector(sym_from, sym_to, sym_close_QMARK_)), kw_doc, "Takes elements from the from channel and supplies them to the to\n  channel. By default, the to channel will be closed when the from\n  channel closes, but can be determined by the close?  parameter. Will\n  stop consuming the from channel if the to channel closes", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1209
 	// poll!
+
+//line ../../../clojure/core/async.glj:150:7
 	{
 		tmp0 := sym_poll_BANG_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:154:3
 			var tmp3 any
 			{ // let
 				// let binding "res"
+
+//line ../../../clojure/core/async.glj:154:13
 				tmp4 := aotDirectFn18(v2)
+//line ../../../clojure/core/async.glj:154:3
 				var v5 any = tmp4
 				_ = v5
 				// let binding "vec__699"
@@ -1089,13 +1236,16 @@ func LoadNS() {
 				tmp9 := runtime.RT.NthDefault(v6, lang.IntCast(int64(1)), nil)
 				var v10 any = tmp9
 				_ = v10
+//line ../../../clojure/core/async.glj:156:5
 				var tmp11 any
 				if lang.IsTruthy(v10) {
 					tmp11 = v8
 				} else {
 				}
+//line ../../../clojure/core/async.glj:154:3
 				tmp3 = tmp11
 			} // end let
+//line ../../../clojure/core/async.glj:150:7
 			return tmp3
 		})
 		aotDirectFn14 = tmp1
@@ -1104,26 +1254,39 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(150), kw_column, int(7), kw_end_DASH_line, int(150), kw_end_DASH_column, int(11), kw_arglists, lang.NewList(lang.NewVector(sym_port)), kw_doc, "Takes a val from port if it's possible to do so immediately.\n   Never blocks. Returns value if successful, nil otherwise.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1258
 	// port-case
+
+//line ../../../clojure/core/async.glj:106:8
 	{
 		tmp0 := sym_port_DASH_case
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:109:3
 			var tmp3 any
 			{ // let
 				// let binding "wport"
+
+//line ../../../clojure/core/async.glj:109:15
 				var tmp4 any
+//line ../../../clojure/core/async.glj:109:21
 				tmp5 := aotExternalFn8(v2)
+//line ../../../clojure/core/async.glj:109:15
 				if lang.IsTruthy(tmp5) {
+//line ../../../clojure/core/async.glj:109:43
 					tmp6 := lang.Apply1(v2, int64(0))
+//line ../../../clojure/core/async.glj:109:15
 					tmp4 = tmp6
 				} else {
 				}
+//line ../../../clojure/core/async.glj:109:3
 				var v7 any = tmp4
 				_ = v7
 				// let binding "port"
+
+//line ../../../clojure/core/async.glj:110:14
 				var tmp8 any
 				{ // let
 					// let binding "or__0__auto__"
@@ -1137,25 +1300,38 @@ func LoadNS() {
 					}
 					tmp8 = tmp10
 				} // end let
+//line ../../../clojure/core/async.glj:109:3
 				var v9 any = tmp8
 				_ = v9
 				// let binding "val"
+
+//line ../../../clojure/core/async.glj:111:13
 				var tmp10 any
 				if lang.IsTruthy(v7) {
+//line ../../../clojure/core/async.glj:111:42
 					tmp11 := lang.Apply1(v2, int64(1))
+//line ../../../clojure/core/async.glj:111:25
 					tmp12 := reflect.ValueOf(tmp11)
+//line ../../../clojure/core/async.glj:111:13
 					tmp10 = tmp12
 				} else {
 				}
+//line ../../../clojure/core/async.glj:109:3
 				var v13 any = tmp10
 				_ = v13
 				// let binding "select-case"
+
+//line ../../../clojure/core/async.glj:112:21
 				tmp14 := reflect.TypeOf((*reflect.SelectCase)(nil)).Elem()
 				tmp15 := lang.NewHostInstance(tmp14)
+//line ../../../clojure/core/async.glj:109:3
 				var v16 any = tmp15
 				_ = v16
+//line ../../../clojure/core/async.glj:113:31
 				tmp17 := reflect.ValueOf(v9)
 				// set! host field
+
+//line ../../../clojure/core/async.glj:113:5
 				var tmp18 any
 				{
 					targetV := reflect.ValueOf(v16)
@@ -1182,7 +1358,9 @@ func LoadNS() {
 					}
 					tmp18 = tmp17
 				}
+//line ../../../clojure/core/async.glj:109:3
 				_ = tmp18
+//line ../../../clojure/core/async.glj:114:30
 				var tmp19 any
 				if lang.IsTruthy(v7) {
 					tmp19 = reflect.SelectSend
@@ -1190,6 +1368,8 @@ func LoadNS() {
 					tmp19 = reflect.SelectRecv
 				}
 				// set! host field
+
+//line ../../../clojure/core/async.glj:114:5
 				var tmp20 any
 				{
 					targetV := reflect.ValueOf(v16)
@@ -1216,10 +1396,14 @@ func LoadNS() {
 					}
 					tmp20 = tmp19
 				}
+//line ../../../clojure/core/async.glj:109:3
 				_ = tmp20
+//line ../../../clojure/core/async.glj:115:5
 				var tmp21 any
 				if lang.IsTruthy(v7) {
 					// set! host field
+
+//line ../../../clojure/core/async.glj:115:15
 					var tmp22 any
 					{
 						targetV := reflect.ValueOf(v16)
@@ -1246,12 +1430,15 @@ func LoadNS() {
 						}
 						tmp22 = v13
 					}
+//line ../../../clojure/core/async.glj:115:5
 					tmp21 = tmp22
 				} else {
 				}
+//line ../../../clojure/core/async.glj:109:3
 				_ = tmp21
 				tmp3 = v16
 			} // end let
+//line ../../../clojure/core/async.glj:106:8
 			return tmp3
 		})
 		aotDirectFn15 = tmp1
@@ -1260,25 +1447,38 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(106), kw_column, int(8), kw_end_DASH_line, int(106), kw_end_DASH_column, int(16), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_port_DASH_or_DASH_put)), kw_doc, "Returns a *reflect.SelectCase for the given channel operation.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1451
 	// timeout
+
+//line ../../../clojure/core/async.glj:91:7
 	{
 		tmp0 := sym_timeout
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:94:3
 			var tmp3 any
 			{ // let
 				// let binding "ret"
+
+//line ../../../clojure/core/async.glj:94:13
 				tmp4 := aotDirectFn6Arity0()
+//line ../../../clojure/core/async.glj:94:3
 				var v5 any = tmp4
 				_ = v5
 				// let binding "after"
+
+//line ../../../clojure/core/async.glj:95:36
 				tmp6 := lang.Apply1(lang.Builtins["int64"], time4.Millisecond)
+//line ../../../clojure/core/async.glj:95:27
 				tmp7 := lang.Numbers.Multiply(v2, tmp6)
+//line ../../../clojure/core/async.glj:95:15
 				tmp8 := lang.Apply1(time4.After, tmp7)
+//line ../../../clojure/core/async.glj:94:3
 				var v9 any = tmp8
 				_ = v9
+//line ../../../clojure/core/async.glj:96:5
 				var tmp10 any
 				{ // let
 					// let binding "c__0__auto__"
@@ -1291,9 +1491,14 @@ func LoadNS() {
 						var tmp14 any
 						{ // let
 							// let binding "res__2__auto__"
+
+//line ../../../clojure/core/async.glj:96:13
 							tmp15 := aotDirectFn0(v9)
+//line ../../../clojure/core/async.glj:96:9
 							_ = tmp15
+//line ../../../clojure/core/async.glj:97:13
 							tmp16 := aotDirectFn8(v5)
+//line ../../../clojure/core/async.glj:96:5
 							var v17 any = tmp16
 							_ = v17
 							tmp18 := aotDirectFn2(v12, v17)
@@ -1308,9 +1513,11 @@ func LoadNS() {
 					tmp16 := lang.Apply1(tmp15, v12)
 					tmp10 = tmp16
 				} // end let
+//line ../../../clojure/core/async.glj:94:3
 				_ = tmp10
 				tmp3 = v5
 			} // end let
+//line ../../../clojure/core/async.glj:91:7
 			return tmp3
 		})
 		aotDirectFn16 = tmp1
@@ -1319,7 +1526,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(91), kw_column, int(7), kw_end_DASH_line, int(91), kw_end_DASH_column, int(13), kw_arglists, lang.NewList(lang.NewVector(sym_msecs)), kw_doc, "Returns a channel that will close after msecs", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1530
 	// try-put
+
+//line ../../../clojure/core/async.glj:118:8
 	{
 		tmp0 := sym_try_DASH_put
 		var tmp1 lang.FnFunc2
@@ -1328,21 +1538,34 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:122:3
 			var tmp4 any
 			{ // let
 				// let binding "sc"
+
+//line ../../../clojure/core/async.glj:122:23
 				tmp5 := lang.NewVector(v2, v3)
+//line ../../../clojure/core/async.glj:122:12
 				tmp6 := aotDirectFn15(tmp5)
+//line ../../../clojure/core/async.glj:122:3
 				var v7 any = tmp6
 				_ = v7
 				// let binding "def"
+
+//line ../../../clojure/core/async.glj:123:13
 				tmp8 := aotDirectFn9()
+//line ../../../clojure/core/async.glj:122:3
 				var v9 any = tmp8
 				_ = v9
 				// let binding "vec__693"
+
+//line ../../../clojure/core/async.glj:124:55
 				tmp10 := lang.NewVector(v7, v9)
+//line ../../../clojure/core/async.glj:124:41
 				tmp11 := aotExternalFn7(lang.Builtins["deref"], tmp10)
+//line ../../../clojure/core/async.glj:124:25
 				tmp12 := lang.Apply1(reflect.Select, tmp11)
+//line ../../../clojure/core/async.glj:122:3
 				var v13 any = tmp12
 				_ = v13
 				// let binding "chosen"
@@ -1357,9 +1580,12 @@ func LoadNS() {
 				tmp18 := runtime.RT.NthDefault(v13, lang.IntCast(int64(2)), nil)
 				var v19 any = tmp18
 				_ = v19
+//line ../../../clojure/core/async.glj:125:5
 				tmp20 := aotExternalFn29(int64(0), v15)
+//line ../../../clojure/core/async.glj:122:3
 				tmp4 = tmp20
 			} // end let
+//line ../../../clojure/core/async.glj:118:8
 			return tmp4
 		})
 		aotDirectFn17 = tmp1
@@ -1368,22 +1594,31 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(118), kw_column, int(8), kw_end_DASH_line, int(118), kw_end_DASH_column, int(14), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_port, sym_val)), kw_doc, "Returns true if val was sent on the port, false if sending would\n  block", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1598
 	// try-take
+
+//line ../../../clojure/core/async.glj:127:8
 	{
 		tmp0 := sym_try_DASH_take
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../clojure/core/async.glj:131:3
 			var tmp3 any
 			{ // let
 				// let binding "sc"
+
+//line ../../../clojure/core/async.glj:131:12
 				tmp4 := reflect.TypeOf((*reflect.SelectCase)(nil)).Elem()
 				tmp5 := lang.NewHostInstance(tmp4)
+//line ../../../clojure/core/async.glj:131:3
 				var v6 any = tmp5
 				_ = v6
 				// let binding "_"
 				// set! host field
+
+//line ../../../clojure/core/async.glj:133:13
 				var tmp7 any
 				{
 					targetV := reflect.ValueOf(v6)
@@ -1410,9 +1645,13 @@ func LoadNS() {
 					}
 					tmp7 = reflect.SelectRecv
 				}
+//line ../../../clojure/core/async.glj:132:11
 				_ = tmp7
+//line ../../../clojure/core/async.glj:134:30
 				tmp8 := reflect.ValueOf(v2)
 				// set! host field
+
+//line ../../../clojure/core/async.glj:134:13
 				var tmp9 any
 				{
 					targetV := reflect.ValueOf(v6)
@@ -1439,15 +1678,21 @@ func LoadNS() {
 					}
 					tmp9 = tmp8
 				}
+//line ../../../clojure/core/async.glj:131:3
 				var v10 any = tmp9
 				_ = v10
 				// let binding "def"
+
+//line ../../../clojure/core/async.glj:135:13
 				tmp11 := reflect.TypeOf((*reflect.SelectCase)(nil)).Elem()
 				tmp12 := lang.NewHostInstance(tmp11)
+//line ../../../clojure/core/async.glj:131:3
 				var v13 any = tmp12
 				_ = v13
 				// let binding "_"
 				// set! host field
+
+//line ../../../clojure/core/async.glj:137:13
 				var tmp14 any
 				{
 					targetV := reflect.ValueOf(v13)
@@ -1474,12 +1719,18 @@ func LoadNS() {
 					}
 					tmp14 = reflect.SelectDefault
 				}
+//line ../../../clojure/core/async.glj:131:3
 				var v15 any = tmp14
 				_ = v15
 				// let binding "vec__696"
+
+//line ../../../clojure/core/async.glj:138:55
 				tmp16 := lang.NewVector(v6, v13)
+//line ../../../clojure/core/async.glj:138:41
 				tmp17 := aotExternalFn7(lang.Builtins["deref"], tmp16)
+//line ../../../clojure/core/async.glj:138:25
 				tmp18 := lang.Apply1(reflect.Select, tmp17)
+//line ../../../clojure/core/async.glj:131:3
 				var v19 any = tmp18
 				_ = v19
 				// let binding "chosen"
@@ -1494,12 +1745,16 @@ func LoadNS() {
 				tmp24 := runtime.RT.NthDefault(v19, lang.IntCast(int64(2)), nil)
 				var v25 any = tmp24
 				_ = v25
+//line ../../../clojure/core/async.glj:139:5
 				var tmp26 any
+//line ../../../clojure/core/async.glj:139:11
 				tmp27 := aotExternalFn29(int64(1), v21)
+//line ../../../clojure/core/async.glj:139:5
 				if lang.IsTruthy(tmp27) {
 				} else {
 					var tmp28 any
 					if lang.IsTruthy(v25) {
+//line ../../../clojure/core/async.glj:140:15
 						tmp29, ok := lang.FieldOrMethod(v23, "Interface")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v23, "Interface")))
@@ -1511,16 +1766,22 @@ func LoadNS() {
 						default:
 							tmp30 = tmp29
 						}
+//line ../../../clojure/core/async.glj:140:14
 						tmp31 := lang.NewVector(tmp30, true)
+//line ../../../clojure/core/async.glj:139:5
 						tmp28 = tmp31
 					} else {
+//line ../../../clojure/core/async.glj:141:17
 						tmp32 := lang.NewVector(nil, false)
+//line ../../../clojure/core/async.glj:139:5
 						tmp28 = tmp32
 					}
 					tmp26 = tmp28
 				}
+//line ../../../clojure/core/async.glj:131:3
 				tmp3 = tmp26
 			} // end let
+//line ../../../clojure/core/async.glj:127:8
 			return tmp3
 		})
 		aotDirectFn18 = tmp1
@@ -1529,7 +1790,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(127), kw_column, int(8), kw_end_DASH_line, int(127), kw_end_DASH_column, int(15), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_port)), kw_doc, "Returns [val true] if val was received from the port, [nil false] if the channel was closed,\n  and nil if receiving would block.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1794
 	// do-alts
+
+//line ../../../clojure/core/async.glj:165:8
 	{
 		tmp0 := sym_do_DASH_alts
 		var tmp1 lang.FnFunc2
@@ -1538,47 +1802,79 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:169:3
 			tmp4 := aotDirectFn7(v2)
+//line ../../../clojure/core/async.glj:165:8
 			_ = tmp4
+//line ../../../clojure/core/async.glj:170:3
 			var tmp5 any
 			{ // let
 				// let binding "ports"
+
+//line ../../../clojure/core/async.glj:170:15
 				tmp6 := aotExternalFn27(v2)
+//line ../../../clojure/core/async.glj:170:3
 				var v7 any = tmp6
 				_ = v7
 				// let binding "n"
+
+//line ../../../clojure/core/async.glj:171:11
 				tmp8 := lang.Count(v7)
+//line ../../../clojure/core/async.glj:170:3
 				var v9 any = tmp8
 				_ = v9
 				// let binding "priority"
+
+//line ../../../clojure/core/async.glj:172:18
 				tmp10 := kw_priority.Invoke1(v3)
+//line ../../../clojure/core/async.glj:170:3
 				var v11 any = tmp10
 				_ = v11
 				// let binding "selects"
+
+//line ../../../clojure/core/async.glj:173:22
 				tmp12 := checkDerefVar(var_clojure_DOT_core_DOT_async_port_DASH_case)
+//line ../../../clojure/core/async.glj:173:17
 				tmp13 := aotExternalFn7(tmp12, v7)
+//line ../../../clojure/core/async.glj:170:3
 				var v14 any = tmp13
 				_ = v14
 				// let binding "selects"
+
+//line ../../../clojure/core/async.glj:174:17
 				var tmp15 any
+//line ../../../clojure/core/async.glj:174:21
 				tmp16 := aotExternalFn28(v3, kw_default)
+//line ../../../clojure/core/async.glj:174:17
 				if lang.IsTruthy(tmp16) {
+//line ../../../clojure/core/async.glj:175:25
 					tmp17 := aotExternalFn27(v14)
+//line ../../../clojure/core/async.glj:175:39
 					tmp18 := aotDirectFn9()
+//line ../../../clojure/core/async.glj:175:19
 					tmp19 := lang.ConjAny(tmp17, tmp18)
+//line ../../../clojure/core/async.glj:174:17
 					tmp15 = tmp19
 				} else {
 					tmp15 = v14
 				}
+//line ../../../clojure/core/async.glj:170:3
 				var v20 any = tmp15
 				_ = v20
 				// let binding "def"
+
+//line ../../../clojure/core/async.glj:177:13
 				tmp21 := aotDirectFn9()
+//line ../../../clojure/core/async.glj:170:3
 				var v22 any = tmp21
 				_ = v22
 				// let binding "vec__702"
+
+//line ../../../clojure/core/async.glj:178:45
 				tmp23 := aotExternalFn7(lang.Builtins["deref"], v20)
+//line ../../../clojure/core/async.glj:178:29
 				tmp24 := lang.Apply1(reflect.Select, tmp23)
+//line ../../../clojure/core/async.glj:170:3
 				var v25 any = tmp24
 				_ = v25
 				// let binding "chosen-idx"
@@ -1594,25 +1890,41 @@ func LoadNS() {
 				var v31 any = tmp30
 				_ = v31
 				// let binding "chosen"
+
+//line ../../../clojure/core/async.glj:179:16
 				tmp32 := runtime.RT.Get(v7, v27)
+//line ../../../clojure/core/async.glj:170:3
 				var v33 any = tmp32
 				_ = v33
+//line ../../../clojure/core/async.glj:180:5
 				var tmp34 any
+//line ../../../clojure/core/async.glj:181:7
 				tmp35 := aotExternalFn8(v33)
+//line ../../../clojure/core/async.glj:180:5
 				if lang.IsTruthy(tmp35) {
+//line ../../../clojure/core/async.glj:181:30
 					tmp36 := lang.Apply1(v33, int64(0))
+//line ../../../clojure/core/async.glj:181:24
 					tmp37 := lang.NewVector(true, tmp36)
+//line ../../../clojure/core/async.glj:180:5
 					tmp34 = tmp37
 				} else {
 					var tmp38 any
+//line ../../../clojure/core/async.glj:182:7
 					tmp39 := aotExternalFn29(v9, v27)
+//line ../../../clojure/core/async.glj:180:5
 					if lang.IsTruthy(tmp39) {
+//line ../../../clojure/core/async.glj:182:25
 						tmp40 := kw_default.Invoke1(v3)
+//line ../../../clojure/core/async.glj:182:24
 						tmp41 := lang.NewVector(tmp40, kw_default)
+//line ../../../clojure/core/async.glj:180:5
 						tmp38 = tmp41
 					} else {
+//line ../../../clojure/core/async.glj:183:14
 						var tmp42 any
 						if lang.IsTruthy(v31) {
+//line ../../../clojure/core/async.glj:183:23
 							tmp43, ok := lang.FieldOrMethod(v29, "Interface")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v29, "Interface")))
@@ -1624,16 +1936,21 @@ func LoadNS() {
 							default:
 								tmp44 = tmp43
 							}
+//line ../../../clojure/core/async.glj:183:14
 							tmp42 = tmp44
 						} else {
 						}
+//line ../../../clojure/core/async.glj:183:13
 						tmp45 := lang.NewVector(tmp42, v33)
+//line ../../../clojure/core/async.glj:180:5
 						tmp38 = tmp45
 					}
 					tmp34 = tmp38
 				}
+//line ../../../clojure/core/async.glj:170:3
 				tmp5 = tmp34
 			} // end let
+//line ../../../clojure/core/async.glj:165:8
 			return tmp5
 		})
 		aotDirectFn11 = tmp1
@@ -1642,7 +1959,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(165), kw_column, int(8), kw_end_DASH_line, int(165), kw_end_DASH_column, int(14), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_ports, sym_opts)), kw_doc, "returns derefable [val port] if immediate, nil if enqueued", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:1963
 	// alt!!
+
+//line ../../../clojure/core/async.glj:281:11
 	{
 		tmp0 := sym_alt_BANG__BANG_
 		var tmp1 lang.ArityFn
@@ -1659,10 +1979,12 @@ func LoadNS() {
 				_ = v3
 				var v4 any = rest
 				_ = v4
+//line ../../../clojure/core/async.glj:283:3
 				tmp5 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp6 := lang.Apply1(tmp5, sym_clojure_DOT_core_DOT_async_SLASH_alt_BANG_)
 				tmp7 := aotExternalFn2(tmp6, v4)
 				tmp8 := lang.Seq(tmp7)
+//line ../../../clojure/core/async.glj:281:11
 				return tmp8
 			}),
 			2,
@@ -1672,7 +1994,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(281), kw_column, int(11), kw_end_DASH_line, int(281), kw_end_DASH_column, int(15), kw_arglists, lang.NewList(lang.NewVector(sym__AMP_, sym_args)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async), kw_macro, true)
 		})
 	}
+//line loader.go:1998
 	// do-alt
+
+//line ../../../clojure/core/async.glj:212:7
 	{
 		tmp0 := sym_do_DASH_alt
 		var tmp1 lang.FnFunc2
@@ -1681,32 +2006,50 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../clojure/core/async.glj:214:3
 			var tmp4 any
 			{ // let
 				// let binding "clauses"
+
+//line ../../../clojure/core/async.glj:214:17
 				tmp5 := aotExternalFn12(int64(2), v3)
+//line ../../../clojure/core/async.glj:214:3
 				var v6 any = tmp5
 				_ = v6
 				// let binding "opt?"
+
+//line ../../../clojure/core/async.glj:215:14
 				var tmp7 lang.FnFunc1
 				tmp7 = lang.FnFunc1(func(p0 any) any {
 					v8 := p0
 					_ = v8
+//line ../../../clojure/core/async.glj:215:25
 					tmp9 := lang.First(v8)
+//line ../../../clojure/core/async.glj:215:15
 					tmp10 := aotExternalFn13(tmp9)
+//line ../../../clojure/core/async.glj:215:14
 					return tmp10
 				})
+//line ../../../clojure/core/async.glj:214:3
 				var v8 any = tmp7
 				_ = v8
 				// let binding "opts"
+
+//line ../../../clojure/core/async.glj:216:14
 				tmp9 := aotExternalFn14(v8, v6)
+//line ../../../clojure/core/async.glj:214:3
 				var v10 any = tmp9
 				_ = v10
 				// let binding "clauses"
+
+//line ../../../clojure/core/async.glj:217:17
 				tmp11 := aotExternalFn15(v8, v6)
+//line ../../../clojure/core/async.glj:214:3
 				var v12 any = tmp11
 				_ = v12
 				// let binding "vec__707"
+
+//line ../../../clojure/core/async.glj:220:10
 				var tmp13 lang.FnFunc2
 				tmp13 = lang.FnFunc2(func(p0, p1 any) any {
 					v14 := p0
@@ -1737,20 +2080,30 @@ func LoadNS() {
 						tmp25 := runtime.RT.NthDefault(v22, lang.IntCast(int64(1)), nil)
 						var v26 any = tmp25
 						_ = v26
+//line ../../../clojure/core/async.glj:221:12
 						var tmp27 any
 						{ // let
 							// let binding "ports"
+
+//line ../../../clojure/core/async.glj:221:24
 							var tmp28 any
+//line ../../../clojure/core/async.glj:221:28
 							tmp29 := aotExternalFn8(v24)
+//line ../../../clojure/core/async.glj:221:24
 							if lang.IsTruthy(tmp29) {
 								tmp28 = v24
 							} else {
+//line ../../../clojure/core/async.glj:221:50
 								tmp30 := lang.NewVector(v24)
+//line ../../../clojure/core/async.glj:221:24
 								tmp28 = tmp30
 							}
+//line ../../../clojure/core/async.glj:221:12
 							var v31 any = tmp28
 							_ = v31
 							// let binding "vec__718"
+
+//line ../../../clojure/core/async.glj:224:19
 							var tmp32 lang.FnFunc2
 							tmp32 = lang.FnFunc2(func(p0, p1 any) any {
 								v33 := p0
@@ -1770,9 +2123,13 @@ func LoadNS() {
 									tmp39 := runtime.RT.NthDefault(v36, lang.IntCast(int64(1)), nil)
 									var v40 any = tmp39
 									_ = v40
+//line ../../../clojure/core/async.glj:225:21
 									var tmp41 any
+//line ../../../clojure/core/async.glj:225:25
 									tmp42 := aotExternalFn8(v34)
+//line ../../../clojure/core/async.glj:225:21
 									if lang.IsTruthy(tmp42) {
+//line ../../../clojure/core/async.glj:226:23
 										var tmp43 any
 										{ // let
 											// let binding "vec__725"
@@ -1787,44 +2144,73 @@ func LoadNS() {
 											var v48 any = tmp47
 											_ = v48
 											// let binding "gp"
+
+//line ../../../clojure/core/async.glj:227:32
 											tmp49 := aotExternalFn17()
+//line ../../../clojure/core/async.glj:226:23
 											var v50 any = tmp49
 											_ = v50
 											// let binding "gv"
+
+//line ../../../clojure/core/async.glj:228:32
 											tmp51 := aotExternalFn17()
+//line ../../../clojure/core/async.glj:226:23
 											var v52 any = tmp51
 											_ = v52
+//line ../../../clojure/core/async.glj:229:38
 											tmp53 := lang.NewVector(v50, v52)
+//line ../../../clojure/core/async.glj:229:26
 											tmp54 := lang.ConjAny(v38, tmp53)
+//line ../../../clojure/core/async.glj:229:62
 											tmp55 := lang.NewVector(v50, v46)
+//line ../../../clojure/core/async.glj:229:72
 											tmp56 := lang.NewVector(v52, v48)
+//line ../../../clojure/core/async.glj:229:47
 											tmp57 := aotExternalFn19(v40, tmp55, tmp56)
+//line ../../../clojure/core/async.glj:229:25
 											tmp58 := lang.NewVector(tmp54, tmp57)
+//line ../../../clojure/core/async.glj:226:23
 											tmp43 = tmp58
 										} // end let
+//line ../../../clojure/core/async.glj:225:21
 										tmp41 = tmp43
 									} else {
+//line ../../../clojure/core/async.glj:230:23
 										var tmp44 any
 										{ // let
 											// let binding "gp"
+
+//line ../../../clojure/core/async.glj:230:32
 											tmp45 := aotExternalFn17()
+//line ../../../clojure/core/async.glj:230:23
 											var v46 any = tmp45
 											_ = v46
+//line ../../../clojure/core/async.glj:231:26
 											tmp47 := lang.ConjAny(v38, v46)
+//line ../../../clojure/core/async.glj:231:57
 											tmp48 := lang.NewVector(v46, v34)
+//line ../../../clojure/core/async.glj:231:42
 											tmp49 := lang.ConjAny(v40, tmp48)
+//line ../../../clojure/core/async.glj:231:25
 											tmp50 := lang.NewVector(tmp47, tmp49)
+//line ../../../clojure/core/async.glj:230:23
 											tmp44 = tmp50
 										} // end let
+//line ../../../clojure/core/async.glj:225:21
 										tmp41 = tmp44
 									}
+//line ../../../clojure/core/async.glj:224:19
 									tmp35 = tmp41
 								} // end let
 								return tmp35
 							})
+//line ../../../clojure/core/async.glj:232:20
 							tmp33 := lang.NewVector()
+//line ../../../clojure/core/async.glj:232:19
 							tmp34 := lang.NewVector(tmp33, v21)
+//line ../../../clojure/core/async.glj:223:18
 							tmp35 := aotExternalFn16(tmp32, tmp34, v31)
+//line ../../../clojure/core/async.glj:221:12
 							var v36 any = tmp35
 							_ = v36
 							// let binding "ports"
@@ -1835,19 +2221,29 @@ func LoadNS() {
 							tmp39 := runtime.RT.NthDefault(v36, lang.IntCast(int64(1)), nil)
 							var v40 any = tmp39
 							_ = v40
+//line ../../../clojure/core/async.glj:233:29
 							tmp41 := lang.NewVector(v38, v26)
+//line ../../../clojure/core/async.glj:233:15
 							tmp42 := lang.ConjAny(v19, tmp41)
+//line ../../../clojure/core/async.glj:233:14
 							tmp43 := lang.NewVector(tmp42, v40)
+//line ../../../clojure/core/async.glj:221:12
 							tmp27 = tmp43
 						} // end let
+//line ../../../clojure/core/async.glj:220:10
 						tmp16 = tmp27
 					} // end let
 					return tmp16
 				})
+//line ../../../clojure/core/async.glj:234:11
 				tmp14 := lang.NewVector()
+//line ../../../clojure/core/async.glj:234:14
 				tmp15 := lang.NewVector()
+//line ../../../clojure/core/async.glj:234:10
 				tmp16 := lang.NewVector(tmp14, tmp15)
+//line ../../../clojure/core/async.glj:219:9
 				tmp17 := aotExternalFn16(tmp13, tmp16, v12)
+//line ../../../clojure/core/async.glj:214:3
 				var v18 any = tmp17
 				_ = v18
 				// let binding "clauses"
@@ -1859,19 +2255,29 @@ func LoadNS() {
 				var v22 any = tmp21
 				_ = v22
 				// let binding "gch"
+
+//line ../../../clojure/core/async.glj:235:13
 				tmp23 := aotExternalFn20("ch")
+//line ../../../clojure/core/async.glj:214:3
 				var v24 any = tmp23
 				_ = v24
 				// let binding "gret"
+
+//line ../../../clojure/core/async.glj:236:14
 				tmp25 := aotExternalFn20("ret")
+//line ../../../clojure/core/async.glj:214:3
 				var v26 any = tmp25
 				_ = v26
+//line ../../../clojure/core/async.glj:237:5
 				tmp27 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp28 := lang.Apply1(tmp27, sym_clojure_DOT_core_SLASH_let)
 				tmp29 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp30 := checkDerefVar(var_clojure_DOT_core_vector)
+//line ../../../clojure/core/async.glj:237:22
 				tmp31 := checkDerefVar(var_clojure_DOT_core_identity)
+//line ../../../clojure/core/async.glj:237:14
 				tmp32 := aotExternalFn23(tmp31, v22)
+//line ../../../clojure/core/async.glj:237:5
 				tmp33 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp34 := checkDerefVar(var_clojure_DOT_core_vector)
 				tmp35 := checkDerefVar(var_clojure_DOT_core_list)
@@ -1891,16 +2297,24 @@ func LoadNS() {
 				tmp49 := lang.Apply1(tmp48, v2)
 				tmp50 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp51 := checkDerefVar(var_clojure_DOT_core_vector)
+//line ../../../clojure/core/async.glj:238:51
 				tmp52 := checkDerefVar(var_clojure_DOT_core_concat)
+//line ../../../clojure/core/async.glj:238:68
 				tmp53 := checkDerefVar(var_clojure_DOT_core_first)
+//line ../../../clojure/core/async.glj:238:58
 				tmp54 := aotExternalFn7(tmp53, v20)
+//line ../../../clojure/core/async.glj:238:44
 				tmp55 := aotExternalFn22(tmp52, tmp54)
+//line ../../../clojure/core/async.glj:237:5
 				tmp56 := aotExternalFn25(tmp55)
 				tmp57 := lang.Seq(tmp56)
 				tmp58 := aotExternalFn22(tmp51, tmp57)
 				tmp59 := lang.Apply1(tmp50, tmp58)
+//line ../../../clojure/core/async.glj:238:94
 				tmp60 := checkDerefVar(var_clojure_DOT_core_concat)
+//line ../../../clojure/core/async.glj:238:87
 				tmp61 := aotExternalFn22(tmp60, v10)
+//line ../../../clojure/core/async.glj:237:5
 				tmp62 := aotExternalFn21(tmp49, tmp59, tmp61)
 				tmp63 := lang.Seq(tmp62)
 				tmp64 := lang.Apply1(tmp47, tmp63)
@@ -1911,6 +2325,7 @@ func LoadNS() {
 				tmp69 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp70 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp71 := lang.Apply1(tmp70, sym_clojure_DOT_core_SLASH_cond)
+//line ../../../clojure/core/async.glj:240:19
 				var tmp72 lang.FnFunc1
 				tmp72 = lang.FnFunc1(func(p0 any) any {
 					v73 := p0
@@ -1928,57 +2343,81 @@ func LoadNS() {
 						tmp78 := runtime.RT.NthDefault(v75, lang.IntCast(int64(1)), nil)
 						var v79 any = tmp78
 						_ = v79
+//line ../../../clojure/core/async.glj:241:22
 						tmp80 := checkDerefVar(var_clojure_DOT_core_list)
 						tmp81 := lang.Apply1(tmp80, sym_clojure_DOT_core_SLASH_or)
+//line ../../../clojure/core/async.glj:241:39
 						var tmp82 lang.FnFunc1
 						tmp82 = lang.FnFunc1(func(p0 any) any {
 							v83 := p0
 							_ = v83
+//line ../../../clojure/core/async.glj:242:36
 							tmp84 := checkDerefVar(var_clojure_DOT_core_list)
 							tmp85 := lang.Apply1(tmp84, sym_clojure_DOT_core_SLASH__EQ_)
 							tmp86 := checkDerefVar(var_clojure_DOT_core_list)
 							tmp87 := lang.Apply1(tmp86, v24)
 							tmp88 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../../clojure/core/async.glj:242:46
 							var tmp89 any
+//line ../../../clojure/core/async.glj:242:50
 							tmp90 := aotExternalFn8(v83)
+//line ../../../clojure/core/async.glj:242:46
 							if lang.IsTruthy(tmp90) {
+//line ../../../clojure/core/async.glj:242:65
 								tmp91 := lang.First(v83)
+//line ../../../clojure/core/async.glj:242:46
 								tmp89 = tmp91
 							} else {
 								tmp89 = v83
 							}
+//line ../../../clojure/core/async.glj:242:36
 							tmp92 := lang.Apply1(tmp88, tmp89)
 							tmp93 := aotExternalFn21(tmp85, tmp87, tmp92)
 							tmp94 := lang.Seq(tmp93)
+//line ../../../clojure/core/async.glj:241:39
 							return tmp94
 						})
+//line ../../../clojure/core/async.glj:241:29
 						tmp83 := aotExternalFn7(tmp82, v77)
+//line ../../../clojure/core/async.glj:241:22
 						tmp84 := aotExternalFn2(tmp81, tmp83)
 						tmp85 := lang.Seq(tmp84)
+//line ../../../clojure/core/async.glj:244:22
 						var tmp86 any
+//line ../../../clojure/core/async.glj:244:26
 						var tmp87 any
 						{ // let
 							// let binding "and__0__auto__"
+
+//line ../../../clojure/core/async.glj:244:31
 							tmp88 := aotExternalFn3(v79)
+//line ../../../clojure/core/async.glj:244:26
 							var v89 any = tmp88
 							_ = v89
 							var tmp90 any
 							if lang.IsTruthy(v89) {
+//line ../../../clojure/core/async.glj:244:52
 								tmp91 := lang.First(v79)
+//line ../../../clojure/core/async.glj:244:43
 								tmp92 := aotExternalFn8(tmp91)
+//line ../../../clojure/core/async.glj:244:26
 								tmp90 = tmp92
 							} else {
 								tmp90 = v89
 							}
 							tmp87 = tmp90
 						} // end let
+//line ../../../clojure/core/async.glj:244:22
 						if lang.IsTruthy(tmp87) {
+//line ../../../clojure/core/async.glj:245:24
 							tmp88 := checkDerefVar(var_clojure_DOT_core_list)
 							tmp89 := lang.Apply1(tmp88, sym_clojure_DOT_core_SLASH_let)
 							tmp90 := checkDerefVar(var_clojure_DOT_core_list)
 							tmp91 := checkDerefVar(var_clojure_DOT_core_vector)
 							tmp92 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../../clojure/core/async.glj:245:32
 							tmp93 := lang.First(v79)
+//line ../../../clojure/core/async.glj:245:24
 							tmp94 := lang.Apply1(tmp92, tmp93)
 							tmp95 := checkDerefVar(var_clojure_DOT_core_list)
 							tmp96 := lang.Apply1(tmp95, v26)
@@ -1986,19 +2425,26 @@ func LoadNS() {
 							tmp98 := lang.Seq(tmp97)
 							tmp99 := aotExternalFn22(tmp91, tmp98)
 							tmp100 := lang.Apply1(tmp90, tmp99)
+//line ../../../clojure/core/async.glj:245:54
 							tmp101 := aotExternalFn26(v79)
+//line ../../../clojure/core/async.glj:245:24
 							tmp102 := aotExternalFn21(tmp89, tmp100, tmp101)
 							tmp103 := lang.Seq(tmp102)
+//line ../../../clojure/core/async.glj:244:22
 							tmp86 = tmp103
 						} else {
 							tmp86 = v79
 						}
+//line ../../../clojure/core/async.glj:241:21
 						tmp104 := lang.NewVector(tmp85, tmp86)
+//line ../../../clojure/core/async.glj:240:19
 						tmp74 = tmp104
 					} // end let
 					return tmp74
 				})
+//line ../../../clojure/core/async.glj:240:11
 				tmp73 := aotExternalFn23(tmp72, v20)
+//line ../../../clojure/core/async.glj:237:5
 				tmp74 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp75 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp76 := lang.Apply1(tmp75, sym_clojure_DOT_core_SLASH__EQ_)
@@ -2016,8 +2462,10 @@ func LoadNS() {
 				tmp88 := lang.Apply1(tmp69, tmp87)
 				tmp89 := aotExternalFn21(tmp28, tmp68, tmp88)
 				tmp90 := lang.Seq(tmp89)
+//line ../../../clojure/core/async.glj:214:3
 				tmp4 = tmp90
 			} // end let
+//line ../../../clojure/core/async.glj:212:7
 			return tmp4
 		})
 		aotDirectFn10 = tmp1
@@ -2026,7 +2474,10 @@ func LoadNS() {
 			return lang.NewMap(kw_file, "clojure/core/async.glj", kw_line, int(212), kw_column, int(7), kw_end_DASH_line, int(212), kw_end_DASH_column, int(12), kw_arglists, lang.NewList(lang.NewVector(sym_alts, sym_clauses)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async))
 		})
 	}
+//line loader.go:2478
 	// go
+
+//line ../../../clojure/core/async.glj:67:11
 	{
 		tmp0 := sym_go
 		var tmp1 lang.ArityFn
@@ -2043,6 +2494,7 @@ func LoadNS() {
 				_ = v3
 				var v4 any = rest
 				_ = v4
+//line ../../../clojure/core/async.glj:84:3
 				tmp5 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp6 := lang.Apply1(tmp5, sym_clojure_DOT_core_SLASH_let)
 				tmp7 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2133,6 +2585,7 @@ func LoadNS() {
 				tmp92 := lang.Apply1(tmp79, tmp91)
 				tmp93 := aotExternalFn24(tmp6, tmp66, tmp78, tmp92)
 				tmp94 := lang.Seq(tmp93)
+//line ../../../clojure/core/async.glj:67:11
 				return tmp94
 			}),
 			2,
@@ -2142,7 +2595,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(67), kw_column, int(11), kw_end_DASH_line, int(67), kw_end_DASH_column, int(12), kw_arglists, lang.NewList(lang.NewVector(sym__AMP_, sym_body)), kw_doc, "Asynchronously executes the body, returning immediately to the\n  calling thread. Additionally, any visible calls to <!, >! and alt!/alts!\n  channel operations within the body will block (if necessary) by\n  'parking' the calling thread rather than tying up an OS thread (or\n  the only JS thread when in ClojureScript). Upon completion of the\n  operation, the body will be resumed.\n\n  Unlike in Clojure or ClojureScript, go blocks may (either directly\n  or indirectly) perform operations that may block indefinitely, as go\n  blocks are run on goroutines, which relinquish the thread of control\n  when parked.\n\n  Returns a channel which will receive the result of the body when\n  completed", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async), kw_macro, true)
 		})
 	}
+//line loader.go:2599
 	// go-loop
+
+//line ../../../clojure/core/async.glj:287:11
 	{
 		tmp0 := sym_go_DASH_loop
 		var tmp1 lang.ArityFn
@@ -2161,6 +2617,7 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../../clojure/core/async.glj:290:3
 				tmp6 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp7 := lang.Apply1(tmp6, sym_clojure_DOT_core_DOT_async_SLASH_go)
 				tmp8 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2173,6 +2630,7 @@ func LoadNS() {
 				tmp15 := lang.Apply1(tmp8, tmp14)
 				tmp16 := aotExternalFn2(tmp7, tmp15)
 				tmp17 := lang.Seq(tmp16)
+//line ../../../clojure/core/async.glj:287:11
 				return tmp17
 			}),
 			3,
@@ -2182,4 +2640,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/core/async.glj", kw_line, int(287), kw_column, int(11), kw_end_DASH_line, int(287), kw_end_DASH_column, int(17), kw_arglists, lang.NewList(lang.NewVector(sym_bindings, sym__AMP_, sym_body)), kw_doc, "Like (go (loop ...))", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_async), kw_macro, true)
 		})
 	}
+//line loader.go:2644
 }
@@ -71,6 +71,54 @@ func aotLinkBoundFn2(vr *lang.Var) lang.FnFunc2 {
 	return func(p0 any, p1 any) any { return lang.Apply2(fn, p0, p1) }
 }
 
+// nsSnapshot holds the vars of clojure.core.protocols as they were after it was loaded.
+var nsSnapshot = runtime.NamespaceSnapshot{
+	Symbols: []string{
+		"apply",
+		"chunk-first",
+		"chunk-next",
+		"chunked-seq?",
+		"class",
+		"clojure.core",
+		"clojure.core.protocols",
+		"deref",
+		"global-hierarchy",
+		"identical?",
+		"CollReduce",
+		"Datafiable",
+		"IKVReduce",
+		"InternalReduce",
+		"Navigable",
+		"coll-reduce",
+		"datafy",
+		"interface-or-naive-reduce",
+		"internal-reduce",
+		"iter-reduce",
+		"kv-reduce",
+		"naive-seq-reduce",
+		"nav",
+		"seq-reduce",
+	},
+	Keywords: []string{},
+	Name:     6,
+	Vars: []runtime.SnapshotVar{
+		{Name: 10, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\x1a\b\x00\x06column\x04\x1c\b\x00\bend-line\x04\x1a\b\x00\nend-column\x04.\x00", Root: "\x06\x02\x03\x00\b\x00\x06multis\x02\x01\x00\b\x00\vcoll-reduce\a\x02\x01\x00\b\x00\adefault\x01\x05\x00\x00\x01\x01\x01\x01\x01\x01\x01\x01\x01\x00\x00\b\x00\fon-interface\x00\x01\x00\b\x00\x04sigs\x05\x01\x05\x03\x00\t\x02\x00\x03\x02\x00\t\x00\x04coll\x00\x00\t\x00\x01f\x00\x03\x03\x00\t\x06\x00\x00\t\a\x00\x00\t\x00\x03val\x00\x00\x00"},
+		{Name: 11, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\xf8\x02\b\x00\x06column\x04\x1c\b\x00\bend-line\x04\xf8\x02\b\x00\nend-column\x04.\x00", Root: "\x06\x02\x03\x00\b\x00\x06multis\x02\x01\x00\b\x00\x06datafy\a\x02\x01\x00\b\x00\adefault\x01\x01\x00\x00\x01\x00\x00\b\x00\fon-interface\x00\x01\x00\b\x00\x04sigs\x05\x01\x05\x03\x00\t\x02\x00\x03\x01\x00\t\x00\x01o\x00\x00\x06\x007return a representation of o as data (default identity)\x00\x00"},
+		{Name: 12, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\xea\x02\b\x00\x06column\x04\x1c\b\x00\bend-line\x04\xea\x02\b\x00\nend-column\x04,\x00", Root: "\x06\x02\x03\x00\b\x00\x06multis\x02\x01\x00\b\x00\tkv-reduce\a\x02\x01\x00\b\x00\adefault\x01\x00\x00\x00\b\x00\fon-interface\x00\x01\x00\b\x00\x04sigs\x05\x01\x05\x02\x00\t\x02\x00\x03\x03\x00\t\x00\x04amap\x00\x00\t\x00\x01f\x00\x00\t\x00\x04init\x00\x00\x00"},
+		{Name: 13, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04&\b\x00\x06column\x04\x1c\b\x00\bend-line\x04&\b\x00\nend-column\x046\x00", Root: "\x06\x02\x03\x00\b\x00\x06multis\x02\x01\x00\b\x00\x0finternal-reduce\a\x02\x01\x00\b\x00\adefault\x01\x03\x00\x00\x01\x01\x01\x01\x01\x00\x00\b\x00\fon-interface\x00\x01\x00\b\x00\x04sigs\x05\x01\x05\x02\x00\t\x02\x00\x03\x03\x00\t\x00\x03seq\x00\x00\t\x00\x01f\x00\x00\t\x00\x05start\x00\x00\x00"},
+		{Name: 14, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\x90\x03\b\x00\x06column\x04\x1c\b\x00\bend-line\x04\x90\x03\b\x00\nend-column\x04,\x00", Root: "\x06\x02\x03\x00\b\x00\x06multis\x02\x01\x00\b\x00\x03nav\a\x02\x01\x00\b\x00\adefault\x01\x00\x00\x00\b\x00\fon-interface\x00\x01\x00\b\x00\x04sigs\x05\x01\x05\x03\x00\t\x02\x00\x03\x03\x00\t\x00\x04coll\x00\x00\t\x00\x01k\x00\x00\t\x00\x01v\x00\x00\x06\x00kreturn (possibly transformed) v in the context of coll and k (a key/index or nil),\ndefaults to returning v.\x00\x00"},
+		{Name: 15, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\"\b\x00\x06column\x04\b\b\x00\bend-line\x04\"\b\x00\nend-column\x04\x1c\x00", Root: "\a\x00\vcoll-reduce\x01\x00\b\x00\adefault\x01\x05\x00\x00\x01\x01\x01\x01\x01\x01\x01\x01\x01\x00"},
+		{Name: 16, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\xfe\x02\b\x00\x06column\x04\b\b\x00\bend-line\x04\xfe\x02\b\x00\nend-column\x04\x12\x00", Root: "\a\x00\x06datafy\x01\x00\b\x00\adefault\x01\x01\x00\x00\x01\x00"},
+		{Name: 17, Meta: "\r\b\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\x88\x01\b\x00\x06column\x04\x10\b\x00\bend-line\x04\x88\x01\b\x00\nend-column\x04@\b\x00\aprivate\x01\b\x00\barglists\n\x01\f\x03\t\x00\x04coll\r\x05\b\x01\x06\x02\b\x03\x04\x8c\x01\b\x04\x04\b\b\x05\x04\x8c\x01\b\x06\x04\x0e\x00\t\x00\x01f\r\x05\b\x01\x06\x02\b\x03\x04\x8c\x01\b\x04\x04\x12\b\x05\x04\x8c\x01\b\x06\x04\x12\x00\t\x00\x03val\r\x05\b\x01\x06\x02\b\x03\x04\x8c\x01\b\x04\x04\x16\b\x05\x04\x8c\x01\b\x06\x04\x1a\x00\r\x05\b\x01\x06\x02\b\x03\x04\x8c\x01\b\x04\x04\x06\b\x05\x04\x8c\x01\b\x06\x04\x1c\x00\x00\b\x00\x03doc\x06\x002Reduces via IReduceInit if possible, else naively.\x00"},
+		{Name: 18, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04,\b\x00\x06column\x04\b\b\x00\bend-line\x04,\b\x00\nend-column\x04$\x00", Root: "\a\x00\x0finternal-reduce\x01\x00\b\x00\adefault\x01\x03\x00\x00\x01\x01\x01\x01\x01\x00"},
+		{Name: 19, Meta: "\r\a\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04B\b\x00\x06column\x04\x10\b\x00\bend-line\x04B\b\x00\nend-column\x04$\b\x00\aprivate\x01\b\x00\barglists\n\x02\f\x02\t\x00\x04coll\r\x06\b\x01\x06\x02\b\x03\x04D\b\x04\x04\n\b\x05\x04D\b\x06\x048\b\x00\x03tag\t\x00\x12java.lang.Iterable\r\x05\b\x01\x06\x02\b\x03\x04D\b\x04\x04\f\b\x05\x04D\b\x06\x04.\x00\x00\t\x00\x01f\r\x05\b\x01\x06\x02\b\x03\x04D\b\x04\x04<\b\x05\x04D\b\x06\x04<\x00\r\x05\b\x01\x06\x02\b\x03\x04D\b\x04\x04\b\b\x05\x04D\b\x06\x04>\x00\f\x03\t\t\r\x06\b\x01\x06\x02\b\x03\x04Z\b\x04\x04\n\b\x05\x04Z\b\x06\x048\b\n\t\v\r\x05\b\x01\x06\x02\b\x03\x04Z\b\x04\x04\f\b\x05\x04Z\b\x06\x04.\x00\x00\t\f\r\x05\b\x01\x06\x02\b\x03\x04Z\b\x04\x04<\b\x05\x04Z\b\x06\x04<\x00\t\x00\x03val\r\x05\b\x01\x06\x02\b\x03\x04Z\b\x04\x04@\b\x05\x04Z\b\x06\x04D\x00\r\x05\b\x01\x06\x02\b\x03\x04Z\b\x04\x04\b\b\x05\x04Z\b\x06\x04F\x00\x00\x00"},
+		{Name: 20, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\xf4\x02\b\x00\x06column\x04\b\b\x00\bend-line\x04\xf4\x02\b\x00\nend-column\x04\x18\x00", Root: "\a\x00\tkv-reduce\x01\x00\b\x00\adefault\x01\x00\x00"},
+		{Name: 21, Meta: "\r\b\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04n\b\x00\x06column\x04\x10\b\x00\bend-line\x04n\b\x00\nend-column\x04.\b\x00\aprivate\x01\b\x00\barglists\n\x01\f\x03\t\x00\x01s\r\x05\b\x01\x06\x02\b\x03\x04t\b\x04\x04\b\b\x05\x04t\b\x06\x04\b\x00\t\x00\x01f\r\x05\b\x01\x06\x02\b\x03\x04t\b\x04\x04\f\b\x05\x04t\b\x06\x04\f\x00\t\x00\x03val\r\x05\b\x01\x06\x02\b\x03\x04t\b\x04\x04\x10\b\x05\x04t\b\x06\x04\x14\x00\r\x05\b\x01\x06\x02\b\x03\x04t\b\x04\x04\x06\b\x05\x04t\b\x06\x04\x16\x00\x00\b\x00\x03doc\x06\x00[Reduces a seq, ignoring any opportunities to switch to a more\n  specialized implementation.\x00"},
+		{Name: 22, Meta: "\r\x05\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x04\x96\x03\b\x00\x06column\x04\b\b\x00\bend-line\x04\x96\x03\b\x00\nend-column\x04\f\x00", Root: "\a\x00\x03nav\x01\x00\b\x00\adefault\x01\x00\x00"},
+		{Name: 23, Meta: "\r\a\b\x00\x04file\x06\x00\x1aclojure/core/protocols.glj\b\x00\x04line\x040\b\x00\x06column\x04\x10\b\x00\bend-line\x040\b\x00\nend-column\x04\"\b\x00\aprivate\x01\b\x00\barglists\n\x02\f\x02\t\x00\x04coll\r\x05\b\x01\x06\x02\b\x03\x042\b\x04\x04\n\b\x05\x042\b\x06\x04\x10\x00\t\x00\x01f\r\x05\b\x01\x06\x02\b\x03\x042\b\x04\x04\x14\b\x05\x042\b\x06\x04\x14\x00\r\x05\b\x01\x06\x02\b\x03\x042\b\x04\x04\b\b\x05\x042\b\x06\x04\x16\x00\f\x03\t\t\r\x05\b\x01\x06\x02\b\x03\x04:\b\x04\x04\n\b\x05\x04:\b\x06\x04\x10\x00\t\n\r\x05\b\x01\x06\x02\b\x03\x04:\b\x04\x04\x14\b\x05\x04:\b\x06\x04\x14\x00\t\x00\x03val\r\x05\b\x01\x06\x02\b\x03\x04:\b\x04\x04\x18\b\x05\x04:\b\x06\x04\x1c\x00\r\x05\b\x01\x06\x02\b\x03\x04:\b\x04\x04\b\b\x05\x04:\b\x06\x04\x1e\x00\x00\x00"},
+	},
+}
+
 func init() {
 	runtime.RegisterNSLoader("clojure/core/protocols", LoadNS)
 }
@@ -84,99 +132,43 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 // LoadNS initializes the namespace "clojure.core.protocols"
 func LoadNS() {
-	sym_CollReduce := lang.NewSymbolUnchecked("CollReduce")
-	sym_Datafiable := lang.NewSymbolUnchecked("Datafiable")
-	sym_IKVReduce := lang.NewSymbolUnchecked("IKVReduce")
-	sym_InternalReduce := lang.NewSymbolUnchecked("InternalReduce")
-	sym_Navigable := lang.NewSymbolUnchecked("Navigable")
-	sym_amap := lang.NewSymbolUnchecked("amap")
-	sym_apply := lang.NewSymbolUnchecked("apply")
-	sym_chunk_DASH_first := lang.NewSymbolUnchecked("chunk-first")
-	sym_chunk_DASH_next := lang.NewSymbolUnchecked("chunk-next")
-	sym_chunked_DASH_seq_QMARK_ := lang.NewSymbolUnchecked("chunked-seq?")
-	sym_class := lang.NewSymbolUnchecked("class")
-	sym_clojure_DOT_core := lang.NewSymbolUnchecked("clojure.core")
-	sym_clojure_DOT_core_DOT_protocols := lang.NewSymbolUnchecked("clojure.core.protocols")
-	sym_coll := lang.NewSymbolUnchecked("coll")
-	sym_coll_DASH_reduce := lang.NewSymbolUnchecked("coll-reduce")
-	sym_datafy := lang.NewSymbolUnchecked("datafy")
-	sym_deref := lang.NewSymbolUnchecked("deref")
-	sym_f := lang.NewSymbolUnchecked("f")
-	sym_global_DASH_hierarchy := lang.NewSymbolUnchecked("global-hierarchy")
-	sym_identical_QMARK_ := lang.NewSymbolUnchecked("identical?")
-	sym_init := lang.NewSymbolUnchecked("init")
-	sym_interface_DASH_or_DASH_naive_DASH_reduce := lang.NewSymbolUnchecked("interface-or-naive-reduce")
-	sym_internal_DASH_reduce := lang.NewSymbolUnchecked("internal-reduce")
-	sym_iter_DASH_reduce := lang.NewSymbolUnchecked("iter-reduce")
-	sym_k := lang.NewSymbolUnchecked("k")
-	sym_kv_DASH_reduce := lang.NewSymbolUnchecked("kv-reduce")
-	sym_naive_DASH_seq_DASH_reduce := lang.NewSymbolUnchecked("naive-seq-reduce")
-	sym_nav := lang.NewSymbolUnchecked("nav")
-	sym_o := lang.NewSymbolUnchecked("o")
-	sym_s := lang.NewSymbolUnchecked("s")
-	sym_seq := lang.NewSymbolUnchecked("seq")
-	sym_seq_DASH_reduce := lang.NewSymbolUnchecked("seq-reduce")
-	sym_start := lang.NewSymbolUnchecked("start")
-	sym_v := lang.NewSymbolUnchecked("v")
-	sym_val := lang.NewSymbolUnchecked("val")
-	kw_arglists := lang.NewKeyword("arglists")
-	kw_coll_DASH_reduce := lang.NewKeyword("coll-reduce")
-	kw_column := lang.NewKeyword("column")
-	kw_datafy := lang.NewKeyword("datafy")
-	kw_default := lang.NewKeyword("default")
-	kw_doc := lang.NewKeyword("doc")
-	kw_end_DASH_column := lang.NewKeyword("end-column")
-	kw_end_DASH_line := lang.NewKeyword("end-line")
-	kw_file := lang.NewKeyword("file")
-	kw_internal_DASH_reduce := lang.NewKeyword("internal-reduce")
-	kw_kv_DASH_reduce := lang.NewKeyword("kv-reduce")
-	kw_line := lang.NewKeyword("line")
-	kw_multis := lang.NewKeyword("multis")
-	kw_nav := lang.NewKeyword("nav")
-	kw_ns := lang.NewKeyword("ns")
-	kw_on_DASH_interface := lang.NewKeyword("on-interface")
-	kw_private := lang.NewKeyword("private")
-	kw_sigs := lang.NewKeyword("sigs")
-	// var clojure.core.protocols/CollReduce
-	var_clojure_DOT_core_DOT_protocols_CollReduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_CollReduce)
-	// var clojure.core.protocols/Datafiable
-	var_clojure_DOT_core_DOT_protocols_Datafiable := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_Datafiable)
-	// var clojure.core.protocols/IKVReduce
-	var_clojure_DOT_core_DOT_protocols_IKVReduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_IKVReduce)
-	// var clojure.core.protocols/InternalReduce
-	var_clojure_DOT_core_DOT_protocols_InternalReduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_InternalReduce)
-	// var clojure.core.protocols/Navigable
-	var_clojure_DOT_core_DOT_protocols_Navigable := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_Navigable)
-	// var clojure.core.protocols/coll-reduce
-	var_clojure_DOT_core_DOT_protocols_coll_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_coll_DASH_reduce)
-	// var clojure.core.protocols/datafy
-	var_clojure_DOT_core_DOT_protocols_datafy := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_datafy)
-	// var clojure.core.protocols/interface-or-naive-reduce
-	var_clojure_DOT_core_DOT_protocols_interface_DASH_or_DASH_naive_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_interface_DASH_or_DASH_naive_DASH_reduce)
-	// var clojure.core.protocols/internal-reduce
-	var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_internal_DASH_reduce)
-	// var clojure.core.protocols/iter-reduce
-	var_clojure_DOT_core_DOT_protocols_iter_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_iter_DASH_reduce)
-	// var clojure.core.protocols/kv-reduce
-	var_clojure_DOT_core_DOT_protocols_kv_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_kv_DASH_reduce)
-	// var clojure.core.protocols/naive-seq-reduce
-	var_clojure_DOT_core_DOT_protocols_naive_DASH_seq_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_naive_DASH_seq_DASH_reduce)
-	// var clojure.core.protocols/nav
-	var_clojure_DOT_core_DOT_protocols_nav := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_nav)
-	// var clojure.core.protocols/seq-reduce
-	var_clojure_DOT_core_DOT_protocols_seq_DASH_reduce := lang.InternVarName(sym_clojure_DOT_core_DOT_protocols, sym_seq_DASH_reduce)
+	restored := runtime.RestoreSnapshot(&nsSnapshot)
+	sym_apply := restored.Symbols[0]
+	sym_chunk_DASH_first := restored.Symbols[1]
+	sym_chunk_DASH_next := restored.Symbols[2]
+	sym_chunked_DASH_seq_QMARK_ := restored.Symbols[3]
+	sym_class := restored.Symbols[4]
+	sym_clojure_DOT_core := restored.Symbols[5]
+	sym_clojure_DOT_core_DOT_protocols := restored.Symbols[6]
+	sym_deref := restored.Symbols[7]
+	sym_global_DASH_hierarchy := restored.Symbols[8]
+	sym_identical_QMARK_ := restored.Symbols[9]
+	var_clojure_DOT_core_DOT_protocols_CollReduce := restored.Vars[0]
+	var_clojure_DOT_core_DOT_protocols_Datafiable := restored.Vars[1]
+	var_clojure_DOT_core_DOT_protocols_IKVReduce := restored.Vars[2]
+	var_clojure_DOT_core_DOT_protocols_InternalReduce := restored.Vars[3]
+	var_clojure_DOT_core_DOT_protocols_Navigable := restored.Vars[4]
+	var_clojure_DOT_core_DOT_protocols_coll_DASH_reduce := restored.Vars[5]
+	var_clojure_DOT_core_DOT_protocols_datafy := restored.Vars[6]
+	var_clojure_DOT_core_DOT_protocols_interface_DASH_or_DASH_naive_DASH_reduce := restored.Vars[7]
+	var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce := restored.Vars[8]
+	var_clojure_DOT_core_DOT_protocols_iter_DASH_reduce := restored.Vars[9]
+	var_clojure_DOT_core_DOT_protocols_kv_DASH_reduce := restored.Vars[10]
+	var_clojure_DOT_core_DOT_protocols_naive_DASH_seq_DASH_reduce := restored.Vars[11]
+	var_clojure_DOT_core_DOT_protocols_nav := restored.Vars[12]
+	var_clojure_DOT_core_DOT_protocols_seq_DASH_reduce := restored.Vars[13]
 	// var clojure.core/apply
 	var_clojure_DOT_core_apply := lang.InternVarName(sym_clojure_DOT_core, sym_apply)
 	// var clojure.core/chunk-first
@@ -209,6 +201,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -299,7 +292,9 @@ func LoadNS() {
 				_ = v1
 				v2 := p1
 				_ = v2
+//line ../../../clojure/core/protocols.glj:78:14
 				tmp3 := lang.Apply0(v2)
+//line loader.go:298
 				return tmp3
 			}),
 			lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -327,7 +322,9 @@ func LoadNS() {
 				_ = v1
 				v2 := p1
 				_ = v2
+//line ../../../clojure/core/protocols.glj:88:15
 				tmp3 := v1.(interface{ Reduce(lang.IFn) any }).Reduce(lang.MustHostCast[lang.IFn](v2))
+//line loader.go:328
 				return tmp3
 			}),
 			lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -337,7 +334,9 @@ func LoadNS() {
 				_ = v2
 				v3 := p2
 				_ = v3
+//line ../../../clojure/core/protocols.glj:89:19
 				tmp4 := v1.(interface{ ReduceInit(lang.IFn, any) any }).ReduceInit(lang.MustHostCast[lang.IFn](v2), v3)
+//line loader.go:340
 				return tmp4
 			}),
 			nil,
@@ -356,7 +355,9 @@ func LoadNS() {
 				_ = v1
 				v2 := p1
 				_ = v2
+//line ../../../clojure/core/protocols.glj:100:14
 				tmp3 := aotDirectFn3Arity2(v1, v2)
+//line loader.go:361
 				return tmp3
 			}),
 			lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -366,7 +367,9 @@ func LoadNS() {
 				_ = v2
 				v3 := p2
 				_ = v3
+//line ../../../clojure/core/protocols.glj:101:18
 				tmp4 := aotDirectFn3Arity3(v1, v2, v3)
+//line loader.go:373
 				return tmp4
 			}),
 			nil,
@@ -385,7 +388,9 @@ func LoadNS() {
 				_ = v1
 				v2 := p1
 				_ = v2
+//line ../../../clojure/core/protocols.glj:106:14
 				tmp3 := aotDirectFn3Arity2(v1, v2)
+//line loader.go:394
 				return tmp3
 			}),
 			lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -395,7 +400,9 @@ func LoadNS() {
 				_ = v2
 				v3 := p2
 				_ = v3
+//line ../../../clojure/core/protocols.glj:107:18
 				tmp4 := aotDirectFn3Arity3(v1, v2, v3)
+//line loader.go:406
 				return tmp4
 			}),
 			nil,
@@ -414,7 +421,9 @@ func LoadNS() {
 				_ = v1
 				v2 := p1
 				_ = v2
+//line ../../../clojure/core/protocols.glj:111:14
 				tmp3 := aotDirectFn3Arity2(v1, v2)
+//line loader.go:427
 				return tmp3
 			}),
 			lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -424,7 +433,9 @@ func LoadNS() {
 				_ = v2
 				v3 := p2
 				_ = v3
+//line ../../../clojure/core/protocols.glj:112:18
 				tmp4 := aotDirectFn3Arity3(v1, v2, v3)
+//line loader.go:439
 				return tmp4
 			}),
 			nil,
@@ -464,11 +475,15 @@ func LoadNS() {
 			_ = v2
 			v3 := p2
 			_ = v3
-		recur_loop_2880:
+		recur_loop_2876:
+//line ../../../clojure/core/protocols.glj:140:4
 			var tmp4 any
 			{ // let
 				// let binding "temp__0__auto__"
+
+//line ../../../clojure/core/protocols.glj:140:15
 				tmp5 := lang.Seq(v1)
+//line ../../../clojure/core/protocols.glj:140:4
 				var v6 any = tmp5
 				_ = v6
 				var tmp7 any
@@ -478,42 +493,59 @@ func LoadNS() {
 						// let binding "s"
 						var v9 any = v6
 						_ = v9
+//line ../../../clojure/core/protocols.glj:141:6
 						var tmp10 any
+//line ../../../clojure/core/protocols.glj:141:10
 						tmp11 := aotExternalFn8(v9)
+//line ../../../clojure/core/protocols.glj:141:6
 						if lang.IsTruthy(tmp11) {
+//line ../../../clojure/core/protocols.glj:142:8
 							var tmp12 any
 							{ // let
 								// let binding "ret"
+
+//line ../../../clojure/core/protocols.glj:142:31
 								tmp13 := aotExternalFn9(v9)
-								tmp14, _ := lang.FieldOrMethod(tmp13, "ReduceInit")
-								if reflect.TypeOf(tmp14).Kind() != reflect.Func {
-									panic(lang.NewIllegalArgumentError(fmt.Sprintf("ReduceInit is not a function")))
-								}
-								tmp15 := lang.Apply2(tmp14, v2, v3)
-								var v16 any = tmp15
-								_ = v16
-								var tmp17 any
-								tmp18 := lang.IsReduced(v16)
-								if lang.IsTruthy(tmp18) {
-									tmp19 := aotExternalFn4(v16)
-									tmp17 = tmp19
+//line ../../../clojure/core/protocols.glj:142:18
+								tmp14 := any(tmp13).(interface{ ReduceInit(lang.IFn, any) any }).ReduceInit(lang.MustHostCast[lang.IFn](v2), v3)
+//line ../../../clojure/core/protocols.glj:142:8
+								var v15 any = tmp14
+								_ = v15
+//line ../../../clojure/core/protocols.glj:143:10
+								var tmp16 any
+//line ../../../clojure/core/protocols.glj:143:14
+								tmp17 := lang.IsReduced(v15)
+//line ../../../clojure/core/protocols.glj:143:10
+								if lang.IsTruthy(tmp17) {
+//line ../../../clojure/core/protocols.glj:144:12
+									tmp18 := aotExternalFn4(v15)
+//line ../../../clojure/core/protocols.glj:143:10
+									tmp16 = tmp18
 								} else {
-									tmp21 := aotExternalFn10(v9)
-									var tmp20 any = tmp21
-									var tmp22 any = v2
-									var tmp23 any = v16
-									v1 = tmp20
-									v2 = tmp22
-									v3 = tmp23
-									goto recur_loop_2880
+//line ../../../clojure/core/protocols.glj:145:19
+									tmp20 := aotExternalFn10(v9)
+//line ../../../clojure/core/protocols.glj:145:12
+									var tmp19 any = tmp20
+									var tmp21 any = v2
+									var tmp22 any = v15
+									v1 = tmp19
+									v2 = tmp21
+									v3 = tmp22
+									goto recur_loop_2876
+//line ../../../clojure/core/protocols.glj:143:10
 								}
-								tmp12 = tmp17
+//line ../../../clojure/core/protocols.glj:142:8
+								tmp12 = tmp16
 							} // end let
+//line ../../../clojure/core/protocols.glj:141:6
 							tmp10 = tmp12
 						} else {
+//line ../../../clojure/core/protocols.glj:148:8
 							tmp13 := aotDirectFn0(v9, v2, v3)
+//line ../../../clojure/core/protocols.glj:141:6
 							tmp10 = tmp13
 						}
+//line ../../../clojure/core/protocols.glj:140:4
 						tmp8 = tmp10
 					} // end let
 					tmp7 = tmp8
@@ -522,6 +554,7 @@ func LoadNS() {
 				}
 				tmp4 = tmp7
 			} // end let
+//line loader.go:558
 			return tmp4
 		})
 		closed7 = tmp0
@@ -535,10 +568,14 @@ func LoadNS() {
 			_ = v2
 			v3 := p2
 			_ = v3
+//line ../../../clojure/core/protocols.glj:168:4
 			var tmp4 any
 			{ // let
 				// let binding "cls"
+
+//line ../../../clojure/core/protocols.glj:168:15
 				tmp5 := aotExternalFn0(v1)
+//line ../../../clojure/core/protocols.glj:168:4
 				var v6 any = tmp5
 				_ = v6
 				// let binding "s"
@@ -551,10 +588,14 @@ func LoadNS() {
 				var v9 any = v3
 				_ = v9
 				for {
+//line ../../../clojure/core/protocols.glj:172:6
 					var tmp10 any
 					{ // let
 						// let binding "temp__0__auto__"
+
+//line ../../../clojure/core/protocols.glj:172:17
 						tmp11 := lang.Seq(v7)
+//line ../../../clojure/core/protocols.glj:172:6
 						var v12 any = tmp11
 						_ = v12
 						var tmp13 any
@@ -564,25 +605,42 @@ func LoadNS() {
 								// let binding "s"
 								var v15 any = v12
 								_ = v15
+//line ../../../clojure/core/protocols.glj:173:8
 								var tmp16 any
+//line ../../../clojure/core/protocols.glj:173:24
 								tmp17 := aotExternalFn0(v15)
+//line ../../../clojure/core/protocols.glj:173:12
 								tmp18 := aotExternalFn11(tmp17, v6)
+//line ../../../clojure/core/protocols.glj:173:8
 								if lang.IsTruthy(tmp18) {
+//line ../../../clojure/core/protocols.glj:174:10
 									var tmp19 any
 									{ // let
 										// let binding "ret"
+
+//line ../../../clojure/core/protocols.glj:174:27
 										tmp20 := lang.First(v15)
+//line ../../../clojure/core/protocols.glj:174:20
 										tmp21 := lang.Apply2(v8, v9, tmp20)
+//line ../../../clojure/core/protocols.glj:174:10
 										var v22 any = tmp21
 										_ = v22
+//line ../../../clojure/core/protocols.glj:175:17
 										var tmp23 any
+//line ../../../clojure/core/protocols.glj:175:21
 										tmp24 := lang.IsReduced(v22)
+//line ../../../clojure/core/protocols.glj:175:17
 										if lang.IsTruthy(tmp24) {
+//line ../../../clojure/core/protocols.glj:176:19
 											tmp25 := aotExternalFn4(v22)
+//line ../../../clojure/core/protocols.glj:175:17
 											tmp23 = tmp25
 										} else {
+//line ../../../clojure/core/protocols.glj:177:19
 											var tmp26 any = v6
+//line ../../../clojure/core/protocols.glj:177:30
 											tmp28 := lang.Next(v15)
+//line ../../../clojure/core/protocols.glj:177:19
 											var tmp27 any = tmp28
 											var tmp29 any = v8
 											var tmp30 any = v22
@@ -591,14 +649,20 @@ func LoadNS() {
 											v8 = tmp29
 											v9 = tmp30
 											continue
+//line ../../../clojure/core/protocols.glj:175:17
 										}
+//line ../../../clojure/core/protocols.glj:174:10
 										tmp19 = tmp23
 									} // end let
+//line ../../../clojure/core/protocols.glj:173:8
 									tmp16 = tmp19
 								} else {
+//line ../../../clojure/core/protocols.glj:178:10
 									tmp20 := aotDirectFn0(v15, v8, v9)
+//line ../../../clojure/core/protocols.glj:173:8
 									tmp16 = tmp20
 								}
+//line ../../../clojure/core/protocols.glj:172:6
 								tmp14 = tmp16
 							} // end let
 							tmp13 = tmp14
@@ -607,353 +671,404 @@ func LoadNS() {
 						}
 						tmp10 = tmp13
 					} // end let
+//line ../../../clojure/core/protocols.glj:168:4
 					tmp4 = tmp10
 					break
 				}
 			} // end let
+//line loader.go:680
 			return tmp4
 		})
 		closed8 = tmp0
 	}
 	// CollReduce
+
+//line ../../../clojure/core/protocols.glj:13:14
 	{
-		tmp0 := sym_CollReduce
-		var tmp3 lang.ArityFn
-		tmp3 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v4 := args[0]
-				_ = v4
-				var v5 any = rest
-				_ = v5
-				tmp6 := aotExternalFn0(v4)
-				return tmp6
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn coll-reduce
-		tmp2 := lang.NewMultiFn("coll-reduce", tmp3, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		var tmp4 lang.ArityFn
-		tmp4 = lang.NewArityFn(
+		var tmp1 lang.ArityFn
+		tmp1 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v5 := args[0]
-				_ = v5
-				var v6 any = rest
-				_ = v6
-				tmp7 := lang.NewCons(v5, v6)
-				tmp8 := aotExternalFn1(closed0, tmp7)
-				return tmp8
+				v2 := args[0]
+				_ = v2
+				var v3 any = rest
+				_ = v3
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp4 := lang.NewCons(v2, v3)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp5 := aotExternalFn1(closed0, tmp4)
+//line ../../../clojure/core/protocols.glj:13:14
+				return tmp5
 			}),
 			1,
 		)
-		tmp2.AddMethod(nil, tmp4)
-		tmp5 := reflect.TypeOf((*lang.IReduceInit)(nil)).Elem()
-		var tmp6 lang.ArityFn
-		tmp6 = lang.NewArityFn(
+		tmp2 := reflect.TypeOf((*lang.IReduceInit)(nil)).Elem()
+		var tmp3 lang.ArityFn
+		tmp3 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v7 := args[0]
-				_ = v7
-				var v8 any = rest
-				_ = v8
-				tmp9 := lang.NewCons(v7, v8)
-				tmp10 := aotExternalFn1(closed1, tmp9)
-				return tmp10
+				v4 := args[0]
+				_ = v4
+				var v5 any = rest
+				_ = v5
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp6 := lang.NewCons(v4, v5)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp7 := aotExternalFn1(closed1, tmp6)
+//line ../../../clojure/core/protocols.glj:13:14
+				return tmp7
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp5, tmp6)
-		tmp7 := reflect.TypeOf((*lang.LazySeq)(nil))
-		var tmp8 lang.ArityFn
-		tmp8 = lang.NewArityFn(
+		tmp4 := reflect.TypeOf((*lang.LazySeq)(nil))
+		var tmp5 lang.ArityFn
+		tmp5 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v9 := args[0]
-				_ = v9
-				var v10 any = rest
-				_ = v10
-				tmp11 := lang.NewCons(v9, v10)
-				tmp12 := aotExternalFn1(closed2, tmp11)
-				return tmp12
+				v6 := args[0]
+				_ = v6
+				var v7 any = rest
+				_ = v7
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp8 := lang.NewCons(v6, v7)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp9 := aotExternalFn1(closed2, tmp8)
+//line ../../../clojure/core/protocols.glj:13:14
+				return tmp9
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp7, tmp8)
-		tmp9 := reflect.TypeOf((*lang.Vector)(nil))
-		var tmp10 lang.ArityFn
-		tmp10 = lang.NewArityFn(
+		tmp6 := reflect.TypeOf((*lang.Vector)(nil))
+		var tmp7 lang.ArityFn
+		tmp7 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v11 := args[0]
-				_ = v11
-				var v12 any = rest
-				_ = v12
-				tmp13 := lang.NewCons(v11, v12)
-				tmp14 := aotExternalFn1(closed3, tmp13)
-				return tmp14
+				v8 := args[0]
+				_ = v8
+				var v9 any = rest
+				_ = v9
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp10 := lang.NewCons(v8, v9)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp11 := aotExternalFn1(closed3, tmp10)
+//line ../../../clojure/core/protocols.glj:13:14
+				return tmp11
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp9, tmp10)
-		tmp11 := reflect.TypeOf("")
-		var tmp12 lang.ArityFn
-		tmp12 = lang.NewArityFn(
+		tmp8 := reflect.TypeOf("")
+		var tmp9 lang.ArityFn
+		tmp9 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v13 := args[0]
-				_ = v13
-				var v14 any = rest
-				_ = v14
-				tmp15 := lang.NewCons(v13, v14)
-				tmp16 := aotExternalFn1(closed4, tmp15)
-				return tmp16
+				v10 := args[0]
+				_ = v10
+				var v11 any = rest
+				_ = v11
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp12 := lang.NewCons(v10, v11)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp13 := aotExternalFn1(closed4, tmp12)
+//line ../../../clojure/core/protocols.glj:13:14
+				return tmp13
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp11, tmp12)
-		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_coll_DASH_reduce, tmp2), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_coll_DASH_reduce, lang.NewVector(sym_coll, sym_f), lang.NewVector(sym_coll, sym_f, sym_val)))))
-		var_clojure_DOT_core_DOT_protocols_CollReduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_CollReduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(13), kw_column, int(14), kw_end_DASH_line, int(13), kw_end_DASH_column, int(23), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_CollReduce.BindRoot(restored.Root(0,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+			tmp1,
+			tmp2,
+			tmp3,
+			tmp4,
+			tmp5,
+			tmp6,
+			tmp7,
+			tmp8,
+			tmp9,
+		))
 	}
+//line loader.go:829
 	// Datafiable
+
+//line ../../../clojure/core/protocols.glj:188:14
 	{
-		tmp0 := sym_Datafiable
-		var tmp3 lang.ArityFn
-		tmp3 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v4 := args[0]
-				_ = v4
-				var v5 any = rest
-				_ = v5
-				tmp6 := aotExternalFn0(v4)
-				return tmp6
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn datafy
-		tmp2 := lang.NewMultiFn("datafy", tmp3, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		var tmp4 lang.ArityFn
-		tmp4 = lang.NewArityFn(
+		var tmp1 lang.ArityFn
+		tmp1 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v5 := args[0]
-				_ = v5
-				var v6 any = rest
-				_ = v6
-				tmp7 := lang.NewCons(v5, v6)
-				tmp8 := aotExternalFn1(closed5, tmp7)
-				return tmp8
+				v2 := args[0]
+				_ = v2
+				var v3 any = rest
+				_ = v3
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp4 := lang.NewCons(v2, v3)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp5 := aotExternalFn1(closed5, tmp4)
+//line ../../../clojure/core/protocols.glj:188:14
+				return tmp5
 			}),
 			1,
 		)
-		tmp2.AddMethod(nil, tmp4)
-		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_datafy, tmp2), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_datafy, lang.NewVector(sym_o), "return a representation of o as data (default identity)"))))
-		var_clojure_DOT_core_DOT_protocols_Datafiable = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_Datafiable.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(188), kw_column, int(14), kw_end_DASH_line, int(188), kw_end_DASH_column, int(23), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_Datafiable.BindRoot(restored.Root(1,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+			tmp1,
+		))
 	}
+//line loader.go:878
 	// IKVReduce
+
+//line ../../../clojure/core/protocols.glj:181:14
 	{
-		tmp0 := sym_IKVReduce
-		var tmp3 lang.ArityFn
-		tmp3 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v4 := args[0]
-				_ = v4
-				var v5 any = rest
-				_ = v5
-				tmp6 := aotExternalFn0(v4)
-				return tmp6
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn kv-reduce
-		tmp2 := lang.NewMultiFn("kv-reduce", tmp3, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_kv_DASH_reduce, tmp2), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_kv_DASH_reduce, lang.NewVector(sym_amap, sym_f, sym_init)))))
-		var_clojure_DOT_core_DOT_protocols_IKVReduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_IKVReduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(181), kw_column, int(14), kw_end_DASH_line, int(181), kw_end_DASH_column, int(22), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_IKVReduce.BindRoot(restored.Root(2,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+		))
 	}
+//line loader.go:905
 	// InternalReduce
+
+//line ../../../clojure/core/protocols.glj:19:14
 	{
-		tmp0 := sym_InternalReduce
-		var tmp3 lang.ArityFn
-		tmp3 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v4 := args[0]
-				_ = v4
-				var v5 any = rest
-				_ = v5
-				tmp6 := aotExternalFn0(v4)
-				return tmp6
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn internal-reduce
-		tmp2 := lang.NewMultiFn("internal-reduce", tmp3, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		var tmp4 lang.ArityFn
-		tmp4 = lang.NewArityFn(
+		var tmp1 lang.ArityFn
+		tmp1 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v5 := args[0]
-				_ = v5
-				var v6 any = rest
-				_ = v6
-				tmp7 := lang.NewCons(v5, v6)
-				tmp8 := aotExternalFn1(closed6, tmp7)
-				return tmp8
+				v2 := args[0]
+				_ = v2
+				var v3 any = rest
+				_ = v3
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp4 := lang.NewCons(v2, v3)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp5 := aotExternalFn1(closed6, tmp4)
+//line ../../../clojure/core/protocols.glj:19:14
+				return tmp5
 			}),
 			1,
 		)
-		tmp2.AddMethod(nil, tmp4)
-		tmp5 := reflect.TypeOf((*lang.IChunkedSeq)(nil)).Elem()
-		var tmp6 lang.ArityFn
-		tmp6 = lang.NewArityFn(
+		tmp2 := reflect.TypeOf((*lang.IChunkedSeq)(nil)).Elem()
+		var tmp3 lang.ArityFn
+		tmp3 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v7 := args[0]
-				_ = v7
-				var v8 any = rest
-				_ = v8
-				tmp9 := lang.NewCons(v7, v8)
-				tmp10 := aotExternalFn1(closed7, tmp9)
-				return tmp10
+				v4 := args[0]
+				_ = v4
+				var v5 any = rest
+				_ = v5
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp6 := lang.NewCons(v4, v5)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp7 := aotExternalFn1(closed7, tmp6)
+//line ../../../clojure/core/protocols.glj:19:14
+				return tmp7
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp5, tmp6)
-		tmp7 := reflect.TypeOf((*lang.Object)(nil)).Elem()
-		var tmp8 lang.ArityFn
-		tmp8 = lang.NewArityFn(
+		tmp4 := reflect.TypeOf((*lang.Object)(nil)).Elem()
+		var tmp5 lang.ArityFn
+		tmp5 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v9 := args[0]
-				_ = v9
-				var v10 any = rest
-				_ = v10
-				tmp11 := lang.NewCons(v9, v10)
-				tmp12 := aotExternalFn1(closed8, tmp11)
-				return tmp12
+				v6 := args[0]
+				_ = v6
+				var v7 any = rest
+				_ = v7
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp8 := lang.NewCons(v6, v7)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp9 := aotExternalFn1(closed8, tmp8)
+//line ../../../clojure/core/protocols.glj:19:14
+				return tmp9
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp7, tmp8)
-		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_internal_DASH_reduce, tmp2), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_internal_DASH_reduce, lang.NewVector(sym_seq, sym_f, sym_start)))))
-		var_clojure_DOT_core_DOT_protocols_InternalReduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_InternalReduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(19), kw_column, int(14), kw_end_DASH_line, int(19), kw_end_DASH_column, int(27), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_InternalReduce.BindRoot(restored.Root(3,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+			tmp1,
+			tmp2,
+			tmp3,
+			tmp4,
+			tmp5,
+		))
 	}
+//line loader.go:1002
 	// Navigable
+
+//line ../../../clojure/core/protocols.glj:200:14
 	{
-		tmp0 := sym_Navigable
-		var tmp3 lang.ArityFn
-		tmp3 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v4 := args[0]
-				_ = v4
-				var v5 any = rest
-				_ = v5
-				tmp6 := aotExternalFn0(v4)
-				return tmp6
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn nav
-		tmp2 := lang.NewMultiFn("nav", tmp3, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_nav, tmp2), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_nav, lang.NewVector(sym_coll, sym_k, sym_v), "return (possibly transformed) v in the context of coll and k (a key/index or nil),\ndefaults to returning v."))))
-		var_clojure_DOT_core_DOT_protocols_Navigable = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_Navigable.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(200), kw_column, int(14), kw_end_DASH_line, int(200), kw_end_DASH_column, int(22), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_Navigable.BindRoot(restored.Root(4,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+		))
 	}
+//line loader.go:1029
 	// coll-reduce
+
+//line ../../../clojure/core/protocols.glj:17:4
 	{
-		tmp0 := sym_coll_DASH_reduce
-		var tmp2 lang.ArityFn
-		tmp2 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v3 := args[0]
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
+			}),
+			1,
+		)
+		var tmp1 lang.ArityFn
+		tmp1 = lang.NewArityFn(
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
+				v2 := args[0]
+				_ = v2
+				var v3 any = rest
 				_ = v3
-				var v4 any = rest
-				_ = v4
-				tmp5 := aotExternalFn0(v3)
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp4 := lang.NewCons(v2, v3)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp5 := aotExternalFn1(closed0, tmp4)
+//line ../../../clojure/core/protocols.glj:17:4
 				return tmp5
 			}),
 			1,
 		)
-		// MultiFn coll-reduce
-		tmp1 := lang.NewMultiFn("coll-reduce", tmp2, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
+		tmp2 := reflect.TypeOf((*lang.IReduceInit)(nil)).Elem()
 		var tmp3 lang.ArityFn
 		tmp3 = lang.NewArityFn(
 			nil,
@@ -966,14 +1081,16 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp6 := lang.NewCons(v4, v5)
-				tmp7 := aotExternalFn1(closed0, tmp6)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp7 := aotExternalFn1(closed1, tmp6)
+//line ../../../clojure/core/protocols.glj:17:4
 				return tmp7
 			}),
 			1,
 		)
-		tmp1.AddMethod(nil, tmp3)
-		tmp4 := reflect.TypeOf((*lang.IReduceInit)(nil)).Elem()
+		tmp4 := reflect.TypeOf((*lang.LazySeq)(nil))
 		var tmp5 lang.ArityFn
 		tmp5 = lang.NewArityFn(
 			nil,
@@ -986,14 +1103,16 @@ func LoadNS() {
 				_ = v6
 				var v7 any = rest
 				_ = v7
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp8 := lang.NewCons(v6, v7)
-				tmp9 := aotExternalFn1(closed1, tmp8)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp9 := aotExternalFn1(closed2, tmp8)
+//line ../../../clojure/core/protocols.glj:17:4
 				return tmp9
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp4, tmp5)
-		tmp6 := reflect.TypeOf((*lang.LazySeq)(nil))
+		tmp6 := reflect.TypeOf((*lang.Vector)(nil))
 		var tmp7 lang.ArityFn
 		tmp7 = lang.NewArityFn(
 			nil,
@@ -1006,14 +1125,16 @@ func LoadNS() {
 				_ = v8
 				var v9 any = rest
 				_ = v9
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp10 := lang.NewCons(v8, v9)
-				tmp11 := aotExternalFn1(closed2, tmp10)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp11 := aotExternalFn1(closed3, tmp10)
+//line ../../../clojure/core/protocols.glj:17:4
 				return tmp11
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp6, tmp7)
-		tmp8 := reflect.TypeOf((*lang.Vector)(nil))
+		tmp8 := reflect.TypeOf("")
 		var tmp9 lang.ArityFn
 		tmp9 = lang.NewArityFn(
 			nil,
@@ -1026,134 +1147,157 @@ func LoadNS() {
 				_ = v10
 				var v11 any = rest
 				_ = v11
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp12 := lang.NewCons(v10, v11)
-				tmp13 := aotExternalFn1(closed3, tmp12)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp13 := aotExternalFn1(closed4, tmp12)
+//line ../../../clojure/core/protocols.glj:17:4
 				return tmp13
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp8, tmp9)
-		tmp10 := reflect.TypeOf("")
-		var tmp11 lang.ArityFn
-		tmp11 = lang.NewArityFn(
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v12 := args[0]
-				_ = v12
-				var v13 any = rest
-				_ = v13
-				tmp14 := lang.NewCons(v12, v13)
-				tmp15 := aotExternalFn1(closed4, tmp14)
-				return tmp15
-			}),
-			1,
-		)
-		tmp1.AddMethod(tmp10, tmp11)
-		var_clojure_DOT_core_DOT_protocols_coll_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_coll_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(17), kw_column, int(4), kw_end_DASH_line, int(17), kw_end_DASH_column, int(14), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_coll_DASH_reduce.BindRoot(restored.Root(5,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+			tmp1,
+			tmp2,
+			tmp3,
+			tmp4,
+			tmp5,
+			tmp6,
+			tmp7,
+			tmp8,
+			tmp9,
+		))
 	}
+//line loader.go:1174
 	// datafy
+
+//line ../../../clojure/core/protocols.glj:191:4
 	{
-		tmp0 := sym_datafy
-		var tmp2 lang.ArityFn
-		tmp2 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v3 := args[0]
-				_ = v3
-				var v4 any = rest
-				_ = v4
-				tmp5 := aotExternalFn0(v3)
-				return tmp5
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn datafy
-		tmp1 := lang.NewMultiFn("datafy", tmp2, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		var tmp3 lang.ArityFn
-		tmp3 = lang.NewArityFn(
+		var tmp1 lang.ArityFn
+		tmp1 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v4 := args[0]
-				_ = v4
-				var v5 any = rest
-				_ = v5
-				tmp6 := lang.NewCons(v4, v5)
-				tmp7 := aotExternalFn1(closed5, tmp6)
-				return tmp7
+				v2 := args[0]
+				_ = v2
+				var v3 any = rest
+				_ = v3
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp4 := lang.NewCons(v2, v3)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp5 := aotExternalFn1(closed5, tmp4)
+//line ../../../clojure/core/protocols.glj:191:4
+				return tmp5
 			}),
 			1,
 		)
-		tmp1.AddMethod(nil, tmp3)
-		var_clojure_DOT_core_DOT_protocols_datafy = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_datafy.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(191), kw_column, int(4), kw_end_DASH_line, int(191), kw_end_DASH_column, int(9), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_datafy.BindRoot(restored.Root(6,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+			tmp1,
+		))
 	}
+//line loader.go:1223
 	// interface-or-naive-reduce
+
+//line ../../../clojure/core/protocols.glj:68:8
 	{
-		tmp0 := sym_interface_DASH_or_DASH_naive_DASH_reduce
-		var tmp1 lang.FnFunc3
-		tmp1 = lang.FnFunc3(func(p0, p1, p2 any) any {
-			v2 := p0
+		var tmp0 lang.FnFunc3
+		tmp0 = lang.FnFunc3(func(p0, p1, p2 any) any {
+			v1 := p0
+			_ = v1
+			v2 := p1
 			_ = v2
-			v3 := p1
+			v3 := p2
 			_ = v3
-			v4 := p2
-			_ = v4
-			var tmp5 any
-			tmp6 := lang.IsInstance[lang.IReduceInit](v2)
-			if lang.IsTruthy(tmp6) {
-				tmp7 := v2.(interface{ ReduceInit(lang.IFn, any) any }).ReduceInit(lang.MustHostCast[lang.IFn](v3), v4)
-				tmp5 = tmp7
+//line ../../../clojure/core/protocols.glj:71:3
+			var tmp4 any
+//line ../../../clojure/core/protocols.glj:71:7
+			tmp5 := lang.IsInstance[lang.IReduceInit](v1)
+//line ../../../clojure/core/protocols.glj:71:3
+			if lang.IsTruthy(tmp5) {
+//line ../../../clojure/core/protocols.glj:72:5
+				tmp6 := v1.(interface{ ReduceInit(lang.IFn, any) any }).ReduceInit(lang.MustHostCast[lang.IFn](v2), v3)
+//line ../../../clojure/core/protocols.glj:71:3
+				tmp4 = tmp6
 			} else {
-				tmp8 := aotDirectFn2(v2, v3, v4)
-				tmp5 = tmp8
+//line ../../../clojure/core/protocols.glj:73:5
+				tmp7 := aotDirectFn2(v1, v2, v3)
+//line ../../../clojure/core/protocols.glj:71:3
+				tmp4 = tmp7
 			}
-			return tmp5
-		})
-		aotDirectFn0 = tmp1
-		var_clojure_DOT_core_DOT_protocols_interface_DASH_or_DASH_naive_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_interface_DASH_or_DASH_naive_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMapUniqueKeys(kw_file, "clojure/core/protocols.glj", kw_line, int(68), kw_column, int(8), kw_end_DASH_line, int(68), kw_end_DASH_column, int(32), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_coll, sym_f, sym_val)), kw_doc, "Reduces via IReduceInit if possible, else naively.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
+//line ../../../clojure/core/protocols.glj:68:8
+			return tmp4
 		})
+		aotDirectFn0 = tmp0
+		var_clojure_DOT_core_DOT_protocols_interface_DASH_or_DASH_naive_DASH_reduce.BindRoot(tmp0)
 	}
+//line loader.go:1258
 	// internal-reduce
+
+//line ../../../clojure/core/protocols.glj:22:4
 	{
-		tmp0 := sym_internal_DASH_reduce
-		var tmp2 lang.ArityFn
-		tmp2 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
+			}),
+			1,
+		)
+		var tmp1 lang.ArityFn
+		tmp1 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v3 := args[0]
+				v2 := args[0]
+				_ = v2
+				var v3 any = rest
 				_ = v3
-				var v4 any = rest
-				_ = v4
-				tmp5 := aotExternalFn0(v3)
+//line ../../../clojure/core_deftype.glj:160:27
+				tmp4 := lang.NewCons(v2, v3)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp5 := aotExternalFn1(closed6, tmp4)
+//line ../../../clojure/core/protocols.glj:22:4
 				return tmp5
 			}),
 			1,
 		)
-		// MultiFn internal-reduce
-		tmp1 := lang.NewMultiFn("internal-reduce", tmp2, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
+		tmp2 := reflect.TypeOf((*lang.IChunkedSeq)(nil)).Elem()
 		var tmp3 lang.ArityFn
 		tmp3 = lang.NewArityFn(
 			nil,
@@ -1166,14 +1310,16 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp6 := lang.NewCons(v4, v5)
-				tmp7 := aotExternalFn1(closed6, tmp6)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp7 := aotExternalFn1(closed7, tmp6)
+//line ../../../clojure/core/protocols.glj:22:4
 				return tmp7
 			}),
 			1,
 		)
-		tmp1.AddMethod(nil, tmp3)
-		tmp4 := reflect.TypeOf((*lang.IChunkedSeq)(nil)).Elem()
+		tmp4 := reflect.TypeOf((*lang.Object)(nil)).Elem()
 		var tmp5 lang.ArityFn
 		tmp5 = lang.NewArityFn(
 			nil,
@@ -1186,236 +1332,284 @@ func LoadNS() {
 				_ = v6
 				var v7 any = rest
 				_ = v7
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp8 := lang.NewCons(v6, v7)
-				tmp9 := aotExternalFn1(closed7, tmp8)
+//line ../../../clojure/core_deftype.glj:160:18
+				tmp9 := aotExternalFn1(closed8, tmp8)
+//line ../../../clojure/core/protocols.glj:22:4
 				return tmp9
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp4, tmp5)
-		tmp6 := reflect.TypeOf((*lang.Object)(nil)).Elem()
-		var tmp7 lang.ArityFn
-		tmp7 = lang.NewArityFn(
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v8 := args[0]
-				_ = v8
-				var v9 any = rest
-				_ = v9
-				tmp10 := lang.NewCons(v8, v9)
-				tmp11 := aotExternalFn1(closed8, tmp10)
-				return tmp11
-			}),
-			1,
-		)
-		tmp1.AddMethod(tmp6, tmp7)
-		var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(22), kw_column, int(4), kw_end_DASH_line, int(22), kw_end_DASH_column, int(18), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce.BindRoot(restored.Root(8,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+			tmp1,
+			tmp2,
+			tmp3,
+			tmp4,
+			tmp5,
+		))
 	}
+//line loader.go:1355
 	// iter-reduce
+
+//line ../../../clojure/core/protocols.glj:33:8
 	{
-		tmp0 := sym_iter_DASH_reduce
-		var tmp1 lang.ArityFn
+		var tmp0 lang.ArityFn
 		aotDirectFn1Arity2 = lang.FnFunc2(func(p0, p1 any) any {
-			v2 := p0
+			v1 := p0
+			_ = v1
+			v2 := p1
 			_ = v2
-			v3 := p1
-			_ = v3
-			var tmp4 any
+//line ../../../clojure/core/protocols.glj:35:4
+			var tmp3 any
 			{ // let
 				// let binding "iter"
-				tmp5, ok := lang.FieldOrMethod(v2, "iterator")
+
+//line ../../../clojure/core/protocols.glj:35:15
+				tmp4, ok := lang.FieldOrMethod(v1, "iterator")
 				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v2, "iterator")))
+					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "iterator")))
 				}
-				var tmp6 any
-				switch reflect.TypeOf(tmp5).Kind() {
+				var tmp5 any
+				switch reflect.TypeOf(tmp4).Kind() {
 				case reflect.Func:
-					tmp6 = lang.Apply(tmp5, nil)
+					tmp5 = lang.Apply(tmp4, nil)
 				default:
-					tmp6 = tmp5
+					tmp5 = tmp4
 				}
-				var v7 any = tmp6
-				_ = v7
-				var tmp8 any
-				tmp9, ok := lang.FieldOrMethod(v7, "hasNext")
+//line ../../../clojure/core/protocols.glj:35:4
+				var v6 any = tmp5
+				_ = v6
+//line ../../../clojure/core/protocols.glj:36:6
+				var tmp7 any
+//line ../../../clojure/core/protocols.glj:36:10
+				tmp8, ok := lang.FieldOrMethod(v6, "hasNext")
 				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "hasNext")))
+					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v6, "hasNext")))
 				}
-				var tmp10 any
-				switch reflect.TypeOf(tmp9).Kind() {
+				var tmp9 any
+				switch reflect.TypeOf(tmp8).Kind() {
 				case reflect.Func:
-					tmp10 = lang.Apply(tmp9, nil)
+					tmp9 = lang.Apply(tmp8, nil)
 				default:
-					tmp10 = tmp9
+					tmp9 = tmp8
 				}
-				if lang.IsTruthy(tmp10) {
-					var tmp11 any
+//line ../../../clojure/core/protocols.glj:36:6
+				if lang.IsTruthy(tmp9) {
+//line ../../../clojure/core/protocols.glj:37:8
+					var tmp10 any
 					{ // let
 						// let binding "ret"
-						tmp12, ok := lang.FieldOrMethod(v7, "next")
+
+//line ../../../clojure/core/protocols.glj:37:19
+						tmp11, ok := lang.FieldOrMethod(v6, "next")
 						if !ok {
-							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "next")))
+							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v6, "next")))
 						}
-						var tmp13 any
-						switch reflect.TypeOf(tmp12).Kind() {
+						var tmp12 any
+						switch reflect.TypeOf(tmp11).Kind() {
 						case reflect.Func:
-							tmp13 = lang.Apply(tmp12, nil)
+							tmp12 = lang.Apply(tmp11, nil)
 						default:
-							tmp13 = tmp12
+							tmp12 = tmp11
 						}
-						var v14 any = tmp13
-						_ = v14
+//line ../../../clojure/core/protocols.glj:37:8
+						var v13 any = tmp12
+						_ = v13
 						for {
-							var tmp15 any
-							tmp16, ok := lang.FieldOrMethod(v7, "hasNext")
+//line ../../../clojure/core/protocols.glj:38:10
+							var tmp14 any
+//line ../../../clojure/core/protocols.glj:38:14
+							tmp15, ok := lang.FieldOrMethod(v6, "hasNext")
 							if !ok {
-								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "hasNext")))
+								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v6, "hasNext")))
 							}
-							var tmp17 any
-							switch reflect.TypeOf(tmp16).Kind() {
+							var tmp16 any
+							switch reflect.TypeOf(tmp15).Kind() {
 							case reflect.Func:
-								tmp17 = lang.Apply(tmp16, nil)
+								tmp16 = lang.Apply(tmp15, nil)
 							default:
-								tmp17 = tmp16
+								tmp16 = tmp15
 							}
-							if lang.IsTruthy(tmp17) {
-								var tmp18 any
+//line ../../../clojure/core/protocols.glj:38:10
+							if lang.IsTruthy(tmp16) {
+//line ../../../clojure/core/protocols.glj:39:12
+								var tmp17 any
 								{ // let
 									// let binding "ret"
-									tmp19, ok := lang.FieldOrMethod(v7, "next")
+
+//line ../../../clojure/core/protocols.glj:39:29
+									tmp18, ok := lang.FieldOrMethod(v6, "next")
 									if !ok {
-										panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "next")))
+										panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v6, "next")))
 									}
-									var tmp20 any
-									switch reflect.TypeOf(tmp19).Kind() {
+									var tmp19 any
+									switch reflect.TypeOf(tmp18).Kind() {
 									case reflect.Func:
-										tmp20 = lang.Apply(tmp19, nil)
+										tmp19 = lang.Apply(tmp18, nil)
 									default:
-										tmp20 = tmp19
+										tmp19 = tmp18
 									}
-									tmp21 := lang.Apply2(v3, v14, tmp20)
-									var v22 any = tmp21
-									_ = v22
-									var tmp23 any
-									tmp24 := lang.IsReduced(v22)
-									if lang.IsTruthy(tmp24) {
-										tmp25 := aotExternalFn4(v22)
-										tmp23 = tmp25
+//line ../../../clojure/core/protocols.glj:39:22
+									tmp20 := lang.Apply2(v2, v13, tmp19)
+//line ../../../clojure/core/protocols.glj:39:12
+									var v21 any = tmp20
+									_ = v21
+//line ../../../clojure/core/protocols.glj:40:14
+									var tmp22 any
+//line ../../../clojure/core/protocols.glj:40:18
+									tmp23 := lang.IsReduced(v21)
+//line ../../../clojure/core/protocols.glj:40:14
+									if lang.IsTruthy(tmp23) {
+//line ../../../clojure/core/protocols.glj:41:16
+										tmp24 := aotExternalFn4(v21)
+//line ../../../clojure/core/protocols.glj:40:14
+										tmp22 = tmp24
 									} else {
-										var tmp26 any = v22
-										v14 = tmp26
+//line ../../../clojure/core/protocols.glj:42:16
+										var tmp25 any = v21
+										v13 = tmp25
 										continue
+//line ../../../clojure/core/protocols.glj:40:14
 									}
-									tmp18 = tmp23
+//line ../../../clojure/core/protocols.glj:39:12
+									tmp17 = tmp22
 								} // end let
-								tmp15 = tmp18
+//line ../../../clojure/core/protocols.glj:38:10
+								tmp14 = tmp17
 							} else {
-								tmp15 = v14
+								tmp14 = v13
 							}
-							tmp11 = tmp15
+//line ../../../clojure/core/protocols.glj:37:8
+							tmp10 = tmp14
 							break
 						}
 					} // end let
-					tmp8 = tmp11
+//line ../../../clojure/core/protocols.glj:36:6
+					tmp7 = tmp10
 				} else {
-					tmp12 := lang.Apply0(v3)
-					tmp8 = tmp12
+//line ../../../clojure/core/protocols.glj:44:8
+					tmp11 := lang.Apply0(v2)
+//line ../../../clojure/core/protocols.glj:36:6
+					tmp7 = tmp11
 				}
-				tmp4 = tmp8
+//line ../../../clojure/core/protocols.glj:35:4
+				tmp3 = tmp7
 			} // end let
-			return tmp4
+//line ../../../clojure/core/protocols.glj:33:8
+			return tmp3
 		})
 		aotDirectFn1Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
-			v2 := p0
+			v1 := p0
+			_ = v1
+			v2 := p1
 			_ = v2
-			v3 := p1
+			v3 := p2
 			_ = v3
-			v4 := p2
-			_ = v4
-			var tmp5 any
+//line ../../../clojure/core/protocols.glj:46:4
+			var tmp4 any
 			{ // let
 				// let binding "iter"
-				tmp6, ok := lang.FieldOrMethod(v2, "iterator")
+
+//line ../../../clojure/core/protocols.glj:46:15
+				tmp5, ok := lang.FieldOrMethod(v1, "iterator")
 				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v2, "iterator")))
+					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "iterator")))
 				}
-				var tmp7 any
-				switch reflect.TypeOf(tmp6).Kind() {
+				var tmp6 any
+				switch reflect.TypeOf(tmp5).Kind() {
 				case reflect.Func:
-					tmp7 = lang.Apply(tmp6, nil)
+					tmp6 = lang.Apply(tmp5, nil)
 				default:
-					tmp7 = tmp6
+					tmp6 = tmp5
 				}
-				var v8 any = tmp7
-				_ = v8
-				var tmp9 any
+//line ../../../clojure/core/protocols.glj:46:4
+				var v7 any = tmp6
+				_ = v7
+//line ../../../clojure/core/protocols.glj:47:6
+				var tmp8 any
 				{ // let
 					// let binding "ret"
-					var v10 any = v4
-					_ = v10
+					var v9 any = v3
+					_ = v9
 					for {
-						var tmp11 any
-						tmp12, ok := lang.FieldOrMethod(v8, "hasNext")
+//line ../../../clojure/core/protocols.glj:48:8
+						var tmp10 any
+//line ../../../clojure/core/protocols.glj:48:12
+						tmp11, ok := lang.FieldOrMethod(v7, "hasNext")
 						if !ok {
-							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v8, "hasNext")))
+							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "hasNext")))
 						}
-						var tmp13 any
-						switch reflect.TypeOf(tmp12).Kind() {
+						var tmp12 any
+						switch reflect.TypeOf(tmp11).Kind() {
 						case reflect.Func:
-							tmp13 = lang.Apply(tmp12, nil)
+							tmp12 = lang.Apply(tmp11, nil)
 						default:
-							tmp13 = tmp12
+							tmp12 = tmp11
 						}
-						if lang.IsTruthy(tmp13) {
-							var tmp14 any
+//line ../../../clojure/core/protocols.glj:48:8
+						if lang.IsTruthy(tmp12) {
+//line ../../../clojure/core/protocols.glj:49:10
+							var tmp13 any
 							{ // let
 								// let binding "ret"
-								tmp15, ok := lang.FieldOrMethod(v8, "next")
+
+//line ../../../clojure/core/protocols.glj:49:27
+								tmp14, ok := lang.FieldOrMethod(v7, "next")
 								if !ok {
-									panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v8, "next")))
+									panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "next")))
 								}
-								var tmp16 any
-								switch reflect.TypeOf(tmp15).Kind() {
+								var tmp15 any
+								switch reflect.TypeOf(tmp14).Kind() {
 								case reflect.Func:
-									tmp16 = lang.Apply(tmp15, nil)
+									tmp15 = lang.Apply(tmp14, nil)
 								default:
-									tmp16 = tmp15
+									tmp15 = tmp14
 								}
-								tmp17 := lang.Apply2(v3, v10, tmp16)
-								var v18 any = tmp17
-								_ = v18
-								var tmp19 any
-								tmp20 := lang.IsReduced(v18)
-								if lang.IsTruthy(tmp20) {
-									tmp21 := aotExternalFn4(v18)
-									tmp19 = tmp21
+//line ../../../clojure/core/protocols.glj:49:20
+								tmp16 := lang.Apply2(v2, v9, tmp15)
+//line ../../../clojure/core/protocols.glj:49:10
+								var v17 any = tmp16
+								_ = v17
+//line ../../../clojure/core/protocols.glj:50:12
+								var tmp18 any
+//line ../../../clojure/core/protocols.glj:50:16
+								tmp19 := lang.IsReduced(v17)
+//line ../../../clojure/core/protocols.glj:50:12
+								if lang.IsTruthy(tmp19) {
+//line ../../../clojure/core/protocols.glj:51:14
+									tmp20 := aotExternalFn4(v17)
+//line ../../../clojure/core/protocols.glj:50:12
+									tmp18 = tmp20
 								} else {
-									var tmp22 any = v18
-									v10 = tmp22
+//line ../../../clojure/core/protocols.glj:52:14
+									var tmp21 any = v17
+									v9 = tmp21
 									continue
+//line ../../../clojure/core/protocols.glj:50:12
 								}
-								tmp14 = tmp19
+//line ../../../clojure/core/protocols.glj:49:10
+								tmp13 = tmp18
 							} // end let
-							tmp11 = tmp14
+//line ../../../clojure/core/protocols.glj:48:8
+							tmp10 = tmp13
 						} else {
-							tmp11 = v10
+							tmp10 = v9
 						}
-						tmp9 = tmp11
+//line ../../../clojure/core/protocols.glj:47:6
+						tmp8 = tmp10
 						break
 					}
 				} // end let
-				tmp5 = tmp9
+//line ../../../clojure/core/protocols.glj:46:4
+				tmp4 = tmp8
 			} // end let
-			return tmp5
+//line ../../../clojure/core/protocols.glj:33:8
+			return tmp4
 		})
-		tmp1 = lang.NewArityFn(
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			aotDirectFn1Arity2,
@@ -1424,184 +1618,224 @@ func LoadNS() {
 			nil,
 			0,
 		)
-		aotDirectFn1 = tmp1
-		var_clojure_DOT_core_DOT_protocols_iter_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_iter_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMapUniqueKeys(kw_file, "clojure/core/protocols.glj", kw_line, int(33), kw_column, int(8), kw_end_DASH_line, int(33), kw_end_DASH_column, int(18), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_coll, sym_f), lang.NewVector(sym_coll, sym_f, sym_val)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		aotDirectFn1 = tmp0
+		var_clojure_DOT_core_DOT_protocols_iter_DASH_reduce.BindRoot(tmp0)
 	}
+//line loader.go:1625
 	// kv-reduce
+
+//line ../../../clojure/core/protocols.glj:186:4
 	{
-		tmp0 := sym_kv_DASH_reduce
-		var tmp2 lang.ArityFn
-		tmp2 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v3 := args[0]
-				_ = v3
-				var v4 any = rest
-				_ = v4
-				tmp5 := aotExternalFn0(v3)
-				return tmp5
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn kv-reduce
-		tmp1 := lang.NewMultiFn("kv-reduce", tmp2, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		var_clojure_DOT_core_DOT_protocols_kv_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_kv_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(186), kw_column, int(4), kw_end_DASH_line, int(186), kw_end_DASH_column, int(12), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_kv_DASH_reduce.BindRoot(restored.Root(10,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+		))
 	}
+//line loader.go:1652
 	// naive-seq-reduce
+
+//line ../../../clojure/core/protocols.glj:55:8
 	{
-		tmp0 := sym_naive_DASH_seq_DASH_reduce
-		var tmp1 lang.FnFunc3
-		tmp1 = lang.FnFunc3(func(p0, p1, p2 any) any {
-			v2 := p0
+		var tmp0 lang.FnFunc3
+		tmp0 = lang.FnFunc3(func(p0, p1, p2 any) any {
+			v1 := p0
+			_ = v1
+			v2 := p1
 			_ = v2
-			v3 := p1
+			v3 := p2
 			_ = v3
-			v4 := p2
-			_ = v4
-			var tmp5 any
+//line ../../../clojure/core/protocols.glj:59:3
+			var tmp4 any
 			{ // let
 				// let binding "s"
-				tmp6 := lang.Seq(v2)
-				var v7 any = tmp6
-				_ = v7
+
+//line ../../../clojure/core/protocols.glj:59:12
+				tmp5 := lang.Seq(v1)
+//line ../../../clojure/core/protocols.glj:59:3
+				var v6 any = tmp5
+				_ = v6
 				// let binding "val"
-				var v8 any = v4
-				_ = v8
+				var v7 any = v3
+				_ = v7
 				for {
-					var tmp9 any
-					if lang.IsTruthy(v7) {
-						var tmp10 any
+//line ../../../clojure/core/protocols.glj:61:5
+					var tmp8 any
+					if lang.IsTruthy(v6) {
+//line ../../../clojure/core/protocols.glj:62:7
+						var tmp9 any
 						{ // let
 							// let binding "ret"
-							tmp11 := lang.First(v7)
-							tmp12 := lang.Apply2(v3, v8, tmp11)
-							var v13 any = tmp12
-							_ = v13
-							var tmp14 any
-							tmp15 := lang.IsReduced(v13)
-							if lang.IsTruthy(tmp15) {
-								tmp16 := aotExternalFn4(v13)
-								tmp14 = tmp16
+
+//line ../../../clojure/core/protocols.glj:62:24
+							tmp10 := lang.First(v6)
+//line ../../../clojure/core/protocols.glj:62:17
+							tmp11 := lang.Apply2(v2, v7, tmp10)
+//line ../../../clojure/core/protocols.glj:62:7
+							var v12 any = tmp11
+							_ = v12
+//line ../../../clojure/core/protocols.glj:63:9
+							var tmp13 any
+//line ../../../clojure/core/protocols.glj:63:13
+							tmp14 := lang.IsReduced(v12)
+//line ../../../clojure/core/protocols.glj:63:9
+							if lang.IsTruthy(tmp14) {
+//line ../../../clojure/core/protocols.glj:64:11
+								tmp15 := aotExternalFn4(v12)
+//line ../../../clojure/core/protocols.glj:63:9
+								tmp13 = tmp15
 							} else {
-								tmp18 := lang.Next(v7)
-								var tmp17 any = tmp18
-								var tmp19 any = v13
-								v7 = tmp17
-								v8 = tmp19
+//line ../../../clojure/core/protocols.glj:65:18
+								tmp17 := lang.Next(v6)
+//line ../../../clojure/core/protocols.glj:65:11
+								var tmp16 any = tmp17
+								var tmp18 any = v12
+								v6 = tmp16
+								v7 = tmp18
 								continue
+//line ../../../clojure/core/protocols.glj:63:9
 							}
-							tmp10 = tmp14
+//line ../../../clojure/core/protocols.glj:62:7
+							tmp9 = tmp13
 						} // end let
-						tmp9 = tmp10
+//line ../../../clojure/core/protocols.glj:61:5
+						tmp8 = tmp9
 					} else {
-						tmp9 = v8
+						tmp8 = v7
 					}
-					tmp5 = tmp9
+//line ../../../clojure/core/protocols.glj:59:3
+					tmp4 = tmp8
 					break
 				}
 			} // end let
-			return tmp5
-		})
-		aotDirectFn2 = tmp1
-		var_clojure_DOT_core_DOT_protocols_naive_DASH_seq_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_naive_DASH_seq_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMapUniqueKeys(kw_file, "clojure/core/protocols.glj", kw_line, int(55), kw_column, int(8), kw_end_DASH_line, int(55), kw_end_DASH_column, int(23), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_f, sym_val)), kw_doc, "Reduces a seq, ignoring any opportunities to switch to a more\n  specialized implementation.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
+//line ../../../clojure/core/protocols.glj:55:8
+			return tmp4
 		})
+		aotDirectFn2 = tmp0
+		var_clojure_DOT_core_DOT_protocols_naive_DASH_seq_DASH_reduce.BindRoot(tmp0)
 	}
+//line loader.go:1734
 	// nav
+
+//line ../../../clojure/core/protocols.glj:203:4
 	{
-		tmp0 := sym_nav
-		var tmp2 lang.ArityFn
-		tmp2 = lang.NewArityFn(
+		var tmp0 lang.ArityFn
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			nil,
 			nil,
 			nil,
 			lang.NewVariadicFn(1, func(args []any, rest lang.ISeq) any {
-				v3 := args[0]
-				_ = v3
-				var v4 any = rest
-				_ = v4
-				tmp5 := aotExternalFn0(v3)
-				return tmp5
+				v1 := args[0]
+				_ = v1
+				var v2 any = rest
+				_ = v2
+				tmp3 := aotExternalFn0(v1)
+				return tmp3
 			}),
 			1,
 		)
-		// MultiFn nav
-		tmp1 := lang.NewMultiFn("nav", tmp2, kw_default, lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy))
-		var_clojure_DOT_core_DOT_protocols_nav = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_nav.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMap(kw_file, "clojure/core/protocols.glj", kw_line, int(203), kw_column, int(4), kw_end_DASH_line, int(203), kw_end_DASH_column, int(6), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		var_clojure_DOT_core_DOT_protocols_nav.BindRoot(restored.Root(12,
+			tmp0,
+			lang.FindOrCreateNamespace(sym_clojure_DOT_core).FindInternedVar(sym_global_DASH_hierarchy),
+		))
 	}
+//line loader.go:1761
 	// seq-reduce
+
+//line ../../../clojure/core/protocols.glj:24:8
 	{
-		tmp0 := sym_seq_DASH_reduce
-		var tmp1 lang.ArityFn
+		var tmp0 lang.ArityFn
 		aotDirectFn3Arity2 = lang.FnFunc2(func(p0, p1 any) any {
-			v2 := p0
+			v1 := p0
+			_ = v1
+			v2 := p1
 			_ = v2
-			v3 := p1
-			_ = v3
-			var tmp4 any
+//line ../../../clojure/core/protocols.glj:26:6
+			var tmp3 any
 			{ // let
 				// let binding "temp__0__auto__"
-				tmp5 := lang.Seq(v2)
-				var v6 any = tmp5
-				_ = v6
-				var tmp7 any
-				if lang.IsTruthy(v6) {
-					var tmp8 any
+
+//line ../../../clojure/core/protocols.glj:26:17
+				tmp4 := lang.Seq(v1)
+//line ../../../clojure/core/protocols.glj:26:6
+				var v5 any = tmp4
+				_ = v5
+				var tmp6 any
+				if lang.IsTruthy(v5) {
+					var tmp7 any
 					{ // let
 						// let binding "s"
-						var v9 any = v6
-						_ = v9
-						tmp10 := checkDerefVar(var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce)
-						tmp11 := lang.Next(v9)
-						tmp12 := lang.First(v9)
-						tmp13 := lang.Apply3(tmp10, tmp11, v3, tmp12)
-						tmp8 = tmp13
+						var v8 any = v5
+						_ = v8
+//line ../../../clojure/core/protocols.glj:27:9
+						tmp9 := checkDerefVar(var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce)
+//line ../../../clojure/core/protocols.glj:27:25
+						tmp10 := lang.Next(v8)
+//line ../../../clojure/core/protocols.glj:27:36
+						tmp11 := lang.First(v8)
+//line ../../../clojure/core/protocols.glj:27:8
+						tmp12 := lang.Apply3(tmp9, tmp10, v2, tmp11)
+//line ../../../clojure/core/protocols.glj:26:6
+						tmp7 = tmp12
 					} // end let
-					tmp7 = tmp8
+					tmp6 = tmp7
 				} else {
-					tmp9 := lang.Apply0(v3)
-					tmp7 = tmp9
+//line ../../../clojure/core/protocols.glj:28:8
+					tmp8 := lang.Apply0(v2)
+//line ../../../clojure/core/protocols.glj:26:6
+					tmp6 = tmp8
 				}
-				tmp4 = tmp7
+				tmp3 = tmp6
 			} // end let
-			return tmp4
+//line ../../../clojure/core/protocols.glj:24:8
+			return tmp3
 		})
 		aotDirectFn3Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
-			v2 := p0
+			v1 := p0
+			_ = v1
+			v2 := p1
 			_ = v2
-			v3 := p1
+			v3 := p2
 			_ = v3
-			v4 := p2
-			_ = v4
-			var tmp5 any
+//line ../../../clojure/core/protocols.glj:30:6
+			var tmp4 any
 			{ // let
 				// let binding "s"
-				tmp6 := lang.Seq(v2)
-				var v7 any = tmp6
-				_ = v7
-				tmp8 := checkDerefVar(var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce)
-				tmp9 := lang.Apply3(tmp8, v7, v3, v4)
-				tmp5 = tmp9
+
+//line ../../../clojure/core/protocols.glj:30:14
+				tmp5 := lang.Seq(v1)
+//line ../../../clojure/core/protocols.glj:30:6
+				var v6 any = tmp5
+				_ = v6
+//line ../../../clojure/core/protocols.glj:31:9
+				tmp7 := checkDerefVar(var_clojure_DOT_core_DOT_protocols_internal_DASH_reduce)
+//line ../../../clojure/core/protocols.glj:31:8
+				tmp8 := lang.Apply3(tmp7, v6, v2, v3)
+//line ../../../clojure/core/protocols.glj:30:6
+				tmp4 = tmp8
 			} // end let
-			return tmp5
+//line ../../../clojure/core/protocols.glj:24:8
+			return tmp4
 		})
-		tmp1 = lang.NewArityFn(
+		tmp0 = lang.NewArityFn(
 			nil,
 			nil,
 			aotDirectFn3Arity2,
@@ -1610,10 +1844,8 @@ func LoadNS() {
 			nil,
 			0,
 		)
-		aotDirectFn3 = tmp1
-		var_clojure_DOT_core_DOT_protocols_seq_DASH_reduce = ns.InternWithValue(tmp0, tmp1, true)
-		var_clojure_DOT_core_DOT_protocols_seq_DASH_reduce.SetMetaLazy(func() lang.IPersistentMap {
-			return lang.NewMapUniqueKeys(kw_file, "clojure/core/protocols.glj", kw_line, int(24), kw_column, int(8), kw_end_DASH_line, int(24), kw_end_DASH_column, int(17), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_coll, sym_f), lang.NewVector(sym_coll, sym_f, sym_val)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_core_DOT_protocols))
-		})
+		aotDirectFn3 = tmp0
+		var_clojure_DOT_core_DOT_protocols_seq_DASH_reduce.BindRoot(tmp0)
 	}
+//line loader.go:1851
 }
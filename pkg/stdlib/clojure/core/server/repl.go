@@ -0,0 +1,484 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/reader"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+var (
+	kwTag       = lang.NewKeyword("tag")
+	kwVal       = lang.NewKeyword("val")
+	kwNS        = lang.NewKeyword("ns")
+	kwMS        = lang.NewKeyword("ms")
+	kwForm      = lang.NewKeyword("form")
+	kwException = lang.NewKeyword("exception")
+	kwRet       = lang.NewKeyword("ret")
+	kwOut       = lang.NewKeyword("out")
+	kwErr       = lang.NewKeyword("err")
+	kwTap       = lang.NewKeyword("tap")
+	kwReplQuit  = lang.NewKeyword("repl/quit")
+	kwPhase     = lang.NewKeyword("phase")
+
+	kwReadSource      = lang.NewKeyword("read-source")
+	kwExecution       = lang.NewKeyword("execution")
+	kwPrintEvalResult = lang.NewKeyword("print-eval-result")
+	kwReadEvalResult  = lang.NewKeyword("read-eval-result")
+)
+
+// replVarNames are the clojure.core vars a REPL binds, as
+// clojure.main/with-bindings does, so that set! works on them for the
+// life of the REPL.
+var replVarNames = []string{
+	"*warn-on-reflection*",
+	"*unchecked-math*",
+	"*data-readers*",
+	"*default-data-reader-fn*",
+	"*print-meta*",
+	"*print-length*",
+	"*print-level*",
+	"*print-namespace-maps*",
+	"*assert*",
+	"*command-line-args*",
+	"*compile-path*",
+}
+
+// resultVarNames hold recent results and the last error.
+var resultVarNames = []string{"*1", "*2", "*3", "*e"}
+
+// Repl runs a plain-text REPL reading forms from in, printing prompts
+// and results to out and errors to errOut, until in ends or a form
+// evaluates to :repl/quit.
+func Repl(in io.Reader, out, errOut io.Writer) {
+	lr := newLineReader(in)
+	withREPLBindings(lr, lr, out, errOut, func(env lang.Environment, rdr *reader.Reader) {
+		for {
+			fmt.Fprintf(out, "%s=> ", env.CurrentNamespace().Name())
+			form, _, err := lr.readForm(rdr)
+			if lr.err != nil {
+				return
+			}
+			if err == nil {
+				lr.skipLineEnd()
+				var result any
+				result, err = eval(env, form)
+				if err == nil {
+					if result == kwReplQuit {
+						return
+					}
+					setResult(result)
+					fmt.Fprintln(out, lang.PrintString(result))
+					continue
+				}
+			} else {
+				// Drop the rest of the line the error is on.
+				lr.ReadLine()
+			}
+			coreVar("*e").Set(err)
+			fmt.Fprintf(errOut, "Error: %v\n", err)
+		}
+	})
+}
+
+// Prepl runs a REPL reading forms from in and passing its output to
+// outFn as maps tagged :ret, :out, :err or :tap, until in ends or a
+// form evaluates to :repl/quit. *in* is bound to stdin, or to in if
+// stdin is nil. Values in :ret and :tap maps are passed on as they are.
+func Prepl(in io.Reader, outFn lang.IFn, stdin io.Reader) {
+	lr := newLineReader(in)
+	var mu sync.Mutex
+	send := func(m lang.IPersistentMap) {
+		mu.Lock()
+		defer mu.Unlock()
+		outFn.Invoke(m)
+	}
+	outWriter := &taggedWriter{tag: kwOut, send: send}
+	errWriter := &taggedWriter{tag: kwErr, send: send}
+	var inReader any = lr
+	if stdin != nil {
+		inReader = stdin
+	}
+
+	withREPLBindings(lr, inReader, outWriter, errWriter, func(env lang.Environment, rdr *reader.Reader) {
+		if addTap := coreVar("add-tap"); addTap != nil {
+			tapFn := lang.FnFunc1(func(v any) any {
+				send(lang.NewMap(kwTag, kwTap, kwVal, v))
+				return nil
+			})
+			addTap.Invoke(tapFn)
+			defer coreVar("remove-tap").Invoke(tapFn)
+		}
+
+		for {
+			form, source, err := lr.readForm(rdr)
+			if lr.err != nil {
+				return
+			}
+			nsName := func() string { return env.CurrentNamespace().Name().String() }
+			if err != nil {
+				lr.ReadLine()
+				coreVar("*e").Set(err)
+				send(lang.NewMap(
+					kwTag, kwRet,
+					kwVal, errorData(err, kwReadSource),
+					kwNS, nsName(),
+					kwException, true,
+				))
+				continue
+			}
+			lr.skipLineEnd()
+
+			start := time.Now()
+			result, err := eval(env, form)
+			ms := time.Since(start).Milliseconds()
+			outWriter.flush()
+			errWriter.flush()
+			if err != nil {
+				coreVar("*e").Set(err)
+				send(lang.NewMap(
+					kwTag, kwRet,
+					kwVal, errorData(err, kwExecution),
+					kwNS, nsName(),
+					kwMS, ms,
+					kwForm, source,
+					kwException, true,
+				))
+				continue
+			}
+			if result == kwReplQuit {
+				return
+			}
+			setResult(result)
+			val := result
+			if e, ok := result.(error); ok {
+				val = errorData(e, nil)
+			}
+			send(lang.NewMap(
+				kwTag, kwRet,
+				kwVal, val,
+				kwNS, nsName(),
+				kwMS, ms,
+				kwForm, source,
+			))
+		}
+	})
+}
+
+// IOPrepl runs Prepl on in, printing each of its maps to out as a
+// line of EDN. The values of :ret and :tap maps are first converted to
+// strings with valf, pr-str if it is nil.
+func IOPrepl(in io.Reader, out io.Writer, valf lang.IFn) {
+	var mu sync.Mutex
+	Prepl(in, lang.FnFunc1(func(m any) any {
+		msg := m.(lang.IPersistentMap)
+		if tag := lang.Get(msg, kwTag); tag == kwRet || tag == kwTap {
+			str, err := printValue(valf, lang.Get(msg, kwVal))
+			if err != nil {
+				msg = msg.Assoc(kwVal, errorData(err, kwPrintEvalResult)).
+					Assoc(kwException, true).(lang.IPersistentMap)
+			} else {
+				msg = msg.Assoc(kwVal, str).(lang.IPersistentMap)
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		// Output is passed on from inside print calls, which may have
+		// bound *print-readably* to nil.
+		lang.PushThreadBindings(lang.NewMap(lang.VarPrintReadably, true))
+		defer lang.PopThreadBindings()
+		fmt.Fprintln(out, lang.PrintString(msg))
+		return nil
+	}), nil)
+}
+
+func printValue(valf lang.IFn, v any) (s any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = asError(r)
+		}
+	}()
+	if valf == nil {
+		return lang.PrintString(v), nil
+	}
+	return valf.Invoke(v), nil
+}
+
+// RemotePrepl connects to a prepl server at host:port, sends it the
+// text read from in until in ends, and passes the maps the server
+// replies with to outFn. The values of :ret and :tap maps are read
+// with valf, read-string if it is nil. readf reads a reply from the
+// connection given an EOF value to return at its end; by default the
+// replies are read as EDN.
+func RemotePrepl(host string, port int, in io.Reader, outFn, valf, readf lang.IFn) error {
+	conn, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if valf == nil {
+		valf = lang.FnFunc1(func(s any) any {
+			return coreVar("read-string").Invoke(s)
+		})
+	}
+	lr := newLineReader(conn)
+	eof := &struct{}{}
+	if readf == nil {
+		rdr := reader.New(lr)
+		readf = lang.FnFunc2(func(_, eofValue any) any {
+			form, err := rdr.ReadOne()
+			if err != nil {
+				return eofValue
+			}
+			return form
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			// A failing readf or outFn ends the session.
+			if r := recover(); r != nil {
+				conn.Close()
+			}
+		}()
+		for {
+			m := readf.Invoke(lr, eof)
+			if m == eof {
+				return
+			}
+			if tag := lang.Get(m, kwTag); tag == kwRet || tag == kwTap {
+				m = readValue(valf, m.(lang.IPersistentMap))
+			}
+			outFn.Invoke(m)
+		}
+	}()
+
+	_, err = io.Copy(conn, in)
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.CloseWrite()
+	}
+	<-done
+	if errors.Is(err, net.ErrClosed) {
+		err = nil
+	}
+	return err
+}
+
+func readValue(valf lang.IFn, m lang.IPersistentMap) (ret lang.IPersistentMap) {
+	defer func() {
+		if r := recover(); r != nil {
+			ret = m.Assoc(kwVal, errorData(asError(r), kwReadEvalResult)).
+				Assoc(kwException, true).(lang.IPersistentMap)
+		}
+	}()
+	return m.Assoc(kwVal, valf.Invoke(lang.Get(m, kwVal))).(lang.IPersistentMap)
+}
+
+// withREPLBindings runs fn with the bindings of a REPL session, in the
+// user namespace, and a reader for the forms in lr.
+func withREPLBindings(lr *lineReader, in any, out, errOut io.Writer, fn func(env lang.Environment, rdr *reader.Reader)) {
+	initUserNS()
+	kvs := []any{
+		lang.VarCurrentNS, lang.FindNamespace(lang.NewSymbol("user")),
+		lang.VarIn, in,
+		lang.VarOut, out,
+		lang.VarErr, errOut,
+	}
+	for _, name := range replVarNames {
+		if vr := coreVar(name); vr != nil && vr.IsDynamic() {
+			kvs = append(kvs, vr, vr.Deref())
+		}
+	}
+	for _, name := range resultVarNames {
+		if vr := coreVar(name); vr != nil && vr.IsDynamic() {
+			kvs = append(kvs, vr, nil)
+		}
+	}
+	lang.PushThreadBindings(lang.NewMap(kvs...))
+	defer lang.PopThreadBindings()
+
+	env := lang.GlobalEnv
+	fn(env, reader.New(lr,
+		reader.WithFilename("NO_SOURCE_FILE"),
+		reader.WithGetCurrentNS(env.CurrentNamespace),
+	))
+}
+
+// initUserNS ensures the user namespace exists with clojure.core
+// referred, as (ns user) does in a standard REPL.
+func initUserNS() {
+	if lang.FindNamespace(lang.NewSymbol("user")) != nil {
+		return
+	}
+	lang.PushThreadBindings(lang.NewMap(
+		lang.VarCurrentNS, lang.NSCore,
+		lang.VarWarnOnReflection, lang.VarWarnOnReflection.Deref(),
+		lang.VarUncheckedMath, lang.VarUncheckedMath.Deref(),
+		lang.VarDataReaders, lang.VarDataReaders.Deref(),
+	))
+	defer lang.PopThreadBindings()
+	lang.GlobalEnv.Eval(lang.NewList(lang.NewSymbol("ns"), lang.NewSymbol("user")))
+}
+
+func setResult(result any) {
+	star1, star2, star3 := coreVar("*1"), coreVar("*2"), coreVar("*3")
+	star3.Set(star2.Deref())
+	star2.Set(star1.Deref())
+	star1.Set(result)
+}
+
+// eval evaluates form, turning a panic into an error.
+func eval(env lang.Environment, form any) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = asError(r)
+		}
+	}()
+	return env.Eval(form)
+}
+
+// errorData returns a map describing err, Throwable->map's, with
+// phase as :phase unless err records the phase it occurred in.
+func errorData(err error, phase any) lang.IPersistentMap {
+	m := runtime.ThrowableMap(err)
+	if phase != nil && m.ValAt(kwPhase) == nil {
+		m = m.Assoc(kwPhase, phase).(lang.IPersistentMap)
+	}
+	return m
+}
+
+// taggedWriter is the *out* or *err* of a prepl. It passes on output a
+// line at a time, and whatever is left when an evaluation finishes.
+type taggedWriter struct {
+	tag  lang.Keyword
+	send func(lang.IPersistentMap)
+	mu   sync.Mutex
+	buf  strings.Builder
+}
+
+func (w *taggedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.buf.Write(p)
+	flush := strings.ContainsRune(string(p), '\n')
+	w.mu.Unlock()
+	if flush {
+		w.flush()
+	}
+	return len(p), nil
+}
+
+func (w *taggedWriter) flush() {
+	w.mu.Lock()
+	s := w.buf.String()
+	w.buf.Reset()
+	w.mu.Unlock()
+	if s != "" {
+		w.send(lang.NewMap(kwTag, w.tag, kwVal, s))
+	}
+}
+
+// lineReader is the *in* of a REPL. The REPL reads forms from it and
+// the code it evaluates can read lines or bytes from where the form
+// ended.
+type lineReader struct {
+	br *bufio.Reader
+	// err is the I/O error that ended the input, if any.
+	err error
+	// capture holds the runes read while capturing is set.
+	capture   []rune
+	capturing bool
+}
+
+func newLineReader(in io.Reader) *lineReader {
+	if lr, ok := in.(*lineReader); ok {
+		return lr
+	}
+	br, ok := in.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(in)
+	}
+	return &lineReader{br: br}
+}
+
+func (lr *lineReader) ReadRune() (rune, int, error) {
+	r, size, err := lr.br.ReadRune()
+	if err != nil {
+		lr.err = err
+		return r, size, err
+	}
+	if lr.capturing {
+		lr.capture = append(lr.capture, r)
+	}
+	return r, size, nil
+}
+
+func (lr *lineReader) UnreadRune() error {
+	if err := lr.br.UnreadRune(); err != nil {
+		return err
+	}
+	if lr.capturing && len(lr.capture) > 0 {
+		lr.capture = lr.capture[:len(lr.capture)-1]
+	}
+	return nil
+}
+
+func (lr *lineReader) Read(p []byte) (int, error) {
+	return lr.br.Read(p)
+}
+
+// ReadLine implements read-line. It returns the next line without its
+// line terminator, or nil at end of file.
+func (lr *lineReader) ReadLine() any {
+	line, err := lr.br.ReadString('\n')
+	if err != nil && line == "" {
+		return nil
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
+
+// readForm reads the next form with rdr, returning it with its source
+// text. The input has ended if lr.err is set afterwards.
+func (lr *lineReader) readForm(rdr *reader.Reader) (form any, source string, err error) {
+	lr.capture = lr.capture[:0]
+	lr.capturing = true
+	defer func() {
+		lr.capturing = false
+		if r := recover(); r != nil {
+			err = asError(r)
+		}
+		source = strings.TrimSpace(string(lr.capture))
+	}()
+	form, err = rdr.ReadOne()
+	return form, source, err
+}
+
+// skipLineEnd consumes the whitespace after a form up to the end of
+// its line, so that code reading *in* starts on the next line.
+func (lr *lineReader) skipLineEnd() {
+	for {
+		r, _, err := lr.br.ReadRune()
+		if err != nil {
+			return
+		}
+		if r == '\n' {
+			return
+		}
+		if !unicode.IsSpace(r) && r != ',' {
+			lr.br.UnreadRune()
+			return
+		}
+	}
+}
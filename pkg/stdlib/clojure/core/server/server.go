@@ -0,0 +1,370 @@
+// Package server implements the socket servers of clojure.core.server.
+// A server runs an accept function on each connection, with *in*, *out*
+// and *err* bound to the socket; Repl and Prepl are the usual accept
+// functions. The functions are published through pkgmap for
+// clojure/core/server.glj, which wraps them.
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+	"github.com/glojurelang/glojure/pkg/reader"
+)
+
+const pkg = "github.com/glojurelang/glojure/pkg/stdlib/clojure/core/server"
+
+// EnvPrefix starts the names of environment variables configuring the
+// servers StartFromEnv starts, the equivalent of Clojure's
+// clojure.server.* system properties. GLJ_SERVER_REPL='{:port 5555
+// :accept clojure.core.server/repl}' starts a server named "repl".
+const EnvPrefix = "GLJ_SERVER_"
+
+// Config describes a socket server.
+type Config struct {
+	// Name identifies the server to Stop. Servers without a name are
+	// not registered, and are stopped only with Close.
+	Name string
+	// Address is the host to listen on, 127.0.0.1 by default.
+	Address string
+	// Port is the TCP port to listen on, or 0 for any free port.
+	Port int
+	// Accept names the function run for each connection, which is
+	// called with Args.
+	Accept *lang.Symbol
+	Args   []any
+	// BindErr binds *err* to the connection as well as *out*.
+	BindErr bool
+}
+
+// A Server accepts connections and runs its accept function on each.
+type Server struct {
+	cfg      Config
+	listener net.Listener
+
+	mu      sync.Mutex
+	conns   map[net.Conn]struct{}
+	clients int
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+var servers = struct {
+	sync.Mutex
+	byName map[string]*Server
+}{byName: map[string]*Server{}}
+
+var (
+	kwName     = lang.NewKeyword("name")
+	kwAddress  = lang.NewKeyword("address")
+	kwPort     = lang.NewKeyword("port")
+	kwAccept   = lang.NewKeyword("accept")
+	kwArgs     = lang.NewKeyword("args")
+	kwBindErr  = lang.NewKeyword("bind-err")
+	kwServer   = lang.NewKeyword("server")
+	kwClient   = lang.NewKeyword("client")
+	symSession = lang.NewSymbol("clojure.core.server/*session*")
+)
+
+func init() {
+	for name, fn := range map[string]any{
+		"StartServer": StartServer,
+		"Stop":        Stop,
+		"StopAll":     StopAll,
+		"Repl":        Repl,
+		"Prepl":       Prepl,
+		"IOPrepl":     IOPrepl,
+		"RemotePrepl": RemotePrepl,
+	} {
+		pkgmap.Set(pkg+"."+name, fn)
+	}
+}
+
+// Start listens as cfg describes and serves connections until the
+// server is closed.
+func Start(cfg Config) (*Server, error) {
+	if cfg.Accept == nil || !cfg.Accept.HasNamespace() {
+		return nil, fmt.Errorf("server %q: accept must be a namespace-qualified symbol", cfg.Name)
+	}
+	if cfg.Address == "" {
+		cfg.Address = "127.0.0.1"
+	}
+	s := &Server{cfg: cfg, conns: map[net.Conn]struct{}{}}
+
+	if cfg.Name != "" {
+		servers.Lock()
+		defer servers.Unlock()
+		if _, ok := servers.byName[cfg.Name]; ok {
+			return nil, fmt.Errorf("server %q already exists", cfg.Name)
+		}
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port)))
+	if err != nil {
+		return nil, fmt.Errorf("server %q: %w", cfg.Name, err)
+	}
+	s.listener = ln
+	if cfg.Name != "" {
+		servers.byName[cfg.Name] = s
+	}
+	go s.serve()
+	return s, nil
+}
+
+// StartServer starts a server configured by a map with the keys of
+// clojure.core.server/start-server.
+func StartServer(opts lang.IPersistentMap) (*Server, error) {
+	cfg := Config{
+		Name:    lang.ToString(lang.Get(opts, kwName)),
+		BindErr: true,
+	}
+	if lang.Get(opts, kwName) == nil {
+		return nil, fmt.Errorf("start-server: :name is required")
+	}
+	if addr, ok := lang.Get(opts, kwAddress).(string); ok {
+		cfg.Address = addr
+	}
+	if port := lang.Get(opts, kwPort); port != nil {
+		p, ok := lang.AsInt(port)
+		if !ok {
+			return nil, fmt.Errorf("start-server: :port must be an integer, got %v", lang.PrintString(port))
+		}
+		cfg.Port = p
+	}
+	accept, ok := lang.Get(opts, kwAccept).(*lang.Symbol)
+	if !ok {
+		return nil, fmt.Errorf("start-server: :accept must be a symbol")
+	}
+	cfg.Accept = accept
+	for seq := lang.Seq(lang.Get(opts, kwArgs)); seq != nil; seq = seq.Next() {
+		cfg.Args = append(cfg.Args, seq.First())
+	}
+	if bindErr, ok := lang.Get(opts, kwBindErr).(bool); ok {
+		cfg.BindErr = bindErr
+	}
+	return Start(cfg)
+}
+
+// StartFromEnv starts a server for each EnvPrefix variable in environ,
+// a list of KEY=VALUE pairs as returned by os.Environ. The rest of the
+// variable name, lower-cased with underscores turned into dashes, names
+// the server; the value is an EDN map of start-server options.
+func StartFromEnv(environ []string) error {
+	var names []string
+	opts := map[string]string{}
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, EnvPrefix) || len(key) == len(EnvPrefix) {
+			continue
+		}
+		name := strings.ReplaceAll(strings.ToLower(key[len(EnvPrefix):]), "_", "-")
+		names = append(names, name)
+		opts[name] = value
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m, err := readOptions(opts[name])
+		if err != nil {
+			return fmt.Errorf("%s%s: %w", EnvPrefix, strings.ToUpper(strings.ReplaceAll(name, "-", "_")), err)
+		}
+		if _, err := StartServer(m.Assoc(kwName, name).(lang.IPersistentMap)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readOptions(s string) (m lang.IPersistentMap, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	form, err := reader.New(strings.NewReader(s)).ReadOne()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := form.(lang.IPersistentMap)
+	if !ok {
+		return nil, fmt.Errorf("expected a map of server options, got %s", lang.PrintString(form))
+	}
+	return m, nil
+}
+
+// Stop closes the server named name, reporting whether there was one.
+func Stop(name string) bool {
+	servers.Lock()
+	s := servers.byName[name]
+	servers.Unlock()
+	if s == nil {
+		return false
+	}
+	s.Close()
+	return true
+}
+
+// StopAll closes every named server.
+func StopAll() {
+	servers.Lock()
+	all := make([]*Server, 0, len(servers.byName))
+	for _, s := range servers.byName {
+		all = append(all, s)
+	}
+	servers.Unlock()
+	for _, s := range all {
+		s.Close()
+	}
+}
+
+// Name returns the name of the server.
+func (s *Server) Name() string {
+	return s.cfg.Name
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Port returns the TCP port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Close stops the server and closes its open connections. It does not
+// wait for their accept functions to return, as it may be called from
+// one of them by stop-server; use Wait for that.
+func (s *Server) Close() error {
+	if s.cfg.Name != "" {
+		servers.Lock()
+		if servers.byName[s.cfg.Name] == s {
+			delete(servers.byName, s.cfg.Name)
+		}
+		servers.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	return err
+}
+
+// Wait waits for the accept functions of a closed server to return.
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "clojure.core.server: %s: accept: %v\n", s.cfg.Name, err)
+			continue
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.clients++
+		client := strconv.Itoa(s.clients)
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			if err := s.accept(conn, client); err != nil {
+				fmt.Fprintf(conn, "%v\n", err)
+			}
+		}()
+	}
+}
+
+// accept runs the accept function on conn, as client, with *in*, *out*
+// and *err* bound to it.
+func (s *Server) accept(conn net.Conn, client string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = asError(r)
+		}
+	}()
+	acceptFn, err := resolve(s.cfg.Accept)
+	if err != nil {
+		return err
+	}
+	session, err := resolve(symSession)
+	if err != nil {
+		return err
+	}
+
+	bindings := lang.NewMap(
+		lang.VarCurrentNS, lang.VarCurrentNS.Deref(),
+		lang.VarIn, newLineReader(conn),
+		lang.VarOut, conn,
+		session, lang.NewMap(kwServer, s.cfg.Name, kwClient, client),
+	)
+	if s.cfg.BindErr {
+		bindings = bindings.Assoc(lang.VarErr, conn).(lang.IPersistentMap)
+	}
+	lang.PushThreadBindings(bindings)
+	defer lang.PopThreadBindings()
+	lang.Apply(acceptFn, s.cfg.Args)
+	return nil
+}
+
+// resolve returns the var named by the namespace-qualified sym,
+// requiring its namespace first.
+func resolve(sym *lang.Symbol) (*lang.Var, error) {
+	nsSym := lang.NewSymbol(sym.Namespace())
+	if lang.FindNamespace(nsSym) == nil {
+		coreVar("require").Invoke(nsSym)
+	}
+	ns := lang.FindNamespace(nsSym)
+	if ns == nil {
+		return nil, fmt.Errorf("namespace %s not found", nsSym)
+	}
+	vr := ns.FindInternedVar(lang.NewSymbol(sym.Name()))
+	if vr == nil {
+		return nil, fmt.Errorf("%s is not defined", sym)
+	}
+	return vr, nil
+}
+
+func coreVar(name string) *lang.Var {
+	return lang.NSCore.FindInternedVar(lang.NewSymbol(name))
+}
+
+// asError turns a recovered panic into an error.
+func asError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
@@ -0,0 +1,188 @@
+package server_test
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/stdlib/clojure/core/server"
+)
+
+var (
+	kwTag       = lang.NewKeyword("tag")
+	kwVal       = lang.NewKeyword("val")
+	kwForm      = lang.NewKeyword("form")
+	kwNS        = lang.NewKeyword("ns")
+	kwException = lang.NewKeyword("exception")
+)
+
+// collect returns an out-fn for a prepl and a function returning the
+// maps passed to it so far.
+func collect() (lang.IFn, func() []lang.IPersistentMap) {
+	var (
+		mu   sync.Mutex
+		msgs []lang.IPersistentMap
+	)
+	return lang.FnFunc1(func(m any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			msgs = append(msgs, m.(lang.IPersistentMap))
+			return nil
+		}), func() []lang.IPersistentMap {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]lang.IPersistentMap(nil), msgs...)
+		}
+}
+
+func tagged(msgs []lang.IPersistentMap, tag string) []lang.IPersistentMap {
+	var out []lang.IPersistentMap
+	for _, m := range msgs {
+		if lang.Get(m, kwTag) == lang.NewKeyword(tag) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func TestPrepl(t *testing.T) {
+	outFn, msgs := collect()
+	server.Prepl(strings.NewReader(`(+ 1 2) (println "hi")
+(read-line)
+a line of input
+(in-ns 'prepl.test) (clojure.core// 1 0)
+)
+:repl/quit
+(+ 3 4)
+`), outFn, nil)
+
+	rets := tagged(msgs(), "ret")
+	if len(rets) != 6 {
+		t.Fatalf("got %d :ret messages, want 6: %v", len(rets), msgs())
+	}
+	if v := lang.Get(rets[0], kwVal); !lang.Equals(v, int64(3)) || lang.Get(rets[0], kwForm) != "(+ 1 2)" {
+		t.Errorf("first :ret = %v", lang.PrintString(rets[0]))
+	}
+	if v := lang.Get(rets[2], kwVal); v != "a line of input" {
+		t.Errorf("(read-line) = %v, want the line after the form", lang.PrintString(v))
+	}
+	failed := rets[4]
+	if lang.Get(failed, kwException) != true || lang.Get(failed, kwNS) != "prepl.test" {
+		t.Errorf("failed eval :ret = %v", lang.PrintString(failed))
+	}
+	if phase := lang.Get(lang.Get(failed, kwVal), lang.NewKeyword("phase")); phase != lang.NewKeyword("execution") {
+		t.Errorf("failed eval phase = %v", phase)
+	}
+	if phase := lang.Get(lang.Get(rets[5], kwVal), lang.NewKeyword("phase")); phase != lang.NewKeyword("read-source") {
+		t.Errorf("read error phase = %v", phase)
+	}
+
+	outs := tagged(msgs(), "out")
+	if len(outs) != 1 || lang.Get(outs[0], kwVal) != "hi\n" {
+		t.Errorf(":out messages = %v", outs)
+	}
+}
+
+// startServer starts a server with accept, returning a connection to
+// it.
+func startServer(t *testing.T, name, accept string) net.Conn {
+	t.Helper()
+	srv, err := server.Start(server.Config{
+		Name:    name,
+		Accept:  lang.NewSymbol(accept),
+		BindErr: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	return conn
+}
+
+func TestReplServer(t *testing.T) {
+	conn := startServer(t, "test-repl", "clojure.core.server/repl")
+	io.WriteString(conn, "(+ 1\n 2)\n(:server clojure.core.server/*session*)\n(/ 1 0)\n:repl/quit\n")
+	out, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "user=> 3\nuser=> \"test-repl\"\nuser=> Error: "
+	if !strings.HasPrefix(string(out), want) || !strings.HasSuffix(string(out), "user=> ") {
+		t.Errorf("output = %q, want it to start with %q", out, want)
+	}
+}
+
+func TestRemotePrepl(t *testing.T) {
+	srv, err := server.Start(server.Config{
+		Name:   "test-io-prepl",
+		Accept: lang.NewSymbol("clojure.core.server/io-prepl"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	outFn, msgs := collect()
+	err = server.RemotePrepl("127.0.0.1", srv.Port(), strings.NewReader("(println \"hi\")\n[1 :a \"b\"]\n"), outFn, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rets := tagged(msgs(), "ret")
+	if len(rets) != 2 {
+		t.Fatalf("got %d :ret messages, want 2: %v", len(rets), msgs())
+	}
+	if v := lang.Get(rets[1], kwVal); !lang.Equals(v, lang.NewVector(int64(1), lang.NewKeyword("a"), "b")) {
+		t.Errorf(":val = %v, want the value read back", lang.PrintString(v))
+	}
+	if outs := tagged(msgs(), "out"); len(outs) != 1 || lang.Get(outs[0], kwVal) != "hi\n" {
+		t.Errorf(":out messages = %v", outs)
+	}
+}
+
+func TestStartFromEnvAndStop(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	err = server.StartFromEnv([]string{
+		"HOME=/tmp",
+		"GLJ_SERVER_ENV_REPL={:port " + strconv.Itoa(port) + " :accept clojure.core.server/repl}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	io.WriteString(conn, "(clojure.core.server/stop-server)\n")
+	// Stopping the server from one of its connections closes it
+	// before true can be printed.
+	if out, err := io.ReadAll(conn); err != nil || string(out) != "user=> " {
+		t.Errorf("read %q, %v", out, err)
+	}
+	if server.Stop("env-repl") {
+		t.Error("env-repl still running after stop-server")
+	}
+
+	if err := server.StartFromEnv([]string{"GLJ_SERVER_BAD=[]"}); err == nil {
+		t.Error("StartFromEnv accepted a vector of options")
+	}
+}
@@ -139,13 +139,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -337,6 +337,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -411,38 +412,58 @@ func LoadNS() {
 		})
 	}
 	// blank?
+
+//line ../../clojure/string.glj:237:7
 	{
 		tmp0 := sym_blank_QMARK_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:241:3
 			var tmp3 any
 			if lang.IsTruthy(v2) {
+//line ../../clojure/string.glj:242:5
 				var tmp4 any
 				{ // let
 					// let binding "index"
+
+//line ../../clojure/string.glj:242:18
 					tmp5 := runtime.RT.IntCast(int64(0))
+//line ../../clojure/string.glj:242:5
 					var v6 any = tmp5
 					_ = v6
 					for {
+//line ../../clojure/string.glj:243:7
 						var tmp7 any
+//line ../../clojure/string.glj:243:14
 						tmp8 := lang.Count(v2)
+//line ../../clojure/string.glj:243:11
 						tmp9 := aotExternalFn0(tmp8, v6)
+//line ../../clojure/string.glj:243:7
 						if lang.IsTruthy(tmp9) {
 							tmp7 = true
 						} else {
+//line ../../clojure/string.glj:245:9
 							var tmp10 any
+//line ../../clojure/string.glj:245:13
 							var tmp11 any
 							{ // let
 								// let binding "c"
+
+//line ../../clojure/string.glj:245:21
 								tmp12 := lang.Apply2(lang.CharAt, v2, v6)
+//line ../../clojure/string.glj:245:13
 								var v13 any = tmp12
 								_ = v13
+//line ../../clojure/string.glj:246:15
 								var tmp14 any
 								{ // let
 									// let binding "and__0__auto__"
+
+//line ../../clojure/string.glj:246:20
 									tmp15 := lang.Apply1(unicode4.IsSpace, v13)
+//line ../../clojure/string.glj:246:15
 									var v16 any = tmp15
 									_ = v16
 									var tmp17 any
@@ -450,8 +471,12 @@ func LoadNS() {
 										var tmp18 any
 										{ // let
 											// let binding "and__0__auto__"
+
+//line ../../clojure/string.glj:247:26
 											tmp19 := runtime.RT.IntCast(v13)
+//line ../../clojure/string.glj:247:20
 											tmp20 := aotExternalFn2(tmp19, int64(160))
+//line ../../clojure/string.glj:246:15
 											var v21 any = tmp20
 											_ = v21
 											var tmp22 any
@@ -459,14 +484,21 @@ func LoadNS() {
 												var tmp23 any
 												{ // let
 													// let binding "and__0__auto__"
+
+//line ../../clojure/string.glj:248:26
 													tmp24 := runtime.RT.IntCast(v13)
+//line ../../clojure/string.glj:248:20
 													tmp25 := aotExternalFn2(tmp24, int64(8199))
+//line ../../clojure/string.glj:246:15
 													var v26 any = tmp25
 													_ = v26
 													var tmp27 any
 													if lang.IsTruthy(v26) {
+//line ../../clojure/string.glj:249:26
 														tmp28 := runtime.RT.IntCast(v13)
+//line ../../clojure/string.glj:249:20
 														tmp29 := aotExternalFn2(tmp28, int64(8239))
+//line ../../clojure/string.glj:246:15
 														tmp27 = tmp29
 													} else {
 														tmp27 = v26
@@ -485,26 +517,35 @@ func LoadNS() {
 									}
 									tmp14 = tmp17
 								} // end let
+//line ../../clojure/string.glj:245:13
 								tmp11 = tmp14
 							} // end let
+//line ../../clojure/string.glj:245:9
 							if lang.IsTruthy(tmp11) {
+//line ../../clojure/string.glj:250:18
 								tmp13 := lang.Numbers.Inc(v6)
+//line ../../clojure/string.glj:250:11
 								var tmp12 any = tmp13
 								v6 = tmp12
 								continue
+//line ../../clojure/string.glj:245:9
 							} else {
 								tmp10 = false
 							}
+//line ../../clojure/string.glj:243:7
 							tmp7 = tmp10
 						}
+//line ../../clojure/string.glj:242:5
 						tmp4 = tmp7
 						break
 					}
 				} // end let
+//line ../../clojure/string.glj:241:3
 				tmp3 = tmp4
 			} else {
 				tmp3 = true
 			}
+//line ../../clojure/string.glj:237:7
 			return tmp3
 		})
 		aotDirectFn0 = tmp1
@@ -513,36 +554,58 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(237), kw_column, int(7), kw_end_DASH_line, int(237), kw_end_DASH_column, int(12), kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "True if s is nil, empty, or contains only whitespace.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:558
 	// capitalize
+
+//line ../../clojure/string.glj:172:7
 	{
 		tmp0 := sym_capitalize
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:177:3
 			var tmp3 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:177:27
 				tmp4 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:177:11
 				tmp5 := aotExternalFn3(tmp4)
+//line ../../clojure/string.glj:177:3
 				var v6 any = tmp5
 				_ = v6
+//line ../../clojure/string.glj:178:5
 				var tmp7 any
+//line ../../clojure/string.glj:178:12
 				tmp8 := lang.Count(v6)
+//line ../../clojure/string.glj:178:9
 				tmp9 := lang.Numbers.Lt(tmp8, int64(2))
+//line ../../clojure/string.glj:178:5
 				if lang.IsTruthy(tmp9) {
+//line ../../clojure/string.glj:179:7
 					tmp10 := lang.Apply1(strings5.ToUpper, v6)
+//line ../../clojure/string.glj:178:5
 					tmp7 = tmp10
 				} else {
+//line ../../clojure/string.glj:180:29
 					tmp11 := aotExternalFn5(v6, int64(0), int64(1))
+//line ../../clojure/string.glj:180:12
 					tmp12 := lang.Apply1(strings5.ToUpper, tmp11)
+//line ../../clojure/string.glj:181:29
 					tmp13 := aotExternalFn6(v6, int64(1))
+//line ../../clojure/string.glj:181:12
 					tmp14 := lang.Apply1(strings5.ToLower, tmp13)
+//line ../../clojure/string.glj:180:7
 					tmp15 := aotExternalFn4(tmp12, tmp14)
+//line ../../clojure/string.glj:178:5
 					tmp7 = tmp15
 				}
+//line ../../clojure/string.glj:177:3
 				tmp3 = tmp7
 			} // end let
+//line ../../clojure/string.glj:172:7
 			return tmp3
 		})
 		aotDirectFn1 = tmp1
@@ -552,21 +615,31 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(172), kw_column, int(7), kw_end_DASH_line, int(172), kw_end_DASH_column, int(27), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Converts first character of the string to upper-case, all other\n  characters to lower-case.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:619
 	// check-string
+
+//line ../../clojure/string.glj:17:8
 	{
 		tmp0 := sym_check_DASH_string
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:19:3
 			var tmp3 any
+//line ../../clojure/string.glj:19:7
 			tmp4 := lang.Identical(v2, nil)
+//line ../../clojure/string.glj:19:3
 			if lang.IsTruthy(tmp4) {
+//line ../../clojure/string.glj:20:12
 				tmp5 := lang.Apply1(lang.NewIllegalArgumentError, "cannot call clojure.string function on nil")
+//line ../../clojure/string.glj:20:5
 				panic(tmp5)
+//line ../../clojure/string.glj:19:3
 			} else {
 				tmp3 = v2
 			}
+//line ../../clojure/string.glj:17:8
 			return tmp3
 		})
 		aotDirectFn2 = tmp1
@@ -575,7 +648,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(17), kw_column, int(8), kw_end_DASH_line, int(17), kw_end_DASH_column, int(19), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_maybe_DASH_s)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:652
 	// ends-with?
+
+//line ../../clojure/string.glj:322:7
 	{
 		tmp0 := sym_ends_DASH_with_QMARK_
 		var tmp1 lang.FnFunc2
@@ -584,16 +660,26 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:326:3
 			var tmp4 any
+//line ../../clojure/string.glj:326:7
 			tmp5 := lang.Identical(v2, nil)
+//line ../../clojure/string.glj:326:3
 			if lang.IsTruthy(tmp5) {
+//line ../../clojure/string.glj:327:12
 				tmp6 := lang.Apply1(lang.NewError, "argument to ends-with? must not be nil")
+//line ../../clojure/string.glj:327:5
 				panic(tmp6)
+//line ../../clojure/string.glj:326:3
 			} else {
+//line ../../clojure/string.glj:328:24
 				tmp7 := lang.ToString(v2)
+//line ../../clojure/string.glj:328:5
 				tmp8 := lang.Apply2(strings5.HasSuffix, tmp7, v3)
+//line ../../clojure/string.glj:326:3
 				tmp4 = tmp8
 			}
+//line ../../clojure/string.glj:322:7
 			return tmp4
 		})
 		aotDirectFn3 = tmp1
@@ -602,7 +688,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(322), kw_column, int(7), kw_end_DASH_line, int(322), kw_end_DASH_column, int(16), kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_substr)), kw_doc, "True if s ends with substr.", kw_added, "1.8", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:692
 	// escape
+
+//line ../../clojure/string.glj:254:7
 	{
 		tmp0 := sym_escape
 		var tmp1 lang.FnFunc2
@@ -611,21 +700,33 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:262:3
 			var tmp4 any
+//line ../../clojure/string.glj:262:9
 			tmp5 := lang.Identical(v2, nil)
+//line ../../clojure/string.glj:262:3
 			if lang.IsTruthy(tmp5) {
+//line ../../clojure/string.glj:263:12
 				tmp6 := lang.Apply1(lang.NewError, "s must not be nil")
+//line ../../clojure/string.glj:263:5
 				panic(tmp6)
+//line ../../clojure/string.glj:262:3
 			} else {
 			}
+//line ../../clojure/string.glj:254:7
 			_ = tmp4
+//line ../../clojure/string.glj:264:3
 			var tmp7 any
 			{ // let
 				// let binding "sb"
+
+//line ../../clojure/string.glj:264:12
 				tmp8 := reflect.TypeOf((*strings5.Builder)(nil)).Elem()
 				tmp9 := lang.NewHostInstance(tmp8)
+//line ../../clojure/string.glj:264:3
 				var v10 any = tmp9
 				_ = v10
+//line ../../clojure/string.glj:265:5
 				var tmp11 any
 				{ // let
 					// let binding "seq_732"
@@ -648,34 +749,46 @@ func LoadNS() {
 							var tmp19 any
 							{ // let
 								// let binding "c"
-								tmp20 := v14.(interface{ Nth(int) any }).Nth(lang.IntCast(v16))
+								tmp20 := any(v14).(interface{ Nth(int) any }).Nth(lang.IntCast(v16))
 								var v21 any = tmp20
 								_ = v21
+//line ../../clojure/string.glj:266:7
 								var tmp22 any
 								{ // let
 									// let binding "replacement"
+
+//line ../../clojure/string.glj:266:25
 									tmp23 := runtime.RT.Get(v3, v21)
+//line ../../clojure/string.glj:266:7
 									var v24 any = tmp23
 									_ = v24
+//line ../../clojure/string.glj:267:9
 									var tmp25 any
 									if lang.IsTruthy(v24) {
+//line ../../clojure/string.glj:268:28
 										tmp26 := aotExternalFn3(v24)
+//line ../../clojure/string.glj:268:11
 										tmp27, _ := lang.FieldOrMethod(v10, "WriteString")
 										if reflect.TypeOf(tmp27).Kind() != reflect.Func {
 											panic(lang.NewIllegalArgumentError(fmt.Sprintf("WriteString is not a function")))
 										}
 										tmp28 := lang.Apply1(tmp27, tmp26)
+//line ../../clojure/string.glj:267:9
 										tmp25 = tmp28
 									} else {
+//line ../../clojure/string.glj:269:11
 										tmp29, _ := lang.FieldOrMethod(v10, "WriteRune")
 										if reflect.TypeOf(tmp29).Kind() != reflect.Func {
 											panic(lang.NewIllegalArgumentError(fmt.Sprintf("WriteRune is not a function")))
 										}
 										tmp30 := lang.Apply1(tmp29, v21)
+//line ../../clojure/string.glj:267:9
 										tmp25 = tmp30
 									}
+//line ../../clojure/string.glj:266:7
 									tmp22 = tmp25
 								} // end let
+//line ../../clojure/string.glj:265:5
 								_ = tmp22
 								var tmp23 any = v13
 								var tmp24 any = v14
@@ -734,31 +847,43 @@ func LoadNS() {
 												tmp30 := lang.First(v25)
 												var v31 any = tmp30
 												_ = v31
+//line ../../clojure/string.glj:266:7
 												var tmp32 any
 												{ // let
 													// let binding "replacement"
+
+//line ../../clojure/string.glj:266:25
 													tmp33 := runtime.RT.Get(v3, v31)
+//line ../../clojure/string.glj:266:7
 													var v34 any = tmp33
 													_ = v34
+//line ../../clojure/string.glj:267:9
 													var tmp35 any
 													if lang.IsTruthy(v34) {
+//line ../../clojure/string.glj:268:28
 														tmp36 := aotExternalFn3(v34)
+//line ../../clojure/string.glj:268:11
 														tmp37, _ := lang.FieldOrMethod(v10, "WriteString")
 														if reflect.TypeOf(tmp37).Kind() != reflect.Func {
 															panic(lang.NewIllegalArgumentError(fmt.Sprintf("WriteString is not a function")))
 														}
 														tmp38 := lang.Apply1(tmp37, tmp36)
+//line ../../clojure/string.glj:267:9
 														tmp35 = tmp38
 													} else {
+//line ../../clojure/string.glj:269:11
 														tmp39, _ := lang.FieldOrMethod(v10, "WriteRune")
 														if reflect.TypeOf(tmp39).Kind() != reflect.Func {
 															panic(lang.NewIllegalArgumentError(fmt.Sprintf("WriteRune is not a function")))
 														}
 														tmp40 := lang.Apply1(tmp39, v31)
+//line ../../clojure/string.glj:267:9
 														tmp35 = tmp40
 													}
+//line ../../clojure/string.glj:266:7
 													tmp32 = tmp35
 												} // end let
+//line ../../clojure/string.glj:265:5
 												_ = tmp32
 												tmp34 := lang.Next(v25)
 												var tmp33 any = tmp34
@@ -786,20 +911,14 @@ func LoadNS() {
 						break
 					}
 				} // end let
+//line ../../clojure/string.glj:264:3
 				_ = tmp11
-				tmp12, ok := lang.FieldOrMethod(v10, "String")
-				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v10, "String")))
-				}
-				var tmp13 any
-				switch reflect.TypeOf(tmp12).Kind() {
-				case reflect.Func:
-					tmp13 = lang.Apply(tmp12, nil)
-				default:
-					tmp13 = tmp12
-				}
-				tmp7 = tmp13
+//line ../../clojure/string.glj:270:5
+				tmp12 := any(v10).(interface{ String() string }).String()
+//line ../../clojure/string.glj:264:3
+				tmp7 = tmp12
 			} // end let
+//line ../../clojure/string.glj:254:7
 			return tmp7
 		})
 		aotDirectFn4 = tmp1
@@ -809,7 +928,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(254), kw_column, int(7), kw_end_DASH_line, int(254), kw_end_DASH_column, int(23), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_cmap)), kw_doc, "Return a new string, using cmap to escape each character ch\n   from s as follows:\n\n   If (cmap ch) is nil, append ch to the new string.\n   If (cmap ch) is non-nil, append (str (cmap ch)) instead.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:932
 	// includes?
+
+//line ../../clojure/string.glj:330:7
 	{
 		tmp0 := sym_includes_QMARK_
 		var tmp1 lang.FnFunc2
@@ -818,8 +940,11 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:334:21
 			tmp4 := lang.ToString(v2)
+//line ../../clojure/string.glj:334:3
 			tmp5 := lang.Apply2(strings5.Contains, tmp4, v3)
+//line ../../clojure/string.glj:330:7
 			return tmp5
 		})
 		aotDirectFn5 = tmp1
@@ -828,7 +953,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(330), kw_column, int(7), kw_end_DASH_line, int(330), kw_end_DASH_column, int(15), kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_substr)), kw_doc, "True if s includes substr.", kw_added, "1.8", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:957
 	// index-of
+
+//line ../../clojure/string.glj:272:7
 	{
 		tmp0 := sym_index_DASH_of
 		var tmp1 lang.ArityFn
@@ -837,34 +965,55 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:277:3
 			var tmp4 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:277:11
 				tmp5 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:277:3
 				var v6 any = tmp5
 				_ = v6
 				// let binding "result"
+
+//line ../../clojure/string.glj:278:16
 				var tmp7 any
+//line ../../clojure/string.glj:279:13
 				tmp8 := lang.IsInstance[lang.Char](v3)
+//line ../../clojure/string.glj:278:16
 				if lang.IsTruthy(tmp8) {
+//line ../../clojure/string.glj:280:32
 					tmp9 := lang.Apply1(lang.Builtins["rune"], v3)
+//line ../../clojure/string.glj:280:11
 					tmp10 := lang.Apply2(strings5.IndexRune, v6, tmp9)
+//line ../../clojure/string.glj:278:16
 					tmp7 = tmp10
 				} else {
+//line ../../clojure/string.glj:281:11
 					tmp11 := lang.Apply2(strings5.Index, v6, v3)
+//line ../../clojure/string.glj:278:16
 					tmp7 = tmp11
 				}
+//line ../../clojure/string.glj:277:3
 				var v12 any = tmp7
 				_ = v12
+//line ../../clojure/string.glj:282:5
 				var tmp13 any
+//line ../../clojure/string.glj:282:9
 				tmp14 := aotExternalFn0(v12, int64(-1))
+//line ../../clojure/string.glj:282:5
 				if lang.IsTruthy(tmp14) {
 				} else {
+//line ../../clojure/string.glj:284:7
 					tmp15 := lang.Apply1(lang.Builtins["int64"], v12)
+//line ../../clojure/string.glj:282:5
 					tmp13 = tmp15
 				}
+//line ../../clojure/string.glj:277:3
 				tmp4 = tmp13
 			} // end let
+//line ../../clojure/string.glj:272:7
 			return tmp4
 		})
 		aotDirectFn6Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -874,42 +1023,70 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:286:3
 			var tmp5 any
 			{ // let
 				// let binding "from-index"
+
+//line ../../clojure/string.glj:286:25
 				tmp6 := lang.Count(v2)
+//line ../../clojure/string.glj:286:42
 				tmp7 := aotExternalFn14(v4)
+//line ../../clojure/string.glj:286:35
 				tmp8 := lang.Numbers.Max(int64(0), tmp7)
+//line ../../clojure/string.glj:286:20
 				tmp9 := lang.Numbers.Min(tmp6, tmp8)
+//line ../../clojure/string.glj:286:3
 				var v10 any = tmp9
 				_ = v10
 				// let binding "s"
+
+//line ../../clojure/string.glj:287:17
 				tmp11 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:287:11
 				tmp12 := aotExternalFn6(tmp11, v10)
+//line ../../clojure/string.glj:286:3
 				var v13 any = tmp12
 				_ = v13
 				// let binding "result"
+
+//line ../../clojure/string.glj:288:16
 				var tmp14 any
+//line ../../clojure/string.glj:289:13
 				tmp15 := lang.IsInstance[lang.Char](v3)
+//line ../../clojure/string.glj:288:16
 				if lang.IsTruthy(tmp15) {
+//line ../../clojure/string.glj:290:32
 					tmp16 := lang.Apply1(lang.Builtins["rune"], v3)
+//line ../../clojure/string.glj:290:11
 					tmp17 := lang.Apply2(strings5.IndexRune, v13, tmp16)
+//line ../../clojure/string.glj:288:16
 					tmp14 = tmp17
 				} else {
+//line ../../clojure/string.glj:291:11
 					tmp18 := lang.Apply2(strings5.Index, v13, v3)
+//line ../../clojure/string.glj:288:16
 					tmp14 = tmp18
 				}
+//line ../../clojure/string.glj:286:3
 				var v19 any = tmp14
 				_ = v19
+//line ../../clojure/string.glj:292:5
 				var tmp20 any
+//line ../../clojure/string.glj:292:9
 				tmp21 := aotExternalFn0(v19, int64(-1))
+//line ../../clojure/string.glj:292:5
 				if lang.IsTruthy(tmp21) {
 				} else {
+//line ../../clojure/string.glj:294:7
 					tmp22 := lang.Numbers.Add(v19, v10)
+//line ../../clojure/string.glj:292:5
 					tmp20 = tmp22
 				}
+//line ../../clojure/string.glj:286:3
 				tmp5 = tmp20
 			} // end let
+//line ../../clojure/string.glj:272:7
 			return tmp5
 		})
 		tmp1 = lang.NewArityFn(
@@ -927,7 +1104,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(272), kw_column, int(7), kw_end_DASH_line, int(272), kw_end_DASH_column, int(14), kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_value), lang.NewVector(sym_s, sym_value, sym_from_DASH_index)), kw_doc, "Return index of value (string or char) in s, optionally searching\n  forward from from-index. Return nil if value not found.", kw_added, "1.8", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1108
 	// last-index-of
+
+//line ../../clojure/string.glj:296:7
 	{
 		tmp0 := sym_last_DASH_index_DASH_of
 		var tmp1 lang.ArityFn
@@ -936,26 +1116,41 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:301:4
 			var tmp4 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:301:12
 				tmp5 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:301:4
 				var v6 any = tmp5
 				_ = v6
 				// let binding "result"
+
+//line ../../clojure/string.glj:302:38
 				tmp7 := aotExternalFn3(v3)
+//line ../../clojure/string.glj:302:17
 				tmp8 := lang.Apply2(strings5.LastIndex, v6, tmp7)
+//line ../../clojure/string.glj:301:4
 				var v9 any = tmp8
 				_ = v9
+//line ../../clojure/string.glj:303:6
 				var tmp10 any
+//line ../../clojure/string.glj:303:10
 				tmp11 := aotExternalFn0(v9, int64(-1))
+//line ../../clojure/string.glj:303:6
 				if lang.IsTruthy(tmp11) {
 				} else {
+//line ../../clojure/string.glj:305:8
 					tmp12 := lang.Apply1(lang.Builtins["int64"], v9)
+//line ../../clojure/string.glj:303:6
 					tmp10 = tmp12
 				}
+//line ../../clojure/string.glj:301:4
 				tmp4 = tmp10
 			} // end let
+//line ../../clojure/string.glj:296:7
 			return tmp4
 		})
 		aotDirectFn8Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -965,35 +1160,58 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:307:4
 			var tmp5 any
 			{ // let
 				// let binding "from-index"
+
+//line ../../clojure/string.glj:307:26
 				tmp6 := lang.Count(v2)
+//line ../../clojure/string.glj:307:48
 				tmp7 := aotExternalFn14(v4)
+//line ../../clojure/string.glj:307:43
 				tmp8 := lang.Numbers.Inc(tmp7)
+//line ../../clojure/string.glj:307:36
 				tmp9 := lang.Numbers.Max(int64(0), tmp8)
+//line ../../clojure/string.glj:307:21
 				tmp10 := lang.Numbers.Min(tmp6, tmp9)
+//line ../../clojure/string.glj:307:4
 				var v11 any = tmp10
 				_ = v11
 				// let binding "s"
+
+//line ../../clojure/string.glj:308:18
 				tmp12 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:308:12
 				tmp13 := aotExternalFn5(tmp12, int64(0), v11)
+//line ../../clojure/string.glj:307:4
 				var v14 any = tmp13
 				_ = v14
 				// let binding "result"
+
+//line ../../clojure/string.glj:309:38
 				tmp15 := aotExternalFn3(v3)
+//line ../../clojure/string.glj:309:17
 				tmp16 := lang.Apply2(strings5.LastIndex, v14, tmp15)
+//line ../../clojure/string.glj:307:4
 				var v17 any = tmp16
 				_ = v17
+//line ../../clojure/string.glj:310:6
 				var tmp18 any
+//line ../../clojure/string.glj:310:10
 				tmp19 := aotExternalFn0(v17, int64(-1))
+//line ../../clojure/string.glj:310:6
 				if lang.IsTruthy(tmp19) {
 				} else {
+//line ../../clojure/string.glj:312:8
 					tmp20 := lang.Apply1(lang.Builtins["int64"], v17)
+//line ../../clojure/string.glj:310:6
 					tmp18 = tmp20
 				}
+//line ../../clojure/string.glj:307:4
 				tmp5 = tmp18
 			} // end let
+//line ../../clojure/string.glj:296:7
 			return tmp5
 		})
 		tmp1 = lang.NewArityFn(
@@ -1011,16 +1229,23 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(296), kw_column, int(7), kw_end_DASH_line, int(296), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_value), lang.NewVector(sym_s, sym_value, sym_from_DASH_index)), kw_doc, "Return last index of value (string or char) in s, optionally\n  searching backward from from-index. Return nil if value not found.", kw_added, "1.8", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1233
 	// lower-case
+
+//line ../../clojure/string.glj:189:7
 	{
 		tmp0 := sym_lower_DASH_case
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:193:36
 			tmp3 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:193:20
 			tmp4 := aotExternalFn3(tmp3)
+//line ../../clojure/string.glj:193:3
 			tmp5 := lang.Apply1(strings5.ToLower, tmp4)
+//line ../../clojure/string.glj:189:7
 			return tmp5
 		})
 		aotDirectFn9 = tmp1
@@ -1030,15 +1255,21 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(189), kw_column, int(7), kw_end_DASH_line, int(189), kw_end_DASH_column, int(27), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Converts string to all lower-case.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1259
 	// re-quote-replacement
+
+//line ../../clojure/string.glj:30:7
 	{
 		tmp0 := sym_re_DASH_quote_DASH_replacement
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:36:23
 			tmp3 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:36:3
 			tmp4 := lang.Apply3(strings5.ReplaceAll, tmp3, "$", "$$")
+//line ../../clojure/string.glj:30:7
 			return tmp4
 		})
 		aotDirectFn10 = tmp1
@@ -1048,7 +1279,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(30), kw_column, int(7), kw_end_DASH_line, int(30), kw_end_DASH_column, int(37), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_replacement)), kw_doc, "Given a replacement string that you wish to be a literal\n   replacement for a pattern match in replace or replace-first, do the\n   necessary escaping of special characters in the replacement.", kw_added, "1.5", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1283
 	// replace
+
+//line ../../clojure/string.glj:53:7
 	{
 		tmp0 := sym_replace
 		var tmp1 lang.FnFunc3
@@ -1059,52 +1293,81 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:79:3
 			var tmp5 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:79:11
 				tmp6 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:79:3
 				var v7 any = tmp6
 				_ = v7
+//line ../../clojure/string.glj:80:5
 				var tmp8 any
+//line ../../clojure/string.glj:81:6
 				tmp9 := lang.IsInstance[lang.Char](v3)
+//line ../../clojure/string.glj:80:5
 				if lang.IsTruthy(tmp9) {
+//line ../../clojure/string.glj:81:69
 					tmp10 := lang.Apply3(strings5.ReplaceAll, v7, v3, v4)
+//line ../../clojure/string.glj:80:5
 					tmp8 = tmp10
 				} else {
 					var tmp11 any
+//line ../../clojure/string.glj:82:6
 					tmp12 := aotExternalFn17(lang.Builtins["string"], v3)
+//line ../../clojure/string.glj:80:5
 					if lang.IsTruthy(tmp12) {
+//line ../../clojure/string.glj:82:34
 						tmp13 := lang.Apply3(strings5.ReplaceAll, v7, v3, v4)
+//line ../../clojure/string.glj:80:5
 						tmp11 = tmp13
 					} else {
 						var tmp14 any
+//line ../../clojure/string.glj:83:6
 						tmp15 := lang.IsInstance[*regexp6.Regexp](v3)
+//line ../../clojure/string.glj:80:5
 						if lang.IsTruthy(tmp15) {
+//line ../../clojure/string.glj:83:32
 							var tmp16 any
+//line ../../clojure/string.glj:83:36
 							tmp17 := aotExternalFn17(lang.Builtins["string"], v4)
+//line ../../clojure/string.glj:83:32
 							if lang.IsTruthy(tmp17) {
+//line ../../clojure/string.glj:84:34
 								tmp18, _ := lang.FieldOrMethod(v3, "replaceAllString")
 								if reflect.TypeOf(tmp18).Kind() != reflect.Func {
 									panic(lang.NewIllegalArgumentError(fmt.Sprintf("replaceAllString is not a function")))
 								}
 								tmp19 := lang.Apply2(tmp18, v7, v4)
+//line ../../clojure/string.glj:83:32
 								tmp16 = tmp19
 							} else {
+//line ../../clojure/string.glj:85:34
 								tmp20 := aotDirectFn12(v7, v3, v4)
+//line ../../clojure/string.glj:83:32
 								tmp16 = tmp20
 							}
+//line ../../clojure/string.glj:80:5
 							tmp14 = tmp16
 						} else {
+//line ../../clojure/string.glj:86:84
 							tmp21 := aotExternalFn4("Invalid match arg: ", v3)
+//line ../../clojure/string.glj:86:19
 							tmp22 := lang.Apply1(lang.NewIllegalArgumentError, tmp21)
+//line ../../clojure/string.glj:86:12
 							panic(tmp22)
+//line ../../clojure/string.glj:80:5
 						}
 						tmp11 = tmp14
 					}
 					tmp8 = tmp11
 				}
+//line ../../clojure/string.glj:79:3
 				tmp5 = tmp8
 			} // end let
+//line ../../clojure/string.glj:53:7
 			return tmp5
 		})
 		aotDirectFn11 = tmp1
@@ -1114,7 +1377,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(53), kw_column, int(7), kw_end_DASH_line, int(53), kw_end_DASH_column, int(24), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_match, sym_replacement)), kw_doc, "Replaces all instance of match with replacement in s.\n\n   match/replacement can be:\n\n   string / string\n   char / char\n   pattern / (string or function of match).\n\n   See also replace-first.\n\n   The replacement is literal (i.e. none of its characters are treated\n   specially) for all cases above except pattern / string.\n\n   For pattern / string, $1, $2, etc. in the replacement string are\n   substituted with the string that matched the corresponding\n   parenthesized group in the pattern.  If you wish your replacement\n   string r to be used literally, use (re-quote-replacement r) as the\n   replacement argument.  See also documentation for\n   java.util.regex.Matcher's appendReplacement method.\n\n   Example:\n   (glojure.string/replace \"Almost Pig Latin\" #\"\\b(\\w)(\\w+)\\b\" \"$2$1ay\")\n   -> \"lmostAay igPay atinLay\"", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1381
 	// replace-by
+
+//line ../../clojure/string.glj:40:8
 	{
 		tmp0 := sym_replace_DASH_by
 		var tmp1 lang.FnFunc3
@@ -1125,85 +1391,95 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:42:3
 			var tmp5 any
 			{ // let
 				// let binding "m"
+
+//line ../../clojure/string.glj:42:11
 				tmp6 := aotExternalFn18(v3, v2)
+//line ../../clojure/string.glj:42:3
 				var v7 any = tmp6
 				_ = v7
+//line ../../clojure/string.glj:43:5
 				var tmp8 any
-				tmp9, ok := lang.FieldOrMethod(v7, "find")
-				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "find")))
-				}
-				var tmp10 any
-				switch reflect.TypeOf(tmp9).Kind() {
-				case reflect.Func:
-					tmp10 = lang.Apply(tmp9, nil)
-				default:
-					tmp10 = tmp9
-				}
-				if lang.IsTruthy(tmp10) {
-					var tmp11 any
+//line ../../clojure/string.glj:43:9
+				tmp9 := any(v7).(interface{ Find() bool }).Find()
+//line ../../clojure/string.glj:43:5
+				if lang.IsTruthy(tmp9) {
+//line ../../clojure/string.glj:44:7
+					var tmp10 any
 					{ // let
 						// let binding "buffer"
-						tmp12 := reflect.TypeOf((*strings5.Builder)(nil)).Elem()
-						tmp13 := lang.NewHostInstance(tmp12)
-						var v14 any = tmp13
-						_ = v14
-						var tmp15 any
+
+//line ../../clojure/string.glj:44:20
+						tmp11 := reflect.TypeOf((*strings5.Builder)(nil)).Elem()
+						tmp12 := lang.NewHostInstance(tmp11)
+//line ../../clojure/string.glj:44:7
+						var v13 any = tmp12
+						_ = v13
+//line ../../clojure/string.glj:45:9
+						var tmp14 any
 						{ // let
 							// let binding "found"
-							var v16 any = true
-							_ = v16
+							var v15 any = true
+							_ = v15
 							for {
-								var tmp17 any
-								if lang.IsTruthy(v16) {
-									tmp18 := aotExternalFn19(v7)
-									tmp19 := lang.Apply1(v4, tmp18)
-									tmp20 := aotDirectFn10(tmp19)
-									tmp21, _ := lang.FieldOrMethod(v7, "appendReplacement")
-									if reflect.TypeOf(tmp21).Kind() != reflect.Func {
+//line ../../clojure/string.glj:46:11
+								var tmp16 any
+								if lang.IsTruthy(v15) {
+//line ../../clojure/string.glj:47:71
+									tmp17 := aotExternalFn19(v7)
+//line ../../clojure/string.glj:47:68
+									tmp18 := lang.Apply1(v4, tmp17)
+//line ../../clojure/string.glj:47:46
+									tmp19 := aotDirectFn10(tmp18)
+//line ../../clojure/string.glj:47:17
+									tmp20, _ := lang.FieldOrMethod(v7, "appendReplacement")
+									if reflect.TypeOf(tmp20).Kind() != reflect.Func {
 										panic(lang.NewIllegalArgumentError(fmt.Sprintf("appendReplacement is not a function")))
 									}
-									tmp22 := lang.Apply2(tmp21, v14, tmp20)
-									_ = tmp22
-									tmp24, ok := lang.FieldOrMethod(v7, "find")
-									if !ok {
-										panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "find")))
-									}
-									var tmp25 any
-									switch reflect.TypeOf(tmp24).Kind() {
-									case reflect.Func:
-										tmp25 = lang.Apply(tmp24, nil)
-									default:
-										tmp25 = tmp24
-									}
-									var tmp23 any = tmp25
-									v16 = tmp23
+									tmp21 := lang.Apply2(tmp20, v13, tmp19)
+//line ../../clojure/string.glj:47:13
+									_ = tmp21
+//line ../../clojure/string.glj:48:24
+									tmp23 := any(v7).(interface{ Find() bool }).Find()
+//line ../../clojure/string.glj:48:17
+									var tmp22 any = tmp23
+									v15 = tmp22
 									continue
+//line ../../clojure/string.glj:46:11
 								} else {
-									tmp26, _ := lang.FieldOrMethod(v7, "appendTail")
-									if reflect.TypeOf(tmp26).Kind() != reflect.Func {
+//line ../../clojure/string.glj:49:17
+									tmp24, _ := lang.FieldOrMethod(v7, "appendTail")
+									if reflect.TypeOf(tmp24).Kind() != reflect.Func {
 										panic(lang.NewIllegalArgumentError(fmt.Sprintf("appendTail is not a function")))
 									}
-									tmp27 := lang.Apply1(tmp26, v14)
-									_ = tmp27
-									tmp28 := lang.ToString(v14)
-									tmp17 = tmp28
+									tmp25 := lang.Apply1(tmp24, v13)
+//line ../../clojure/string.glj:49:13
+									_ = tmp25
+//line ../../clojure/string.glj:50:17
+									tmp26 := lang.ToString(v13)
+//line ../../clojure/string.glj:46:11
+									tmp16 = tmp26
 								}
-								tmp15 = tmp17
+//line ../../clojure/string.glj:45:9
+								tmp14 = tmp16
 								break
 							}
 						} // end let
-						tmp11 = tmp15
+//line ../../clojure/string.glj:44:7
+						tmp10 = tmp14
 					} // end let
-					tmp8 = tmp11
+//line ../../clojure/string.glj:43:5
+					tmp8 = tmp10
 				} else {
 					tmp8 = v2
 				}
+//line ../../clojure/string.glj:42:3
 				tmp5 = tmp8
 			} // end let
+//line ../../clojure/string.glj:40:8
 			return tmp5
 		})
 		aotDirectFn12 = tmp1
@@ -1212,7 +1488,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(40), kw_column, int(8), kw_end_DASH_line, int(40), kw_end_DASH_column, int(17), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_re, sym_f)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1492
 	// replace-first
+
+//line ../../clojure/string.glj:115:7
 	{
 		tmp0 := sym_replace_DASH_first
 		var tmp1 lang.FnFunc3
@@ -1223,82 +1502,127 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:142:3
 			var tmp5 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:142:11
 				tmp6 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:142:3
 				var v7 any = tmp6
 				_ = v7
+//line ../../clojure/string.glj:143:5
 				var tmp8 any
+//line ../../clojure/string.glj:144:6
 				tmp9 := lang.IsInstance[lang.Char](v3)
+//line ../../clojure/string.glj:143:5
 				if lang.IsTruthy(tmp9) {
+//line ../../clojure/string.glj:145:6
 					tmp10 := aotDirectFn15(v7, v3, v4)
+//line ../../clojure/string.glj:143:5
 					tmp8 = tmp10
 				} else {
 					var tmp11 any
+//line ../../clojure/string.glj:146:6
 					tmp12 := aotExternalFn17(lang.Builtins["string"], v3)
+//line ../../clojure/string.glj:143:5
 					if lang.IsTruthy(tmp12) {
+//line ../../clojure/string.glj:147:27
 						tmp13 := lang.ToString(v3)
+//line ../../clojure/string.glj:148:25
 						tmp14 := lang.ToString(v4)
+//line ../../clojure/string.glj:147:6
 						tmp15 := aotDirectFn16(v7, tmp13, tmp14)
+//line ../../clojure/string.glj:143:5
 						tmp11 = tmp15
 					} else {
 						var tmp16 any
+//line ../../clojure/string.glj:149:6
 						tmp17 := lang.IsInstance[*regexp6.Regexp](v3)
+//line ../../clojure/string.glj:143:5
 						if lang.IsTruthy(tmp17) {
+//line ../../clojure/string.glj:150:6
 							var tmp18 any
+//line ../../clojure/string.glj:150:10
 							tmp19 := aotExternalFn17(lang.Builtins["string"], v4)
+//line ../../clojure/string.glj:150:6
 							if lang.IsTruthy(tmp19) {
+//line ../../clojure/string.glj:151:8
 								var tmp20 any
 								{ // let
 									// let binding "done"
+
+//line ../../clojure/string.glj:151:19
 									tmp21 := aotExternalFn20(false)
+//line ../../clojure/string.glj:151:8
 									var v22 any = tmp21
 									_ = v22
+//line ../../clojure/string.glj:153:33
 									var tmp23 lang.FnFunc1
 									tmp23 = lang.FnFunc1(func(p0 any) any {
 										v24 := p0
 										_ = v24
+//line ../../clojure/string.glj:154:35
 										var tmp25 any
+//line ../../clojure/string.glj:154:39
 										tmp26 := aotExternalFn21(v22)
+//line ../../clojure/string.glj:154:35
 										if lang.IsTruthy(tmp26) {
 											tmp25 = v24
 										} else {
+//line ../../clojure/string.glj:157:39
 											tmp27 := aotExternalFn22(v22, true)
+//line ../../clojure/string.glj:156:37
 											_ = tmp27
+//line ../../clojure/string.glj:158:39
 											tmp28, _ := lang.FieldOrMethod(v3, "ReplaceAllString")
 											if reflect.TypeOf(tmp28).Kind() != reflect.Func {
 												panic(lang.NewIllegalArgumentError(fmt.Sprintf("ReplaceAllString is not a function")))
 											}
 											tmp29 := lang.Apply2(tmp28, v24, v4)
+//line ../../clojure/string.glj:154:35
 											tmp25 = tmp29
 										}
+//line ../../clojure/string.glj:153:33
 										return tmp25
 									})
+//line ../../clojure/string.glj:152:10
 									tmp24, _ := lang.FieldOrMethod(v3, "ReplaceAllStringFunc")
 									if reflect.TypeOf(tmp24).Kind() != reflect.Func {
 										panic(lang.NewIllegalArgumentError(fmt.Sprintf("ReplaceAllStringFunc is not a function")))
 									}
 									tmp25 := lang.Apply2(tmp24, v7, tmp23)
+//line ../../clojure/string.glj:151:8
 									tmp20 = tmp25
 								} // end let
+//line ../../clojure/string.glj:150:6
 								tmp18 = tmp20
 							} else {
+//line ../../clojure/string.glj:159:8
 								tmp21 := aotDirectFn14(v7, v3, v4)
+//line ../../clojure/string.glj:150:6
 								tmp18 = tmp21
 							}
+//line ../../clojure/string.glj:143:5
 							tmp16 = tmp18
 						} else {
+//line ../../clojure/string.glj:160:84
 							tmp22 := aotExternalFn4("Invalid match arg: ", v3)
+//line ../../clojure/string.glj:160:19
 							tmp23 := lang.Apply1(lang.NewIllegalArgumentError, tmp22)
+//line ../../clojure/string.glj:160:12
 							panic(tmp23)
+//line ../../clojure/string.glj:143:5
 						}
 						tmp11 = tmp16
 					}
 					tmp8 = tmp11
 				}
+//line ../../clojure/string.glj:142:3
 				tmp5 = tmp8
 			} // end let
+//line ../../clojure/string.glj:115:7
 			return tmp5
 		})
 		aotDirectFn13 = tmp1
@@ -1308,7 +1632,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(115), kw_column, int(7), kw_end_DASH_line, int(115), kw_end_DASH_column, int(30), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_match, sym_replacement)), kw_doc, "Replaces the first instance of match with replacement in s.\n\n   match/replacement can be:\n\n   char / char\n   string / string\n   pattern / (string or function of match).\n\n   See also replace.\n\n   The replacement is literal (i.e. none of its characters are treated\n   specially) for all cases above except pattern / string.\n\n   For pattern / string, $1, $2, etc. in the replacement string are\n   substituted with the string that matched the corresponding\n   parenthesized group in the pattern.  If you wish your replacement\n   string r to be used literally, use (re-quote-replacement r) as the\n   replacement argument.  See also documentation for\n   java.util.regex.Matcher's appendReplacement method.\n\n   Example:\n   (glojure.string/replace-first \"swap first two words\"\n                                 #\"(\\w+)(\\s+)(\\w+)\" \"$3$2$1\")\n   -> \"first swap two words\"", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1636
 	// replace-first-by
+
+//line ../../clojure/string.glj:88:8
 	{
 		tmp0 := sym_replace_DASH_first_DASH_by
 		var tmp1 lang.FnFunc3
@@ -1319,59 +1646,74 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:90:3
 			var tmp5 any
 			{ // let
 				// let binding "m"
+
+//line ../../clojure/string.glj:90:11
 				tmp6 := aotExternalFn18(v3, v2)
+//line ../../clojure/string.glj:90:3
 				var v7 any = tmp6
 				_ = v7
+//line ../../clojure/string.glj:91:5
 				var tmp8 any
-				tmp9, ok := lang.FieldOrMethod(v7, "find")
-				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "find")))
-				}
-				var tmp10 any
-				switch reflect.TypeOf(tmp9).Kind() {
-				case reflect.Func:
-					tmp10 = lang.Apply(tmp9, nil)
-				default:
-					tmp10 = tmp9
-				}
-				if lang.IsTruthy(tmp10) {
-					var tmp11 any
+//line ../../clojure/string.glj:91:9
+				tmp9 := any(v7).(interface{ Find() bool }).Find()
+//line ../../clojure/string.glj:91:5
+				if lang.IsTruthy(tmp9) {
+//line ../../clojure/string.glj:92:7
+					var tmp10 any
 					{ // let
 						// let binding "buffer"
-						tmp12 := reflect.TypeOf((*strings5.Builder)(nil)).Elem()
-						tmp13 := lang.NewHostInstance(tmp12)
-						var v14 any = tmp13
-						_ = v14
+
+//line ../../clojure/string.glj:92:20
+						tmp11 := reflect.TypeOf((*strings5.Builder)(nil)).Elem()
+						tmp12 := lang.NewHostInstance(tmp11)
+//line ../../clojure/string.glj:92:7
+						var v13 any = tmp12
+						_ = v13
 						// let binding "rep"
-						tmp15 := aotExternalFn19(v7)
-						tmp16 := lang.Apply1(v4, tmp15)
-						tmp17 := aotDirectFn10(tmp16)
-						var v18 any = tmp17
-						_ = v18
-						tmp19, _ := lang.FieldOrMethod(v7, "appendReplacement")
-						if reflect.TypeOf(tmp19).Kind() != reflect.Func {
+
+//line ../../clojure/string.glj:93:42
+						tmp14 := aotExternalFn19(v7)
+//line ../../clojure/string.glj:93:39
+						tmp15 := lang.Apply1(v4, tmp14)
+//line ../../clojure/string.glj:93:17
+						tmp16 := aotDirectFn10(tmp15)
+//line ../../clojure/string.glj:92:7
+						var v17 any = tmp16
+						_ = v17
+//line ../../clojure/string.glj:94:9
+						tmp18, _ := lang.FieldOrMethod(v7, "appendReplacement")
+						if reflect.TypeOf(tmp18).Kind() != reflect.Func {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("appendReplacement is not a function")))
 						}
-						tmp20 := lang.Apply2(tmp19, v14, v18)
-						_ = tmp20
-						tmp21, _ := lang.FieldOrMethod(v7, "appendTail")
-						if reflect.TypeOf(tmp21).Kind() != reflect.Func {
+						tmp19 := lang.Apply2(tmp18, v13, v17)
+//line ../../clojure/string.glj:92:7
+						_ = tmp19
+//line ../../clojure/string.glj:95:9
+						tmp20, _ := lang.FieldOrMethod(v7, "appendTail")
+						if reflect.TypeOf(tmp20).Kind() != reflect.Func {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("appendTail is not a function")))
 						}
-						tmp22 := lang.Apply1(tmp21, v14)
-						_ = tmp22
-						tmp23 := aotExternalFn3(v14)
-						tmp11 = tmp23
+						tmp21 := lang.Apply1(tmp20, v13)
+//line ../../clojure/string.glj:92:7
+						_ = tmp21
+//line ../../clojure/string.glj:96:9
+						tmp22 := aotExternalFn3(v13)
+//line ../../clojure/string.glj:92:7
+						tmp10 = tmp22
 					} // end let
-					tmp8 = tmp11
+//line ../../clojure/string.glj:91:5
+					tmp8 = tmp10
 				} else {
 					tmp8 = v2
 				}
+//line ../../clojure/string.glj:90:3
 				tmp5 = tmp8
 			} // end let
+//line ../../clojure/string.glj:88:8
 			return tmp5
 		})
 		aotDirectFn14 = tmp1
@@ -1380,7 +1722,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(88), kw_column, int(8), kw_end_DASH_line, int(88), kw_end_DASH_column, int(23), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_re, sym_f)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1726
 	// replace-first-char
+
+//line ../../clojure/string.glj:99:8
 	{
 		tmp0 := sym_replace_DASH_first_DASH_char
 		var tmp1 lang.FnFunc3
@@ -1391,30 +1736,48 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:101:3
 			var tmp5 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:101:11
 				tmp6 := lang.ToString(v2)
+//line ../../clojure/string.glj:101:3
 				var v7 any = tmp6
 				_ = v7
 				// let binding "i"
+
+//line ../../clojure/string.glj:102:28
 				tmp8 := runtime.RT.IntCast(v3)
+//line ../../clojure/string.glj:102:11
 				tmp9 := lang.Apply2(strings5.Index, v7, tmp8)
+//line ../../clojure/string.glj:101:3
 				var v10 any = tmp9
 				_ = v10
+//line ../../clojure/string.glj:103:5
 				var tmp11 any
+//line ../../clojure/string.glj:103:9
 				tmp12 := aotExternalFn0(int64(-1), v10)
+//line ../../clojure/string.glj:103:5
 				if lang.IsTruthy(tmp12) {
 					tmp11 = v7
 				} else {
+//line ../../clojure/string.glj:105:12
 					tmp13 := aotExternalFn5(v7, int64(0), v10)
+//line ../../clojure/string.glj:105:41
 					tmp14 := lang.Numbers.Inc(v10)
+//line ../../clojure/string.glj:105:33
 					tmp15 := aotExternalFn6(v7, tmp14)
+//line ../../clojure/string.glj:105:7
 					tmp16 := aotExternalFn23(tmp13, v4, tmp15)
+//line ../../clojure/string.glj:103:5
 					tmp11 = tmp16
 				}
+//line ../../clojure/string.glj:101:3
 				tmp5 = tmp11
 			} // end let
+//line ../../clojure/string.glj:99:8
 			return tmp5
 		})
 		aotDirectFn15 = tmp1
@@ -1423,7 +1786,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(99), kw_column, int(8), kw_end_DASH_line, int(99), kw_end_DASH_column, int(25), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_match, sym_replace)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1790
 	// replace-first-str
+
+//line ../../clojure/string.glj:107:8
 	{
 		tmp0 := sym_replace_DASH_first_DASH_str
 		var tmp1 lang.FnFunc3
@@ -1434,30 +1800,48 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:109:3
 			var tmp5 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/string.glj:109:22
 				tmp6 := lang.ToString(v2)
+//line ../../clojure/string.glj:109:3
 				var v7 any = tmp6
 				_ = v7
 				// let binding "i"
+
+//line ../../clojure/string.glj:110:11
 				tmp8 := lang.Apply2(strings5.Index, v7, v3)
+//line ../../clojure/string.glj:109:3
 				var v9 any = tmp8
 				_ = v9
+//line ../../clojure/string.glj:111:5
 				var tmp10 any
+//line ../../clojure/string.glj:111:9
 				tmp11 := aotExternalFn0(int64(-1), v9)
+//line ../../clojure/string.glj:111:5
 				if lang.IsTruthy(tmp11) {
 					tmp10 = v7
 				} else {
+//line ../../clojure/string.glj:113:12
 					tmp12 := aotExternalFn5(v7, int64(0), v9)
+//line ../../clojure/string.glj:113:46
 					tmp13 := lang.Count(v3)
+//line ../../clojure/string.glj:113:41
 					tmp14 := lang.Numbers.Add(v9, tmp13)
+//line ../../clojure/string.glj:113:33
 					tmp15 := aotExternalFn6(v7, tmp14)
+//line ../../clojure/string.glj:113:7
 					tmp16 := aotExternalFn23(tmp12, v4, tmp15)
+//line ../../clojure/string.glj:111:5
 					tmp10 = tmp16
 				}
+//line ../../clojure/string.glj:109:3
 				tmp5 = tmp10
 			} // end let
+//line ../../clojure/string.glj:107:8
 			return tmp5
 		})
 		aotDirectFn16 = tmp1
@@ -1466,15 +1850,21 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(107), kw_column, int(8), kw_end_DASH_line, int(107), kw_end_DASH_column, int(24), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_match, sym_replace)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1854
 	// reverse
+
+//line ../../clojure/string.glj:23:7
 	{
 		tmp0 := sym_reverse
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:28:4
 			tmp3 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:27:3
 			tmp4 := lang.Apply1(lang.ReverseString, tmp3)
+//line ../../clojure/string.glj:23:7
 			return tmp4
 		})
 		aotDirectFn17 = tmp1
@@ -1484,7 +1874,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(23), kw_column, int(7), kw_end_DASH_line, int(23), kw_end_DASH_column, int(24), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Returns s with its characters reversed.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1878
 	// split
+
+//line ../../clojure/string.glj:195:7
 	{
 		tmp0 := sym_split
 		var tmp1 lang.ArityFn
@@ -1493,12 +1886,15 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:201:82
 			tmp4, _ := lang.FieldOrMethod(v3, "split")
 			if reflect.TypeOf(tmp4).Kind() != reflect.Func {
 				panic(lang.NewIllegalArgumentError(fmt.Sprintf("split is not a function")))
 			}
 			tmp5 := lang.Apply2(tmp4, v2, int64(-1))
+//line ../../clojure/string.glj:201:6
 			tmp6 := lang.CreateOwningLazilyPersistentVector(tmp5)
+//line ../../clojure/string.glj:195:7
 			return tmp6
 		})
 		aotDirectFn18Arity3 = lang.FnFunc3(func(p0, p1, p2 any) any {
@@ -1508,12 +1904,15 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/string.glj:203:82
 			tmp5, _ := lang.FieldOrMethod(v3, "split")
 			if reflect.TypeOf(tmp5).Kind() != reflect.Func {
 				panic(lang.NewIllegalArgumentError(fmt.Sprintf("split is not a function")))
 			}
 			tmp6 := lang.Apply2(tmp5, v2, v4)
+//line ../../clojure/string.glj:203:6
 			tmp7 := lang.CreateOwningLazilyPersistentVector(tmp6)
+//line ../../clojure/string.glj:195:7
 			return tmp7
 		})
 		tmp1 = lang.NewArityFn(
@@ -1531,15 +1930,21 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(195), kw_column, int(7), kw_end_DASH_line, int(195), kw_end_DASH_column, int(11), kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_re), lang.NewVector(sym_s, sym_re, sym_limit)), kw_doc, "Splits string on a regular expression.  Optional argument limit is\n  the maximum number of parts. Not lazy. Returns vector of the parts.\n  Trailing empty strings are not returned - pass limit of -1 to return all.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1934
 	// split-lines
+
+//line ../../clojure/string.glj:205:7
 	{
 		tmp0 := sym_split_DASH_lines
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:210:12
 			tmp3 := aotExternalFn24("\\r?\\n")
+//line ../../clojure/string.glj:209:3
 			tmp4 := aotDirectFn18Arity2(v2, tmp3)
+//line ../../clojure/string.glj:205:7
 			return tmp4
 		})
 		aotDirectFn19 = tmp1
@@ -1548,7 +1953,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(205), kw_column, int(7), kw_end_DASH_line, int(205), kw_end_DASH_column, int(17), kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Splits s on \\n or \\r\\n. Trailing empty lines are not returned.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1957
 	// starts-with?
+
+//line ../../clojure/string.glj:314:7
 	{
 		tmp0 := sym_starts_DASH_with_QMARK_
 		var tmp1 lang.FnFunc2
@@ -1557,16 +1965,26 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:318:3
 			var tmp4 any
+//line ../../clojure/string.glj:318:7
 			tmp5 := lang.Identical(v2, nil)
+//line ../../clojure/string.glj:318:3
 			if lang.IsTruthy(tmp5) {
+//line ../../clojure/string.glj:319:12
 				tmp6 := lang.Apply1(lang.NewError, "argument to starts-with? must not be nil")
+//line ../../clojure/string.glj:319:5
 				panic(tmp6)
+//line ../../clojure/string.glj:318:3
 			} else {
+//line ../../clojure/string.glj:320:24
 				tmp7 := lang.ToString(v2)
+//line ../../clojure/string.glj:320:5
 				tmp8 := lang.Apply2(strings5.HasPrefix, tmp7, v3)
+//line ../../clojure/string.glj:318:3
 				tmp4 = tmp8
 			}
+//line ../../clojure/string.glj:314:7
 			return tmp4
 		})
 		aotDirectFn20 = tmp1
@@ -1575,14 +1993,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(314), kw_column, int(7), kw_end_DASH_line, int(314), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_s, sym_substr)), kw_doc, "True if s starts with substr.", kw_added, "1.8", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:1997
 	// trim
+
+//line ../../clojure/string.glj:212:7
 	{
 		tmp0 := sym_trim
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:216:3
 			tmp3 := lang.Apply1(strings5.TrimSpace, v2)
+//line ../../clojure/string.glj:212:7
 			return tmp3
 		})
 		aotDirectFn21 = tmp1
@@ -1592,14 +2015,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(212), kw_column, int(7), kw_end_DASH_line, int(212), kw_end_DASH_column, int(21), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Removes whitespace from both ends of string.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:2019
 	// trim-newline
+
+//line ../../clojure/string.glj:230:7
 	{
 		tmp0 := sym_trim_DASH_newline
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:235:3
 			tmp3 := lang.Apply2(strings5.TrimRight, v2, "\r\n")
+//line ../../clojure/string.glj:230:7
 			return tmp3
 		})
 		aotDirectFn22 = tmp1
@@ -1609,14 +2037,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(230), kw_column, int(7), kw_end_DASH_line, int(230), kw_end_DASH_column, int(29), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Removes all trailing newline \\n or return \\r characters from\n  string.  Similar to Perl's chomp.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:2041
 	// triml
+
+//line ../../clojure/string.glj:218:7
 	{
 		tmp0 := sym_triml
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:222:3
 			tmp3 := lang.Apply2(strings5.TrimLeftFunc, v2, unicode4.IsSpace)
+//line ../../clojure/string.glj:218:7
 			return tmp3
 		})
 		aotDirectFn23 = tmp1
@@ -1626,14 +2059,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(218), kw_column, int(7), kw_end_DASH_line, int(218), kw_end_DASH_column, int(22), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Removes whitespace from the left side of string.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:2063
 	// trimr
+
+//line ../../clojure/string.glj:224:7
 	{
 		tmp0 := sym_trimr
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:228:3
 			tmp3 := lang.Apply2(strings5.TrimRightFunc, v2, unicode4.IsSpace)
+//line ../../clojure/string.glj:224:7
 			return tmp3
 		})
 		aotDirectFn24 = tmp1
@@ -1643,16 +2081,23 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(224), kw_column, int(7), kw_end_DASH_line, int(224), kw_end_DASH_column, int(22), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Removes whitespace from the right side of string.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:2085
 	// upper-case
+
+//line ../../clojure/string.glj:183:7
 	{
 		tmp0 := sym_upper_DASH_case
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:187:36
 			tmp3 := aotDirectFn2(v2)
+//line ../../clojure/string.glj:187:20
 			tmp4 := aotExternalFn3(tmp3)
+//line ../../clojure/string.glj:187:3
 			tmp5 := lang.Apply1(strings5.ToUpper, tmp4)
+//line ../../clojure/string.glj:183:7
 			return tmp5
 		})
 		aotDirectFn25 = tmp1
@@ -1662,15 +2107,21 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(183), kw_column, int(7), kw_end_DASH_line, int(183), kw_end_DASH_column, int(27), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_s)), kw_doc, "Converts string to all upper-case.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:2111
 	// join
+
+//line ../../clojure/string.glj:163:7
 	{
 		tmp0 := sym_join
 		var tmp1 lang.ArityFn
 		aotDirectFn7Arity1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/string.glj:168:13
 			tmp3 := checkDerefVar(var_clojure_DOT_core_str)
+//line ../../clojure/string.glj:168:6
 			tmp4 := aotExternalFn15(tmp3, v2)
+//line ../../clojure/string.glj:163:7
 			return tmp4
 		})
 		aotDirectFn7Arity2 = lang.FnFunc2(func(p0, p1 any) any {
@@ -1678,10 +2129,15 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/string.glj:170:23
 			tmp4 := checkDerefVar(var_clojure_DOT_core_str)
+//line ../../clojure/string.glj:170:18
 			tmp5 := aotExternalFn16(tmp4, v3)
+//line ../../clojure/string.glj:170:33
 			tmp6 := aotExternalFn3(v2)
+//line ../../clojure/string.glj:170:4
 			tmp7 := lang.Apply2(strings5.Join, tmp5, tmp6)
+//line ../../clojure/string.glj:163:7
 			return tmp7
 		})
 		tmp1 = lang.NewArityFn(
@@ -1700,4 +2156,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/string.glj", kw_line, int(163), kw_column, int(7), kw_end_DASH_line, int(163), kw_end_DASH_column, int(21), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_coll), lang.NewVector(sym_separator, sym_coll)), kw_doc, "Returns a string of all elements in coll, as returned by (seq coll),\n   separated by an optional separator.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 		})
 	}
+//line loader.go:2160
 }
@@ -77,13 +77,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -147,6 +147,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -220,6 +221,8 @@ func LoadNS() {
 	}
 	ns.AddAlias(sym_walk, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 	// apply-template
+
+//line ../../clojure/template.glj:30:7
 	{
 		tmp0 := sym_apply_DASH_template
 		var tmp1 lang.FnFunc3
@@ -230,8 +233,11 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/template.glj:43:26
 			tmp5 := aotExternalFn1(v2, v4)
+//line ../../clojure/template.glj:43:3
 			tmp6 := aotExternalFn0(tmp5, v3)
+//line ../../clojure/template.glj:30:7
 			return tmp6
 		})
 		aotDirectFn0 = tmp1
@@ -240,7 +246,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/template.glj", kw_line, int(30), kw_column, int(7), kw_end_DASH_line, int(30), kw_end_DASH_column, int(20), kw_arglists, lang.NewList(lang.NewVector(sym_argv, sym_expr, sym_values)), kw_doc, "For use in macros.  argv is an argument list, as in defn.  expr is\n  a quoted expression using the symbols in argv.  values is a sequence\n  of values to be used for the arguments.\n\n  apply-template will recursively replace argument symbols in expr\n  with their corresponding values, returning a modified expr.\n\n  Example: (apply-template '[x] '(+ x x) '[2])\n           ;=> (+ 2 2)", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_template))
 		})
 	}
+//line loader.go:250
 	// do-template
+
+//line ../../clojure/template.glj:45:11
 	{
 		tmp0 := sym_do_DASH_template
 		var tmp1 lang.ArityFn
@@ -261,27 +270,40 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../clojure/template.glj:53:3
 				var tmp7 any
 				{ // let
 					// let binding "c"
+
+//line ../../clojure/template.glj:53:11
 					tmp8 := lang.Count(v4)
+//line ../../clojure/template.glj:53:3
 					var v9 any = tmp8
 					_ = v9
+//line ../../clojure/template.glj:54:5
 					tmp10 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp11 := lang.Apply1(tmp10, sym_do)
+//line ../../clojure/template.glj:54:17
 					var tmp12 lang.FnFunc1
 					tmp12 = lang.FnFunc1(func(p0 any) any {
 						v13 := p0
 						_ = v13
+//line ../../clojure/template.glj:54:25
 						tmp14 := aotDirectFn0(v4, v5, v13)
+//line ../../clojure/template.glj:54:17
 						return tmp14
 					})
+//line ../../clojure/template.glj:55:17
 					tmp13 := aotExternalFn6(v9, v6)
+//line ../../clojure/template.glj:54:12
 					tmp14 := aotExternalFn5(tmp12, tmp13)
+//line ../../clojure/template.glj:54:5
 					tmp15 := aotExternalFn4(tmp11, tmp14)
 					tmp16 := lang.Seq(tmp15)
+//line ../../clojure/template.glj:53:3
 					tmp7 = tmp16
 				} // end let
+//line ../../clojure/template.glj:45:11
 				return tmp7
 			}),
 			4,
@@ -291,4 +313,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/template.glj", kw_line, int(45), kw_column, int(11), kw_end_DASH_line, int(45), kw_end_DASH_column, int(21), kw_arglists, lang.NewList(lang.NewVector(sym_argv, sym_expr, sym__AMP_, sym_values)), kw_doc, "Repeatedly copies expr (in a do block) for each group of arguments\n  in values.  values are automatically partitioned by the number of\n  arguments in argv, an argument vector as in defn.\n\n  Example: (macroexpand '(do-template [x y] (+ y x) 2 4 3 5))\n           ;=> (do (+ 4 2) (+ 5 3))", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_template), kw_macro, true)
 		})
 	}
+//line loader.go:317
 }
@@ -179,13 +179,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -678,6 +678,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -752,6 +753,8 @@ func LoadNS() {
 	ns.AddAlias(sym_temp, lang.FindOrCreateNamespace(sym_clojure_DOT_template))
 	ns.AddAlias(sym_str, lang.FindOrCreateNamespace(sym_clojure_DOT_string))
 	// *initial-report-counters*
+
+//line ../../clojure/test.glj:263:6
 	{
 		tmp0 := sym__STAR_initial_DASH_report_DASH_counters_STAR_
 		var_clojure_DOT_test__STAR_initial_DASH_report_DASH_counters_STAR_ = ns.InternWithValue(tmp0, lang.NewMap(kw_test, int64(0), kw_pass, int64(0), kw_fail, int64(0), kw_error, int64(0)), true)
@@ -760,7 +763,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_initial_DASH_report_DASH_counters_STAR_.SetDynamic()
 	}
+//line loader.go:767
 	// *load-tests*
+
+//line ../../clojure/test.glj:244:10
 	{
 		tmp0 := sym__STAR_load_DASH_tests_STAR_
 		var_clojure_DOT_test__STAR_load_DASH_tests_STAR_ = ns.InternWithValue(tmp0, true, true)
@@ -769,7 +775,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_load_DASH_tests_STAR_.SetDynamic()
 	}
+//line loader.go:779
 	// *report-counters*
+
+//line ../../clojure/test.glj:261:6
 	{
 		tmp0 := sym__STAR_report_DASH_counters_STAR_
 		var_clojure_DOT_test__STAR_report_DASH_counters_STAR_ = ns.InternWithValue(tmp0, nil, true)
@@ -778,7 +787,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_report_DASH_counters_STAR_.SetDynamic()
 	}
+//line loader.go:791
 	// *stack-trace-depth*
+
+//line ../../clojure/test.glj:251:6
 	{
 		tmp0 := sym__STAR_stack_DASH_trace_DASH_depth_STAR_
 		var_clojure_DOT_test__STAR_stack_DASH_trace_DASH_depth_STAR_ = ns.InternWithValue(tmp0, nil, true)
@@ -787,7 +799,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_stack_DASH_trace_DASH_depth_STAR_.SetDynamic()
 	}
+//line loader.go:803
 	// *test-out*
+
+//line ../../clojure/test.glj:270:6
 	{
 		tmp0 := sym__STAR_test_DASH_out_STAR_
 		var_clojure_DOT_test__STAR_test_DASH_out_STAR_ = ns.InternWithValue(tmp0, nil, true)
@@ -796,7 +811,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_test_DASH_out_STAR_.SetDynamic()
 	}
+//line loader.go:815
 	// *testing-contexts*
+
+//line ../../clojure/test.glj:268:6
 	{
 		tmp0 := sym__STAR_testing_DASH_contexts_STAR_
 		var_clojure_DOT_test__STAR_testing_DASH_contexts_STAR_ = ns.InternWithValue(tmp0, lang.NewList(), true)
@@ -805,7 +823,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_testing_DASH_contexts_STAR_.SetDynamic()
 	}
+//line loader.go:827
 	// *testing-vars*
+
+//line ../../clojure/test.glj:266:6
 	{
 		tmp0 := sym__STAR_testing_DASH_vars_STAR_
 		var_clojure_DOT_test__STAR_testing_DASH_vars_STAR_ = ns.InternWithValue(tmp0, lang.NewList(), true)
@@ -814,7 +835,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test__STAR_testing_DASH_vars_STAR_.SetDynamic()
 	}
+//line loader.go:839
 	// compose-fixtures
+
+//line ../../clojure/test.glj:680:7
 	{
 		tmp0 := sym_compose_DASH_fixtures
 		var tmp1 lang.FnFunc2
@@ -823,18 +847,25 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/test.glj:685:3
 			var tmp4 lang.FnFunc1
 			tmp4 = lang.FnFunc1(func(p0 any) any {
 				v5 := p0
 				_ = v5
+//line ../../clojure/test.glj:685:15
 				var tmp6 lang.FnFunc0
 				tmp6 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:685:22
 					tmp7 := lang.Apply1(v3, v5)
+//line ../../clojure/test.glj:685:15
 					return tmp7
 				})
+//line ../../clojure/test.glj:685:11
 				tmp7 := lang.Apply1(v2, tmp6)
+//line ../../clojure/test.glj:685:3
 				return tmp7
 			})
+//line ../../clojure/test.glj:680:7
 			return tmp4
 		})
 		aotDirectFn3 = tmp1
@@ -843,14 +874,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(680), kw_column, int(7), kw_end_DASH_line, int(680), kw_end_DASH_column, int(22), kw_arglists, lang.NewList(lang.NewVector(sym_f1, sym_f2)), kw_doc, "Composes two fixture functions, creating a new fixture function\n  that combines their behavior.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:878
 	// default-fixture
+
+//line ../../clojure/test.glj:674:8
 	{
 		tmp0 := sym_default_DASH_fixture
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:678:3
 			tmp3 := lang.Apply0(v2)
+//line ../../clojure/test.glj:674:8
 			return tmp3
 		})
 		aotDirectFn4 = tmp1
@@ -859,7 +895,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(674), kw_column, int(8), kw_end_DASH_line, int(674), kw_end_DASH_column, int(22), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_f)), kw_doc, "The default, empty, fixture function.  Just calls its argument.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:899
 	// file-and-line
+
+//line ../../clojure/test.glj:334:8
 	{
 		tmp0 := sym_file_DASH_and_DASH_line
 		var tmp1 lang.FnFunc2
@@ -868,9 +907,12 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/test.glj:337:3
 			var tmp4 any
 			{ // let
 				// let binding "stacktrace"
+
+//line ../../clojure/test.glj:337:20
 				tmp5, ok := lang.FieldOrMethod(v2, "getStackTrace")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v2, "getStackTrace")))
@@ -882,50 +924,48 @@ func LoadNS() {
 				default:
 					tmp6 = tmp5
 				}
+//line ../../clojure/test.glj:337:3
 				var v7 any = tmp6
 				_ = v7
+//line ../../clojure/test.glj:338:5
 				var tmp8 any
+//line ../../clojure/test.glj:338:18
 				tmp9 := lang.Count(v7)
+//line ../../clojure/test.glj:338:9
 				tmp10 := lang.Numbers.Lt(v3, tmp9)
+//line ../../clojure/test.glj:338:5
 				if lang.IsTruthy(tmp10) {
+//line ../../clojure/test.glj:339:7
 					var tmp11 any
 					{ // let
 						// let binding "s"
+
+//line ../../clojure/test.glj:339:34
 						tmp12 := runtime.RT.Nth(v7, lang.IntCast(v3))
+//line ../../clojure/test.glj:339:7
 						var v13 any = tmp12
 						_ = v13
-						tmp14, ok := lang.FieldOrMethod(v13, "getFileName")
-						if !ok {
-							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v13, "getFileName")))
-						}
-						var tmp15 any
-						switch reflect.TypeOf(tmp14).Kind() {
-						case reflect.Func:
-							tmp15 = lang.Apply(tmp14, nil)
-						default:
-							tmp15 = tmp14
-						}
-						tmp16, ok := lang.FieldOrMethod(v13, "getLineNumber")
-						if !ok {
-							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v13, "getLineNumber")))
-						}
-						var tmp17 any
-						switch reflect.TypeOf(tmp16).Kind() {
-						case reflect.Func:
-							tmp17 = lang.Apply(tmp16, nil)
-						default:
-							tmp17 = tmp16
-						}
-						tmp18 := lang.NewMap(kw_file, tmp15, kw_line, tmp17)
-						tmp11 = tmp18
+//line ../../clojure/test.glj:340:16
+						tmp14 := any(v13).(interface{ GetFileName() any }).GetFileName()
+//line ../../clojure/test.glj:340:39
+						tmp15 := any(v13).(interface{ GetLineNumber() int32 }).GetLineNumber()
+//line ../../clojure/test.glj:340:9
+						tmp16 := lang.NewMap(kw_file, tmp14, kw_line, tmp15)
+//line ../../clojure/test.glj:339:7
+						tmp11 = tmp16
 					} // end let
+//line ../../clojure/test.glj:338:5
 					tmp8 = tmp11
 				} else {
+//line ../../clojure/test.glj:341:7
 					tmp12 := lang.NewMap(kw_file, nil, kw_line, nil)
+//line ../../clojure/test.glj:338:5
 					tmp8 = tmp12
 				}
+//line ../../clojure/test.glj:337:3
 				tmp4 = tmp8
 			} // end let
+//line ../../clojure/test.glj:334:8
 			return tmp4
 		})
 		aotDirectFn6 = tmp1
@@ -934,16 +974,22 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(334), kw_column, int(8), kw_end_DASH_line, int(334), kw_end_DASH_column, int(20), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_exception, sym_depth)), kw_deprecated, "1.8", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:978
 	// file-position
+
+//line ../../clojure/test.glj:281:7
 	{
 		tmp0 := sym_file_DASH_position
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:290:3
 			var tmp3 any
 			{ // let
 				// let binding "s"
+
+//line ../../clojure/test.glj:290:35
 				tmp4, ok := lang.FieldOrMethod("unimplemented: new with non-constant class type", "getStackTrace")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", "unimplemented: new with non-constant class type", "getStackTrace")))
@@ -955,34 +1001,21 @@ func LoadNS() {
 				default:
 					tmp5 = tmp4
 				}
+//line ../../clojure/test.glj:290:30
 				tmp6 := runtime.RT.Nth(tmp5, lang.IntCast(v2))
+//line ../../clojure/test.glj:290:3
 				var v7 any = tmp6
 				_ = v7
-				tmp8, ok := lang.FieldOrMethod(v7, "getFileName")
-				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "getFileName")))
-				}
-				var tmp9 any
-				switch reflect.TypeOf(tmp8).Kind() {
-				case reflect.Func:
-					tmp9 = lang.Apply(tmp8, nil)
-				default:
-					tmp9 = tmp8
-				}
-				tmp10, ok := lang.FieldOrMethod(v7, "getLineNumber")
-				if !ok {
-					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "getLineNumber")))
-				}
-				var tmp11 any
-				switch reflect.TypeOf(tmp10).Kind() {
-				case reflect.Func:
-					tmp11 = lang.Apply(tmp10, nil)
-				default:
-					tmp11 = tmp10
-				}
-				tmp12 := lang.NewVector(tmp9, tmp11)
-				tmp3 = tmp12
+//line ../../clojure/test.glj:291:6
+				tmp8 := any(v7).(interface{ GetFileName() any }).GetFileName()
+//line ../../clojure/test.glj:291:23
+				tmp9 := any(v7).(interface{ GetLineNumber() int32 }).GetLineNumber()
+//line ../../clojure/test.glj:291:5
+				tmp10 := lang.NewVector(tmp8, tmp9)
+//line ../../clojure/test.glj:290:3
+				tmp3 = tmp10
 			} // end let
+//line ../../clojure/test.glj:281:7
 			return tmp3
 		})
 		aotDirectFn7 = tmp1
@@ -991,20 +1024,30 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(281), kw_column, int(7), kw_end_DASH_line, int(281), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(sym_n)), kw_doc, "Returns a vector [filename line-number] for the nth call up the\n  stack.\n\n  Deprecated in 1.2: The information needed for test reporting is\n  now on :file and :line keys in the result map.", kw_added, "1.1", kw_deprecated, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1028
 	// function?
+
+//line ../../clojure/test.glj:415:7
 	{
 		tmp0 := sym_function_QMARK_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:420:3
 			var tmp3 any
+//line ../../clojure/test.glj:420:7
 			tmp4 := aotExternalFn18(v2)
+//line ../../clojure/test.glj:420:3
 			if lang.IsTruthy(tmp4) {
+//line ../../clojure/test.glj:421:5
 				var tmp5 any
 				{ // let
 					// let binding "temp__0__auto__"
+
+//line ../../clojure/test.glj:421:18
 					tmp6 := aotExternalFn19(v2)
+//line ../../clojure/test.glj:421:5
 					var v7 any = tmp6
 					_ = v7
 					var tmp8 any
@@ -1014,10 +1057,14 @@ func LoadNS() {
 							// let binding "v"
 							var v10 any = v7
 							_ = v10
+//line ../../clojure/test.glj:422:7
 							var tmp11 any
 							{ // let
 								// let binding "temp__0__auto__"
+
+//line ../../clojure/test.glj:422:24
 								tmp12 := aotDirectFn9(v10)
+//line ../../clojure/test.glj:422:7
 								var v13 any = tmp12
 								_ = v13
 								var tmp14 any
@@ -1027,23 +1074,32 @@ func LoadNS() {
 										// let binding "value"
 										var v16 any = v13
 										_ = v16
+//line ../../clojure/test.glj:423:9
 										var tmp17 any
 										{ // let
 											// let binding "and__0__auto__"
+
+//line ../../clojure/test.glj:423:14
 											tmp18 := aotExternalFn20(v16)
+//line ../../clojure/test.glj:423:9
 											var v19 any = tmp18
 											_ = v19
 											var tmp20 any
 											if lang.IsTruthy(v19) {
+//line ../../clojure/test.glj:424:27
 												tmp21 := aotExternalFn22(v10)
+//line ../../clojure/test.glj:424:19
 												tmp22 := kw_macro.Invoke1(tmp21)
+//line ../../clojure/test.glj:424:14
 												tmp23 := aotExternalFn21(tmp22)
+//line ../../clojure/test.glj:423:9
 												tmp20 = tmp23
 											} else {
 												tmp20 = v19
 											}
 											tmp17 = tmp20
 										} // end let
+//line ../../clojure/test.glj:422:7
 										tmp15 = tmp17
 									} // end let
 									tmp14 = tmp15
@@ -1051,6 +1107,7 @@ func LoadNS() {
 								}
 								tmp11 = tmp14
 							} // end let
+//line ../../clojure/test.glj:421:5
 							tmp9 = tmp11
 						} // end let
 						tmp8 = tmp9
@@ -1058,11 +1115,15 @@ func LoadNS() {
 					}
 					tmp5 = tmp8
 				} // end let
+//line ../../clojure/test.glj:420:3
 				tmp3 = tmp5
 			} else {
+//line ../../clojure/test.glj:425:5
 				tmp6 := aotExternalFn20(v2)
+//line ../../clojure/test.glj:420:3
 				tmp3 = tmp6
 			}
+//line ../../clojure/test.glj:415:7
 			return tmp3
 		})
 		aotDirectFn8 = tmp1
@@ -1071,18 +1132,24 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(415), kw_column, int(7), kw_end_DASH_line, int(415), kw_end_DASH_column, int(15), kw_arglists, lang.NewList(lang.NewVector(sym_x)), kw_doc, "Returns true if argument is a function or a symbol that resolves to\n  a function (not a macro).", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1136
 	// get-possibly-unbound-var
+
+//line ../../clojure/test.glj:408:7
 	{
 		tmp0 := sym_get_DASH_possibly_DASH_unbound_DASH_var
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:412:3
 			var tmp3 any
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
+//line ../../clojure/test.glj:413:15
 						tmp4 := reflect.TypeOf((*lang.IllegalStateError)(nil))
+//line ../../clojure/test.glj:412:3
 						if lang.CatchMatches(r, tmp4) {
 							v5 := r
 							_ = v5
@@ -1091,9 +1158,12 @@ func LoadNS() {
 						}
 					}
 				}()
+//line ../../clojure/test.glj:412:8
 				tmp5 := aotExternalFn23(v2)
+//line ../../clojure/test.glj:412:3
 				tmp3 = tmp5
 			}()
+//line ../../clojure/test.glj:408:7
 			return tmp3
 		})
 		aotDirectFn9 = tmp1
@@ -1102,16 +1172,23 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(408), kw_column, int(7), kw_end_DASH_line, int(408), kw_end_DASH_column, int(30), kw_arglists, lang.NewList(lang.NewVector(sym_v)), kw_doc, "Like var-get but returns nil if the var is unbound.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1176
 	// join-fixtures
+
+//line ../../clojure/test.glj:687:7
 	{
 		tmp0 := sym_join_DASH_fixtures
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:692:11
 			tmp3 := checkDerefVar(var_clojure_DOT_test_compose_DASH_fixtures)
+//line ../../clojure/test.glj:692:28
 			tmp4 := checkDerefVar(var_clojure_DOT_test_default_DASH_fixture)
+//line ../../clojure/test.glj:692:3
 			tmp5 := aotExternalFn26(tmp3, tmp4, v2)
+//line ../../clojure/test.glj:687:7
 			return tmp5
 		})
 		aotDirectFn11 = tmp1
@@ -1120,18 +1197,25 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(687), kw_column, int(7), kw_end_DASH_line, int(687), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(sym_fixtures)), kw_doc, "Composes a collection of fixtures, in order.  Always returns a valid\n  fixture function, even if the collection is empty.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1201
 	// run-test-var
+
+//line ../../clojure/test.glj:788:7
 	{
 		tmp0 := sym_run_DASH_test_DASH_var
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:792:3
 			var tmp3 any
 			{ // let
 				tmp4 := lang.InternVarName(sym_clojure_DOT_test, sym__STAR_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:792:36
 				tmp5 := checkDerefVar(var_clojure_DOT_test__STAR_initial_DASH_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:792:31
 				tmp6 := aotExternalFn43(tmp5)
+//line ../../clojure/test.glj:792:3
 				tmp7 := aotExternalFn28(tmp4, tmp6)
 				tmp8 := aotExternalFn27(tmp7)
 				_ = tmp8
@@ -1141,37 +1225,59 @@ func LoadNS() {
 						tmp10 := aotExternalFn29()
 						_ = tmp10
 					}()
+//line ../../clojure/test.glj:793:5
 					var tmp11 any
 					{ // let
 						// let binding "ns-obj"
+
+//line ../../clojure/test.glj:793:18
 						tmp12 := aotExternalFn22(v2)
 						tmp13 := kw_ns.Invoke1(tmp12)
+//line ../../clojure/test.glj:793:5
 						var v14 any = tmp13
 						_ = v14
 						// let binding "summary"
+
+//line ../../clojure/test.glj:795:32
 						tmp15 := lang.NewMap(kw_type, kw_begin_DASH_test_DASH_ns, kw_ns, v14)
+//line ../../clojure/test.glj:795:21
 						tmp16 := aotDirectFn5(tmp15)
+//line ../../clojure/test.glj:794:19
 						_ = tmp16
+//line ../../clojure/test.glj:797:32
 						tmp17 := lang.NewVector(v2)
+//line ../../clojure/test.glj:797:21
 						tmp18 := aotDirectFn19(tmp17)
+//line ../../clojure/test.glj:794:19
 						_ = tmp18
+//line ../../clojure/test.glj:798:32
 						tmp19 := lang.NewMap(kw_type, kw_end_DASH_test_DASH_ns, kw_ns, v14)
+//line ../../clojure/test.glj:798:21
 						tmp20 := aotDirectFn5(tmp19)
+//line ../../clojure/test.glj:794:19
 						_ = tmp20
+//line ../../clojure/test.glj:800:29
 						tmp21 := checkDerefVar(var_clojure_DOT_test__STAR_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:800:28
 						tmp22 := aotExternalFn44(tmp21)
+//line ../../clojure/test.glj:800:21
 						var tmp23 any = tmp22
 						tmp23 = lang.Assoc(tmp23, kw_type, kw_summary)
+//line ../../clojure/test.glj:793:5
 						var v24 any = tmp23
 						_ = v24
+//line ../../clojure/test.glj:801:7
 						tmp25 := aotDirectFn5(v24)
+//line ../../clojure/test.glj:793:5
 						_ = tmp25
 						tmp11 = v24
 					} // end let
+//line ../../clojure/test.glj:792:3
 					tmp9 = tmp11
 				}()
 				tmp3 = tmp9
 			} // end let
+//line ../../clojure/test.glj:788:7
 			return tmp3
 		})
 		aotDirectFn13 = tmp1
@@ -1180,52 +1286,48 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(788), kw_column, int(7), kw_end_DASH_line, int(788), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_v)), kw_doc, "Runs the tests for a single Var, with fixtures executed around the test, and summary output after.", kw_added, "1.11", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1290
 	// stacktrace-file-and-line
+
+//line ../../clojure/test.glj:343:8
 	{
 		tmp0 := sym_stacktrace_DASH_file_DASH_and_DASH_line
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:345:3
 			var tmp3 any
 			tmp4 := lang.IsSeqTruthy(v2)
 			if tmp4 {
+//line ../../clojure/test.glj:346:5
 				var tmp5 any
 				{ // let
 					// let binding "s"
+
+//line ../../clojure/test.glj:346:32
 					tmp6 := lang.First(v2)
+//line ../../clojure/test.glj:346:5
 					var v7 any = tmp6
 					_ = v7
-					tmp8, ok := lang.FieldOrMethod(v7, "getFileName")
-					if !ok {
-						panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "getFileName")))
-					}
-					var tmp9 any
-					switch reflect.TypeOf(tmp8).Kind() {
-					case reflect.Func:
-						tmp9 = lang.Apply(tmp8, nil)
-					default:
-						tmp9 = tmp8
-					}
-					tmp10, ok := lang.FieldOrMethod(v7, "getLineNumber")
-					if !ok {
-						panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "getLineNumber")))
-					}
-					var tmp11 any
-					switch reflect.TypeOf(tmp10).Kind() {
-					case reflect.Func:
-						tmp11 = lang.Apply(tmp10, nil)
-					default:
-						tmp11 = tmp10
-					}
-					tmp12 := lang.NewMap(kw_file, tmp9, kw_line, tmp11)
-					tmp5 = tmp12
+//line ../../clojure/test.glj:347:14
+					tmp8 := any(v7).(interface{ GetFileName() any }).GetFileName()
+//line ../../clojure/test.glj:347:37
+					tmp9 := any(v7).(interface{ GetLineNumber() int32 }).GetLineNumber()
+//line ../../clojure/test.glj:347:7
+					tmp10 := lang.NewMap(kw_file, tmp8, kw_line, tmp9)
+//line ../../clojure/test.glj:346:5
+					tmp5 = tmp10
 				} // end let
+//line ../../clojure/test.glj:345:3
 				tmp3 = tmp5
 			} else {
+//line ../../clojure/test.glj:348:5
 				tmp6 := lang.NewMap(kw_file, nil, kw_line, nil)
+//line ../../clojure/test.glj:345:3
 				tmp3 = tmp6
 			}
+//line ../../clojure/test.glj:343:8
 			return tmp3
 		})
 		aotDirectFn15 = tmp1
@@ -1234,30 +1336,42 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(343), kw_column, int(8), kw_end_DASH_line, int(343), kw_end_DASH_column, int(31), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_stacktrace)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1340
 	// successful?
+
+//line ../../clojure/test.glj:780:7
 	{
 		tmp0 := sym_successful_QMARK_
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:785:3
 			var tmp3 any
 			{ // let
 				// let binding "and__0__auto__"
+
+//line ../../clojure/test.glj:785:15
 				tmp4 := kw_fail.Invoke2(v2, int64(0))
+//line ../../clojure/test.glj:785:8
 				tmp5 := lang.Numbers.IsZero(tmp4)
+//line ../../clojure/test.glj:785:3
 				var v6 any = tmp5
 				_ = v6
 				var tmp7 any
 				if lang.IsTruthy(v6) {
+//line ../../clojure/test.glj:786:15
 					tmp8 := kw_error.Invoke2(v2, int64(0))
+//line ../../clojure/test.glj:786:8
 					tmp9 := lang.Numbers.IsZero(tmp8)
+//line ../../clojure/test.glj:785:3
 					tmp7 = tmp9
 				} else {
 					tmp7 = v6
 				}
 				tmp3 = tmp7
 			} // end let
+//line ../../clojure/test.glj:780:7
 			return tmp3
 		})
 		aotDirectFn16 = tmp1
@@ -1266,16 +1380,23 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(780), kw_column, int(7), kw_end_DASH_line, int(780), kw_end_DASH_column, int(17), kw_arglists, lang.NewList(lang.NewVector(sym_summary)), kw_doc, "Returns true if the given test summary indicates all tests\n  were successful, false otherwise.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1384
 	// test-all-vars
+
+//line ../../clojure/test.glj:728:7
 	{
 		tmp0 := sym_test_DASH_all_DASH_vars
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:732:20
 			tmp3 := aotExternalFn48(v2)
+//line ../../clojure/test.glj:732:14
 			tmp4 := aotExternalFn47(tmp3)
+//line ../../clojure/test.glj:732:3
 			tmp5 := aotDirectFn19(tmp4)
+//line ../../clojure/test.glj:728:7
 			return tmp5
 		})
 		aotDirectFn17 = tmp1
@@ -1284,18 +1405,25 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(728), kw_column, int(7), kw_end_DASH_line, int(728), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(sym_ns)), kw_doc, "Calls test-vars on every var interned in the namespace, with fixtures.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1409
 	// test-ns
+
+//line ../../clojure/test.glj:734:7
 	{
 		tmp0 := sym_test_DASH_ns
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:744:3
 			var tmp3 any
 			{ // let
 				tmp4 := lang.InternVarName(sym_clojure_DOT_test, sym__STAR_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:744:36
 				tmp5 := checkDerefVar(var_clojure_DOT_test__STAR_initial_DASH_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:744:31
 				tmp6 := aotExternalFn43(tmp5)
+//line ../../clojure/test.glj:744:3
 				tmp7 := aotExternalFn28(tmp4, tmp6)
 				tmp8 := aotExternalFn27(tmp7)
 				_ = tmp8
@@ -1305,22 +1433,36 @@ func LoadNS() {
 						tmp10 := aotExternalFn29()
 						_ = tmp10
 					}()
+//line ../../clojure/test.glj:745:5
 					var tmp11 any
 					{ // let
 						// let binding "ns-obj"
+
+//line ../../clojure/test.glj:745:18
 						tmp12 := aotExternalFn49(v2)
+//line ../../clojure/test.glj:745:5
 						var v13 any = tmp12
 						_ = v13
+//line ../../clojure/test.glj:746:18
 						tmp14 := lang.NewMap(kw_type, kw_begin_DASH_test_DASH_ns, kw_ns, v13)
+//line ../../clojure/test.glj:746:7
 						tmp15 := aotDirectFn5(tmp14)
+//line ../../clojure/test.glj:745:5
 						_ = tmp15
+//line ../../clojure/test.glj:748:7
 						var tmp16 any
 						{ // let
 							// let binding "temp__0__auto__"
+
+//line ../../clojure/test.glj:748:41
 							tmp17 := aotExternalFn37(v13)
+//line ../../clojure/test.glj:748:36
 							tmp18 := aotExternalFn52(tmp17)
+//line ../../clojure/test.glj:748:28
 							tmp19 := aotExternalFn51(tmp18, "test-ns-hook")
+//line ../../clojure/test.glj:748:18
 							tmp20 := aotExternalFn50(tmp19)
+//line ../../clojure/test.glj:748:7
 							var v21 any = tmp20
 							_ = v21
 							var tmp22 any
@@ -1330,29 +1472,43 @@ func LoadNS() {
 									// let binding "v"
 									var v24 any = v21
 									_ = v24
+//line ../../clojure/test.glj:749:3
 									tmp25 := aotExternalFn23(v24)
+//line ../../clojure/test.glj:749:2
 									tmp26 := lang.Apply0(tmp25)
+//line ../../clojure/test.glj:748:7
 									tmp23 = tmp26
 								} // end let
 								tmp22 = tmp23
 							} else {
+//line ../../clojure/test.glj:751:9
 								tmp24 := aotDirectFn17(v13)
+//line ../../clojure/test.glj:748:7
 								tmp22 = tmp24
 							}
 							tmp16 = tmp22
 						} // end let
+//line ../../clojure/test.glj:745:5
 						_ = tmp16
+//line ../../clojure/test.glj:752:18
 						tmp17 := lang.NewMap(kw_type, kw_end_DASH_test_DASH_ns, kw_ns, v13)
+//line ../../clojure/test.glj:752:7
 						tmp18 := aotDirectFn5(tmp17)
+//line ../../clojure/test.glj:745:5
 						tmp11 = tmp18
 					} // end let
+//line ../../clojure/test.glj:744:3
 					_ = tmp11
+//line ../../clojure/test.glj:753:6
 					tmp12 := checkDerefVar(var_clojure_DOT_test__STAR_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:753:5
 					tmp13 := aotExternalFn44(tmp12)
+//line ../../clojure/test.glj:744:3
 					tmp9 = tmp13
 				}()
 				tmp3 = tmp9
 			} // end let
+//line ../../clojure/test.glj:734:7
 			return tmp3
 		})
 		aotDirectFn18 = tmp1
@@ -1361,18 +1517,26 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(734), kw_column, int(7), kw_end_DASH_line, int(734), kw_end_DASH_column, int(13), kw_arglists, lang.NewList(lang.NewVector(sym_ns)), kw_doc, "If the namespace defines a function named test-ns-hook, calls that.\n  Otherwise, calls test-all-vars on the namespace.  'ns' is a\n  namespace object or a symbol.\n\n  Internally binds *report-counters* to a ref initialized to\n  *initial-report-counters*.  Returns the final, dereferenced state of\n  *report-counters*.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1521
 	// test-var
+
+//line ../../clojure/test.glj:699:7
 	{
 		tmp0 := sym_test_DASH_var
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:704:3
 			var tmp3 any
 			{ // let
 				// let binding "temp__0__auto__"
+
+//line ../../clojure/test.glj:704:23
 				tmp4 := aotExternalFn22(v2)
+//line ../../clojure/test.glj:704:16
 				tmp5 := kw_test.Invoke1(tmp4)
+//line ../../clojure/test.glj:704:3
 				var v6 any = tmp5
 				_ = v6
 				var tmp7 any
@@ -1382,11 +1546,15 @@ func LoadNS() {
 						// let binding "t"
 						var v9 any = v6
 						_ = v9
+//line ../../clojure/test.glj:705:5
 						var tmp10 any
 						{ // let
 							tmp11 := lang.InternVarName(sym_clojure_DOT_test, sym__STAR_testing_DASH_vars_STAR_)
+//line ../../clojure/test.glj:705:36
 							tmp12 := checkDerefVar(var_clojure_DOT_test__STAR_testing_DASH_vars_STAR_)
+//line ../../clojure/test.glj:705:30
 							tmp13 := lang.ConjAny(tmp12, v2)
+//line ../../clojure/test.glj:705:5
 							tmp14 := aotExternalFn28(tmp11, tmp13)
 							tmp15 := aotExternalFn27(tmp14)
 							_ = tmp15
@@ -1396,11 +1564,17 @@ func LoadNS() {
 									tmp17 := aotExternalFn29()
 									_ = tmp17
 								}()
+//line ../../clojure/test.glj:706:18
 								tmp18 := lang.NewMap(kw_type, kw_begin_DASH_test_DASH_var, kw_var, v2)
+//line ../../clojure/test.glj:706:7
 								tmp19 := aotDirectFn5(tmp18)
+//line ../../clojure/test.glj:705:5
 								_ = tmp19
+//line ../../clojure/test.glj:707:7
 								tmp20 := aotDirectFn10(kw_test)
+//line ../../clojure/test.glj:705:5
 								_ = tmp20
+//line ../../clojure/test.glj:708:7
 								var tmp21 any
 								func() {
 									defer func() {
@@ -1408,24 +1582,34 @@ func LoadNS() {
 											if lang.CatchMatches(r, lang.Builtins["any"]) {
 												v22 := r
 												_ = v22
+//line ../../clojure/test.glj:710:25
 												tmp23 := lang.NewMap(kw_type, kw_error, kw_message, "Uncaught exception, not in assertion.", kw_expected, nil, kw_actual, v22)
+//line ../../clojure/test.glj:710:14
 												tmp24 := aotDirectFn5(tmp23)
+//line ../../clojure/test.glj:708:7
 												tmp21 = tmp24
 											} else {
 												panic(r)
 											}
 										}
 									}()
+//line ../../clojure/test.glj:708:12
 									tmp22 := lang.Apply0(v9)
+//line ../../clojure/test.glj:708:7
 									tmp21 = tmp22
 								}()
+//line ../../clojure/test.glj:705:5
 								_ = tmp21
+//line ../../clojure/test.glj:712:18
 								tmp23 := lang.NewMap(kw_type, kw_end_DASH_test_DASH_var, kw_var, v2)
+//line ../../clojure/test.glj:712:7
 								tmp24 := aotDirectFn5(tmp23)
+//line ../../clojure/test.glj:705:5
 								tmp16 = tmp24
 							}()
 							tmp10 = tmp16
 						} // end let
+//line ../../clojure/test.glj:704:3
 						tmp8 = tmp10
 					} // end let
 					tmp7 = tmp8
@@ -1433,6 +1617,7 @@ func LoadNS() {
 				}
 				tmp3 = tmp7
 			} // end let
+//line ../../clojure/test.glj:699:7
 			return tmp3
 		})
 		var_clojure_DOT_test_test_DASH_var = ns.InternWithValue(tmp0, tmp1, true)
@@ -1441,7 +1626,10 @@ func LoadNS() {
 		})
 		var_clojure_DOT_test_test_DASH_var.SetDynamic()
 	}
+//line loader.go:1630
 	// use-fixtures
+
+//line ../../clojure/test.glj:661:11
 	{
 		tmp0 := sym_use_DASH_fixtures
 		var tmp2 lang.ArityFn
@@ -1474,12 +1662,13 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../clojure/test.glj:669:3
 				tmp6 := aotDirectFn0(kw_clojure_DOT_test_SLASH_each_DASH_fixtures, v5)
+//line ../../clojure/test.glj:661:11
 				return tmp6
 			}),
 			1,
 		)
-		tmp1.AddMethod(kw_each, tmp3)
 		var tmp4 lang.ArityFn
 		tmp4 = lang.NewArityFn(
 			nil,
@@ -1492,18 +1681,26 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../clojure/test.glj:672:3
 				tmp7 := aotDirectFn0(kw_clojure_DOT_test_SLASH_once_DASH_fixtures, v6)
+//line ../../clojure/test.glj:661:11
 				return tmp7
 			}),
 			1,
 		)
-		tmp1.AddMethod(kw_once, tmp4)
+		tmp1.AddMethods(
+			kw_each, tmp3,
+			kw_once, tmp4,
+		)
 		var_clojure_DOT_test_use_DASH_fixtures = ns.InternWithValue(tmp0, tmp1, true)
 		var_clojure_DOT_test_use_DASH_fixtures.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(661), kw_column, int(11), kw_end_DASH_line, int(661), kw_end_DASH_column, int(22), kw_added, "1.1", kw_doc, "Wrap test runs in a fixture function to perform setup and\n  teardown. Using a fixture-type of :each wraps every test\n  individually, while :once wraps the whole run in a single function.", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1701
 	// add-ns-meta
+
+//line ../../clojure/test.glj:654:8
 	{
 		tmp0 := sym_add_DASH_ns_DASH_meta
 		var tmp1 lang.FnFunc2
@@ -1512,9 +1709,13 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/test.glj:659:16
 			tmp4 := checkDerefVar(var_clojure_DOT_core__STAR_ns_STAR_)
+//line ../../clojure/test.glj:659:21
 			tmp5 := checkDerefVar(var_clojure_DOT_core_assoc)
+//line ../../clojure/test.glj:659:3
 			tmp6 := aotExternalFn0(tmp4, tmp5, v2, v3)
+//line ../../clojure/test.glj:654:8
 			return tmp6
 		})
 		aotDirectFn0 = tmp1
@@ -1523,7 +1724,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(654), kw_column, int(8), kw_end_DASH_line, int(654), kw_end_DASH_column, int(18), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_key, sym_coll)), kw_doc, "Adds elements in coll to the current namespace metadata as the\n  value of key.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1728
 	// are
+
+//line ../../clojure/test.glj:563:11
 	{
 		tmp0 := sym_are
 		var tmp1 lang.ArityFn
@@ -1544,36 +1748,51 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../clojure/test.glj:578:3
 				var tmp7 any
+//line ../../clojure/test.glj:578:7
 				var tmp8 any
 				{ // let
 					// let binding "or__0__auto__"
+
+//line ../../clojure/test.glj:580:8
 					var tmp9 any
 					{ // let
 						// let binding "and__0__auto__"
+
+//line ../../clojure/test.glj:580:13
 						tmp10 := lang.IsEmpty(v4)
+//line ../../clojure/test.glj:580:8
 						var v11 any = tmp10
 						_ = v11
 						var tmp12 any
 						if lang.IsTruthy(v11) {
+//line ../../clojure/test.glj:580:27
 							tmp13 := lang.IsEmpty(v6)
+//line ../../clojure/test.glj:580:8
 							tmp12 = tmp13
 						} else {
 							tmp12 = v11
 						}
 						tmp9 = tmp12
 					} // end let
+//line ../../clojure/test.glj:578:7
 					var v10 any = tmp9
 					_ = v10
 					var tmp11 any
 					if lang.IsTruthy(v10) {
 						tmp11 = v10
 					} else {
+//line ../../clojure/test.glj:582:8
 						var tmp12 any
 						{ // let
 							// let binding "and__0__auto__"
+
+//line ../../clojure/test.glj:582:19
 							tmp13 := lang.Count(v4)
+//line ../../clojure/test.glj:582:13
 							tmp14 := lang.Numbers.IsPos(tmp13)
+//line ../../clojure/test.glj:582:8
 							var v15 any = tmp14
 							_ = v15
 							var tmp16 any
@@ -1581,16 +1800,25 @@ func LoadNS() {
 								var tmp17 any
 								{ // let
 									// let binding "and__0__auto__"
+
+//line ../../clojure/test.glj:583:19
 									tmp18 := lang.Count(v6)
+//line ../../clojure/test.glj:583:13
 									tmp19 := lang.Numbers.IsPos(tmp18)
+//line ../../clojure/test.glj:582:8
 									var v20 any = tmp19
 									_ = v20
 									var tmp21 any
 									if lang.IsTruthy(v20) {
+//line ../../clojure/test.glj:584:25
 										tmp22 := lang.Count(v6)
+//line ../../clojure/test.glj:584:38
 										tmp23 := lang.Count(v4)
+//line ../../clojure/test.glj:584:20
 										tmp24 := aotExternalFn3(tmp22, tmp23)
+//line ../../clojure/test.glj:584:13
 										tmp25 := lang.Numbers.IsZero(tmp24)
+//line ../../clojure/test.glj:582:8
 										tmp21 = tmp25
 									} else {
 										tmp21 = v20
@@ -1603,11 +1831,14 @@ func LoadNS() {
 							}
 							tmp12 = tmp16
 						} // end let
+//line ../../clojure/test.glj:578:7
 						tmp11 = tmp12
 					}
 					tmp8 = tmp11
 				} // end let
+//line ../../clojure/test.glj:578:3
 				if lang.IsTruthy(tmp8) {
+//line ../../clojure/test.glj:585:5
 					tmp9 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp10 := lang.Apply1(tmp9, sym_clojure_DOT_template_SLASH_do_DASH_template)
 					tmp11 := checkDerefVar(var_clojure_DOT_core_list)
@@ -1622,11 +1853,16 @@ func LoadNS() {
 					tmp20 := lang.Apply1(tmp13, tmp19)
 					tmp21 := aotExternalFn5(tmp10, tmp12, tmp20, v6)
 					tmp22 := lang.Seq(tmp21)
+//line ../../clojure/test.glj:578:3
 					tmp7 = tmp22
 				} else {
+//line ../../clojure/test.glj:586:12
 					tmp23 := lang.Apply1(lang.NewIllegalArgumentError, "The number of args doesn't match are's argv.")
+//line ../../clojure/test.glj:586:5
 					panic(tmp23)
+//line ../../clojure/test.glj:578:3
 				}
+//line ../../clojure/test.glj:563:11
 				return tmp7
 			}),
 			4,
@@ -1636,7 +1872,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(563), kw_column, int(11), kw_end_DASH_line, int(563), kw_end_DASH_column, int(13), kw_arglists, lang.NewList(lang.NewVector(sym_argv, sym_expr, sym__AMP_, sym_args)), kw_doc, "Checks multiple assertions with a template expression.\n  See clojure.template/do-template for an explanation of\n  templates.\n\n  Example: (are [x y] (= x y)\n                2 (+ 1 1)\n                4 (* 2 2))\n  Expands to:\n           (do (is (= 2 (+ 1 1)))\n               (is (= 4 (* 2 2))))\n\n  Note: This breaks some reporting features, such as line numbers.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:1876
 	// assert-any
+
+//line ../../clojure/test.glj:446:7
 	{
 		tmp0 := sym_assert_DASH_any
 		var tmp1 lang.FnFunc2
@@ -1645,6 +1884,7 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/test.glj:451:3
 			tmp4 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp5 := lang.Apply1(tmp4, sym_clojure_DOT_core_SLASH_let)
 			tmp6 := checkDerefVar(var_clojure_DOT_core_list)
@@ -1739,6 +1979,7 @@ func LoadNS() {
 			tmp95 := lang.Apply1(tmp94, sym_value__0__auto__)
 			tmp96 := aotExternalFn5(tmp5, tmp15, tmp93, tmp95)
 			tmp97 := lang.Seq(tmp96)
+//line ../../clojure/test.glj:446:7
 			return tmp97
 		})
 		aotDirectFn1 = tmp1
@@ -1747,7 +1988,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(446), kw_column, int(7), kw_end_DASH_line, int(446), kw_end_DASH_column, int(16), kw_arglists, lang.NewList(lang.NewVector(sym_msg, sym_form)), kw_doc, "Returns generic assertion code for any test, including macros, Java\n  method calls, or isolated symbols.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:1992
 	// assert-expr
+
+//line ../../clojure/test.glj:467:11
 	{
 		tmp0 := sym_assert_DASH_expr
 		var tmp2 lang.FnFunc2
@@ -1756,21 +2000,29 @@ func LoadNS() {
 			_ = v3
 			v4 := p1
 			_ = v4
+//line ../../clojure/test.glj:469:5
 			var tmp5 any
+//line ../../clojure/test.glj:470:7
 			tmp6 := lang.Identical(v4, nil)
+//line ../../clojure/test.glj:469:5
 			if lang.IsTruthy(tmp6) {
 				tmp5 = kw_always_DASH_fail
 			} else {
 				var tmp7 any
+//line ../../clojure/test.glj:471:7
 				tmp8 := aotExternalFn8(v4)
+//line ../../clojure/test.glj:469:5
 				if lang.IsTruthy(tmp8) {
+//line ../../clojure/test.glj:471:19
 					tmp9 := lang.First(v4)
+//line ../../clojure/test.glj:469:5
 					tmp7 = tmp9
 				} else {
 					tmp7 = kw_default
 				}
 				tmp5 = tmp7
 			}
+//line ../../clojure/test.glj:467:11
 			return tmp5
 		})
 		// MultiFn assert-expr
@@ -1781,6 +2033,7 @@ func LoadNS() {
 			_ = v4
 			v5 := p1
 			_ = v5
+//line ../../clojure/test.glj:476:3
 			tmp6 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp7 := lang.Apply1(tmp6, sym_clojure_DOT_test_SLASH_do_DASH_report)
 			tmp8 := checkDerefVar(var_clojure_DOT_core_list)
@@ -1799,48 +2052,62 @@ func LoadNS() {
 			tmp21 := lang.Apply1(tmp8, tmp20)
 			tmp22 := aotExternalFn6(tmp7, tmp21)
 			tmp23 := lang.Seq(tmp22)
+//line ../../clojure/test.glj:467:11
 			return tmp23
 		})
-		tmp1.AddMethod(kw_always_DASH_fail, tmp3)
 		var tmp4 lang.FnFunc2
 		tmp4 = lang.FnFunc2(func(p0, p1 any) any {
 			v5 := p0
 			_ = v5
 			v6 := p1
 			_ = v6
+//line ../../clojure/test.glj:479:3
 			var tmp7 any
+//line ../../clojure/test.glj:479:7
 			var tmp8 any
 			{ // let
 				// let binding "and__0__auto__"
+
+//line ../../clojure/test.glj:479:12
 				tmp9 := aotExternalFn10(v6)
+//line ../../clojure/test.glj:479:7
 				var v10 any = tmp9
 				_ = v10
 				var tmp11 any
 				if lang.IsTruthy(v10) {
+//line ../../clojure/test.glj:479:42
 					tmp12 := lang.First(v6)
+//line ../../clojure/test.glj:479:31
 					tmp13 := aotDirectFn8(tmp12)
+//line ../../clojure/test.glj:479:7
 					tmp11 = tmp13
 				} else {
 					tmp11 = v10
 				}
 				tmp8 = tmp11
 			} // end let
+//line ../../clojure/test.glj:479:3
 			if lang.IsTruthy(tmp8) {
+//line ../../clojure/test.glj:480:5
 				tmp9 := aotDirectFn2(v5, v6)
+//line ../../clojure/test.glj:479:3
 				tmp7 = tmp9
 			} else {
+//line ../../clojure/test.glj:481:5
 				tmp10 := aotDirectFn1(v5, v6)
+//line ../../clojure/test.glj:479:3
 				tmp7 = tmp10
 			}
+//line ../../clojure/test.glj:467:11
 			return tmp7
 		})
-		tmp1.AddMethod(kw_default, tmp4)
 		var tmp5 lang.FnFunc2
 		tmp5 = lang.FnFunc2(func(p0, p1 any) any {
 			v6 := p0
 			_ = v6
 			v7 := p1
 			_ = v7
+//line ../../clojure/test.glj:485:3
 			tmp8 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp9 := lang.Apply1(tmp8, sym_clojure_DOT_core_SLASH_let)
 			tmp10 := checkDerefVar(var_clojure_DOT_core_list)
@@ -1848,12 +2115,16 @@ func LoadNS() {
 			tmp12 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp13 := lang.Apply1(tmp12, sym_klass__0__auto__)
 			tmp14 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../clojure/test.glj:485:18
 			tmp15 := runtime.RT.Nth(v7, lang.IntCast(int64(1)))
+//line ../../clojure/test.glj:485:3
 			tmp16 := lang.Apply1(tmp14, tmp15)
 			tmp17 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp18 := lang.Apply1(tmp17, sym_object__1__auto__)
 			tmp19 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../clojure/test.glj:486:19
 			tmp20 := runtime.RT.Nth(v7, lang.IntCast(int64(2)))
+//line ../../clojure/test.glj:485:3
 			tmp21 := lang.Apply1(tmp19, tmp20)
 			tmp22 := aotExternalFn5(tmp13, tmp16, tmp18, tmp21)
 			tmp23 := lang.Seq(tmp22)
@@ -1977,25 +2248,33 @@ func LoadNS() {
 			tmp141 := lang.Apply1(tmp26, tmp140)
 			tmp142 := aotExternalFn11(tmp9, tmp25, tmp141)
 			tmp143 := lang.Seq(tmp142)
+//line ../../clojure/test.glj:467:11
 			return tmp143
 		})
-		tmp1.AddMethod(sym_instance_QMARK_, tmp5)
 		var tmp6 lang.FnFunc2
 		tmp6 = lang.FnFunc2(func(p0, p1 any) any {
 			v7 := p0
 			_ = v7
 			v8 := p1
 			_ = v8
+//line ../../clojure/test.glj:499:3
 			var tmp9 any
 			{ // let
 				// let binding "klass"
+
+//line ../../clojure/test.glj:499:15
 				tmp10 := aotExternalFn12(v8)
+//line ../../clojure/test.glj:499:3
 				var v11 any = tmp10
 				_ = v11
 				// let binding "body"
+
+//line ../../clojure/test.glj:500:14
 				tmp12 := aotExternalFn13(v8, int64(2))
+//line ../../clojure/test.glj:499:3
 				var v13 any = tmp12
 				_ = v13
+//line ../../clojure/test.glj:501:5
 				tmp14 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp15 := lang.Apply1(tmp14, sym_try)
 				tmp16 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2083,31 +2362,43 @@ func LoadNS() {
 				tmp98 := lang.Apply1(tmp51, tmp97)
 				tmp99 := aotExternalFn5(tmp15, v13, tmp50, tmp98)
 				tmp100 := lang.Seq(tmp99)
+//line ../../clojure/test.glj:499:3
 				tmp9 = tmp100
 			} // end let
+//line ../../clojure/test.glj:467:11
 			return tmp9
 		})
-		tmp1.AddMethod(sym_thrown_QMARK_, tmp6)
 		var tmp7 lang.FnFunc2
 		tmp7 = lang.FnFunc2(func(p0, p1 any) any {
 			v8 := p0
 			_ = v8
 			v9 := p1
 			_ = v9
+//line ../../clojure/test.glj:514:3
 			var tmp10 any
 			{ // let
 				// let binding "klass"
+
+//line ../../clojure/test.glj:514:15
 				tmp11 := runtime.RT.Nth(v9, lang.IntCast(int64(1)))
+//line ../../clojure/test.glj:514:3
 				var v12 any = tmp11
 				_ = v12
 				// let binding "re"
+
+//line ../../clojure/test.glj:515:12
 				tmp13 := runtime.RT.Nth(v9, lang.IntCast(int64(2)))
+//line ../../clojure/test.glj:514:3
 				var v14 any = tmp13
 				_ = v14
 				// let binding "body"
+
+//line ../../clojure/test.glj:516:14
 				tmp15 := aotExternalFn13(v9, int64(3))
+//line ../../clojure/test.glj:514:3
 				var v16 any = tmp15
 				_ = v16
+//line ../../clojure/test.glj:517:5
 				tmp17 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp18 := lang.Apply1(tmp17, sym_try)
 				tmp19 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2268,17 +2559,28 @@ func LoadNS() {
 				tmp174 := lang.Apply1(tmp54, tmp173)
 				tmp175 := aotExternalFn5(tmp18, v16, tmp53, tmp174)
 				tmp176 := lang.Seq(tmp175)
+//line ../../clojure/test.glj:514:3
 				tmp10 = tmp176
 			} // end let
+//line ../../clojure/test.glj:467:11
 			return tmp10
 		})
-		tmp1.AddMethod(sym_thrown_DASH_with_DASH_msg_QMARK_, tmp7)
+		tmp1.AddMethods(
+			kw_always_DASH_fail, tmp3,
+			kw_default, tmp4,
+			sym_instance_QMARK_, tmp5,
+			sym_thrown_QMARK_, tmp6,
+			sym_thrown_DASH_with_DASH_msg_QMARK_, tmp7,
+		)
 		var_clojure_DOT_test_assert_DASH_expr = ns.InternWithValue(tmp0, tmp1, true)
 		var_clojure_DOT_test_assert_DASH_expr.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "clojure/test.glj", kw_line, int(467), kw_column, int(11), kw_end_DASH_line, int(467), kw_end_DASH_column, int(21), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:2581
 	// assert-predicate
+
+//line ../../clojure/test.glj:427:7
 	{
 		tmp0 := sym_assert_DASH_predicate
 		var tmp1 lang.FnFunc2
@@ -2287,16 +2589,24 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/test.glj:435:3
 			var tmp4 any
 			{ // let
 				// let binding "args"
+
+//line ../../clojure/test.glj:435:14
 				tmp5 := aotExternalFn14(v3)
+//line ../../clojure/test.glj:435:3
 				var v6 any = tmp5
 				_ = v6
 				// let binding "pred"
+
+//line ../../clojure/test.glj:436:14
 				tmp7 := lang.First(v3)
+//line ../../clojure/test.glj:435:3
 				var v8 any = tmp7
 				_ = v8
+//line ../../clojure/test.glj:437:5
 				tmp9 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp10 := lang.Apply1(tmp9, sym_clojure_DOT_core_SLASH_let)
 				tmp11 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2449,8 +2759,10 @@ func LoadNS() {
 				tmp158 := lang.Apply1(tmp157, sym_result__1__auto__)
 				tmp159 := aotExternalFn5(tmp10, tmp36, tmp156, tmp158)
 				tmp160 := lang.Seq(tmp159)
+//line ../../clojure/test.glj:435:3
 				tmp4 = tmp160
 			} // end let
+//line ../../clojure/test.glj:427:7
 			return tmp4
 		})
 		aotDirectFn2 = tmp1
@@ -2459,7 +2771,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(427), kw_column, int(7), kw_end_DASH_line, int(427), kw_end_DASH_column, int(22), kw_arglists, lang.NewList(lang.NewVector(sym_msg, sym_form)), kw_doc, "Returns generic assertion code for any functional predicate.  The\n  'expected' argument to 'report' will contains the original form, the\n  'actual' argument will contain the form with all its sub-forms\n  evaluated.  If the predicate returns false, the 'actual' form will\n  be wrapped in (not...).", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:2775
 	// deftest
+
+//line ../../clojure/test.glj:613:11
 	{
 		tmp0 := sym_deftest
 		var tmp1 lang.ArityFn
@@ -2478,13 +2793,19 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../clojure/test.glj:626:3
 				var tmp6 any
+//line ../../clojure/test.glj:626:9
 				tmp7 := checkDerefVar(var_clojure_DOT_test__STAR_load_DASH_tests_STAR_)
+//line ../../clojure/test.glj:626:3
 				if lang.IsTruthy(tmp7) {
+//line ../../clojure/test.glj:627:5
 					tmp8 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp9 := lang.Apply1(tmp8, sym_def)
 					tmp10 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../clojure/test.glj:627:28
 					tmp11 := checkDerefVar(var_clojure_DOT_core_assoc)
+//line ../../clojure/test.glj:627:40
 					tmp12 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp13 := lang.Apply1(tmp12, sym_clojure_DOT_core_SLASH_fn)
 					tmp14 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2495,7 +2816,9 @@ func LoadNS() {
 					tmp19 := lang.Apply1(tmp14, tmp18)
 					tmp20 := aotExternalFn11(tmp13, tmp19, v5)
 					tmp21 := lang.Seq(tmp20)
+//line ../../clojure/test.glj:627:12
 					tmp22 := aotExternalFn15(v4, tmp11, kw_test, tmp21)
+//line ../../clojure/test.glj:627:5
 					tmp23 := lang.Apply1(tmp10, tmp22)
 					tmp24 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp25 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2525,9 +2848,11 @@ func LoadNS() {
 					tmp49 := lang.Apply1(tmp24, tmp48)
 					tmp50 := aotExternalFn11(tmp9, tmp23, tmp49)
 					tmp51 := lang.Seq(tmp50)
+//line ../../clojure/test.glj:626:3
 					tmp6 = tmp51
 				} else {
 				}
+//line ../../clojure/test.glj:613:11
 				return tmp6
 			}),
 			3,
@@ -2537,7 +2862,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(613), kw_column, int(11), kw_end_DASH_line, int(613), kw_end_DASH_column, int(17), kw_arglists, lang.NewList(lang.NewVector(sym_name, sym__AMP_, sym_body)), kw_doc, "Defines a test function with no arguments.  Test functions may call\n  other tests, so tests may be composed.  If you compose tests, you\n  should also define a function named test-ns-hook; run-tests will\n  call test-ns-hook instead of testing all vars.\n\n  Note: Actually, the test body goes in the :test metadata on the var,\n  and the real function (the value of the var) calls test-var on\n  itself.\n\n  When *load-tests* is false, deftest is ignored.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:2866
 	// deftest-
+
+//line ../../clojure/test.glj:630:11
 	{
 		tmp0 := sym_deftest_DASH_
 		var tmp1 lang.ArityFn
@@ -2556,14 +2884,21 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../clojure/test.glj:634:3
 				var tmp6 any
+//line ../../clojure/test.glj:634:9
 				tmp7 := checkDerefVar(var_clojure_DOT_test__STAR_load_DASH_tests_STAR_)
+//line ../../clojure/test.glj:634:3
 				if lang.IsTruthy(tmp7) {
+//line ../../clojure/test.glj:635:5
 					tmp8 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp9 := lang.Apply1(tmp8, sym_def)
 					tmp10 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../clojure/test.glj:635:13
 					tmp11 := checkDerefVar(var_clojure_DOT_core_vary_DASH_meta)
+//line ../../clojure/test.glj:635:28
 					tmp12 := checkDerefVar(var_clojure_DOT_core_assoc)
+//line ../../clojure/test.glj:635:40
 					tmp13 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp14 := lang.Apply1(tmp13, sym_clojure_DOT_core_SLASH_fn)
 					tmp15 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2574,7 +2909,9 @@ func LoadNS() {
 					tmp20 := lang.Apply1(tmp15, tmp19)
 					tmp21 := aotExternalFn11(tmp14, tmp20, v5)
 					tmp22 := lang.Seq(tmp21)
+//line ../../clojure/test.glj:635:12
 					tmp23 := lang.Apply6(tmp11, v4, tmp12, kw_test, tmp22, kw_private, true)
+//line ../../clojure/test.glj:635:5
 					tmp24 := lang.Apply1(tmp10, tmp23)
 					tmp25 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp26 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2604,9 +2941,11 @@ func LoadNS() {
 					tmp50 := lang.Apply1(tmp25, tmp49)
 					tmp51 := aotExternalFn11(tmp9, tmp24, tmp50)
 					tmp52 := lang.Seq(tmp51)
+//line ../../clojure/test.glj:634:3
 					tmp6 = tmp52
 				} else {
 				}
+//line ../../clojure/test.glj:630:11
 				return tmp6
 			}),
 			3,
@@ -2616,18 +2955,26 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(630), kw_column, int(11), kw_end_DASH_line, int(630), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_name, sym__AMP_, sym_body)), kw_doc, "Like deftest but creates a private var.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:2959
 	// do-report
+
+//line ../../clojure/test.glj:350:7
 	{
 		tmp0 := sym_do_DASH_report
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:356:4
 			tmp3 := checkDerefVar(var_clojure_DOT_test_report)
+//line ../../clojure/test.glj:357:4
 			var tmp4 any
 			{ // let
 				// let binding "G__745"
+
+//line ../../clojure/test.glj:358:5
 				tmp5 := kw_type.Invoke1(v2)
+//line ../../clojure/test.glj:357:4
 				var v6 any = tmp5
 				_ = v6
 				// case
@@ -2637,8 +2984,11 @@ func LoadNS() {
 				// case entry 0 (key=0, collision=false)
 				if tmp8 == 0 {
 					if v6 == kw_error {
+//line ../../clojure/test.glj:360:19
 						tmp9 := lang.NewMap()
+//line ../../clojure/test.glj:360:12
 						tmp10 := aotExternalFn17(tmp9, v2)
+//line ../../clojure/test.glj:357:4
 						tmp7 = tmp10
 					} else {
 						tmp7 = v2
@@ -2646,8 +2996,11 @@ func LoadNS() {
 					// case entry 1 (key=1, collision=false)
 				} else if tmp8 == 1 {
 					if v6 == kw_fail {
+//line ../../clojure/test.glj:359:18
 						tmp11 := lang.NewMap()
+//line ../../clojure/test.glj:359:11
 						tmp12 := aotExternalFn17(tmp11, v2)
+//line ../../clojure/test.glj:357:4
 						tmp7 = tmp12
 					} else {
 						tmp7 = v2
@@ -2657,7 +3010,9 @@ func LoadNS() {
 				}
 				tmp4 = tmp7
 			} // end let
+//line ../../clojure/test.glj:356:3
 			tmp5 := lang.Apply1(tmp3, tmp4)
+//line ../../clojure/test.glj:350:7
 			return tmp5
 		})
 		aotDirectFn5 = tmp1
@@ -2666,30 +3021,46 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(350), kw_column, int(7), kw_end_DASH_line, int(350), kw_end_DASH_column, int(15), kw_arglists, lang.NewList(lang.NewVector(sym_m)), kw_doc, "Add file and line information to a test result and call report.\n   If you are writing a custom assert-expr method, call this function\n   to pass test results to report.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:3025
 	// inc-report-counter
+
+//line ../../clojure/test.glj:313:7
 	{
 		tmp0 := sym_inc_DASH_report_DASH_counter
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:318:3
 			var tmp3 any
+//line ../../clojure/test.glj:318:9
 			tmp4 := checkDerefVar(var_clojure_DOT_test__STAR_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:318:3
 			if lang.IsTruthy(tmp4) {
+//line ../../clojure/test.glj:319:5
 				var tmp5 lang.FnFunc0
 				tmp5 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:319:22
 					tmp6 := checkDerefVar(var_clojure_DOT_test__STAR_report_DASH_counters_STAR_)
+//line ../../clojure/test.glj:319:40
 					tmp7 := checkDerefVar(var_clojure_DOT_core_update_DASH_in)
+//line ../../clojure/test.glj:319:50
 					tmp8 := lang.NewVector(v2)
+//line ../../clojure/test.glj:319:63
 					tmp9 := checkDerefVar(var_clojure_DOT_core_inc)
+//line ../../clojure/test.glj:319:57
 					tmp10 := aotExternalFn25(tmp9, int64(0))
+//line ../../clojure/test.glj:319:13
 					tmp11 := aotExternalFn24(tmp6, tmp7, tmp8, tmp10)
+//line ../../clojure/test.glj:319:5
 					return tmp11
 				})
 				tmp6 := lang.LockingTransaction.RunInTransaction(lang.MustHostCast[lang.IFn](tmp5))
+//line ../../clojure/test.glj:318:3
 				tmp3 = tmp6
 			} else {
 			}
+//line ../../clojure/test.glj:313:7
 			return tmp3
 		})
 		aotDirectFn10 = tmp1
@@ -2698,7 +3069,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(313), kw_column, int(7), kw_end_DASH_line, int(313), kw_end_DASH_column, int(24), kw_arglists, lang.NewList(lang.NewVector(sym_name)), kw_doc, "Increments the named counter in *report-counters*, a ref to a map.\n  Does nothing if *report-counters* is nil.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:3073
 	// is
+
+//line ../../clojure/test.glj:545:11
 	{
 		tmp0 := sym_is
 		var tmp1 lang.ArityFn
@@ -2713,6 +3087,7 @@ func LoadNS() {
 				_ = v3
 				v4 := p2
 				_ = v4
+//line ../../clojure/test.glj:560:11
 				tmp5 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp6 := lang.Apply1(tmp5, sym_clojure_DOT_test_SLASH_is)
 				tmp7 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2721,6 +3096,7 @@ func LoadNS() {
 				tmp10 := lang.Apply1(tmp9, nil)
 				tmp11 := aotExternalFn11(tmp6, tmp8, tmp10)
 				tmp12 := lang.Seq(tmp11)
+//line ../../clojure/test.glj:545:11
 				return tmp12
 			}),
 			lang.FnFunc4(func(p0, p1, p2, p3 any) any {
@@ -2732,6 +3108,7 @@ func LoadNS() {
 				_ = v4
 				v5 := p3
 				_ = v5
+//line ../../clojure/test.glj:561:15
 				tmp6 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp7 := lang.Apply1(tmp6, sym_clojure_DOT_test_SLASH_try_DASH_expr)
 				tmp8 := checkDerefVar(var_clojure_DOT_core_list)
@@ -2740,6 +3117,7 @@ func LoadNS() {
 				tmp11 := lang.Apply1(tmp10, v4)
 				tmp12 := aotExternalFn11(tmp7, tmp9, tmp11)
 				tmp13 := lang.Seq(tmp12)
+//line ../../clojure/test.glj:545:11
 				return tmp13
 			}),
 			nil,
@@ -2750,7 +3128,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(545), kw_column, int(11), kw_end_DASH_line, int(545), kw_end_DASH_column, int(12), kw_arglists, lang.NewList(lang.NewVector(sym_form), lang.NewVector(sym_form, sym_msg)), kw_doc, "Generic assertion macro.  'form' is any predicate test.\n  'msg' is an optional message to attach to the assertion.\n\n  Example: (is (= 4 (+ 2 2)) \"Two plus two should be 4\")\n\n  Special forms:\n\n  (is (thrown? c body)) checks that an instance of c is thrown from\n  body, fails if not; then returns the thing thrown.\n\n  (is (thrown-with-msg? c re body)) checks that an instance of c is\n  thrown AND that the message on the exception matches (with\n  re-find) the regular expression re.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:3132
 	// report
+
+//line ../../clojure/test.glj:324:3
 	{
 		tmp0 := sym_report
 		// MultiFn report
@@ -2759,6 +3140,7 @@ func LoadNS() {
 		tmp2 = lang.FnFunc1(func(p0 any) any {
 			v3 := p0
 			_ = v3
+//line ../../clojure/test.glj:364:3
 			var tmp4 any
 			{ // let
 				tmp5 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
@@ -2786,18 +3168,21 @@ func LoadNS() {
 						tmp10 := aotExternalFn29()
 						_ = tmp10
 					}()
+//line ../../clojure/test.glj:364:18
 					tmp11 := aotExternalFn30(v3)
+//line ../../clojure/test.glj:364:3
 					tmp9 = tmp11
 				}()
 				tmp4 = tmp9
 			} // end let
+//line ../../clojure/test.glj:324:3
 			return tmp4
 		})
-		tmp1.AddMethod(kw_default, tmp2)
 		var tmp3 lang.FnFunc1
 		tmp3 = lang.FnFunc1(func(p0 any) any {
 			v4 := p0
 			_ = v4
+//line ../../clojure/test.glj:367:3
 			var tmp5 any
 			{ // let
 				tmp6 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
@@ -2825,18 +3210,21 @@ func LoadNS() {
 						tmp11 := aotExternalFn29()
 						_ = tmp11
 					}()
+//line ../../clojure/test.glj:367:18
 					tmp12 := aotDirectFn10(kw_pass)
+//line ../../clojure/test.glj:367:3
 					tmp10 = tmp12
 				}()
 				tmp5 = tmp10
 			} // end let
+//line ../../clojure/test.glj:324:3
 			return tmp5
 		})
-		tmp1.AddMethod(kw_pass, tmp3)
 		var tmp4 lang.FnFunc1
 		tmp4 = lang.FnFunc1(func(p0 any) any {
 			v5 := p0
 			_ = v5
+//line ../../clojure/test.glj:370:3
 			var tmp6 any
 			{ // let
 				tmp7 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
@@ -2864,25 +3252,41 @@ func LoadNS() {
 						tmp12 := aotExternalFn29()
 						_ = tmp12
 					}()
+//line ../../clojure/test.glj:371:5
 					tmp13 := aotDirectFn10(kw_fail)
+//line ../../clojure/test.glj:370:3
 					_ = tmp13
+//line ../../clojure/test.glj:372:26
 					tmp14 := aotDirectFn21(v5)
+//line ../../clojure/test.glj:372:5
 					tmp15 := aotExternalFn31("\nFAIL in", tmp14)
+//line ../../clojure/test.glj:370:3
 					_ = tmp15
+//line ../../clojure/test.glj:373:5
 					var tmp16 any
+//line ../../clojure/test.glj:373:16
 					tmp17 := checkDerefVar(var_clojure_DOT_test__STAR_testing_DASH_contexts_STAR_)
+//line ../../clojure/test.glj:373:5
 					tmp18 := lang.IsSeqTruthy(tmp17)
 					if tmp18 {
+//line ../../clojure/test.glj:373:45
 						tmp19 := aotDirectFn20()
+//line ../../clojure/test.glj:373:36
 						tmp20 := aotExternalFn32(tmp19)
+//line ../../clojure/test.glj:373:5
 						tmp16 = tmp20
 					} else {
 					}
+//line ../../clojure/test.glj:370:3
 					_ = tmp16
+//line ../../clojure/test.glj:374:5
 					var tmp21 any
 					{ // let
 						// let binding "temp__0__auto__"
+
+//line ../../clojure/test.glj:374:24
 						tmp22 := kw_message.Invoke1(v5)
+//line ../../clojure/test.glj:374:5
 						var v23 any = tmp22
 						_ = v23
 						var tmp24 any
@@ -2892,7 +3296,9 @@ func LoadNS() {
 								// let binding "message"
 								var v26 any = v23
 								_ = v26
+//line ../../clojure/test.glj:374:38
 								tmp27 := aotExternalFn32(v26)
+//line ../../clojure/test.glj:374:5
 								tmp25 = tmp27
 							} // end let
 							tmp24 = tmp25
@@ -2900,25 +3306,35 @@ func LoadNS() {
 						}
 						tmp21 = tmp24
 					} // end let
+//line ../../clojure/test.glj:370:3
 					_ = tmp21
+//line ../../clojure/test.glj:375:34
 					tmp22 := kw_expected.Invoke1(v5)
+//line ../../clojure/test.glj:375:26
 					tmp23 := aotExternalFn33(tmp22)
+//line ../../clojure/test.glj:375:5
 					tmp24 := aotExternalFn31("expected:", tmp23)
+//line ../../clojure/test.glj:370:3
 					_ = tmp24
+//line ../../clojure/test.glj:376:34
 					tmp25 := kw_actual.Invoke1(v5)
+//line ../../clojure/test.glj:376:26
 					tmp26 := aotExternalFn33(tmp25)
+//line ../../clojure/test.glj:376:5
 					tmp27 := aotExternalFn31("  actual:", tmp26)
+//line ../../clojure/test.glj:370:3
 					tmp11 = tmp27
 				}()
 				tmp6 = tmp11
 			} // end let
+//line ../../clojure/test.glj:324:3
 			return tmp6
 		})
-		tmp1.AddMethod(kw_fail, tmp4)
 		var tmp5 lang.FnFunc1
 		tmp5 = lang.FnFunc1(func(p0 any) any {
 			v6 := p0
 			_ = v6
+//line ../../clojure/test.glj:379:3
 			var tmp7 any
 			{ // let
 				tmp8 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
@@ -2946,25 +3362,41 @@ func LoadNS() {
 						tmp13 := aotExternalFn29()
 						_ = tmp13
 					}()
+//line ../../clojure/test.glj:380:4
 					tmp14 := aotDirectFn10(kw_error)
+//line ../../clojure/test.glj:379:3
 					_ = tmp14
+//line ../../clojure/test.glj:381:26
 					tmp15 := aotDirectFn21(v6)
+//line ../../clojure/test.glj:381:4
 					tmp16 := aotExternalFn31("\nERROR in", tmp15)
+//line ../../clojure/test.glj:379:3
 					_ = tmp16
+//line ../../clojure/test.glj:382:4
 					var tmp17 any
+//line ../../clojure/test.glj:382:15
 					tmp18 := checkDerefVar(var_clojure_DOT_test__STAR_testing_DASH_contexts_STAR_)
+//line ../../clojure/test.glj:382:4
 					tmp19 := lang.IsSeqTruthy(tmp18)
 					if tmp19 {
+//line ../../clojure/test.glj:382:44
 						tmp20 := aotDirectFn20()
+//line ../../clojure/test.glj:382:35
 						tmp21 := aotExternalFn32(tmp20)
+//line ../../clojure/test.glj:382:4
 						tmp17 = tmp21
 					} else {
 					}
+//line ../../clojure/test.glj:379:3
 					_ = tmp17
+//line ../../clojure/test.glj:383:4
 					var tmp22 any
 					{ // let
 						// let binding "temp__0__auto__"
+
+//line ../../clojure/test.glj:383:23
 						tmp23 := kw_message.Invoke1(v6)
+//line ../../clojure/test.glj:383:4
 						var v24 any = tmp23
 						_ = v24
 						var tmp25 any
@@ -2974,7 +3406,9 @@ func LoadNS() {
 								// let binding "message"
 								var v27 any = v24
 								_ = v27
+//line ../../clojure/test.glj:383:37
 								tmp28 := aotExternalFn32(v27)
+//line ../../clojure/test.glj:383:4
 								tmp26 = tmp28
 							} // end let
 							tmp25 = tmp26
@@ -2982,22 +3416,37 @@ func LoadNS() {
 						}
 						tmp22 = tmp25
 					} // end let
+//line ../../clojure/test.glj:379:3
 					_ = tmp22
+//line ../../clojure/test.glj:384:33
 					tmp23 := kw_expected.Invoke1(v6)
+//line ../../clojure/test.glj:384:25
 					tmp24 := aotExternalFn33(tmp23)
+//line ../../clojure/test.glj:384:4
 					tmp25 := aotExternalFn31("expected:", tmp24)
+//line ../../clojure/test.glj:379:3
 					_ = tmp25
+//line ../../clojure/test.glj:385:4
 					tmp26 := aotExternalFn34("  actual: ")
+//line ../../clojure/test.glj:379:3
 					_ = tmp26
+//line ../../clojure/test.glj:386:4
 					var tmp27 any
 					{ // let
 						// let binding "actual"
+
+//line ../../clojure/test.glj:386:17
 						tmp28 := kw_actual.Invoke1(v6)
+//line ../../clojure/test.glj:386:4
 						var v29 any = tmp28
 						_ = v29
+//line ../../clojure/test.glj:387:6
 						var tmp30 any
+//line ../../clojure/test.glj:387:10
 						tmp31 := aotExternalFn35(lang.Builtins["error"], v29)
+//line ../../clojure/test.glj:387:6
 						if lang.IsTruthy(tmp31) {
+//line ../../clojure/test.glj:387:43
 							tmp32, ok := lang.FieldOrMethod(v29, "Error")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v29, "Error")))
@@ -3009,25 +3458,32 @@ func LoadNS() {
 							default:
 								tmp33 = tmp32
 							}
+//line ../../clojure/test.glj:387:38
 							tmp34 := aotExternalFn30(tmp33)
+//line ../../clojure/test.glj:387:6
 							tmp30 = tmp34
 						} else {
+//line ../../clojure/test.glj:387:60
 							tmp35 := aotExternalFn30(v29)
+//line ../../clojure/test.glj:387:6
 							tmp30 = tmp35
 						}
+//line ../../clojure/test.glj:386:4
 						tmp27 = tmp30
 					} // end let
+//line ../../clojure/test.glj:379:3
 					tmp12 = tmp27
 				}()
 				tmp7 = tmp12
 			} // end let
+//line ../../clojure/test.glj:324:3
 			return tmp7
 		})
-		tmp1.AddMethod(kw_error, tmp5)
 		var tmp6 lang.FnFunc1
 		tmp6 = lang.FnFunc1(func(p0 any) any {
 			v7 := p0
 			_ = v7
+//line ../../clojure/test.glj:390:3
 			var tmp8 any
 			{ // let
 				tmp9 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
@@ -3055,29 +3511,43 @@ func LoadNS() {
 						tmp14 := aotExternalFn29()
 						_ = tmp14
 					}()
+//line ../../clojure/test.glj:391:5
 					tmp15 := checkDerefVar(var_clojure_DOT_core_println)
+//line ../../clojure/test.glj:391:21
 					tmp16 := kw_test.Invoke1(v7)
+//line ../../clojure/test.glj:392:16
 					tmp17 := kw_pass.Invoke1(v7)
+//line ../../clojure/test.glj:392:26
 					tmp18 := kw_fail.Invoke1(v7)
+//line ../../clojure/test.glj:392:13
 					tmp19 := lang.Numbers.Add(tmp17, tmp18)
+//line ../../clojure/test.glj:392:36
 					tmp20 := kw_error.Invoke1(v7)
+//line ../../clojure/test.glj:392:13
 					tmp21 := lang.Numbers.Add(tmp19, tmp20)
+//line ../../clojure/test.glj:391:4
 					tmp22 := lang.Apply5(tmp15, "\nRan", tmp16, "tests containing", tmp21, "assertions.")
+//line ../../clojure/test.glj:390:3
 					_ = tmp22
+//line ../../clojure/test.glj:393:13
 					tmp23 := kw_fail.Invoke1(v7)
+//line ../../clojure/test.glj:393:35
 					tmp24 := kw_error.Invoke1(v7)
+//line ../../clojure/test.glj:393:4
 					tmp25 := aotExternalFn36(tmp23, "failures,", tmp24, "errors.")
+//line ../../clojure/test.glj:390:3
 					tmp13 = tmp25
 				}()
 				tmp8 = tmp13
 			} // end let
+//line ../../clojure/test.glj:324:3
 			return tmp8
 		})
-		tmp1.AddMethod(kw_summary, tmp6)
 		var tmp7 lang.FnFunc1
 		tmp7 = lang.FnFunc1(func(p0 any) any {
 			v8 := p0
 			_ = v8
+//line ../../clojure/test.glj:396:3
 			var tmp9 any
 			{ // let
 				tmp10 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
@@ -3105,69 +3575,98 @@ func LoadNS() {
 						tmp15 := aotExternalFn29()
 						_ = tmp15
 					}()
+//line ../../clojure/test.glj:397:34
 					tmp16 := kw_ns.Invoke1(v8)
+//line ../../clojure/test.glj:397:25
 					tmp17 := aotExternalFn37(tmp16)
+//line ../../clojure/test.glj:397:4
 					tmp18 := aotExternalFn31("\nTesting", tmp17)
+//line ../../clojure/test.glj:396:3
 					tmp14 = tmp18
 				}()
 				tmp9 = tmp14
 			} // end let
+//line ../../clojure/test.glj:324:3
 			return tmp9
 		})
-		tmp1.AddMethod(kw_begin_DASH_test_DASH_ns, tmp7)
 		var tmp8 lang.FnFunc1
 		tmp8 = lang.FnFunc1(func(p0 any) any {
 			v9 := p0
 			_ = v9
 			return nil
 		})
-		tmp1.AddMethod(kw_end_DASH_test_DASH_ns, tmp8)
 		var tmp9 lang.FnFunc1
 		tmp9 = lang.FnFunc1(func(p0 any) any {
 			v10 := p0
 			_ = v10
 			return nil
 		})
-		tmp1.AddMethod(kw_begin_DASH_test_DASH_var, tmp9)
 		var tmp10 lang.FnFunc1
 		tmp10 = lang.FnFunc1(func(p0 any) any {
 			v11 := p0
 			_ = v11
 			return nil
 		})
-		tmp1.AddMethod(kw_end_DASH_test_DASH_var, tmp10)
+		tmp1.AddMethods(
+			kw_default, tmp2,
+			kw_pass, tmp3,
+			kw_fail, tmp4,
+			kw_error, tmp5,
+			kw_summary, tmp6,
+			kw_begin_DASH_test_DASH_ns, tmp7,
+			kw_end_DASH_test_DASH_ns, tmp8,
+			kw_begin_DASH_test_DASH_var, tmp9,
+			kw_end_DASH_test_DASH_var, tmp10,
+		)
 		var_clojure_DOT_test_report = ns.InternWithValue(tmp0, tmp1, true)
 		var_clojure_DOT_test_report.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(324), kw_column, int(3), kw_end_DASH_line, int(332), kw_end_DASH_column, int(8), kw_doc, "Generic reporting function, may be overridden to plug in\n   different report formats (e.g., TAP, JUnit).  Assertions such as\n   'is' call 'report' to indicate results.  The argument given to\n   'report' will be a map with a :type key.  See the documentation at\n   the top of test_is.clj for more information on the types of\n   arguments for 'report'.", kw_dynamic, true, kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 		var_clojure_DOT_test_report.SetDynamic()
 	}
+//line loader.go:3628
 	// run-all-tests
+
+//line ../../clojure/test.glj:771:7
 	{
 		tmp0 := sym_run_DASH_all_DASH_tests
 		var tmp1 lang.ArityFn
 		aotDirectFn12Arity0 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:777:14
 			tmp2 := checkDerefVar(var_clojure_DOT_test_run_DASH_tests)
+//line ../../clojure/test.glj:777:24
 			tmp3 := aotExternalFn38()
+//line ../../clojure/test.glj:777:7
 			tmp4 := aotExternalFn7(tmp2, tmp3)
+//line ../../clojure/test.glj:771:7
 			return tmp4
 		})
 		aotDirectFn12Arity1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:778:16
 			tmp3 := checkDerefVar(var_clojure_DOT_test_run_DASH_tests)
+//line ../../clojure/test.glj:778:34
 			var tmp4 lang.FnFunc1
 			tmp4 = lang.FnFunc1(func(p0 any) any {
 				v5 := p0
 				_ = v5
+//line ../../clojure/test.glj:778:56
 				tmp6 := aotExternalFn37(v5)
+//line ../../clojure/test.glj:778:50
 				tmp7 := aotExternalFn41(tmp6)
+//line ../../clojure/test.glj:778:35
 				tmp8 := aotExternalFn40(v2, tmp7)
+//line ../../clojure/test.glj:778:34
 				return tmp8
 			})
+//line ../../clojure/test.glj:778:70
 			tmp5 := aotExternalFn38()
+//line ../../clojure/test.glj:778:26
 			tmp6 := aotExternalFn39(tmp4, tmp5)
+//line ../../clojure/test.glj:778:9
 			tmp7 := aotExternalFn7(tmp3, tmp6)
+//line ../../clojure/test.glj:771:7
 			return tmp7
 		})
 		tmp1 = lang.NewArityFn(
@@ -3185,7 +3684,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(771), kw_column, int(7), kw_end_DASH_line, int(771), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(), lang.NewVector(sym_re)), kw_doc, "Runs all tests in all namespaces; prints results.\n  Optional argument is a regular expression; only namespaces with\n  names matching the regular expression (with re-matches) will be\n  tested.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:3688
 	// run-test
+
+//line ../../clojure/test.glj:804:11
 	{
 		tmp0 := sym_run_DASH_test
 		var tmp1 lang.FnFunc3
@@ -3196,19 +3698,29 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/test.glj:810:3
 			var tmp5 any
 			{ // let
 				// let binding "test-var"
+
+//line ../../clojure/test.glj:810:18
 				tmp6 := aotExternalFn19(v4)
+//line ../../clojure/test.glj:810:3
 				var v7 any = tmp6
 				_ = v7
+//line ../../clojure/test.glj:811:5
 				var tmp8 any
+//line ../../clojure/test.glj:812:7
 				tmp9 := lang.Identical(v7, nil)
+//line ../../clojure/test.glj:811:5
 				if lang.IsTruthy(tmp9) {
+//line ../../clojure/test.glj:813:7
 					var tmp10 any
 					{ // let
 						tmp11 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
+//line ../../clojure/test.glj:813:23
 						tmp12 := checkDerefVar(var_clojure_DOT_core__STAR_err_STAR_)
+//line ../../clojure/test.glj:813:7
 						tmp13 := aotExternalFn28(tmp11, tmp12)
 						tmp14 := aotExternalFn27(tmp13)
 						_ = tmp14
@@ -3218,22 +3730,31 @@ func LoadNS() {
 								tmp16 := aotExternalFn29()
 								_ = tmp16
 							}()
+//line ../../clojure/test.glj:814:9
 							tmp17 := aotExternalFn42("Unable to resolve", v4, "to a test function.")
+//line ../../clojure/test.glj:813:7
 							tmp15 = tmp17
 						}()
 						tmp10 = tmp15
 					} // end let
+//line ../../clojure/test.glj:811:5
 					tmp8 = tmp10
 				} else {
 					var tmp11 any
+//line ../../clojure/test.glj:816:12
 					tmp12 := aotExternalFn22(v7)
 					tmp13 := kw_test.Invoke1(tmp12)
+//line ../../clojure/test.glj:816:7
 					tmp14 := aotExternalFn21(tmp13)
+//line ../../clojure/test.glj:811:5
 					if lang.IsTruthy(tmp14) {
+//line ../../clojure/test.glj:817:7
 						var tmp15 any
 						{ // let
 							tmp16 := lang.InternVarName(sym_clojure_DOT_core, sym__STAR_out_STAR_)
+//line ../../clojure/test.glj:817:23
 							tmp17 := checkDerefVar(var_clojure_DOT_core__STAR_err_STAR_)
+//line ../../clojure/test.glj:817:7
 							tmp18 := aotExternalFn28(tmp16, tmp17)
 							tmp19 := aotExternalFn27(tmp18)
 							_ = tmp19
@@ -3243,25 +3764,32 @@ func LoadNS() {
 									tmp21 := aotExternalFn29()
 									_ = tmp21
 								}()
+//line ../../clojure/test.glj:818:9
 								tmp22 := aotExternalFn31(v4, "is not a test.")
+//line ../../clojure/test.glj:817:7
 								tmp20 = tmp22
 							}()
 							tmp15 = tmp20
 						} // end let
+//line ../../clojure/test.glj:811:5
 						tmp11 = tmp15
 					} else {
+//line ../../clojure/test.glj:821:7
 						tmp16 := checkDerefVar(var_clojure_DOT_core_list)
 						tmp17 := lang.Apply1(tmp16, sym_clojure_DOT_test_SLASH_run_DASH_test_DASH_var)
 						tmp18 := checkDerefVar(var_clojure_DOT_core_list)
 						tmp19 := lang.Apply1(tmp18, v7)
 						tmp20 := aotExternalFn6(tmp17, tmp19)
 						tmp21 := lang.Seq(tmp20)
+//line ../../clojure/test.glj:811:5
 						tmp11 = tmp21
 					}
 					tmp8 = tmp11
 				}
+//line ../../clojure/test.glj:810:3
 				tmp5 = tmp8
 			} // end let
+//line ../../clojure/test.glj:804:11
 			return tmp5
 		})
 		var_clojure_DOT_test_run_DASH_test = ns.InternWithValue(tmp0, tmp1, true)
@@ -3269,13 +3797,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(804), kw_column, int(11), kw_end_DASH_line, int(804), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_test_DASH_symbol)), kw_doc, "Runs a single test.\n\n  Because the intent is to run a single test, there is no check for the namespace test-ns-hook.", kw_added, "1.11", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:3801
 	// run-tests
+
+//line ../../clojure/test.glj:759:7
 	{
 		tmp0 := sym_run_DASH_tests
 		var tmp1 lang.ArityFn
 		aotDirectFn14Arity0 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:764:18
 			tmp2 := checkDerefVar(var_clojure_DOT_core__STAR_ns_STAR_)
+//line ../../clojure/test.glj:764:7
 			tmp3 := aotDirectFn14.Invoke1(tmp2)
+//line ../../clojure/test.glj:759:7
 			return tmp3
 		})
 		tmp1 = lang.NewArityFn(
@@ -3287,22 +3821,34 @@ func LoadNS() {
 			lang.NewVariadicFn(0, func(args []any, rest lang.ISeq) any {
 				var v2 any = rest
 				_ = v2
+//line ../../clojure/test.glj:766:6
 				var tmp3 any
 				{ // let
 					// let binding "summary"
+
+//line ../../clojure/test.glj:766:34
 					tmp4 := checkDerefVar(var_clojure_DOT_core_merge_DASH_with)
+//line ../../clojure/test.glj:766:45
 					tmp5 := checkDerefVar(var_clojure_DOT_core__PLUS_)
+//line ../../clojure/test.glj:766:52
 					tmp6 := checkDerefVar(var_clojure_DOT_test_test_DASH_ns)
+//line ../../clojure/test.glj:766:47
 					tmp7 := aotExternalFn46(tmp6, v2)
+//line ../../clojure/test.glj:766:27
 					tmp8 := aotExternalFn45(tmp4, tmp5, tmp7)
+//line ../../clojure/test.glj:766:20
 					var tmp9 any = tmp8
 					tmp9 = lang.Assoc(tmp9, kw_type, kw_summary)
+//line ../../clojure/test.glj:766:6
 					var v10 any = tmp9
 					_ = v10
+//line ../../clojure/test.glj:768:8
 					tmp11 := aotDirectFn5(v10)
+//line ../../clojure/test.glj:766:6
 					_ = tmp11
 					tmp3 = v10
 				} // end let
+//line ../../clojure/test.glj:759:7
 				return tmp3
 			}),
 			0,
@@ -3313,7 +3859,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(759), kw_column, int(7), kw_end_DASH_line, int(759), kw_end_DASH_column, int(15), kw_arglists, lang.NewList(lang.NewVector(), lang.NewVector(sym__AMP_, sym_namespaces)), kw_doc, "Runs all tests in the given namespaces; prints results.\n  Defaults to current namespace if none given.  Returns a map\n  summarizing test results.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:3863
 	// set-test
+
+//line ../../clojure/test.glj:639:11
 	{
 		tmp0 := sym_set_DASH_test
 		var tmp1 lang.ArityFn
@@ -3332,9 +3881,13 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../clojure/test.glj:647:3
 				var tmp6 any
+//line ../../clojure/test.glj:647:9
 				tmp7 := checkDerefVar(var_clojure_DOT_test__STAR_load_DASH_tests_STAR_)
+//line ../../clojure/test.glj:647:3
 				if lang.IsTruthy(tmp7) {
+//line ../../clojure/test.glj:648:5
 					tmp8 := checkDerefVar(var_clojure_DOT_core_concat)
 					tmp9 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp10 := lang.Apply1(tmp9, sym_clojure_DOT_core_SLASH_alter_DASH_meta_BANG_)
@@ -3364,9 +3917,11 @@ func LoadNS() {
 					tmp34 := lang.Apply1(tmp23, tmp33)
 					tmp35 := lang.Apply5(tmp8, tmp10, tmp18, tmp20, tmp22, tmp34)
 					tmp36 := lang.Seq(tmp35)
+//line ../../clojure/test.glj:647:3
 					tmp6 = tmp36
 				} else {
 				}
+//line ../../clojure/test.glj:639:11
 				return tmp6
 			}),
 			3,
@@ -3376,19 +3931,28 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(639), kw_column, int(11), kw_end_DASH_line, int(639), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_name, sym__AMP_, sym_body)), kw_doc, "Experimental.\n  Sets :test metadata of the named var to a fn with the given body.\n  The var must already exist.  Does not modify the value of the var.\n\n  When *load-tests* is false, set-test is ignored.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:3935
 	// test-vars
+
+//line ../../clojure/test.glj:714:7
 	{
 		tmp0 := sym_test_DASH_vars
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:719:3
 			var tmp3 any
 			{ // let
 				// let binding "seq_746"
+
+//line ../../clojure/test.glj:719:41
 				tmp4 := checkDerefVar(var_clojure_DOT_core_meta)
+//line ../../clojure/test.glj:719:31
 				tmp5 := aotExternalFn55(kw_ns, tmp4)
+//line ../../clojure/test.glj:719:21
 				tmp6 := aotExternalFn54(tmp5, v2)
+//line ../../clojure/test.glj:719:3
 				tmp7 := lang.Seq(tmp6)
 				var v8 any = tmp7
 				_ = v8
@@ -3408,7 +3972,7 @@ func LoadNS() {
 						var tmp14 any
 						{ // let
 							// let binding "vec__750"
-							tmp15 := v9.(interface{ Nth(int) any }).Nth(lang.IntCast(v11))
+							tmp15 := any(v9).(interface{ Nth(int) any }).Nth(lang.IntCast(v11))
 							var v16 any = tmp15
 							_ = v16
 							// let binding "ns"
@@ -3419,22 +3983,35 @@ func LoadNS() {
 							tmp19 := runtime.RT.NthDefault(v16, lang.IntCast(int64(1)), nil)
 							var v20 any = tmp19
 							_ = v20
+//line ../../clojure/test.glj:720:5
 							var tmp21 any
 							{ // let
 								// let binding "once-fixture-fn"
+
+//line ../../clojure/test.glj:720:59
 								tmp22 := aotExternalFn22(v18)
+//line ../../clojure/test.glj:720:42
 								tmp23 := kw_clojure_DOT_test_SLASH_once_DASH_fixtures.Invoke1(tmp22)
+//line ../../clojure/test.glj:720:27
 								tmp24 := aotDirectFn11(tmp23)
+//line ../../clojure/test.glj:720:5
 								var v25 any = tmp24
 								_ = v25
 								// let binding "each-fixture-fn"
+
+//line ../../clojure/test.glj:721:59
 								tmp26 := aotExternalFn22(v18)
+//line ../../clojure/test.glj:721:42
 								tmp27 := kw_clojure_DOT_test_SLASH_each_DASH_fixtures.Invoke1(tmp26)
+//line ../../clojure/test.glj:721:27
 								tmp28 := aotDirectFn11(tmp27)
+//line ../../clojure/test.glj:720:5
 								var v29 any = tmp28
 								_ = v29
+//line ../../clojure/test.glj:723:8
 								var tmp30 lang.FnFunc0
 								tmp30 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:724:10
 									var tmp31 any
 									{ // let
 										// let binding "seq_753"
@@ -3457,23 +4034,34 @@ func LoadNS() {
 												var tmp39 any
 												{ // let
 													// let binding "v"
-													tmp40 := v34.(interface{ Nth(int) any }).Nth(lang.IntCast(v36))
+													tmp40 := any(v34).(interface{ Nth(int) any }).Nth(lang.IntCast(v36))
 													var v41 any = tmp40
 													_ = v41
+//line ../../clojure/test.glj:725:12
 													var tmp42 any
+//line ../../clojure/test.glj:725:25
 													tmp43 := aotExternalFn22(v41)
+//line ../../clojure/test.glj:725:18
 													tmp44 := kw_test.Invoke1(tmp43)
+//line ../../clojure/test.glj:725:12
 													if lang.IsTruthy(tmp44) {
+//line ../../clojure/test.glj:726:31
 														var tmp45 lang.FnFunc0
 														tmp45 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:726:39
 															tmp46 := checkDerefVar(var_clojure_DOT_test_test_DASH_var)
+//line ../../clojure/test.glj:726:38
 															tmp47 := lang.Apply1(tmp46, v41)
+//line ../../clojure/test.glj:726:31
 															return tmp47
 														})
+//line ../../clojure/test.glj:726:14
 														tmp46 := lang.Apply1(v29, tmp45)
+//line ../../clojure/test.glj:725:12
 														tmp42 = tmp46
 													} else {
 													}
+//line ../../clojure/test.glj:724:10
 													_ = tmp42
 													var tmp47 any = v33
 													var tmp48 any = v34
@@ -3532,20 +4120,31 @@ func LoadNS() {
 																	tmp50 := lang.First(v45)
 																	var v51 any = tmp50
 																	_ = v51
+//line ../../clojure/test.glj:725:12
 																	var tmp52 any
+//line ../../clojure/test.glj:725:25
 																	tmp53 := aotExternalFn22(v51)
+//line ../../clojure/test.glj:725:18
 																	tmp54 := kw_test.Invoke1(tmp53)
+//line ../../clojure/test.glj:725:12
 																	if lang.IsTruthy(tmp54) {
+//line ../../clojure/test.glj:726:31
 																		var tmp55 lang.FnFunc0
 																		tmp55 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:726:39
 																			tmp56 := checkDerefVar(var_clojure_DOT_test_test_DASH_var)
+//line ../../clojure/test.glj:726:38
 																			tmp57 := lang.Apply1(tmp56, v51)
+//line ../../clojure/test.glj:726:31
 																			return tmp57
 																		})
+//line ../../clojure/test.glj:726:14
 																		tmp56 := lang.Apply1(v29, tmp55)
+//line ../../clojure/test.glj:725:12
 																		tmp52 = tmp56
 																	} else {
 																	}
+//line ../../clojure/test.glj:724:10
 																	_ = tmp52
 																	tmp58 := lang.Next(v45)
 																	var tmp57 any = tmp58
@@ -3573,11 +4172,15 @@ func LoadNS() {
 											break
 										}
 									} // end let
+//line ../../clojure/test.glj:723:8
 									return tmp31
 								})
+//line ../../clojure/test.glj:722:7
 								tmp31 := lang.Apply1(v25, tmp30)
+//line ../../clojure/test.glj:720:5
 								tmp21 = tmp31
 							} // end let
+//line ../../clojure/test.glj:719:3
 							_ = tmp21
 							var tmp22 any = v8
 							var tmp23 any = v9
@@ -3644,22 +4247,35 @@ func LoadNS() {
 											tmp29 := runtime.RT.NthDefault(v26, lang.IntCast(int64(1)), nil)
 											var v30 any = tmp29
 											_ = v30
+//line ../../clojure/test.glj:720:5
 											var tmp31 any
 											{ // let
 												// let binding "once-fixture-fn"
+
+//line ../../clojure/test.glj:720:59
 												tmp32 := aotExternalFn22(v28)
+//line ../../clojure/test.glj:720:42
 												tmp33 := kw_clojure_DOT_test_SLASH_once_DASH_fixtures.Invoke1(tmp32)
+//line ../../clojure/test.glj:720:27
 												tmp34 := aotDirectFn11(tmp33)
+//line ../../clojure/test.glj:720:5
 												var v35 any = tmp34
 												_ = v35
 												// let binding "each-fixture-fn"
+
+//line ../../clojure/test.glj:721:59
 												tmp36 := aotExternalFn22(v28)
+//line ../../clojure/test.glj:721:42
 												tmp37 := kw_clojure_DOT_test_SLASH_each_DASH_fixtures.Invoke1(tmp36)
+//line ../../clojure/test.glj:721:27
 												tmp38 := aotDirectFn11(tmp37)
+//line ../../clojure/test.glj:720:5
 												var v39 any = tmp38
 												_ = v39
+//line ../../clojure/test.glj:723:8
 												var tmp40 lang.FnFunc0
 												tmp40 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:724:10
 													var tmp41 any
 													{ // let
 														// let binding "seq_760"
@@ -3682,23 +4298,34 @@ func LoadNS() {
 																var tmp49 any
 																{ // let
 																	// let binding "v"
-																	tmp50 := v44.(interface{ Nth(int) any }).Nth(lang.IntCast(v46))
+																	tmp50 := any(v44).(interface{ Nth(int) any }).Nth(lang.IntCast(v46))
 																	var v51 any = tmp50
 																	_ = v51
+//line ../../clojure/test.glj:725:12
 																	var tmp52 any
+//line ../../clojure/test.glj:725:25
 																	tmp53 := aotExternalFn22(v51)
+//line ../../clojure/test.glj:725:18
 																	tmp54 := kw_test.Invoke1(tmp53)
+//line ../../clojure/test.glj:725:12
 																	if lang.IsTruthy(tmp54) {
+//line ../../clojure/test.glj:726:31
 																		var tmp55 lang.FnFunc0
 																		tmp55 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:726:39
 																			tmp56 := checkDerefVar(var_clojure_DOT_test_test_DASH_var)
+//line ../../clojure/test.glj:726:38
 																			tmp57 := lang.Apply1(tmp56, v51)
+//line ../../clojure/test.glj:726:31
 																			return tmp57
 																		})
+//line ../../clojure/test.glj:726:14
 																		tmp56 := lang.Apply1(v39, tmp55)
+//line ../../clojure/test.glj:725:12
 																		tmp52 = tmp56
 																	} else {
 																	}
+//line ../../clojure/test.glj:724:10
 																	_ = tmp52
 																	var tmp57 any = v43
 																	var tmp58 any = v44
@@ -3757,20 +4384,31 @@ func LoadNS() {
 																					tmp60 := lang.First(v55)
 																					var v61 any = tmp60
 																					_ = v61
+//line ../../clojure/test.glj:725:12
 																					var tmp62 any
+//line ../../clojure/test.glj:725:25
 																					tmp63 := aotExternalFn22(v61)
+//line ../../clojure/test.glj:725:18
 																					tmp64 := kw_test.Invoke1(tmp63)
+//line ../../clojure/test.glj:725:12
 																					if lang.IsTruthy(tmp64) {
+//line ../../clojure/test.glj:726:31
 																						var tmp65 lang.FnFunc0
 																						tmp65 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:726:39
 																							tmp66 := checkDerefVar(var_clojure_DOT_test_test_DASH_var)
+//line ../../clojure/test.glj:726:38
 																							tmp67 := lang.Apply1(tmp66, v61)
+//line ../../clojure/test.glj:726:31
 																							return tmp67
 																						})
+//line ../../clojure/test.glj:726:14
 																						tmp66 := lang.Apply1(v39, tmp65)
+//line ../../clojure/test.glj:725:12
 																						tmp62 = tmp66
 																					} else {
 																					}
+//line ../../clojure/test.glj:724:10
 																					_ = tmp62
 																					tmp68 := lang.Next(v55)
 																					var tmp67 any = tmp68
@@ -3798,11 +4436,15 @@ func LoadNS() {
 															break
 														}
 													} // end let
+//line ../../clojure/test.glj:723:8
 													return tmp41
 												})
+//line ../../clojure/test.glj:722:7
 												tmp41 := lang.Apply1(v35, tmp40)
+//line ../../clojure/test.glj:720:5
 												tmp31 = tmp41
 											} // end let
+//line ../../clojure/test.glj:719:3
 											_ = tmp31
 											tmp33 := lang.Next(v20)
 											var tmp32 any = tmp33
@@ -3830,6 +4472,7 @@ func LoadNS() {
 					break
 				}
 			} // end let
+//line ../../clojure/test.glj:714:7
 			return tmp3
 		})
 		aotDirectFn19 = tmp1
@@ -3838,7 +4481,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(714), kw_column, int(7), kw_end_DASH_line, int(714), kw_end_DASH_column, int(15), kw_arglists, lang.NewList(lang.NewVector(sym_vars)), kw_doc, "Groups vars by their namespace and runs test-var on them with\n  appropriate fixtures applied.", kw_added, "1.6", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:4485
 	// testing
+
+//line ../../clojure/test.glj:588:11
 	{
 		tmp0 := sym_testing
 		var tmp1 lang.ArityFn
@@ -3857,6 +4503,7 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../clojure/test.glj:593:3
 				tmp6 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp7 := lang.Apply1(tmp6, sym_clojure_DOT_core_SLASH_binding)
 				tmp8 := checkDerefVar(var_clojure_DOT_core_list)
@@ -3879,6 +4526,7 @@ func LoadNS() {
 				tmp25 := lang.Apply1(tmp8, tmp24)
 				tmp26 := aotExternalFn11(tmp7, tmp25, v5)
 				tmp27 := lang.Seq(tmp26)
+//line ../../clojure/test.glj:588:11
 				return tmp27
 			}),
 			3,
@@ -3888,16 +4536,25 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(588), kw_column, int(11), kw_end_DASH_line, int(588), kw_end_DASH_column, int(17), kw_arglists, lang.NewList(lang.NewVector(sym_string, sym__AMP_, sym_body)), kw_doc, "Adds a new string to the list of testing contexts.  May be nested,\n  but must occur inside a test function (deftest).", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:4540
 	// testing-contexts-str
+
+//line ../../clojure/test.glj:306:7
 	{
 		tmp0 := sym_testing_DASH_contexts_DASH_str
 		var tmp1 lang.FnFunc0
 		tmp1 = lang.FnFunc0(func() any {
+//line ../../clojure/test.glj:311:10
 			tmp2 := checkDerefVar(var_clojure_DOT_core_str)
+//line ../../clojure/test.glj:311:38
 			tmp3 := checkDerefVar(var_clojure_DOT_test__STAR_testing_DASH_contexts_STAR_)
+//line ../../clojure/test.glj:311:29
 			tmp4 := aotExternalFn62(tmp3)
+//line ../../clojure/test.glj:311:14
 			tmp5 := aotExternalFn61(" ", tmp4)
+//line ../../clojure/test.glj:311:3
 			tmp6 := aotExternalFn7(tmp2, tmp5)
+//line ../../clojure/test.glj:306:7
 			return tmp6
 		})
 		aotDirectFn20 = tmp1
@@ -3906,13 +4563,17 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(306), kw_column, int(7), kw_end_DASH_line, int(306), kw_end_DASH_column, int(26), kw_arglists, lang.NewList(lang.NewVector()), kw_doc, "Returns a string representation of the current test context. Joins\n  strings in *testing-contexts* with spaces.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:4567
 	// testing-vars-str
+
+//line ../../clojure/test.glj:293:7
 	{
 		tmp0 := sym_testing_DASH_vars_DASH_str
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/test.glj:299:3
 			var tmp3 any
 			{ // let
 				// let binding "map__744"
@@ -3954,21 +4615,32 @@ func LoadNS() {
 				tmp18 := runtime.RT.Get(v15, kw_line)
 				var v19 any = tmp18
 				_ = v19
+//line ../../clojure/test.glj:300:6
 				tmp20 := checkDerefVar(var_clojure_DOT_core_str)
+//line ../../clojure/test.glj:303:20
 				var tmp21 lang.FnFunc1
 				tmp21 = lang.FnFunc1(func(p0 any) any {
 					v22 := p0
 					_ = v22
+//line ../../clojure/test.glj:303:28
 					tmp23 := aotExternalFn22(v22)
+//line ../../clojure/test.glj:303:21
 					tmp24 := kw_name.Invoke1(tmp23)
+//line ../../clojure/test.glj:303:20
 					return tmp24
 				})
+//line ../../clojure/test.glj:303:38
 				tmp22 := checkDerefVar(var_clojure_DOT_test__STAR_testing_DASH_vars_STAR_)
+//line ../../clojure/test.glj:303:15
 				tmp23 := aotExternalFn46(tmp21, tmp22)
+//line ../../clojure/test.glj:303:6
 				tmp24 := aotExternalFn62(tmp23)
+//line ../../clojure/test.glj:300:5
 				tmp25 := lang.Apply6(tmp20, tmp24, " (", v17, ":", v19, ")")
+//line ../../clojure/test.glj:299:3
 				tmp3 = tmp25
 			} // end let
+//line ../../clojure/test.glj:293:7
 			return tmp3
 		})
 		aotDirectFn21 = tmp1
@@ -3977,7 +4649,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(293), kw_column, int(7), kw_end_DASH_line, int(293), kw_end_DASH_column, int(22), kw_arglists, lang.NewList(lang.NewVector(sym_m)), kw_doc, "Returns a string representation of the current test.  Renders names\n  in *testing-vars* as a list, then the source file and line of\n  current assertion.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test))
 		})
 	}
+//line loader.go:4653
 	// try-expr
+
+//line ../../clojure/test.glj:529:11
 	{
 		tmp0 := sym_try_DASH_expr
 		var tmp1 lang.FnFunc4
@@ -3990,11 +4665,15 @@ func LoadNS() {
 			_ = v4
 			v5 := p3
 			_ = v5
+//line ../../clojure/test.glj:534:3
 			tmp6 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp7 := lang.Apply1(tmp6, sym_try)
 			tmp8 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../clojure/test.glj:534:11
 			tmp9 := checkDerefVar(var_clojure_DOT_test_assert_DASH_expr)
+//line ../../clojure/test.glj:534:10
 			tmp10 := lang.Apply2(tmp9, v4, v5)
+//line ../../clojure/test.glj:534:3
 			tmp11 := lang.Apply1(tmp8, tmp10)
 			tmp12 := checkDerefVar(var_clojure_DOT_core_list)
 			tmp13 := checkDerefVar(var_clojure_DOT_core_list)
@@ -4043,6 +4722,7 @@ func LoadNS() {
 			tmp56 := lang.Apply1(tmp12, tmp55)
 			tmp57 := aotExternalFn11(tmp7, tmp11, tmp56)
 			tmp58 := lang.Seq(tmp57)
+//line ../../clojure/test.glj:529:11
 			return tmp58
 		})
 		var_clojure_DOT_test_try_DASH_expr = ns.InternWithValue(tmp0, tmp1, true)
@@ -4050,7 +4730,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(529), kw_column, int(11), kw_end_DASH_line, int(529), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_msg, sym_form)), kw_doc, "Used by the 'is' macro to catch unexpected exceptions.\n  You don't call this.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:4734
 	// with-test
+
+//line ../../clojure/test.glj:600:11
 	{
 		tmp0 := sym_with_DASH_test
 		var tmp1 lang.ArityFn
@@ -4069,9 +4752,13 @@ func LoadNS() {
 				_ = v4
 				var v5 any = rest
 				_ = v5
+//line ../../clojure/test.glj:608:3
 				var tmp6 any
+//line ../../clojure/test.glj:608:7
 				tmp7 := checkDerefVar(var_clojure_DOT_test__STAR_load_DASH_tests_STAR_)
+//line ../../clojure/test.glj:608:3
 				if lang.IsTruthy(tmp7) {
+//line ../../clojure/test.glj:609:5
 					tmp8 := checkDerefVar(var_clojure_DOT_core_list)
 					tmp9 := lang.Apply1(tmp8, sym_clojure_DOT_core_SLASH_doto)
 					tmp10 := checkDerefVar(var_clojure_DOT_core_list)
@@ -4100,10 +4787,12 @@ func LoadNS() {
 					tmp33 := lang.Apply1(tmp12, tmp32)
 					tmp34 := aotExternalFn11(tmp9, tmp11, tmp33)
 					tmp35 := lang.Seq(tmp34)
+//line ../../clojure/test.glj:608:3
 					tmp6 = tmp35
 				} else {
 					tmp6 = v4
 				}
+//line ../../clojure/test.glj:600:11
 				return tmp6
 			}),
 			3,
@@ -4113,7 +4802,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(600), kw_column, int(11), kw_end_DASH_line, int(600), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(sym_definition, sym__AMP_, sym_body)), kw_doc, "Takes any definition form (that returns a Var) as the first argument.\n  Remaining body goes in the :test metadata function for that Var.\n\n  When *load-tests* is false, only evaluates the definition, ignoring\n  the tests.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:4806
 	// with-test-out
+
+//line ../../clojure/test.glj:272:11
 	{
 		tmp0 := sym_with_DASH_test_DASH_out
 		var tmp1 lang.ArityFn
@@ -4130,6 +4822,7 @@ func LoadNS() {
 				_ = v3
 				var v4 any = rest
 				_ = v4
+//line ../../clojure/test.glj:276:3
 				tmp5 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp6 := lang.Apply1(tmp5, sym_clojure_DOT_core_SLASH_binding)
 				tmp7 := checkDerefVar(var_clojure_DOT_core_list)
@@ -4152,6 +4845,7 @@ func LoadNS() {
 				tmp24 := lang.Apply1(tmp7, tmp23)
 				tmp25 := aotExternalFn11(tmp6, tmp24, v4)
 				tmp26 := lang.Seq(tmp25)
+//line ../../clojure/test.glj:272:11
 				return tmp26
 			}),
 			2,
@@ -4161,4 +4855,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/test.glj", kw_line, int(272), kw_column, int(11), kw_end_DASH_line, int(272), kw_end_DASH_column, int(23), kw_arglists, lang.NewList(lang.NewVector(sym__AMP_, sym_body)), kw_doc, "Runs body with *out* bound to the value of *test-out*.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_test), kw_macro, true)
 		})
 	}
+//line loader.go:4859
 }
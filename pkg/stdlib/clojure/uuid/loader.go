@@ -52,13 +52,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -93,6 +93,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -165,21 +166,32 @@ func LoadNS() {
 		})
 	}
 	// default-uuid-reader
+
+//line ../../clojure/uuid.glj:11:8
 	{
 		tmp0 := sym_default_DASH_uuid_DASH_reader
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/uuid.glj:12:3
 			var tmp3 any
+//line ../../clojure/uuid.glj:12:7
 			tmp4 := aotExternalFn0(v2)
+//line ../../clojure/uuid.glj:12:3
 			if lang.IsTruthy(tmp4) {
+//line ../../clojure/uuid.glj:13:5
 				tmp5 := lang.Apply1(uuid4.FromString, v2)
+//line ../../clojure/uuid.glj:12:3
 				tmp3 = tmp5
 			} else {
+//line ../../clojure/uuid.glj:14:12
 				tmp6 := lang.Apply1(lang.NewIllegalArgumentError, "#uuid data reader expected string")
+//line ../../clojure/uuid.glj:14:5
 				panic(tmp6)
+//line ../../clojure/uuid.glj:12:3
 			}
+//line ../../clojure/uuid.glj:11:8
 			return tmp3
 		})
 		aotDirectFn0 = tmp1
@@ -188,4 +200,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/uuid.glj", kw_line, int(11), kw_column, int(8), kw_end_DASH_line, int(11), kw_end_DASH_column, int(26), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_form)), kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_uuid))
 		})
 	}
+//line loader.go:204
 }
@@ -113,13 +113,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -281,6 +281,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -353,15 +354,20 @@ func LoadNS() {
 		})
 	}
 	// keywordize-keys
+
+//line ../../clojure/walk.glj:94:7
 	{
 		tmp0 := sym_keywordize_DASH_keys
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/walk.glj:98:3
 			var tmp3 any
 			{ // let
 				// let binding "f"
+
+//line ../../clojure/walk.glj:98:11
 				var tmp4 lang.FnFunc1
 				tmp4 = lang.FnFunc1(func(p0 any) any {
 					v5 := p0
@@ -379,41 +385,63 @@ func LoadNS() {
 						tmp10 := runtime.RT.NthDefault(v7, lang.IntCast(int64(1)), nil)
 						var v11 any = tmp10
 						_ = v11
+//line ../../clojure/walk.glj:98:23
 						var tmp12 any
+//line ../../clojure/walk.glj:98:27
 						tmp13 := aotExternalFn1(v9)
+//line ../../clojure/walk.glj:98:23
 						if lang.IsTruthy(tmp13) {
+//line ../../clojure/walk.glj:98:40
 							tmp14 := aotExternalFn2(v9)
+//line ../../clojure/walk.glj:98:39
 							tmp15 := lang.NewVector(tmp14, v11)
+//line ../../clojure/walk.glj:98:23
 							tmp12 = tmp15
 						} else {
+//line ../../clojure/walk.glj:98:55
 							tmp16 := lang.NewVector(v9, v11)
+//line ../../clojure/walk.glj:98:23
 							tmp12 = tmp16
 						}
+//line ../../clojure/walk.glj:98:11
 						tmp6 = tmp12
 					} // end let
 					return tmp6
 				})
+//line ../../clojure/walk.glj:98:3
 				var v5 any = tmp4
 				_ = v5
+//line ../../clojure/walk.glj:100:15
 				var tmp6 lang.FnFunc1
 				tmp6 = lang.FnFunc1(func(p0 any) any {
 					v7 := p0
 					_ = v7
+//line ../../clojure/walk.glj:100:23
 					var tmp8 any
+//line ../../clojure/walk.glj:100:27
 					tmp9 := aotExternalFn3(v7)
+//line ../../clojure/walk.glj:100:23
 					if lang.IsTruthy(tmp9) {
+//line ../../clojure/walk.glj:100:42
 						tmp10 := lang.NewMap()
+//line ../../clojure/walk.glj:100:45
 						tmp11 := aotExternalFn5(v5, v7)
+//line ../../clojure/walk.glj:100:36
 						tmp12 := aotExternalFn4(tmp10, tmp11)
+//line ../../clojure/walk.glj:100:23
 						tmp8 = tmp12
 					} else {
 						tmp8 = v7
 					}
+//line ../../clojure/walk.glj:100:15
 					return tmp8
 				})
+//line ../../clojure/walk.glj:100:5
 				tmp7 := aotDirectFn2(tmp6, v2)
+//line ../../clojure/walk.glj:98:3
 				tmp3 = tmp7
 			} // end let
+//line ../../clojure/walk.glj:94:7
 			return tmp3
 		})
 		aotDirectFn0 = tmp1
@@ -422,28 +450,40 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(94), kw_column, int(7), kw_end_DASH_line, int(94), kw_end_DASH_column, int(21), kw_arglists, lang.NewList(lang.NewVector(sym_m)), kw_doc, "Recursively transforms all map keys from strings to keywords.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:454
 	// macroexpand-all
+
+//line ../../clojure/walk.glj:126:7
 	{
 		tmp0 := sym_macroexpand_DASH_all
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/walk.glj:130:12
 			var tmp3 lang.FnFunc1
 			tmp3 = lang.FnFunc1(func(p0 any) any {
 				v4 := p0
 				_ = v4
+//line ../../clojure/walk.glj:130:20
 				var tmp5 any
+//line ../../clojure/walk.glj:130:24
 				tmp6 := aotExternalFn6(v4)
+//line ../../clojure/walk.glj:130:20
 				if lang.IsTruthy(tmp6) {
+//line ../../clojure/walk.glj:130:33
 					tmp7 := aotExternalFn7(v4)
+//line ../../clojure/walk.glj:130:20
 					tmp5 = tmp7
 				} else {
 					tmp5 = v4
 				}
+//line ../../clojure/walk.glj:130:12
 				return tmp5
 			})
+//line ../../clojure/walk.glj:130:3
 			tmp4 := aotDirectFn5(tmp3, v2)
+//line ../../clojure/walk.glj:126:7
 			return tmp4
 		})
 		aotDirectFn1 = tmp1
@@ -452,7 +492,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(126), kw_column, int(7), kw_end_DASH_line, int(126), kw_end_DASH_column, int(21), kw_arglists, lang.NewList(lang.NewVector(sym_form)), kw_doc, "Recursively performs all possible macroexpansions in form.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:496
 	// postwalk
+
+//line ../../clojure/walk.glj:53:7
 	{
 		tmp0 := sym_postwalk
 		var tmp1 lang.FnFunc2
@@ -461,9 +504,13 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/walk.glj:59:18
 			tmp4 := checkDerefVar(var_clojure_DOT_walk_postwalk)
+//line ../../clojure/walk.glj:59:9
 			tmp5 := aotExternalFn8(tmp4, v2)
+//line ../../clojure/walk.glj:59:3
 			tmp6 := aotDirectFn9(tmp5, v2, v3)
+//line ../../clojure/walk.glj:53:7
 			return tmp6
 		})
 		aotDirectFn2 = tmp1
@@ -472,24 +519,34 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(53), kw_column, int(7), kw_end_DASH_line, int(53), kw_end_DASH_column, int(14), kw_arglists, lang.NewList(lang.NewVector(sym_f, sym_form)), kw_doc, "Performs a depth-first, post-order traversal of form.  Calls f on\n  each sub-form, uses f's return value in place of the original.\n  Recognizes all Clojure data structures. Consumes seqs as with doall.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:523
 	// postwalk-demo
+
+//line ../../clojure/walk.glj:80:7
 	{
 		tmp0 := sym_postwalk_DASH_demo
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/walk.glj:85:13
 			var tmp3 lang.FnFunc1
 			tmp3 = lang.FnFunc1(func(p0 any) any {
 				v4 := p0
 				_ = v4
+//line ../../clojure/walk.glj:85:21
 				tmp5 := aotExternalFn9("Walked: ")
+//line ../../clojure/walk.glj:85:13
 				_ = tmp5
+//line ../../clojure/walk.glj:85:40
 				tmp6 := aotExternalFn10(v4)
+//line ../../clojure/walk.glj:85:13
 				_ = tmp6
 				return v4
 			})
+//line ../../clojure/walk.glj:85:3
 			tmp4 := aotDirectFn2(tmp3, v2)
+//line ../../clojure/walk.glj:80:7
 			return tmp4
 		})
 		aotDirectFn3 = tmp1
@@ -498,7 +555,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(80), kw_column, int(7), kw_end_DASH_line, int(80), kw_end_DASH_column, int(19), kw_arglists, lang.NewList(lang.NewVector(sym_form)), kw_doc, "Demonstrates the behavior of postwalk by printing each form as it is\n  walked.  Returns form.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:559
 	// postwalk-replace
+
+//line ../../clojure/walk.glj:118:7
 	{
 		tmp0 := sym_postwalk_DASH_replace
 		var tmp1 lang.FnFunc2
@@ -507,21 +567,30 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/walk.glj:124:13
 			var tmp4 lang.FnFunc1
 			tmp4 = lang.FnFunc1(func(p0 any) any {
 				v5 := p0
 				_ = v5
+//line ../../clojure/walk.glj:124:21
 				var tmp6 any
+//line ../../clojure/walk.glj:124:25
 				tmp7 := aotExternalFn11(v2, v5)
+//line ../../clojure/walk.glj:124:21
 				if lang.IsTruthy(tmp7) {
+//line ../../clojure/walk.glj:124:44
 					tmp8 := lang.Apply1(v2, v5)
+//line ../../clojure/walk.glj:124:21
 					tmp6 = tmp8
 				} else {
 					tmp6 = v5
 				}
+//line ../../clojure/walk.glj:124:13
 				return tmp6
 			})
+//line ../../clojure/walk.glj:124:3
 			tmp5 := aotDirectFn2(tmp4, v3)
+//line ../../clojure/walk.glj:118:7
 			return tmp5
 		})
 		aotDirectFn4 = tmp1
@@ -530,24 +599,34 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(118), kw_column, int(7), kw_end_DASH_line, int(118), kw_end_DASH_column, int(22), kw_arglists, lang.NewList(lang.NewVector(sym_smap, sym_form)), kw_doc, "Recursively transforms form by replacing keys in smap with their\n  values.  Like clojure/replace but works on any data structure.  Does\n  replacement at the leaves of the tree first.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:603
 	// prewalk-demo
+
+//line ../../clojure/walk.glj:87:7
 	{
 		tmp0 := sym_prewalk_DASH_demo
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/walk.glj:92:12
 			var tmp3 lang.FnFunc1
 			tmp3 = lang.FnFunc1(func(p0 any) any {
 				v4 := p0
 				_ = v4
+//line ../../clojure/walk.glj:92:20
 				tmp5 := aotExternalFn9("Walked: ")
+//line ../../clojure/walk.glj:92:12
 				_ = tmp5
+//line ../../clojure/walk.glj:92:39
 				tmp6 := aotExternalFn10(v4)
+//line ../../clojure/walk.glj:92:12
 				_ = tmp6
 				return v4
 			})
+//line ../../clojure/walk.glj:92:3
 			tmp4 := aotDirectFn5(tmp3, v2)
+//line ../../clojure/walk.glj:87:7
 			return tmp4
 		})
 		aotDirectFn6 = tmp1
@@ -556,7 +635,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(87), kw_column, int(7), kw_end_DASH_line, int(87), kw_end_DASH_column, int(18), kw_arglists, lang.NewList(lang.NewVector(sym_form)), kw_doc, "Demonstrates the behavior of prewalk by printing each form as it is\n  walked.  Returns form.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:639
 	// prewalk-replace
+
+//line ../../clojure/walk.glj:110:7
 	{
 		tmp0 := sym_prewalk_DASH_replace
 		var tmp1 lang.FnFunc2
@@ -565,21 +647,30 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/walk.glj:116:12
 			var tmp4 lang.FnFunc1
 			tmp4 = lang.FnFunc1(func(p0 any) any {
 				v5 := p0
 				_ = v5
+//line ../../clojure/walk.glj:116:20
 				var tmp6 any
+//line ../../clojure/walk.glj:116:24
 				tmp7 := aotExternalFn11(v2, v5)
+//line ../../clojure/walk.glj:116:20
 				if lang.IsTruthy(tmp7) {
+//line ../../clojure/walk.glj:116:43
 					tmp8 := lang.Apply1(v2, v5)
+//line ../../clojure/walk.glj:116:20
 					tmp6 = tmp8
 				} else {
 					tmp6 = v5
 				}
+//line ../../clojure/walk.glj:116:12
 				return tmp6
 			})
+//line ../../clojure/walk.glj:116:3
 			tmp5 := aotDirectFn5(tmp4, v3)
+//line ../../clojure/walk.glj:110:7
 			return tmp5
 		})
 		aotDirectFn7 = tmp1
@@ -588,16 +679,22 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(110), kw_column, int(7), kw_end_DASH_line, int(110), kw_end_DASH_column, int(21), kw_arglists, lang.NewList(lang.NewVector(sym_smap, sym_form)), kw_doc, "Recursively transforms form by replacing keys in smap with their\n  values.  Like clojure/replace but works on any data structure.  Does\n  replacement at the root of the tree first.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:683
 	// stringify-keys
+
+//line ../../clojure/walk.glj:102:7
 	{
 		tmp0 := sym_stringify_DASH_keys
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../clojure/walk.glj:106:3
 			var tmp3 any
 			{ // let
 				// let binding "f"
+
+//line ../../clojure/walk.glj:106:11
 				var tmp4 lang.FnFunc1
 				tmp4 = lang.FnFunc1(func(p0 any) any {
 					v5 := p0
@@ -615,41 +712,63 @@ func LoadNS() {
 						tmp10 := runtime.RT.NthDefault(v7, lang.IntCast(int64(1)), nil)
 						var v11 any = tmp10
 						_ = v11
+//line ../../clojure/walk.glj:106:23
 						var tmp12 any
+//line ../../clojure/walk.glj:106:27
 						tmp13 := aotExternalFn12(v9)
+//line ../../clojure/walk.glj:106:23
 						if lang.IsTruthy(tmp13) {
+//line ../../clojure/walk.glj:106:41
 							tmp14 := aotExternalFn13(v9)
+//line ../../clojure/walk.glj:106:40
 							tmp15 := lang.NewVector(tmp14, v11)
+//line ../../clojure/walk.glj:106:23
 							tmp12 = tmp15
 						} else {
+//line ../../clojure/walk.glj:106:53
 							tmp16 := lang.NewVector(v9, v11)
+//line ../../clojure/walk.glj:106:23
 							tmp12 = tmp16
 						}
+//line ../../clojure/walk.glj:106:11
 						tmp6 = tmp12
 					} // end let
 					return tmp6
 				})
+//line ../../clojure/walk.glj:106:3
 				var v5 any = tmp4
 				_ = v5
+//line ../../clojure/walk.glj:108:15
 				var tmp6 lang.FnFunc1
 				tmp6 = lang.FnFunc1(func(p0 any) any {
 					v7 := p0
 					_ = v7
+//line ../../clojure/walk.glj:108:23
 					var tmp8 any
+//line ../../clojure/walk.glj:108:27
 					tmp9 := aotExternalFn3(v7)
+//line ../../clojure/walk.glj:108:23
 					if lang.IsTruthy(tmp9) {
+//line ../../clojure/walk.glj:108:42
 						tmp10 := lang.NewMap()
+//line ../../clojure/walk.glj:108:45
 						tmp11 := aotExternalFn5(v5, v7)
+//line ../../clojure/walk.glj:108:36
 						tmp12 := aotExternalFn4(tmp10, tmp11)
+//line ../../clojure/walk.glj:108:23
 						tmp8 = tmp12
 					} else {
 						tmp8 = v7
 					}
+//line ../../clojure/walk.glj:108:15
 					return tmp8
 				})
+//line ../../clojure/walk.glj:108:5
 				tmp7 := aotDirectFn2(tmp6, v2)
+//line ../../clojure/walk.glj:106:3
 				tmp3 = tmp7
 			} // end let
+//line ../../clojure/walk.glj:102:7
 			return tmp3
 		})
 		aotDirectFn8 = tmp1
@@ -658,7 +777,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(102), kw_column, int(7), kw_end_DASH_line, int(102), kw_end_DASH_column, int(20), kw_arglists, lang.NewList(lang.NewVector(sym_m)), kw_doc, "Recursively transforms all map keys from keywords to strings.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:781
 	// prewalk
+
+//line ../../clojure/walk.glj:61:7
 	{
 		tmp0 := sym_prewalk
 		var tmp1 lang.FnFunc2
@@ -667,11 +789,17 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../clojure/walk.glj:65:18
 			tmp4 := checkDerefVar(var_clojure_DOT_walk_prewalk)
+//line ../../clojure/walk.glj:65:9
 			tmp5 := aotExternalFn8(tmp4, v2)
+//line ../../clojure/walk.glj:65:29
 			tmp6 := checkDerefVar(var_clojure_DOT_core_identity)
+//line ../../clojure/walk.glj:65:38
 			tmp7 := lang.Apply1(v2, v3)
+//line ../../clojure/walk.glj:65:3
 			tmp8 := aotDirectFn9(tmp5, tmp6, tmp7)
+//line ../../clojure/walk.glj:61:7
 			return tmp8
 		})
 		aotDirectFn5 = tmp1
@@ -680,7 +808,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(61), kw_column, int(7), kw_end_DASH_line, int(61), kw_end_DASH_column, int(13), kw_arglists, lang.NewList(lang.NewVector(sym_f, sym_form)), kw_doc, "Like postwalk, but does pre-order traversal.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:812
 	// walk
+
+//line ../../clojure/walk.glj:35:7
 	{
 		tmp0 := sym_walk
 		var tmp1 lang.FnFunc3
@@ -691,69 +822,115 @@ func LoadNS() {
 			_ = v3
 			v4 := p2
 			_ = v4
+//line ../../clojure/walk.glj:43:3
 			var tmp5 any
+//line ../../clojure/walk.glj:44:4
 			tmp6 := aotExternalFn14(v4)
+//line ../../clojure/walk.glj:43:3
 			if lang.IsTruthy(tmp6) {
+//line ../../clojure/walk.glj:44:42
 				tmp7 := checkDerefVar(var_clojure_DOT_core_list)
+//line ../../clojure/walk.glj:44:47
 				tmp8 := aotExternalFn5(v2, v4)
+//line ../../clojure/walk.glj:44:35
 				tmp9 := aotExternalFn16(tmp7, tmp8)
+//line ../../clojure/walk.glj:44:65
 				tmp10 := aotExternalFn17(v4)
+//line ../../clojure/walk.glj:44:24
 				tmp11 := aotExternalFn15(tmp9, tmp10)
+//line ../../clojure/walk.glj:44:17
 				tmp12 := lang.Apply1(v3, tmp11)
+//line ../../clojure/walk.glj:43:3
 				tmp5 = tmp12
 			} else {
 				var tmp13 any
+//line ../../clojure/walk.glj:45:4
 				tmp14 := lang.IsInstance[lang.IMapEntry](v4)
+//line ../../clojure/walk.glj:43:3
 				if lang.IsTruthy(tmp14) {
+//line ../../clojure/walk.glj:46:12
 					tmp15, ok := pkgmap4.Get("clojure.lang.MapEntry.create")
 					if !ok {
 						panic(lang.NewIllegalArgumentError("unable to resolve host form: clojure.lang.MapEntry.create"))
 					}
+//line ../../clojure/walk.glj:46:48
 					tmp16 := aotExternalFn19(v4)
+//line ../../clojure/walk.glj:46:41
 					tmp17 := lang.Apply1(v2, tmp16)
+//line ../../clojure/walk.glj:46:67
 					tmp18 := aotExternalFn20(v4)
+//line ../../clojure/walk.glj:46:60
 					tmp19 := lang.Apply1(v2, tmp18)
+//line ../../clojure/walk.glj:46:11
 					tmp20 := lang.Apply2(tmp15, tmp17, tmp19)
+//line ../../clojure/walk.glj:46:4
 					tmp21 := lang.Apply1(v3, tmp20)
+//line ../../clojure/walk.glj:43:3
 					tmp13 = tmp21
 				} else {
 					var tmp22 any
+//line ../../clojure/walk.glj:47:4
 					tmp23 := aotExternalFn6(v4)
+//line ../../clojure/walk.glj:43:3
 					if lang.IsTruthy(tmp23) {
+//line ../../clojure/walk.glj:47:41
 						tmp24 := aotExternalFn5(v2, v4)
+//line ../../clojure/walk.glj:47:34
 						tmp25 := aotExternalFn21(tmp24)
+//line ../../clojure/walk.glj:47:59
 						tmp26 := aotExternalFn17(v4)
+//line ../../clojure/walk.glj:47:23
 						tmp27 := aotExternalFn15(tmp25, tmp26)
+//line ../../clojure/walk.glj:47:16
 						tmp28 := lang.Apply1(v3, tmp27)
+//line ../../clojure/walk.glj:43:3
 						tmp22 = tmp28
 					} else {
 						var tmp29 any
+//line ../../clojure/walk.glj:48:4
 						tmp30 := lang.IsInstance[lang.IRecord](v4)
+//line ../../clojure/walk.glj:43:3
 						if lang.IsTruthy(tmp30) {
+//line ../../clojure/walk.glj:49:21
 							var tmp31 lang.FnFunc2
 							tmp31 = lang.FnFunc2(func(p0, p1 any) any {
 								v32 := p0
 								_ = v32
 								v33 := p1
 								_ = v33
+//line ../../clojure/walk.glj:49:39
 								tmp34 := lang.Apply1(v2, v33)
+//line ../../clojure/walk.glj:49:31
 								tmp35 := lang.ConjAny(v32, tmp34)
+//line ../../clojure/walk.glj:49:21
 								return tmp35
 							})
+//line ../../clojure/walk.glj:49:13
 							tmp32 := aotExternalFn22(tmp31, v4, v4)
+//line ../../clojure/walk.glj:49:6
 							tmp33 := lang.Apply1(v3, tmp32)
+//line ../../clojure/walk.glj:43:3
 							tmp29 = tmp33
 						} else {
 							var tmp34 any
+//line ../../clojure/walk.glj:50:4
 							tmp35 := aotExternalFn24(v4)
+//line ../../clojure/walk.glj:43:3
 							if lang.IsTruthy(tmp35) {
+//line ../../clojure/walk.glj:50:30
 								tmp36 := aotExternalFn25(v4)
+//line ../../clojure/walk.glj:50:43
 								tmp37 := aotExternalFn5(v2, v4)
+//line ../../clojure/walk.glj:50:24
 								tmp38 := aotExternalFn4(tmp36, tmp37)
+//line ../../clojure/walk.glj:50:17
 								tmp39 := lang.Apply1(v3, tmp38)
+//line ../../clojure/walk.glj:43:3
 								tmp34 = tmp39
 							} else {
+//line ../../clojure/walk.glj:51:10
 								tmp40 := lang.Apply1(v3, v4)
+//line ../../clojure/walk.glj:43:3
 								tmp34 = tmp40
 							}
 							tmp29 = tmp34
@@ -764,6 +941,7 @@ func LoadNS() {
 				}
 				tmp5 = tmp13
 			}
+//line ../../clojure/walk.glj:35:7
 			return tmp5
 		})
 		aotDirectFn9 = tmp1
@@ -772,4 +950,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "clojure/walk.glj", kw_line, int(35), kw_column, int(7), kw_end_DASH_line, int(35), kw_end_DASH_column, int(10), kw_arglists, lang.NewList(lang.NewVector(sym_inner, sym_outer, sym_form)), kw_doc, "Traverses form, an arbitrary data structure.  inner and outer are\n  functions.  Applies inner to each element of form, building up a\n  data structure of the same type, then applies outer to the result.\n  Recognizes all Clojure data structures. Consumes seqs as with doall.", kw_added, "1.1", kw_ns, lang.FindOrCreateNamespace(sym_clojure_DOT_walk))
 		})
 	}
+//line loader.go:954
 }
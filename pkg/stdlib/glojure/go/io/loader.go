@@ -133,13 +133,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -283,6 +283,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -405,10 +406,15 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:174:55
 			tmp3 := aotExternalFn4(v1)
+//line ../../../glojure/go/io.glj:174:34
 			tmp4 := aotExternalFn3("Cannot open <", tmp3, "> as an OutputStream.")
+//line ../../../glojure/go/io.glj:173:33
 			tmp5 := lang.Apply1(lang.NewIllegalArgumentError, tmp4)
+//line ../../../glojure/go/io.glj:173:26
 			panic(tmp5)
+//line loader.go:418
 		})
 		closed7 = tmp0
 	}
@@ -424,6 +430,8 @@ func LoadNS() {
 		closed8 = tmp0
 	}
 	// byte-array-type
+
+//line ../../../glojure/go/io.glj:16:5
 	{
 		tmp0 := sym_byte_DASH_array_DASH_type
 		tmp1 := reflect.TypeOf([]uint8(nil))
@@ -432,7 +440,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/io.glj", kw_line, int(16), kw_column, int(5), kw_end_DASH_line, int(19), kw_end_DASH_column, int(16), kw_doc, "Type object for a Go primitive byte slice.", kw_private, true, kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:444
 	// do-copy
+
+//line ../../../glojure/go/io.glj:296:3
 	{
 		tmp0 := sym_do_DASH_copy
 		var tmp2 lang.FnFunc3
@@ -443,9 +454,13 @@ func LoadNS() {
 			_ = v4
 			v5 := p2
 			_ = v5
+//line ../../../glojure/go/io.glj:300:28
 			tmp6 := aotExternalFn5(v3)
+//line ../../../glojure/go/io.glj:300:41
 			tmp7 := aotExternalFn5(v4)
+//line ../../../glojure/go/io.glj:300:27
 			tmp8 := lang.NewVector(tmp6, tmp7)
+//line ../../../glojure/go/io.glj:296:3
 			return tmp8
 		})
 		// MultiFn do-copy
@@ -460,6 +475,7 @@ func LoadNS() {
 			_ = v7
 			v8 := p2
 			_ = v8
+//line ../../../glojure/go/io.glj:303:3
 			var tmp9 any
 			{ // let
 				// let binding "res__0__auto__"
@@ -527,15 +543,21 @@ func LoadNS() {
 				_ = tmp35
 				tmp9 = v34
 			} // end let
+//line ../../../glojure/go/io.glj:296:3
 			return tmp9
 		})
-		tmp1.AddMethod(lang.NewVector(tmp3, tmp4), tmp5)
+		tmp1.AddMethods(
+			lang.NewVector(tmp3, tmp4), tmp5,
+		)
 		var_glojure_DOT_go_DOT_io_do_DASH_copy = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_io_do_DASH_copy.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/io.glj", kw_line, int(296), kw_column, int(3), kw_end_DASH_line, int(299), kw_end_DASH_column, int(9), kw_doc, "Internal helper for copy", kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_input, sym_output, sym_opts)), kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:558
 	// make-input-stream
+
+//line ../../../glojure/go/io.glj:86:4
 	{
 		tmp0 := sym_make_DASH_input_DASH_stream
 		var tmp2 lang.ArityFn
@@ -570,13 +592,15 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp7 := lang.NewCons(v5, v6)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp8 := aotExternalFn1(closed3, tmp7)
+//line ../../../glojure/go/io.glj:86:4
 				return tmp8
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp3, tmp4)
 		tmp5 := reflect.TypeOf((*os6.File)(nil))
 		var tmp6 lang.ArityFn
 		tmp6 = lang.NewArityFn(
@@ -590,13 +614,15 @@ func LoadNS() {
 				_ = v7
 				var v8 any = rest
 				_ = v8
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp9 := lang.NewCons(v7, v8)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp10 := aotExternalFn1(closed4, tmp9)
+//line ../../../glojure/go/io.glj:86:4
 				return tmp10
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp5, tmp6)
 		tmp7 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp8 lang.ArityFn
 		tmp8 = lang.NewArityFn(
@@ -610,13 +636,15 @@ func LoadNS() {
 				_ = v9
 				var v10 any = rest
 				_ = v10
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp11 := lang.NewCons(v9, v10)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp12 := aotExternalFn1(closed5, tmp11)
+//line ../../../glojure/go/io.glj:86:4
 				return tmp12
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp7, tmp8)
 		tmp9 := reflect.TypeOf("")
 		var tmp10 lang.ArityFn
 		tmp10 = lang.NewArityFn(
@@ -630,19 +658,30 @@ func LoadNS() {
 				_ = v11
 				var v12 any = rest
 				_ = v12
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp13 := lang.NewCons(v11, v12)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp14 := aotExternalFn1(closed6, tmp13)
+//line ../../../glojure/go/io.glj:86:4
 				return tmp14
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp9, tmp10)
+		tmp1.AddMethods(
+			tmp3, tmp4,
+			tmp5, tmp6,
+			tmp7, tmp8,
+			tmp9, tmp10,
+		)
 		var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/io.glj", kw_line, int(86), kw_column, int(4), kw_end_DASH_line, int(86), kw_end_DASH_column, int(36), kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:682
 	// make-output-stream
+
+//line ../../../glojure/go/io.glj:87:4
 	{
 		tmp0 := sym_make_DASH_output_DASH_stream
 		var tmp2 lang.ArityFn
@@ -677,13 +716,15 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp7 := lang.NewCons(v5, v6)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp8 := aotExternalFn1(closed7, tmp7)
+//line ../../../glojure/go/io.glj:87:4
 				return tmp8
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp3, tmp4)
 		tmp5 := reflect.TypeOf((*os6.File)(nil))
 		var tmp6 lang.ArityFn
 		tmp6 = lang.NewArityFn(
@@ -697,13 +738,15 @@ func LoadNS() {
 				_ = v7
 				var v8 any = rest
 				_ = v8
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp9 := lang.NewCons(v7, v8)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp10 := aotExternalFn1(closed8, tmp9)
+//line ../../../glojure/go/io.glj:87:4
 				return tmp10
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp5, tmp6)
 		tmp7 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp8 lang.ArityFn
 		tmp8 = lang.NewArityFn(
@@ -717,13 +760,15 @@ func LoadNS() {
 				_ = v9
 				var v10 any = rest
 				_ = v10
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp11 := lang.NewCons(v9, v10)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp12 := aotExternalFn1(closed9, tmp11)
+//line ../../../glojure/go/io.glj:87:4
 				return tmp12
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp7, tmp8)
 		tmp9 := reflect.TypeOf("")
 		var tmp10 lang.ArityFn
 		tmp10 = lang.NewArityFn(
@@ -737,19 +782,30 @@ func LoadNS() {
 				_ = v11
 				var v12 any = rest
 				_ = v12
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp13 := lang.NewCons(v11, v12)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp14 := aotExternalFn1(closed10, tmp13)
+//line ../../../glojure/go/io.glj:87:4
 				return tmp14
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp9, tmp10)
+		tmp1.AddMethods(
+			tmp3, tmp4,
+			tmp5, tmp6,
+			tmp7, tmp8,
+			tmp9, tmp10,
+		)
 		var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/io.glj", kw_line, int(87), kw_column, int(4), kw_end_DASH_line, int(87), kw_end_DASH_column, int(37), kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:806
 	// make-reader
+
+//line ../../../glojure/go/io.glj:84:4
 	{
 		tmp0 := sym_make_DASH_reader
 		var tmp2 lang.ArityFn
@@ -784,13 +840,15 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp7 := lang.NewCons(v5, v6)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp8 := aotExternalFn1(closed0, tmp7)
+//line ../../../glojure/go/io.glj:84:4
 				return tmp8
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp3, tmp4)
 		tmp5 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp6 lang.ArityFn
 		tmp6 = lang.NewArityFn(
@@ -804,13 +862,15 @@ func LoadNS() {
 				_ = v7
 				var v8 any = rest
 				_ = v8
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp9 := lang.NewCons(v7, v8)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp10 := aotExternalFn1(closed1, tmp9)
+//line ../../../glojure/go/io.glj:84:4
 				return tmp10
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp5, tmp6)
 		tmp7 := reflect.TypeOf("")
 		var tmp8 lang.ArityFn
 		tmp8 = lang.NewArityFn(
@@ -824,19 +884,29 @@ func LoadNS() {
 				_ = v9
 				var v10 any = rest
 				_ = v10
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp11 := lang.NewCons(v9, v10)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp12 := aotExternalFn1(closed1, tmp11)
+//line ../../../glojure/go/io.glj:84:4
 				return tmp12
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp7, tmp8)
+		tmp1.AddMethods(
+			tmp3, tmp4,
+			tmp5, tmp6,
+			tmp7, tmp8,
+		)
 		var_glojure_DOT_go_DOT_io_make_DASH_reader = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_io_make_DASH_reader.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/io.glj", kw_line, int(84), kw_column, int(4), kw_end_DASH_line, int(84), kw_end_DASH_column, int(30), kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:907
 	// make-writer
+
+//line ../../../glojure/go/io.glj:85:4
 	{
 		tmp0 := sym_make_DASH_writer
 		var tmp2 lang.ArityFn
@@ -871,13 +941,15 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp7 := lang.NewCons(v5, v6)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp8 := aotExternalFn1(closed2, tmp7)
+//line ../../../glojure/go/io.glj:85:4
 				return tmp8
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp3, tmp4)
 		tmp5 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp6 lang.ArityFn
 		tmp6 = lang.NewArityFn(
@@ -891,13 +963,15 @@ func LoadNS() {
 				_ = v7
 				var v8 any = rest
 				_ = v8
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp9 := lang.NewCons(v7, v8)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp10 := aotExternalFn1(closed2, tmp9)
+//line ../../../glojure/go/io.glj:85:4
 				return tmp10
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp5, tmp6)
 		tmp7 := reflect.TypeOf("")
 		var tmp8 lang.ArityFn
 		tmp8 = lang.NewArityFn(
@@ -911,18 +985,26 @@ func LoadNS() {
 				_ = v9
 				var v10 any = rest
 				_ = v10
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp11 := lang.NewCons(v9, v10)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp12 := aotExternalFn1(closed2, tmp11)
+//line ../../../glojure/go/io.glj:85:4
 				return tmp12
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp7, tmp8)
+		tmp1.AddMethods(
+			tmp3, tmp4,
+			tmp5, tmp6,
+			tmp7, tmp8,
+		)
 		var_glojure_DOT_go_DOT_io_make_DASH_writer = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_io_make_DASH_writer.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/io.glj", kw_line, int(85), kw_column, int(4), kw_end_DASH_line, int(85), kw_end_DASH_column, int(30), kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:1008
 	{
 		var tmp0 lang.FnFunc2
 		tmp0 = lang.FnFunc2(func(p0, p1 any) any {
@@ -930,8 +1012,11 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:167:31
 			tmp3 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream)
+//line ../../../glojure/go/io.glj:167:30
 			tmp4 := lang.Apply2(tmp3, v1, v2)
+//line loader.go:1020
 			return tmp4
 		})
 		closed1 = tmp0
@@ -943,6 +1028,7 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:260:27
 			var tmp3 any
 			func() {
 				defer func() {
@@ -950,11 +1036,16 @@ func LoadNS() {
 						if lang.CatchMatches(r, lang.Builtins["any"]) {
 							v4 := r
 							_ = v4
+//line ../../../glojure/go/io.glj:263:31
 							tmp5 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream)
+//line ../../../glojure/go/io.glj:263:50
 							var tmp6 any
 							{ // let
 								// let binding "res__0__auto__"
+
+//line ../../../glojure/go/io.glj:263:59
 								tmp7 := lang.Apply1(os6.Open, v1)
+//line ../../../glojure/go/io.glj:263:50
 								tmp8 := lang.Apply0(tmp7)
 								var v9 any = tmp8
 								_ = v9
@@ -1019,18 +1110,25 @@ func LoadNS() {
 								_ = tmp33
 								tmp6 = v32
 							} // end let
+//line ../../../glojure/go/io.glj:263:30
 							tmp7 := lang.Apply2(tmp5, tmp6, v2)
+//line ../../../glojure/go/io.glj:260:27
 							tmp3 = tmp7
 						} else {
 							panic(r)
 						}
 					}
 				}()
+//line ../../../glojure/go/io.glj:261:29
 				tmp4 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream)
+//line ../../../glojure/go/io.glj:261:48
 				var tmp5 any
 				{ // let
 					// let binding "res__0__auto__"
+
+//line ../../../glojure/go/io.glj:261:57
 					tmp6 := lang.Apply1(url5.Parse, v1)
+//line ../../../glojure/go/io.glj:261:48
 					tmp7 := lang.Apply0(tmp6)
 					var v8 any = tmp7
 					_ = v8
@@ -1095,9 +1193,12 @@ func LoadNS() {
 					_ = tmp32
 					tmp5 = v31
 				} // end let
+//line ../../../glojure/go/io.glj:261:28
 				tmp6 := lang.Apply2(tmp4, tmp5, v2)
+//line ../../../glojure/go/io.glj:260:27
 				tmp3 = tmp6
 			}()
+//line loader.go:1202
 			return tmp3
 		})
 		closed10 = tmp0
@@ -1109,10 +1210,15 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:168:31
 			tmp3 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_writer)
+//line ../../../glojure/go/io.glj:168:44
 			tmp4 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream)
+//line ../../../glojure/go/io.glj:168:43
 			tmp5 := lang.Apply2(tmp4, v1, v2)
+//line ../../../glojure/go/io.glj:168:30
 			tmp6 := lang.Apply2(tmp3, tmp5, v2)
+//line loader.go:1222
 			return tmp6
 		})
 		closed2 = tmp0
@@ -1124,8 +1230,11 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:233:27
 			tmp3 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream)
+//line ../../../glojure/go/io.glj:234:27
 			var tmp4 any
+//line ../../../glojure/go/io.glj:234:41
 			tmp5, ok := lang.FieldOrMethod(v1, "scheme")
 			if !ok {
 				panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "scheme")))
@@ -1137,11 +1246,16 @@ func LoadNS() {
 			default:
 				tmp6 = tmp5
 			}
+//line ../../../glojure/go/io.glj:234:31
 			tmp7 := aotExternalFn18("file", tmp6)
+//line ../../../glojure/go/io.glj:234:27
 			if lang.IsTruthy(tmp7) {
+//line ../../../glojure/go/io.glj:235:29
 				tmp8 := lang.Apply1(nil, v1)
+//line ../../../glojure/go/io.glj:234:27
 				tmp4 = tmp8
 			} else {
+//line ../../../glojure/go/io.glj:236:29
 				var tmp9 any
 				{ // let
 					// let binding "res__0__auto__"
@@ -1209,9 +1323,12 @@ func LoadNS() {
 					_ = tmp35
 					tmp9 = v34
 				} // end let
+//line ../../../glojure/go/io.glj:234:27
 				tmp4 = tmp9
 			}
+//line ../../../glojure/go/io.glj:233:26
 			tmp10 := lang.Apply2(tmp3, tmp4, v2)
+//line loader.go:1332
 			return tmp10
 		})
 		closed5 = tmp0
@@ -1223,10 +1340,14 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:255:26
 			var tmp3 any
 			{ // let
 				// let binding "vec__778"
+
+//line ../../../glojure/go/io.glj:255:42
 				tmp4 := lang.Apply1(url5.ParseRequestURI, v1)
+//line ../../../glojure/go/io.glj:255:26
 				var v5 any = tmp4
 				_ = v5
 				// let binding "url"
@@ -1237,7 +1358,9 @@ func LoadNS() {
 				tmp8 := runtime.RT.NthDefault(v5, lang.IntCast(int64(1)), nil)
 				var v9 any = tmp8
 				_ = v9
+//line ../../../glojure/go/io.glj:256:28
 				var tmp10 any
+//line ../../../glojure/go/io.glj:256:32
 				var tmp11 any
 				{ // let
 					// let binding "or__0__auto__"
@@ -1247,6 +1370,7 @@ func LoadNS() {
 					if lang.IsTruthy(v12) {
 						tmp13 = v12
 					} else {
+//line ../../../glojure/go/io.glj:256:46
 						tmp14, ok := lang.FieldOrMethod(v7, "Scheme")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "Scheme")))
@@ -1258,13 +1382,18 @@ func LoadNS() {
 						default:
 							tmp15 = tmp14
 						}
+//line ../../../glojure/go/io.glj:256:40
 						tmp16 := aotExternalFn18("", tmp15)
+//line ../../../glojure/go/io.glj:256:32
 						tmp13 = tmp16
 					}
 					tmp11 = tmp13
 				} // end let
+//line ../../../glojure/go/io.glj:256:28
 				if lang.IsTruthy(tmp11) {
+//line ../../../glojure/go/io.glj:257:31
 					tmp12 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream)
+//line ../../../glojure/go/io.glj:257:49
 					var tmp13 any
 					{ // let
 						// let binding "res__0__auto__"
@@ -1332,15 +1461,22 @@ func LoadNS() {
 						_ = tmp39
 						tmp13 = v38
 					} // end let
+//line ../../../glojure/go/io.glj:257:30
 					tmp14 := lang.Apply2(tmp12, tmp13, v2)
+//line ../../../glojure/go/io.glj:256:28
 					tmp10 = tmp14
 				} else {
+//line ../../../glojure/go/io.glj:258:31
 					tmp15 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream)
+//line ../../../glojure/go/io.glj:258:30
 					tmp16 := lang.Apply2(tmp15, v7, v2)
+//line ../../../glojure/go/io.glj:256:28
 					tmp10 = tmp16
 				}
+//line ../../../glojure/go/io.glj:255:26
 				tmp3 = tmp10
 			} // end let
+//line loader.go:1480
 			return tmp3
 		})
 		closed6 = tmp0
@@ -1352,7 +1488,9 @@ func LoadNS() {
 			_ = v1
 			v2 := p1
 			_ = v2
+//line ../../../glojure/go/io.glj:241:27
 			var tmp3 any
+//line ../../../glojure/go/io.glj:241:41
 			tmp4, ok := lang.FieldOrMethod(v1, "scheme")
 			if !ok {
 				panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "scheme")))
@@ -1364,22 +1502,35 @@ func LoadNS() {
 			default:
 				tmp5 = tmp4
 			}
+//line ../../../glojure/go/io.glj:241:31
 			tmp6 := aotExternalFn18("file", tmp5)
+//line ../../../glojure/go/io.glj:241:27
 			if lang.IsTruthy(tmp6) {
+//line ../../../glojure/go/io.glj:242:30
 				tmp7 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream)
+//line ../../../glojure/go/io.glj:242:49
 				tmp8 := lang.Apply1(nil, v1)
+//line ../../../glojure/go/io.glj:242:29
 				tmp9 := lang.Apply2(tmp7, tmp8, v2)
+//line ../../../glojure/go/io.glj:241:27
 				tmp3 = tmp9
 			} else {
+//line ../../../glojure/go/io.glj:243:101
 				tmp10 := aotExternalFn3("Can not write to non-file URL <", v1, ">")
+//line ../../../glojure/go/io.glj:243:36
 				tmp11 := lang.Apply1(lang.NewIllegalArgumentError, tmp10)
+//line ../../../glojure/go/io.glj:243:29
 				panic(tmp11)
+//line ../../../glojure/go/io.glj:241:27
 			}
+//line loader.go:1527
 			return tmp3
 		})
 		closed9 = tmp0
 	}
 	// IOFactory
+
+//line ../../../glojure/go/io.glj:72:14
 	{
 		tmp0 := sym_IOFactory
 		var tmp3 lang.ArityFn
@@ -1414,13 +1565,15 @@ func LoadNS() {
 				_ = v6
 				var v7 any = rest
 				_ = v7
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp8 := lang.NewCons(v6, v7)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp9 := aotExternalFn1(closed0, tmp8)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp9
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp4, tmp5)
 		tmp6 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp7 lang.ArityFn
 		tmp7 = lang.NewArityFn(
@@ -1434,13 +1587,15 @@ func LoadNS() {
 				_ = v8
 				var v9 any = rest
 				_ = v9
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp10 := lang.NewCons(v8, v9)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp11 := aotExternalFn1(closed1, tmp10)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp11
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp6, tmp7)
 		tmp8 := reflect.TypeOf("")
 		var tmp9 lang.ArityFn
 		tmp9 = lang.NewArityFn(
@@ -1454,13 +1609,20 @@ func LoadNS() {
 				_ = v10
 				var v11 any = rest
 				_ = v11
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp12 := lang.NewCons(v10, v11)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp13 := aotExternalFn1(closed1, tmp12)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp13
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp8, tmp9)
+		tmp2.AddMethods(
+			tmp4, tmp5,
+			tmp6, tmp7,
+			tmp8, tmp9,
+		)
 		var tmp11 lang.ArityFn
 		tmp11 = lang.NewArityFn(
 			nil,
@@ -1493,13 +1655,15 @@ func LoadNS() {
 				_ = v14
 				var v15 any = rest
 				_ = v15
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp16 := lang.NewCons(v14, v15)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp17 := aotExternalFn1(closed2, tmp16)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp17
 			}),
 			1,
 		)
-		tmp10.AddMethod(tmp12, tmp13)
 		tmp14 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp15 lang.ArityFn
 		tmp15 = lang.NewArityFn(
@@ -1513,13 +1677,15 @@ func LoadNS() {
 				_ = v16
 				var v17 any = rest
 				_ = v17
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp18 := lang.NewCons(v16, v17)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp19 := aotExternalFn1(closed2, tmp18)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp19
 			}),
 			1,
 		)
-		tmp10.AddMethod(tmp14, tmp15)
 		tmp16 := reflect.TypeOf("")
 		var tmp17 lang.ArityFn
 		tmp17 = lang.NewArityFn(
@@ -1533,13 +1699,20 @@ func LoadNS() {
 				_ = v18
 				var v19 any = rest
 				_ = v19
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp20 := lang.NewCons(v18, v19)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp21 := aotExternalFn1(closed2, tmp20)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp21
 			}),
 			1,
 		)
-		tmp10.AddMethod(tmp16, tmp17)
+		tmp10.AddMethods(
+			tmp12, tmp13,
+			tmp14, tmp15,
+			tmp16, tmp17,
+		)
 		var tmp19 lang.ArityFn
 		tmp19 = lang.NewArityFn(
 			nil,
@@ -1572,13 +1745,15 @@ func LoadNS() {
 				_ = v22
 				var v23 any = rest
 				_ = v23
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp24 := lang.NewCons(v22, v23)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp25 := aotExternalFn1(closed3, tmp24)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp25
 			}),
 			1,
 		)
-		tmp18.AddMethod(tmp20, tmp21)
 		tmp22 := reflect.TypeOf((*os6.File)(nil))
 		var tmp23 lang.ArityFn
 		tmp23 = lang.NewArityFn(
@@ -1592,13 +1767,15 @@ func LoadNS() {
 				_ = v24
 				var v25 any = rest
 				_ = v25
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp26 := lang.NewCons(v24, v25)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp27 := aotExternalFn1(closed4, tmp26)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp27
 			}),
 			1,
 		)
-		tmp18.AddMethod(tmp22, tmp23)
 		tmp24 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp25 lang.ArityFn
 		tmp25 = lang.NewArityFn(
@@ -1612,13 +1789,15 @@ func LoadNS() {
 				_ = v26
 				var v27 any = rest
 				_ = v27
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp28 := lang.NewCons(v26, v27)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp29 := aotExternalFn1(closed5, tmp28)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp29
 			}),
 			1,
 		)
-		tmp18.AddMethod(tmp24, tmp25)
 		tmp26 := reflect.TypeOf("")
 		var tmp27 lang.ArityFn
 		tmp27 = lang.NewArityFn(
@@ -1632,13 +1811,21 @@ func LoadNS() {
 				_ = v28
 				var v29 any = rest
 				_ = v29
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp30 := lang.NewCons(v28, v29)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp31 := aotExternalFn1(closed6, tmp30)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp31
 			}),
 			1,
 		)
-		tmp18.AddMethod(tmp26, tmp27)
+		tmp18.AddMethods(
+			tmp20, tmp21,
+			tmp22, tmp23,
+			tmp24, tmp25,
+			tmp26, tmp27,
+		)
 		var tmp29 lang.ArityFn
 		tmp29 = lang.NewArityFn(
 			nil,
@@ -1671,13 +1858,15 @@ func LoadNS() {
 				_ = v32
 				var v33 any = rest
 				_ = v33
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp34 := lang.NewCons(v32, v33)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp35 := aotExternalFn1(closed7, tmp34)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp35
 			}),
 			1,
 		)
-		tmp28.AddMethod(tmp30, tmp31)
 		tmp32 := reflect.TypeOf((*os6.File)(nil))
 		var tmp33 lang.ArityFn
 		tmp33 = lang.NewArityFn(
@@ -1691,13 +1880,15 @@ func LoadNS() {
 				_ = v34
 				var v35 any = rest
 				_ = v35
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp36 := lang.NewCons(v34, v35)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp37 := aotExternalFn1(closed8, tmp36)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp37
 			}),
 			1,
 		)
-		tmp28.AddMethod(tmp32, tmp33)
 		tmp34 := reflect.TypeOf((*url5.URL)(nil))
 		var tmp35 lang.ArityFn
 		tmp35 = lang.NewArityFn(
@@ -1711,13 +1902,15 @@ func LoadNS() {
 				_ = v36
 				var v37 any = rest
 				_ = v37
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp38 := lang.NewCons(v36, v37)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp39 := aotExternalFn1(closed9, tmp38)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp39
 			}),
 			1,
 		)
-		tmp28.AddMethod(tmp34, tmp35)
 		tmp36 := reflect.TypeOf("")
 		var tmp37 lang.ArityFn
 		tmp37 = lang.NewArityFn(
@@ -1731,20 +1924,31 @@ func LoadNS() {
 				_ = v38
 				var v39 any = rest
 				_ = v39
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp40 := lang.NewCons(v38, v39)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp41 := aotExternalFn1(closed10, tmp40)
+//line ../../../glojure/go/io.glj:72:14
 				return tmp41
 			}),
 			1,
 		)
-		tmp28.AddMethod(tmp36, tmp37)
+		tmp28.AddMethods(
+			tmp30, tmp31,
+			tmp32, tmp33,
+			tmp34, tmp35,
+			tmp36, tmp37,
+		)
 		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_make_DASH_reader, tmp2, kw_make_DASH_writer, tmp10, kw_make_DASH_input_DASH_stream, tmp18, kw_make_DASH_output_DASH_stream, tmp28), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_make_DASH_reader, lang.NewVector(sym_x, sym_opts), "Creates an io.Reader. See also IOFactory docs."), lang.NewList(sym_make_DASH_writer, lang.NewVector(sym_x, sym_opts), "Creates an io.Reader. See also IOFactory docs."), lang.NewList(sym_make_DASH_input_DASH_stream, lang.NewVector(sym_x, sym_opts), "Creates a BufferedInputStream. See also IOFactory docs."), lang.NewList(sym_make_DASH_output_DASH_stream, lang.NewVector(sym_x, sym_opts), "Creates a BufferedOutputStream. See also IOFactory docs."))))
 		var_glojure_DOT_go_DOT_io_IOFactory = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_io_IOFactory.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/io.glj", kw_line, int(72), kw_column, int(14), kw_end_DASH_line, int(72), kw_end_DASH_column, int(38), kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:1949
 	// default-streams-impl
+
+//line ../../../glojure/go/io.glj:166:6
 	{
 		tmp0 := sym_default_DASH_streams_DASH_impl
 		var tmp1 lang.FnFunc2
@@ -1753,8 +1957,11 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../glojure/go/io.glj:167:31
 			tmp4 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_input_DASH_stream)
+//line ../../../glojure/go/io.glj:167:30
 			tmp5 := lang.Apply2(tmp4, v2, v3)
+//line ../../../glojure/go/io.glj:166:6
 			return tmp5
 		})
 		var tmp2 lang.FnFunc2
@@ -1763,10 +1970,15 @@ func LoadNS() {
 			_ = v3
 			v4 := p1
 			_ = v4
+//line ../../../glojure/go/io.glj:168:31
 			tmp5 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_writer)
+//line ../../../glojure/go/io.glj:168:44
 			tmp6 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_output_DASH_stream)
+//line ../../../glojure/go/io.glj:168:43
 			tmp7 := lang.Apply2(tmp6, v3, v4)
+//line ../../../glojure/go/io.glj:168:30
 			tmp8 := lang.Apply2(tmp5, tmp7, v4)
+//line ../../../glojure/go/io.glj:166:6
 			return tmp8
 		})
 		var tmp3 lang.FnFunc2
@@ -1775,10 +1987,15 @@ func LoadNS() {
 			_ = v4
 			v5 := p1
 			_ = v5
+//line ../../../glojure/go/io.glj:171:54
 			tmp6 := aotExternalFn4(v4)
+//line ../../../glojure/go/io.glj:171:33
 			tmp7 := aotExternalFn3("Cannot open <", tmp6, "> as an InputStream.")
+//line ../../../glojure/go/io.glj:170:32
 			tmp8 := lang.Apply1(lang.NewIllegalArgumentError, tmp7)
+//line ../../../glojure/go/io.glj:170:25
 			panic(tmp8)
+//line ../../../glojure/go/io.glj:166:6
 		})
 		var tmp4 lang.FnFunc2
 		tmp4 = lang.FnFunc2(func(p0, p1 any) any {
@@ -1786,17 +2003,25 @@ func LoadNS() {
 			_ = v5
 			v6 := p1
 			_ = v6
+//line ../../../glojure/go/io.glj:174:55
 			tmp7 := aotExternalFn4(v5)
+//line ../../../glojure/go/io.glj:174:34
 			tmp8 := aotExternalFn3("Cannot open <", tmp7, "> as an OutputStream.")
+//line ../../../glojure/go/io.glj:173:33
 			tmp9 := lang.Apply1(lang.NewIllegalArgumentError, tmp8)
+//line ../../../glojure/go/io.glj:173:26
 			panic(tmp9)
+//line ../../../glojure/go/io.glj:166:6
 		})
 		var_glojure_DOT_go_DOT_io_default_DASH_streams_DASH_impl = ns.InternWithValue(tmp0, lang.NewMap(kw_make_DASH_reader, tmp1, kw_make_DASH_writer, tmp2, kw_make_DASH_input_DASH_stream, tmp3, kw_make_DASH_output_DASH_stream, tmp4), true)
 		var_glojure_DOT_go_DOT_io_default_DASH_streams_DASH_impl.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/io.glj", kw_line, int(166), kw_column, int(6), kw_end_DASH_line, int(166), kw_end_DASH_column, int(25), kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:2022
 	// copy
+
+//line ../../../glojure/go/io.glj:386:7
 	{
 		tmp0 := sym_copy
 		var tmp1 lang.ArityFn
@@ -1813,15 +2038,22 @@ func LoadNS() {
 				_ = v3
 				var v4 any = rest
 				_ = v4
+//line ../../../glojure/go/io.glj:401:4
 				tmp5 := checkDerefVar(var_glojure_DOT_go_DOT_io_do_DASH_copy)
+//line ../../../glojure/go/io.glj:401:25
 				var tmp6 any
 				if lang.IsTruthy(v4) {
+//line ../../../glojure/go/io.glj:401:43
 					tmp7 := checkDerefVar(var_clojure_DOT_core_hash_DASH_map)
+//line ../../../glojure/go/io.glj:401:36
 					tmp8 := aotExternalFn1(tmp7, v4)
+//line ../../../glojure/go/io.glj:401:25
 					tmp6 = tmp8
 				} else {
 				}
+//line ../../../glojure/go/io.glj:401:3
 				tmp9 := lang.Apply3(tmp5, v2, v3, tmp6)
+//line ../../../glojure/go/io.glj:386:7
 				return tmp9
 			}),
 			2,
@@ -1832,7 +2064,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/io.glj", kw_line, int(386), kw_column, int(7), kw_end_DASH_line, int(386), kw_end_DASH_column, int(10), kw_arglists, lang.NewList(lang.NewVector(sym_input, sym_output, sym__AMP_, sym_opts)), kw_doc, "Copies input to output.  Returns nil or throws IOException.\n  Input may be an InputStream, Reader, File, byte[], char[], or String.\n  Output may be an OutputStream, Writer, or File.\n\n  Options are key/value pairs and may be one of\n\n    :buffer-size  buffer size to use, default is 1024.\n    :encoding     encoding to use if converting between\n                  byte and char streams.   \n\n  Does not close any streams except those it opens itself \n  (on a File).", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:2068
 	// go-try!
+
+//line ../../../glojure/go/io.glj:21:11
 	{
 		tmp0 := sym_go_DASH_try_BANG_
 		var tmp1 lang.ArityFn
@@ -1849,6 +2084,7 @@ func LoadNS() {
 				_ = v3
 				var v4 any = rest
 				_ = v4
+//line ../../../glojure/go/io.glj:23:3
 				tmp5 := checkDerefVar(var_clojure_DOT_core_list)
 				tmp6 := lang.Apply1(tmp5, sym_clojure_DOT_core_SLASH_let)
 				tmp7 := checkDerefVar(var_clojure_DOT_core_list)
@@ -1975,6 +2211,7 @@ func LoadNS() {
 				tmp128 := lang.Apply1(tmp127, sym_res__0__auto__)
 				tmp129 := aotExternalFn14(tmp6, tmp110, tmp126, tmp128)
 				tmp130 := lang.Seq(tmp129)
+//line ../../../glojure/go/io.glj:21:11
 				return tmp130
 			}),
 			2,
@@ -1984,7 +2221,10 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/io.glj", kw_line, int(21), kw_column, int(11), kw_end_DASH_line, int(21), kw_end_DASH_column, int(27), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym__AMP_, sym_call)), kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io), kw_macro, true)
 		})
 	}
+//line loader.go:2225
 	// reader
+
+//line ../../../glojure/go/io.glj:89:7
 	{
 		tmp0 := sym_reader
 		var tmp1 lang.ArityFn
@@ -1999,15 +2239,22 @@ func LoadNS() {
 				_ = v2
 				var v3 any = rest
 				_ = v3
+//line ../../../glojure/go/io.glj:104:4
 				tmp4 := checkDerefVar(var_glojure_DOT_go_DOT_io_make_DASH_reader)
+//line ../../../glojure/go/io.glj:104:18
 				var tmp5 any
 				if lang.IsTruthy(v3) {
+//line ../../../glojure/go/io.glj:104:36
 					tmp6 := checkDerefVar(var_clojure_DOT_core_hash_DASH_map)
+//line ../../../glojure/go/io.glj:104:29
 					tmp7 := aotExternalFn1(tmp6, v3)
+//line ../../../glojure/go/io.glj:104:18
 					tmp5 = tmp7
 				} else {
 				}
+//line ../../../glojure/go/io.glj:104:3
 				tmp8 := lang.Apply2(tmp4, v2, tmp5)
+//line ../../../glojure/go/io.glj:89:7
 				return tmp8
 			}),
 			1,
@@ -2019,4 +2266,5 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/io.glj", kw_line, int(89), kw_column, int(7), kw_end_DASH_line, int(89), kw_end_DASH_column, int(23), kw_tag, tmp2, kw_arglists, lang.NewList(lang.NewVector(sym_x, sym__AMP_, sym_opts)), kw_doc, "Attempts to coerce its argument into an open io.Reader.\n\n   Default implementations are provided for Reader, BufferedReader,\n   InputStream, File, URI, URL, Socket, byte slices, rune slices,\n   and string.\n\n   If argument is a string, it tries to resolve it first as a URI, then\n   as a local file name.  URIs with a 'file' protocol are converted to\n   local file names.\n\n   Should be used inside with-open to ensure the io.Reader is properly\n   closed.", kw_added, "1.2", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_io))
 		})
 	}
+//line loader.go:2270
 }
@@ -109,13 +109,13 @@ func checkDerefVar(v *lang.Var) any {
 
 func checkArity(args []any, expected int) {
 	if len(args) != expected {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
 func checkArityGTE(args []any, min int) {
 	if len(args) < min {
-		panic(lang.NewIllegalArgumentError("wrong number of arguments (" + fmt.Sprint(len(args)) + ")"))
+		panic(lang.NewArityError(len(args), ""))
 	}
 }
 
@@ -205,6 +205,7 @@ func LoadNS() {
 		ns.ReferAllSnapshot(srcNS, []string{
 			"*loaded-libs*",
 			"*loading-verbosely*",
+			"*namespace-table*",
 			"*pending-paths*",
 			"-protocols",
 			">0?",
@@ -287,9 +288,12 @@ func LoadNS() {
 		tmp0 = lang.FnFunc1(func(p0 any) any {
 			v1 := p0
 			_ = v1
+//line ../../../glojure/go/types.glj:24:5
 			var tmp2 any
 			{ // let
 				// let binding "name"
+
+//line ../../../glojure/go/types.glj:24:16
 				tmp3, ok := lang.FieldOrMethod(v1, "Name")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Name")))
@@ -301,26 +305,38 @@ func LoadNS() {
 				default:
 					tmp4 = tmp3
 				}
+//line ../../../glojure/go/types.glj:24:5
 				var v5 any = tmp4
 				_ = v5
 				// let binding "typ"
+
+//line ../../../glojure/go/types.glj:25:15
 				tmp6 := runtime.RT.Get(lang.BuiltinTypes, v5)
+//line ../../../glojure/go/types.glj:24:5
 				var v7 any = tmp6
 				_ = v7
+//line ../../../glojure/go/types.glj:26:7
 				var tmp8 any
 				if lang.IsTruthy(v7) {
 				} else {
+//line ../../../glojure/go/types.glj:26:28
 					tmp9 := lang.Apply2(fmt.Errorf, "unknown type %s", v5)
+//line ../../../glojure/go/types.glj:26:21
 					panic(tmp9)
+//line ../../../glojure/go/types.glj:26:7
 				}
+//line ../../../glojure/go/types.glj:24:5
 				_ = tmp8
 				tmp2 = v7
 			} // end let
+//line loader.go:333
 			return tmp2
 		})
 		closed0 = tmp0
 	}
 	// ast->type
+
+//line ../../../glojure/go/types.glj:4:4
 	{
 		tmp0 := sym_ast_DASH__GT_type
 		var tmp2 lang.ArityFn
@@ -355,13 +371,15 @@ func LoadNS() {
 				_ = v5
 				var v6 any = rest
 				_ = v6
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp7 := lang.NewCons(v5, v6)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp8 := aotExternalFn1(closed0, tmp7)
+//line ../../../glojure/go/types.glj:4:4
 				return tmp8
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp3, tmp4)
 		tmp5 := reflect.TypeOf((*ast4.ArrayType)(nil))
 		var tmp6 lang.ArityFn
 		tmp6 = lang.NewArityFn(
@@ -375,13 +393,15 @@ func LoadNS() {
 				_ = v7
 				var v8 any = rest
 				_ = v8
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp9 := lang.NewCons(v7, v8)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp10 := aotExternalFn1(closed1, tmp9)
+//line ../../../glojure/go/types.glj:4:4
 				return tmp10
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp5, tmp6)
 		tmp7 := reflect.TypeOf((*ast4.MapType)(nil))
 		var tmp8 lang.ArityFn
 		tmp8 = lang.NewArityFn(
@@ -395,13 +415,15 @@ func LoadNS() {
 				_ = v9
 				var v10 any = rest
 				_ = v10
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp11 := lang.NewCons(v9, v10)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp12 := aotExternalFn1(closed2, tmp11)
+//line ../../../glojure/go/types.glj:4:4
 				return tmp12
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp7, tmp8)
 		tmp9 := reflect.TypeOf((*ast4.ChanType)(nil))
 		var tmp10 lang.ArityFn
 		tmp10 = lang.NewArityFn(
@@ -415,13 +437,15 @@ func LoadNS() {
 				_ = v11
 				var v12 any = rest
 				_ = v12
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp13 := lang.NewCons(v11, v12)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp14 := aotExternalFn1(closed3, tmp13)
+//line ../../../glojure/go/types.glj:4:4
 				return tmp14
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp9, tmp10)
 		tmp11 := reflect.TypeOf((*ast4.FuncType)(nil))
 		var tmp12 lang.ArityFn
 		tmp12 = lang.NewArityFn(
@@ -435,13 +459,15 @@ func LoadNS() {
 				_ = v13
 				var v14 any = rest
 				_ = v14
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp15 := lang.NewCons(v13, v14)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp16 := aotExternalFn1(closed4, tmp15)
+//line ../../../glojure/go/types.glj:4:4
 				return tmp16
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp11, tmp12)
 		tmp13 := reflect.TypeOf((*ast4.StructType)(nil))
 		var tmp14 lang.ArityFn
 		tmp14 = lang.NewArityFn(
@@ -455,38 +481,62 @@ func LoadNS() {
 				_ = v15
 				var v16 any = rest
 				_ = v16
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp17 := lang.NewCons(v15, v16)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp18 := aotExternalFn1(closed5, tmp17)
+//line ../../../glojure/go/types.glj:4:4
 				return tmp18
 			}),
 			1,
 		)
-		tmp1.AddMethod(tmp13, tmp14)
+		tmp1.AddMethods(
+			tmp3, tmp4,
+			tmp5, tmp6,
+			tmp7, tmp8,
+			tmp9, tmp10,
+			tmp11, tmp12,
+			tmp13, tmp14,
+		)
 		var_glojure_DOT_go_DOT_types_ast_DASH__GT_type = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_types_ast_DASH__GT_type.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/types.glj", kw_line, int(4), kw_column, int(4), kw_end_DASH_line, int(4), kw_end_DASH_column, int(12), kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_types))
 		})
 	}
+//line loader.go:507
 	// from-string
+
+//line ../../../glojure/go/types.glj:92:7
 	{
 		tmp0 := sym_from_DASH_string
 		var tmp1 lang.FnFunc1
 		tmp1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../glojure/go/types.glj:95:3
 			var tmp3 any
+//line ../../../glojure/go/types.glj:95:11
 			tmp4 := aotExternalFn4(v2)
+//line ../../../glojure/go/types.glj:95:3
 			tmp5 := aotExternalFn3(tmp4)
 			if lang.IsTruthy(tmp5) {
+//line ../../../glojure/go/types.glj:95:32
 				tmp6 := lang.Apply2(fmt.Errorf, "from-string: argument must be a string, got %T", v2)
+//line ../../../glojure/go/types.glj:95:25
 				panic(tmp6)
+//line ../../../glojure/go/types.glj:95:3
 			} else {
 			}
+//line ../../../glojure/go/types.glj:92:7
 			_ = tmp3
+//line ../../../glojure/go/types.glj:96:3
 			var tmp7 any
 			{ // let
 				// let binding "vec__805"
+
+//line ../../../glojure/go/types.glj:96:19
 				tmp8 := lang.Apply1(parser5.ParseExpr, v2)
+//line ../../../glojure/go/types.glj:96:3
 				var v9 any = tmp8
 				_ = v9
 				// let binding "ast"
@@ -497,17 +547,26 @@ func LoadNS() {
 				tmp12 := runtime.RT.NthDefault(v9, lang.IntCast(int64(1)), nil)
 				var v13 any = tmp12
 				_ = v13
+//line ../../../glojure/go/types.glj:97:5
 				var tmp14 any
 				if lang.IsTruthy(v13) {
+//line ../../../glojure/go/types.glj:97:20
 					tmp15 := lang.Apply3(fmt.Errorf, "from-string: invalid type string '%s': %w", v2, v13)
+//line ../../../glojure/go/types.glj:97:13
 					panic(tmp15)
+//line ../../../glojure/go/types.glj:97:5
 				} else {
 				}
+//line ../../../glojure/go/types.glj:96:3
 				_ = tmp14
+//line ../../../glojure/go/types.glj:98:6
 				tmp16 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:98:5
 				tmp17 := lang.Apply1(tmp16, v11)
+//line ../../../glojure/go/types.glj:96:3
 				tmp7 = tmp17
 			} // end let
+//line ../../../glojure/go/types.glj:92:7
 			return tmp7
 		})
 		aotDirectFn0 = tmp1
@@ -516,14 +575,19 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/types.glj", kw_line, int(92), kw_column, int(7), kw_end_DASH_line, int(92), kw_end_DASH_column, int(17), kw_arglists, lang.NewList(lang.NewVector(sym_typ)), kw_doc, "Returns a Go type from a go type expression.", kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_types))
 		})
 	}
+//line loader.go:579
 	// struct-field
+
+//line ../../../glojure/go/types.glj:6:8
 	{
 		tmp0 := sym_struct_DASH_field
 		var tmp1 lang.ArityFn
 		aotDirectFn1Arity1 = lang.FnFunc1(func(p0 any) any {
 			v2 := p0
 			_ = v2
+//line ../../../glojure/go/types.glj:7:15
 			tmp3 := aotDirectFn1Arity2(v2, nil)
+//line ../../../glojure/go/types.glj:6:8
 			return tmp3
 		})
 		aotDirectFn1Arity2 = lang.FnFunc2(func(p0, p1 any) any {
@@ -531,27 +595,42 @@ func LoadNS() {
 			_ = v2
 			v3 := p1
 			_ = v3
+//line ../../../glojure/go/types.glj:9:4
 			var tmp4 any
 			{ // let
 				// let binding "typ"
+
+//line ../../../glojure/go/types.glj:9:15
 				tmp5 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:9:14
 				tmp6 := lang.Apply1(tmp5, v2)
+//line ../../../glojure/go/types.glj:9:4
 				var v7 any = tmp6
 				_ = v7
 				// let binding "name"
+
+//line ../../../glojure/go/types.glj:10:15
 				var tmp8 any
+//line ../../../glojure/go/types.glj:10:19
 				tmp9 := aotExternalFn6("", v3)
+//line ../../../glojure/go/types.glj:10:15
 				if lang.IsTruthy(tmp9) {
 				} else {
 					tmp8 = v3
 				}
+//line ../../../glojure/go/types.glj:9:4
 				var v10 any = tmp8
 				_ = v10
 				// let binding "anonymous"
+
+//line ../../../glojure/go/types.glj:11:20
 				tmp11 := lang.Identical(v10, nil)
+//line ../../../glojure/go/types.glj:9:4
 				var v12 any = tmp11
 				_ = v12
 				// let binding "name"
+
+//line ../../../glojure/go/types.glj:12:15
 				var tmp13 any
 				{ // let
 					// let binding "or__0__auto__"
@@ -561,6 +640,7 @@ func LoadNS() {
 					if lang.IsTruthy(v14) {
 						tmp15 = v14
 					} else {
+//line ../../../glojure/go/types.glj:12:24
 						tmp16, ok := lang.FieldOrMethod(v7, "Name")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v7, "Name")))
@@ -572,18 +652,26 @@ func LoadNS() {
 						default:
 							tmp17 = tmp16
 						}
+//line ../../../glojure/go/types.glj:12:15
 						tmp15 = tmp17
 					}
 					tmp13 = tmp15
 				} // end let
+//line ../../../glojure/go/types.glj:9:4
 				var v14 any = tmp13
 				_ = v14
 				// let binding "sf"
+
+//line ../../../glojure/go/types.glj:13:21
 				tmp15 := reflect.TypeOf((*reflect.StructField)(nil)).Elem()
+//line ../../../glojure/go/types.glj:13:13
 				tmp16 := lang.Apply1(lang.Builtins["new"], tmp15)
+//line ../../../glojure/go/types.glj:9:4
 				var v17 any = tmp16
 				_ = v17
 				// set! host field
+
+//line ../../../glojure/go/types.glj:14:6
 				var tmp18 any
 				{
 					targetV := reflect.ValueOf(v17)
@@ -610,8 +698,11 @@ func LoadNS() {
 					}
 					tmp18 = v7
 				}
+//line ../../../glojure/go/types.glj:9:4
 				_ = tmp18
 				// set! host field
+
+//line ../../../glojure/go/types.glj:15:6
 				var tmp19 any
 				{
 					targetV := reflect.ValueOf(v17)
@@ -638,10 +729,14 @@ func LoadNS() {
 					}
 					tmp19 = v14
 				}
+//line ../../../glojure/go/types.glj:9:4
 				_ = tmp19
+//line ../../../glojure/go/types.glj:16:6
 				var tmp20 any
 				if lang.IsTruthy(v12) {
 					// set! host field
+
+//line ../../../glojure/go/types.glj:17:8
 					var tmp21 any
 					{
 						targetV := reflect.ValueOf(v17)
@@ -668,13 +763,18 @@ func LoadNS() {
 						}
 						tmp21 = true
 					}
+//line ../../../glojure/go/types.glj:16:6
 					tmp20 = tmp21
 				} else {
 				}
+//line ../../../glojure/go/types.glj:9:4
 				_ = tmp20
+//line ../../../glojure/go/types.glj:18:6
 				tmp22 := lang.Apply1(lang.Builtins["deref"], v17)
+//line ../../../glojure/go/types.glj:9:4
 				tmp4 = tmp22
 			} // end let
+//line ../../../glojure/go/types.glj:6:8
 			return tmp4
 		})
 		tmp1 = lang.NewArityFn(
@@ -692,14 +792,18 @@ func LoadNS() {
 			return lang.NewMapUniqueKeys(kw_file, "glojure/go/types.glj", kw_line, int(6), kw_column, int(8), kw_end_DASH_line, int(6), kw_end_DASH_column, int(19), kw_private, true, kw_arglists, lang.NewList(lang.NewVector(sym_type_DASH_ast), lang.NewVector(sym_type_DASH_ast, sym_name)), kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_types))
 		})
 	}
+//line loader.go:796
 	{
 		var tmp0 lang.FnFunc1
 		tmp0 = lang.FnFunc1(func(p0 any) any {
 			v1 := p0
 			_ = v1
+//line ../../../glojure/go/types.glj:32:5
 			var tmp2 any
 			{ // let
 				// let binding "len"
+
+//line ../../../glojure/go/types.glj:32:15
 				tmp3, ok := lang.FieldOrMethod(v1, "Len")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Len")))
@@ -711,10 +815,14 @@ func LoadNS() {
 				default:
 					tmp4 = tmp3
 				}
+//line ../../../glojure/go/types.glj:32:5
 				var v5 any = tmp4
 				_ = v5
 				// let binding "elt"
+
+//line ../../../glojure/go/types.glj:33:16
 				tmp6 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:33:26
 				tmp7, ok := lang.FieldOrMethod(v1, "Elt")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Elt")))
@@ -726,11 +834,15 @@ func LoadNS() {
 				default:
 					tmp8 = tmp7
 				}
+//line ../../../glojure/go/types.glj:33:15
 				tmp9 := lang.Apply1(tmp6, tmp8)
+//line ../../../glojure/go/types.glj:32:5
 				var v10 any = tmp9
 				_ = v10
+//line ../../../glojure/go/types.glj:34:7
 				var tmp11 any
 				if lang.IsTruthy(v5) {
+//line ../../../glojure/go/types.glj:35:22
 					tmp12, ok := lang.FieldOrMethod(v5, "Value")
 					if !ok {
 						panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v5, "Value")))
@@ -744,14 +856,20 @@ func LoadNS() {
 					}
 					tmp14 := lang.Apply1(strconv6.Atoi, tmp13)
 					tmp15 := lang.First(tmp14)
+//line ../../../glojure/go/types.glj:35:9
 					tmp16 := lang.Apply2(lang.Builtins["array-of"], tmp15, v10)
+//line ../../../glojure/go/types.glj:34:7
 					tmp11 = tmp16
 				} else {
+//line ../../../glojure/go/types.glj:36:9
 					tmp17 := lang.Apply1(lang.Builtins["slice-of"], v10)
+//line ../../../glojure/go/types.glj:34:7
 					tmp11 = tmp17
 				}
+//line ../../../glojure/go/types.glj:32:5
 				tmp2 = tmp11
 			} // end let
+//line loader.go:873
 			return tmp2
 		})
 		closed1 = tmp0
@@ -761,7 +879,9 @@ func LoadNS() {
 		tmp0 = lang.FnFunc1(func(p0 any) any {
 			v1 := p0
 			_ = v1
+//line ../../../glojure/go/types.glj:41:17
 			tmp2 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:41:27
 			tmp3, ok := lang.FieldOrMethod(v1, "Key")
 			if !ok {
 				panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Key")))
@@ -773,8 +893,11 @@ func LoadNS() {
 			default:
 				tmp4 = tmp3
 			}
+//line ../../../glojure/go/types.glj:41:16
 			tmp5 := lang.Apply1(tmp2, tmp4)
+//line ../../../glojure/go/types.glj:41:40
 			tmp6 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:41:50
 			tmp7, ok := lang.FieldOrMethod(v1, "Value")
 			if !ok {
 				panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Value")))
@@ -786,8 +909,11 @@ func LoadNS() {
 			default:
 				tmp8 = tmp7
 			}
+//line ../../../glojure/go/types.glj:41:39
 			tmp9 := lang.Apply1(tmp6, tmp8)
+//line ../../../glojure/go/types.glj:41:5
 			tmp10 := lang.Apply2(lang.Builtins["map-of"], tmp5, tmp9)
+//line loader.go:917
 			return tmp10
 		})
 		closed2 = tmp0
@@ -797,9 +923,12 @@ func LoadNS() {
 		tmp0 = lang.FnFunc1(func(p0 any) any {
 			v1 := p0
 			_ = v1
+//line ../../../glojure/go/types.glj:46:5
 			var tmp2 any
 			{ // let
 				// let binding "dir"
+
+//line ../../../glojure/go/types.glj:46:15
 				tmp3, ok := lang.FieldOrMethod(v1, "Dir")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Dir")))
@@ -811,16 +940,23 @@ func LoadNS() {
 				default:
 					tmp4 = tmp3
 				}
+//line ../../../glojure/go/types.glj:46:5
 				var v5 any = tmp4
 				_ = v5
 				// let binding "ctor"
+
+//line ../../../glojure/go/types.glj:47:16
 				var tmp6 any
+//line ../../../glojure/go/types.glj:48:18
 				tmp7 := aotExternalFn6(v5, ast4.SEND)
+//line ../../../glojure/go/types.glj:47:16
 				if lang.IsTruthy(tmp7) {
 					tmp6 = lang.Builtins["chan<--of"]
 				} else {
 					var tmp8 any
+//line ../../../glojure/go/types.glj:49:18
 					tmp9 := aotExternalFn6(v5, ast4.RECV)
+//line ../../../glojure/go/types.glj:47:16
 					if lang.IsTruthy(tmp9) {
 						tmp8 = lang.Builtins["<-chan-of"]
 					} else {
@@ -828,9 +964,12 @@ func LoadNS() {
 					}
 					tmp6 = tmp8
 				}
+//line ../../../glojure/go/types.glj:46:5
 				var v10 any = tmp6
 				_ = v10
+//line ../../../glojure/go/types.glj:51:14
 				tmp11 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:51:24
 				tmp12, ok := lang.FieldOrMethod(v1, "Value")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Value")))
@@ -842,10 +981,14 @@ func LoadNS() {
 				default:
 					tmp13 = tmp12
 				}
+//line ../../../glojure/go/types.glj:51:13
 				tmp14 := lang.Apply1(tmp11, tmp13)
+//line ../../../glojure/go/types.glj:51:7
 				tmp15 := lang.Apply1(v10, tmp14)
+//line ../../../glojure/go/types.glj:46:5
 				tmp2 = tmp15
 			} // end let
+//line loader.go:992
 			return tmp2
 		})
 		closed3 = tmp0
@@ -855,12 +998,17 @@ func LoadNS() {
 		tmp0 = lang.FnFunc1(func(p0 any) any {
 			v1 := p0
 			_ = v1
+//line ../../../glojure/go/types.glj:56:5
 			var tmp2 any
 			{ // let
 				// let binding "pl"
+
+//line ../../../glojure/go/types.glj:56:14
 				var tmp3 any
 				{ // let
 					// let binding "or__0__auto__"
+
+//line ../../../glojure/go/types.glj:56:18
 					tmp4, ok := lang.FieldOrMethod(v1, "Params")
 					if !ok {
 						panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Params")))
@@ -872,6 +1020,7 @@ func LoadNS() {
 					default:
 						tmp5 = tmp4
 					}
+//line ../../../glojure/go/types.glj:56:14
 					var v6 any = tmp5
 					_ = v6
 					var tmp7 any
@@ -881,9 +1030,12 @@ func LoadNS() {
 					}
 					tmp3 = tmp7
 				} // end let
+//line ../../../glojure/go/types.glj:56:5
 				var v4 any = tmp3
 				_ = v4
 				// let binding "params"
+
+//line ../../../glojure/go/types.glj:57:18
 				var tmp5 any
 				{ // let
 					// let binding "and__0__auto__"
@@ -891,11 +1043,14 @@ func LoadNS() {
 					_ = v6
 					var tmp7 any
 					if lang.IsTruthy(v6) {
+//line ../../../glojure/go/types.glj:60:35
 						tmp8 := checkDerefVar(var_clojure_DOT_core_concat)
+//line ../../../glojure/go/types.glj:59:33
 						var tmp9 lang.FnFunc1
 						tmp9 = lang.FnFunc1(func(p0 any) any {
 							v10 := p0
 							_ = v10
+//line ../../../glojure/go/types.glj:59:57
 							tmp11, ok := lang.FieldOrMethod(v10, "Names")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v10, "Names")))
@@ -907,8 +1062,11 @@ func LoadNS() {
 							default:
 								tmp12 = tmp11
 							}
+//line ../../../glojure/go/types.glj:59:49
 							tmp13 := lang.Apply1(lang.Builtins["len"], tmp12)
+//line ../../../glojure/go/types.glj:59:42
 							tmp14 := lang.Numbers.Max(int64(1), tmp13)
+//line ../../../glojure/go/types.glj:59:70
 							tmp15, ok := lang.FieldOrMethod(v10, "Type")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v10, "Type")))
@@ -920,9 +1078,12 @@ func LoadNS() {
 							default:
 								tmp16 = tmp15
 							}
+//line ../../../glojure/go/types.glj:59:34
 							tmp17 := aotExternalFn9(tmp14, tmp16)
+//line ../../../glojure/go/types.glj:59:33
 							return tmp17
 						})
+//line ../../../glojure/go/types.glj:58:28
 						tmp10, ok := lang.FieldOrMethod(v4, "List")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v4, "List")))
@@ -934,36 +1095,56 @@ func LoadNS() {
 						default:
 							tmp11 = tmp10
 						}
+//line ../../../glojure/go/types.glj:59:28
 						tmp12 := aotExternalFn8(tmp9, tmp11)
+//line ../../../glojure/go/types.glj:60:28
 						tmp13 := aotExternalFn1(tmp8, tmp12)
+//line ../../../glojure/go/types.glj:57:18
 						tmp7 = tmp13
 					} else {
 						tmp7 = v6
 					}
 					tmp5 = tmp7
 				} // end let
+//line ../../../glojure/go/types.glj:56:5
 				var v6 any = tmp5
 				_ = v6
 				// let binding "last-param"
+
+//line ../../../glojure/go/types.glj:61:22
 				tmp7 := aotExternalFn10(v6)
+//line ../../../glojure/go/types.glj:56:5
 				var v8 any = tmp7
 				_ = v8
 				// let binding "variadic"
+
+//line ../../../glojure/go/types.glj:62:20
 				tmp9 := lang.IsInstance[*ast4.Ellipsis](v8)
+//line ../../../glojure/go/types.glj:56:5
 				var v10 any = tmp9
 				_ = v10
 				// let binding "params"
+
+//line ../../../glojure/go/types.glj:63:18
 				var tmp11 any
 				tmp12 := aotExternalFn3(v10)
 				if lang.IsTruthy(tmp12) {
+//line ../../../glojure/go/types.glj:64:25
 					tmp13 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:64:20
 					tmp14 := aotExternalFn8(tmp13, v6)
+//line ../../../glojure/go/types.glj:63:18
 					tmp11 = tmp14
 				} else {
+//line ../../../glojure/go/types.glj:65:33
 					tmp15 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:65:43
 					tmp16 := aotExternalFn13(v6)
+//line ../../../glojure/go/types.glj:65:28
 					tmp17 := aotExternalFn8(tmp15, tmp16)
+//line ../../../glojure/go/types.glj:66:43
 					tmp18 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:66:53
 					tmp19, ok := lang.FieldOrMethod(v8, "Elt")
 					if !ok {
 						panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v8, "Elt")))
@@ -975,18 +1156,28 @@ func LoadNS() {
 					default:
 						tmp20 = tmp19
 					}
+//line ../../../glojure/go/types.glj:66:42
 					tmp21 := lang.Apply1(tmp18, tmp20)
+//line ../../../glojure/go/types.glj:66:29
 					tmp22 := lang.Apply1(lang.Builtins["slice-of"], tmp21)
+//line ../../../glojure/go/types.glj:66:28
 					tmp23 := lang.NewVector(tmp22)
+//line ../../../glojure/go/types.glj:65:20
 					tmp24 := aotExternalFn12(tmp17, tmp23)
+//line ../../../glojure/go/types.glj:63:18
 					tmp11 = tmp24
 				}
+//line ../../../glojure/go/types.glj:56:5
 				var v25 any = tmp11
 				_ = v25
 				// let binding "rl"
+
+//line ../../../glojure/go/types.glj:67:14
 				var tmp26 any
 				{ // let
 					// let binding "or__0__auto__"
+
+//line ../../../glojure/go/types.glj:67:18
 					tmp27, ok := lang.FieldOrMethod(v1, "Results")
 					if !ok {
 						panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Results")))
@@ -998,6 +1189,7 @@ func LoadNS() {
 					default:
 						tmp28 = tmp27
 					}
+//line ../../../glojure/go/types.glj:67:14
 					var v29 any = tmp28
 					_ = v29
 					var tmp30 any
@@ -1007,9 +1199,12 @@ func LoadNS() {
 					}
 					tmp26 = tmp30
 				} // end let
+//line ../../../glojure/go/types.glj:56:5
 				var v27 any = tmp26
 				_ = v27
 				// let binding "results"
+
+//line ../../../glojure/go/types.glj:68:19
 				var tmp28 any
 				{ // let
 					// let binding "and__0__auto__"
@@ -1017,12 +1212,16 @@ func LoadNS() {
 					_ = v29
 					var tmp30 any
 					if lang.IsTruthy(v29) {
+//line ../../../glojure/go/types.glj:72:34
 						tmp31 := checkDerefVar(var_glojure_DOT_go_DOT_types_ast_DASH__GT_type)
+//line ../../../glojure/go/types.glj:71:36
 						tmp32 := checkDerefVar(var_clojure_DOT_core_concat)
+//line ../../../glojure/go/types.glj:70:34
 						var tmp33 lang.FnFunc1
 						tmp33 = lang.FnFunc1(func(p0 any) any {
 							v34 := p0
 							_ = v34
+//line ../../../glojure/go/types.glj:70:58
 							tmp35, ok := lang.FieldOrMethod(v34, "Names")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v34, "Names")))
@@ -1034,8 +1233,11 @@ func LoadNS() {
 							default:
 								tmp36 = tmp35
 							}
+//line ../../../glojure/go/types.glj:70:50
 							tmp37 := lang.Apply1(lang.Builtins["len"], tmp36)
+//line ../../../glojure/go/types.glj:70:43
 							tmp38 := lang.Numbers.Max(int64(1), tmp37)
+//line ../../../glojure/go/types.glj:70:71
 							tmp39, ok := lang.FieldOrMethod(v34, "Type")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v34, "Type")))
@@ -1047,9 +1249,12 @@ func LoadNS() {
 							default:
 								tmp40 = tmp39
 							}
+//line ../../../glojure/go/types.glj:70:35
 							tmp41 := aotExternalFn9(tmp38, tmp40)
+//line ../../../glojure/go/types.glj:70:34
 							return tmp41
 						})
+//line ../../../glojure/go/types.glj:69:29
 						tmp34, ok := lang.FieldOrMethod(v27, "List")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v27, "List")))
@@ -1061,20 +1266,28 @@ func LoadNS() {
 						default:
 							tmp35 = tmp34
 						}
+//line ../../../glojure/go/types.glj:70:29
 						tmp36 := aotExternalFn8(tmp33, tmp35)
+//line ../../../glojure/go/types.glj:71:29
 						tmp37 := aotExternalFn1(tmp32, tmp36)
+//line ../../../glojure/go/types.glj:72:29
 						tmp38 := aotExternalFn8(tmp31, tmp37)
+//line ../../../glojure/go/types.glj:68:19
 						tmp30 = tmp38
 					} else {
 						tmp30 = v29
 					}
 					tmp28 = tmp30
 				} // end let
+//line ../../../glojure/go/types.glj:56:5
 				var v29 any = tmp28
 				_ = v29
+//line ../../../glojure/go/types.glj:73:5
 				tmp30 := lang.Apply3(lang.Builtins["func-of"], v25, v29, v10)
+//line ../../../glojure/go/types.glj:56:5
 				tmp2 = tmp30
 			} // end let
+//line loader.go:1291
 			return tmp2
 		})
 		closed4 = tmp0
@@ -1084,9 +1297,12 @@ func LoadNS() {
 		tmp0 = lang.FnFunc1(func(p0 any) any {
 			v1 := p0
 			_ = v1
+//line ../../../glojure/go/types.glj:78:5
 			var tmp2 any
 			{ // let
 				// let binding "fields"
+
+//line ../../../glojure/go/types.glj:78:18
 				tmp3, ok := lang.FieldOrMethod(v1, "Fields")
 				if !ok {
 					panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v1, "Fields")))
@@ -1109,21 +1325,29 @@ func LoadNS() {
 				default:
 					tmp6 = tmp5
 				}
+//line ../../../glojure/go/types.glj:78:5
 				var v7 any = tmp6
 				_ = v7
 				// let binding "struct-fields"
+
+//line ../../../glojure/go/types.glj:88:37
 				tmp8 := checkDerefVar(var_clojure_DOT_core_concat)
+//line ../../../glojure/go/types.glj:80:35
 				var tmp9 lang.FnFunc1
 				tmp9 = lang.FnFunc1(func(p0 any) any {
 					v10 := p0
 					_ = v10
+//line ../../../glojure/go/types.glj:81:37
 					var tmp11 any
 					{ // let
 						// let binding "names"
+
+//line ../../../glojure/go/types.glj:81:54
 						var tmp12 lang.FnFunc1
 						tmp12 = lang.FnFunc1(func(p0 any) any {
 							v13 := p0
 							_ = v13
+//line ../../../glojure/go/types.glj:81:55
 							tmp14, ok := lang.FieldOrMethod(v13, "Name")
 							if !ok {
 								panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v13, "Name")))
@@ -1135,8 +1359,10 @@ func LoadNS() {
 							default:
 								tmp15 = tmp14
 							}
+//line ../../../glojure/go/types.glj:81:54
 							return tmp15
 						})
+//line ../../../glojure/go/types.glj:81:65
 						tmp13, ok := lang.FieldOrMethod(v10, "Names")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v10, "Names")))
@@ -1148,10 +1374,14 @@ func LoadNS() {
 						default:
 							tmp14 = tmp13
 						}
+//line ../../../glojure/go/types.glj:81:49
 						tmp15 := aotExternalFn8(tmp12, tmp14)
+//line ../../../glojure/go/types.glj:81:37
 						var v16 any = tmp15
 						_ = v16
 						// let binding "type"
+
+//line ../../../glojure/go/types.glj:82:48
 						tmp17, ok := lang.FieldOrMethod(v10, "Type")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v10, "Type")))
@@ -1163,9 +1393,12 @@ func LoadNS() {
 						default:
 							tmp18 = tmp17
 						}
+//line ../../../glojure/go/types.glj:81:37
 						var v19 any = tmp18
 						_ = v19
 						// let binding "tag"
+
+//line ../../../glojure/go/types.glj:83:47
 						tmp20, ok := lang.FieldOrMethod(v10, "Type")
 						if !ok {
 							panic(lang.NewIllegalArgumentError(fmt.Sprintf("no such field or method on %T: %s", v10, "Type")))
@@ -1177,52 +1410,81 @@ func LoadNS() {
 						default:
 							tmp21 = tmp20
 						}
+//line ../../../glojure/go/types.glj:81:37
 						var v22 any = tmp21
 						_ = v22
+//line ../../../glojure/go/types.glj:84:39
 						var tmp23 any
+//line ../../../glojure/go/types.glj:85:41
 						tmp24 := lang.IsEmpty(v16)
+//line ../../../glojure/go/types.glj:84:39
 						if lang.IsTruthy(tmp24) {
+//line ../../../glojure/go/types.glj:85:57
 							tmp25 := aotDirectFn1Arity1(v19)
+//line ../../../glojure/go/types.glj:85:56
 							tmp26 := lang.NewVector(tmp25)
+//line ../../../glojure/go/types.glj:84:39
 							tmp23 = tmp26
 						} else {
 							var tmp27 any
+//line ../../../glojure/go/types.glj:86:46
 							tmp28 := lang.Count(v16)
+//line ../../../glojure/go/types.glj:86:41
 							tmp29 := aotExternalFn6(int64(1), tmp28)
+//line ../../../glojure/go/types.glj:84:39
 							if lang.IsTruthy(tmp29) {
+//line ../../../glojure/go/types.glj:86:81
 								tmp30 := lang.First(v16)
+//line ../../../glojure/go/types.glj:86:62
 								tmp31 := aotDirectFn1Arity2(v19, tmp30)
+//line ../../../glojure/go/types.glj:86:61
 								tmp32 := lang.NewVector(tmp31)
+//line ../../../glojure/go/types.glj:84:39
 								tmp27 = tmp32
 							} else {
+//line ../../../glojure/go/types.glj:87:52
 								var tmp33 lang.FnFunc1
 								tmp33 = lang.FnFunc1(func(p0 any) any {
 									v34 := p0
 									_ = v34
+//line ../../../glojure/go/types.glj:87:53
 									tmp35 := aotDirectFn1Arity2(v19, v34)
+//line ../../../glojure/go/types.glj:87:52
 									return tmp35
 								})
+//line ../../../glojure/go/types.glj:87:47
 								tmp34 := aotExternalFn8(tmp33, v16)
+//line ../../../glojure/go/types.glj:84:39
 								tmp27 = tmp34
 							}
 							tmp23 = tmp27
 						}
+//line ../../../glojure/go/types.glj:81:37
 						tmp11 = tmp23
 					} // end let
+//line ../../../glojure/go/types.glj:80:35
 					return tmp11
 				})
+//line ../../../glojure/go/types.glj:80:30
 				tmp10 := aotExternalFn8(tmp9, v7)
+//line ../../../glojure/go/types.glj:88:30
 				tmp11 := aotExternalFn1(tmp8, tmp10)
+//line ../../../glojure/go/types.glj:78:5
 				var v12 any = tmp11
 				_ = v12
+//line ../../../glojure/go/types.glj:89:7
 				tmp13 := lang.Apply1(reflect.StructOf, v12)
+//line ../../../glojure/go/types.glj:78:5
 				tmp2 = tmp13
 			} // end let
+//line loader.go:1481
 			return tmp2
 		})
 		closed5 = tmp0
 	}
 	// AstType
+
+//line ../../../glojure/go/types.glj:3:14
 	{
 		tmp0 := sym_AstType
 		var tmp3 lang.ArityFn
@@ -1257,13 +1519,15 @@ func LoadNS() {
 				_ = v6
 				var v7 any = rest
 				_ = v7
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp8 := lang.NewCons(v6, v7)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp9 := aotExternalFn1(closed0, tmp8)
+//line ../../../glojure/go/types.glj:3:14
 				return tmp9
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp4, tmp5)
 		tmp6 := reflect.TypeOf((*ast4.ArrayType)(nil))
 		var tmp7 lang.ArityFn
 		tmp7 = lang.NewArityFn(
@@ -1277,13 +1541,15 @@ func LoadNS() {
 				_ = v8
 				var v9 any = rest
 				_ = v9
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp10 := lang.NewCons(v8, v9)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp11 := aotExternalFn1(closed1, tmp10)
+//line ../../../glojure/go/types.glj:3:14
 				return tmp11
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp6, tmp7)
 		tmp8 := reflect.TypeOf((*ast4.MapType)(nil))
 		var tmp9 lang.ArityFn
 		tmp9 = lang.NewArityFn(
@@ -1297,13 +1563,15 @@ func LoadNS() {
 				_ = v10
 				var v11 any = rest
 				_ = v11
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp12 := lang.NewCons(v10, v11)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp13 := aotExternalFn1(closed2, tmp12)
+//line ../../../glojure/go/types.glj:3:14
 				return tmp13
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp8, tmp9)
 		tmp10 := reflect.TypeOf((*ast4.ChanType)(nil))
 		var tmp11 lang.ArityFn
 		tmp11 = lang.NewArityFn(
@@ -1317,13 +1585,15 @@ func LoadNS() {
 				_ = v12
 				var v13 any = rest
 				_ = v13
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp14 := lang.NewCons(v12, v13)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp15 := aotExternalFn1(closed3, tmp14)
+//line ../../../glojure/go/types.glj:3:14
 				return tmp15
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp10, tmp11)
 		tmp12 := reflect.TypeOf((*ast4.FuncType)(nil))
 		var tmp13 lang.ArityFn
 		tmp13 = lang.NewArityFn(
@@ -1337,13 +1607,15 @@ func LoadNS() {
 				_ = v14
 				var v15 any = rest
 				_ = v15
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp16 := lang.NewCons(v14, v15)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp17 := aotExternalFn1(closed4, tmp16)
+//line ../../../glojure/go/types.glj:3:14
 				return tmp17
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp12, tmp13)
 		tmp14 := reflect.TypeOf((*ast4.StructType)(nil))
 		var tmp15 lang.ArityFn
 		tmp15 = lang.NewArityFn(
@@ -1357,17 +1629,28 @@ func LoadNS() {
 				_ = v16
 				var v17 any = rest
 				_ = v17
+//line ../../../clojure/core_deftype.glj:160:27
 				tmp18 := lang.NewCons(v16, v17)
+//line ../../../clojure/core_deftype.glj:160:18
 				tmp19 := aotExternalFn1(closed5, tmp18)
+//line ../../../glojure/go/types.glj:3:14
 				return tmp19
 			}),
 			1,
 		)
-		tmp2.AddMethod(tmp14, tmp15)
+		tmp2.AddMethods(
+			tmp4, tmp5,
+			tmp6, tmp7,
+			tmp8, tmp9,
+			tmp10, tmp11,
+			tmp12, tmp13,
+			tmp14, tmp15,
+		)
 		tmp1 := lang.NewAtom(lang.NewMap(kw_multis, lang.NewMap(kw_ast_DASH__GT_type, tmp2), kw_on_DASH_interface, true, kw_sigs, lang.NewList(lang.NewList(sym_ast_DASH__GT_type, lang.NewVector(sym_ast)))))
 		var_glojure_DOT_go_DOT_types_AstType = ns.InternWithValue(tmp0, tmp1, true)
 		var_glojure_DOT_go_DOT_types_AstType.SetMetaLazy(func() lang.IPersistentMap {
 			return lang.NewMap(kw_file, "glojure/go/types.glj", kw_line, int(3), kw_column, int(14), kw_end_DASH_line, int(3), kw_end_DASH_column, int(20), kw_ns, lang.FindOrCreateNamespace(sym_glojure_DOT_go_DOT_types))
 		})
 	}
+//line loader.go:1656
 }
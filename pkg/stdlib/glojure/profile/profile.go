@@ -0,0 +1,134 @@
+// Package profile takes Go pprof profiles of Glojure programs. While a
+// profile runs, evaluated functions label the goroutines calling them
+// with their var name and source location (see
+// runtime.ProfileLabelFn), so CPU profiles attribute time to Glojure
+// functions rather than only to the evaluator: go tool pprof -tagfocus
+// and -tags select and list them, and TopFns summarizes them. The
+// functions are published through pkgmap for glojure/profile.glj, which
+// wraps them.
+package profile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	goruntime "runtime"
+	"runtime/pprof"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+const pkg = "github.com/glojurelang/glojure/pkg/stdlib/glojure/profile"
+
+func init() {
+	pkgmap.Set(pkg+".WithProfile", WithProfile)
+}
+
+// Options says what a profile records.
+type Options struct {
+	// CPUFile and MemFile name the files to write the CPU and heap
+	// profiles to. The CPU profile is taken even without a file when Top
+	// is set.
+	CPUFile string
+	MemFile string
+	// Top is the number of Glojure functions with the most CPU time to
+	// write to Out when the profile stops.
+	Top int
+	Out io.Writer
+}
+
+// A Profile is a running profile.
+type Profile struct {
+	opts       Options
+	cpu        bytes.Buffer
+	cpuRunning bool
+	prevLabels bool
+}
+
+// Start starts a profile. Only one CPU profile can run at a time.
+func Start(opts Options) (*Profile, error) {
+	p := &Profile{opts: opts}
+	if opts.CPUFile != "" || opts.Top > 0 {
+		if err := pprof.StartCPUProfile(&p.cpu); err != nil {
+			return nil, err
+		}
+		p.cpuRunning = true
+	}
+	p.prevLabels = runtime.SetProfileLabels(true)
+	return p, nil
+}
+
+// Stop stops the profile, writing its files and the top functions.
+func (p *Profile) Stop() error {
+	runtime.SetProfileLabels(p.prevLabels)
+	var errs []error
+	if p.cpuRunning {
+		pprof.StopCPUProfile()
+		p.cpuRunning = false
+		if p.opts.CPUFile != "" {
+			errs = append(errs, os.WriteFile(p.opts.CPUFile, p.cpu.Bytes(), 0o644))
+		}
+		if p.opts.Top > 0 && p.opts.Out != nil {
+			fns, total, err := TopFns(p.cpu.Bytes(), p.opts.Top)
+			if err == nil {
+				err = WriteTop(p.opts.Out, fns, total)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if p.opts.MemFile != "" {
+		errs = append(errs, writeHeapProfile(p.opts.MemFile))
+	}
+	return errors.Join(errs...)
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// Collect garbage so the profile shows live objects.
+	goruntime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var (
+	kwCPU = lang.NewKeyword("cpu")
+	kwMem = lang.NewKeyword("mem")
+	kwTop = lang.NewKeyword("top")
+)
+
+// WithProfile calls fn under a profile described by opts, a map with
+// the optional keys :cpu and :mem, naming the files to write, and :top,
+// the number of functions to write to out (10 by default; 0 for none).
+// It returns what fn returns.
+func WithProfile(opts lang.IPersistentMap, out io.Writer, fn lang.IFn) (res any, err error) {
+	o := Options{Top: 10, Out: out}
+	if v := lang.Get(opts, kwCPU); v != nil {
+		o.CPUFile = fmt.Sprint(v)
+	}
+	if v := lang.Get(opts, kwMem); v != nil {
+		o.MemFile = fmt.Sprint(v)
+	}
+	if v := lang.Get(opts, kwTop); v != nil {
+		o.Top = lang.MustAsInt(v)
+	}
+	p, err := Start(o)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if stopErr := p.Stop(); err == nil {
+			err = stopErr
+		}
+	}()
+	return fn.Invoke(), nil
+}
@@ -0,0 +1,50 @@
+package profile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
+	"github.com/glojurelang/glojure/pkg/stdlib/glojure/profile"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+)
+
+func TestWithProfile(t *testing.T) {
+	dir := t.TempDir()
+	cpu, mem := filepath.Join(dir, "cpu.pprof"), filepath.Join(dir, "mem.pprof")
+	lang.PushThreadBindings(lang.NewMap(lang.VarCurrentNS, lang.VarCurrentNS.Deref()))
+	defer lang.PopThreadBindings()
+	runtime.ReadEval(`
+		(ns profile.test (:require [glojure.profile :refer [with-profile]]))
+		(defn spin [n] (loop [i 0] (if (< i n) (recur (inc i)) i)))`)
+	got := runtime.ReadEval(`
+		(with-out-str
+		  (with-profile {:cpu ` + quote(cpu) + ` :mem ` + quote(mem) + ` :top 3}
+		    (spin 3000000)))`).(string)
+	if !strings.HasPrefix(got, "Glojure fns by CPU time") || !strings.Contains(got, "profile.test/spin") {
+		t.Errorf("with-profile printed:\n%s", got)
+	}
+
+	data, err := os.ReadFile(cpu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fns, total, err := profile.TopFns(data, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fns) == 0 || fns[0].Fn != "profile.test/spin" || fns[0].CPU > total {
+		t.Errorf("TopFns = %+v, total %v", fns, total)
+	}
+	if info, err := os.Stat(mem); err != nil || info.Size() == 0 {
+		t.Errorf("heap profile not written: %v", err)
+	}
+}
+
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `\`, `\\`) + `"`
+}
@@ -0,0 +1,250 @@
+package profile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+// A FnTime is the CPU time sampled while a Glojure function was the
+// innermost one running on a goroutine, including the Go code it called.
+type FnTime struct {
+	Fn     string // qualified var name, or "fn" for anonymous functions
+	Source string // file:line, if known
+	CPU    time.Duration
+}
+
+// TopFns decodes a CPU profile written by runtime/pprof with profile
+// labels enabled and returns the n Glojure functions with the most CPU
+// time, most first, and the total CPU time sampled. n <= 0 returns them
+// all.
+func TopFns(profile []byte, n int) (fns []FnTime, total time.Duration, err error) {
+	zr, err := gzip.NewReader(bytes.NewReader(profile))
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, 0, err
+	}
+	p, err := decodeProfile(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// CPU profiles have sample count and CPU nanosecond values.
+	value := p.sampleTypes - 1
+	if value < 0 {
+		return nil, 0, errors.New("profile has no sample types")
+	}
+	byFn := map[[2]string]time.Duration{}
+	for _, s := range p.samples {
+		if value >= len(s.values) {
+			continue
+		}
+		d := time.Duration(s.values[value])
+		total += d
+		var key [2]string
+		for _, l := range s.labels {
+			switch p.str(l.key) {
+			case runtime.ProfileLabelFn:
+				key[0] = p.str(l.str)
+			case runtime.ProfileLabelSource:
+				key[1] = p.str(l.str)
+			}
+		}
+		if key[0] != "" {
+			byFn[key] += d
+		}
+	}
+	for key, d := range byFn {
+		fns = append(fns, FnTime{Fn: key[0], Source: key[1], CPU: d})
+	}
+	sort.Slice(fns, func(i, j int) bool {
+		if fns[i].CPU != fns[j].CPU {
+			return fns[i].CPU > fns[j].CPU
+		}
+		if fns[i].Fn != fns[j].Fn {
+			return fns[i].Fn < fns[j].Fn
+		}
+		return fns[i].Source < fns[j].Source
+	})
+	if n > 0 && len(fns) > n {
+		fns = fns[:n]
+	}
+	return fns, total, nil
+}
+
+// WriteTop writes fns as a table of their CPU time and its share of
+// total.
+func WriteTop(w io.Writer, fns []FnTime, total time.Duration) error {
+	if _, err := fmt.Fprintf(w, "Glojure fns by CPU time, of %v sampled:\n%10s %7s  %s\n", total, "flat", "flat%", "fn"); err != nil {
+		return err
+	}
+	for _, fn := range fns {
+		pct := 0.0
+		if total > 0 {
+			pct = 100 * float64(fn.CPU) / float64(total)
+		}
+		name := fn.Fn
+		if fn.Source != "" {
+			name += " (" + fn.Source + ")"
+		}
+		if _, err := fmt.Fprintf(w, "%10v %6.2f%%  %s\n", fn.CPU, pct, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The subset of profile.proto that TopFns reads.
+type (
+	pprofProfile struct {
+		sampleTypes int
+		samples     []pprofSample
+		strings     []string
+	}
+	pprofSample struct {
+		values []int64
+		labels []pprofLabel
+	}
+	pprofLabel struct {
+		key, str int64
+	}
+)
+
+func (p *pprofProfile) str(i int64) string {
+	if i < 0 || i >= int64(len(p.strings)) {
+		return ""
+	}
+	return p.strings[i]
+}
+
+// Field numbers of profile.proto.
+const (
+	fieldProfileSampleType  = 1
+	fieldProfileSample      = 2
+	fieldProfileStringTable = 6
+	fieldSampleValue        = 2
+	fieldSampleLabel        = 3
+	fieldLabelKey           = 1
+	fieldLabelStr           = 2
+)
+
+func decodeProfile(data []byte) (*pprofProfile, error) {
+	var p pprofProfile
+	err := decodeFields(data, func(field int, wire int, v uint64, b []byte) error {
+		switch field {
+		case fieldProfileSampleType:
+			p.sampleTypes++
+		case fieldProfileSample:
+			s, err := decodeSample(b)
+			if err != nil {
+				return err
+			}
+			p.samples = append(p.samples, s)
+		case fieldProfileStringTable:
+			p.strings = append(p.strings, string(b))
+		}
+		return nil
+	})
+	return &p, err
+}
+
+func decodeSample(data []byte) (pprofSample, error) {
+	var s pprofSample
+	err := decodeFields(data, func(field int, wire int, v uint64, b []byte) error {
+		switch field {
+		case fieldSampleValue:
+			if wire == wireVarint {
+				s.values = append(s.values, int64(v))
+				return nil
+			}
+			// Packed.
+			for len(b) > 0 {
+				x, n := binary.Uvarint(b)
+				if n <= 0 {
+					return errBadProfile
+				}
+				s.values = append(s.values, int64(x))
+				b = b[n:]
+			}
+		case fieldSampleLabel:
+			var l pprofLabel
+			err := decodeFields(b, func(field int, wire int, v uint64, _ []byte) error {
+				switch field {
+				case fieldLabelKey:
+					l.key = int64(v)
+				case fieldLabelStr:
+					l.str = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			s.labels = append(s.labels, l)
+		}
+		return nil
+	})
+	return s, err
+}
+
+// Protocol buffer wire types.
+const (
+	wireVarint = 0
+	wire64     = 1
+	wireBytes  = 2
+	wire32     = 5
+)
+
+var errBadProfile = errors.New("malformed profile")
+
+// decodeFields calls fn with each field of a protocol buffer message:
+// the value of varints, or the contents of length-delimited fields.
+func decodeFields(data []byte, fn func(field, wire int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errBadProfile
+		}
+		data = data[n:]
+		field, wire := int(tag>>3), int(tag&7)
+		var v uint64
+		var b []byte
+		switch wire {
+		case wireVarint:
+			if v, n = binary.Uvarint(data); n <= 0 {
+				return errBadProfile
+			}
+		case wire64:
+			n = 8
+		case wire32:
+			n = 4
+		case wireBytes:
+			length, m := binary.Uvarint(data)
+			if m <= 0 || uint64(len(data)-m) < length {
+				return errBadProfile
+			}
+			b = data[m : m+int(length)]
+			n = m + int(length)
+		default:
+			return errBadProfile
+		}
+		if n > len(data) {
+			return errBadProfile
+		}
+		data = data[n:]
+		if err := fn(field, wire, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
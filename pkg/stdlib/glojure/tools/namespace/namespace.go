@@ -0,0 +1,231 @@
+// Package namespace reloads namespaces whose source files have changed,
+// in the manner of Clojure's tools.namespace. The runtime records the
+// files each namespace was loaded from and the libs they require;
+// Refresh unloads the namespaces with changed files and every namespace
+// that depends on them, then loads them again in dependency order. The
+// functions are published through pkgmap for glojure/tools/namespace.glj,
+// which wraps them.
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/pkgmap"
+	"github.com/glojurelang/glojure/pkg/runtime"
+)
+
+const pkg = "github.com/glojurelang/glojure/pkg/stdlib/glojure/tools/namespace"
+
+func init() {
+	for name, fn := range map[string]any{
+		"Refresh":         Refresh,
+		"RefreshAll":      RefreshAll,
+		"Changed":         Changed,
+		"DependencyGraph": DependencyGraph,
+	} {
+		pkgmap.Set(pkg+"."+name, fn)
+	}
+}
+
+var (
+	// mu serializes refreshes.
+	mu sync.Mutex
+	// pending holds the namespaces a failed refresh left unloaded, which
+	// the next refresh loads again, with their resources.
+	pending = map[string][]string{}
+)
+
+// Refresh unloads and reloads the namespaces whose files changed since
+// they were loaded, and the namespaces that depend on them, returning the
+// names of the namespaces it reloaded. Namespaces whose files were
+// deleted are unloaded and not reloaded. If a namespace fails to load,
+// Refresh stops, leaving it and the namespaces after it unloaded, and the
+// next refresh tries them again.
+func Refresh() ([]string, error) {
+	changed, removed := runtime.ChangedNamespaces()
+	return refresh(changed, removed)
+}
+
+// RefreshAll is Refresh for every namespace loaded from a source file
+// that can change, whether or not it has.
+func RefreshAll() ([]string, error) {
+	var all []string
+	for ns := range runtime.NamespaceDependencies() {
+		all = append(all, ns)
+	}
+	_, removed := runtime.ChangedNamespaces()
+	return refresh(all, removed)
+}
+
+// Changed returns the names of the namespaces the next Refresh would
+// reload, in the order it would reload them.
+func Changed() ([]string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	changed, removed := runtime.ChangedNamespaces()
+	order, err := reloadOrder(changed, removed)
+	if err != nil {
+		return nil, err
+	}
+	return without(order, removed), nil
+}
+
+// DependencyGraph returns a map from the symbol of each namespace loaded
+// from source to the set of the symbols of the libs it requires.
+func DependencyGraph() lang.IPersistentMap {
+	var kvs []any
+	for ns, deps := range runtime.NamespaceDependencies() {
+		set := make([]any, len(deps))
+		for i, dep := range deps {
+			set[i] = lang.NewSymbol(dep)
+		}
+		kvs = append(kvs, lang.NewSymbol(ns), lang.NewSet(set...))
+	}
+	return lang.NewMap(kvs...)
+}
+
+func refresh(changed, removed []string) (reloaded []string, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	order, err := reloadOrder(changed, removed)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range order {
+		if _, ok := pending[ns]; !ok {
+			pending[ns] = runtime.NamespaceResources(ns)
+		}
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		unload(order[i])
+	}
+	for _, ns := range removed {
+		delete(pending, ns)
+	}
+
+	for _, ns := range without(order, removed) {
+		if err := reload(ns, pending[ns]); err != nil {
+			return reloaded, fmt.Errorf("reloading %s: %w", ns, err)
+		}
+		delete(pending, ns)
+		reloaded = append(reloaded, ns)
+	}
+	return reloaded, nil
+}
+
+// reloadOrder returns the namespaces to reload for the changed and
+// removed namespaces, with those pending from a failed refresh, in
+// dependency order.
+func reloadOrder(changed, removed []string) ([]string, error) {
+	names := append(append([]string(nil), changed...), removed...)
+	for ns := range pending {
+		names = append(names, ns)
+	}
+	return runtime.ReloadOrder(names)
+}
+
+// unload removes the named namespace and its record as a loaded lib.
+func unload(name string) {
+	sym := lang.NewSymbol(name)
+	runtime.ForgetNamespace(name)
+	if lang.FindNamespace(sym) != nil {
+		lang.RemoveNamespace(sym)
+	}
+	libs := coreVar("*loaded-libs*").Deref().(*lang.Ref)
+	lang.LockingTransaction.RunInTransaction(lang.FnFunc(func(...any) any {
+		return libs.Commute(coreVar("disj"), lang.NewCons(sym, nil))
+	}))
+}
+
+// reload loads the resources of the named namespace and records it as a
+// loaded lib.
+func reload(name string, resources []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = asError(r)
+			// Leave no partly loaded namespace behind.
+			runtime.ForgetNamespace(name)
+			if sym := lang.NewSymbol(name); lang.FindNamespace(sym) != nil {
+				lang.RemoveNamespace(sym)
+			}
+		}
+	}()
+	if len(resources) == 0 {
+		resources = []string{rootResource(name)}
+	}
+	for _, resource := range resources {
+		coreVar("load").Invoke("/" + resource)
+	}
+	libs := coreVar("*loaded-libs*").Deref().(*lang.Ref)
+	lang.LockingTransaction.RunInTransaction(lang.FnFunc(func(...any) any {
+		return libs.Commute(coreVar("conj"), lang.NewCons(lang.NewSymbol(name), nil))
+	}))
+	return nil
+}
+
+// Watch polls for changed files every interval until ctx is done,
+// refreshing when any change. It reports what it reloads, and refresh
+// errors, to w.
+func Watch(ctx context.Context, interval time.Duration, w io.Writer) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changed, removed := runtime.ChangedNamespaces()
+		if len(changed) == 0 && len(removed) == 0 {
+			continue
+		}
+		reloaded, err := refresh(changed, removed)
+		if len(reloaded) > 0 {
+			fmt.Fprintf(w, ":reloading (%s)\n", strings.Join(reloaded, " "))
+		}
+		if err != nil {
+			fmt.Fprintf(w, ":error-while-loading %v\n", err)
+		}
+	}
+}
+
+// rootResource is clojure.core/root-resource without the leading slash.
+func rootResource(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "-", "_"), ".", "/")
+}
+
+func without(names, drop []string) []string {
+	if len(drop) == 0 {
+		return names
+	}
+	skip := map[string]bool{}
+	for _, name := range drop {
+		skip[name] = true
+	}
+	var kept []string
+	for _, name := range names {
+		if !skip[name] {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+func coreVar(name string) *lang.Var {
+	return lang.NSCore.FindInternedVar(lang.NewSymbol(name))
+}
+
+// asError turns a recovered panic into an error.
+func asError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
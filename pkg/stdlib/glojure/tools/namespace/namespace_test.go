@@ -0,0 +1,96 @@
+package namespace_test
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/glojurelang/glojure/pkg/lang"
+	"github.com/glojurelang/glojure/pkg/runtime"
+	"github.com/glojurelang/glojure/pkg/stdlib/glojure/tools/namespace"
+
+	_ "github.com/glojurelang/glojure/pkg/glj"
+)
+
+func TestRefresh(t *testing.T) {
+	modTime := time.Now()
+	files := fstest.MapFS{}
+	write := func(name, src string) {
+		modTime = modTime.Add(time.Second)
+		files[name] = &fstest.MapFile{Data: []byte(src), ModTime: modTime}
+	}
+	write("refresh/base.glj", `(ns refresh.base) (def value 1)`)
+	write("refresh/mid.glj", `(ns refresh.mid (:require [refresh.base :as b])) (defn value [] (* 10 b/value))`)
+	write("refresh/top.glj", `(ns refresh.top (:require refresh.mid)) (def value (refresh.mid/value))`)
+	write("refresh/other.glj", `(ns refresh.other)`)
+	runtime.AddLoadPath(files)
+	runtime.ReadEval(`(require 'refresh.top 'refresh.other)`)
+
+	graph := namespace.DependencyGraph()
+	if got := lang.PrintString(lang.Get(graph, lang.NewSymbol("refresh.top"))); got != "#{refresh.mid}" {
+		t.Errorf("dependencies of refresh.top = %s", got)
+	}
+	value := func() any { return runtime.ReadEval(`refresh.top/value`) }
+	other := lang.FindNamespace(lang.NewSymbol("refresh.other"))
+
+	refresh := func(want ...string) {
+		t.Helper()
+		reloaded, err := namespace.Refresh()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(reloaded, want) {
+			t.Errorf("Refresh reloaded %q, want %q", reloaded, want)
+		}
+	}
+
+	// Touching a file without changing it reloads nothing.
+	write("refresh/mid.glj", string(files["refresh/mid.glj"].Data))
+	refresh()
+
+	write("refresh/base.glj", `(ns refresh.base) (def value 2)`)
+	if changed, err := namespace.Changed(); err != nil || !slices.Equal(changed, []string{"refresh.base", "refresh.mid", "refresh.top"}) {
+		t.Errorf("Changed() = %q, %v", changed, err)
+	}
+	refresh("refresh.base", "refresh.mid", "refresh.top")
+	if got := value(); !lang.Equals(got, 20) {
+		t.Errorf("refresh.top/value = %v after refresh, want 20", got)
+	}
+	if lang.FindNamespace(lang.NewSymbol("refresh.other")) != other {
+		t.Error("refresh.other was reloaded")
+	}
+
+	// A namespace that fails to load is left unloaded, with its
+	// dependents, until a later refresh loads it.
+	write("refresh/mid.glj", `(ns refresh.mid (:require [refresh.base :as b])) (throw (ex-info "broken" {}))`)
+	reloaded, err := namespace.Refresh()
+	if err == nil || len(reloaded) != 0 {
+		t.Fatalf("Refresh() = %q, %v; want an error", reloaded, err)
+	}
+	for _, name := range []string{"refresh.mid", "refresh.top"} {
+		if lang.FindNamespace(lang.NewSymbol(name)) != nil {
+			t.Errorf("%s is loaded after failing to reload", name)
+		}
+	}
+	write("refresh/mid.glj", `(ns refresh.mid (:require [refresh.base :as b])) (defn value [] (* 100 b/value))`)
+	refresh("refresh.mid", "refresh.top")
+	if got := value(); !lang.Equals(got, 200) {
+		t.Errorf("refresh.top/value = %v after refresh, want 200", got)
+	}
+
+	// Deleting a file unloads its namespace.
+	delete(files, "refresh/other.glj")
+	refresh()
+	if lang.FindNamespace(lang.NewSymbol("refresh.other")) != nil {
+		t.Error("refresh.other is loaded after its file was deleted")
+	}
+
+	write("refresh/base.glj", `(ns refresh.base) (def value 3)`)
+	got := runtime.ReadEval(`
+		(require 'glojure.tools.namespace)
+		(with-out-str (glojure.tools.namespace/refresh))`)
+	if want := ":reloading (refresh.base refresh.mid refresh.top)\n"; got != want {
+		t.Errorf("refresh printed %q, want %q", got, want)
+	}
+}